@@ -8,9 +8,10 @@ import (
 var AdminCmd = &cobra.Command{
 	Use:   "admin",
 	Short: "Admin commands",
-	Long:  `Admin-only commands for managing Lissto resources and API keys.`,
+	Long:  `Admin-only commands for managing Lissto resources, users and API keys.`,
 }
 
 func init() {
 	AdminCmd.AddCommand(apikeyCmd)
+	AdminCmd.AddCommand(userCmd)
 }
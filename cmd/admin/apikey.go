@@ -13,22 +13,37 @@ var (
 	apikeyRole string
 )
 
-// apikeyCmd represents the apikey command
+// apikeyCmd groups the API key commands
 var apikeyCmd = &cobra.Command{
-	Use:   "apikey create",
+	Use:   "apikey",
+	Short: "Manage API keys (admin only)",
+	Long:  `Create, list, revoke and rotate API keys. Requires admin privileges.`,
+}
+
+// apikeyCreateCmd represents the apikey create command
+var apikeyCreateCmd = &cobra.Command{
+	Use:   "create",
 	Short: "Create a new API key (admin only)",
 	Long:  `Create a new API key for a user. Requires admin privileges.`,
+	Args:  cobra.NoArgs,
 	RunE:  runCreateAPIKey,
 }
 
 func init() {
-	apikeyCmd.Flags().StringVar(&apikeyName, "name", "", "User name for the API key (required)")
-	apikeyCmd.Flags().StringVar(&apikeyRole, "role", "user", "Role for the API key (user, deploy)")
-	_ = apikeyCmd.MarkFlagRequired("name")
+	apikeyCmd.AddCommand(apikeyCreateCmd)
+	apikeyCmd.AddCommand(apikeyListCmd)
+	apikeyCmd.AddCommand(apikeyRevokeCmd)
+	apikeyCmd.AddCommand(apikeyRotateCmd)
+
+	apikeyCreateCmd.Flags().StringVar(&apikeyName, "name", "", "User name for the API key (required)")
+	apikeyCreateCmd.Flags().StringVar(&apikeyRole, "role", "user", "Role for the API key (user, deploy)")
+	_ = apikeyCreateCmd.MarkFlagRequired("name")
 }
 
 func runCreateAPIKey(cmd *cobra.Command, args []string) error {
-	apiClient, err := cmdutil.GetAPIClient()
+	ctx := cmd.Context()
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -38,16 +53,21 @@ func runCreateAPIKey(cmd *cobra.Command, args []string) error {
 		Role: apikeyRole,
 	}
 
-	result, err := apiClient.CreateAPIKey(req)
+	result, err := apiClient.CreateAPIKey(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to create API key: %w", err)
 	}
 
 	fmt.Printf("API key created successfully\n")
+	printAPIKeySecret(result)
+
+	return nil
+}
+
+// printAPIKeySecret prints a new API key, which is only shown once
+func printAPIKeySecret(result *client.CreateAPIKeyResponse) {
 	fmt.Printf("Name: %s\n", result.Name)
 	fmt.Printf("Role: %s\n", result.Role)
 	fmt.Printf("API Key: %s\n", result.APIKey)
 	fmt.Println("\nIMPORTANT: Save this API key securely. It cannot be retrieved later.")
-
-	return nil
 }
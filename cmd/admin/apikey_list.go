@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var apikeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys (admin only)",
+	Long: `List API keys with their role, who created them and when they were last
+used. Secrets are never shown.
+
+Examples:
+  # Find keys nobody used in a while
+  lissto admin apikey list
+
+  # Export for an audit
+  lissto admin apikey list -o json`,
+	Args: cobra.NoArgs,
+	RunE: runListAPIKeys,
+}
+
+func runListAPIKeys(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := apiClient.ListAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+
+	if len(keys) == 0 {
+		fmt.Println("No API keys found")
+		return nil
+	}
+
+	return cmdutil.PrintOutput(cmd, keys, func() {
+		headers := []string{"NAME", "ROLE", "CREATED BY", "AGE", "LAST USED"}
+		rows := make([][]string, 0, len(keys))
+		for _, key := range keys {
+			rows = append(rows, []string{key.Name, key.Role, key.CreatedBy, formatAgo(key.CreatedAt, "-"), formatAgo(key.LastUsedAt, "never")})
+		}
+		output.PrintTable(os.Stdout, headers, rows)
+	})
+}
+
+// formatAgo formats a time as an age, or returns unset for nil
+func formatAgo(t *time.Time, unset string) string {
+	if t == nil || t.IsZero() {
+		return unset
+	}
+	return k8s.FormatAge(time.Since(*t))
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/spf13/cobra"
+)
+
+var apikeyRevokeYes bool
+
+var apikeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <name>",
+	Short: "Revoke an API key (admin only)",
+	Long: `Revoke an API key. Every request made with it is rejected from then on,
+including those of contexts logged in with it.
+
+Examples:
+  # Revoke a departed user's key
+  lissto admin apikey revoke alice
+
+  # Without prompting
+  lissto admin apikey revoke ci-legacy --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRevokeAPIKey,
+}
+
+func init() {
+	apikeyRevokeCmd.Flags().BoolVarP(&apikeyRevokeYes, "yes", "y", false, "Skip confirmation prompt")
+}
+
+func runRevokeAPIKey(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	name := args[0]
+
+	if !apikeyRevokeYes {
+		confirmed, err := interactive.ConfirmAction(fmt.Sprintf("Revoke API key '%s'? Requests using it will be rejected.", name), false)
+		if err != nil || !confirmed {
+			return fmt.Errorf("revoke cancelled")
+		}
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := apiClient.RevokeAPIKey(ctx, name); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ API key '%s' revoked\n", name)
+	return nil
+}
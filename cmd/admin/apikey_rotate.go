@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/spf13/cobra"
+)
+
+var apikeyRotateYes bool
+
+var apikeyRotateCmd = &cobra.Command{
+	Use:   "rotate <name>",
+	Short: "Replace an API key's secret (admin only)",
+	Long: `Generate a new secret for an API key, keeping its name and role. The
+previous secret stops working immediately; hand the new one to the key's
+owner, who logs in again with it.
+
+Examples:
+  # Rotate a leaked CI key
+  lissto admin apikey rotate ci --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRotateAPIKey,
+}
+
+func init() {
+	apikeyRotateCmd.Flags().BoolVarP(&apikeyRotateYes, "yes", "y", false, "Skip confirmation prompt")
+}
+
+func runRotateAPIKey(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	name := args[0]
+
+	if !apikeyRotateYes {
+		confirmed, err := interactive.ConfirmAction(fmt.Sprintf("Rotate API key '%s'? The current secret stops working.", name), false)
+		if err != nil || !confirmed {
+			return fmt.Errorf("rotate cancelled")
+		}
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := apiClient.RotateAPIKey(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("API key rotated successfully\n")
+	printAPIKeySecret(result)
+	return nil
+}
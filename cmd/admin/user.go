@@ -0,0 +1,207 @@
+package admin
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// userRoles are the roles a user can have
+var userRoles = []string{"admin", "user", "deploy"}
+
+var userDeactivateYes bool
+
+// userCmd groups the user management commands
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage users (admin only)",
+	Long: `List users and manage their access: change their role or deactivate
+them. A user's role applies to all of their API keys. Requires admin
+privileges.`,
+}
+
+var userListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List users (admin only)",
+	Long: `List users with their role, status, API keys and last activity.
+
+Examples:
+  lissto admin user list
+
+  # Who has admin access? (for an audit)
+  lissto admin user list -o json`,
+	Args: cobra.NoArgs,
+	RunE: runListUsers,
+}
+
+var userGetCmd = &cobra.Command{
+	Use:   "get <name>",
+	Short: "Show a user (admin only)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runGetUser,
+}
+
+var userSetRoleCmd = &cobra.Command{
+	Use:   "set-role <name> <role>",
+	Short: "Change a user's role (admin only)",
+	Long: `Change a user's role: admin, user or deploy. The new role applies to
+all of the user's API keys immediately.
+
+Examples:
+  # Let a CI user deploy but not manage resources
+  lissto admin user set-role ci deploy`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSetUserRole,
+}
+
+var userDeactivateCmd = &cobra.Command{
+	Use:   "deactivate <name>",
+	Short: "Block a user's access (admin only)",
+	Long: `Deactivate a user: requests made with any of their API keys are rejected
+from then on. Their stacks and blueprints are kept.
+
+Examples:
+  lissto admin user deactivate alice --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeactivateUser,
+}
+
+func init() {
+	userCmd.AddCommand(userListCmd)
+	userCmd.AddCommand(userGetCmd)
+	userCmd.AddCommand(userSetRoleCmd)
+	userCmd.AddCommand(userDeactivateCmd)
+
+	userDeactivateCmd.Flags().BoolVarP(&userDeactivateYes, "yes", "y", false, "Skip confirmation prompt")
+}
+
+func runListUsers(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	users, err := apiClient.ListUsers(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+
+	if len(users) == 0 {
+		fmt.Println("No users found")
+		return nil
+	}
+
+	return cmdutil.PrintOutput(cmd, users, func() {
+		headers := []string{"NAME", "ROLE", "STATUS", "API KEYS", "LAST SEEN"}
+		rows := make([][]string, 0, len(users))
+		for _, user := range users {
+			rows = append(rows, []string{user.Name, user.Role, formatUserStatus(user.Active), fmt.Sprintf("%d", len(user.APIKeys)), formatAgo(user.LastSeenAt, "never")})
+		}
+		output.PrintTable(os.Stdout, headers, rows)
+	})
+}
+
+func runGetUser(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := apiClient.GetUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return cmdutil.PrintOutput(cmd, user, func() {
+		printUser(user)
+	})
+}
+
+func runSetUserRole(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	name, role := args[0], args[1]
+
+	valid := false
+	for _, r := range userRoles {
+		if role == r {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid role '%s': use one of %s", role, strings.Join(userRoles, ", "))
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := apiClient.SetUserRole(ctx, name, role)
+	if err != nil {
+		return err
+	}
+
+	return cmdutil.PrintOutput(cmd, user, func() {
+		fmt.Printf("✅ User '%s' now has role '%s'\n", user.Name, user.Role)
+	})
+}
+
+func runDeactivateUser(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	name := args[0]
+
+	if !userDeactivateYes {
+		confirmed, err := interactive.ConfirmAction(fmt.Sprintf("Deactivate user '%s'? Requests with any of their API keys will be rejected.", name), false)
+		if err != nil || !confirmed {
+			return fmt.Errorf("deactivate cancelled")
+		}
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := apiClient.DeactivateUser(ctx, name); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ User '%s' deactivated\n", name)
+	return nil
+}
+
+func formatUserStatus(active bool) string {
+	if active {
+		return output.Green("active")
+	}
+	return output.Gray("deactivated")
+}
+
+// printUser prints the details of a user
+func printUser(user *client.UserDetails) {
+	fmt.Printf("Name:      %s\n", user.Name)
+	fmt.Printf("Role:      %s\n", user.Role)
+	fmt.Printf("Status:    %s\n", formatUserStatus(user.Active))
+	fmt.Printf("Created:   %s\n", formatAgo(user.CreatedAt, "-"))
+	fmt.Printf("Last seen: %s\n", formatAgo(user.LastSeenAt, "never"))
+	if len(user.APIKeys) == 0 {
+		fmt.Println("API keys:  none")
+		return
+	}
+	fmt.Println("API keys:")
+	for _, key := range user.APIKeys {
+		fmt.Printf("  - %s\n", key)
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	attachStack   string
+	attachService string
+	attachTail    int64
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach [[local:]remote]",
+	Short: "Port-forward to a stack service and follow its logs",
+	Long: `Forward a local port to a pod of a stack service and follow that pod's
+logs in the same terminal, until Ctrl+C stops both.
+
+Ports work as in 'lissto port-forward': the remote port defaults to the
+service's first port and the local port to the remote one, or the next free
+port. Container restarts are shown in the logs and followed.
+
+Examples:
+  # Select stack and service interactively
+  lissto attach
+
+  # Debug the api locally on its default port
+  lissto attach --stack my-stack --service api
+
+  # Forward local 8080 to remote 3000, starting with the last 100 lines
+  lissto attach --stack my-stack --service api --tail 100 8080:3000`,
+	Args:          cobra.MaximumNArgs(1),
+	RunE:          runAttach,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(attachCmd)
+	attachCmd.Flags().StringVar(&attachStack, "stack", "", "Stack name")
+	attachCmd.Flags().StringVar(&attachService, "service", "", "Service name")
+	attachCmd.Flags().Int64Var(&attachTail, "tail", 10, "Number of past log lines to show (use -1 for all)")
+}
+
+func runAttach(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	var localPort, remotePort int
+	if len(args) == 1 {
+		var err error
+		localPort, remotePort, err = parsePortMapping(args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	stack, err := resolveStack(ctx, apiClient, attachStack)
+	if err != nil {
+		return err
+	}
+
+	service, err := resolveService(stack, attachService)
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+
+	pod, err := resolveServicePod(ctx, k8sClient, stack, service)
+	if err != nil {
+		return err
+	}
+
+	attachCtx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if remotePort == 0 {
+		remotePort = defaultServicePort(attachCtx, k8sClient, stack.Namespace, service, pod)
+		if remotePort == 0 {
+			return fmt.Errorf("could not determine a port for service '%s'; specify one as [local:]remote", service)
+		}
+	}
+
+	requestedLocal := localPort
+	localPort, stop, err := k8sClient.PortForwardPod(attachCtx, stack.Namespace, pod.Name, localPort, remotePort)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	if requestedLocal != 0 && requestedLocal != localPort {
+		fmt.Fprintf(os.Stderr, "⚠️  Port %d is in use, using %d instead\n", requestedLocal, localPort)
+	}
+	fmt.Printf("🔗 Forwarding http://localhost:%d -> %s/%s:%d\n", localPort, service, pod.Name, remotePort)
+	fmt.Println("📜 Following logs. Press Ctrl+C to stop.")
+
+	opts := k8s.LogOptions{Follow: true}
+	if attachTail >= 0 {
+		opts.TailLines = &attachTail
+	}
+	lines := make(chan k8s.LogLine, 100)
+	done := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		done <- k8sClient.StreamLogsMulti(attachCtx, stack.Namespace, []corev1.Pod{*pod}, opts, nil, lines)
+	}()
+
+	multiContainer := len(pod.Spec.Containers) > 1
+	for line := range lines {
+		switch {
+		case line.Marker:
+			fmt.Println(output.Gray(line.Message))
+		case multiContainer:
+			fmt.Printf("%s %s\n", output.Gray("["+line.Container+"]"), line.Message)
+		default:
+			fmt.Println(line.Message)
+		}
+	}
+
+	if err := <-done; err != nil && attachCtx.Err() == nil {
+		return fmt.Errorf("log stream failed: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "\nStopping port-forward...")
+	return nil
+}
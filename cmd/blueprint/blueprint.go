@@ -16,4 +16,9 @@ func init() {
 	BlueprintCmd.AddCommand(getCmd)
 	BlueprintCmd.AddCommand(createCmd)
 	BlueprintCmd.AddCommand(deleteCmd)
+	BlueprintCmd.AddCommand(diffCmd)
+	BlueprintCmd.AddCommand(exportCmd)
+	BlueprintCmd.AddCommand(importCmd)
+	BlueprintCmd.AddCommand(usageCmd)
+	BlueprintCmd.AddCommand(pruneCmd)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/compose"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +17,7 @@ var (
 	createBranch     string
 	createAuthor     string
 	createRepository string
+	createProfiles   []string
 )
 
 var createCmd = &cobra.Command{
@@ -27,6 +29,8 @@ Optional flags:
   --branch          Branch name (for CI/CD workflows)
   --author          Author name (for CI/CD workflows)
   --repository      Repository name/URL (overrides auto-detection)
+  --profile         Compose profile to include (repeatable); services in
+                    other profiles are left out of the blueprint
 
 Environment variables:
   LISSTO_REPOSITORY    Override repository auto-detection
@@ -41,6 +45,7 @@ func init() {
 	createCmd.Flags().StringVar(&createBranch, "branch", "", "Branch name (for CI/CD workflows)")
 	createCmd.Flags().StringVar(&createAuthor, "author", "", "Author name (for CI/CD workflows)")
 	createCmd.Flags().StringVar(&createRepository, "repository", "", "Repository name/URL (used for blueprint title)")
+	createCmd.Flags().StringSliceVar(&createProfiles, "profile", nil, "Compose profile to include (repeatable)")
 }
 
 // findGitRepo searches upward from the given directory to find a .git directory
@@ -103,7 +108,9 @@ func inferRepositoryFromFile(composeFile string) (string, error) {
 	return remote, nil
 }
 
-func runCreate(_ *cobra.Command, args []string) error {
+func runCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Load environment variable overrides
 	overrides := cmdutil.LoadOverrides()
 
@@ -118,17 +125,23 @@ func runCreate(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("compose file required: provide as argument or set %s", cmdutil.EnvOverrideComposeFile)
 	}
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
 
 	// Read docker-compose file
-	composeContent, err := os.ReadFile(composeFile)
+	rawContent, err := os.ReadFile(composeFile)
 	if err != nil {
 		return fmt.Errorf("failed to read docker-compose file: %w", err)
 	}
 
+	composeContent, profiles, err := compose.ApplyProfiles(string(rawContent), createProfiles)
+	if err != nil {
+		return err
+	}
+	profiles.Print(os.Stdout)
+
 	// Determine repository: flag > env var > auto-detect
 	repository := createRepository
 	if repository == "" {
@@ -146,13 +159,13 @@ func runCreate(_ *cobra.Command, args []string) error {
 
 	// Build request (scope determined by API based on repository)
 	req := client.CreateBlueprintRequest{
-		Compose:    string(composeContent),
+		Compose:    composeContent,
 		Branch:     createBranch,
 		Author:     createAuthor,
 		Repository: repository,
 	}
 
-	identifier, err := apiClient.CreateBlueprint(req)
+	identifier, err := apiClient.CreateBlueprint(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to create blueprint: %w", err)
 	}
@@ -16,14 +16,16 @@ var deleteCmd = &cobra.Command{
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	blueprintName := args[0]
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err := apiClient.DeleteBlueprint(blueprintName); err != nil {
+	if err := apiClient.DeleteBlueprint(ctx, blueprintName); err != nil {
 		return fmt.Errorf("failed to delete blueprint: %w", err)
 	}
 
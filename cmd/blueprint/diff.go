@@ -0,0 +1,111 @@
+package blueprint
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/compose"
+	"github.com/lissto-dev/cli/pkg/diff"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var diffContextLines int
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <blueprint-id> <other-blueprint-id>",
+	Short: "Compare two blueprints",
+	Long: `Compare the docker-compose content of two blueprints.
+
+Prints a summary of added, removed and changed services followed by a
+unified diff from the first blueprint to the second.
+
+Examples:
+  # Compare two versions of a blueprint
+  lissto blueprint diff 20250101-120000-abc123 20250102-090000-def456
+
+  # Show more context around each change
+  lissto blueprint diff <id1> <id2> -U 10
+
+  # Machine-readable summary and diff
+  lissto blueprint diff <id1> <id2> -o json`,
+	Args:          cobra.ExactArgs(2),
+	RunE:          runDiff,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+// blueprintDiff is the machine-readable result of 'blueprint diff'
+type blueprintDiff struct {
+	Old      string                  `json:"old" yaml:"old"`
+	New      string                  `json:"new" yaml:"new"`
+	Services *compose.ServiceChanges `json:"services" yaml:"services"`
+	Diff     string                  `json:"diff" yaml:"diff"`
+}
+
+func init() {
+	diffCmd.Flags().IntVarP(&diffContextLines, "unified", "U", 3, "Number of context lines around each change")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	oldID, newID := args[0], args[1]
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldBlueprint, err := apiClient.GetBlueprintDetailed(ctx, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to get blueprint '%s': %w", oldID, err)
+	}
+	newBlueprint, err := apiClient.GetBlueprintDetailed(ctx, newID)
+	if err != nil {
+		return fmt.Errorf("failed to get blueprint '%s': %w", newID, err)
+	}
+
+	services, err := compose.CompareServices(oldBlueprint.Spec.DockerCompose, newBlueprint.Spec.DockerCompose)
+	if err != nil {
+		return fmt.Errorf("failed to compare services: %w", err)
+	}
+
+	result := &blueprintDiff{
+		Old:      oldID,
+		New:      newID,
+		Services: services,
+		Diff:     diff.Unified(oldID, newID, oldBlueprint.Spec.DockerCompose, newBlueprint.Spec.DockerCompose, diffContextLines),
+	}
+
+	return cmdutil.PrintOutput(cmd, result, func() {
+		printBlueprintDiff(result)
+	})
+}
+
+// printBlueprintDiff prints the service summary and a colored unified diff
+func printBlueprintDiff(result *blueprintDiff) {
+	if result.Diff == "" {
+		fmt.Println("✅ Blueprints are identical")
+		return
+	}
+
+	if result.Services.Empty() {
+		fmt.Println("Services: no changes")
+	} else {
+		fmt.Println("Services:")
+		for _, name := range result.Services.Added {
+			fmt.Printf("  %s\n", output.Green("+ "+name))
+		}
+		for _, name := range result.Services.Removed {
+			fmt.Printf("  %s\n", output.Red("- "+name))
+		}
+		for _, name := range result.Services.Changed {
+			fmt.Printf("  %s\n", output.Yellow("~ "+name))
+		}
+	}
+	fmt.Println()
+
+	output.PrintUnifiedDiff(os.Stdout, result.Diff)
+}
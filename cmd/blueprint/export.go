@@ -0,0 +1,94 @@
+package blueprint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFile string
+	exportDir  string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <blueprint-id>...",
+	Short: "Export blueprints to local files",
+	Long: `Export blueprints (docker-compose content and annotations) to YAML files
+that can be committed to git or re-imported with 'lissto blueprint import',
+for example into another Lissto installation.
+
+Without --file or --dir a single blueprint is written to stdout.
+
+Examples:
+  # Print a blueprint export
+  lissto blueprint export global/20250101-120000-abc123
+
+  # Write to a file
+  lissto blueprint export <id> -f backup.yaml
+
+  # Export several blueprints into a directory (one file each)
+  lissto blueprint export <id1> <id2> --dir blueprints/`,
+	Args:          cobra.MinimumNArgs(1),
+	RunE:          runExport,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFile, "file", "f", "", "Write the export to this file")
+	exportCmd.Flags().StringVar(&exportDir, "dir", "", "Write one file per blueprint into this directory")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if exportFile != "" && exportDir != "" {
+		return fmt.Errorf("--file and --dir are mutually exclusive")
+	}
+	if len(args) > 1 && exportDir == "" {
+		return fmt.Errorf("use --dir to export more than one blueprint")
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if exportDir != "" {
+		if err := os.MkdirAll(exportDir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	for _, id := range args {
+		blueprint, err := apiClient.GetBlueprintDetailed(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get blueprint '%s': %w", id, err)
+		}
+
+		data, err := cmdutil.MarshalBlueprintExport(id, blueprint)
+		if err != nil {
+			return err
+		}
+
+		path := exportFile
+		if exportDir != "" {
+			path = filepath.Join(exportDir, blueprint.Metadata.Name+".yaml")
+		}
+		if path == "" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Printf("✅ Exported %s to %s\n", id, path)
+	}
+
+	return nil
+}
@@ -16,14 +16,16 @@ var getCmd = &cobra.Command{
 }
 
 func runGet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	blueprintName := args[0]
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	blueprint, err := apiClient.GetBlueprint(blueprintName)
+	blueprint, err := apiClient.GetBlueprint(ctx, blueprintName)
 	if err != nil {
 		return fmt.Errorf("failed to get blueprint: %w", err)
 	}
@@ -0,0 +1,93 @@
+package blueprint
+
+import (
+	"fmt"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importRepository string
+	importBranch     string
+	importAuthor     string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file-or-dir>...",
+	Short: "Import blueprints from exported files",
+	Long: `Create blueprints from files written by 'lissto blueprint export'.
+
+Directories are searched for *.yaml and *.yml export files. Each import
+creates a new blueprint in the current context; the repository is taken
+from the exported annotations unless --repository is set.
+
+Examples:
+  # Import a single export
+  lissto blueprint import backup.yaml
+
+  # Import every export in a directory into another installation
+  lissto context use production
+  lissto blueprint import blueprints/`,
+	Args:          cobra.MinimumNArgs(1),
+	RunE:          runImport,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importRepository, "repository", "", "Repository name/URL (overrides the exported annotation)")
+	importCmd.Flags().StringVar(&importBranch, "branch", "", "Branch name to record on the blueprint")
+	importCmd.Flags().StringVar(&importAuthor, "author", "", "Author name to record on the blueprint")
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	files, err := cmdutil.CollectBlueprintExports(args)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no export files found")
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, path := range files {
+		export, err := cmdutil.ReadBlueprintExport(path)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", path, err)
+			failed++
+			continue
+		}
+
+		repository := importRepository
+		if repository == "" {
+			repository = export.Annotations[cmdutil.RepositoryAnnotation]
+		}
+
+		identifier, err := apiClient.CreateBlueprint(ctx, client.CreateBlueprintRequest{
+			Compose:    export.Compose,
+			Branch:     importBranch,
+			Author:     importAuthor,
+			Repository: repository,
+		})
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", path, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ Imported %s as %s\n", export.ID, identifier)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to import %d blueprint(s)", failed)
+	}
+	return nil
+}
@@ -1,9 +1,11 @@
 package blueprint
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/cmdutil"
 	"github.com/lissto-dev/cli/pkg/output"
 	"github.com/spf13/cobra"
@@ -12,21 +14,30 @@ import (
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all blueprints",
-	Long:  `List all blueprints (both user and global).`,
-	RunE:  runList,
+	Long: `List all blueprints (both user and global).
+
+Use --cached to show the last blueprints fetched when the cluster is
+unreachable.`,
+	RunE: runList,
+}
+
+func init() {
+	cmdutil.AddCachedFlag(listCmd)
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	apiClient, err := cmdutil.GetAPIClient()
+	results, err := cmdutil.ForEachContextCached(cmd, "blueprints", func(ctx context.Context, target cmdutil.ContextTarget) ([]client.BlueprintResponse, error) {
+		// Always include global blueprints (API returns both by default)
+		blueprints, err := target.Client.ListBlueprints(ctx, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blueprints: %w", err)
+		}
+		return blueprints, nil
+	})
 	if err != nil {
 		return err
 	}
-
-	// Always include global blueprints (API returns both by default)
-	blueprints, err := apiClient.ListBlueprints(true)
-	if err != nil {
-		return fmt.Errorf("failed to list blueprints: %w", err)
-	}
+	blueprints := results[0].Value
 
 	if len(blueprints) == 0 {
 		fmt.Println("No blueprints found.")
@@ -0,0 +1,110 @@
+package blueprint
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/output"
+	controllerconfig "github.com/lissto-dev/controller/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneRepository string
+	pruneKeep       int
+	pruneDryRun     bool
+	pruneYes        bool
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete old blueprints no stack uses",
+	Long: `Delete the blueprints no stack of any env references, keeping the --keep
+newest blueprints of every repository whether they are used or not.
+
+Blueprints whose repository is unknown (not created from a git checkout) are
+never pruned. The blueprints to delete are listed and confirmed first.
+
+Examples:
+  # Show what would be deleted
+  lissto blueprint prune --dry-run
+
+  # Keep only the newest blueprint of one repository
+  lissto blueprint prune --repository github.com/org/app --keep 1
+
+  # Nightly cleanup job
+  lissto blueprint prune --yes`,
+	Args: cobra.NoArgs,
+	RunE: runPrune,
+}
+
+func init() {
+	pruneCmd.Flags().StringVarP(&pruneRepository, "repository", "r", "", "Only prune the blueprints of this repository")
+	pruneCmd.Flags().IntVar(&pruneKeep, "keep", 3, "Newest blueprints to keep per repository")
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Only list the blueprints that would be deleted")
+	pruneCmd.Flags().BoolVarP(&pruneYes, "yes", "y", false, "Skip confirmation prompt")
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if pruneKeep < 0 {
+		return fmt.Errorf("--keep must not be negative")
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+	usages, err := apiClient.GetBlueprintUsages(ctx)
+	if err != nil {
+		return err
+	}
+
+	repository := pruneRepository
+	if repository != "" {
+		repository = controllerconfig.NormalizeRepositoryURL(repository)
+	}
+	candidates := client.PruneCandidates(usages, repository, pruneKeep)
+	if len(candidates) == 0 {
+		fmt.Println("No blueprints to prune.")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(candidates))
+	for _, u := range candidates {
+		rows = append(rows, []string{u.ID, u.Title, u.Repository, output.ExtractBlueprintAge(u.ID)})
+	}
+	output.PrintTable(os.Stdout, []string{"ID", "TITLE", "REPOSITORY", "AGE"}, rows)
+	fmt.Println()
+
+	if pruneDryRun {
+		fmt.Printf("Dry run: %d blueprint(s) would be deleted, keeping the %d newest per repository\n", len(candidates), pruneKeep)
+		return nil
+	}
+
+	if !pruneYes {
+		confirmed, err := interactive.ConfirmAction(fmt.Sprintf("Delete %d unused blueprint(s)?", len(candidates)), false)
+		if err != nil || !confirmed {
+			return fmt.Errorf("prune cancelled")
+		}
+	}
+
+	var failed int
+	for _, u := range candidates {
+		if err := apiClient.DeleteBlueprint(ctx, u.ID); err != nil {
+			fmt.Printf("❌ %s: %v\n", u.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ Deleted blueprint: %s\n", u.ID)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d blueprint(s)", failed)
+	}
+	return nil
+}
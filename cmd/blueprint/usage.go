@@ -0,0 +1,95 @@
+package blueprint
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var usageUnused bool
+
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show which stacks use each blueprint",
+	Long: `List every blueprint with the stacks of all envs referencing it, its age and
+whether its repository has a newer blueprint.
+
+Blueprints that are unused and superseded by a newer blueprint of the same
+repository are safe to delete. The repository is only known for blueprints
+created from a git checkout.
+
+Examples:
+  # Show blueprint usage
+  lissto blueprint usage
+
+  # Only blueprints no stack references
+  lissto blueprint usage --unused`,
+	Args: cobra.NoArgs,
+	RunE: runUsage,
+}
+
+func init() {
+	usageCmd.Flags().BoolVar(&usageUnused, "unused", false, "Only show blueprints no stack references")
+}
+
+func runUsage(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+	usages, err := apiClient.GetBlueprintUsages(ctx)
+	if err != nil {
+		return err
+	}
+	if usageUnused {
+		unused := usages[:0]
+		for _, u := range usages {
+			if u.Unused() {
+				unused = append(unused, u)
+			}
+		}
+		usages = unused
+	}
+
+	return cmdutil.PrintOutput(cmd, usages, func() {
+		if len(usages) == 0 {
+			fmt.Println("No blueprints found.")
+			return
+		}
+		rows := make([][]string, 0, len(usages))
+		for _, u := range usages {
+			stacks := make([]string, 0, len(u.Stacks))
+			for _, s := range u.Stacks {
+				stacks = append(stacks, s.Env+"/"+s.Name)
+			}
+			stackList := strings.Join(stacks, ", ")
+			if stackList == "" {
+				stackList = "-"
+			}
+			rows = append(rows, []string{u.ID, u.Title, output.ExtractBlueprintAge(u.ID), stackList, usageState(u)})
+		}
+		output.PrintTable(os.Stdout, []string{"ID", "TITLE", "AGE", "STACKS", "STATUS"}, rows)
+	})
+}
+
+// usageState describes whether a blueprint is used and superseded
+func usageState(u client.BlueprintUsage) string {
+	switch {
+	case u.Unused() && u.Superseded():
+		return output.Yellow("unused, superseded by " + u.Newer[0])
+	case u.Unused() && u.Repository != "":
+		return output.Gray("unused (latest)")
+	case u.Unused():
+		return output.Gray("unused")
+	case u.Superseded():
+		return "in use, superseded by " + u.Newer[0]
+	}
+	return output.Green("in use")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/lissto-dev/cli/pkg/cmdutil"
 	"github.com/lissto-dev/cli/pkg/compose"
 	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/messages"
 	controllerconfig "github.com/lissto-dev/controller/pkg/config"
 	"github.com/spf13/cobra"
 )
@@ -77,7 +78,9 @@ func inferRepositoryFromFile(composeFile string) (string, error) {
 }
 
 // blueprintWizardFlow orchestrates the complete blueprint creation wizard
-func blueprintWizardFlow(_ *cobra.Command, apiClient *client.Client) (*client.BlueprintResponse, error) {
+func blueprintWizardFlow(cmd *cobra.Command, apiClient *client.Client) (*client.BlueprintResponse, error) {
+	ctx := cmd.Context()
+
 	var selectedFile string
 	var repository string
 
@@ -136,12 +139,18 @@ func blueprintWizardFlow(_ *cobra.Command, apiClient *client.Client) (*client.Bl
 
 	// Step 5: Validate compose file with warning detection
 	fmt.Println("\n📋 Validating compose file...")
-	composeContent, err := os.ReadFile(selectedFile)
+	rawContent, err := os.ReadFile(selectedFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read compose file: %w", err)
 	}
 
-	validationResult, err := apicompose.ValidateCompose(string(composeContent))
+	composeContent, profiles, err := compose.ApplyProfiles(string(rawContent), createProfiles)
+	if err != nil {
+		return nil, err
+	}
+	profiles.Print(os.Stdout)
+
+	validationResult, err := apicompose.ValidateCompose(composeContent)
 	if err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
@@ -157,13 +166,13 @@ func blueprintWizardFlow(_ *cobra.Command, apiClient *client.Client) (*client.Bl
 	// Show concise validation result
 	if len(validationResult.Warnings) > 0 {
 		fmt.Printf("⚠️  Compose file is valid but %d warning(s) found\n", len(validationResult.Warnings))
-		fmt.Printf("💡 Run 'lissto verify %s --verbose' to see details\n", filepath.Base(selectedFile))
+		fmt.Println(messages.Get(messages.VerifyWarningsHint, map[string]string{"File": filepath.Base(selectedFile)}))
 	} else {
 		fmt.Println("✅ Compose file is valid")
 	}
 
 	// Step 6: Check for existing blueprints for this repository
-	existingBlueprints, err := apiClient.FindBlueprintsByRepository(normalizedRepo)
+	existingBlueprints, err := apiClient.FindBlueprintsByRepository(ctx, normalizedRepo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check for existing blueprints: %w", err)
 	}
@@ -175,6 +184,11 @@ func blueprintWizardFlow(_ *cobra.Command, apiClient *client.Client) (*client.Bl
 		// Step 7: Handle existing blueprint
 		latestBP := existingBlueprints[0] // Already sorted by newest first
 
+		// Warn when the chosen file would drop services from the existing blueprint
+		if err := checkServiceOverlap(selectedFile, composeContent, latestBP); err != nil {
+			return nil, err
+		}
+
 		action, err := interactive.ConfirmBlueprintAction(latestBP)
 		if err != nil {
 			return nil, fmt.Errorf("cancelled: %w", err)
@@ -186,12 +200,12 @@ func blueprintWizardFlow(_ *cobra.Command, apiClient *client.Client) (*client.Bl
 			blueprintIDToDelete = latestBP.ID
 
 			// Step 8: Check for active stacks using this blueprint
-			env, err := cmdutil.GetOrCreateDefaultEnv(apiClient, createEnv, false)
+			env, err := cmdutil.GetOrCreateDefaultEnv(ctx, apiClient, createEnv, false)
 			if err != nil {
 				return nil, fmt.Errorf("failed to determine environment: %w", err)
 			}
 
-			stacks, err := apiClient.FindStacksByBlueprint(latestBP.ID, env)
+			stacks, err := apiClient.FindStacksByBlueprint(ctx, latestBP.ID, env)
 			if err != nil {
 				return nil, fmt.Errorf("failed to check for active stacks: %w", err)
 			}
@@ -214,7 +228,7 @@ func blueprintWizardFlow(_ *cobra.Command, apiClient *client.Client) (*client.Bl
 					fmt.Println("\nDeleting stacks...")
 					for _, stack := range stacks {
 						fmt.Printf("  Deleting stack: %s\n", stack.Name)
-						if err := apiClient.DeleteStack(stack.Name, env); err != nil {
+						if err := apiClient.DeleteStack(ctx, stack.Name, env); err != nil {
 							return nil, fmt.Errorf("failed to delete stack %s: %w", stack.Name, err)
 						}
 					}
@@ -240,7 +254,7 @@ func blueprintWizardFlow(_ *cobra.Command, apiClient *client.Client) (*client.Bl
 	// Step 9: Delete old blueprint if overriding
 	if shouldOverride && blueprintIDToDelete != "" {
 		fmt.Printf("Deleting old blueprint: %s\n", blueprintIDToDelete)
-		if err := apiClient.DeleteBlueprint(blueprintIDToDelete); err != nil {
+		if err := apiClient.DeleteBlueprint(ctx, blueprintIDToDelete); err != nil {
 			return nil, fmt.Errorf("failed to delete old blueprint: %w", err)
 		}
 	}
@@ -248,20 +262,17 @@ func blueprintWizardFlow(_ *cobra.Command, apiClient *client.Client) (*client.Bl
 	// Step 10: Create new blueprint
 	fmt.Println("\nCreating blueprint...")
 	req := client.CreateBlueprintRequest{
-		Compose:    string(composeContent),
+		Compose:    composeContent,
 		Repository: normalizedRepo,
 	}
 
-	identifier, err := apiClient.CreateBlueprint(req)
+	identifier, err := apiClient.CreateBlueprint(ctx, req)
 	if err != nil {
 		// Check if it's a repository configuration error
 		if strings.Contains(err.Error(), "is not configured") || strings.Contains(err.Error(), "not allowed") {
 			fmt.Printf("\n❌ Repository configuration error\n")
 			fmt.Printf("   Detected: %s\n\n", normalizedRepo)
-			fmt.Printf("💡 This repository is not in your Lissto configuration.\n")
-			fmt.Printf("   If using SSH host aliases (e.g., github.com-lissto), try:\n")
-			fmt.Printf("   • Set %s=git@github.com:org/repo.git\n", cmdutil.EnvOverrideRepository)
-			fmt.Printf("   • Or use: lissto blueprint create <file> --repository git@github.com:org/repo.git\n")
+			fmt.Println(messages.Get(messages.RepoNotConfigured, map[string]string{"EnvVar": cmdutil.EnvOverrideRepository}))
 		}
 		return nil, fmt.Errorf("failed to create blueprint: %w", err)
 	}
@@ -270,7 +281,7 @@ func blueprintWizardFlow(_ *cobra.Command, apiClient *client.Client) (*client.Bl
 	fmt.Printf("Blueprint ID: %s\n\n", identifier)
 
 	// Fetch the created blueprint to return
-	createdBP, err := apiClient.GetBlueprint(identifier)
+	createdBP, err := apiClient.GetBlueprint(ctx, identifier)
 	if err != nil {
 		// Don't fail the whole operation, just return nil
 		fmt.Printf("⚠️  Warning: Could not fetch created blueprint details: %v\n", err)
@@ -279,3 +290,34 @@ func blueprintWizardFlow(_ *cobra.Command, apiClient *client.Client) (*client.Bl
 
 	return createdBP, nil
 }
+
+// checkServiceOverlap warns when the selected compose file lacks services the
+// existing blueprint defines, which usually means a partial compose file
+// (e.g. an override file) was picked by mistake
+func checkServiceOverlap(composeFile, content string, existing client.BlueprintResponse) error {
+	summary, err := compose.SummarizeComposeContent(composeFile, content)
+	if err != nil {
+		return nil // validation already passed; skip the advisory check
+	}
+
+	expected := append(append([]string{}, existing.Content.Services...), existing.Content.Infra...)
+	missing := summary.MissingServices(expected)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	fmt.Printf("\n⚠️  Warning: %s does not define services present in the existing blueprint:\n", filepath.Base(composeFile))
+	for _, svc := range missing {
+		fmt.Printf("  - %s\n", svc)
+	}
+	fmt.Println("   Stacks created from the new blueprint will not include them.")
+
+	confirmed, err := interactive.ConfirmAction("Continue with this compose file?", false)
+	if err != nil {
+		return fmt.Errorf("cancelled: %w", err)
+	}
+	if !confirmed {
+		return fmt.Errorf("cancelled by user")
+	}
+	return nil
+}
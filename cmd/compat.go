@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+// warnIfIncompatible prints a warning for an out-of-range API version. Current
+// APIs don't report their version, so mostly it says the check isn't possible;
+// nothing is blocked either way. Set LISSTO_SKIP_COMPAT_CHECK=1 to silence it.
+func warnIfIncompatible(ctx context.Context, apiClient *client.Client) {
+	if cmdutil.LoadOverrides().SkipCompatCheck {
+		return
+	}
+	compat, err := apiClient.CheckServerCompatibility(ctx)
+	if err != nil {
+		return
+	}
+	switch compat.Status {
+	case client.CompatibilityOK:
+	case client.CompatibilityUnknown:
+		fmt.Fprintf(os.Stderr, "ℹ️  The Lissto API doesn't report its version, so compatibility with this CLI (API %s) can't be checked\n", compat.Supported)
+	default:
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", compat.Advice())
+	}
+}
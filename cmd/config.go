@@ -2,9 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/lissto-dev/cli/pkg/cmdutil"
 	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/mcp"
 	"github.com/lissto-dev/cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -23,7 +29,12 @@ var configGetCmd = &cobra.Command{
 	Long: `Get a configuration value.
 
 Available keys:
-  settings.update-check  Whether automatic update checks are enabled (true/false)`,
+  settings.update-check  Whether automatic update checks are enabled (true/false)
+  credential-store       Where API keys and tokens are stored (file/keychain)
+  mcp.read-only          Whether 'lissto mcp' only exposes read-only tools
+  mcp.allow              Tools 'lissto mcp' may call (comma-separated globs)
+  mcp.deny               Tools 'lissto mcp' may never call (comma-separated globs)
+  defaults.<cmd>.<flag>  Default value of a command's flag, e.g. defaults.logs.tail`,
 	Args: cobra.ExactArgs(1),
 	RunE: runConfigGet,
 }
@@ -36,10 +47,25 @@ var configSetCmd = &cobra.Command{
 
 Available keys:
   settings.update-check  Set to 'true' to enable automatic update checks, 'false' to disable
+  credential-store       Set to 'keychain' to keep API keys and login tokens in the OS
+                         keychain (macOS Keychain, Windows Credential Manager or Secret
+                         Service), or 'file' to keep them in config.yaml. Existing
+                         credentials are moved.
+  mcp.read-only          Set to 'true' to only expose read-only tools to MCP clients
+  mcp.allow              Comma-separated tool globs MCP clients may call ('' for all)
+  mcp.deny               Comma-separated tool globs MCP clients may never call
+  defaults.<cmd>.<flag>  Default value of a flag, used when the flag isn't given.
+                         Subcommands are separated by dots (defaults.stack.list.output).
+                         Set to '' to remove the default.
 
 Examples:
   lissto config set settings.update-check true
-  lissto config set settings.update-check false`,
+  lissto config set settings.update-check false
+  lissto config set credential-store keychain
+  lissto config set mcp.deny stack_delete,blueprint_delete,secret_*
+  lissto config set defaults.logs.tail 200
+  lissto config set defaults.status.output table
+  lissto config set defaults.create.branch main`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConfigSet,
 }
@@ -66,9 +92,26 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if strings.HasPrefix(key, config.DefaultsKeyPrefix) {
+		command, flag, err := config.ParseDefaultsKey(key)
+		if err != nil {
+			return err
+		}
+		fmt.Println(cfg.CommandDefaults(command)[flag])
+		return nil
+	}
+
 	switch key {
 	case "settings.update-check":
 		fmt.Printf("%t\n", cfg.Settings.UpdateCheck)
+	case "credential-store", "settings.credential-store":
+		fmt.Println(credentialStore(cfg))
+	case "mcp.read-only":
+		fmt.Printf("%t\n", cfg.Settings.MCP.ReadOnly)
+	case "mcp.allow":
+		fmt.Println(strings.Join(cfg.Settings.MCP.Allow, ","))
+	case "mcp.deny":
+		fmt.Println(strings.Join(cfg.Settings.MCP.Deny, ","))
 	default:
 		return fmt.Errorf("unknown configuration key: %s", key)
 	}
@@ -80,9 +123,33 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	value := args[1]
 
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+	if err := config.UpdateConfig(func(cfg *config.Config) error {
+		return setConfigValue(cfg, key, value)
+	}); err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(key, config.DefaultsKeyPrefix) && value == "" {
+		fmt.Printf("Removed %s\n", key)
+	} else {
+		fmt.Printf("Set %s to %s\n", key, value)
+	}
+	return nil
+}
+
+// setConfigValue sets a configuration key on cfg
+func setConfigValue(cfg *config.Config, key, value string) error {
+	if strings.HasPrefix(key, config.DefaultsKeyPrefix) {
+		command, flag, err := config.ParseDefaultsKey(key)
+		if err != nil {
+			return err
+		}
+		command, err = defaultsCommand(command, flag)
+		if err != nil {
+			return err
+		}
+		cfg.SetDefault(command, flag, value)
+		return nil
 	}
 
 	switch key {
@@ -95,15 +162,30 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		default:
 			return fmt.Errorf("invalid value for settings.update-check: %s (use 'true' or 'false')", value)
 		}
+	case "credential-store", "settings.credential-store":
+		if err := config.ValidateCredentialStore(value); err != nil {
+			return err
+		}
+		cfg.Settings.CredentialStore = value
+	case "mcp.read-only":
+		readOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for mcp.read-only: %s (use 'true' or 'false')", value)
+		}
+		cfg.Settings.MCP.ReadOnly = readOnly
+	case "mcp.allow", "mcp.deny":
+		tools, err := mcp.ParseToolList(value)
+		if err != nil {
+			return err
+		}
+		if key == "mcp.allow" {
+			cfg.Settings.MCP.Allow = tools
+		} else {
+			cfg.Settings.MCP.Deny = tools
+		}
 	default:
 		return fmt.Errorf("unknown configuration key: %s", key)
 	}
-
-	if err := config.SaveConfig(cfg); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
-	}
-
-	fmt.Printf("Set %s to %s\n", key, value)
 	return nil
 }
 
@@ -124,8 +206,39 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	headers := []string{"KEY", "VALUE"}
 	rows := [][]string{
 		{"settings.update-check", fmt.Sprintf("%t", cfg.Settings.UpdateCheck)},
+		{"credential-store", credentialStore(cfg)},
+		{"mcp.read-only", fmt.Sprintf("%t", cfg.Settings.MCP.ReadOnly)},
+		{"mcp.allow", strings.Join(cfg.Settings.MCP.Allow, ",")},
+		{"mcp.deny", strings.Join(cfg.Settings.MCP.Deny, ",")},
+	}
+	for _, command := range slices.Sorted(maps.Keys(cfg.Defaults)) {
+		defaults := cfg.Defaults[command]
+		for _, flag := range slices.Sorted(maps.Keys(defaults)) {
+			rows = append(rows, []string{config.DefaultsKeyPrefix + command + "." + flag, defaults[flag]})
+		}
 	}
 	output.PrintTable(os.Stdout, headers, rows)
 
 	return nil
 }
+
+// defaultsCommand checks that a command (e.g. "stack.list") exists and has
+// the flag a default is set for, and returns its name without aliases
+func defaultsCommand(command, flag string) (string, error) {
+	target, rest, err := rootCmd.Find(strings.Split(command, "."))
+	if err != nil || len(rest) > 0 || target == rootCmd {
+		return "", fmt.Errorf("unknown command '%s' (use dots between subcommands, e.g. defaults.stack.list.output)", strings.ReplaceAll(command, ".", " "))
+	}
+	if target.Flags().Lookup(flag) == nil && target.InheritedFlags().Lookup(flag) == nil {
+		return "", fmt.Errorf("command '%s' has no flag --%s", target.CommandPath(), flag)
+	}
+	return cmdutil.DefaultsCommand(target), nil
+}
+
+// credentialStore returns the configured credential store, defaulting to file
+func credentialStore(cfg *config.Config) string {
+	if cfg.Settings.CredentialStore == "" {
+		return config.CredentialStoreFile
+	}
+	return cfg.Settings.CredentialStore
+}
@@ -3,12 +3,21 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
 	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/spinner"
 	"github.com/spf13/cobra"
 )
 
+var (
+	contextNoProbe      bool
+	contextProbeTimeout time.Duration
+)
+
 // contextCmd represents the context command
 var contextCmd = &cobra.Command{
 	Use:   "context",
@@ -20,7 +29,20 @@ var contextCmd = &cobra.Command{
 var contextListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all contexts",
-	RunE:  runContextList,
+	Long: `List all contexts with how their API is reached, whether it answers,
+its version and when the context was last used.
+
+The APIs are probed concurrently, each within --timeout; contexts without a
+public URL are probed one at a time through a port-forward. Use
+'lissto context test' to see why a context is unreachable.
+
+Examples:
+  # List contexts and probe their APIs
+  lissto context list
+
+  # Only the saved settings, without network calls
+  lissto context list --no-probe`,
+	RunE: runContextList,
 }
 
 // contextCurrentCmd shows the current context
@@ -52,6 +74,10 @@ func init() {
 	contextCmd.AddCommand(contextCurrentCmd)
 	contextCmd.AddCommand(contextUseCmd)
 	contextCmd.AddCommand(contextDeleteCmd)
+	contextCmd.AddCommand(contextTestCmd)
+
+	contextListCmd.Flags().BoolVar(&contextNoProbe, "no-probe", false, "Don't check whether the APIs are reachable")
+	contextListCmd.Flags().DurationVar(&contextProbeTimeout, "timeout", 3*time.Second, "Maximum time to wait for each API")
 }
 
 func runContextList(cmd *cobra.Command, args []string) error {
@@ -72,26 +98,59 @@ func runContextList(cmd *cobra.Command, args []string) error {
 		return output.PrintYAML(os.Stdout, cfg.Contexts)
 	}
 
+	var health []client.ContextHealth
+	if !contextNoProbe {
+		spin := spinner.StartTransient(os.Stderr, "Probing context APIs")
+		health = client.ProbeContexts(cmd.Context(), cfg.Contexts, contextProbeTimeout)
+		spin.Stop()
+	}
+	lastUsed := client.ContextLastUsed()
+
 	// Table format
-	headers := []string{"NAME", "CONTEXT", "SERVICE", "NAMESPACE"}
+	headers := []string{"NAME", "CONTEXT", "SERVICE", "NAMESPACE", "MODE", "REACHABLE", "VERSION", "LAST USED"}
 	rows := make([][]string, 0, len(cfg.Contexts))
-	for _, ctx := range cfg.Contexts {
-		name := ctx.Name
-		kubeContext := ctx.KubeContext
-		serviceName := ctx.ServiceName
-		serviceNamespace := ctx.ServiceNamespace
+	unreachable := false
+	for i, ctx := range cfg.Contexts {
+		mode, reachable, version := client.ModePortForward, "-", "-"
+		switch {
+		case ctx.KubeContext == k8s.InClusterContext:
+			mode = client.ModeInCluster
+		case ctx.APIUrl != "":
+			mode = client.ModePublic
+		}
+		if health != nil {
+			mode = health[i].Mode
+			reachable = output.Green("yes")
+			if !health[i].Reachable {
+				reachable = output.Red("no")
+				unreachable = true
+			}
+			if health[i].Version != "" {
+				version = health[i].Version
+			}
+		}
+		used := "never"
+		if t, ok := lastUsed[ctx.Name]; ok {
+			used = k8s.FormatAge(time.Since(t)) + " ago"
+		}
 
-		if ctx.Name == cfg.CurrentContext {
+		row := []string{ctx.Name, ctx.KubeContext, ctx.ServiceName, ctx.ServiceNamespace, mode, reachable, version, used}
+		if ctx.Name == cfg.ActiveContextName() {
 			// Bold the entire row and add asterisk to name at the end
-			name = output.Bold(ctx.Name + " *")
-			kubeContext = output.Bold(ctx.KubeContext)
-			serviceName = output.Bold(ctx.ServiceName)
-			serviceNamespace = output.Bold(ctx.ServiceNamespace)
+			row[0] += " *"
+			for j := range row {
+				row[j] = output.Bold(row[j])
+			}
 		}
-		rows = append(rows, []string{name, kubeContext, serviceName, serviceNamespace})
+		rows = append(rows, row)
 	}
 	output.PrintTable(os.Stdout, headers, rows)
 
+	if unreachable {
+		fmt.Println()
+		fmt.Println("Run 'lissto context test <name>' to see why a context is unreachable.")
+	}
+
 	return nil
 }
 
@@ -101,7 +160,7 @@ func runContextCurrent(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	if cfg.CurrentContext == "" {
+	if cfg.ActiveContextName() == "" {
 		return fmt.Errorf("no context selected")
 	}
 
@@ -119,6 +178,9 @@ func runContextCurrent(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Current context: %s\n", ctx.Name)
 	fmt.Printf("Kubernetes context: %s\n", ctx.KubeContext)
+	if ctx.Kubeconfig != "" {
+		fmt.Printf("Kubeconfig: %s\n", ctx.Kubeconfig)
+	}
 	fmt.Printf("Service: %s/%s\n", ctx.ServiceNamespace, ctx.ServiceName)
 	if ctx.APIUrl != "" {
 		fmt.Printf("API URL: %s\n", ctx.APIUrl)
@@ -130,19 +192,12 @@ func runContextCurrent(cmd *cobra.Command, args []string) error {
 func runContextUse(cmd *cobra.Command, args []string) error {
 	contextName := args[0]
 
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	if err := cfg.SetCurrentContext(contextName); err != nil {
+	if err := config.UpdateConfig(func(cfg *config.Config) error {
+		return cfg.SetCurrentContext(contextName)
+	}); err != nil {
 		return err
 	}
 
-	if err := config.SaveConfig(cfg); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
-	}
-
 	fmt.Printf("Switched to context: %s\n", contextName)
 
 	return nil
@@ -151,19 +206,14 @@ func runContextUse(cmd *cobra.Command, args []string) error {
 func runContextDelete(cmd *cobra.Command, args []string) error {
 	contextName := args[0]
 
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	if err := cfg.DeleteContext(contextName); err != nil {
+	var cfg *config.Config
+	if err := config.UpdateConfig(func(c *config.Config) error {
+		cfg = c
+		return c.DeleteContext(contextName)
+	}); err != nil {
 		return err
 	}
 
-	if err := config.SaveConfig(cfg); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
-	}
-
 	fmt.Printf("Deleted context: %s\n", contextName)
 	if cfg.CurrentContext == "" && len(cfg.Contexts) > 0 {
 		fmt.Printf("Hint: Set a new current context with 'lissto context use <name>'\n")
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/spf13/cobra"
+)
+
+// contextTestCheckNames lists the checks of 'context test' in the order they run
+var contextTestCheckNames = []string{
+	"Kubernetes reachable",
+	"Lissto API",
+	"API instance ID",
+	"Authentication",
+	"Environments",
+}
+
+// contextTestCmd validates a context end-to-end
+var contextTestCmd = &cobra.Command{
+	Use:   "test [context-name]",
+	Short: "Check that a context works end-to-end",
+	Long: `Check that a context works end-to-end: its Kubernetes cluster answers,
+the Lissto API is reachable (publicly or through a port-forward), it is the
+API instance the context was created for, the API key or login is accepted
+and environments can be listed.
+
+Unlike 'lissto doctor', the context doesn't have to be the current one and
+kubectl's current context doesn't have to match it.
+
+Examples:
+  # Test the current context
+  lissto context test
+
+  # Test another context
+  lissto context test staging -o json`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runContextTest,
+	SilenceUsage: true,
+}
+
+func runContextTest(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	var lisstoCtx *config.Context
+	if len(args) == 1 {
+		lisstoCtx, err = cfg.GetContext(args[0])
+	} else {
+		lisstoCtx, err = cfg.GetCurrentContext()
+	}
+	if err != nil {
+		return err
+	}
+
+	checks := runContextChecks(cmd.Context(), lisstoCtx)
+	if err := cmdutil.PrintOutput(cmd, checks, func() {
+		fmt.Printf("🔌 Context '%s'\n\n", lisstoCtx.Name)
+		cmdutil.PrintChecks(os.Stdout, checks)
+	}); err != nil {
+		return err
+	}
+	return cmdutil.ChecksError(checks)
+}
+
+// runContextChecks runs the checks of a context in order. Checks that depend
+// on a failed one are reported as skipped.
+func runContextChecks(ctx context.Context, lisstoCtx *config.Context) []cmdutil.Check {
+	checks := cmdutil.NewChecklist(contextTestCheckNames...)
+	add, skipRest := checks.Add, checks.SkipRest
+
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+
+	// 1. Cluster; a public API still works without it, so go on
+	k8sClient, err := k8s.NewClientWithContext(lisstoCtx.Kubeconfig, lisstoCtx.KubeContext)
+	if err == nil {
+		var version string
+		if version, err = k8sClient.ServerVersion(); err == nil {
+			add("Kubernetes reachable", cmdutil.CheckOK, fmt.Sprintf("k8s context '%s', server %s", lisstoCtx.KubeContext, version), "")
+		}
+	}
+	if err != nil {
+		add("Kubernetes reachable", cmdutil.CheckFail, err.Error(), "Check your cluster credentials and network/VPN access")
+	}
+
+	// 2. API reachable, without the saved ID so a mismatch is reported below
+	probeCtx := *lisstoCtx
+	probeCtx.APIID = ""
+	health := client.ProbeContext(ctx, &probeCtx, doctorTimeout)
+	if !health.Reachable {
+		fix := "Check that the lissto-api pods are running: kubectl get pods -n " + lisstoCtx.ServiceNamespace
+		if health.Mode != client.ModePortForward {
+			fix = "Check that " + health.URL + " is reachable, or run 'lissto login' again to re-discover the API"
+		}
+		add("Lissto API", cmdutil.CheckFail, health.Error, fix)
+		return skipRest()
+	}
+	detail := fmt.Sprintf("%s via %s (%dms)", health.Mode, health.URL, health.LatencyMS)
+	if health.Version != "" {
+		detail = health.Version + ", " + detail
+	}
+	add("Lissto API", cmdutil.CheckOK, detail, "")
+
+	// 3. API instance ID
+	if !checks.CheckAPIID(lisstoCtx.APIID, health.APIID) {
+		return skipRest()
+	}
+
+	// 4. API key, or browser login token
+	fix := "Ask an admin for a new key and run 'lissto login'"
+	if lisstoCtx.AccessToken != "" {
+		fix = "Run 'lissto login --web' again"
+	}
+	apiClient, err := client.NewClientForContext(ctx, lisstoCtx)
+	if err != nil {
+		add("Authentication", cmdutil.CheckFail, err.Error(), fix)
+		return skipRest()
+	}
+	defer client.CloseTunnels()
+	if !checks.CheckUser(ctx, apiClient, "Authentication", fix) {
+		return skipRest()
+	}
+
+	// 5. Environments
+	envs, err := apiClient.ListEnvs(ctx)
+	if err != nil {
+		add("Environments", cmdutil.CheckFail, err.Error(), "Check the API logs")
+		return checks.Checks
+	}
+	add("Environments", cmdutil.CheckOK, fmt.Sprintf("%d env(s)", len(envs)), "")
+
+	return checks.Checks
+}
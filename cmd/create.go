@@ -1,13 +1,25 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/compose"
 	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/hooks"
 	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
 	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/registry"
+	"github.com/lissto-dev/cli/pkg/spinner"
+	"github.com/lissto-dev/cli/pkg/types"
 	controllerconfig "github.com/lissto-dev/controller/pkg/config"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +31,20 @@ var (
 	createCommit         string
 	createEnv            string
 	createNonInteractive bool
+	createProvenance     bool
+	createParams         []string
+	createQuiet          bool
+	createWait           bool
+	createTimeout        time.Duration
+	createNotify         string
+	createProfiles       []string
+	createFile           string
+	createSetImages      []string
+	createTTL            time.Duration
+	createLabels         []string
+
+	// createImageOverrides replaces resolved images of individual services
+	createImageOverrides map[string]cmdutil.ImageOverride
 )
 
 // createCmd represents the unified create command (parent)
@@ -43,7 +69,10 @@ Examples:
   lissto create stack --blueprint my-blueprint
 
   # Explicit blueprint creation
-  lissto create blueprint`,
+  lissto create blueprint
+
+  # Deploy a stack described in a file
+  lissto create -f stack.yaml`,
 	RunE: runCreateRouter,
 }
 
@@ -74,8 +103,65 @@ Examples:
   lissto create stack --blueprint my-blueprint --tag v1.2.3
   lissto create stack --blueprint my-blueprint --commit abc123
 
-  # Output in different formats
-  lissto create stack --blueprint my-blueprint --output json`,
+  # Pass blueprint parameters declared under x-lissto.params. Undeclared ones
+  # are rejected; current Lissto APIs don't apply the values yet
+  lissto create stack --blueprint my-blueprint --param hostname-prefix=feature-x
+
+  # Take one service's image from another branch, or pin an image
+  lissto create stack --blueprint my-blueprint --branch main --set-image api=branch:feature-x
+  lissto create stack --blueprint my-blueprint --set-image worker=ghcr.io/org/worker:v2
+
+  # Include image build time and commit in the preview
+  lissto create stack --blueprint my-blueprint --provenance
+
+  # A preview stack that 'lissto gc' may delete after two days
+  lissto create stack --blueprint my-blueprint --ttl 48h
+
+  # Label the stack so 'lissto status -l team=payments' finds it. Labels and
+  # the --ttl expiry are set on the Stack resource, so they need access to
+  # the cluster
+  lissto create stack --blueprint my-blueprint --label team=payments --label feature=checkout
+
+  # Wait until every service is ready (fails after the timeout)
+  lissto create stack --blueprint my-blueprint --wait --timeout 5m
+
+  # Get a desktop notification once the stack is ready
+  lissto create stack --blueprint my-blueprint --wait --notify
+
+  # CI: print only the stack ID
+  STACK=$(lissto create stack --blueprint my-blueprint --env ci --quiet)
+
+  # CI: print the result (id, env, blueprint, URLs) as JSON
+  lissto create stack --blueprint my-blueprint --env ci -o json
+
+  # Deploy from a stack file (flags override the file)
+  lissto create -f stack.yaml
+  lissto create stack -f stack.yaml --branch hotfix
+
+A stack file declares the blueprint, env, branch/tag/commit, params, image
+overrides and variables of a deployment so it can be stored in git:
+
+  version: v1
+  blueprint: my-blueprint
+  env: staging
+  branch: main
+  params:
+    hostname-prefix: demo
+  images:                    # same refs as --set-image
+    api: branch:feature-x
+    worker: ghcr.io/org/worker:v2
+  variables:
+    LOG_LEVEL: debug
+
+Generate one from a running stack with 'lissto stack export'. Variables are
+merged into the env's variables before the stack is created.
+
+With --quiet, -o id, -o json or -o yaml the command runs non-interactively,
+writes only the result to stdout and progress to stderr. -o json and -o yaml
+print the stack's id, env, blueprint and URLs next to the image preview
+(request_id, images, exposed) they printed in earlier releases. It exits
+with code 2 when some services have missing images and 3 when --wait times
+out.`,
 	RunE: runCreateStack,
 }
 
@@ -111,10 +197,29 @@ func init() {
 	createStackCmd.Flags().StringVar(&createCommit, "commit", "", "Git commit hash to use for image resolution")
 	createStackCmd.Flags().StringVar(&createEnv, "env", "", "Environment to deploy to")
 	createStackCmd.Flags().BoolVar(&createNonInteractive, "non-interactive", false, "Run in non-interactive mode (fail if required info is missing)")
+	createStackCmd.Flags().StringArrayVar(&createParams, "param", nil, "Blueprint parameter as key=value (repeatable)")
+	createStackCmd.Flags().BoolVarP(&createQuiet, "quiet", "q", false, "Print only the created stack ID (implies --non-interactive)")
+	createStackCmd.Flags().BoolVar(&createWait, "wait", false, "Wait until all services are ready")
+	createStackCmd.Flags().DurationVar(&createTimeout, "timeout", defaultWaitTimeout, "Maximum time to wait with --wait")
+	addNotifyFlag(createStackCmd, &createNotify)
+	createStackCmd.Flags().StringSliceVar(&createProfiles, "profile", nil, "Compose profile to include when creating a blueprint (repeatable)")
+	createStackCmd.Flags().BoolVar(&createProvenance, "provenance", false, "Show image build time and git commit from registry labels in the preview")
+	createStackCmd.Flags().StringVarP(&createFile, "file", "f", "", "Stack file describing the deployment")
+	createStackCmd.Flags().DurationVar(&createTTL, "ttl", 0, "Mark the stack for deletion by 'lissto gc' after this long, e.g. 48h")
+	createStackCmd.Flags().StringArrayVar(&createLabels, "label", nil, "Label the stack as key=value, for filtering with -l (repeatable)")
+	createStackCmd.Flags().StringArrayVar(&createSetImages, "set-image", nil, "Override a service's image as service=ref; ref is branch:<name>, tag:<name>, commit:<sha> or an image reference (repeatable)")
+	createCmd.Flags().StringVarP(&createFile, "file", "f", "", "Stack file describing the deployment (implies 'create stack')")
 }
 
 // runCreateRouter is the smart router for bare 'lissto create' command
 func runCreateRouter(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// A stack file always describes a stack deployment
+	if createFile != "" {
+		return runCreateStack(cmd, args)
+	}
+
 	// Load config
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -122,21 +227,21 @@ func runCreateRouter(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get current context
-	ctx, err := cfg.GetCurrentContext()
+	lisstoCtx, err := cfg.GetCurrentContext()
 	if err != nil {
-		return fmt.Errorf("no active context. Run 'lissto login' first: %w", err)
+		return messages.Wrap(messages.NoActiveContext, nil, err)
 	}
 
 	// Create API client
 	fmt.Println("🔌 Connecting to Lissto API...")
-	apiClient, err := client.NewClientFromConfig(ctx)
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
 	if err != nil {
 		return fmt.Errorf("failed to initialize API client: %w", err)
 	}
 
 	// List blueprints to determine routing
 	fmt.Println("🔍 Checking for existing blueprints...")
-	blueprints, err := apiClient.ListBlueprints(true)
+	blueprints, err := apiClient.ListBlueprints(ctx, true)
 	if err != nil {
 		return fmt.Errorf("failed to list blueprints: %w", err)
 	}
@@ -165,6 +270,8 @@ func runCreateRouter(cmd *cobra.Command, args []string) error {
 
 // runCreateBlueprintWizard handles the blueprint creation wizard flow
 func runCreateBlueprintWizard(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Load config
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -172,13 +279,13 @@ func runCreateBlueprintWizard(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get current context
-	ctx, err := cfg.GetCurrentContext()
+	lisstoCtx, err := cfg.GetCurrentContext()
 	if err != nil {
-		return fmt.Errorf("no active context. Run 'lissto login' first: %w", err)
+		return messages.Wrap(messages.NoActiveContext, nil, err)
 	}
 
 	// Create API client
-	apiClient, err := client.NewClientFromConfig(ctx)
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
 	if err != nil {
 		return fmt.Errorf("failed to initialize API client: %w", err)
 	}
@@ -207,22 +314,76 @@ func runCreateBlueprintWizard(cmd *cobra.Command, args []string) error {
 }
 
 func runCreateStack(cmd *cobra.Command, args []string) error {
+	result, err := createStack(cmd)
+	if err != nil {
+		return err
+	}
+	return printCreateResult(result)
+}
+
+// createStack runs the stack creation flow configured by the create flags.
+// The result is nil when nothing was created.
+func createStack(cmd *cobra.Command) (*cmdutil.CreateResult, error) {
+	ctx := cmd.Context()
+
+	// In machine-readable modes only the result goes to stdout; progress,
+	// previews and diagnostics go to stderr
+	progress := io.Writer(os.Stdout)
+	if isMachineCreateOutput() {
+		progress = os.Stderr
+		createNonInteractive = true
+	}
+	if createNonInteractive {
+		spinner.SetAnimated(false)
+	}
+
+	// Apply the stack file before anything reads the create flags
+	var stackVariables, imageRefs map[string]string
+	if createFile != "" {
+		spec, err := applyStackFile(cmd, createFile)
+		if err != nil {
+			return nil, err
+		}
+		stackVariables = spec.Variables
+		imageRefs = spec.Images
+	}
+
+	imageRefs, err := cmdutil.ParseSetImages(createSetImages, imageRefs)
+	if err != nil {
+		return nil, err
+	}
+	if createTTL < 0 {
+		return nil, fmt.Errorf("--ttl must be positive")
+	}
+	if _, err := cmdutil.ParseLabels(createLabels); err != nil {
+		return nil, err
+	}
+	if err := validateNotifyFlag(createNotify, createWait); err != nil {
+		return nil, err
+	}
+
 	// Load config
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Get current context
-	ctx, err := cfg.GetCurrentContext()
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return nil, messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	// Load project hooks from .lissto.yaml (if any)
+	projectCfg, err := hooks.Load()
 	if err != nil {
-		return fmt.Errorf("no active context. Run 'lissto login' first: %w", err)
+		return nil, fmt.Errorf("failed to load project config: %w", err)
 	}
 
 	// Create API client with k8s discovery and validation
-	apiClient, err := client.NewClientFromConfig(ctx)
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
 	if err != nil {
-		return fmt.Errorf("failed to initialize API client: %w", err)
+		return nil, fmt.Errorf("failed to initialize API client: %w", err)
 	}
 
 	// Track if blueprint was selected interactively (to show/hide Back button)
@@ -237,99 +398,100 @@ func runCreateStack(cmd *cobra.Command, args []string) error {
 
 	if envToUse == "" {
 		// Try to get existing envs
-		envs, err := apiClient.ListEnvs()
+		envs, err := apiClient.ListEnvs(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to list environments: %w", err)
+			return nil, fmt.Errorf("failed to list environments: %w", err)
 		}
 
 		if len(envs) > 0 {
 			if createNonInteractive {
 				// Use first env in non-interactive mode
 				envToUse = envs[0].Name
-				fmt.Printf("Using environment: %s\n", envToUse)
+				fmt.Fprintf(progress, "Using environment: %s\n", envToUse)
 			} else {
 				// Interactive env selection
 				selectedEnv, err := interactive.SelectEnv(envs)
 				if err != nil {
-					return fmt.Errorf("environment selection cancelled: %w", err)
+					return nil, fmt.Errorf("environment selection cancelled: %w", err)
 				}
 				envToUse = selectedEnv.Name
 			}
 		} else {
 			// No envs exist, create default
-			user, err := apiClient.GetCurrentUser()
+			user, err := apiClient.GetCurrentUser(ctx)
 			if err != nil {
-				return fmt.Errorf("failed to get current user: %w", err)
+				return nil, fmt.Errorf("failed to get current user: %w", err)
 			}
 
 			envToUse = user.Name
-			fmt.Printf("Creating default environment: %s\n", envToUse)
-			_, err = apiClient.CreateEnv(envToUse)
+			fmt.Fprintf(progress, "Creating default environment: %s\n", envToUse)
+			_, err = apiClient.CreateEnv(ctx, envToUse)
 			if err != nil {
-				return fmt.Errorf("failed to create environment: %w", err)
+				return nil, fmt.Errorf("failed to create environment: %w", err)
 			}
 		}
 	}
 
 	// Step 2: Blueprint selection loop (allows going back from preview)
 	var selectedBlueprint *client.BlueprintResponse
+	var result *cmdutil.CreateResult
 blueprintLoop:
 	for {
 		if createBlueprint != "" {
 			// Blueprint provided via flag, skip selection
-			fmt.Printf("Using blueprint: %s\n", createBlueprint)
-			bp, err := apiClient.GetBlueprint(createBlueprint)
+			fmt.Fprintf(progress, "Using blueprint: %s\n", createBlueprint)
+			bp, err := apiClient.GetBlueprint(ctx, createBlueprint)
 			if err != nil {
-				return fmt.Errorf("failed to get blueprint: %w", err)
+				return nil, fmt.Errorf("failed to get blueprint: %w", err)
 			}
 			selectedBlueprint = bp
 		} else {
 			// Interactive blueprint selection
 			if createNonInteractive {
-				return fmt.Errorf("--blueprint is required in non-interactive mode")
+				return nil, fmt.Errorf("--blueprint is required in non-interactive mode")
 			}
 
-			fmt.Println("\nFetching blueprints...")
-			blueprints, err := apiClient.ListBlueprints(true) // Include global
+			fmt.Fprintln(progress, "\nFetching blueprints...")
+			blueprints, err := apiClient.ListBlueprints(ctx, true) // Include global
 			if err != nil {
-				return fmt.Errorf("failed to list blueprints: %w", err)
+				return nil, fmt.Errorf("failed to list blueprints: %w", err)
 			}
 
 			if len(blueprints) == 0 {
-				return fmt.Errorf("no blueprints available")
+				return nil, fmt.Errorf("no blueprints available")
 			}
 
 			selectedBlueprint, err = interactive.SelectBlueprint(blueprints)
 			if err != nil {
-				return fmt.Errorf("blueprint selection cancelled: %w", err)
+				return nil, fmt.Errorf("blueprint selection cancelled: %w", err)
 			}
 		}
 
 		// Validation: Check for duplicate stacks
 		// Step 1: Check for exact blueprint match in current environment
-		existingStacks, err := apiClient.ListStacks(envToUse)
+		existingStacks, err := apiClient.ListStacks(ctx, envToUse)
 		if err != nil {
-			return fmt.Errorf("failed to list existing stacks: %w", err)
+			return nil, fmt.Errorf("failed to list existing stacks: %w", err)
 		}
 
 		// Check for exact blueprint ID match
 		for _, stack := range existingStacks {
 			if stack.Spec.BlueprintReference == selectedBlueprint.ID {
-				fmt.Printf("\n❌ Error: Stack with this blueprint already exists: %s\n", stack.Name)
-				fmt.Printf("💡 Tip: Use 'lissto update' to update the stack with new images\n\n")
-				return fmt.Errorf("stack '%s' already deployed with blueprint '%s'", stack.Name, selectedBlueprint.ID)
+				fmt.Fprintf(progress, "\n❌ Error: Stack with this blueprint already exists: %s\n", stack.Name)
+				fmt.Fprintf(progress, "%s\n\n", messages.Get(messages.StackAlreadyExists, nil))
+				return nil, fmt.Errorf("stack '%s' already deployed with blueprint '%s'", stack.Name, selectedBlueprint.ID)
 			}
 		}
 
 		// Step 2: Check for same repository blueprint (if repository info available)
 		// Get detailed info to access repository annotation
-		selectedBlueprintDetailed, err := apiClient.GetBlueprintDetailed(selectedBlueprint.ID)
+		selectedBlueprintDetailed, err := apiClient.GetBlueprintDetailed(ctx, selectedBlueprint.ID)
 		if err == nil && selectedBlueprintDetailed.Metadata.Annotations["lissto.dev/repository"] != "" && !createNonInteractive {
 			selectedRepo := selectedBlueprintDetailed.Metadata.Annotations["lissto.dev/repository"]
 			// Repository is already normalized in the annotation
 			normalizedSelectedRepo := selectedRepo
 
-			fmt.Printf("🔍 Checking for existing stacks from repository: %s\n", normalizedSelectedRepo)
+			fmt.Fprintf(progress, "🔍 Checking for existing stacks from repository: %s\n", normalizedSelectedRepo)
 
 			// Check existing stacks for same repository
 			var matchingStacks []string
@@ -341,71 +503,93 @@ blueprintLoop:
 				}
 
 				// Get the blueprint detailed to check its repository
-				stackBlueprint, err := apiClient.GetBlueprintDetailed(stackBlueprintID)
+				stackBlueprint, err := apiClient.GetBlueprintDetailed(ctx, stackBlueprintID)
 				if err != nil {
 					// Skip if we can't get blueprint details
-					fmt.Printf("  ⚠️  Warning: Could not fetch blueprint %s: %v\n", stackBlueprintID, err)
+					fmt.Fprintf(progress, "  ⚠️  Warning: Could not fetch blueprint %s: %v\n", stackBlueprintID, err)
 					continue
 				}
 
 				// Extract repository from annotations
 				if repo, ok := stackBlueprint.Metadata.Annotations["lissto.dev/repository"]; ok && repo != "" {
 					normalizedStackRepo := controllerconfig.NormalizeRepositoryURL(repo)
-					fmt.Printf("  📦 Stack %s uses repository: %s\n", stack.Name, normalizedStackRepo)
+					fmt.Fprintf(progress, "  📦 Stack %s uses repository: %s\n", stack.Name, normalizedStackRepo)
 					if normalizedStackRepo == normalizedSelectedRepo {
 						matchingStacks = append(matchingStacks, stack.Name)
 					}
 				} else {
-					fmt.Printf("  ℹ️  Stack %s has no repository annotation (might be an old blueprint)\n", stack.Name)
+					fmt.Fprintf(progress, "  ℹ️  Stack %s has no repository annotation (might be an old blueprint)\n", stack.Name)
 				}
 			}
 
 			// If we found matching repositories, warn the user
 			if len(matchingStacks) > 0 {
-				fmt.Printf("\n⚠️  Warning: Found existing stack(s) from the same repository:\n")
+				fmt.Fprintf(progress, "\n⚠️  Warning: Found existing stack(s) from the same repository:\n")
 				for _, stackName := range matchingStacks {
-					fmt.Printf("  - %s\n", stackName)
+					fmt.Fprintf(progress, "  - %s\n", stackName)
 				}
-				fmt.Println()
+				fmt.Fprintln(progress)
 
 				action, err := interactive.ConfirmDuplicateRepoAction()
 				if err != nil {
-					return fmt.Errorf("cancelled: %w", err)
+					return nil, fmt.Errorf("cancelled: %w", err)
 				}
 
 				switch action {
 				case interactive.ActionUpdateExisting:
 					// Suggest using lissto update command
-					fmt.Println("\n💡 Please run 'lissto update' to update the existing stack")
-					return fmt.Errorf("use 'lissto update' to update existing stacks")
+					fmt.Fprintln(progress, "\n"+messages.Get(messages.UseUpdateForExisting, nil))
+					return nil, fmt.Errorf("use 'lissto update' to update existing stacks")
 				case interactive.ActionDeployAnyway:
-					fmt.Println("\n⚠️  Proceeding with deployment (risky)...")
+					fmt.Fprintln(progress, "\n⚠️  Proceeding with deployment (risky)...")
 					// Continue with create flow
 				case interactive.ActionCancel:
-					return fmt.Errorf("deployment cancelled by user")
+					return nil, fmt.Errorf("deployment cancelled by user")
 				}
 			}
 		}
 
+		// Resolve blueprint parameters (flags, defaults and prompts)
+		stackParams, err := resolveStackParams(ctx, apiClient, selectedBlueprint.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(stackParams) > 0 {
+			fmt.Fprintln(progress, "ℹ️  Blueprint parameters are checked and sent to the API, but current Lissto APIs don't apply them yet")
+		}
+
+		// Resolve per-service image overrides (--set-image, stack file images)
+		createImageOverrides = nil
+		if len(imageRefs) > 0 {
+			fmt.Fprintln(progress, "\nResolving image overrides...")
+			createImageOverrides, err = cmdutil.ResolveImageOverrides(ctx, apiClient, selectedBlueprint.ID, envToUse, stackParams, imageRefs)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// Step 3: Prepare and preview loop
 		var prepareResp *client.PrepareStackResponse
 		for {
 			// Prepare stack
-			fmt.Println("\nPreparing stack...")
+			fmt.Fprintln(progress)
+			spin := spinner.Start(progress, "Preparing stack")
 			var err error
-			prepareResp, err = apiClient.PrepareStack(
+			prepareResp, err = apiClient.PrepareStackWithParams(
+				ctx,
 				selectedBlueprint.ID,
 				envToUse,
 				createCommit,
 				createBranch,
 				createTag,
 				true, // detailed
+				stackParams,
 			)
 			if err != nil {
-				fmt.Printf("❌ Failed to prepare stack: %v\n", err)
+				spin.Fail(fmt.Sprintf("Failed to prepare stack: %v", err))
 
 				if createNonInteractive {
-					return fmt.Errorf("failed to prepare stack: %w", err)
+					return nil, fmt.Errorf("failed to prepare stack: %w", err)
 				}
 
 				// Ask what user wants to do
@@ -417,7 +601,7 @@ blueprintLoop:
 					action, retryErr = interactive.ConfirmRetry()
 				}
 				if retryErr != nil {
-					return fmt.Errorf("failed to prepare stack: %w", err)
+					return nil, fmt.Errorf("failed to prepare stack: %w", err)
 				}
 
 				switch action {
@@ -425,7 +609,7 @@ blueprintLoop:
 					// Get new branch/tag/commit
 					branch, tag, commit, promptErr := interactive.PromptBranchTag()
 					if promptErr != nil {
-						return fmt.Errorf("cancelled: %w", promptErr)
+						return nil, fmt.Errorf("cancelled: %w", promptErr)
 					}
 
 					// Update for next iteration
@@ -440,31 +624,29 @@ blueprintLoop:
 					createCommit = ""
 					continue blueprintLoop
 				case interactive.ActionCancel:
-					return fmt.Errorf("failed to prepare stack: %w", err)
+					return nil, fmt.Errorf("failed to prepare stack: %w", err)
 				}
 			}
+			spin.Success("Stack prepared")
 
-			// Display preview
-			format := outputFormat
-			if format == "" {
-				format = outputFormatTable
+			if err := cmdutil.ApplyImageOverrides(prepareResp.Images, createImageOverrides); err != nil {
+				return nil, err
 			}
 
-			if format == outputFormatTable {
-				output.PrintImagePreview(os.Stdout, prepareResp.Images, prepareResp.Exposed)
-			} else {
-				err = output.PrintImagePreviewWithFormat(format, prepareResp)
-				if err != nil {
-					return fmt.Errorf("failed to print preview: %w", err)
-				}
+			// Display preview
+			var provenance map[string]*registry.Provenance
+			if createProvenance {
+				provenance = collectProvenance(ctx, prepareResp.Images)
 			}
+			output.PrintImagePreviewWithProvenance(progress, prepareResp.Images, prepareResp.Exposed, provenance)
 
 			// Check for missing images
 			if output.HasMissingImages(prepareResp.Images) {
-				fmt.Println("❌ Cannot deploy: Some services have missing images.")
+				fmt.Fprintln(progress, "❌ Cannot deploy: Some services have missing images.")
+				output.PrintImageDiagnostics(progress, prepareResp.Images)
 
 				if createNonInteractive {
-					return fmt.Errorf("deployment blocked: missing images")
+					return nil, fmt.Errorf("deployment blocked: %w", client.ErrMissingImages)
 				}
 
 				// Ask what user wants to do
@@ -475,7 +657,7 @@ blueprintLoop:
 					action, err = interactive.ConfirmRetry()
 				}
 				if err != nil {
-					return fmt.Errorf("deployment cancelled: missing images")
+					return nil, fmt.Errorf("deployment cancelled: missing images")
 				}
 
 				switch action {
@@ -483,7 +665,7 @@ blueprintLoop:
 					// Get new branch/tag/commit
 					branch, tag, commit, err := interactive.PromptBranchTag()
 					if err != nil {
-						return fmt.Errorf("cancelled: %w", err)
+						return nil, fmt.Errorf("cancelled: %w", err)
 					}
 
 					// Update for next iteration
@@ -498,7 +680,7 @@ blueprintLoop:
 					createCommit = ""
 					continue blueprintLoop
 				case interactive.ActionCancel:
-					return fmt.Errorf("deployment cancelled: missing images")
+					return nil, fmt.Errorf("deployment cancelled: missing images")
 				}
 			}
 
@@ -516,7 +698,7 @@ blueprintLoop:
 				action, err = interactive.ConfirmDeployment()
 			}
 			if err != nil {
-				return fmt.Errorf("cancelled: %w", err)
+				return nil, fmt.Errorf("cancelled: %w", err)
 			}
 
 			switch action {
@@ -526,7 +708,7 @@ blueprintLoop:
 				// Get new branch/tag/commit
 				branch, tag, commit, err := interactive.PromptBranchTag()
 				if err != nil {
-					return fmt.Errorf("cancelled: %w", err)
+					return nil, fmt.Errorf("cancelled: %w", err)
 				}
 
 				// Update for next iteration
@@ -541,34 +723,235 @@ blueprintLoop:
 				createCommit = ""
 				continue blueprintLoop
 			case interactive.ActionCancel:
-				return fmt.Errorf("deployment cancelled by user")
+				return nil, fmt.Errorf("deployment cancelled by user")
 			}
 
 			// Break out of loop after successful confirmation
 			break
 		}
 
+		hookVars := hooks.Vars{
+			Context:   lisstoCtx.Name,
+			Env:       envToUse,
+			Blueprint: selectedBlueprint.ID,
+		}
+		if err := projectCfg.Run(hooks.PhasePre, "create", hookVars, progress); err != nil {
+			return nil, err
+		}
+
+		if err := cmdutil.ApplyEnvVariables(ctx, progress, apiClient, envToUse, stackVariables); err != nil {
+			return nil, err
+		}
+
 		// Step 5: Create stack
-		fmt.Println("\nCreating stack...")
-		stackID, err := apiClient.CreateStack(selectedBlueprint.ID, envToUse, prepareResp.RequestID)
+		fmt.Fprintln(progress)
+		spin := spinner.Start(progress, "Creating stack")
+		stackID, err := cmdutil.CreateStack(ctx, apiClient, selectedBlueprint.ID, envToUse, prepareResp.RequestID, createStackOptions(stackParams), createdImages(prepareResp.Images))
+		if client.IsRequestExpired(err) {
+			// The prepared request expired while the user was deciding; resolve again and retry
+			spin.Stop()
+			stackID, prepareResp, err = retryCreateWithFreshRequest(ctx, progress, apiClient, selectedBlueprint.ID, envToUse, stackParams, prepareResp)
+		}
 		if err != nil {
-			return fmt.Errorf("failed to create stack: %w", err)
+			spin.Fail("Failed to create stack")
+			return nil, fmt.Errorf("failed to create stack: %w", err)
+		}
+		spin.Stop()
+
+		if err := setCreatedStackMetadata(ctx, apiClient, envToUse, stackID); err != nil {
+			return nil, err
 		}
 
-		fmt.Printf("✅ Stack created successfully!\n")
-		fmt.Printf("Stack ID: %s\n", stackID)
+		fmt.Fprintf(progress, "✅ Stack created successfully!\n")
+		fmt.Fprintf(progress, "Stack ID: %s\n", stackID)
+		if createTTL > 0 {
+			fmt.Fprintf(progress, "⏳ Expires in %s\n", createTTL)
+		}
 
 		// Show exposed URLs if any
 		if len(prepareResp.Exposed) > 0 {
-			fmt.Println("\n🔗 Exposed services:")
+			fmt.Fprintln(progress, "\n🔗 Exposed services:")
 			for _, exp := range prepareResp.Exposed {
-				fmt.Printf("  - %s: https://%s\n", exp.Service, exp.URL)
+				fmt.Fprintf(progress, "  - %s: https://%s\n", exp.Service, exp.URL)
 			}
 		}
 
+		if createWait {
+			if err := waitForStackReady(ctx, progress, apiClient, stackID, envToUse, createTimeout, createNotify); err != nil {
+				return nil, err
+			}
+		}
+
+		hookVars.Stack = stackID
+		if err := projectCfg.Run(hooks.PhasePost, "create", hookVars, progress); err != nil {
+			return nil, err
+		}
+
+		result = cmdutil.NewCreateResult(stackID, envToUse, selectedBlueprint.ID, prepareResp)
+
 		// Successfully created stack, break out of blueprint loop
 		break blueprintLoop
 	}
 
+	return result, nil
+}
+
+// retryCreateWithFreshRequest re-runs PrepareStack with the same parameters
+// after a request ID expired, verifies the resolved images are unchanged (or
+// asks the user to accept the new ones) and retries the stack creation
+func retryCreateWithFreshRequest(ctx context.Context, progress io.Writer, apiClient *client.Client, blueprintID, env string, params map[string]string, previous *client.PrepareStackResponse) (string, *client.PrepareStackResponse, error) {
+	fmt.Fprintln(progress, "⏳ Prepared request expired, resolving images again...")
+
+	fresh, err := apiClient.PrepareStackWithParams(ctx, blueprintID, env, createCommit, createBranch, createTag, true, params)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := cmdutil.ApplyImageOverrides(fresh.Images, createImageOverrides); err != nil {
+		return "", nil, err
+	}
+
+	if output.HasMissingImages(fresh.Images) {
+		output.PrintImageDiagnostics(progress, fresh.Images)
+		return "", nil, fmt.Errorf("some services still have %w after re-resolving", client.ErrMissingImages)
+	}
+
+	if changed := changedServiceImages(previous.Images, fresh.Images); len(changed) > 0 {
+		fmt.Fprintf(progress, "⚠️  Images changed since the preview: %s\n", strings.Join(changed, ", "))
+		if createNonInteractive {
+			return "", nil, fmt.Errorf("images changed since preview, re-run to deploy the new images")
+		}
+
+		output.PrintImagePreview(progress, fresh.Images, fresh.Exposed)
+		confirmed, err := interactive.ConfirmAction("Deploy the updated images?", false)
+		if err != nil || !confirmed {
+			return "", nil, fmt.Errorf("deployment cancelled by user")
+		}
+	}
+
+	stackID, err := cmdutil.CreateStack(ctx, apiClient, blueprintID, env, fresh.RequestID, createStackOptions(params), createdImages(fresh.Images))
+	if err != nil {
+		return "", nil, err
+	}
+	return stackID, fresh, nil
+}
+
+// createdImages returns the images to apply to a new stack: all of them
+// when --set-image overrides some, otherwise none, as the API deploys the
+// prepared images
+func createdImages(images []client.DetailedImageResolutionInfo) map[string]interface{} {
+	if len(createImageOverrides) == 0 {
+		return nil
+	}
+	return cmdutil.StackImagesMap(images)
+}
+
+// setCreatedStackMetadata sets the --label labels and the --ttl expiry of a
+// new stack on its Stack resource, as the API doesn't take them on create
+func setCreatedStackMetadata(ctx context.Context, apiClient *client.Client, env, stackID string) error {
+	// Labels were validated when the command started
+	stackLabels, _ := cmdutil.ParseLabels(createLabels)
+	if len(stackLabels) == 0 && createTTL <= 0 {
+		return nil
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("stack %s created but its labels and expiry can't be set: failed to connect to Kubernetes: %w", stackID, err)
+	}
+	if len(stackLabels) > 0 {
+		if err := cmdutil.LabelStack(ctx, apiClient, k8sClient, env, stackID, stackLabels); err != nil {
+			return fmt.Errorf("stack %s created but its labels can't be set: %w", stackID, err)
+		}
+	}
+	if createTTL > 0 {
+		expiresAt := time.Now().Add(createTTL).UTC().Format(time.RFC3339)
+		if err := cmdutil.AnnotateStack(ctx, apiClient, k8sClient, env, stackID, map[string]string{types.AnnotationExpiresAt: expiresAt}); err != nil {
+			return fmt.Errorf("stack %s created but its expiry can't be set: %w", stackID, err)
+		}
+	}
 	return nil
 }
+
+// createStackOptions returns the options of a new stack: its parameters
+func createStackOptions(params map[string]string) client.CreateStackOptions {
+	return client.CreateStackOptions{Params: params}
+}
+
+// isMachineCreateOutput reports whether create should emit a machine-readable result
+func isMachineCreateOutput() bool {
+	return cmdutil.IsMachineCreateOutput(outputFormat, createQuiet)
+}
+
+// printCreateResult writes the result of a create in machine-readable modes
+func printCreateResult(result *cmdutil.CreateResult) error {
+	if result == nil || !isMachineCreateOutput() {
+		return nil
+	}
+	return cmdutil.PrintCreateResult(os.Stdout, outputFormat, result)
+}
+
+// changedServiceImages returns the services whose resolved digest differs
+func changedServiceImages(previous, current []client.DetailedImageResolutionInfo) []string {
+	digests := make(map[string]string, len(previous))
+	for _, img := range previous {
+		digests[img.Service] = img.Digest
+	}
+
+	var changed []string
+	for _, img := range current {
+		if digest, ok := digests[img.Service]; !ok || digest != img.Digest {
+			changed = append(changed, img.Service)
+		}
+	}
+	return changed
+}
+
+// resolveStackParams combines --param flags with the parameters declared in
+// the blueprint's x-lissto.params, prompting for undeclared values in
+// interactive mode
+func resolveStackParams(ctx context.Context, apiClient *client.Client, blueprintID string) (map[string]string, error) {
+	provided, err := cmdutil.ParseKeyValueArgs(createParams)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --param: %w", err)
+	}
+
+	detailed, err := apiClient.GetBlueprintDetailed(ctx, blueprintID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blueprint: %w", err)
+	}
+
+	declared, err := compose.ParseParams(detailed.Spec.DockerCompose)
+	if err != nil {
+		return nil, err
+	}
+	if undeclared := compose.UndeclaredParams(declared, provided); len(undeclared) > 0 {
+		names := make([]string, 0, len(declared))
+		for _, p := range declared {
+			names = append(names, p.Name)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("blueprint declares no parameters, got --param %s", strings.Join(undeclared, ", "))
+		}
+		return nil, fmt.Errorf("unknown blueprint parameters: %s (declared: %s)", strings.Join(undeclared, ", "), strings.Join(names, ", "))
+	}
+
+	if !createNonInteractive {
+		for _, p := range declared {
+			if _, ok := provided[p.Name]; ok {
+				continue
+			}
+			value, err := interactive.PromptParam(p.Name, p.Description, p.Default, p.Required)
+			if err != nil {
+				return nil, fmt.Errorf("cancelled: %w", err)
+			}
+			provided[p.Name] = value
+		}
+	}
+
+	resolved, missing := compose.ResolveParams(declared, provided)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required blueprint parameters: %s (use --param key=value)", strings.Join(missing, ", "))
+	}
+
+	return resolved, nil
+}
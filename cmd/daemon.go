@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Keep API connections warm for other commands",
+	Long: `Run a local daemon that keeps API discovery results and port-forwards
+open, until Ctrl+C or 'lissto daemon stop'.
+
+Without a public API URL, every command spends a few seconds discovering the
+API and opening a port-forward to it. While the daemon runs, commands send
+their requests through it instead (over a unix socket in the cache
+directory) and fall back to connecting directly when it isn't running.
+Set ` + client.DaemonEnv + `=0 to bypass it for a command.
+
+Examples:
+  # Start the daemon in the background
+  lissto daemon &
+
+  # Which APIs is it connected to?
+  lissto daemon status
+
+  # Stop it
+  lissto daemon stop`,
+	Args:          cobra.NoArgs,
+	RunE:          runDaemon,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the daemon runs and its connections",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStatus,
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running daemon",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStop,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.AddCommand(daemonStatusCmd)
+	daemonCmd.AddCommand(daemonStopCmd)
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	socket, err := client.DaemonSocketPath()
+	if err != nil {
+		return fmt.Errorf("failed to locate daemon socket: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "🔌 Serving API connections on %s\n", socket)
+	fmt.Fprintln(os.Stderr, "Press Ctrl+C to stop.")
+	err = client.NewDaemon(nil).Serve(cmd.Context(), socket)
+	if errors.Is(err, client.ErrDaemonRunning) {
+		return fmt.Errorf("%w on %s, see 'lissto daemon status'", err, socket)
+	}
+	return err
+}
+
+func runDaemonStatus(cmd *cobra.Command, args []string) error {
+	socket, err := client.DaemonSocketPath()
+	if err != nil {
+		return fmt.Errorf("failed to locate daemon socket: %w", err)
+	}
+	status, err := client.FetchDaemonStatus(cmd.Context(), socket)
+	if err != nil {
+		if errors.Is(err, client.ErrDaemonNotRunning) {
+			return client.ErrDaemonNotRunning
+		}
+		return err
+	}
+
+	switch outputFormat {
+	case outputFormatJSON:
+		return output.PrintJSON(os.Stdout, status)
+	case outputFormatYAML:
+		return output.PrintYAML(os.Stdout, status)
+	}
+
+	fmt.Printf("Daemon running (pid %d) for %s on %s\n", status.PID, time.Since(status.Started).Round(time.Second), socket)
+	if len(status.APIs) == 0 {
+		fmt.Println("No API connections yet.")
+		return nil
+	}
+	headers := []string{"KUBE CONTEXT", "SERVICE", "NAMESPACE", "API ID", "CONNECTION"}
+	rows := make([][]string, 0, len(status.APIs))
+	for _, api := range status.APIs {
+		connection := "port-forward"
+		if !api.Tunnel {
+			connection = api.PublicURL
+		}
+		rows = append(rows, []string{api.KubeContext, api.Service, api.Namespace, api.APIID, connection})
+	}
+	output.PrintTable(os.Stdout, headers, rows)
+	return nil
+}
+
+func runDaemonStop(cmd *cobra.Command, args []string) error {
+	socket, err := client.DaemonSocketPath()
+	if err != nil {
+		return fmt.Errorf("failed to locate daemon socket: %w", err)
+	}
+	if err := client.StopDaemon(cmd.Context(), socket); err != nil {
+		if errors.Is(err, client.ErrDaemonNotRunning) {
+			return client.ErrDaemonNotRunning
+		}
+		return err
+	}
+	fmt.Println("✅ Daemon stopped")
+	return nil
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/dashboard"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var dashboardInterval time.Duration
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Interactive terminal dashboard for your stacks",
+	Long: `Open an interactive terminal UI combining status, logs and actions.
+
+The left pane lists environments and their stacks, the right pane shows the
+selected stack's services, pods and traffic readiness, refreshed live.
+
+Keys:
+  ↑/↓ or k/j   Select a stack
+  l            Stream the stack's logs (Ctrl+C returns to the dashboard)
+  u            Update the stack's images
+  r            Restart the stack's pods
+  d            Delete the stack
+  ctrl+r       Refresh now
+  q            Quit
+
+Examples:
+  # Open the dashboard for all environments
+  lissto dashboard
+
+  # Only show one environment, refreshing every 10 seconds
+  lissto dashboard --env staging --interval 10s`,
+	RunE:          runDashboard,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(dashboardCmd)
+	dashboardCmd.Flags().DurationVar(&dashboardInterval, "interval", dashboard.DefaultInterval, "Refresh interval")
+}
+
+func runDashboard(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if !term.IsTerminal(int(os.Stdout.Fd())) || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("dashboard requires an interactive terminal; use 'lissto status' instead")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	// Pod details and restarts need cluster access; the rest works without it
+	k8sClient, _ := k8s.NewClient()
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate lissto executable: %w", err)
+	}
+
+	infra := cachedInfraLookup(blueprintInfraLookup(apiClient))
+	actions := dashboard.Actions{
+		Load: func(ctx context.Context) (*status.Report, error) {
+			stacks, err := apiClient.ListStacks(ctx, envName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list stacks: %w", err)
+			}
+			return status.BuildReport(ctx, k8sClient, stacks, infra), nil
+		},
+		Delete: func(ctx context.Context, target dashboard.Target) error {
+			return apiClient.DeleteStack(ctx, target.Stack.Name, target.Env)
+		},
+		Logs: func(target dashboard.Target) *exec.Cmd {
+			return exec.Command(executable, "logs", "--stack", target.Stack.Name, "--env", target.Env, "--follow")
+		},
+		Update: func(target dashboard.Target) *exec.Cmd {
+			return exec.Command(executable, "update", "--stack", target.Stack.Name, "--env", target.Env)
+		},
+	}
+	if k8sClient != nil {
+		actions.Restart = func(ctx context.Context, target dashboard.Target) error {
+			return restartStackPods(ctx, k8sClient, target.Stack)
+		}
+	}
+
+	return dashboard.Run(ctx, actions, dashboardInterval)
+}
+
+// restartStackPods deletes the long-running pods of a stack so their
+// controllers recreate them; completed job pods are left alone
+func restartStackPods(ctx context.Context, k8sClient *k8s.Client, stack status.StackReport) error {
+	pods, err := k8sClient.ListPods(ctx, stack.Namespace, status.StackPodLabels(stack.Name))
+	if err != nil {
+		return err
+	}
+
+	for i := range pods {
+		if status.IsJobPod(&pods[i]) {
+			continue
+		}
+		if err := k8sClient.DeletePod(ctx, pods[i].Namespace, pods[i].Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cachedInfraLookup memoizes blueprint lookups; blueprints are immutable, so
+// refreshes only need to fetch new ones
+func cachedInfraLookup(lookup status.InfraLookup) status.InfraLookup {
+	var mu sync.Mutex
+	cache := make(map[string][]string)
+
+	return func(ctx context.Context, blueprintRef string) []string {
+		mu.Lock()
+		infra, ok := cache[blueprintRef]
+		mu.Unlock()
+		if ok {
+			return infra
+		}
+
+		infra = lookup(ctx, blueprintRef)
+		mu.Lock()
+		cache[blueprintRef] = infra
+		mu.Unlock()
+		return infra
+	}
+}
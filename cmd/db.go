@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/database"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbStack   string
+	dbService string
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Work with the databases of a stack",
+}
+
+var dbConnectCmd = &cobra.Command{
+	Use:   "connect",
+	Short: "Open a database console on a stack's postgres, mysql or redis",
+	Long: `Open psql, mysql or redis-cli on a database service of a stack.
+
+The database type and credentials are read from the stack's blueprint:
+variables like ${DB_PASSWORD} are resolved from the secrets and variables
+that apply to the stack's environment (revealing a secret is audited). The
+service is port-forwarded to a free local port and the client started with
+the connection pre-filled; the forward is closed when the client exits.
+
+The client must be installed locally.
+
+Examples:
+  # Pick the database service interactively
+  lissto db connect --stack my-stack
+
+  # Connect to a specific service
+  lissto db connect --stack my-stack --service postgres`,
+	Args:          cobra.NoArgs,
+	RunE:          runDBConnect,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbConnectCmd)
+	dbConnectCmd.Flags().StringVar(&dbStack, "stack", "", "Stack name")
+	dbConnectCmd.Flags().StringVar(&dbService, "service", "", "Database service name")
+}
+
+func runDBConnect(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	stack, err := resolveStack(ctx, apiClient, dbStack)
+	if err != nil {
+		return err
+	}
+
+	blueprint, err := apiClient.GetBlueprintDetailed(ctx, stack.Spec.BlueprintReference)
+	if err != nil {
+		return fmt.Errorf("failed to get blueprint: %w", err)
+	}
+	db, err := resolveDatabase(stack, blueprint.Spec.DockerCompose, dbService)
+	if err != nil {
+		return err
+	}
+	if _, err := exec.LookPath(db.Client()); err != nil {
+		return fmt.Errorf("%s not found in PATH: install it to connect to %s", db.Client(), db.Kind)
+	}
+
+	if vars := db.Variables(); len(vars) > 0 {
+		repository := blueprint.Metadata.Annotations["lissto.dev/repository"]
+		values, err := resolveConfigValues(ctx, apiClient, vars, stack.Spec.Env, repository)
+		if err != nil {
+			return err
+		}
+		var missing []string
+		*db, missing = db.Resolve(values)
+		for _, name := range missing {
+			fmt.Fprintf(os.Stderr, "⚠️  No value for ${%s}, %s may ask for it\n", name, db.Client())
+		}
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+	pod, err := resolveServicePod(ctx, k8sClient, stack, db.Service)
+	if err != nil {
+		return err
+	}
+
+	localPort, stop, err := k8sClient.PortForwardPod(ctx, stack.Namespace, pod.Name, 0, db.Port)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	console := db.Command(localPort)
+	fmt.Fprintf(os.Stderr, "🔗 Forwarding localhost:%d -> %s/%s:%d\n", localPort, db.Service, pod.Name, db.Port)
+	fmt.Fprintf(os.Stderr, "🗄️  %s %s\n", console.Name, strings.Join(console.Args, " "))
+
+	// The client handles Ctrl+C itself; it must not stop lissto and the forward
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	proc := exec.Command(console.Name, console.Args...)
+	proc.Env = append(os.Environ(), console.Env...)
+	proc.Stdin, proc.Stdout, proc.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := proc.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &exitError{code: exitErr.ExitCode(), err: fmt.Errorf("%s exited with code %d", console.Name, exitErr.ExitCode())}
+		}
+		return fmt.Errorf("failed to run %s: %w", console.Name, err)
+	}
+	return nil
+}
+
+// resolveDatabase picks the database service of a stack's compose content,
+// asking when there are several
+func resolveDatabase(stack *types.Stack, compose, service string) (*database.Database, error) {
+	dbs, err := database.Detect(compose)
+	if err != nil {
+		return nil, err
+	}
+
+	if service != "" {
+		for i := range dbs {
+			if dbs[i].Service == service {
+				return &dbs[i], nil
+			}
+		}
+		return nil, fmt.Errorf("service '%s' of stack '%s' is not a postgres, mysql or redis database", service, stack.Name)
+	}
+
+	switch len(dbs) {
+	case 0:
+		return nil, fmt.Errorf("stack '%s' has no postgres, mysql or redis service", stack.Name)
+	case 1:
+		return &dbs[0], nil
+	}
+
+	options := make([]string, len(dbs))
+	for i, db := range dbs {
+		options[i] = fmt.Sprintf("%s (%s)", db.Service, db.Kind)
+	}
+	idx, err := interactive.SelectOption("Choose a database:", options)
+	if err != nil {
+		return nil, fmt.Errorf("database selection cancelled: %w", err)
+	}
+	return &dbs[idx], nil
+}
+
+// resolveConfigValues looks up names in the variables and secrets that
+// apply to env and repository. Only secrets holding one of the names are
+// revealed; secrets take precedence over variables.
+func resolveConfigValues(ctx context.Context, apiClient *client.Client, names []string, env, repository string) (map[string]string, error) {
+	variables, err := apiClient.ListVariables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variables: %w", err)
+	}
+	varConfigs := make([]cmdutil.ScopedConfig, 0, len(variables))
+	for _, v := range variables {
+		varConfigs = append(varConfigs, cmdutil.ScopedConfig{Name: v.Name, Scope: v.Scope, Env: v.Env, Repository: v.Repository, Data: v.Data})
+	}
+
+	secrets, err := apiClient.ListSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	var secretConfigs []cmdutil.ScopedConfig
+	for _, s := range secrets {
+		scoped := cmdutil.ScopedConfig{Name: s.Name, Scope: s.Scope, Env: s.Env, Repository: s.Repository}
+		if !scoped.AppliesTo(env, repository) || !slices.ContainsFunc(s.Keys, func(k string) bool { return slices.Contains(names, k) }) {
+			continue
+		}
+		revealed, err := apiClient.RevealSecret(ctx, s.Name, s.Scope, s.Env, s.Repository, "")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Cannot read secret '%s': %v\n", s.Name, err)
+			continue
+		}
+		scoped.Data = revealed.Values
+		secretConfigs = append(secretConfigs, scoped)
+	}
+
+	values := make(map[string]string)
+	for _, merged := range []map[string]cmdutil.ScopedValue{
+		cmdutil.MergeScopes(varConfigs, env, repository),
+		cmdutil.MergeScopes(secretConfigs, env, repository),
+	} {
+		for _, name := range names {
+			if v, ok := merged[name]; ok {
+				values[name] = v.Value
+			}
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/lissto-dev/cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteStacks []string
+	deleteYes    bool
+)
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete stacks (interactive)",
+	Long: `Interactively select and delete one or more stacks.
+
+Stacks from all environments are listed grouped by environment (use --env to
+limit to one). Before deleting, the pods, ingresses and URLs that will be
+removed are shown and a confirmation is required.
+
+Examples:
+  # Pick stacks to delete interactively
+  lissto delete
+
+  # Only show stacks from one environment
+  lissto delete --env staging
+
+  # Delete specific stacks without prompting
+  lissto delete --stack my-stack --stack other-stack --yes`,
+	RunE:          runDelete,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(deleteCmd)
+	deleteCmd.Flags().StringArrayVar(&deleteStacks, "stack", nil, "Stack name to delete (repeatable)")
+	deleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Skip confirmation prompt")
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	stacks, err := apiClient.ListStacks(ctx, envName)
+	if err != nil {
+		return fmt.Errorf("failed to list stacks: %w", err)
+	}
+
+	if len(stacks) == 0 {
+		fmt.Println("No stacks found.")
+		return nil
+	}
+
+	// Order stacks by env, then newest first, so the prompt reads like 'lissto status'
+	sort.Slice(stacks, func(i, j int) bool {
+		if stacks[i].Spec.Env != stacks[j].Spec.Env {
+			return stacks[i].Spec.Env < stacks[j].Spec.Env
+		}
+		return stacks[i].CreationTimestamp.After(stacks[j].CreationTimestamp.Time)
+	})
+
+	selected, err := selectStacksToDelete(stacks)
+	if err != nil {
+		return err
+	}
+
+	// Show what will be removed
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Kubernetes access unavailable - pod details not shown\n")
+		k8sClient = nil
+	}
+
+	fmt.Println("\n🗑️  The following will be deleted:")
+	for i := range selected {
+		printDeletionPreview(ctx, k8sClient, &selected[i])
+	}
+	fmt.Println()
+
+	if !deleteYes {
+		confirmed, err := interactive.ConfirmAction(fmt.Sprintf("Delete %d stack(s)?", len(selected)), false)
+		if err != nil || !confirmed {
+			return fmt.Errorf("deletion cancelled")
+		}
+	}
+
+	var failed int
+	for _, stack := range selected {
+		if err := apiClient.DeleteStack(ctx, stack.Name, stack.Spec.Env); err != nil {
+			fmt.Printf("❌ %s: %v\n", stack.Name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ Deleted stack: %s (env: %s)\n", stack.Name, stack.Spec.Env)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d stack(s)", failed)
+	}
+	return nil
+}
+
+// selectStacksToDelete resolves --stack flags or prompts for a multi-selection
+func selectStacksToDelete(stacks []types.Stack) ([]types.Stack, error) {
+	if len(deleteStacks) > 0 {
+		byName := make(map[string]types.Stack, len(stacks))
+		for _, s := range stacks {
+			byName[s.Name] = s
+		}
+
+		var selected []types.Stack
+		for _, name := range deleteStacks {
+			s, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("stack '%s' %w", name, client.ErrNotFound)
+			}
+			selected = append(selected, s)
+		}
+		return selected, nil
+	}
+
+	if deleteYes {
+		return nil, fmt.Errorf("--stack is required with --yes")
+	}
+
+	envs := make([]string, len(stacks))
+	titles := make([]string, len(stacks))
+	ages := make([]string, len(stacks))
+	for i := range stacks {
+		envs[i] = "env: " + stacks[i].Spec.Env
+		titles[i] = types.GetStackDisplayName(&stacks[i])
+		ages[i] = k8s.FormatAge(time.Since(stacks[i].CreationTimestamp.Time))
+	}
+
+	indices, err := interactive.SelectMultiple("Choose stacks to delete:", interactive.FormatAlignedColumns(envs, titles, ages))
+	if err != nil {
+		return nil, fmt.Errorf("stack selection cancelled: %w", err)
+	}
+
+	selected := make([]types.Stack, 0, len(indices))
+	for _, i := range indices {
+		selected = append(selected, stacks[i])
+	}
+	return selected, nil
+}
+
+// printDeletionPreview lists the pods, ingresses and URLs owned by a stack
+func printDeletionPreview(ctx context.Context, k8sClient *k8s.Client, stack *types.Stack) {
+	fmt.Printf("\n  Stack: %s (env: %s)\n", types.GetStackDisplayName(stack), stack.Spec.Env)
+
+	for _, svc := range status.ParseServiceStatuses(stack) {
+		if svc.URL != "" {
+			fmt.Printf("    🔗 https://%s\n", svc.URL)
+		}
+	}
+
+	if k8sClient == nil {
+		return
+	}
+
+	labels := map[string]string{"lissto.dev/stack": stack.Name}
+
+	if pods, err := k8sClient.ListPods(ctx, stack.Namespace, labels); err == nil {
+		for _, pod := range pods {
+			fmt.Printf("    📦 pod/%s\n", pod.Name)
+		}
+	}
+
+	if ingresses, err := k8sClient.ListIngresses(ctx, stack.Namespace, labels); err == nil {
+		for _, ing := range ingresses {
+			fmt.Printf("    🌐 ingress/%s\n", ing.Name)
+		}
+	}
+}
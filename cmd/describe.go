@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/lissto-dev/cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var describeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Show detailed information about a resource",
+	Long:  `Show detailed information about a Lissto resource.`,
+}
+
+var describeStackCmd = &cobra.Command{
+	Use:   "stack [name]",
+	Short: "Show detailed information about a stack",
+	Long: `Show a detailed view of a single stack: blueprint, image digests,
+conditions timeline, URLs, attached variables and secrets, pods and recent events.
+
+Secret values are never shown, only their keys.
+
+Examples:
+  # Describe a stack (select interactively)
+  lissto describe stack
+
+  # Describe a specific stack
+  lissto describe stack my-stack
+
+  # Output as JSON
+  lissto describe stack my-stack -o json`,
+	Args:          cobra.MaximumNArgs(1),
+	RunE:          runDescribeStack,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	describeCmd.AddCommand(describeStackCmd)
+	rootCmd.AddCommand(describeCmd)
+}
+
+func runDescribeStack(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	stack, err := resolveStack(ctx, apiClient, name)
+	if err != nil {
+		return err
+	}
+
+	// Pod details and events are best-effort, like the status view
+	k8sClient, k8sErr := k8s.NewClient()
+	if k8sErr != nil {
+		k8sClient = nil
+	}
+
+	repository := ""
+	if detailed, err := apiClient.GetBlueprintDetailed(ctx, stack.Spec.BlueprintReference); err == nil {
+		repository = detailed.Metadata.Annotations["lissto.dev/repository"]
+	}
+
+	desc := status.DescribeStack(ctx, k8sClient, stack, blueprintInfraLookup(apiClient),
+		repository, listVariableRefs(ctx, apiClient), listSecretRefs(ctx, apiClient))
+
+	return cmdutil.PrintOutput(cmd, desc, func() {
+		if k8sErr != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Kubernetes access unavailable - pod details and events not shown\n")
+			fmt.Fprintf(os.Stderr, "   Error: %v\n", k8sErr)
+		}
+		printStackDescription(stack, desc)
+	})
+}
+
+// listVariableRefs lists variable configs as refs (best-effort)
+func listVariableRefs(ctx context.Context, apiClient *client.Client) []status.ConfigRef {
+	variables, err := apiClient.ListVariables(ctx)
+	if err != nil {
+		return nil
+	}
+
+	refs := make([]status.ConfigRef, 0, len(variables))
+	for _, v := range variables {
+		keys := make([]string, 0, len(v.Data))
+		for k := range v.Data {
+			keys = append(keys, k)
+		}
+		refs = append(refs, status.ConfigRef{Name: v.Name, Scope: v.Scope, Env: v.Env, Repository: v.Repository, Keys: keys})
+	}
+	return refs
+}
+
+// listSecretRefs lists secret configs as refs, keys only (best-effort)
+func listSecretRefs(ctx context.Context, apiClient *client.Client) []status.ConfigRef {
+	secrets, err := apiClient.ListSecrets(ctx)
+	if err != nil {
+		return nil
+	}
+
+	refs := make([]status.ConfigRef, 0, len(secrets))
+	for _, s := range secrets {
+		refs = append(refs, status.ConfigRef{Name: s.Name, Scope: s.Scope, Env: s.Env, Repository: s.Repository, Keys: s.Keys})
+	}
+	return refs
+}
+
+func printStackDescription(stack *types.Stack, desc *status.StackDescription) {
+	printer := output.NewPrettyPrinter(os.Stdout)
+
+	printer.PrintHeader(fmt.Sprintf("Stack: %s", types.GetStackDisplayName(stack)))
+
+	stackStatus := desc.State
+	if desc.Reason != "" {
+		stackStatus = fmt.Sprintf("%s (%s)", stackStatus, desc.Reason)
+	}
+	printer.PrintField("Status", stackStatus)
+	printer.PrintField("Env", desc.Env)
+	printer.PrintField("Namespace", desc.Namespace)
+	printer.PrintField("Blueprint", desc.Blueprint)
+	if desc.Repository != "" {
+		printer.PrintField("Repository", desc.Repository)
+	}
+	if desc.Source != nil {
+		var parts []string
+		for _, p := range [][2]string{{"commit", desc.Source.Commit}, {"tag", desc.Source.Tag}, {"branch", desc.Source.Branch}, {"author", desc.Source.Author}} {
+			if p[1] != "" {
+				parts = append(parts, p[0]+" "+p[1])
+			}
+		}
+		printer.PrintField("Source", strings.Join(parts, ", "))
+	}
+	formatted, timeAgo := output.FormatTimestamp(desc.Created)
+	printer.PrintField("Created", fmt.Sprintf("%s (%s)", formatted, timeAgo))
+	printer.PrintField("Services", fmt.Sprintf("%d/%d ready", desc.ReadyServices, desc.TotalServices))
+
+	printer.PrintSubSection("📦", "Images")
+	imageRows := make([][]string, 0, len(desc.Images))
+	for _, img := range desc.Images {
+		imageRows = append(imageRows, []string{img.Service, img.Image, img.Digest})
+	}
+	output.PrintTableWithPriorities(os.Stdout, []string{"SERVICE", "IMAGE", "DIGEST"}, imageRows, output.ColumnPriorities{"DIGEST": 2, "IMAGE": 1})
+
+	var urlRows [][]string
+	for _, svc := range desc.Services {
+		if svc.URL == "" {
+			continue
+		}
+		ready := "⚪ (unknown)"
+		if svc.Traffic != nil {
+			ready = output.GreenCheck()
+			if !svc.Traffic.Ready {
+				ready = "❌ " + svc.Traffic.Reason
+			}
+		}
+		urlRows = append(urlRows, []string{svc.Name, svc.URL, ready})
+	}
+	if len(urlRows) > 0 {
+		printer.PrintSubSection("🌐", "URLs")
+		output.PrintTableWithPriorities(os.Stdout, []string{"SERVICE", "URL", "READY"}, urlRows, output.ColumnPriorities{"URL": 1})
+	}
+
+	var podRows [][]string
+	for _, svc := range desc.Services {
+		for _, pod := range svc.Pods {
+			ready := "no"
+			if pod.Ready {
+				ready = "yes"
+			}
+			podRows = append(podRows, []string{
+				svc.Name,
+				svc.Category,
+				pod.Name,
+				pod.Phase,
+				ready,
+				fmt.Sprintf("%d", pod.Restarts),
+				k8s.FormatAge(time.Since(pod.Created)),
+			})
+		}
+	}
+	if len(podRows) > 0 {
+		printer.PrintSubSection("🧩", "Pods")
+		output.PrintTable(os.Stdout, []string{"SERVICE", "CATEGORY", "POD NAME", "PHASE", "READY", "RESTARTS", "AGE"}, podRows)
+	}
+
+	if len(desc.Conditions) > 0 {
+		printer.PrintSubSection("🕒", "Conditions")
+		rows := make([][]string, 0, len(desc.Conditions))
+		for _, c := range desc.Conditions {
+			rows = append(rows, []string{k8s.FormatAge(time.Since(c.Time)), c.Type, c.Status, c.Reason, c.Message})
+		}
+		output.PrintTableWithPriorities(os.Stdout, []string{"AGE", "TYPE", "STATUS", "REASON", "MESSAGE"}, rows, output.ColumnPriorities{"MESSAGE": 1})
+	}
+
+	printConfigRefs(printer, "🔧", "Variables", desc.Variables)
+	printConfigRefs(printer, "🔐", "Secrets", desc.Secrets)
+
+	if len(desc.Events) > 0 {
+		printer.PrintSubSection("📰", "Recent Events")
+		printEventsTable(desc.Events)
+	}
+}
+
+func printConfigRefs(printer *output.PrettyPrinter, emoji, title string, refs []status.ConfigRef) {
+	if len(refs) == 0 {
+		return
+	}
+
+	printer.PrintSubSection(emoji, title)
+	rows := make([][]string, 0, len(refs))
+	for _, r := range refs {
+		rows = append(rows, []string{r.Name, r.Scope, strings.Join(r.Keys, ", ")})
+	}
+	output.PrintTableWithPriorities(os.Stdout, []string{"NAME", "SCOPE", "KEYS"}, rows, output.ColumnPriorities{"KEYS": 1})
+}
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+
+	apicompose "github.com/lissto-dev/api/pkg/compose"
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/compose"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/spinner"
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+var (
+	devRepository string
+	devBranch     string
+	devProfiles   []string
+	devNoLogs     bool
+	devCleanup    bool
+	devDebounce   time.Duration
+)
+
+var devCmd = &cobra.Command{
+	Use:   "dev [compose-file]",
+	Short: "Redeploy a stack whenever its compose file changes",
+	Long: `Watch a Docker Compose file and keep a stack in sync with it.
+
+On start and on every save the file is validated; a valid file becomes a new
+blueprint, the stack of the previous version is replaced by one deployed from
+it, and the stack's logs are tailed until the next change. Invalid files are
+reported and the running stack is kept.
+
+Stop with Ctrl+C. The last stack keeps running unless --cleanup is given.
+
+Environment variables:
+  LISSTO_COMPOSE_FILE  Override compose file path (used when no argument provided)
+
+Examples:
+  # Inner loop on the current environment
+  lissto dev docker-compose.yaml
+
+  # Resolve images from a branch, and delete the stack on exit
+  lissto dev compose.yaml --branch feature-x --cleanup`,
+	Args:          cobra.MaximumNArgs(1),
+	RunE:          runDev,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(devCmd)
+	devCmd.Flags().StringVar(&devRepository, "repository", "", "Repository of the compose file (default: the git remote)")
+	devCmd.Flags().StringVar(&devBranch, "branch", "", "Git branch to use for image resolution")
+	devCmd.Flags().StringSliceVar(&devProfiles, "profile", nil, "Compose profile to include (repeatable)")
+	devCmd.Flags().BoolVar(&devNoLogs, "no-logs", false, "Don't tail the stack's logs")
+	devCmd.Flags().BoolVar(&devCleanup, "cleanup", false, "Delete the stack when dev stops")
+	devCmd.Flags().DurationVar(&devDebounce, "debounce", compose.DefaultWatchDebounce, "How long writes must settle before redeploying")
+}
+
+// devSession is the state of a running 'lissto dev'
+type devSession struct {
+	apiClient  *client.Client
+	k8sClient  *k8s.Client // nil when logs are off or unavailable
+	env        string
+	path       string
+	repository string
+
+	// content is the last deployed compose content
+	content string
+	// stack is the name of the stack deployed from it
+	stack string
+	// stopLogs stops tailing the current stack's logs
+	stopLogs func()
+}
+
+func runDev(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	overrides := cmdutil.LoadOverrides()
+	var composePath string
+	switch {
+	case len(args) > 0:
+		composePath = args[0]
+	case overrides.HasComposeFile():
+		composePath = overrides.ComposeFile
+		fmt.Printf("📄 Using compose file from %s: %s\n", cmdutil.EnvOverrideComposeFile, composePath)
+	default:
+		return fmt.Errorf("compose file required: provide as argument or set %s", cmdutil.EnvOverrideComposeFile)
+	}
+	if _, err := os.Stat(composePath); err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	repository := devRepository
+	if repository == "" {
+		if overrides.HasRepository() {
+			repository = overrides.Repository
+		} else {
+			inferred, err := inferRepositoryFromFile(composePath)
+			if err != nil {
+				return fmt.Errorf("failed to infer repository: %w\nPlease specify --repository or set %s", err, cmdutil.EnvOverrideRepository)
+			}
+			repository = inferred
+		}
+	}
+
+	apiClient, env, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return err
+	}
+
+	s := &devSession{
+		apiClient:  apiClient,
+		env:        env,
+		path:       composePath,
+		repository: repository,
+		stopLogs:   func() {},
+	}
+	if !devNoLogs {
+		if s.k8sClient, err = k8s.NewClient(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Kubernetes access unavailable - logs not shown: %v\n", err)
+		}
+	}
+
+	// Silence the compose parser, warnings are captured by validation
+	logrus.SetLevel(logrus.PanicLevel)
+
+	fmt.Printf("👀 Watching %s (env: %s). Press Ctrl+C to stop.\n", composePath, env)
+	s.deploy(ctx)
+	err = compose.Watch(ctx, composePath, devDebounce, func() { s.deploy(ctx) })
+	s.stopLogs()
+
+	if devCleanup && s.stack != "" {
+		// The command context is cancelled by now
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := apiClient.DeleteStack(cleanupCtx, s.stack, env); err != nil {
+			return fmt.Errorf("failed to delete stack '%s': %w", s.stack, err)
+		}
+		fmt.Printf("🗑️  Deleted stack: %s\n", s.stack)
+	} else if s.stack != "" {
+		fmt.Printf("\nStack '%s' keeps running; delete it with 'lissto delete --stack %s --env %s'\n", s.stack, s.stack, env)
+	}
+	return err
+}
+
+// deploy validates the compose file and, when it is valid and changed,
+// replaces the stack with one deployed from a new blueprint. Failures are
+// reported and leave the running stack in place.
+func (s *devSession) deploy(ctx context.Context) {
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		s.fail("Failed to read file", err)
+		return
+	}
+	content, _, err := compose.ApplyProfiles(string(raw), devProfiles)
+	if err != nil {
+		s.fail("Invalid compose file", err)
+		return
+	}
+	if content == s.content {
+		return
+	}
+
+	fmt.Printf("\n🔄 %s changed at %s\n", s.path, time.Now().Format("15:04:05"))
+	result, err := apicompose.ValidateCompose(content)
+	if err != nil {
+		s.fail("Validation failed", err)
+		return
+	}
+	if !result.Valid {
+		fmt.Println(output.Red("❌ Compose file is invalid, keeping the running stack:"))
+		for _, msg := range result.Errors {
+			fmt.Printf("  - %s\n", msg)
+		}
+		return
+	}
+	for _, warning := range result.Warnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+
+	spin := spinner.Start(os.Stdout, "Creating blueprint")
+	blueprintID, err := s.apiClient.CreateBlueprint(ctx, client.CreateBlueprintRequest{
+		Compose:    content,
+		Branch:     devBranch,
+		Repository: s.repository,
+	})
+	if err != nil {
+		spin.Fail("Failed to create blueprint")
+		s.fail("", err)
+		return
+	}
+	spin.Success("Blueprint " + blueprintID)
+
+	spin = spinner.Start(os.Stdout, "Resolving images")
+	prepared, err := s.apiClient.PrepareStack(ctx, blueprintID, s.env, "", devBranch, "", false)
+	if err != nil {
+		spin.Fail("Failed to resolve images")
+		s.fail("", err)
+		return
+	}
+	spin.Stop()
+
+	// A stack can't switch blueprints, so the previous one is replaced
+	s.stopLogs()
+	if s.stack != "" {
+		if err := s.apiClient.DeleteStack(ctx, s.stack, s.env); err != nil {
+			s.fail("Failed to delete the previous stack", err)
+			return
+		}
+		fmt.Printf("🗑️  Deleted stack: %s\n", s.stack)
+		s.stack = ""
+	}
+
+	spin = spinner.Start(os.Stdout, "Creating stack")
+	stackID, err := s.apiClient.CreateStack(ctx, blueprintID, s.env, prepared.RequestID)
+	if err != nil {
+		spin.Fail("Failed to create stack")
+		s.fail("", err)
+		return
+	}
+	s.stack = stackID[strings.LastIndex(stackID, "/")+1:]
+	s.content = content
+	spin.Success("Stack " + s.stack)
+	for _, exp := range prepared.Exposed {
+		fmt.Printf("  🔗 %s: https://%s\n", exp.Service, exp.URL)
+	}
+
+	s.tailLogs(ctx)
+}
+
+// tailLogs streams the current stack's logs in the background until the
+// next deploy
+func (s *devSession) tailLogs(ctx context.Context) {
+	if s.k8sClient == nil {
+		return
+	}
+
+	logCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	s.stopLogs = func() {
+		cancel()
+		wg.Wait()
+	}
+
+	stackName := s.stack
+	listPods := func(ctx context.Context) ([]corev1.Pod, error) {
+		stacks, err := s.apiClient.ListStacks(ctx, s.env)
+		if err != nil {
+			return nil, err
+		}
+		for i := range stacks {
+			if stacks[i].Name == stackName {
+				pods := status.ListStackPods(ctx, s.k8sClient, &stacks[i])
+				return pods.Items, pods.Err
+			}
+		}
+		return nil, nil
+	}
+
+	lines := make(chan k8s.LogLine, 100)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer close(lines)
+		namespace, err := s.stackNamespace(logCtx, stackName)
+		if err != nil {
+			return
+		}
+		opts := k8s.LogOptions{Follow: true}
+		_ = s.k8sClient.StreamLogsMulti(logCtx, namespace, nil, opts, listPods, lines)
+	}()
+	go func() {
+		defer wg.Done()
+		for line := range lines {
+			fmt.Printf("%s %s\n", output.Gray("["+line.PodName+"]"), line.Message)
+		}
+	}()
+}
+
+// stackNamespace returns the namespace of a stack
+func (s *devSession) stackNamespace(ctx context.Context, name string) (string, error) {
+	stacks, err := s.apiClient.ListStacks(ctx, s.env)
+	if err != nil {
+		return "", err
+	}
+	for i := range stacks {
+		if stacks[i].Name == name {
+			return stacks[i].Namespace, nil
+		}
+	}
+	return "", fmt.Errorf("stack '%s' %w", name, client.ErrNotFound)
+}
+
+// fail reports a failed deploy step
+func (s *devSession) fail(message string, err error) {
+	if message != "" {
+		fmt.Printf("❌ %s: %v\n", message, err)
+	} else {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println("   Waiting for the next change...")
+}
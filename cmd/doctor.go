@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/spf13/cobra"
+)
+
+// doctorTimeout bounds the cluster calls made by doctor
+const doctorTimeout = 30 * time.Second
+
+// doctorCheckNames lists the checks in the order they run
+var doctorCheckNames = []string{
+	"Lissto config",
+	"Kubeconfig",
+	"Kubernetes reachable",
+	"Lissto API service",
+	"Port-forward",
+	"API instance ID",
+	"API key",
+	"Environment",
+	"Env cache",
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose CLI setup and connectivity",
+	Long: `Run a checklist of everything the CLI needs to talk to Lissto and print
+an actionable fix for each failed check:
+
+  - Lissto config and current context
+  - kubeconfig and Kubernetes context
+  - Kubernetes API reachable
+  - Lissto API service found
+  - Port-forward to the API works
+  - API instance ID matches the saved context
+  - API key valid
+  - Current environment exists and the env cache can be refreshed
+
+Examples:
+  # Run all checks
+  lissto doctor
+
+  # Machine-readable results
+  lissto doctor -o json`,
+	RunE:          runDoctor,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	checks := runDoctorChecks(cmd.Context())
+	if err := cmdutil.PrintOutput(cmd, checks, func() { printDoctorChecks(checks) }); err != nil {
+		return err
+	}
+	return cmdutil.ChecksError(checks)
+}
+
+// runDoctorChecks runs the checklist in order. Checks that depend on a failed
+// one are reported as skipped.
+func runDoctorChecks(ctx context.Context) []cmdutil.Check {
+	checks := cmdutil.NewChecklist(doctorCheckNames...)
+	add, skipRest := checks.Add, checks.SkipRest
+
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+
+	// 1. Lissto config and context
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		add("Lissto config", cmdutil.CheckFail, err.Error(), "Fix or remove the config file, then run 'lissto login'")
+		return skipRest()
+	}
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		add("Lissto config", cmdutil.CheckFail, err.Error(), "Run 'lissto login' to create a context")
+		return skipRest()
+	}
+	add("Lissto config", cmdutil.CheckOK, fmt.Sprintf("context '%s'", lisstoCtx.Name), "")
+
+	// 2. Kubeconfig and context
+	kubeContext, err := k8s.GetCurrentKubeContext()
+	switch {
+	case err != nil:
+		add("Kubeconfig", cmdutil.CheckFail, err.Error(), "Check KUBECONFIG or ~/.kube/config")
+	case kubeContext != lisstoCtx.KubeContext:
+		add("Kubeconfig", cmdutil.CheckFail,
+			fmt.Sprintf("current k8s context is '%s' but Lissto context expects '%s'", kubeContext, lisstoCtx.KubeContext),
+			fmt.Sprintf("Run 'kubectl config use-context %s'", lisstoCtx.KubeContext))
+	default:
+		add("Kubeconfig", cmdutil.CheckOK, fmt.Sprintf("k8s context '%s'", kubeContext), "")
+	}
+
+	// 3. Cluster reachable
+	k8sClient, err := k8s.NewClientWithContext(lisstoCtx.Kubeconfig, lisstoCtx.KubeContext)
+	if err == nil {
+		var version string
+		version, err = k8sClient.ServerVersion()
+		if err == nil {
+			add("Kubernetes reachable", cmdutil.CheckOK, "server "+version, "")
+		}
+	}
+	if err != nil {
+		add("Kubernetes reachable", cmdutil.CheckFail, err.Error(), "Check your cluster credentials and network/VPN access")
+		return skipRest()
+	}
+
+	// 4. API service
+	if _, err := k8sClient.GetService(ctx, lisstoCtx.ServiceNamespace, lisstoCtx.ServiceName); err != nil {
+		add("Lissto API service", cmdutil.CheckFail, err.Error(),
+			fmt.Sprintf("Check that Lissto is installed and service %s/%s exists, or run 'lissto login' with the right service", lisstoCtx.ServiceNamespace, lisstoCtx.ServiceName))
+		return skipRest()
+	}
+	add("Lissto API service", cmdutil.CheckOK, lisstoCtx.ServiceNamespace+"/"+lisstoCtx.ServiceName, "")
+
+	// 5. Port-forward and API info
+	url, stop, err := k8sClient.SetupPortForward(ctx, lisstoCtx.ServiceName, lisstoCtx.ServiceNamespace, 8080)
+	if err != nil {
+		add("Port-forward", cmdutil.CheckFail, err.Error(), "Check that the lissto-api pods are running: kubectl get pods -n "+lisstoCtx.ServiceNamespace)
+		return skipRest()
+	}
+	defer stop()
+
+	info, err := client.NewClient(url, "").GetAPIInfo(ctx)
+	if err != nil {
+		add("Port-forward", cmdutil.CheckFail, err.Error(), "Check the lissto-api logs: kubectl logs -n "+lisstoCtx.ServiceNamespace+" svc/"+lisstoCtx.ServiceName)
+		return skipRest()
+	}
+	add("Port-forward", cmdutil.CheckOK, url, "")
+
+	// 6. API instance ID
+	checks.CheckAPIID(lisstoCtx.APIID, info.APIID)
+
+	// 7. API key, or browser login token
+	apiClient := client.NewClient(url, lisstoCtx.APIKey)
+	check, fix := "API key", "Ask an admin for a new key and run 'lissto login'"
+	if lisstoCtx.AccessToken != "" {
+		check, fix = "Login", "Run 'lissto login --web' again"
+		if err := apiClient.UseContextToken(ctx, lisstoCtx); err != nil {
+			add(check, cmdutil.CheckFail, err.Error(), fix)
+			return skipRest()
+		}
+	}
+	if !checks.CheckUser(ctx, apiClient, check, fix) {
+		return skipRest()
+	}
+
+	// 8. Environment and cache
+	checks.CheckEnv(ctx, apiClient, cfg.ActiveContextName(), cfg.CurrentEnv)
+
+	return checks.Checks
+}
+
+func printDoctorChecks(checks []cmdutil.Check) {
+	fmt.Println("🩺 Lissto doctor")
+	fmt.Println()
+	cmdutil.PrintChecks(os.Stdout, checks)
+}
@@ -15,17 +15,20 @@ var createCmd = &cobra.Command{
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	envName := args[0]
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	identifier, err := apiClient.CreateEnv(envName)
+	identifier, err := apiClient.CreateEnv(ctx, envName)
 	if err != nil {
 		return fmt.Errorf("failed to create environment: %w", err)
 	}
+	cmdutil.InvalidateCurrentEnvCache()
 
 	fmt.Printf("Environment '%s' created successfully\n", envName)
 	fmt.Printf("ID: %s\n", identifier)
@@ -0,0 +1,67 @@
+package env
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteCascade bool
+	deleteYes     bool
+)
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <env-name>",
+	Short: "Delete an environment",
+	Long: `Delete an environment.
+
+An environment with active stacks is only deleted together with its stacks:
+pass --cascade to delete them first, or confirm when prompted. Lissto APIs
+without environment deletion are reported as such.
+
+Examples:
+  # Delete an empty environment
+  lissto env delete staging
+
+  # Delete an environment and all of its stacks without prompting
+  lissto env delete staging --cascade --yes`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runDelete,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	deleteCmd.Flags().BoolVar(&deleteCascade, "cascade", false, "Also delete all stacks in the environment")
+	deleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Skip confirmation prompts")
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	envName := args[0]
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = cmdutil.DeleteEnv(ctx, os.Stdout, apiClient, envName, cmdutil.DeleteEnvOptions{
+		Cascade: deleteCascade,
+		Yes:     deleteYes,
+		Confirm: interactive.ConfirmAction,
+	})
+	if err != nil {
+		return err
+	}
+
+	syncLocalEnv(envName, "")
+
+	fmt.Printf("Environment '%s' deleted successfully\n", envName)
+
+	return nil
+}
@@ -1,6 +1,11 @@
 package env
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
 	"github.com/spf13/cobra"
 )
 
@@ -17,4 +22,24 @@ func init() {
 	EnvCmd.AddCommand(createCmd)
 	EnvCmd.AddCommand(useCmd)
 	EnvCmd.AddCommand(currentCmd)
+	EnvCmd.AddCommand(deleteCmd)
+	EnvCmd.AddCommand(renameCmd)
+	EnvCmd.AddCommand(usageCmd)
+}
+
+// syncLocalEnv updates the active environment and drops the env cache after
+// an environment was renamed to newName, or deleted when newName is empty.
+// Failures only warn, the server-side change already succeeded.
+func syncLocalEnv(name, newName string) {
+	err := config.UpdateConfig(func(cfg *config.Config) error {
+		if cfg.CurrentEnv != name {
+			return nil
+		}
+		return cfg.SetCurrentEnv(newName)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Failed to update active environment: %v\n", err)
+	}
+
+	cmdutil.InvalidateCurrentEnvCache()
 }
@@ -15,14 +15,16 @@ var getCmd = &cobra.Command{
 }
 
 func runGet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	envName := args[0]
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	env, err := apiClient.GetEnv(envName)
+	env, err := apiClient.GetEnv(ctx, envName)
 	if err != nil {
 		return fmt.Errorf("failed to get environment: %w", err)
 	}
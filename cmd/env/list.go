@@ -1,9 +1,11 @@
 package env
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/cmdutil"
 	"github.com/lissto-dev/cli/pkg/output"
 	"github.com/spf13/cobra"
@@ -12,26 +14,79 @@ import (
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all environments",
-	RunE:  runList,
+	Long: `List all environments.
+
+Environments are cached for a few minutes and refreshed automatically when
+the cache expires. Use --refresh to fetch them from the API right away.
+
+Examples:
+  # List environments of the current context
+  lissto env list
+
+  # Bypass the environment cache
+  lissto env list --refresh
+
+  # Show the last environments fetched, without contacting the cluster
+  lissto env list --cached
+
+  # List environments of another context
+  lissto env list --context staging
+
+  # List environments of every context
+  lissto env list --all-contexts`,
+	RunE: runList,
+}
+
+// contextEnvs are the environments of one context, for --all-contexts output
+type contextEnvs struct {
+	Context string               `json:"context" yaml:"context"`
+	Envs    []client.EnvResponse `json:"envs" yaml:"envs"`
+}
+
+func init() {
+	cmdutil.AddAllContextsFlag(listCmd)
+	listCmd.Flags().Bool("refresh", false, "Fetch environments from the API instead of the cache")
+	cmdutil.AddCachedFlag(listCmd)
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	apiClient, err := cmdutil.GetAPIClient()
+	refresh, _ := cmd.Flags().GetBool("refresh")
+
+	results, err := cmdutil.ForEachContextCached(cmd, "envs", func(ctx context.Context, target cmdutil.ContextTarget) ([]client.EnvResponse, error) {
+		envs, err := cmdutil.ListEnvsCached(ctx, target.Client, target.Context.Name, refresh)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list environments: %w", err)
+		}
+		return envs, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	envs, err := apiClient.ListEnvs()
-	if err != nil {
-		return fmt.Errorf("failed to list environments: %w", err)
+	if !cmdutil.AllContexts(cmd) {
+		envs := results[0].Value
+		return cmdutil.PrintOutput(cmd, envs, func() {
+			// Table format
+			headers := []string{"NAME", "ID"}
+			var rows [][]string
+			for _, env := range envs {
+				rows = append(rows, []string{env.Name, env.ID})
+			}
+			output.PrintTable(os.Stdout, headers, rows)
+		})
 	}
 
-	return cmdutil.PrintOutput(cmd, envs, func() {
-		// Table format
-		headers := []string{"NAME", "ID"}
+	data := make([]contextEnvs, 0, len(results))
+	for _, result := range results {
+		data = append(data, contextEnvs{Context: result.Context, Envs: result.Value})
+	}
+	return cmdutil.PrintOutput(cmd, data, func() {
+		headers := []string{"CONTEXT", "NAME", "ID"}
 		var rows [][]string
-		for _, env := range envs {
-			rows = append(rows, []string{env.Name, env.ID})
+		for _, result := range results {
+			for _, env := range result.Value {
+				rows = append(rows, []string{result.Context, env.Name, env.ID})
+			}
 		}
 		output.PrintTable(os.Stdout, headers, rows)
 	})
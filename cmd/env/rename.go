@@ -0,0 +1,61 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <env-name> <new-name>",
+	Short: "Rename an environment",
+	Long: `Rename an environment. The active environment and the local environment
+cache are updated to the new name. Lissto APIs without environment renaming
+are reported as such.
+
+Examples:
+  lissto env rename staging qa`,
+	Args:          cobra.ExactArgs(2),
+	RunE:          runRename,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func runRename(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	envName, newName := args[0], args[1]
+	if envName == newName {
+		return fmt.Errorf("environment is already named '%s'", envName)
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A missing environment would look like an API without renames
+	if _, err := apiClient.GetEnv(ctx, envName); err != nil {
+		return fmt.Errorf("failed to get environment: %w", err)
+	}
+
+	identifier, err := apiClient.RenameEnv(ctx, envName, newName)
+	if err != nil {
+		if errors.Is(err, client.ErrEnvRenameUnsupported) {
+			return err
+		}
+		return fmt.Errorf("failed to rename environment: %w", err)
+	}
+
+	syncLocalEnv(envName, newName)
+
+	fmt.Printf("Environment '%s' renamed to '%s'\n", envName, newName)
+	if identifier != "" {
+		fmt.Printf("ID: %s\n", identifier)
+	}
+
+	return nil
+}
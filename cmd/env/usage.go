@@ -0,0 +1,149 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var usageCmd = &cobra.Command{
+	Use:   "usage [env-name]",
+	Short: "Show resource requests of an environment against its quotas",
+	Long: `Sum the CPU and memory requests and limits of every stack in an
+environment and compare them to the ResourceQuotas of its namespace.
+
+Quotas at 80% or more are flagged: a stack whose pods stay Pending or are
+rejected usually needs more than what is left. Requests and limits come from
+the pod specs, use 'lissto top' for actual usage.
+
+Examples:
+  # Usage of the current environment
+  lissto env usage
+
+  # Why won't my stack schedule in staging?
+  lissto env usage staging
+
+  # Machine-readable output
+  lissto env usage -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUsage,
+}
+
+func runUsage(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	apiClient, envName, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		envName = args[0]
+	}
+
+	stacks, err := apiClient.ListStacks(ctx, envName)
+	if err != nil {
+		return fmt.Errorf("failed to list stacks: %w", err)
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	namespaces := make(map[string]bool)
+	stackResources := make([]status.StackResources, 0, len(stacks))
+	for i := range stacks {
+		stack := &stacks[i]
+		pods, err := k8sClient.ListPods(ctx, stack.Namespace, status.StackPodLabels(stack.Name))
+		if err != nil {
+			return fmt.Errorf("failed to list pods for stack %s: %w", stack.Name, err)
+		}
+		stackResources = append(stackResources, status.BuildStackResources(stack, pods))
+		namespaces[stack.Namespace] = true
+	}
+	sort.Slice(stackResources, func(i, j int) bool { return stackResources[i].Name < stackResources[j].Name })
+
+	// Without stacks, the quotas of the environment's own namespace apply
+	if len(namespaces) == 0 {
+		env, err := apiClient.GetEnv(ctx, envName)
+		if err != nil {
+			return fmt.Errorf("failed to get environment: %w", err)
+		}
+		if ns, _, ok := strings.Cut(env.ID, "/"); ok {
+			namespaces[ns] = true
+		}
+	}
+
+	var quotas []corev1.ResourceQuota
+	for ns := range namespaces {
+		nsQuotas, err := k8sClient.ListResourceQuotas(ctx, ns)
+		if err != nil {
+			return fmt.Errorf("failed to get quotas of namespace %s: %w", ns, err)
+		}
+		quotas = append(quotas, nsQuotas...)
+	}
+
+	report := status.BuildEnvResources(envName, stackResources, quotas)
+	return cmdutil.PrintOutput(cmd, report, func() {
+		printEnvUsage(&report)
+	})
+}
+
+// printEnvUsage prints the requests and limits per stack, then the quotas
+func printEnvUsage(report *status.EnvResources) {
+	if len(report.Stacks) == 0 {
+		fmt.Printf("No stacks in environment '%s'.\n", report.Env)
+	} else {
+		headers := []string{"STACK", "PODS", "CPU REQ", "CPU LIM", "MEM REQ", "MEM LIM"}
+		rows := make([][]string, 0, len(report.Stacks)+1)
+		for _, s := range report.Stacks {
+			rows = append(rows, resourcesRow(s.Name, s.Resources))
+		}
+		rows = append(rows, resourcesRow("(total)", report.Total))
+		output.PrintTable(os.Stdout, headers, rows)
+	}
+
+	fmt.Println()
+	if len(report.Quotas) == 0 {
+		fmt.Println("No resource quotas apply to this environment.")
+		return
+	}
+	headers := []string{"QUOTA", "RESOURCE", "USED", "HARD", "STACKS", "USE"}
+	rows := make([][]string, 0, len(report.Quotas))
+	for _, q := range report.Quotas {
+		stacks := q.Stacks
+		if stacks == "" {
+			stacks = "-"
+		}
+		rows = append(rows, []string{q.Quota, q.Resource, q.Used, q.Hard, stacks, formatQuotaPercent(q)})
+	}
+	output.PrintTable(os.Stdout, headers, rows)
+
+	for _, warning := range report.Warnings() {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+}
+
+func resourcesRow(name string, r status.Resources) []string {
+	return []string{name, strconv.Itoa(r.Pods), r.Requests.CPU(), r.Limits.CPU(), r.Requests.Memory(), r.Limits.Memory()}
+}
+
+func formatQuotaPercent(q status.QuotaUsage) string {
+	percent := fmt.Sprintf("%.0f%%", q.Percent)
+	switch q.State {
+	case status.QuotaExceeded:
+		return output.Red(percent)
+	case status.QuotaNear:
+		return output.Yellow(percent)
+	}
+	return percent
+}
@@ -17,19 +17,12 @@ var useCmd = &cobra.Command{
 func runUse(cmd *cobra.Command, args []string) error {
 	envName := args[0]
 
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	if err := cfg.SetCurrentEnv(envName); err != nil {
+	if err := config.UpdateConfig(func(cfg *config.Config) error {
+		return cfg.SetCurrentEnv(envName)
+	}); err != nil {
 		return err
 	}
 
-	if err := config.SaveConfig(cfg); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
-	}
-
 	fmt.Printf("Switched to environment: %s\n", envName)
 
 	return nil
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/spf13/cobra"
+)
+
+var (
+	eventsStack  string
+	eventsFollow bool
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show Kubernetes events for a stack",
+	Long: `List recent Kubernetes events in a stack's namespace.
+
+Only events for objects belonging to the stack are shown: its pods (matched
+with the same labels as 'lissto status'), their owners, and resources named
+after the stack's services.
+
+Examples:
+  # Show events (select stack interactively)
+  lissto events
+
+  # Show events for a specific stack
+  lissto events --stack my-stack
+
+  # Keep watching for new events
+  lissto events --stack my-stack --follow
+
+  # Output as JSON
+  lissto events --stack my-stack -o json`,
+	RunE:          runEvents,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+	eventsCmd.Flags().StringVar(&eventsStack, "stack", "", "Stack name")
+	eventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "Watch for new events")
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	stack, err := resolveStack(ctx, apiClient, eventsStack)
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+
+	watchCtx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	pods, err := k8sClient.ListPods(watchCtx, stack.Namespace, status.StackPodLabels(stack.Name))
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	filter := status.NewEventFilter(stack, pods)
+
+	events, err := k8sClient.ListEvents(watchCtx, stack.Namespace)
+	if err != nil {
+		return err
+	}
+
+	reports := make([]status.EventReport, 0, len(events))
+	for i := range events {
+		if filter.Matches(&events[i]) {
+			reports = append(reports, status.NewEventReport(&events[i]))
+		}
+	}
+
+	format := cmdutil.GetOutputFormat(cmd)
+	if !eventsFollow {
+		return cmdutil.PrintOutput(cmd, reports, func() {
+			if len(reports) == 0 {
+				fmt.Printf("No events found for stack '%s'\n", stack.Name)
+				return
+			}
+			printEventsTable(reports)
+		})
+	}
+
+	// In follow mode, json/yaml are streamed as one JSON object per line
+	printEvent := func(r status.EventReport) {
+		if format == "json" || format == "yaml" {
+			data, _ := json.Marshal(r)
+			fmt.Println(string(data))
+			return
+		}
+		fmt.Println(formatEventLine(r))
+	}
+
+	for _, r := range reports {
+		printEvent(r)
+	}
+
+	stream, err := k8sClient.WatchEvents(watchCtx, stack.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// Watches replay existing events as "added"; skip those already printed
+	seen := make(map[string]bool)
+	for i := range events {
+		seen[eventKey(events[i].Name, events[i].Count)] = true
+	}
+
+	for ev := range stream {
+		if !filter.Matches(&ev) || seen[eventKey(ev.Name, ev.Count)] {
+			continue
+		}
+		seen[eventKey(ev.Name, ev.Count)] = true
+		printEvent(status.NewEventReport(&ev))
+	}
+
+	return nil
+}
+
+func eventKey(name string, count int32) string {
+	return fmt.Sprintf("%s/%d", name, count)
+}
+
+func printEventsTable(reports []status.EventReport) {
+	headers := []string{"LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"}
+	rows := make([][]string, 0, len(reports))
+	for _, r := range reports {
+		rows = append(rows, []string{
+			k8s.FormatAge(time.Since(r.Time)),
+			formatEventType(r.Type),
+			r.Reason,
+			r.Object,
+			r.Message,
+		})
+	}
+	output.PrintTableWithPriorities(os.Stdout, headers, rows, output.ColumnPriorities{"MESSAGE": 2, "OBJECT": 1})
+}
+
+func formatEventLine(r status.EventReport) string {
+	return strings.Join([]string{
+		r.Time.Local().Format("15:04:05"),
+		formatEventType(r.Type),
+		r.Reason,
+		r.Object,
+		r.Message,
+	}, "  ")
+}
+
+func formatEventType(eventType string) string {
+	if eventType == "Warning" {
+		return output.Yellow(eventType)
+	}
+	return eventType
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	execStack     string
+	execService   string
+	execContainer string
+	execTTY       bool
+	execNoTTY     bool
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec [-- command [args...]]",
+	Short: "Open a shell or run a command in a service's pod",
+	Long: `Run a command in a running pod of a stack service.
+
+The pod is resolved with the same label matching used by 'lissto status'.
+Without a command an interactive shell (sh) is opened with a TTY.
+
+Examples:
+  # Open a shell (select stack and service interactively)
+  lissto exec
+
+  # Open a shell in a specific service
+  lissto exec --stack my-stack --service api
+
+  # Run a one-off command
+  lissto exec --stack my-stack --service api -- rails db:migrate
+
+  # Choose a container in multi-container pods
+  lissto exec --stack my-stack --service api --container sidecar -- env`,
+	RunE:          runExec,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+	execCmd.Flags().StringVar(&execStack, "stack", "", "Stack name")
+	execCmd.Flags().StringVar(&execService, "service", "", "Service name")
+	execCmd.Flags().StringVarP(&execContainer, "container", "c", "", "Container name (defaults to the first container)")
+	execCmd.Flags().BoolVarP(&execTTY, "tty", "t", false, "Allocate a TTY for the command")
+	execCmd.Flags().BoolVarP(&execNoTTY, "no-tty", "T", false, "Disable TTY allocation for the shell")
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	stack, err := resolveStack(ctx, apiClient, execStack)
+	if err != nil {
+		return err
+	}
+
+	service, err := resolveService(stack, execService)
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+
+	pod, err := resolveServicePod(ctx, k8sClient, stack, service)
+	if err != nil {
+		return err
+	}
+
+	command := args
+	tty := execTTY
+	if len(command) == 0 {
+		command = []string{"sh"}
+		tty = !execNoTTY
+	}
+	// A TTY only makes sense when we're attached to a terminal
+	if tty && !term.IsTerminal(int(os.Stdin.Fd())) {
+		tty = false
+	}
+
+	fmt.Fprintf(os.Stderr, "🔌 Connecting to %s/%s...\n", stack.Namespace, pod.Name)
+
+	execCtx, cancel := signal.NotifyContext(ctx, syscall.SIGTERM)
+	defer cancel()
+
+	return k8sClient.Exec(execCtx, stack.Namespace, pod.Name, k8s.ExecOptions{
+		Container: execContainer,
+		Command:   command,
+		Stdin:     os.Stdin,
+		Stdout:    os.Stdout,
+		Stderr:    os.Stderr,
+		TTY:       tty,
+	})
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gcDryRun bool
+	gcYes    bool
+)
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete stacks past their TTL",
+	Long: `Find and delete the stacks whose TTL has passed.
+
+Stacks get a TTL with 'lissto create --ttl'. Expired stacks from all
+environments are listed (use --env to limit to one) and deleted after a
+confirmation. Stacks without a TTL are never touched.
+
+Examples:
+  # Show what would be deleted
+  lissto gc --dry-run
+
+  # Clean up the shared staging environment
+  lissto gc --env staging
+
+  # Nightly cleanup job
+  lissto gc --yes`,
+	Args:          cobra.NoArgs,
+	RunE:          runGC,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+	gcCmd.Flags().BoolVar(&gcDryRun, "dry-run", false, "Only list the stacks that would be deleted")
+	gcCmd.Flags().BoolVarP(&gcYes, "yes", "y", false, "Skip confirmation prompt")
+}
+
+func runGC(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	stacks, err := apiClient.ListStacks(ctx, envName)
+	if err != nil {
+		return fmt.Errorf("failed to list stacks: %w", err)
+	}
+
+	now := time.Now()
+	var expired []types.Stack
+	for i := range stacks {
+		if types.IsStackExpired(&stacks[i], now) {
+			expired = append(expired, stacks[i])
+		}
+	}
+	if len(expired) == 0 {
+		fmt.Println("No expired stacks found.")
+		return nil
+	}
+
+	// Longest expired first
+	sort.Slice(expired, func(i, j int) bool {
+		a, _ := types.GetStackExpiry(&expired[i])
+		b, _ := types.GetStackExpiry(&expired[j])
+		return a.Before(b)
+	})
+
+	rows := make([][]string, 0, len(expired))
+	for i := range expired {
+		expiresAt, _ := types.GetStackExpiry(&expired[i])
+		rows = append(rows, []string{
+			expired[i].Name,
+			expired[i].Spec.Env,
+			k8s.FormatAge(now.Sub(expired[i].CreationTimestamp.Time)),
+			k8s.FormatAge(now.Sub(expiresAt)) + " ago",
+		})
+	}
+	output.PrintTable(os.Stdout, []string{"NAME", "ENV", "AGE", "EXPIRED"}, rows)
+	fmt.Println()
+
+	if gcDryRun {
+		fmt.Printf("Dry run: %d stack(s) would be deleted\n", len(expired))
+		return nil
+	}
+
+	if !gcYes {
+		confirmed, err := interactive.ConfirmAction(fmt.Sprintf("Delete %d expired stack(s)?", len(expired)), false)
+		if err != nil || !confirmed {
+			return fmt.Errorf("deletion cancelled")
+		}
+	}
+
+	var failed int
+	for _, stack := range expired {
+		if err := apiClient.DeleteStack(ctx, stack.Name, stack.Spec.Env); err != nil {
+			fmt.Printf("❌ %s: %v\n", stack.Name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ Deleted stack: %s (env: %s)\n", stack.Name, stack.Spec.Env)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d stack(s)", failed)
+	}
+	return nil
+}
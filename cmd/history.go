@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/history"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyFailed bool
+	historyLimit  int
+)
+
+// historyCommands are the commands recorded in the history, by command path
+// without the "lissto " prefix
+var historyCommands = map[string]bool{
+	"create":                true,
+	"create stack":          true,
+	"create blueprint":      true,
+	"update":                true,
+	"delete":                true,
+	"scale":                 true,
+	"gc":                    true,
+	"stack create":          true,
+	"stack delete":          true,
+	"stack clone":           true,
+	"stack pause":           true,
+	"stack resume":          true,
+	"blueprint create":      true,
+	"blueprint delete":      true,
+	"blueprint import":      true,
+	"env create":            true,
+	"env delete":            true,
+	"env rename":            true,
+	"variable create":       true,
+	"variable update":       true,
+	"variable delete":       true,
+	"variable edit":         true,
+	"secret create":         true,
+	"secret set":            true,
+	"secret delete":         true,
+	"secret rotate":         true,
+	"admin apikey create":   true,
+	"admin apikey revoke":   true,
+	"admin apikey rotate":   true,
+	"admin user set-role":   true,
+	"admin user deactivate": true,
+}
+
+// commandStarted is when the command's run started; zero if it never did,
+// e.g. because of invalid flags
+var commandStarted time.Time
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the history of operations run from this machine",
+	Long: `List the create, update and delete operations on stacks, blueprints,
+environments, variables and secrets run with this CLI, newest first.
+
+Every operation is appended to history.jsonl in the config directory with
+its time, command line, context, environment and result. Secret values
+given as KEY=value arguments are not recorded.
+
+Examples:
+  # What did I do recently?
+  lissto history
+
+  # Only operations that failed
+  lissto history --failed
+
+  # Everything, as JSON
+  lissto history --limit 0 -o json`,
+	Args:          cobra.NoArgs,
+	RunE:          runHistory,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().BoolVar(&historyFailed, "failed", false, "Only show failed operations")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 20, "Maximum number of entries to show (0 for all)")
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	path, err := history.DefaultPath()
+	if err != nil {
+		return err
+	}
+	entries, err := history.Read(path)
+	if err != nil {
+		return err
+	}
+
+	// Newest first
+	shown := make([]history.Entry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		if historyFailed && !entries[i].Failed() {
+			continue
+		}
+		shown = append(shown, entries[i])
+		if historyLimit > 0 && len(shown) == historyLimit {
+			break
+		}
+	}
+
+	return cmdutil.PrintOutput(cmd, shown, func() {
+		if len(shown) == 0 {
+			fmt.Println("No operations recorded yet")
+			return
+		}
+
+		headers := []string{"TIME", "COMMAND", "CONTEXT", "ENV", "RESULT"}
+		rows := make([][]string, 0, len(shown))
+		for _, e := range shown {
+			rows = append(rows, []string{
+				e.Time.Local().Format("2006-01-02 15:04:05"),
+				strings.Join(append([]string{e.Command}, e.Args...), " "),
+				e.Context,
+				e.Env,
+				historyResult(&e),
+			})
+		}
+		output.PrintTableWithPriorities(os.Stdout, headers, rows, output.ColumnPriorities{"COMMAND": 2, "RESULT": 1})
+	})
+}
+
+// historyResult formats the result column, with the first line of the error
+func historyResult(e *history.Entry) string {
+	if !e.Failed() {
+		return "✅ " + e.Result
+	}
+	message, _, _ := strings.Cut(e.Error, "\n")
+	if len(message) > 60 {
+		message = message[:57] + "..."
+	}
+	return "❌ " + message
+}
+
+// recordHistory appends a finished mutating command to the history. Failing
+// to record never fails the command itself.
+func recordHistory(cmd *cobra.Command, runErr error) {
+	if cmd == nil || commandStarted.IsZero() {
+		return
+	}
+	command := strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+	if !historyCommands[command] {
+		return
+	}
+
+	entry := history.Entry{
+		Time:     commandStarted,
+		Command:  command,
+		Args:     history.RedactArgs(commandArgs(cmd), strings.HasPrefix(command, "secret ")),
+		Context:  contextName,
+		Env:      envName,
+		Result:   history.ResultOK,
+		Duration: time.Since(commandStarted).Round(time.Millisecond).String(),
+	}
+	if cfg, err := config.LoadConfig(); err == nil {
+		if entry.Context == "" {
+			entry.Context = cfg.ActiveContextName()
+		}
+		if entry.Env == "" {
+			entry.Env = cfg.CurrentEnv
+		}
+	}
+	if runErr != nil {
+		entry.Result = history.ResultFailed
+		entry.Error = runErr.Error()
+	}
+
+	path, err := history.DefaultPath()
+	if err != nil {
+		return
+	}
+	_ = history.Append(path, entry)
+}
+
+// commandArgs returns the command line after the command path, i.e. the
+// arguments and flags the user gave
+func commandArgs(cmd *cobra.Command) []string {
+	args := os.Args[1:]
+	for _, word := range strings.Fields(cmd.CommandPath())[1:] {
+		for i, arg := range args {
+			if arg == word {
+				args = append(args[:i:i], args[i+1:]...)
+				break
+			}
+		}
+	}
+	return args
+}
@@ -3,12 +3,17 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
 	"github.com/lissto-dev/cli/pkg/config"
 	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/spinner"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +21,9 @@ var (
 	loginContextName      string
 	loginServiceName      string
 	loginServiceNamespace string
+	loginWeb              bool
+	loginAll              bool
+	loginKubeconfig       string
 )
 
 // loginCmd represents the login command
@@ -29,9 +37,33 @@ authenticates with the provided API key, and sets up a new context for future co
 The API key can be provided as an argument or will be prompted interactively.
 The context name is automatically set based on your k8s context. Use --name to override.
 
+With --web, no API key is needed: the Lissto web console opens in the browser
+to approve the login, and the CLI receives a short-lived access token that is
+renewed automatically. Tokens are stored like API keys: in the config file, or
+in the OS keychain with 'lissto config set credential-store keychain'. Use API
+keys for CI and other non-interactive logins.
+
+With --all, every kubeconfig context is probed for the Lissto API service and
+a context is created, named after the kube context, for each one that
+responds. The API key argument is used for all of them; without it, each
+login is approved in the browser with --web or an API key is prompted for
+(leave it empty to skip the cluster). Kube contexts that already have a
+context are skipped. A summary table lists the outcome for every kube context.
+
+With --kubeconfig, the cluster is read from that kubeconfig file instead of
+$KUBECONFIG or ~/.kube/config. The file is saved with the context and used by
+every command run in it, whatever kubectl's current context is.
+
+In a pod without a kubeconfig (e.g. a CI runner), the context targets the
+cluster the pod runs in: the pod's service account is used and the API is
+reached through its service DNS name instead of a port-forward.
+
 Examples:
   lissto login                          # Interactive mode, prompts for API key
   lissto login abc123                   # Provide API key as argument
+  lissto login --web                    # Approve the login in the browser
+  lissto login --all --web              # Log in to every cluster running Lissto
+  lissto login --kubeconfig ~/.kube/staging.yaml  # Cluster from its own kubeconfig file
   lissto login --service my-api --namespace my-ns  # Custom service location`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runLogin,
@@ -42,22 +74,45 @@ func init() {
 	loginCmd.Flags().StringVar(&loginContextName, "name", "", "Name for the context (defaults to k8s context)")
 	loginCmd.Flags().StringVar(&loginServiceName, "service", "lissto-api", "Name of the Lissto API service")
 	loginCmd.Flags().StringVar(&loginServiceNamespace, "namespace", "lissto-system", "Namespace of the Lissto API service")
+	loginCmd.Flags().BoolVar(&loginWeb, "web", false, "Log in through the web console instead of with an API key")
+	loginCmd.Flags().BoolVar(&loginAll, "all", false, "Create a context for every kube context running the Lissto API")
+	loginCmd.Flags().StringVar(&loginKubeconfig, "kubeconfig", "", "Kubeconfig file of the cluster, saved with the context (default: $KUBECONFIG or ~/.kube/config)")
 }
 
 func runLogin(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if loginWeb && len(args) > 0 {
+		return fmt.Errorf("--web cannot be combined with an API key")
+	}
+	if loginKubeconfig != "" {
+		// Saved with the context, so it must not depend on the working directory
+		path, err := filepath.Abs(loginKubeconfig)
+		if err != nil {
+			return fmt.Errorf("invalid --kubeconfig: %w", err)
+		}
+		loginKubeconfig = path
+	}
+	if loginAll {
+		if loginContextName != "" {
+			return fmt.Errorf("--all names contexts after their kube context and cannot be combined with --name")
+		}
+		return runLoginAll(cmd, args)
+	}
+
 	// Step 1: Get current k8s context
-	kubeContext, err := k8s.GetCurrentKubeContext()
+	kubeContext, err := k8s.KubeconfigCurrentContext(loginKubeconfig)
 	if err != nil {
 		return fmt.Errorf("failed to get current k8s context: %w\nMake sure you have a valid kubeconfig", err)
 	}
 
 	fmt.Printf("Using Kubernetes context: %s\n", kubeContext)
 
-	// Step 2: Get API key (from arg or prompt)
+	// Step 2: Get API key (from arg or prompt), unless logging in with the browser
 	var apiKey string
 	if len(args) > 0 {
 		apiKey = args[0]
-	} else {
+	} else if !loginWeb {
 		// Interactive prompt for API key
 		prompt := &survey.Password{
 			Message: "Enter your API key:",
@@ -67,45 +122,61 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if apiKey == "" {
+	if apiKey == "" && !loginWeb {
 		return fmt.Errorf("API key is required")
 	}
 
 	// Step 3: Create k8s client for current context
-	fmt.Println("Connecting to Kubernetes cluster...")
-	k8sClient, err := k8s.NewClientWithContext(kubeContext)
+	steps := spinner.NewSteps(os.Stdout, 3)
+	spin := steps.Next("Connecting to Kubernetes cluster")
+	k8sClient, err := k8s.NewClientWithContext(loginKubeconfig, kubeContext)
 	if err != nil {
+		spin.Fail("Failed to connect to Kubernetes")
 		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
 	}
+	spin.Success("Connected to Kubernetes cluster")
 
-	// Step 4: Discover API endpoint with fast discovery (opens port-forward once, gets all info)
-	fmt.Printf("Discovering Lissto API service (%s/%s)...\n", loginServiceNamespace, loginServiceName)
-	discoveryInfo, err := k8sClient.DiscoverAPIEndpointFast(
-		context.Background(),
-		loginServiceName,
-		loginServiceNamespace,
-	)
+	// Step 4: Discover API endpoint with fast discovery (opens port-forward once, gets all info),
+	// or through the service DNS name when running in the cluster
+	spin = steps.Next(fmt.Sprintf("Discovering Lissto API service (%s/%s)", loginServiceNamespace, loginServiceName))
+	discover := k8sClient.DiscoverAPIEndpointFast
+	if kubeContext == k8s.InClusterContext {
+		discover = k8sClient.DiscoverAPIEndpointInCluster
+	}
+	discoveryInfo, err := discover(ctx, loginServiceName, loginServiceNamespace)
 	if err != nil {
+		spin.Fail("Failed to discover the Lissto API service")
 		return fmt.Errorf("failed to discover API endpoint: %w\nMake sure the service exists in the cluster", err)
 	}
+	spin.Success("Discovered Lissto API service")
 
-	// Use public URL if available, otherwise use the port-forward URL we already established
-	apiURL := discoveryInfo.PublicURL
-	if apiURL == "" {
-		apiURL = discoveryInfo.PortForwardURL
-	}
+	// Use the cluster or public URL if available, otherwise the port-forward URL we already established
+	apiURL := discoveryInfo.URL()
 
 	// Step 5: Test authentication
-	fmt.Println("Authenticating...")
 	apiClient := client.NewClient(apiURL, apiKey)
+	var token *client.Token
+	if loginWeb {
+		if token, err = webLogin(ctx, apiClient); err != nil {
+			return err
+		}
+		apiClient.SetToken(token.AccessToken)
+	}
+	spin = steps.Next("Authenticating")
 
-	user, err := apiClient.GetCurrentUser()
+	user, err := apiClient.GetCurrentUser(ctx)
 	if err != nil {
+		spin.Fail("Authentication failed")
 		return fmt.Errorf("authentication failed: %w", err)
 	}
+	spin.Stop()
 
 	fmt.Printf("✓ Logged in as: %s (role: %s)\n", user.Name, user.Role)
 
+	// Warn early if the server is outside the supported version range, or
+	// doesn't say which version it runs
+	warnIfIncompatible(ctx, apiClient)
+
 	// Step 6: Determine context name
 	ctxName := loginContextName
 	if ctxName == "" {
@@ -125,67 +196,56 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	}
 
 	// Step 8: Create and save new context with discovered API info
-	ctx := config.Context{
+	lisstoCtx := config.Context{
 		Name:             ctxName,
 		KubeContext:      kubeContext,
+		Kubeconfig:       loginKubeconfig,
 		ServiceName:      loginServiceName,
 		ServiceNamespace: loginServiceNamespace,
 		APIKey:           apiKey,
-		APIUrl:           discoveryInfo.PublicURL, // Cache public URL (empty if not available)
-		APIID:            discoveryInfo.APIID,     // Cache API instance ID
+		APIUrl:           discoveryInfo.SavedURL(), // Cache cluster or public URL (empty if not available)
+		APIID:            discoveryInfo.APIID,      // Cache API instance ID
+	}
+	if token != nil {
+		lisstoCtx.AccessToken = token.AccessToken
+		lisstoCtx.RefreshToken = token.RefreshToken
+		lisstoCtx.TokenExpiry = token.Expiry(time.Now())
 	}
-	cfg.AddOrUpdateContext(ctx)
-	cfg.CurrentContext = ctxName
 
 	// Step 9: Fetch and cache environments
-	envList, err := apiClient.ListEnvs()
+	var defaultEnv string
+	envs, err := cmdutil.ListEnvsCached(ctx, apiClient, ctxName, true)
 	if err != nil {
 		fmt.Printf("Warning: failed to fetch environments: %v\n", err)
 	} else {
-		envCache := &config.EnvCache{
-			TTL: 300, // 5 minutes
-		}
-
-		var envs []config.EnvInfo
-		for _, env := range envList {
-			// Parse namespace from ID (format: "namespace/envname")
-			namespace := ""
-			if idx := strings.Index(env.ID, "/"); idx != -1 {
-				namespace = env.ID[:idx]
-			}
-			envs = append(envs, config.EnvInfo{
-				Name:      env.Name,
-				Namespace: namespace,
-			})
-		}
-		envCache.UpdateEnvs(envs)
-
-		if err := config.SaveEnvCache(envCache); err != nil {
-			fmt.Printf("Warning: failed to save environment cache: %v\n", err)
-		} else {
-			fmt.Printf("✓ Discovered %d environment(s):\n", len(envs))
-			for _, env := range envs {
-				fmt.Printf("  - %s\n", env.Name)
-			}
+		fmt.Printf("✓ Discovered %d environment(s):\n", len(envs))
+		for _, env := range envs {
+			fmt.Printf("  - %s\n", env.Name)
 		}
 
 		// Set default environment
 		if len(envs) > 0 {
 			// Prefer user's own environment, otherwise use first one
-			defaultEnv := envs[0].Name
+			defaultEnv = envs[0].Name
 			for _, env := range envs {
 				if strings.Contains(env.Name, user.Name) {
 					defaultEnv = env.Name
 					break
 				}
 			}
-			cfg.CurrentEnv = defaultEnv
 			fmt.Printf("✓ Set current environment to: %s\n", defaultEnv)
 		}
 	}
 
-	// Step 10: Save config
-	if err := config.SaveConfig(cfg); err != nil {
+	// Step 10: Save config, on top of changes made since it was loaded
+	if err := config.UpdateConfig(func(cfg *config.Config) error {
+		cfg.AddOrUpdateContext(lisstoCtx)
+		cfg.CurrentContext = ctxName
+		if defaultEnv != "" {
+			cfg.CurrentEnv = defaultEnv
+		}
+		return nil
+	}); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
@@ -195,3 +255,26 @@ func runLogin(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// webLogin asks the user to approve the login in the web console and waits
+// for the token
+func webLogin(ctx context.Context, apiClient *client.Client) (*client.Token, error) {
+	auth, err := apiClient.StartDeviceLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("\nConfirm the code %s in the web console:\n  %s\n\n", auth.UserCode, auth.URL())
+	if err := cmdutil.OpenBrowser(auth.URL()); err != nil {
+		fmt.Println("Open the link above in a browser to continue.")
+	}
+
+	spin := spinner.StartTransient(os.Stdout, "Waiting for approval in the browser")
+	token, err := apiClient.PollDeviceToken(ctx, auth)
+	if err != nil {
+		spin.Fail("Browser login failed")
+		return nil, err
+	}
+	spin.Success("Login approved")
+	return token, nil
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/spinner"
+	"github.com/spf13/cobra"
+)
+
+// loginProbeTimeout bounds the API discovery in each kube context
+const loginProbeTimeout = 15 * time.Second
+
+// Outcomes of logging in to a kube context with --all
+const (
+	loginCreated = "created"
+	loginExists  = "exists"
+	loginNoAPI   = "no API"
+	loginSkipped = "skipped"
+	loginFailed  = "failed"
+)
+
+var (
+	// errLoginSkipped means no API key was entered for a cluster
+	errLoginSkipped = errors.New("no API key entered")
+	// errNoLisstoAPI means a cluster doesn't run the Lissto API service, or
+	// can't be reached
+	errNoLisstoAPI = errors.New("lissto API not found")
+)
+
+// loginResult is the outcome of logging in to one kube context
+type loginResult struct {
+	kubeContext string
+	outcome     string
+	detail      string
+}
+
+// runLoginAll creates a context for every kube context whose cluster runs
+// the Lissto API
+func runLoginAll(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	kubeContexts, err := k8s.ListKubeContexts(loginKubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to list kube contexts: %w\nMake sure you have a valid kubeconfig", err)
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	existing := make(map[string]string)
+	for _, c := range cfg.Contexts {
+		if c.Kubeconfig == loginKubeconfig {
+			existing[c.KubeContext] = c.Name
+		}
+	}
+
+	var apiKey string
+	if len(args) > 0 {
+		apiKey = args[0]
+	}
+
+	var results []loginResult
+	var created []config.Context
+	for _, kubeContext := range kubeContexts {
+		if name, ok := existing[kubeContext]; ok {
+			results = append(results, loginResult{kubeContext, loginExists, "context '" + name + "'"})
+			continue
+		}
+		if _, err := cfg.GetContext(kubeContext); err == nil {
+			results = append(results, loginResult{kubeContext, loginExists, "a context with this name exists"})
+			continue
+		}
+
+		lisstoCtx, user, err := loginKubeContext(ctx, kubeContext, apiKey)
+		switch {
+		case errors.Is(err, errLoginSkipped):
+			results = append(results, loginResult{kubeContext, loginSkipped, err.Error()})
+		case errors.Is(err, errNoLisstoAPI):
+			results = append(results, loginResult{kubeContext, loginNoAPI, err.Error()})
+		case err != nil:
+			results = append(results, loginResult{kubeContext, loginFailed, err.Error()})
+		default:
+			created = append(created, *lisstoCtx)
+			results = append(results, loginResult{kubeContext, loginCreated, fmt.Sprintf("logged in as %s (role: %s)", user.Name, user.Role)})
+		}
+	}
+
+	if len(created) > 0 {
+		if err := config.UpdateConfig(func(cfg *config.Config) error {
+			for _, c := range created {
+				cfg.AddOrUpdateContext(c)
+			}
+			if cfg.CurrentContext == "" {
+				cfg.CurrentContext = created[0].Name
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	fmt.Println()
+	rows := make([][]string, 0, len(results))
+	failed := 0
+	for _, r := range results {
+		outcome := r.outcome
+		switch r.outcome {
+		case loginCreated:
+			outcome = output.Green("✅ " + outcome)
+		case loginFailed:
+			outcome = output.Red("❌ " + outcome)
+			failed++
+		default:
+			outcome = output.Gray(outcome)
+		}
+		rows = append(rows, []string{r.kubeContext, outcome, r.detail})
+	}
+	output.PrintTable(os.Stdout, []string{"KUBE CONTEXT", "RESULT", "DETAIL"}, rows)
+	fmt.Printf("\nCreated %d context(s)\n", len(created))
+
+	if failed > 0 {
+		return fmt.Errorf("failed to log in to %d kube context(s)", failed)
+	}
+	return nil
+}
+
+// loginKubeContext discovers the Lissto API of a kube context and logs in
+// with apiKey, the browser (--web) or a prompted API key
+func loginKubeContext(ctx context.Context, kubeContext, apiKey string) (*config.Context, *client.User, error) {
+	spin := spinner.StartTransient(os.Stdout, "Probing "+kubeContext)
+	k8sClient, err := k8s.NewClientWithContext(loginKubeconfig, kubeContext)
+	if err != nil {
+		spin.Stop()
+		return nil, nil, err
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, loginProbeTimeout)
+	defer cancel()
+	discoveryInfo, err := k8sClient.DiscoverAPIEndpointFast(probeCtx, loginServiceName, loginServiceNamespace)
+	spin.Stop()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", errNoLisstoAPI, err)
+	}
+	if discoveryInfo.StopPortForward != nil {
+		defer discoveryInfo.StopPortForward()
+	}
+
+	apiURL := discoveryInfo.PublicURL
+	if apiURL == "" {
+		apiURL = discoveryInfo.PortForwardURL
+	}
+	fmt.Printf("🔑 %s: Lissto API found\n", kubeContext)
+
+	var token *client.Token
+	switch {
+	case apiKey != "":
+	case loginWeb:
+		if token, err = webLogin(ctx, client.NewClient(apiURL, "")); err != nil {
+			return nil, nil, err
+		}
+	default:
+		prompt := &survey.Password{Message: fmt.Sprintf("API key for %s (empty to skip):", kubeContext)}
+		if err := survey.AskOne(prompt, &apiKey); err != nil {
+			return nil, nil, fmt.Errorf("cancelled: %w", err)
+		}
+		if apiKey == "" {
+			return nil, nil, errLoginSkipped
+		}
+	}
+
+	apiClient := client.NewClient(apiURL, apiKey)
+	if token != nil {
+		apiClient.SetToken(token.AccessToken)
+	}
+	user, err := apiClient.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	lisstoCtx := &config.Context{
+		Name:             kubeContext,
+		KubeContext:      kubeContext,
+		Kubeconfig:       loginKubeconfig,
+		ServiceName:      loginServiceName,
+		ServiceNamespace: loginServiceNamespace,
+		APIKey:           apiKey,
+		APIUrl:           discoveryInfo.PublicURL,
+		APIID:            discoveryInfo.APIID,
+	}
+	if token != nil {
+		lisstoCtx.AccessToken = token.AccessToken
+		lisstoCtx.RefreshToken = token.RefreshToken
+		lisstoCtx.TokenExpiry = token.Expiry(time.Now())
+	}
+	return lisstoCtx, user, nil
+}
@@ -2,19 +2,24 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
-	"github.com/lissto-dev/cli/pkg/client"
-	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
 	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/logfilter"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/status"
 	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 var (
@@ -22,12 +27,23 @@ var (
 	logsTimestamps bool
 	logsTail       int64
 	logsSince      string
+	logsSinceTime  string
+	logsUntil      string
 	logsStack      string
 	logsService    string
 	logsPod        string
 	logsContainer  string
 	logsEnv        string
 	logsMaxPods    int
+	logsGrep       []string
+	logsInvert     bool
+	logsLevel      string
+	logsHighlight  bool
+	logsPrevious   bool
+	logsInit       bool
+
+	// logsSelector is the parsed --selector
+	logsSelector = labels.Everything()
 )
 
 var logsCmd = &cobra.Command{
@@ -38,11 +54,19 @@ var logsCmd = &cobra.Command{
 Use filters to narrow down what logs to stream:
   --stack      Filter by stack name
   --env        Filter by environment
+  -l           Filter by stack labels, e.g. -l team=payments
   --service    Filter by service name
   --pod        Filter by specific pod name
-  --container  Filter by container name
+  --container  Filter by container name (init containers included)
   --max-pods   Maximum number of pods to stream (default 10)
 
+Filter lines client-side:
+  --grep       Show lines matching a regular expression (repeatable; any
+               pattern matches; use (?i) to ignore case)
+  --invert     Hide matching lines instead
+  --level      Show lines at this level or above (debug, info, warn, error),
+               detected from JSON, logfmt, [LEVEL], LEVEL and klog formats
+
 Examples:
   # Stream logs from all stacks (default)
   lissto logs
@@ -59,14 +83,51 @@ Examples:
   # Combine filters
   lissto logs --env dev --service api --tail 100
 
+  # Follow the api of every stack labeled for a team
+  lissto logs -l team=payments --service api -f
+
   # Show last 100 lines from specific pod
   lissto logs --pod frontend-abc123 --tail 100
 
   # Show logs from last 5 minutes
   lissto logs --since 5m
 
+  # Show an exact incident window
+  lissto logs --stack my-stack --since-time 2025-01-02T03:00:00Z --until 2025-01-02T03:15:00Z
+
   # Allow more pods to stream
-  lissto logs --max-pods 50`,
+  lissto logs --max-pods 50
+
+  # Follow errors across a stack, highlighting timeouts
+  lissto logs --stack my-stack -f --level error --grep '(?i)timeout'
+
+  # Show why a crashed container restarted
+  lissto logs --stack my-stack --service api --previous
+
+  # Include init containers (migrations, config rendering, ...)
+  lissto logs --stack my-stack --init-containers
+
+  # Hide health checks
+  lissto logs --stack my-stack --grep healthz --invert
+
+  # Follow a service in every context (dev and staging clusters, ...)
+  lissto logs --all-contexts --service api -f
+
+  # Emit JSON lines for jq or a log aggregator
+  lissto logs --stack my-stack -o json | jq 'select(.service == "api")'
+
+--since-time and --until select an absolute window. --until also takes a
+duration before now (e.g. 10m); lines logged later are cut off client-side.
+Without an explicit --tail, the whole window is shown.
+
+With -f, pods that restart or get replaced keep being followed: streams are
+reattached automatically, new pods are picked up, and restarts are shown as
+"--- pod X container Y restarted ---" lines.
+
+With -o json each line is printed as a JSON object with pod, container,
+service, stack, timestamp and message fields. Restart and pod lifecycle
+lines have "marker": true. With --all-contexts, pods are prefixed with their
+context and JSON lines get a context field.`,
 	Args:          cobra.NoArgs,
 	RunE:          runLogs,
 	SilenceUsage:  true,
@@ -79,114 +140,86 @@ func init() {
 	logsCmd.Flags().BoolVar(&logsTimestamps, "timestamps", false, "Include timestamps in output")
 	logsCmd.Flags().Int64Var(&logsTail, "tail", 10, "Number of lines to show from end of logs (use -1 for all)")
 	logsCmd.Flags().StringVar(&logsSince, "since", "", "Show logs since duration (e.g., 5s, 2m, 3h)")
+	logsCmd.Flags().StringVar(&logsSinceTime, "since-time", "", "Show logs since an RFC3339 timestamp (e.g. 2025-01-02T03:00:00Z)")
+	logsCmd.Flags().StringVar(&logsUntil, "until", "", "Hide logs after an RFC3339 timestamp or a duration before now")
+	logsCmd.MarkFlagsMutuallyExclusive("since", "since-time")
+	logsCmd.MarkFlagsMutuallyExclusive("until", "follow")
 	logsCmd.Flags().StringVar(&logsStack, "stack", "", "Filter by stack name")
 	logsCmd.Flags().StringVar(&logsService, "service", "", "Filter by service name")
 	logsCmd.Flags().StringVar(&logsPod, "pod", "", "Filter by specific pod name")
 	logsCmd.Flags().StringVar(&logsContainer, "container", "", "Filter by container name")
 	logsCmd.Flags().StringVar(&logsEnv, "env", "", "Filter by environment")
+	cmdutil.AddSelectorFlag(logsCmd)
 	logsCmd.Flags().IntVar(&logsMaxPods, "max-pods", 10, "Maximum number of pods to stream logs from")
+	logsCmd.Flags().StringArrayVar(&logsGrep, "grep", nil, "Only show lines matching this regular expression (repeatable)")
+	logsCmd.Flags().BoolVar(&logsInvert, "invert", false, "With --grep, hide matching lines instead")
+	logsCmd.Flags().StringVar(&logsLevel, "level", "", "Only show lines at this level or above: debug, info, warn, error")
+	logsCmd.Flags().BoolVar(&logsHighlight, "highlight", true, "Highlight --grep matches in color")
+	logsCmd.Flags().BoolVarP(&logsPrevious, "previous", "p", false, "Show logs of the previous, terminated container instance")
+	logsCmd.Flags().BoolVar(&logsInit, "init-containers", false, "Include init containers")
+	cmdutil.AddAllContextsFlag(logsCmd)
 }
 
 func runLogs(cmd *cobra.Command, args []string) error {
-	// Load config
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Get current context
-	ctx, err := cfg.GetCurrentContext()
-	if err != nil {
-		return fmt.Errorf("no active context. Run 'lissto login' first: %w", err)
-	}
+	ctx := cmd.Context()
 
-	// Create API client with k8s discovery and validation
-	apiClient, err := client.NewClientFromConfig(ctx)
+	filter, err := newLogFilter()
 	if err != nil {
-		return fmt.Errorf("failed to initialize API client: %w", err)
-	}
-
-	// Get stacks
-	allStacks, err := apiClient.ListStacks("")
-	if err != nil {
-		return fmt.Errorf("failed to list stacks: %w", err)
-	}
-
-	if len(allStacks) == 0 {
-		return fmt.Errorf("no stacks found")
+		return err
 	}
-
-	// Filter stacks by provided filters
-	targetStacks := make([]interface{}, 0, len(allStacks))
-	for _, stack := range allStacks {
-		// Filter by stack name
-		if logsStack != "" && stack.Name != logsStack {
-			continue
-		}
-
-		// Filter by environment
-		if logsEnv != "" && stack.Spec.Env != logsEnv {
-			continue
-		}
-
-		targetStacks = append(targetStacks, stack)
+	if logsPrevious && logsFollow {
+		return fmt.Errorf("--previous cannot be combined with --follow: a terminated container's log is complete")
 	}
-
-	if len(targetStacks) == 0 {
-		return fmt.Errorf("no stacks match the filters")
+	if logsSelector, err = cmdutil.StackSelector(cmd); err != nil {
+		return err
 	}
 
-	// Create k8s client
-	k8sClient, err := k8s.NewClient()
+	allContexts := cmdutil.AllContexts(cmd)
+	results, err := cmdutil.ForEachContext(cmd, func(ctx context.Context, target cmdutil.ContextTarget) (*logTarget, error) {
+		return findLogTarget(ctx, target)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create kubernetes client: %w", err)
+		return err
 	}
 
-	// Collect all pods from target stacks
-	podCtx := context.Background()
-	var allPods []corev1.Pod
-
-	for _, s := range targetStacks {
-		stack := s.(envv1alpha1.Stack)
-
-		labels := map[string]string{
-			"lissto.dev/stack": stack.Name,
-		}
-
-		pods, err := k8sClient.ListPods(podCtx, stack.Namespace, labels)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to list pods for stack %s: %v\n", stack.Name, err)
-			continue
-		}
-
-		allPods = append(allPods, pods...)
+	// Merge the counts so filter errors read the same with one or many contexts
+	targets := make([]*logTarget, 0, len(results))
+	var allStacks, targetStacks, allPods, filteredPods int
+	for _, result := range results {
+		target := result.Value
+		targets = append(targets, target)
+		allStacks += target.stackCount
+		targetStacks += len(target.stacks)
+		allPods += target.podCount
+		filteredPods += len(target.pods)
 	}
 
-	if len(allPods) == 0 {
+	switch {
+	case allStacks == 0:
+		return fmt.Errorf("no stacks found")
+	case targetStacks == 0:
+		return fmt.Errorf("no stacks match the filters")
+	case allPods == 0:
 		return fmt.Errorf("no pods found")
-	}
-
-	// Filter pods by service/pod name
-	filteredPods := filterPods(allPods, logsService, logsPod)
-	if len(filteredPods) == 0 {
+	case filteredPods == 0:
 		return fmt.Errorf("no pods match the filters")
 	}
 
 	// Check max-pods limit
-	if len(filteredPods) > logsMaxPods {
+	if filteredPods > logsMaxPods {
 		return fmt.Errorf("found %d pods but max-pods is set to %d. Use --max-pods to increase the limit or add filters (--service, --pod, --env)",
-			len(filteredPods), logsMaxPods)
+			filteredPods, logsMaxPods)
 	}
 
+	jsonOutput := cmdutil.GetOutputFormat(cmd) == outputFormatJSON
+
 	// Parse log options
 	logOpts := k8s.LogOptions{
-		Follow:     logsFollow,
-		Timestamps: logsTimestamps,
-		Container:  logsContainer,
-	}
-
-	if logsTail >= 0 {
-		logOpts.TailLines = &logsTail
+		Follow:         logsFollow,
+		Timestamps:     logsTimestamps || jsonOutput,
+		Container:      logsContainer,
+		Previous:       logsPrevious,
+		InitContainers: logsInit,
 	}
 
 	if logsSince != "" {
@@ -196,9 +229,36 @@ func runLogs(cmd *cobra.Command, args []string) error {
 		}
 		logOpts.Since = &duration
 	}
+	if logsSinceTime != "" {
+		sinceTime, err := time.Parse(time.RFC3339, logsSinceTime)
+		if err != nil {
+			return fmt.Errorf("invalid --since-time value: use an RFC3339 timestamp such as 2025-01-02T03:00:00Z")
+		}
+		logOpts.SinceTime = &sinceTime
+	}
+	if logsUntil != "" {
+		until, err := k8s.ParseLogTime(logsUntil, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid --until value: %w", err)
+		}
+		if logOpts.SinceTime != nil && !until.After(*logOpts.SinceTime) {
+			return fmt.Errorf("--until must be after --since-time")
+		}
+		logOpts.UntilTime = &until
+	}
+
+	// A window shows all of its lines unless --tail is given: tailing would
+	// keep the end of the log, past --until
+	windowed := logOpts.SinceTime != nil || logOpts.UntilTime != nil
+	if logsTail >= 0 && (!windowed || cmd.Flags().Changed("tail")) {
+		if logOpts.UntilTime != nil {
+			return fmt.Errorf("--tail cannot be combined with --until")
+		}
+		logOpts.TailLines = &logsTail
+	}
 
 	// Setup signal handling for graceful shutdown
-	logCtx, cancel := context.WithCancel(context.Background())
+	logCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	sigChan := make(chan os.Signal, 1)
@@ -210,14 +270,14 @@ func runLogs(cmd *cobra.Command, args []string) error {
 	}()
 
 	// Stream logs
-	logChan := make(chan k8s.LogLine, 100)
+	logChan := make(chan targetLogLine, 100)
 	errChan := make(chan error, 1)
 
 	// Show info about what we're streaming
 	if logsStack != "" {
-		fmt.Fprintf(os.Stderr, "📡 Streaming logs from %d pod(s) in stack '%s'...\n", len(filteredPods), logsStack)
+		fmt.Fprintf(os.Stderr, "📡 Streaming logs from %d pod(s) in stack '%s'...\n", filteredPods, logsStack)
 	} else {
-		fmt.Fprintf(os.Stderr, "📡 Streaming logs from %d pod(s) across %d stack(s)...\n", len(filteredPods), len(targetStacks))
+		fmt.Fprintf(os.Stderr, "📡 Streaming logs from %d pod(s) across %d stack(s)...\n", filteredPods, targetStacks)
 	}
 	if logsFollow {
 		fmt.Fprintln(os.Stderr, "Press Ctrl+C to stop.")
@@ -225,54 +285,114 @@ func runLogs(cmd *cobra.Command, args []string) error {
 	fmt.Fprintln(os.Stderr)
 
 	go func() {
-		// Group pods by namespace for streaming
-		podsByNamespace := make(map[string][]corev1.Pod)
-		for _, pod := range filteredPods {
-			podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
-		}
-
-		// Stream from each namespace
+		var wg sync.WaitGroup
+		var mu sync.Mutex
 		var streamErr error
-		for namespace, pods := range podsByNamespace {
-			err := k8sClient.StreamLogsMulti(logCtx, namespace, pods, logOpts, logChan)
-			if err != nil {
-				streamErr = err
+		for _, target := range targets {
+			// Group pods by namespace for streaming
+			podsByNamespace := make(map[string][]corev1.Pod)
+			for _, pod := range target.pods {
+				podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
 			}
+
+			// Stream from each namespace concurrently, tagging lines with
+			// the context they came from
+			lines := make(chan k8s.LogLine, 100)
+			var streams sync.WaitGroup
+			for namespace, pods := range podsByNamespace {
+				streams.Add(1)
+				go func() {
+					defer streams.Done()
+					refresh := refreshLogPods(target.k8sClient, target.stacks, namespace, target.sources)
+					if err := target.k8sClient.StreamLogsMulti(logCtx, namespace, pods, logOpts, refresh, lines); err != nil {
+						mu.Lock()
+						streamErr = err
+						mu.Unlock()
+					}
+				}()
+			}
+			go func() {
+				streams.Wait()
+				close(lines)
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for line := range lines {
+					logChan <- targetLogLine{LogLine: line, target: target}
+				}
+			}()
 		}
+		wg.Wait()
 
 		errChan <- streamErr
 		close(logChan)
 	}()
 
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		for logLine := range logChan {
+			if !logLine.Marker && !filter.Match(logLine.Message) {
+				continue
+			}
+			jsonLine := newJSONLogLine(logLine.LogLine, logLine.target.sources.get(logLine.PodName))
+			if allContexts {
+				jsonLine.Context = logLine.target.context
+			}
+			_ = encoder.Encode(jsonLine)
+		}
+		return <-errChan
+	}
+
 	// Print logs
 	colors := []string{
-		"\033[36m", // Cyan
-		"\033[33m", // Yellow
-		"\033[35m", // Magenta
-		"\033[32m", // Green
-		"\033[34m", // Blue
-		"\033[31m", // Red
+		output.ColorCyan,
+		output.ColorYellow,
+		output.ColorPurple,
+		output.ColorGreen,
+		output.ColorBlue,
+		output.ColorRed,
 	}
-	reset := "\033[0m"
+	highlightColor := "\033[1;31m" // Bold red
 
 	podColors := make(map[string]string)
 	colorIdx := 0
 
 	for logLine := range logChan {
+		if !logLine.Marker && !filter.Match(logLine.Message) {
+			continue
+		}
+
+		// Pods are named per context with --all-contexts
+		podName := logLine.PodName
+		if allContexts {
+			podName = logLine.target.context + ":" + podName
+		}
+
 		// Assign color to pod if not already assigned
-		if _, exists := podColors[logLine.PodName]; !exists {
-			podColors[logLine.PodName] = colors[colorIdx%len(colors)]
+		if _, exists := podColors[podName]; !exists {
+			podColors[podName] = colors[colorIdx%len(colors)]
 			colorIdx++
 		}
 
-		color := podColors[logLine.PodName]
-		prefix := fmt.Sprintf("%s[%s]%s", color, logLine.PodName, reset)
+		color := podColors[podName]
+		if logLine.Marker {
+			_, _ = fmt.Fprintln(os.Stdout, output.Colorize(color, "--- "+logLine.Message+" ---"))
+			continue
+		}
+
+		prefix := output.Colorize(color, "["+podName+"]")
 
 		if logsContainer == "" && logLine.Container != "" {
-			prefix = fmt.Sprintf("%s[%s/%s]%s", color, logLine.PodName, logLine.Container, reset)
+			prefix = output.Colorize(color, "["+podName+"/"+logLine.Container+"]")
 		}
 
-		_, _ = fmt.Fprintf(os.Stdout, "%s %s\n", prefix, logLine.Message)
+		message := logLine.Message
+		if logsHighlight && output.ColorEnabled() {
+			message = filter.Highlight(message, highlightColor, output.ColorReset)
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "%s %s\n", prefix, message)
 	}
 
 	// Check for errors
@@ -283,6 +403,197 @@ func runLogs(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// newLogFilter builds the line filter from --grep, --invert and --level
+func newLogFilter() (*logfilter.Filter, error) {
+	if logsInvert && len(logsGrep) == 0 {
+		return nil, fmt.Errorf("--invert requires --grep")
+	}
+
+	minLevel := logfilter.LevelUnknown
+	if logsLevel != "" {
+		level, err := logfilter.ParseLevel(logsLevel)
+		if err != nil {
+			return nil, err
+		}
+		minLevel = level
+	}
+
+	return logfilter.New(logsGrep, logsInvert, minLevel)
+}
+
+// logTarget is what to stream from one Lissto context
+type logTarget struct {
+	context    string
+	k8sClient  *k8s.Client
+	stackCount int
+	stacks     []interface{} // stacks matching --stack and --env
+	podCount   int
+	pods       []corev1.Pod // pods matching --service and --pod
+	sources    *logSources
+}
+
+// targetLogLine is a streamed line and the context it came from
+type targetLogLine struct {
+	k8s.LogLine
+	target *logTarget
+}
+
+// findLogTarget lists the stacks and pods of a context matching the filters
+func findLogTarget(ctx context.Context, contextTarget cmdutil.ContextTarget) (*logTarget, error) {
+	// Get stacks
+	allStacks, err := contextTarget.Client.ListStacks(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", err)
+	}
+
+	target := &logTarget{
+		context:    contextTarget.Context.Name,
+		stackCount: len(allStacks),
+		sources:    &logSources{byPod: make(map[string]logSource)},
+	}
+
+	// Filter stacks by provided filters
+	for _, stack := range allStacks {
+		// Filter by stack name
+		if logsStack != "" && stack.Name != logsStack {
+			continue
+		}
+
+		// Filter by environment
+		if logsEnv != "" && stack.Spec.Env != logsEnv {
+			continue
+		}
+
+		// Filter by stack labels
+		if !logsSelector.Matches(labels.Set(stack.Labels)) {
+			continue
+		}
+
+		target.stacks = append(target.stacks, stack)
+	}
+	if len(target.stacks) == 0 {
+		return target, nil
+	}
+
+	// Create k8s client
+	target.k8sClient, err = contextTarget.K8sClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	// Collect all pods from target stacks
+	var allPods []corev1.Pod
+	for _, s := range target.stacks {
+		stack := s.(envv1alpha1.Stack)
+		pods, err := listStackLogPods(ctx, target.k8sClient, &stack, target.sources)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to list pods for stack %s: %v\n", stack.Name, err)
+			continue
+		}
+		allPods = append(allPods, pods...)
+	}
+
+	// Filter pods by service/pod name
+	target.podCount = len(allPods)
+	target.pods = filterPods(allPods, logsService, logsPod)
+	return target, nil
+}
+
+// logSource identifies the stack and service a pod belongs to
+type logSource struct {
+	stack   string
+	service string
+}
+
+// logSources maps pod names to their source; pods are added while streaming
+type logSources struct {
+	mu    sync.Mutex
+	byPod map[string]logSource
+}
+
+func (s *logSources) get(pod string) logSource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.byPod[pod]
+}
+
+func (s *logSources) set(pod string, source logSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byPod[pod] = source
+}
+
+// listStackLogPods lists the pods of a stack and records their sources
+func listStackLogPods(ctx context.Context, k8sClient *k8s.Client, stack *envv1alpha1.Stack, sources *logSources) ([]corev1.Pod, error) {
+	pods, err := k8sClient.ListPods(ctx, stack.Namespace, status.StackPodLabels(stack.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]string, 0, len(stack.Spec.Images))
+	for name := range stack.Spec.Images {
+		services = append(services, name)
+	}
+	for i := range pods {
+		sources.set(pods[i].Name, logSource{
+			stack:   stack.Name,
+			service: status.PodServiceName(&pods[i], services),
+		})
+	}
+	return pods, nil
+}
+
+// refreshLogPods re-lists the filtered pods of the target stacks in a
+// namespace, so followed logs pick up new replicas and recreated pods
+func refreshLogPods(k8sClient *k8s.Client, stacks []interface{}, namespace string, sources *logSources) k8s.PodLister {
+	return func(ctx context.Context) ([]corev1.Pod, error) {
+		var pods []corev1.Pod
+		for _, s := range stacks {
+			stack := s.(envv1alpha1.Stack)
+			if stack.Namespace != namespace {
+				continue
+			}
+			stackPods, err := listStackLogPods(ctx, k8sClient, &stack, sources)
+			if err != nil {
+				return nil, err
+			}
+			pods = append(pods, stackPods...)
+		}
+		return filterPods(pods, logsService, logsPod), nil
+	}
+}
+
+// jsonLogLine is a log line in JSON lines output
+type jsonLogLine struct {
+	Context   string    `json:"context,omitempty"`
+	Pod       string    `json:"pod"`
+	Container string    `json:"container"`
+	Service   string    `json:"service"`
+	Stack     string    `json:"stack"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+	Marker    bool      `json:"marker,omitempty"`
+}
+
+// newJSONLogLine converts a streamed line, taking the timestamp from the
+// Kubernetes prefix when present
+func newJSONLogLine(line k8s.LogLine, source logSource) jsonLogLine {
+	ts, message, ok := k8s.SplitTimestamp(line.Message)
+	if !ok || line.Marker {
+		ts, message = line.Timestamp, line.Message
+	}
+
+	return jsonLogLine{
+		Pod:       line.PodName,
+		Container: line.Container,
+		Service:   source.service,
+		Stack:     source.stack,
+		Timestamp: ts.UTC(),
+		Message:   message,
+		Marker:    line.Marker,
+	}
+}
+
 // filterPods filters pods by service name or pod name
 func filterPods(pods []corev1.Pod, serviceName, podName string) []corev1.Pod {
 	if podName != "" {
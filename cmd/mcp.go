@@ -1,34 +1,66 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/interactive"
 	"github.com/lissto-dev/cli/pkg/mcp"
 	"github.com/spf13/cobra"
 )
 
 var (
-	mcpLogFile string
+	mcpLogFile  string
+	mcpListen   string
+	mcpToken    string
+	mcpReadOnly bool
+	mcpAllow    []string
+	mcpDeny     []string
+	mcpConfirm  bool
+	mcpMaxConc  int
+	mcpAudit    string
 )
 
+// mcpTokenEnv is the environment variable holding the --listen bearer token
+const mcpTokenEnv = "LISSTO_MCP_TOKEN"
+
 // mcpCmd represents the mcp command
 var mcpCmd = &cobra.Command{
 	Use:   "mcp",
 	Short: "Start MCP (Model Context Protocol) server",
-	Long: `Start an MCP server that exposes lissto operations as tools.
+	Long: `Start an MCP server that exposes lissto operations as tools and
+stacks, logs and blueprints as resources.
 
 The MCP server communicates via JSON-RPC 2.0 over stdin/stdout and can be
 used by AI assistants like Claude Desktop and Cursor.
 
+With --listen, the server speaks HTTP instead, for remote or containerized
+agents:
+  GET  /sse       HTTP+SSE transport; POST messages to the announced endpoint
+  POST /mcp       Plain HTTP: one JSON-RPC message in, its response out
+
+Set --token (or LISSTO_MCP_TOKEN) to require "Authorization: Bearer <token>"
+on every HTTP request. Without a token the server only listens on loopback
+(":8765" binds to 127.0.0.1:8765) and refuses other addresses, since anyone
+who can reach it acts with your lissto credentials.
+
 The server uses your current lissto context (configured via 'lissto login')
 for authentication and API access.
 
 Example usage:
   lissto mcp
 
+  # Serve over HTTP with bearer-token auth
+  LISSTO_MCP_TOKEN=s3cret lissto mcp --listen :8765
+
 Configuration for Cursor:
   Add to your MCP settings:
   {
@@ -47,6 +79,38 @@ Available tools:
   - Admin operations (API key creation, force delete)
   - Status and logs (get stack status, retrieve logs)
 
+Available resources:
+  - lissto://stack/<env>/<name>   Stack status
+  - lissto://logs/<env>/<stack>   Recent stack logs
+  - lissto://blueprint/<id>       Blueprint compose content
+
+Requests are handled concurrently (up to --max-concurrency at a time), so a
+slow lissto_logs call doesn't block others. Clients can cancel a request with
+notifications/cancelled or $/cancelRequest; over POST /mcp, the cancellation
+must carry the same Mcp-Session-Id header as the request.
+
+Tool policy:
+  --read-only exposes only tools that don't change anything. --allow and
+  --deny take tool globs (the "lissto_" prefix is optional); deny wins over
+  allow. Defaults come from 'lissto config set mcp.read-only|mcp.allow|mcp.deny'.
+  --confirm asks on your terminal before every tool call that changes
+  something. It needs a controlling terminal, so it fails at startup when
+  the server is launched by an editor or runs in a container; use the
+  policy flags there instead. Blocked calls fail with a "permission denied"
+  error (-32003).
+
+  lissto mcp --read-only
+  lissto mcp --deny tools=stack_delete,blueprint_delete,secret_set
+  lissto mcp --allow tools=env_*,stack_* --confirm
+
+Audit log:
+  --audit-file appends a JSON line for every call of a tool that changes
+  something, including blocked calls: time, context, tool, arguments (secret
+  values redacted), caller (transport, address, client name), outcome and
+  error. Read-only tools are not recorded.
+
+  lissto mcp --listen :8765 --audit-file /var/log/lissto-mcp-audit.jsonl
+
 Prerequisites:
   - Run 'lissto login' to configure your context
   - Ensure you have a valid API key and active context`,
@@ -58,6 +122,14 @@ Prerequisites:
 func init() {
 	rootCmd.AddCommand(mcpCmd)
 	mcpCmd.Flags().StringVar(&mcpLogFile, "log-file", "/tmp/lissto-mcp.log", "Path to log file for debugging MCP server")
+	mcpCmd.Flags().StringVar(&mcpListen, "listen", "", "Serve over HTTP+SSE on this address (e.g. :8765) instead of stdio")
+	mcpCmd.Flags().StringVar(&mcpToken, "token", "", "Bearer token required by the HTTP transport (default $"+mcpTokenEnv+")")
+	mcpCmd.Flags().BoolVar(&mcpReadOnly, "read-only", false, "Only expose tools that don't change anything")
+	mcpCmd.Flags().StringArrayVar(&mcpAllow, "allow", nil, "Only allow these tools (tools=<glob>,...)")
+	mcpCmd.Flags().StringArrayVar(&mcpDeny, "deny", nil, "Never allow these tools (tools=<glob>,...)")
+	mcpCmd.Flags().BoolVar(&mcpConfirm, "confirm", false, "Ask on the terminal before tool calls that change something")
+	mcpCmd.Flags().StringVar(&mcpAudit, "audit-file", "", "Append a JSON line for every mutating tool call to this file")
+	mcpCmd.Flags().IntVar(&mcpMaxConc, "max-concurrency", mcp.DefaultMaxConcurrency, "Maximum number of requests handled at once")
 }
 
 func runMCP(cmd *cobra.Command, args []string) error {
@@ -68,6 +140,31 @@ func runMCP(cmd *cobra.Command, args []string) error {
 	}
 	defer func() { _ = server.Close() }()
 
+	policy, err := mcpPolicy(cmd)
+	if err != nil {
+		return err
+	}
+	server.SetPolicy(policy)
+	server.SetMaxConcurrency(mcpMaxConc)
+
+	if mcpAudit != "" {
+		audit, err := mcp.OpenAuditLog(mcpAudit)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = audit.Close() }()
+		if cfg, err := config.LoadConfig(); err == nil {
+			if lisstoCtx, err := cfg.GetCurrentContext(); err == nil {
+				audit.Context = lisstoCtx.Name
+			}
+		}
+		server.SetAuditLog(audit)
+	}
+
+	if mcpListen != "" {
+		return runMCPHTTP(cmd, server)
+	}
+
 	// Setup signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -75,7 +172,7 @@ func runMCP(cmd *cobra.Command, args []string) error {
 	// Run server in a goroutine
 	errChan := make(chan error, 1)
 	go func() {
-		errChan <- server.Run()
+		errChan <- server.Run(cmd.Context())
 	}()
 
 	// Wait for either server error or shutdown signal
@@ -90,3 +187,98 @@ func runMCP(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 }
+
+// runMCPHTTP serves the MCP server over HTTP until interrupted
+func runMCPHTTP(cmd *cobra.Command, server *mcp.Server) error {
+	token := mcpToken
+	if token == "" {
+		token = os.Getenv(mcpTokenEnv)
+	}
+
+	addr, err := mcp.ListenAddress(mcpListen, token)
+	if err != nil {
+		return err
+	}
+
+	handler := mcp.NewHTTPHandler(server, token)
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	// SSE streams never finish on their own; end them so Shutdown can complete
+	httpServer.RegisterOnShutdown(handler.Close)
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- httpServer.ListenAndServe()
+	}()
+
+	fmt.Fprintf(os.Stderr, "🔌 MCP server listening on %s (SSE: /sse, HTTP: /mcp)\n", addr)
+	if token == "" {
+		fmt.Fprintf(os.Stderr, "⚠️  No --token set: any local user or process can use your lissto credentials\n")
+	}
+
+	select {
+	case err := <-errChan:
+		return fmt.Errorf("MCP server error: %w", err)
+	case <-ctx.Done():
+		fmt.Fprintln(os.Stderr, "\nShutting down...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			_ = httpServer.Close()
+		}
+		return nil
+	}
+}
+
+// mcpPolicy builds the tool policy from the config and flags. --allow replaces
+// the configured allow list; --deny adds to the configured deny list.
+func mcpPolicy(cmd *cobra.Command) (*mcp.Policy, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	policy := &mcp.Policy{
+		ReadOnly: cfg.Settings.MCP.ReadOnly || mcpReadOnly,
+		Allow:    cfg.Settings.MCP.Allow,
+		Deny:     cfg.Settings.MCP.Deny,
+	}
+	if cmd.Flags().Changed("allow") {
+		policy.Allow = nil
+		for _, value := range mcpAllow {
+			tools, err := mcp.ParseToolList(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --allow: %w", err)
+			}
+			policy.Allow = append(policy.Allow, tools...)
+		}
+	}
+	for _, value := range mcpDeny {
+		tools, err := mcp.ParseToolList(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --deny: %w", err)
+		}
+		policy.Deny = append(policy.Deny, tools...)
+	}
+
+	if mcpConfirm {
+		if err := interactive.CheckTerminal(); err != nil {
+			return nil, fmt.Errorf("--confirm: %w", err)
+		}
+		// Clients may call tools concurrently; ask one question at a time
+		var mu sync.Mutex
+		policy.Confirm = func(ctx context.Context, tool string, args map[string]interface{}) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			argsJSON, _ := json.Marshal(args)
+			return interactive.ConfirmOnTerminal(fmt.Sprintf("Allow MCP client to call %s %s?", tool, argsJSON))
+		}
+	}
+	return policy, nil
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/notify"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/spf13/cobra"
+)
+
+// Outcomes 'lissto notify --on' accepts
+const (
+	notifyOnReady  = "ready"
+	notifyOnFailed = "failed"
+)
+
+var (
+	notifyStack   string
+	notifyOn      []string
+	notifyWebhook string
+	notifyTimeout time.Duration
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Notify when a stack becomes ready or fails",
+	Long: `Watch a stack's pods and send a notification when it becomes ready or
+one of its services fails, then exit.
+
+Notifications are shown on the desktop (notify-send on Linux, Notification
+Center on macOS) or posted to a Slack or Discord incoming webhook with
+--webhook. Readiness is checked the same way 'lissto status' and --wait do.
+
+Examples:
+  # Desktop notification once the stack is ready or broken
+  lissto notify --stack my-stack
+
+  # Only tell me about failures, in Slack
+  lissto notify --stack my-stack --on failed --webhook https://hooks.slack.com/services/...
+
+  # Or get notified right from a deploy
+  lissto update --stack my-stack --branch main --yes --wait --notify`,
+	Args:          cobra.NoArgs,
+	RunE:          runNotify,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.Flags().StringVar(&notifyStack, "stack", "", "Stack name")
+	notifyCmd.Flags().StringSliceVar(&notifyOn, "on", []string{notifyOnReady, notifyOnFailed}, "Outcomes to notify about: ready, failed")
+	notifyCmd.Flags().StringVar(&notifyWebhook, "webhook", "", "Slack or Discord incoming webhook URL (default: desktop notification)")
+	notifyCmd.Flags().DurationVar(&notifyTimeout, "timeout", time.Hour, "Stop watching after this long")
+}
+
+// addNotifyFlag adds --notify to a command with --wait
+func addNotifyFlag(cmd *cobra.Command, target *string) {
+	cmd.Flags().StringVar(target, "notify", "", "Notify when --wait finishes: 'desktop' or a Slack/Discord webhook URL")
+	cmd.Flags().Lookup("notify").NoOptDefVal = notify.Desktop
+}
+
+// validateNotifyFlag checks --notify, which only applies with --wait
+func validateNotifyFlag(target string, wait bool) error {
+	if target == "" {
+		return nil
+	}
+	if !wait {
+		return fmt.Errorf("--notify requires --wait")
+	}
+	return notify.ValidateTarget(target)
+}
+
+func runNotify(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	var onReady, onFailed bool
+	for _, on := range notifyOn {
+		switch on {
+		case notifyOnReady:
+			onReady = true
+		case notifyOnFailed:
+			onFailed = true
+		default:
+			return fmt.Errorf("invalid --on value '%s': use %s or %s", on, notifyOnReady, notifyOnFailed)
+		}
+	}
+	target := notify.Desktop
+	if notifyWebhook != "" {
+		target = notifyWebhook
+		if err := notify.ValidateTarget(target); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	stack, err := resolveStack(ctx, apiClient, notifyStack)
+	if err != nil {
+		return err
+	}
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	fmt.Printf("🔔 Watching stack '%s' (env: %s), notifying when %s. Press Ctrl+C to stop.\n",
+		stack.Name, stack.Spec.Env, formatNotifyOutcomes(onReady, onFailed))
+	until := func(r status.StackReport) bool {
+		return (onReady && status.StackReady(r)) || (onFailed && status.StackFailed(r))
+	}
+	fetch := stackFetcher(apiClient, stack.Name, stack.Spec.Env)
+	report, err := status.WaitForStackUntil(ctx, k8sClient, fetch, status.DefaultWaitInterval, notifyTimeout, nil, until)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !onReady && errors.Is(err, status.ErrWaitTimeout) {
+			fmt.Printf("✅ Stack '%s' didn't fail within %s\n", stack.Name, notifyTimeout)
+			return nil
+		}
+		return &exitError{code: exitCodeNotReady, err: err}
+	}
+
+	sendStackNotification(ctx, os.Stdout, target, stack.Name, stack.Spec.Env, report, nil)
+	if status.StackReady(report) {
+		fmt.Printf("✅ Stack '%s' is ready\n", stack.Name)
+		return nil
+	}
+	return &exitError{code: exitCodeNotReady, err: fmt.Errorf("stack '%s' failed", stack.Name)}
+}
+
+func formatNotifyOutcomes(onReady, onFailed bool) string {
+	switch {
+	case onReady && onFailed:
+		return "it is ready or fails"
+	case onReady:
+		return "it is ready"
+	}
+	return "it fails"
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/types"
+	"github.com/spf13/cobra"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+var (
+	openStack   string
+	openService string
+	openPrint   bool
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open",
+	Short: "Open a stack's exposed URL in the browser",
+	Long: `Open the exposed URL of a stack service in the default browser.
+
+URLs come from the stack (the same ones 'lissto status' shows). Services
+without one fall back to the ingress routing to them. When several URLs
+match you're asked to choose.
+
+Examples:
+  # Choose stack and URL interactively
+  lissto open
+
+  # Open the frontend of a stack
+  lissto open --stack my-stack --service frontend
+
+  # Just print the URL, e.g. for scripts
+  lissto open --stack my-stack --service api --print`,
+	Args:          cobra.NoArgs,
+	RunE:          runOpen,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+	openCmd.Flags().StringVar(&openStack, "stack", "", "Stack name")
+	openCmd.Flags().StringVar(&openService, "service", "", "Service name")
+	openCmd.Flags().BoolVar(&openPrint, "print", false, "Print the URL instead of opening it")
+}
+
+// exposedURL is a URL a stack service is reachable at
+type exposedURL struct {
+	Service string
+	URL     string
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	stack, err := resolveStack(ctx, apiClient, openStack)
+	if err != nil {
+		return err
+	}
+
+	urls := stackURLs(stack)
+	if len(urls) == 0 || (openService != "" && !hasServiceURL(urls, openService)) {
+		// Fall back to ingresses, e.g. for stacks deployed before URLs were recorded
+		urls = append(urls, ingressURLs(ctx, stack)...)
+	}
+
+	if openService != "" {
+		var matched []exposedURL
+		for _, u := range urls {
+			if u.Service == openService {
+				matched = append(matched, u)
+			}
+		}
+		if len(matched) == 0 {
+			return fmt.Errorf("service '%s' in stack '%s' has no exposed URL: %w", openService, stack.Name, client.ErrNotFound)
+		}
+		urls = matched
+	}
+
+	if len(urls) == 0 {
+		return fmt.Errorf("stack '%s' has no exposed URLs: %w", types.GetStackDisplayName(stack), client.ErrNotFound)
+	}
+
+	url, err := selectURL(urls)
+	if err != nil {
+		return err
+	}
+
+	if openPrint {
+		fmt.Println(url)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "🌐 Opening %s\n", url)
+	if err := cmdutil.OpenBrowser(url); err != nil {
+		return fmt.Errorf("%w; open %s manually", err, url)
+	}
+	return nil
+}
+
+// stackURLs returns the exposed URLs recorded on the stack, by service name
+func stackURLs(stack *types.Stack) []exposedURL {
+	var urls []exposedURL
+	for service, info := range stack.Spec.Images {
+		if info.URL != "" {
+			urls = append(urls, exposedURL{Service: service, URL: "https://" + info.URL})
+		}
+	}
+	sort.Slice(urls, func(i, j int) bool { return urls[i].Service < urls[j].Service })
+	return urls
+}
+
+// ingressURLs returns the hosts of the ingresses in the stack's namespace.
+// Lookup failures are ignored: ingresses are only a fallback.
+func ingressURLs(ctx context.Context, stack *types.Stack) []exposedURL {
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return nil
+	}
+	ingresses, err := k8sClient.ListIngresses(ctx, stack.Namespace, nil)
+	if err != nil {
+		return nil
+	}
+
+	var urls []exposedURL
+	seen := make(map[exposedURL]bool)
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		for _, rule := range ingress.Spec.Rules {
+			if rule.Host == "" || rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				if path.Backend.Service == nil {
+					continue
+				}
+				u := exposedURL{Service: path.Backend.Service.Name, URL: ingressScheme(ingress, rule.Host) + "://" + rule.Host + path.Path}
+				if !seen[u] {
+					seen[u] = true
+					urls = append(urls, u)
+				}
+			}
+		}
+	}
+	sort.Slice(urls, func(i, j int) bool {
+		if urls[i].Service != urls[j].Service {
+			return urls[i].Service < urls[j].Service
+		}
+		return urls[i].URL < urls[j].URL
+	})
+	return urls
+}
+
+// ingressScheme returns https when the ingress terminates TLS for host
+func ingressScheme(ingress *networkingv1.Ingress, host string) string {
+	for _, tls := range ingress.Spec.TLS {
+		for _, h := range tls.Hosts {
+			if h == host {
+				return "https"
+			}
+		}
+	}
+	return "http"
+}
+
+func hasServiceURL(urls []exposedURL, service string) bool {
+	for _, u := range urls {
+		if u.Service == service {
+			return true
+		}
+	}
+	return false
+}
+
+// selectURL returns the only URL, or prompts for one
+func selectURL(urls []exposedURL) (string, error) {
+	if len(urls) == 1 {
+		return urls[0].URL, nil
+	}
+
+	services := make([]string, len(urls))
+	addresses := make([]string, len(urls))
+	for i, u := range urls {
+		services[i] = u.Service
+		addresses[i] = u.URL
+	}
+
+	idx, err := interactive.SelectOption("Choose a URL:", interactive.FormatAlignedColumns(services, addresses))
+	if err != nil {
+		return "", fmt.Errorf("URL selection cancelled: %w", err)
+	}
+	return urls[idx].URL, nil
+}
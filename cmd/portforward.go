@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	portForwardStack   string
+	portForwardService string
+)
+
+var portForwardCmd = &cobra.Command{
+	Use:   "port-forward [[local:]remote]",
+	Short: "Forward a local port to a stack service",
+	Long: `Forward a local port to a pod of a stack service until Ctrl+C.
+
+The remote port defaults to the first port declared by the service's
+container (or its Kubernetes Service). The local port defaults to the remote
+port; if it's in use the next free port is chosen.
+
+Examples:
+  # Select stack and service interactively
+  lissto port-forward
+
+  # Forward the service's default port
+  lissto port-forward --stack my-stack --service api
+
+  # Forward local 8080 to remote 3000
+  lissto port-forward --stack my-stack --service api 8080:3000`,
+	Args:          cobra.MaximumNArgs(1),
+	RunE:          runPortForward,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(portForwardCmd)
+	portForwardCmd.Flags().StringVar(&portForwardStack, "stack", "", "Stack name")
+	portForwardCmd.Flags().StringVar(&portForwardService, "service", "", "Service name")
+}
+
+func runPortForward(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	var localPort, remotePort int
+	if len(args) == 1 {
+		var err error
+		localPort, remotePort, err = parsePortMapping(args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	stack, err := resolveStack(ctx, apiClient, portForwardStack)
+	if err != nil {
+		return err
+	}
+
+	service, err := resolveService(stack, portForwardService)
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+
+	pod, err := resolveServicePod(ctx, k8sClient, stack, service)
+	if err != nil {
+		return err
+	}
+
+	fwdCtx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if remotePort == 0 {
+		remotePort = defaultServicePort(fwdCtx, k8sClient, stack.Namespace, service, pod)
+		if remotePort == 0 {
+			return fmt.Errorf("could not determine a port for service '%s'; specify one as [local:]remote", service)
+		}
+	}
+
+	requestedLocal := localPort
+	localPort, stop, err := k8sClient.PortForwardPod(fwdCtx, stack.Namespace, pod.Name, localPort, remotePort)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	if requestedLocal != 0 && requestedLocal != localPort {
+		fmt.Fprintf(os.Stderr, "⚠️  Port %d is in use, using %d instead\n", requestedLocal, localPort)
+	}
+	fmt.Printf("🔗 Forwarding http://localhost:%d -> %s/%s:%d\n", localPort, service, pod.Name, remotePort)
+	fmt.Println("Press Ctrl+C to stop.")
+
+	<-fwdCtx.Done()
+	fmt.Fprintln(os.Stderr, "\nStopping port-forward...")
+	return nil
+}
+
+// parsePortMapping parses "remote" or "local:remote"
+func parsePortMapping(value string) (int, int, error) {
+	parts := strings.SplitN(value, ":", 2)
+	ports := make([]int, len(parts))
+	for i, p := range parts {
+		port, err := strconv.Atoi(p)
+		if err != nil || port < 0 || port > 65535 {
+			return 0, 0, fmt.Errorf("invalid port %q in %q", p, value)
+		}
+		ports[i] = port
+	}
+
+	if len(ports) == 1 {
+		return 0, ports[0], nil
+	}
+	return ports[0], ports[1], nil
+}
+
+// defaultServicePort returns the first container port of the pod, falling
+// back to the target port of the Kubernetes Service with the same name
+func defaultServicePort(ctx context.Context, k8sClient *k8s.Client, namespace, service string, pod *corev1.Pod) int {
+	for _, c := range pod.Spec.Containers {
+		if len(c.Ports) > 0 {
+			return int(c.Ports[0].ContainerPort)
+		}
+	}
+
+	svc, err := k8sClient.GetService(ctx, namespace, service)
+	if err != nil || len(svc.Spec.Ports) == 0 {
+		return 0
+	}
+	if target := svc.Spec.Ports[0].TargetPort.IntVal; target != 0 {
+		return int(target)
+	}
+	return int(svc.Spec.Ports[0].Port)
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	controllerconfig "github.com/lissto-dev/controller/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	previewPR        int
+	previewPrefix    string
+	previewBlueprint string
+	previewBranch    string
+	previewCommit    string
+	previewTTL       time.Duration
+	previewWait      bool
+	previewTimeout   time.Duration
+	previewComment   bool
+)
+
+var previewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Manage pull request preview environments",
+	Long: `Create and tear down one preview environment per pull request.
+
+A preview lives in its own env named after the pull request (pr-123 by
+default), so deleting the preview removes everything it deployed.
+
+Examples:
+  # In CI, when a pull request is opened or updated
+  lissto preview create --pr 123 --wait --comment > comment.md
+
+  # When it is closed
+  lissto preview delete --pr 123`,
+}
+
+var previewCreateCmd = &cobra.Command{
+	Use:   "create --pr <number>",
+	Short: "Deploy the current repository's blueprint as a pull request preview",
+	Long: `Deploy a preview of a pull request into its own env, creating the env if
+needed.
+
+The blueprint defaults to the newest one of the current git repository
+(or $` + cmdutil.EnvOverrideRepository + `), and images are resolved from the pull request's
+branch: --branch, $GITHUB_HEAD_REF, $CI_MERGE_REQUEST_SOURCE_BRANCH_NAME or
+the checked out branch. The stack is labelled ` + cmdutil.PreviewLabel + `=<number>.
+
+With --comment only a Markdown summary with the exposed URLs is written to
+stdout, ready to post on the pull request; progress goes to stderr.
+
+Examples:
+  lissto preview create --pr 123
+
+  # Wait for readiness and write a PR comment
+  lissto preview create --pr 123 --wait --comment > comment.md
+
+  # Let 'lissto gc' remove forgotten previews after three days
+  lissto preview create --pr 123 --ttl 72h`,
+	Args:          cobra.NoArgs,
+	RunE:          runPreviewCreate,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+var previewDeleteCmd = &cobra.Command{
+	Use:   "delete --pr <number>",
+	Short: "Delete a pull request preview and its env",
+	Long: `Delete every stack of a pull request's preview env, then the env itself.
+Deleting a preview that doesn't exist succeeds, so it is safe to run on every
+closed pull request.
+
+Examples:
+  lissto preview delete --pr 123`,
+	Args:          cobra.NoArgs,
+	RunE:          runPreviewDelete,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+	previewCmd.AddCommand(previewCreateCmd)
+	previewCmd.AddCommand(previewDeleteCmd)
+
+	previewCmd.PersistentFlags().IntVar(&previewPR, "pr", 0, "Pull request number")
+	previewCmd.PersistentFlags().StringVar(&previewPrefix, "prefix", cmdutil.DefaultPreviewPrefix, "Prefix of preview env names")
+	_ = previewCreateCmd.MarkPersistentFlagRequired("pr")
+	_ = previewDeleteCmd.MarkPersistentFlagRequired("pr")
+
+	previewCreateCmd.Flags().StringVar(&previewBlueprint, "blueprint", "", "Blueprint to deploy (default: newest blueprint of the current repository)")
+	previewCreateCmd.Flags().StringVar(&previewBranch, "branch", "", "Git branch to resolve images from (default: the pull request's branch)")
+	previewCreateCmd.Flags().StringVar(&previewCommit, "commit", "", "Git commit to resolve images from, instead of a branch")
+	previewCreateCmd.Flags().DurationVar(&previewTTL, "ttl", 0, "Mark the preview for deletion by 'lissto gc' after this long, e.g. 72h")
+	previewCreateCmd.Flags().BoolVar(&previewWait, "wait", false, "Wait until all services are ready")
+	previewCreateCmd.Flags().DurationVar(&previewTimeout, "timeout", defaultWaitTimeout, "Maximum time to wait with --wait")
+	previewCreateCmd.Flags().BoolVar(&previewComment, "comment", false, "Write a Markdown pull request comment with the preview's URLs to stdout")
+}
+
+func runPreviewCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	env, err := cmdutil.PreviewEnvName(previewPrefix, previewPR)
+	if err != nil {
+		return err
+	}
+	progress := os.Stdout
+	if previewComment || isMachineCreateOutput() {
+		progress = os.Stderr
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	blueprint := previewBlueprint
+	if blueprint == "" {
+		if blueprint, err = repositoryBlueprint(cmd, apiClient); err != nil {
+			return err
+		}
+	}
+	branch := previewBranch
+	if branch == "" && previewCommit == "" {
+		if branch = pullRequestBranch(); branch == "" {
+			return fmt.Errorf("cannot tell the pull request's branch: use --branch or --commit")
+		}
+	}
+
+	if _, err := apiClient.GetEnv(ctx, env); errors.Is(err, client.ErrNotFound) {
+		if _, err := apiClient.CreateEnv(ctx, env); err != nil {
+			return fmt.Errorf("failed to create env '%s': %w", env, err)
+		}
+		fmt.Fprintf(progress, "Created env: %s\n", env)
+	} else if err != nil {
+		return fmt.Errorf("failed to get env '%s': %w", env, err)
+	} else {
+		stacks, err := apiClient.ListStacks(ctx, env)
+		if err != nil {
+			return fmt.Errorf("failed to list stacks: %w", err)
+		}
+		for _, stack := range stacks {
+			if stack.Spec.BlueprintReference == blueprint {
+				return fmt.Errorf("preview for #%d already exists as stack '%s': redeploy it with 'lissto update --env %s' or delete it with 'lissto preview delete --pr %d'", previewPR, stack.Name, env, previewPR)
+			}
+		}
+	}
+
+	// Run the create flow non-interactively with the preview's settings
+	createBlueprint = blueprint
+	createEnv = env
+	createBranch, createCommit, createTag = branch, previewCommit, ""
+	createNonInteractive = true
+	createTTL = previewTTL
+	createLabels = []string{cmdutil.PreviewLabel + "=" + strconv.Itoa(previewPR)}
+	createWait, createTimeout = previewWait, previewTimeout
+	// Only the comment goes to stdout
+	createQuiet = createQuiet || previewComment
+
+	result, err := createStack(cmd)
+	if err != nil {
+		return err
+	}
+	if !previewComment {
+		return printCreateResult(result)
+	}
+
+	urls := make([]cmdutil.PreviewURL, 0, len(result.URLs))
+	for _, u := range result.URLs {
+		urls = append(urls, cmdutil.PreviewURL{Service: u.Service, URL: u.URL})
+	}
+	fmt.Print(cmdutil.PreviewComment(previewPR, result.ID, result.Env, result.Blueprint, urls))
+	return nil
+}
+
+func runPreviewDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	env, err := cmdutil.PreviewEnvName(previewPrefix, previewPR)
+	if err != nil {
+		return err
+	}
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := apiClient.GetEnv(ctx, env); errors.Is(err, client.ErrNotFound) {
+		fmt.Printf("No preview for #%d (env '%s' doesn't exist)\n", previewPR, env)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get env '%s': %w", env, err)
+	}
+
+	stacks, err := apiClient.ListStacks(ctx, env)
+	if err != nil {
+		return fmt.Errorf("failed to list stacks: %w", err)
+	}
+	for _, stack := range stacks {
+		if err := apiClient.DeleteStack(ctx, stack.Name, env); err != nil && !errors.Is(err, client.ErrNotFound) {
+			return fmt.Errorf("failed to delete stack '%s': %w", stack.Name, err)
+		}
+		fmt.Printf("🗑️  Deleted stack: %s\n", stack.Name)
+	}
+	if err := apiClient.DeleteEnv(ctx, env); errors.Is(err, client.ErrEnvDeleteUnsupported) {
+		fmt.Printf("⚠️  Env '%s' kept: %v\n", env, err)
+	} else if err != nil {
+		return fmt.Errorf("failed to delete env '%s': %w", env, err)
+	}
+	fmt.Printf("✅ Preview for #%d deleted (env: %s)\n", previewPR, env)
+	return nil
+}
+
+// repositoryBlueprint returns the newest blueprint of the repository the
+// command runs in
+func repositoryBlueprint(cmd *cobra.Command, apiClient *client.Client) (string, error) {
+	repository := cmdutil.LoadOverrides().Repository
+	if repository == "" {
+		var err error
+		if repository, err = inferRepositoryFromFile("."); err != nil {
+			return "", fmt.Errorf("failed to detect git repository: %w\nUse --blueprint or set %s", err, cmdutil.EnvOverrideRepository)
+		}
+	}
+	normalized := controllerconfig.NormalizeRepositoryURL(repository)
+	blueprints, err := apiClient.FindBlueprintsByRepository(cmd.Context(), normalized)
+	if err != nil {
+		return "", err
+	}
+	if len(blueprints) == 0 {
+		return "", fmt.Errorf("no blueprint found for repository %s: create one with 'lissto blueprint create' or use --blueprint", normalized)
+	}
+	return blueprints[0].ID, nil
+}
+
+// pullRequestBranch returns the branch of the pull request being built: the
+// one CI names, or the checked out branch
+func pullRequestBranch() string {
+	for _, env := range []string{"GITHUB_HEAD_REF", "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"} {
+		if branch := os.Getenv(env); branch != "" {
+			return branch
+		}
+	}
+	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	// A detached HEAD has no branch
+	if branch := strings.TrimSpace(string(out)); branch != "HEAD" {
+		return branch
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/registry"
+)
+
+// collectProvenance queries registries for the provenance of each resolved
+// image in parallel. Images that can't be inspected (private registries,
+// missing labels) are left out of the result.
+func collectProvenance(ctx context.Context, images []client.DetailedImageResolutionInfo) map[string]*registry.Provenance {
+	ctx, cancel := context.WithTimeout(ctx, registry.ProvenanceTimeout)
+	defer cancel()
+
+	digests := make(map[string]registry.ImageDigest, len(images))
+	for _, img := range images {
+		digests[img.Service] = registry.ImageDigest{Image: img.Image, Digest: img.Digest}
+	}
+	return registry.FetchProvenances(ctx, digests)
+}
+
+// formatImageDetails renders provenance and vulnerability info as a single
+// line, e.g. "built 2h ago · commit abc1234 · vulns 0C 1H 3M 0L"
+func formatImageDetails(p *registry.Provenance, vulns *client.VulnerabilitySummary) string {
+	var parts []string
+	if p != nil {
+		if !p.Created.IsZero() {
+			_, ago := output.FormatTimestamp(p.Created)
+			parts = append(parts, "built "+ago)
+		}
+		if p.Revision != "" {
+			parts = append(parts, "commit "+p.ShortRevision())
+		}
+	}
+	if vulns != nil {
+		parts = append(parts, "vulns "+output.FormatVulnerabilities(vulns))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return output.Gray(strings.Join(parts, " · "))
+}
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/lissto-dev/cli/cmd/admin"
 	"github.com/lissto-dev/cli/cmd/blueprint"
@@ -10,6 +16,11 @@ import (
 	"github.com/lissto-dev/cli/cmd/secret"
 	"github.com/lissto-dev/cli/cmd/stack"
 	"github.com/lissto-dev/cli/cmd/variable"
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/output"
 	"github.com/lissto-dev/cli/pkg/update"
 	"github.com/spf13/cobra"
 )
@@ -19,8 +30,16 @@ var (
 	contextName  string
 	envName      string
 	showVersion  bool
+	noRetry      bool
+	debugHTTP    string
+	noColor      bool
+	wideOutput   bool
+	asUser       string
 )
 
+// debugEnv enables --debug-http from the environment
+const debugEnv = "LISSTO_DEBUG"
+
 // Version information (set via ldflags during build)
 var (
 	Version = "dev"
@@ -36,13 +55,44 @@ var rootCmd = &cobra.Command{
 	Use:   "lissto",
 	Short: "Lissto CLI - Manage your Lissto resources",
 	Long: `Lissto CLI is a command-line tool for managing Lissto resources
-including blueprints, stacks, and environments.`,
+including blueprints, stacks, and environments.
+
+Exit codes:
+  0  Success
+  1  General error
+  2  Deployment blocked by missing images
+  3  Stack not ready before the --wait timeout
+  4  Authentication failed or permission denied
+  5  Resource not found
+  6  Resource already exists or was modified concurrently
+  7  Lint findings at or above the failOn severity (verify --lint)`,
 	SilenceUsage: true, // Don't show usage on errors
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		commandStarted = time.Now()
+		applyContextOverride()
+		applyConfigDefaults(cmd)
+		if noRetry {
+			client.SetRetryPolicy(client.NoRetry)
+		}
+		setupDebugHTTP()
+		if asUser != "" {
+			if err := impersonate(cmd.Context(), asUser); err != nil {
+				return err
+			}
+		}
+		if noColor {
+			output.SetColor(false)
+		}
+		if wideOutput || outputFormat == "wide" {
+			output.SetWideOutput(true)
+		}
+
 		// Check for updates in the background (respects 24h cache)
 		// Errors are silently ignored to not disrupt normal CLI usage
 		result, _ := update.CheckForUpdate(Version)
 		updateCheckResult = result
+
+		return cmdutil.ValidateEnvFlag(cmd)
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
 		// Display update message after command execution
@@ -59,19 +109,159 @@ including blueprints, stacks, and environments.`,
 	},
 }
 
-// Execute runs the root command
+// Exit codes, listed in the root command help
+const (
+	exitCodeError         = 1
+	exitCodeMissingImages = 2
+	exitCodeNotReady      = 3
+	exitCodeAuth          = 4
+	exitCodeNotFound      = 5
+	exitCodeConflict      = 6
+	exitCodeLint          = 7
+)
+
+// exitError is an error that terminates the CLI with a specific exit code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// Execute runs the root command. The first Ctrl+C cancels the command
+// context so in-flight requests and port-forwards are torn down; a second
+// one terminates immediately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	context.AfterFunc(ctx, stop)
+
+	executed, err := rootCmd.ExecuteContextC(ctx)
+	stop()
+	client.CloseTunnels()
+	recordHistory(executed, err)
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+}
+
+// setupDebugHTTP enables API request tracing from --debug-http or
+// LISSTO_DEBUG: "-", "1" or "true" trace to stderr, any other value is a file
+// to append to
+func setupDebugHTTP() {
+	target := debugHTTP
+	if target == "" {
+		target = os.Getenv(debugEnv)
+	}
+	switch strings.ToLower(target) {
+	case "", "0", "false":
+		return
+	case "-", "1", "true":
+		client.SetDebugOutput(os.Stderr)
+		return
+	}
+
+	// The file stays open until the process exits
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Cannot open HTTP trace file, tracing to stderr: %v\n", err)
+		client.SetDebugOutput(os.Stderr)
+		return
+	}
+	client.SetDebugOutput(f)
+}
+
+// impersonate makes API requests act as user, after checking that the API
+// honours it: otherwise every request would run as the API key's user
+func impersonate(ctx context.Context, user string) error {
+	client.SetImpersonation(user)
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+	if err := apiClient.VerifyImpersonation(ctx); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "⚠️  Acting as user '%s'\n", user)
+	return nil
+}
+
+// applyContextOverride makes the context named by --context or
+// LISSTO_CONTEXT the active one for this invocation, for the API and
+// Kubernetes clients alike. The config file is left untouched. Contexts
+// saved with their own kubeconfig file always use it, instead of kubectl's
+// current context.
+func applyContextOverride() {
+	name := contextName
+	if name == "" {
+		name = os.Getenv(config.EnvContext)
+	}
+	if name != "" {
+		config.SetContextOverride(name)
+	}
+
+	// Unknown contexts are reported when the command looks the context up
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return
+	}
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return
+	}
+	if name != "" || lisstoCtx.Kubeconfig != "" {
+		k8s.SetKubeContext(lisstoCtx.Kubeconfig, lisstoCtx.KubeContext)
+	}
+}
+
+// applyConfigDefaults sets flags the user didn't give from the defaults
+// section of config.yaml. Bad defaults are reported but never fail the command.
+func applyConfigDefaults(cmd *cobra.Command) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return
+	}
+	defaults := cfg.CommandDefaults(cmdutil.DefaultsCommand(cmd))
+	if len(defaults) == 0 {
+		return
+	}
+	if err := cmdutil.ApplyDefaults(cmd, defaults); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	}
+}
+
+// exitCode maps an error to the exit code scripts can rely on
+func exitCode(err error) int {
+	var exitErr *exitError
+	switch {
+	case errors.As(err, &exitErr):
+		return exitErr.code
+	case errors.Is(err, client.ErrMissingImages):
+		return exitCodeMissingImages
+	case errors.Is(err, client.ErrAuth):
+		return exitCodeAuth
+	case errors.Is(err, client.ErrNotFound):
+		return exitCodeNotFound
+	case errors.Is(err, client.ErrConflict):
+		return exitCodeConflict
 	}
+	return exitCodeError
 }
 
 func init() {
 	// Global flags
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (json, yaml, wide)")
-	rootCmd.PersistentFlags().StringVar(&contextName, "context", "", "Override current context")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (json, yaml, wide, id, go-template=..., jsonpath=...)")
+	rootCmd.PersistentFlags().StringVar(&contextName, "context", "", "Context to use for this command, without switching to it (also $"+config.EnvContext+")")
 	rootCmd.PersistentFlags().StringVar(&envName, "env", "", "Override current environment")
+	_ = rootCmd.RegisterFlagCompletionFunc("env", cmdutil.CompleteEnvNames)
+	rootCmd.PersistentFlags().BoolVar(&noRetry, "no-retry", false, "Fail on the first transient API error instead of retrying")
+	rootCmd.PersistentFlags().StringVar(&debugHTTP, "debug-http", "", "Trace API requests to stderr, or to a file with --debug-http=<path> (also $"+debugEnv+")")
+	rootCmd.PersistentFlags().Lookup("debug-http").NoOptDefVal = "-"
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also $NO_COLOR; off when stdout is not a terminal)")
+	rootCmd.PersistentFlags().BoolVar(&wideOutput, "wide", false, "Don't truncate table columns to the terminal width")
+	rootCmd.PersistentFlags().StringVar(&asUser, "as", "", "Make API requests as another user to see what they see (admin only)")
 	rootCmd.Flags().BoolVarP(&showVersion, "version", "v", false, "Show version information")
 
 	// Add subcommands
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/lissto-dev/cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scaleStack    string
+	scaleService  string
+	scaleReplicas int
+)
+
+// scaleResult is the -o json/yaml output of a scale
+type scaleResult struct {
+	Stack      string `json:"stack" yaml:"stack"`
+	Service    string `json:"service" yaml:"service"`
+	Deployment string `json:"deployment" yaml:"deployment"`
+	Previous   int32  `json:"previousReplicas" yaml:"previousReplicas"`
+	Replicas   int32  `json:"replicas" yaml:"replicas"`
+}
+
+var scaleCmd = &cobra.Command{
+	Use:   "scale",
+	Short: "Change the number of replicas of a stack service",
+	Long: `Scale the deployment of a stack service to a number of replicas.
+
+The stack and service are selected interactively when --stack or --service
+are omitted, and the replica count is prompted for without --replicas.
+Scaling to 0 stops the service without deleting it. Updating the stack may
+reset the replica count to the blueprint's.
+
+Examples:
+  # Pick the stack, service and replica count interactively
+  lissto scale
+
+  # Run three API replicas
+  lissto scale --stack my-stack --service api --replicas 3
+
+  # Stop a worker for now
+  lissto scale --stack my-stack --service worker --replicas 0`,
+	Args:          cobra.NoArgs,
+	RunE:          runScale,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(scaleCmd)
+	scaleCmd.Flags().StringVar(&scaleStack, "stack", "", "Stack name")
+	scaleCmd.Flags().StringVar(&scaleService, "service", "", "Service name")
+	scaleCmd.Flags().IntVar(&scaleReplicas, "replicas", 0, "Number of replicas")
+}
+
+func runScale(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	replicasSet := cmd.Flags().Changed("replicas")
+	if replicasSet && scaleReplicas < 0 {
+		return fmt.Errorf("--replicas must not be negative")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	stack, err := resolveStack(ctx, apiClient, scaleStack)
+	if err != nil {
+		return err
+	}
+
+	service, err := resolveService(stack, scaleService)
+	if err != nil {
+		return err
+	}
+	// Only services of the stack spec have a deployment to scale
+	if _, ok := stack.Spec.Images[service]; !ok {
+		return fmt.Errorf("service '%s' %w in stack '%s' (services: %s)",
+			service, client.ErrNotFound, stack.Name, strings.Join(stackServiceNames(stack), ", "))
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+
+	deployments, err := status.ListStackDeployments(ctx, k8sClient, stack)
+	if err != nil {
+		return err
+	}
+	deployment := status.MatchServiceDeployment(deployments, service)
+	if deployment == nil {
+		return fmt.Errorf("no deployment found for service '%s' in stack '%s'", service, stack.Name)
+	}
+
+	if !replicasSet {
+		current := status.DeploymentReplicas(deployment)
+		value, err := interactive.PromptParam("Replicas", fmt.Sprintf("Currently %d", current), strconv.Itoa(int(current)), true)
+		if err != nil {
+			return fmt.Errorf("replica count prompt cancelled: %w", err)
+		}
+		scaleReplicas, err = strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || scaleReplicas < 0 {
+			return fmt.Errorf("invalid replica count '%s'", value)
+		}
+	}
+
+	replicas := int32(scaleReplicas)
+	previous, err := k8sClient.ScaleDeployment(ctx, stack.Namespace, deployment.Name, replicas)
+	if err != nil {
+		return err
+	}
+
+	result := &scaleResult{
+		Stack:      stack.Name,
+		Service:    service,
+		Deployment: deployment.Name,
+		Previous:   previous,
+		Replicas:   replicas,
+	}
+	return cmdutil.PrintOutput(cmd, result, func() {
+		if previous == replicas {
+			fmt.Printf("Service '%s' of stack '%s' already has %d replicas\n", service, stack.Name, replicas)
+			return
+		}
+		fmt.Printf("✅ Scaled service '%s' of stack '%s' from %d to %d replicas\n", service, stack.Name, previous, replicas)
+	})
+}
+
+// stackServiceNames returns the sorted service names of a stack's spec
+func stackServiceNames(stack *types.Stack) []string {
+	names := make([]string, 0, len(stack.Spec.Images))
+	for name := range stack.Spec.Images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/messages"
 	"github.com/spf13/cobra"
 )
 
@@ -18,11 +19,12 @@ var (
 	createScope      string
 	createEnv        string
 	createRepository string
+	createFromFile   string
 	createYes        bool
 )
 
 var createCmd = &cobra.Command{
-	Use:   "create KEY=value [KEY=value...]",
+	Use:   "create [KEY=value...] [--from-file .env]",
 	Short: "Create or update secret config",
 	Long: `Create a new secret config or add keys to an existing one.
 
@@ -30,6 +32,10 @@ If a config already exists for the same scope/env, you'll be prompted to confirm
 before merging keys (since secrets are write-only and we can't detect conflicts).
 Use --yes to skip confirmation.
 
+--from-file reads KEY=value lines in .env format: # comments, optional
+"export" prefixes, and single- or double-quoted values (which may span
+lines). Keys given as arguments override keys from the file.
+
 Examples:
   # Create env-scoped secrets (uses current env)
   lissto secret create KEY1=value1 KEY2=value2
@@ -43,6 +49,12 @@ Examples:
   # Update an existing key (overwrites with confirmation)
   lissto secret create KEY1=newvalue
 
+  # Import keys from a .env file
+  lissto secret create --from-file .env
+
+  # Import from a file and override one key
+  lissto secret create --from-file .env KEY1=override
+
   # Create with explicit env
   lissto secret create KEY1=value1 --env production
 
@@ -52,7 +64,7 @@ Examples:
   # Create global secrets (admin only)
   lissto secret create KEY=value --scope global
 `,
-	Args: cobra.MinimumNArgs(1),
+	Args: cobra.ArbitraryArgs,
 	RunE: runCreate,
 }
 
@@ -60,10 +72,13 @@ func init() {
 	createCmd.Flags().StringVarP(&createScope, "scope", "s", "", "Scope: env, repo, or global (default: env)")
 	createCmd.Flags().StringVarP(&createEnv, "env", "e", "", "Environment name (default: current env)")
 	createCmd.Flags().StringVarP(&createRepository, "repository", "r", "", "Repository (required for scope=repo)")
+	createCmd.Flags().StringVar(&createFromFile, "from-file", "", "Read KEY=value pairs from a .env file")
 	createCmd.Flags().BoolVarP(&createYes, "yes", "y", false, "Skip confirmation prompt for overwriting existing secrets")
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Default scope to "env"
 	scope := createScope
 	if scope == "" {
@@ -75,17 +90,17 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	if scope == scopeEnv && env == "" {
 		env = cmdutil.GetCurrentEnv()
 		if env == "" {
-			return fmt.Errorf("env is required for scope=env. Set with --env or run 'lissto env use <env>'")
+			return messages.Error(messages.NoEnvForScope, nil)
 		}
 	}
 
-	// Parse KEY=value arguments
-	secrets, err := cmdutil.ParseKeyValueArgs(args)
+	// Collect keys from --from-file and KEY=value arguments
+	secrets, err := cmdutil.CollectKeyValues(args, createFromFile)
 	if err != nil {
 		return err
 	}
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -102,12 +117,12 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		Secrets:    secrets,
 	}
 
-	secret, err := apiClient.CreateSecret(req)
+	secret, err := apiClient.CreateSecret(ctx, req)
 	if err != nil {
 		// Check if it's a conflict error (409 or "already exists")
 		if strings.Contains(err.Error(), "409") || strings.Contains(strings.ToLower(err.Error()), "already exists") {
 			// Secret exists - get it to check for key overlaps
-			existing, err := apiClient.GetSecret(name, scope, env, createRepository)
+			existing, err := apiClient.GetSecret(ctx, name, scope, env, createRepository)
 			if err != nil {
 				return fmt.Errorf("failed to get existing secret: %w", err)
 			}
@@ -154,7 +169,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 			setReq := &client.SetSecretRequest{
 				Secrets: secrets,
 			}
-			secret, err = apiClient.UpdateSecret(name, scope, env, createRepository, setReq)
+			secret, err = apiClient.UpdateSecret(ctx, name, scope, env, createRepository, setReq)
 			if err != nil {
 				return fmt.Errorf("failed to add keys to secret: %w", err)
 			}
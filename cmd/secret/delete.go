@@ -15,15 +15,17 @@ var deleteCmd = &cobra.Command{
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	name := args[0]
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
 
 	// Use default scope (env) - TODO: add scope flags
-	if err := apiClient.DeleteSecret(name, "", "", ""); err != nil {
+	if err := apiClient.DeleteSecret(ctx, name, "", "", ""); err != nil {
 		return fmt.Errorf("failed to delete secret: %w", err)
 	}
 
@@ -0,0 +1,115 @@
+package secret
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportScope      string
+	exportEnv        string
+	exportRepository string
+	exportMerge      bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export secret keys as dotenv, JSON or YAML",
+	Long: `Export the keys of a secret config. Values are write-only, so dotenv output
+contains empty KEY= placeholders to fill in locally (or use 'secret get --reveal').
+
+By default a single config is exported (the current env's, or the one selected
+with --scope). --merge combines every config that applies to the env and
+repository with the controller's precedence: global < repo < env.
+
+Output defaults to dotenv; use -o json or -o yaml for structured output.
+
+Examples:
+  # Write a .env template with the current env's secret keys
+  lissto secret export > .env.secrets
+
+  # List the effective secret keys for a repository in staging, with their source
+  lissto secret export --merge --env staging --repository github.com/org/app -o yaml`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportScope, "scope", "s", scopeEnv, "Scope: env, repo, or global")
+	exportCmd.Flags().StringVarP(&exportEnv, "env", "e", "", "Environment name (default: current env)")
+	exportCmd.Flags().StringVarP(&exportRepository, "repository", "r", "", "Repository (required for scope=repo; used by --merge)")
+	exportCmd.Flags().BoolVar(&exportMerge, "merge", false, "Merge global, repo and env scopes like the controller does")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if exportMerge && cmd.Flags().Changed("scope") {
+		return fmt.Errorf("--merge combines all scopes and cannot be used with --scope")
+	}
+
+	env := exportEnv
+	if (exportMerge || exportScope == scopeEnv) && env == "" {
+		env = cmdutil.GetCurrentEnv()
+		if env == "" {
+			return messages.Error(messages.NoEnvForScope, nil)
+		}
+	}
+
+	repository := exportRepository
+	if repository == "" {
+		repository = cmdutil.LoadOverrides().Repository
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !exportMerge {
+		name := cmdutil.GenerateResourceName(exportScope, env, repository)
+		secret, err := apiClient.GetSecret(ctx, name, exportScope, env, repository)
+		if err != nil {
+			return fmt.Errorf("failed to get secret: %w", err)
+		}
+		keys := append([]string(nil), secret.Keys...)
+		sort.Strings(keys)
+		return cmdutil.PrintExport(cmd, keys, placeholders(keys))
+	}
+
+	secrets, err := apiClient.ListSecrets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	configs := make([]cmdutil.ScopedConfig, 0, len(secrets))
+	for _, s := range secrets {
+		configs = append(configs, cmdutil.ScopedConfig{
+			Name:       s.Name,
+			Scope:      s.Scope,
+			Env:        s.Env,
+			Repository: s.Repository,
+			Data:       placeholders(s.Keys),
+		})
+	}
+
+	merged := cmdutil.MergeScopes(configs, env, repository)
+	values := make(map[string]string, len(merged))
+	for key := range merged {
+		values[key] = ""
+	}
+	return cmdutil.PrintExport(cmd, merged, values)
+}
+
+// placeholders maps each key to an empty value
+func placeholders(keys []string) map[string]string {
+	values := make(map[string]string, len(keys))
+	for _, k := range keys {
+		values[k] = ""
+	}
+	return values
+}
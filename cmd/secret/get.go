@@ -2,7 +2,11 @@ package secret
 
 import (
 	"fmt"
+	"os"
+	"slices"
+	"sort"
 
+	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/cmdutil"
 	"github.com/spf13/cobra"
 )
@@ -11,52 +15,129 @@ var (
 	getScope      string
 	getEnv        string
 	getRepository string
+	getReveal     bool
+	getKey        string
 )
 
 var getCmd = &cobra.Command{
 	Use:   "get <name>",
-	Short: "Get a specific secret (keys only, no values)",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runGet,
+	Short: "Get a specific secret (keys only, unless --reveal)",
+	Long: `Get a secret config. Only key names are shown unless --reveal is given.
+
+--reveal prints the decrypted values when the Lissto API allows it. Every
+reveal is recorded in the API audit log.
+
+Examples:
+  # Show the keys of a secret
+  lissto secret get db-credentials
+
+  # Show all values
+  lissto secret get db-credentials --reveal
+
+  # Print a single value, e.g. for scripts
+  lissto secret get db-credentials --reveal --key DB_PASSWORD`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGet,
 }
 
 func init() {
 	getCmd.Flags().StringVar(&getScope, "scope", "env", "Scope: env, repo, or global")
 	getCmd.Flags().StringVar(&getEnv, "env", "", "Environment name (defaults to current env for scope=env)")
 	getCmd.Flags().StringVar(&getRepository, "repository", "", "Repository for scope=repo")
+	getCmd.Flags().BoolVar(&getReveal, "reveal", false, "Show decrypted values (audited)")
+	getCmd.Flags().StringVar(&getKey, "key", "", "With --reveal, print only this key's value")
 }
 
 func runGet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	name := args[0]
 
+	if getKey != "" && !getReveal {
+		return fmt.Errorf("--key requires --reveal")
+	}
+
 	// Default env for scope=env
 	env := getEnv
 	if getScope == "env" && env == "" {
 		env = cmdutil.GetCurrentEnv()
 	}
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	secret, err := apiClient.GetSecret(name, getScope, env, getRepository)
+	secret, err := apiClient.GetSecret(ctx, name, getScope, env, getRepository)
 	if err != nil {
 		return fmt.Errorf("failed to get secret: %w", err)
 	}
 
+	if getReveal {
+		return revealSecret(cmd, apiClient, secret, env)
+	}
+
 	return cmdutil.PrintOutput(cmd, secret, func() {
-		fmt.Printf("Name:       %s\n", secret.Name)
-		fmt.Printf("Scope:      %s\n", secret.Scope)
-		if secret.Env != "" {
-			fmt.Printf("Env:        %s\n", secret.Env)
-		}
-		if secret.Repository != "" {
-			fmt.Printf("Repository: %s\n", secret.Repository)
-		}
+		printSecretHeader(secret)
 		fmt.Println("Keys:")
 		for _, k := range secret.Keys {
 			fmt.Printf("  - %s\n", k)
 		}
 	})
 }
+
+// revealSecret fetches and prints decrypted values after an audit warning
+func revealSecret(cmd *cobra.Command, apiClient *client.Client, secret *client.SecretResponse, env string) error {
+	if getKey != "" && !slices.Contains(secret.Keys, getKey) {
+		return fmt.Errorf("secret '%s' has no key '%s' (keys: %v)", secret.Name, getKey, secret.Keys)
+	}
+
+	fmt.Fprintln(os.Stderr, "⚠️  WARNING: revealing secret values in plain text.")
+	fmt.Fprintln(os.Stderr, "   This access is recorded in the Lissto audit log. Don't paste this output anywhere shared.")
+
+	revealed, err := apiClient.RevealSecret(cmd.Context(), secret.Name, getScope, env, getRepository, getKey)
+	if err != nil {
+		return err
+	}
+	if revealed.Name == "" {
+		revealed.SecretResponse = *secret
+	}
+
+	if getKey != "" {
+		value, ok := revealed.Values[getKey]
+		if !ok {
+			return fmt.Errorf("the API did not return a value for key '%s'", getKey)
+		}
+		revealed.Values = map[string]string{getKey: value}
+
+		// A single value prints bare so it can be captured by scripts
+		return cmdutil.PrintOutput(cmd, revealed, func() {
+			fmt.Println(value)
+		})
+	}
+
+	return cmdutil.PrintOutput(cmd, revealed, func() {
+		printSecretHeader(&revealed.SecretResponse)
+		fmt.Println("Values:")
+		keys := make([]string, 0, len(revealed.Values))
+		for k := range revealed.Values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("  %s=%s\n", k, revealed.Values[k])
+		}
+	})
+}
+
+// printSecretHeader prints the identifying fields of a secret
+func printSecretHeader(secret *client.SecretResponse) {
+	fmt.Printf("Name:       %s\n", secret.Name)
+	fmt.Printf("Scope:      %s\n", secret.Scope)
+	if secret.Env != "" {
+		fmt.Printf("Env:        %s\n", secret.Env)
+	}
+	if secret.Repository != "" {
+		fmt.Printf("Repository: %s\n", secret.Repository)
+	}
+}
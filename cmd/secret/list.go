@@ -18,12 +18,14 @@ var listCmd = &cobra.Command{
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	apiClient, err := cmdutil.GetAPIClient()
+	ctx := cmd.Context()
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	secrets, err := apiClient.ListSecrets()
+	secrets, err := apiClient.ListSecrets(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list secrets: %w", err)
 	}
@@ -0,0 +1,181 @@
+package secret
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rotateKeys       []string
+	rotateLength     int
+	rotateCharset    string
+	rotateScope      string
+	rotateEnv        string
+	rotateRepository string
+	rotateRestart    bool
+	rotateYes        bool
+)
+
+var rotateCmd = &cobra.Command{
+	Use:   "rotate <name> --keys KEY1,KEY2",
+	Short: "Replace secret values with generated random values",
+	Long: `Rotate secret keys: every key given with --keys gets a new
+cryptographically random value. Other keys of the secret are kept.
+
+The new values are never printed; use 'lissto secret get --reveal' to read
+them. With --restart the deployments of every stack using the secret are
+restarted so they pick up the new values.
+
+Charsets: alnum (default), alpha, hex, numeric and symbols (alnum plus
+punctuation that is safe in shells and .env files).
+
+Examples:
+  # Rotate a database password in the current env
+  lissto secret rotate my-secrets --keys DB_PASSWORD
+
+  # Longer hex keys, then restart the stacks using them
+  lissto secret rotate my-secrets --keys API_KEY,SIGNING_KEY --length 64 --charset hex --restart
+
+  # Rotate a repo-scoped secret without prompting
+  lissto secret rotate app-secrets --scope repo --repository github.com/org/app --keys TOKEN --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRotate,
+}
+
+func init() {
+	rotateCmd.Flags().StringSliceVar(&rotateKeys, "keys", nil, "Keys to rotate (comma-separated or repeated)")
+	rotateCmd.Flags().IntVar(&rotateLength, "length", 32, "Length of the generated values")
+	rotateCmd.Flags().StringVar(&rotateCharset, "charset", "alnum", "Characters of the generated values: "+strings.Join(cmdutil.CharsetNames(), ", "))
+	rotateCmd.Flags().StringVarP(&rotateScope, "scope", "s", scopeEnv, "Scope: env, repo, or global")
+	rotateCmd.Flags().StringVarP(&rotateEnv, "env", "e", "", "Environment name (default: current env)")
+	rotateCmd.Flags().StringVarP(&rotateRepository, "repository", "r", "", "Repository (required for scope=repo)")
+	rotateCmd.Flags().BoolVar(&rotateRestart, "restart", false, "Restart the deployments of stacks using the secret")
+	rotateCmd.Flags().BoolVarP(&rotateYes, "yes", "y", false, "Skip confirmation prompt")
+	_ = rotateCmd.MarkFlagRequired("keys")
+}
+
+func runRotate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	name := args[0]
+
+	env := rotateEnv
+	if rotateScope == scopeEnv && env == "" {
+		env = cmdutil.GetCurrentEnv()
+		if env == "" {
+			return messages.Error(messages.NoEnvForScope, nil)
+		}
+	}
+
+	// Generate first, so bad --length or --charset values fail before any change
+	values := make(map[string]string, len(rotateKeys))
+	for _, key := range rotateKeys {
+		value, err := cmdutil.GenerateValue(rotateLength, rotateCharset)
+		if err != nil {
+			return err
+		}
+		values[key] = value
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	secret, err := apiClient.GetSecret(ctx, name, rotateScope, env, rotateRepository)
+	if err != nil {
+		return fmt.Errorf("failed to get secret: %w", err)
+	}
+
+	// Rotating a key that doesn't exist is most likely a typo
+	existing := make(map[string]bool, len(secret.Keys))
+	for _, key := range secret.Keys {
+		existing[key] = true
+	}
+	var unknown []string
+	for key := range values {
+		if !existing[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("secret '%s' has no key %s (use 'lissto secret set' to add keys)", name, strings.Join(unknown, ", "))
+	}
+
+	if !rotateYes {
+		message := fmt.Sprintf("Replace %s in '%s'? The current values will be lost", strings.Join(rotateKeys, ", "), name)
+		confirmed, err := interactive.ConfirmAction(message, false)
+		if err != nil || !confirmed {
+			return fmt.Errorf("rotation cancelled")
+		}
+	}
+
+	if _, err := apiClient.UpdateSecret(ctx, name, rotateScope, env, rotateRepository, &client.SetSecretRequest{Secrets: values}); err != nil {
+		return fmt.Errorf("failed to set secrets: %w", err)
+	}
+	fmt.Printf("✅ Rotated %d key(s) in secret '%s'\n", len(values), name)
+
+	if !rotateRestart {
+		return nil
+	}
+	return restartSecretConsumers(cmd, apiClient, secret)
+}
+
+// restartSecretConsumers rolls out the deployments of every stack the secret
+// applies to
+func restartSecretConsumers(cmd *cobra.Command, apiClient *client.Client, secret *client.SecretResponse) error {
+	ctx := cmd.Context()
+
+	stacks, err := cmdutil.StacksUsingConfig(ctx, apiClient, cmdutil.ScopedConfig{
+		Name:       secret.Name,
+		Scope:      secret.Scope,
+		Env:        secret.Env,
+		Repository: secret.Repository,
+	})
+	if err != nil {
+		return err
+	}
+	if len(stacks) == 0 {
+		fmt.Println("No stacks use this secret")
+		return nil
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+
+	var failed int
+	for i := range stacks {
+		stack := &stacks[i]
+		deployments, err := status.ListStackDeployments(ctx, k8sClient, stack)
+		if err == nil {
+			for j := range deployments {
+				if err = k8sClient.RestartDeployment(ctx, stack.Namespace, deployments[j].Name); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", stack.Name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("🔄 Restarted stack: %s (env: %s)\n", stack.Name, stack.Spec.Env)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to restart %d stack(s)", failed)
+	}
+	return nil
+}
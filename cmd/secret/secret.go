@@ -8,7 +8,7 @@ import (
 var SecretCmd = &cobra.Command{
 	Use:   "secret",
 	Short: "Manage secrets",
-	Long:  `Manage Lissto secrets. Secrets can be scoped to env, repo, or global. Values are write-only.`,
+	Long:  `Manage Lissto secrets. Secrets can be scoped to env, repo, or global. Values are write-only unless revealed with 'secret get --reveal'.`,
 }
 
 func init() {
@@ -17,4 +17,6 @@ func init() {
 	SecretCmd.AddCommand(createCmd)
 	SecretCmd.AddCommand(setCmd)
 	SecretCmd.AddCommand(deleteCmd)
+	SecretCmd.AddCommand(exportCmd)
+	SecretCmd.AddCommand(rotateCmd)
 }
@@ -31,6 +31,8 @@ func init() {
 }
 
 func runSet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	name := args[0]
 
 	// Parse secrets
@@ -39,7 +41,7 @@ func runSet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -49,7 +51,7 @@ func runSet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Use default scope (env) - TODO: add scope flags
-	secret, err := apiClient.UpdateSecret(name, "", "", "", req)
+	secret, err := apiClient.UpdateSecret(ctx, name, "", "", "", req)
 	if err != nil {
 		return fmt.Errorf("failed to set secrets: %w", err)
 	}
@@ -0,0 +1,138 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cloneToEnv         string
+	cloneWithVariables bool
+)
+
+// cloneResult is the -o json/yaml output of a clone
+type cloneResult struct {
+	Source    string            `json:"source" yaml:"source"`
+	SourceEnv string            `json:"sourceEnv" yaml:"sourceEnv"`
+	Stack     string            `json:"stack" yaml:"stack"`
+	Env       string            `json:"env" yaml:"env"`
+	Blueprint string            `json:"blueprint" yaml:"blueprint"`
+	Images    map[string]string `json:"images" yaml:"images"`
+}
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <stack-name>",
+	Short: "Create an identical stack in another environment",
+	Long: `Clone a stack into another environment. The new stack uses the same
+blueprint and runs exactly the same images: every service is pinned to the
+digest the source stack runs, even if its branch or tag moved on since.
+
+The source stack is looked up in the current env (or --env).
+
+Examples:
+  # Reproduce a stack from your env in staging
+  lissto stack clone my-stack --to-env staging
+
+  # Also copy the env-scoped variables
+  lissto stack clone my-stack --to-env staging --with-variables`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClone,
+}
+
+func init() {
+	cloneCmd.Flags().StringVar(&cloneToEnv, "to-env", "", "Environment to create the copy in (required)")
+	cloneCmd.Flags().BoolVar(&cloneWithVariables, "with-variables", false, "Merge the source env's variables into the target env")
+	_ = cloneCmd.MarkFlagRequired("to-env")
+}
+
+func runClone(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	stackName := args[0]
+
+	apiClient, envName, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return err
+	}
+	if cloneToEnv == envName {
+		return fmt.Errorf("--to-env must differ from the source env '%s'", envName)
+	}
+
+	progress := progressWriter(cmd)
+
+	source, err := findStack(ctx, apiClient, envName, stackName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := apiClient.GetEnv(ctx, cloneToEnv); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return fmt.Errorf("env '%s' %w", cloneToEnv, client.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get env: %w", err)
+	}
+
+	// The images are replaced by the source's digests below
+	branch, tag, commit := cmdutil.CloneRef(source)
+
+	blueprint := source.Spec.BlueprintReference
+	fmt.Fprintf(progress, "Preparing stack in env '%s'...\n", cloneToEnv)
+	prepareResp, err := apiClient.PrepareStack(ctx, blueprint, cloneToEnv, commit, branch, tag, true)
+	if err != nil {
+		return fmt.Errorf("failed to prepare stack: %w", err)
+	}
+
+	pinned, err := cmdutil.PinCloneImages(prepareResp.Images, source)
+	if err != nil {
+		output.PrintImageDiagnostics(progress, prepareResp.Images)
+		return fmt.Errorf("cannot clone stack: %w", err)
+	}
+
+	if cloneWithVariables {
+		name := cmdutil.GenerateResourceName(cmdutil.ScopeEnv, envName, "")
+		variable, err := apiClient.GetVariable(ctx, name, cmdutil.ScopeEnv, envName, "")
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			return fmt.Errorf("failed to get variables: %w", err)
+		}
+		if variable != nil {
+			if err := cmdutil.ApplyEnvVariables(ctx, progress, apiClient, cloneToEnv, variable.Data); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Fprintln(progress, "Creating stack...")
+	stackID, err := apiClient.CreateStack(ctx, blueprint, cloneToEnv, prepareResp.RequestID)
+	if err != nil {
+		return fmt.Errorf("failed to create stack: %w", err)
+	}
+
+	// The API deploys the prepared images; the pinned digests are applied on top
+	if pinned {
+		if err := apiClient.UpdateStack(ctx, client.StackName(stackID), cmdutil.StackImagesMap(prepareResp.Images)); err != nil {
+			return fmt.Errorf("stack created but failed to pin images: %w", err)
+		}
+	}
+
+	result := &cloneResult{
+		Source:    stackName,
+		SourceEnv: envName,
+		Stack:     stackID,
+		Env:       cloneToEnv,
+		Blueprint: blueprint,
+		Images:    make(map[string]string, len(prepareResp.Images)),
+	}
+	for _, img := range prepareResp.Images {
+		result.Images[img.Service] = img.Digest
+	}
+
+	return cmdutil.PrintOutput(cmd, result, func() {
+		fmt.Printf("✅ Stack '%s' cloned to env '%s'\n", stackName, cloneToEnv)
+		fmt.Printf("Stack ID: %s\n", stackID)
+	})
+}
@@ -15,6 +15,8 @@ var createCmd = &cobra.Command{
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	blueprintName := args[0]
 
 	apiClient, envName, err := cmdutil.GetAPIClientAndEnv(cmd)
@@ -24,7 +26,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	// First, prepare the stack to get request_id
 	fmt.Println("Preparing stack...")
-	prepareResp, err := apiClient.PrepareStack(blueprintName, envName, "", "", "", true)
+	prepareResp, err := apiClient.PrepareStack(ctx, blueprintName, envName, "", "", "", true)
 	if err != nil {
 		return fmt.Errorf("failed to prepare stack: %w", err)
 	}
@@ -44,7 +46,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	// Create stack with request_id
 	fmt.Println("Creating stack...")
-	identifier, err := apiClient.CreateStack(blueprintName, envName, prepareResp.RequestID)
+	identifier, err := apiClient.CreateStack(ctx, blueprintName, envName, prepareResp.RequestID)
 	if err != nil {
 		return fmt.Errorf("failed to create stack: %w", err)
 	}
@@ -22,7 +22,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := apiClient.DeleteStack(stackName, envName); err != nil {
+	if err := apiClient.DeleteStack(cmd.Context(), stackName, envName); err != nil {
 		return fmt.Errorf("failed to delete stack: %w", err)
 	}
 
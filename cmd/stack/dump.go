@@ -0,0 +1,257 @@
+package stack
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/dump"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/spinner"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/lissto-dev/cli/pkg/types"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	dumpOut  string
+	dumpTail int64
+)
+
+var dumpCmd = &cobra.Command{
+	Use:   "dump <stack-name>",
+	Short: "Capture a stack's runtime state for a bug report",
+	Long: `Write a tar.gz archive with everything needed to investigate a stack:
+
+  stack.yaml       the Stack resource
+  readiness.yaml   service readiness, as reported by 'lissto status'
+  pods/            pod specs and status
+  events.yaml      recent Kubernetes events of the stack
+  logs/            the last --tail lines of every container, with timestamps,
+                   and of the previous instance of restarted containers
+  errors.txt       what could not be collected, if anything
+
+Secret values are redacted: sensitive environment variable values in pod
+specs and values assigned to keys like password= or token: in log lines.
+Review the archive before sharing it, applications may log secrets in other
+forms.
+
+Examples:
+  # Write my-stack-<time>.tar.gz
+  lissto stack dump my-stack
+
+  # Choose the file and capture more log lines
+  lissto stack dump my-stack --out dump.tar.gz --tail 1000`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDump,
+}
+
+func init() {
+	dumpCmd.Flags().StringVar(&dumpOut, "out", "", "Archive to write (default: <stack>-<time>.tar.gz)")
+	dumpCmd.Flags().Int64Var(&dumpTail, "tail", 500, "Log lines to capture per container (use -1 for all)")
+}
+
+// dumpInfo describes a dump, written as dump.yaml
+type dumpInfo struct {
+	Stack     string    `json:"stack"`
+	Env       string    `json:"env"`
+	Namespace string    `json:"namespace"`
+	CreatedAt time.Time `json:"createdAt"`
+	TailLines int64     `json:"tailLines"`
+}
+
+// stackDump collects a stack's state into an archive. Failures to collect
+// a part are recorded in errors.txt rather than failing the dump.
+type stackDump struct {
+	k8sClient *k8s.Client
+	stack     *types.Stack
+	archive   *dump.Archive
+	errors    []string
+}
+
+func runDump(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	stackName := args[0]
+
+	apiClient, envName, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return err
+	}
+	stack, err := findStack(ctx, apiClient, envName, stackName)
+	if err != nil {
+		return err
+	}
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+
+	now := time.Now()
+	root := fmt.Sprintf("%s-%s", stack.Name, now.Format("20060102-150405"))
+	out := dumpOut
+	if out == "" {
+		out = root + ".tar.gz"
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", out, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	d := &stackDump{k8sClient: k8sClient, stack: stack, archive: dump.NewArchive(f, root)}
+	spin := spinner.Start(os.Stderr, "Capturing stack "+stack.Name)
+	if err := d.collect(ctx, apiClient, dumpInfo{
+		Stack:     stack.Name,
+		Env:       envName,
+		Namespace: stack.Namespace,
+		CreatedAt: now,
+		TailLines: dumpTail,
+	}); err != nil {
+		spin.Fail("Failed to write " + out)
+		_ = os.Remove(out)
+		return err
+	}
+	if err := d.archive.Close(); err != nil {
+		spin.Fail("Failed to write " + out)
+		_ = os.Remove(out)
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	spin.Success("Wrote " + out)
+
+	if len(d.errors) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  %d item(s) could not be collected, see errors.txt in the archive\n", len(d.errors))
+	}
+	return nil
+}
+
+// collect writes every part of the dump. Only archive write errors are
+// returned.
+func (d *stackDump) collect(ctx context.Context, apiClient *client.Client, info dumpInfo) error {
+	if err := d.archive.AddYAML("dump.yaml", info); err != nil {
+		return err
+	}
+
+	stack := d.stack.DeepCopy()
+	stack.ManagedFields = nil
+	if err := d.archive.AddYAML("stack.yaml", stack); err != nil {
+		return err
+	}
+
+	infra := func(ctx context.Context, ref string) []string {
+		if blueprint, err := apiClient.GetBlueprint(ctx, ref); err == nil {
+			return blueprint.Content.Infra
+		}
+		return nil
+	}
+	if err := d.archive.AddYAML("readiness.yaml", status.BuildStackReport(ctx, d.k8sClient, d.stack, infra)); err != nil {
+		return err
+	}
+
+	pods, err := d.k8sClient.ListPods(ctx, d.stack.Namespace, status.StackPodLabels(d.stack.Name))
+	if err != nil {
+		d.fail("pods", err)
+	}
+	for i := range pods {
+		if err := d.archive.AddYAML("pods/"+pods[i].Name+".yaml", dump.RedactPod(&pods[i])); err != nil {
+			return err
+		}
+	}
+
+	if err := d.addEvents(ctx, pods); err != nil {
+		return err
+	}
+	for i := range pods {
+		if err := d.addLogs(ctx, &pods[i]); err != nil {
+			return err
+		}
+	}
+
+	if len(d.errors) > 0 {
+		return d.archive.Add("errors.txt", []byte(strings.Join(d.errors, "\n")+"\n"))
+	}
+	return nil
+}
+
+// addEvents writes the events of the stack and its pods, oldest first
+func (d *stackDump) addEvents(ctx context.Context, pods []corev1.Pod) error {
+	events, err := d.k8sClient.ListEvents(ctx, d.stack.Namespace)
+	if err != nil {
+		d.fail("events", err)
+		return nil
+	}
+	filter := status.NewEventFilter(d.stack, pods)
+	reports := []status.EventReport{}
+	for i := range events {
+		if filter.Matches(&events[i]) {
+			reports = append(reports, status.NewEventReport(&events[i]))
+		}
+	}
+	return d.archive.AddYAML("events.yaml", reports)
+}
+
+// addLogs writes the logs of every container of a pod, and of the previous
+// instance of restarted containers
+func (d *stackDump) addLogs(ctx context.Context, pod *corev1.Pod) error {
+	restarts := make(map[string]int32)
+	for _, s := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		restarts[s.Name] = s.RestartCount
+	}
+
+	for _, c := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		if err := d.addContainerLogs(ctx, pod.Name, c.Name, false); err != nil {
+			return err
+		}
+		if restarts[c.Name] > 0 {
+			if err := d.addContainerLogs(ctx, pod.Name, c.Name, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *stackDump) addContainerLogs(ctx context.Context, podName, container string, previous bool) error {
+	name := fmt.Sprintf("logs/%s/%s.log", podName, container)
+	if previous {
+		name = fmt.Sprintf("logs/%s/%s.previous.log", podName, container)
+	}
+
+	opts := k8s.LogOptions{Container: container, Timestamps: true, Previous: previous}
+	if dumpTail >= 0 {
+		opts.TailLines = &dumpTail
+	}
+	stream, err := d.k8sClient.StreamLogs(ctx, d.stack.Namespace, podName, opts)
+	if err != nil {
+		d.fail(name, err)
+		return nil
+	}
+	defer func() { _ = stream.Close() }()
+
+	var b strings.Builder
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			b.WriteString(dump.RedactLine(line))
+		}
+		if err != nil {
+			if err != io.EOF {
+				d.fail(name, err)
+			}
+			break
+		}
+	}
+	return d.archive.Add(name, []byte(b.String()))
+}
+
+func (d *stackDump) fail(item string, err error) {
+	d.errors = append(d.errors, fmt.Sprintf("%s: %v", item, err))
+}
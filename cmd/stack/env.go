@@ -0,0 +1,151 @@
+package stack
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var envContainer string
+
+var envCmd = &cobra.Command{
+	Use:   "env <stack-name> <service>",
+	Short: "Show the environment a running service received",
+	Long: `Print the environment variables of a service's container, as set in its pod
+spec, with where each value comes from:
+
+  variable   a variable config (global, repo or env scope)
+  secret     a secret config; the value is masked
+  compose    set in the blueprint's compose file
+  reference  read from another Kubernetes object (secret, configmap, field)
+
+Variable and secret configs are matched the way the controller merges them,
+global < repo < env, for the stack's env and its blueprint's repository.
+
+Examples:
+  # Show where the api service's settings come from
+  lissto stack env my-stack api
+
+  # A specific container of the service, as JSON
+  lissto stack env my-stack api --container migrate -o json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runEnv,
+}
+
+func init() {
+	envCmd.Flags().StringVarP(&envContainer, "container", "c", "", "Container to show (default: the one named after the service, else the first)")
+}
+
+func runEnv(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	stackName, serviceName := args[0], args[1]
+
+	apiClient, envName, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return err
+	}
+	stack, err := findStack(ctx, apiClient, envName, stackName)
+	if err != nil {
+		return err
+	}
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+
+	pods, err := k8sClient.ListPods(ctx, stack.Namespace, status.StackPodLabels(stack.Name))
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	pods = status.MatchServicePods(pods, serviceName)
+	if len(pods) == 0 {
+		return fmt.Errorf("service '%s' %w in stack '%s' (no pods)", serviceName, client.ErrNotFound, stack.Name)
+	}
+	container, err := serviceContainer(&pods[0], serviceName, envContainer)
+	if err != nil {
+		return err
+	}
+
+	var repository string
+	if blueprint, err := apiClient.GetBlueprint(ctx, stack.Spec.BlueprintReference); err == nil {
+		repository = blueprint.Repository
+	}
+	variables, err := apiClient.ListVariables(ctx)
+	if err != nil {
+		return err
+	}
+	secrets, err := apiClient.ListSecrets(ctx)
+	if err != nil {
+		return err
+	}
+	variableConfigs := make([]cmdutil.ScopedConfig, 0, len(variables))
+	for _, v := range variables {
+		variableConfigs = append(variableConfigs, cmdutil.ScopedConfig{Name: v.Name, Scope: v.Scope, Env: v.Env, Repository: v.Repository, Data: v.Data})
+	}
+	secretConfigs := make([]cmdutil.ScopedConfig, 0, len(secrets))
+	for _, s := range secrets {
+		keys := make(map[string]string, len(s.Keys))
+		for _, key := range s.Keys {
+			keys[key] = ""
+		}
+		secretConfigs = append(secretConfigs, cmdutil.ScopedConfig{Name: s.Name, Scope: s.Scope, Env: s.Env, Repository: s.Repository, Data: keys})
+	}
+
+	entries := cmdutil.EffectiveEnv(container,
+		cmdutil.MergeScopes(variableConfigs, envName, repository),
+		cmdutil.MergeScopes(secretConfigs, envName, repository))
+
+	return cmdutil.PrintOutput(cmd, entries, func() {
+		fmt.Printf("Environment of %s/%s (pod %s, container %s):\n", stack.Name, serviceName, pods[0].Name, container.Name)
+		if len(entries) == 0 {
+			fmt.Println("  No environment variables")
+			return
+		}
+		rows := make([][]string, 0, len(entries))
+		for _, e := range entries {
+			source := e.Source
+			switch {
+			case e.Scope != "":
+				source = fmt.Sprintf("%s %s (%s)", e.Source, e.Config, e.Scope)
+			case e.Config != "":
+				source = e.Source + " " + e.Config
+			}
+			value := e.Value
+			if e.Masked {
+				value = output.Gray(value)
+			}
+			rows = append(rows, []string{e.Name, value, source})
+		}
+		output.PrintTable(os.Stdout, []string{"NAME", "VALUE", "SOURCE"}, rows)
+	})
+}
+
+// serviceContainer picks the container to show: the named one, else the one
+// named after the service, else the first
+func serviceContainer(pod *corev1.Pod, service, name string) (*corev1.Container, error) {
+	containers := pod.Spec.Containers
+	if name != "" {
+		for i := range containers {
+			if containers[i].Name == name {
+				return &containers[i], nil
+			}
+		}
+		return nil, fmt.Errorf("container '%s' %w in pod %s", name, client.ErrNotFound, pod.Name)
+	}
+	for i := range containers {
+		if containers[i].Name == service {
+			return &containers[i], nil
+		}
+	}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("pod %s has no containers", pod.Name)
+	}
+	return &containers[0], nil
+}
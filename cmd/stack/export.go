@@ -0,0 +1,138 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/stackfile"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFile          string
+	exportPinImages     bool
+	exportWithVariables bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <stack-name>",
+	Short: "Export a stack as a stack file",
+	Long: `Generate a stack file from an existing stack, to store in git and redeploy
+with 'lissto create -f'.
+
+The file records the blueprint, env and the branch/tag/commit the stack's
+images were resolved from. --pin-images also records every service's current
+image by digest, so the exact deployment can be reproduced.
+
+Examples:
+  # Print the stack file
+  lissto stack export my-stack
+
+  # Write it to stack.yaml, pinning the current images
+  lissto stack export my-stack -f stack.yaml --pin-images
+
+  # Include the env's variables
+  lissto stack export my-stack -f stack.yaml --with-variables`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFile, "file", "f", "", "Write the stack file to this path instead of stdout")
+	exportCmd.Flags().BoolVar(&exportPinImages, "pin-images", false, "Record the current image digest of every service")
+	exportCmd.Flags().BoolVar(&exportWithVariables, "with-variables", false, "Include the env-scoped variables")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	stackName := args[0]
+
+	apiClient, envName, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return err
+	}
+
+	stacks, err := apiClient.ListStacks(ctx, envName)
+	if err != nil {
+		return fmt.Errorf("failed to list stacks: %w", err)
+	}
+
+	var spec *stackfile.Spec
+	for _, s := range stacks {
+		if s.Name != stackName {
+			continue
+		}
+		spec = &stackfile.Spec{
+			Version:   stackfile.Version,
+			Blueprint: s.Spec.BlueprintReference,
+			Env:       envName,
+			Branch:    s.Spec.Metadata.Branch,
+			Tag:       s.Spec.Metadata.Tag,
+			Commit:    s.Spec.Metadata.Commit,
+		}
+
+		// Branch is the most reusable reference; keep only one
+		switch {
+		case spec.Branch != "":
+			spec.Tag, spec.Commit = "", ""
+		case spec.Tag != "":
+			spec.Commit = ""
+		}
+
+		if exportPinImages {
+			spec.Images = make(map[string]string, len(s.Spec.Images))
+			for service, img := range s.Spec.Images {
+				if img.Image == "" || img.Digest == "" {
+					continue
+				}
+				spec.Images[service] = pinnedImage(img.Image, img.Digest)
+			}
+		}
+		break
+	}
+	if spec == nil {
+		return fmt.Errorf("stack '%s' %w in env '%s'", stackName, client.ErrNotFound, envName)
+	}
+
+	if exportWithVariables {
+		name := cmdutil.GenerateResourceName(cmdutil.ScopeEnv, envName, "")
+		variable, err := apiClient.GetVariable(ctx, name, cmdutil.ScopeEnv, envName, "")
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			return fmt.Errorf("failed to get variables: %w", err)
+		}
+		if variable != nil {
+			spec.Variables = variable.Data
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if exportFile != "" {
+		f, err := os.Create(exportFile)
+		if err != nil {
+			return fmt.Errorf("failed to create stack file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if err := stackfile.Write(out, spec); err != nil {
+		return err
+	}
+
+	if exportFile != "" {
+		fmt.Fprintf(os.Stderr, "✅ Stack '%s' exported to %s\n", stackName, exportFile)
+	}
+	return nil
+}
+
+// pinnedImage combines an image reference with its digest
+func pinnedImage(image, digest string) string {
+	name, _, _ := strings.Cut(image, "@")
+	return name + "@" + digest
+}
@@ -22,7 +22,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	identifier, err := apiClient.GetStack(stackName, envName)
+	identifier, err := apiClient.GetStack(cmd.Context(), stackName, envName)
 	if err != nil {
 		return fmt.Errorf("failed to get stack: %w", err)
 	}
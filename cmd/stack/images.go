@@ -0,0 +1,75 @@
+package stack
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/registry"
+	"github.com/spf13/cobra"
+)
+
+var imagesNoProvenance bool
+
+var imagesCmd = &cobra.Command{
+	Use:   "images <stack-name>",
+	Short: "Show the images a stack runs and where they came from",
+	Long: `Show, per service, the image and digest a stack is deployed with, the
+branch/tag/commit they were resolved from and the registry they live in.
+
+The commit an image was built from and its build time are read from the
+image's OCI labels (org.opencontainers.image.revision and .created) when the
+registry allows anonymous access, so reviewers can confirm exactly which
+code is running.
+
+Examples:
+  lissto stack images my-stack
+
+  # Skip the registry lookups
+  lissto stack images my-stack --no-provenance
+
+  # Full digests and provenance
+  lissto stack images my-stack -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImages,
+}
+
+func init() {
+	imagesCmd.Flags().BoolVar(&imagesNoProvenance, "no-provenance", false, "Don't query registries for build time and commit")
+}
+
+func runImages(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	apiClient, envName, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return err
+	}
+	stack, err := findStack(ctx, apiClient, envName, args[0])
+	if err != nil {
+		return err
+	}
+
+	var provenance map[string]*registry.Provenance
+	if !imagesNoProvenance {
+		digests := make(map[string]registry.ImageDigest, len(stack.Spec.Images))
+		for service, img := range stack.Spec.Images {
+			digests[service] = registry.ImageDigest{Image: img.Image, Digest: img.Digest}
+		}
+		lookupCtx, cancel := context.WithTimeout(ctx, registry.ProvenanceTimeout)
+		provenance = registry.FetchProvenances(lookupCtx, digests)
+		cancel()
+	}
+
+	images := output.StackImages(stack, provenance)
+	return cmdutil.PrintOutput(cmd, images, func() {
+		if len(images) == 0 {
+			fmt.Printf("Stack '%s' has no images.\n", stack.Name)
+			return
+		}
+		fmt.Printf("Images of stack '%s' (env: %s)\n\n", stack.Name, envName)
+		output.PrintStackImages(os.Stdout, images)
+	})
+}
@@ -1,14 +1,17 @@
 package stack
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/lissto-dev/cli/pkg/cmdutil"
 	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
 	"github.com/lissto-dev/cli/pkg/output"
 	"github.com/lissto-dev/cli/pkg/types"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
 	"github.com/spf13/cobra"
 )
 
@@ -25,30 +28,101 @@ Examples:
   lissto stack list -o wide
 
   # List stacks in a specific environment
-  lissto stack list --env dev`,
+  lissto stack list --env dev
+
+  # List stacks of every context (all environments unless --env is given)
+  lissto stack list --all-contexts
+
+  # Show the last stacks fetched while the cluster is unreachable
+  lissto stack list --cached
+
+  # List the stacks labeled for a team (see 'lissto create --label')
+  lissto stack list -l team=payments
+
+  # List stacks past their TTL (see 'lissto create --ttl' and 'lissto gc')
+  lissto stack list --expired`,
 	RunE: runList,
 }
 
+// contextStacks are the stacks of one context, for --all-contexts output
+type contextStacks struct {
+	Context string              `json:"context" yaml:"context"`
+	Stacks  []envv1alpha1.Stack `json:"stacks" yaml:"stacks"`
+}
+
+var listExpired bool
+
+func init() {
+	cmdutil.AddAllContextsFlag(listCmd)
+	listCmd.Flags().BoolVar(&listExpired, "expired", false, "Only list stacks past their TTL")
+	cmdutil.AddCachedFlag(listCmd)
+	cmdutil.AddSelectorFlag(listCmd)
+}
+
 func runList(cmd *cobra.Command, args []string) error {
-	apiClient, envName, err := cmdutil.GetAPIClientAndEnv(cmd)
+	// The current env belongs to the current context, so --all-contexts
+	// lists every environment unless --env is given
+	allContexts := cmdutil.AllContexts(cmd)
+	selector, err := cmdutil.StackSelector(cmd)
 	if err != nil {
 		return err
 	}
+	envName, _ := cmd.Flags().GetString("env")
+	if envName == "" && !allContexts {
+		envName = cmdutil.GetCurrentEnv()
+		if envName == "" {
+			return messages.Error(messages.NoEnvSelected, nil)
+		}
+	}
 
-	stacks, err := apiClient.ListStacks(envName)
+	results, err := cmdutil.ForEachContextCached(cmd, "stacks-"+envName, func(ctx context.Context, target cmdutil.ContextTarget) ([]envv1alpha1.Stack, error) {
+		stacks, err := target.Client.ListStacks(ctx, envName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stacks: %w", err)
+		}
+		return stacks, nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to list stacks: %w", err)
+		return err
+	}
+	for i := range results {
+		results[i].Value = cmdutil.FilterStacks(results[i].Value, selector)
+		if listExpired {
+			results[i].Value = expiredStacks(results[i].Value, time.Now())
+		}
+	}
+
+	total := 0
+	for _, result := range results {
+		total += len(result.Value)
 	}
 
 	// Check if no stacks exist
-	if len(stacks) == 0 {
+	if total == 0 {
+		if listExpired {
+			fmt.Println("No expired stacks found.")
+			return nil
+		}
+		if !selector.Empty() {
+			fmt.Printf("No stacks match selector '%s'.\n", selector)
+			return nil
+		}
 		fmt.Println("No stacks found. Use 'lissto create' to create a new stack.")
 		return nil
 	}
 
+	var data interface{} = results[0].Value
+	if allContexts {
+		grouped := make([]contextStacks, 0, len(results))
+		for _, result := range results {
+			grouped = append(grouped, contextStacks{Context: result.Context, Stacks: result.Value})
+		}
+		data = grouped
+	}
+
 	format := cmdutil.GetOutputFormat(cmd)
 
-	return cmdutil.PrintOutput(cmd, stacks, func() {
+	return cmdutil.PrintOutput(cmd, data, func() {
 		// Table format - check if wide format is requested
 		isWide := format == "wide"
 		var headers []string
@@ -57,31 +131,50 @@ func runList(cmd *cobra.Command, args []string) error {
 		} else {
 			headers = []string{"NAME", "ENV", "BLUEPRINT", "AGE"}
 		}
+		if allContexts {
+			headers = append([]string{"CONTEXT"}, headers...)
+		}
 
 		var rows [][]string
-		for _, stack := range stacks {
-			// Calculate age using time.Since
-			duration := time.Since(stack.CreationTimestamp.Time)
-			age := k8s.FormatAge(duration)
-
-			// Get blueprint title from annotations, fallback to blueprint reference
-			blueprintTitle := types.GetBlueprintTitle(&stack)
-			if blueprintTitle == "" {
-				blueprintTitle = stack.Spec.BlueprintReference
-			}
+		for _, result := range results {
+			for _, stack := range result.Value {
+				// Calculate age using time.Since
+				duration := time.Since(stack.CreationTimestamp.Time)
+				age := k8s.FormatAge(duration)
 
-			// Get environment from spec
-			env := stack.Spec.Env
+				// Get blueprint title from annotations, fallback to blueprint reference
+				blueprintTitle := types.GetBlueprintTitle(&stack)
+				if blueprintTitle == "" {
+					blueprintTitle = stack.Spec.BlueprintReference
+				}
 
-			// Build row based on format
-			var row []string
-			if isWide {
-				row = []string{stack.Name, env, blueprintTitle, stack.Spec.BlueprintReference, age}
-			} else {
-				row = []string{stack.Name, env, blueprintTitle, age}
+				// Get environment from spec
+				env := stack.Spec.Env
+
+				// Build row based on format
+				var row []string
+				if isWide {
+					row = []string{stack.Name, env, blueprintTitle, stack.Spec.BlueprintReference, age}
+				} else {
+					row = []string{stack.Name, env, blueprintTitle, age}
+				}
+				if allContexts {
+					row = append([]string{result.Context}, row...)
+				}
+				rows = append(rows, row)
 			}
-			rows = append(rows, row)
 		}
 		output.PrintTable(os.Stdout, headers, rows)
 	})
 }
+
+// expiredStacks returns the stacks whose TTL has passed at now
+func expiredStacks(stacks []envv1alpha1.Stack, now time.Time) []envv1alpha1.Stack {
+	expired := []envv1alpha1.Stack{}
+	for i := range stacks {
+		if types.IsStackExpired(&stacks[i], now) {
+			expired = append(expired, stacks[i])
+		}
+	}
+	return expired
+}
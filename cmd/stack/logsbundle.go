@@ -0,0 +1,164 @@
+package stack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/dump"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/spinner"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	bundleSince string
+	bundleUntil string
+	bundleOut   string
+)
+
+var logsBundleCmd = &cobra.Command{
+	Use:   "logs-bundle <stack-name>",
+	Short: "Collect a stack's logs around an incident into one file",
+	Long: `Collect the logs every container of a stack wrote in a time window and
+merge them chronologically into one interleaved file, for post-mortems.
+
+--since and --until take a duration before now (30m) or an RFC3339
+timestamp. Every line is prefixed with its timestamp and pod/container; logs
+of the previous instance of restarted containers are included and marked
+"(previous)". Values assigned to keys like password= or token: are redacted.
+
+Logs only go back as far as the cluster keeps them, and pods deleted since
+the incident are gone.
+
+Examples:
+  # From 30 minutes ago until 10 minutes ago
+  lissto stack logs-bundle my-stack --since 30m --until 10m
+
+  # An exact window, to stdout
+  lissto stack logs-bundle my-stack --since 2025-01-02T03:00:00Z --until 2025-01-02T03:15:00Z --out -`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLogsBundle,
+}
+
+func init() {
+	logsBundleCmd.Flags().StringVar(&bundleSince, "since", "1h", "Start of the window: a duration before now or an RFC3339 timestamp")
+	logsBundleCmd.Flags().StringVar(&bundleUntil, "until", "", "End of the window: a duration before now or an RFC3339 timestamp (default: now)")
+	logsBundleCmd.Flags().StringVar(&bundleOut, "out", "", "File to write, - for stdout (default: <stack>-logs-<time>.log)")
+}
+
+func runLogsBundle(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	now := time.Now()
+
+	since, err := k8s.ParseLogTime(bundleSince, now)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	var until time.Time
+	if bundleUntil != "" {
+		if until, err = k8s.ParseLogTime(bundleUntil, now); err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+		if !until.After(since) {
+			return fmt.Errorf("--until must be after --since")
+		}
+	}
+
+	apiClient, envName, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return err
+	}
+	stack, err := findStack(ctx, apiClient, envName, args[0])
+	if err != nil {
+		return err
+	}
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+
+	spin := spinner.StartTransient(os.Stderr, "Collecting logs of stack "+stack.Name)
+	pods, err := k8sClient.ListPods(ctx, stack.Namespace, status.StackPodLabels(stack.Name))
+	if err != nil {
+		spin.Stop()
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	var sources [][]dump.LogLine
+	var failures []string
+	for i := range pods {
+		for _, source := range logSources(&pods[i]) {
+			lines, err := collectLogs(ctx, k8sClient, stack.Namespace, source, since, until)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s/%s: %v", source.Pod, source.Container, err))
+			}
+			sources = append(sources, lines)
+		}
+	}
+	spin.Stop()
+	merged := dump.MergeLogs(sources...)
+
+	out := bundleOut
+	if out == "" {
+		out = fmt.Sprintf("%s-logs-%s.log", stack.Name, now.Format("20060102-150405"))
+	}
+	var w io.Writer = os.Stdout
+	if out != "-" {
+		f, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", out, err)
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+	if err := dump.WriteLogLines(w, merged); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+
+	for _, failure := range failures {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", failure)
+	}
+	if out != "-" {
+		fmt.Fprintf(os.Stderr, "✅ Wrote %d lines from %d pods to %s\n", len(merged), len(pods), out)
+	}
+	return nil
+}
+
+// logSources lists the containers of a pod, and the previous instance of
+// restarted ones
+func logSources(pod *corev1.Pod) []dump.LogSource {
+	restarts := make(map[string]int32)
+	for _, s := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		restarts[s.Name] = s.RestartCount
+	}
+
+	var sources []dump.LogSource
+	for _, c := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		if restarts[c.Name] > 0 {
+			sources = append(sources, dump.LogSource{Pod: pod.Name, Container: c.Name, Previous: true})
+		}
+		sources = append(sources, dump.LogSource{Pod: pod.Name, Container: c.Name})
+	}
+	return sources
+}
+
+// collectLogs reads the lines a container logged between since and until
+func collectLogs(ctx context.Context, k8sClient *k8s.Client, namespace string, source dump.LogSource, since, until time.Time) ([]dump.LogLine, error) {
+	stream, err := k8sClient.StreamLogs(ctx, namespace, source.Pod, k8s.LogOptions{
+		Container:  source.Container,
+		Previous:   source.Previous,
+		Timestamps: true,
+		SinceTime:  &since,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stream.Close() }()
+	return dump.ReadLogLines(stream, source, since, until)
+}
@@ -0,0 +1,208 @@
+package stack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/lissto-dev/cli/pkg/types"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// pausedService is one service changed by pause or resume
+type pausedService struct {
+	Service    string `json:"service" yaml:"service"`
+	Deployment string `json:"deployment" yaml:"deployment"`
+	Replicas   int32  `json:"replicas" yaml:"replicas"`
+}
+
+// pauseResult is the -o json/yaml output of pause and resume
+type pauseResult struct {
+	Stack    string          `json:"stack" yaml:"stack"`
+	Env      string          `json:"env" yaml:"env"`
+	Services []pausedService `json:"services" yaml:"services"`
+}
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause <stack-name>",
+	Short: "Scale all services of a stack to zero",
+	Long: `Pause a stack: every deployment of the stack is scaled to zero replicas,
+so an idle stack stops using cluster capacity. The stack itself is kept and
+'lissto stack resume' brings every service back to its previous replica count,
+which is recorded in a deployment annotation.
+
+Examples:
+  # Free the resources of a preview stack overnight
+  lissto stack pause my-stack
+
+  # Bring it back in the morning
+  lissto stack resume my-stack`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPause,
+}
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume <stack-name>",
+	Short: "Restore the services of a paused stack",
+	Long: `Resume a stack paused with 'lissto stack pause': every paused deployment is
+scaled back to the replica count it had before.
+
+Examples:
+  lissto stack resume my-stack`,
+	Args: cobra.ExactArgs(1),
+	RunE: runResume,
+}
+
+func runPause(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	stack, envName, k8sClient, err := stackDeploymentTarget(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	deployments, err := status.ListStackDeployments(ctx, k8sClient, stack)
+	if err != nil {
+		return err
+	}
+	if len(deployments) == 0 {
+		return fmt.Errorf("no deployments found for stack '%s'", stack.Name)
+	}
+	sortDeployments(deployments)
+
+	progress := progressWriter(cmd)
+	result := &pauseResult{Stack: stack.Name, Env: envName, Services: []pausedService{}}
+	for i := range deployments {
+		deployment := &deployments[i]
+		service := status.DeploymentService(deployment)
+		// A second pause must not overwrite the recorded replica count with 0
+		if _, paused := status.PausedReplicas(deployment); paused {
+			continue
+		}
+		replicas := status.DeploymentReplicas(deployment)
+		if replicas == 0 {
+			continue
+		}
+
+		// Record before scaling, so a failed scale can still be resumed
+		recorded := strconv.Itoa(int(replicas))
+		annotations := map[string]*string{status.AnnotationPausedReplicas: &recorded}
+		if err := k8sClient.AnnotateDeployment(ctx, stack.Namespace, deployment.Name, annotations); err != nil {
+			return err
+		}
+		if _, err := k8sClient.ScaleDeployment(ctx, stack.Namespace, deployment.Name, 0); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(progress, "  ⏸️  %s: %d → 0\n", service, replicas)
+		result.Services = append(result.Services, pausedService{Service: service, Deployment: deployment.Name, Replicas: replicas})
+	}
+
+	return cmdutil.PrintOutput(cmd, result, func() {
+		if len(result.Services) == 0 {
+			fmt.Printf("Stack '%s' is already paused\n", stack.Name)
+			return
+		}
+		fmt.Printf("✅ Stack '%s' paused. Resume it with: lissto stack resume %s\n", stack.Name, stack.Name)
+	})
+}
+
+func runResume(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	stack, envName, k8sClient, err := stackDeploymentTarget(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	deployments, err := status.ListStackDeployments(ctx, k8sClient, stack)
+	if err != nil {
+		return err
+	}
+	sortDeployments(deployments)
+
+	progress := progressWriter(cmd)
+	result := &pauseResult{Stack: stack.Name, Env: envName, Services: []pausedService{}}
+	for i := range deployments {
+		deployment := &deployments[i]
+		replicas, paused := status.PausedReplicas(deployment)
+		if !paused {
+			continue
+		}
+		service := status.DeploymentService(deployment)
+
+		if _, err := k8sClient.ScaleDeployment(ctx, stack.Namespace, deployment.Name, replicas); err != nil {
+			return err
+		}
+		annotations := map[string]*string{status.AnnotationPausedReplicas: nil}
+		if err := k8sClient.AnnotateDeployment(ctx, stack.Namespace, deployment.Name, annotations); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(progress, "  ▶️  %s: 0 → %d\n", service, replicas)
+		result.Services = append(result.Services, pausedService{Service: service, Deployment: deployment.Name, Replicas: replicas})
+	}
+
+	return cmdutil.PrintOutput(cmd, result, func() {
+		if len(result.Services) == 0 {
+			fmt.Printf("Stack '%s' is not paused\n", stack.Name)
+			return
+		}
+		fmt.Printf("✅ Stack '%s' resumed\n", stack.Name)
+	})
+}
+
+// sortDeployments orders deployments by service for stable output
+func sortDeployments(deployments []appsv1.Deployment) {
+	sort.Slice(deployments, func(i, j int) bool {
+		return status.DeploymentService(&deployments[i]) < status.DeploymentService(&deployments[j])
+	})
+}
+
+// stackDeploymentTarget finds a stack in the current env and connects to the
+// cluster running it
+func stackDeploymentTarget(cmd *cobra.Command, stackName string) (*types.Stack, string, *k8s.Client, error) {
+	apiClient, envName, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	stack, err := findStack(cmd.Context(), apiClient, envName, stackName)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to connect to Kubernetes: %w", err)
+	}
+	return stack, envName, k8sClient, nil
+}
+
+// findStack looks up a stack by name in an env
+func findStack(ctx context.Context, apiClient *client.Client, envName, stackName string) (*types.Stack, error) {
+	stacks, err := apiClient.ListStacks(ctx, envName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", err)
+	}
+	for i := range stacks {
+		if stacks[i].Name == stackName {
+			return &stacks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("stack '%s' %w in env '%s'", stackName, client.ErrNotFound, envName)
+}
+
+// progressWriter returns where progress goes: stderr when stdout carries
+// machine-readable output
+func progressWriter(cmd *cobra.Command) io.Writer {
+	if cmdutil.GetOutputFormat(cmd) != "" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
@@ -16,4 +16,12 @@ func init() {
 	StackCmd.AddCommand(getCmd)
 	StackCmd.AddCommand(createCmd)
 	StackCmd.AddCommand(deleteCmd)
+	StackCmd.AddCommand(exportCmd)
+	StackCmd.AddCommand(cloneCmd)
+	StackCmd.AddCommand(pauseCmd)
+	StackCmd.AddCommand(resumeCmd)
+	StackCmd.AddCommand(dumpCmd)
+	StackCmd.AddCommand(imagesCmd)
+	StackCmd.AddCommand(logsBundleCmd)
+	StackCmd.AddCommand(envCmd)
 }
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/lissto-dev/cli/pkg/stackfile"
+	"github.com/spf13/cobra"
+)
+
+// applyStackFile loads a stack file into the create flags. Flags given on the
+// command line take precedence over the file.
+func applyStackFile(cmd *cobra.Command, path string) (*stackfile.Spec, error) {
+	spec, err := stackfile.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := cmd.Flags()
+	if !flags.Changed("blueprint") {
+		createBlueprint = spec.Blueprint
+	}
+	if createEnv == "" && envName == "" {
+		createEnv = spec.Env
+	}
+	if !flags.Changed("branch") && !flags.Changed("tag") && !flags.Changed("commit") {
+		createBranch, createTag, createCommit = spec.Branch, spec.Tag, spec.Commit
+	}
+
+	// --param flags come last so they override the file's values
+	fileParams := make([]string, 0, len(spec.Params))
+	for k, v := range spec.Params {
+		fileParams = append(fileParams, k+"="+v)
+	}
+	sort.Strings(fileParams)
+	createParams = append(fileParams, createParams...)
+
+	return spec, nil
+}
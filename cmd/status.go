@@ -2,22 +2,24 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/cmdutil"
-	"github.com/lissto-dev/cli/pkg/config"
 	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
 	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/spinner"
 	"github.com/lissto-dev/cli/pkg/status"
 	"github.com/lissto-dev/cli/pkg/types"
 	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // Output format constants
@@ -25,16 +27,30 @@ const (
 	outputFormatJSON  = "json"
 	outputFormatYAML  = "yaml"
 	outputFormatTable = "table"
+	outputFormatID    = "id"
 )
 
+// errStatusCached explains the missing pod details of --cached
+var errStatusCached = errors.New("showing cached stacks (--cached)")
+
 // Pod status constants
 const (
-	podStatusError   = "Error"
-	podStatusPending = "Pending"
+	podStatusError   = status.PodStateError
+	podStatusPending = status.PodStatePending
 )
 
 var (
 	statusEnvFilter string
+	statusRaw       bool
+	statusServe     string
+	statusInterval  time.Duration
+	statusWatch     bool
+	statusAnalyze   bool
+	statusWindow    time.Duration
+	statusSummary   bool
+
+	// statusSelector is the parsed --selector
+	statusSelector = labels.Everything()
 )
 
 var statusCmd = &cobra.Command{
@@ -47,8 +63,54 @@ Shows deployment status, services, and pod-level details.
 Output formats:
   (default)    Detailed view with emojis and pod status
   -o table     Compact table view
-  -o json      Raw JSON output
-  -o yaml      Raw YAML output`,
+  -o json      Normalized status (env → stacks → services → pods) as JSON
+  -o yaml      Normalized status as YAML
+  -o jsonpath=EXPR, -o go-template=TEMPLATE
+               Extract fields from the normalized status
+
+Use --raw with -o json/yaml to print the raw Stack resources instead.
+
+Use --summary for one line per environment (ready stacks, erroring and
+pending pods, and a health score: the percentage of ready services), e.g. for
+shell prompts. It exits with code 3 unless every stack is ready and no pod is
+erroring, so it also works as a CI gate; -o json/yaml prints the counts.
+
+Use --context NAME to show another context without switching to it, or
+--all-contexts to show every context; the table view then gets a CONTEXT
+column and JSON/YAML output is grouped by context.
+
+Use -l/--selector to only show stacks with matching labels (see
+'lissto create --label').
+
+Use --cached when the cluster or VPN is down to show the stacks from the last
+successful run, without pod details.
+
+Use --watch to refresh the view every --interval until Ctrl+C. Add --analyze
+to record every service's readiness meanwhile and list flapping services:
+ones that changed between ready and not ready twice or more, or whose
+containers restarted three times or more during the last --window. This
+spots crashloops that look ready between restarts.
+
+Use --serve ADDR to keep running and expose the status over HTTP instead:
+  /metrics     Prometheus metrics: lissto_stack_ready, lissto_service_ready,
+               lissto_pod_ready, lissto_pod_restarts_total, lissto_up, ...
+  /healthz     JSON summary; 200 when every stack is ready, 503 otherwise
+
+Examples:
+  # Compact table of every stack
+  lissto status -o table
+
+  # Only the payments team's stacks
+  lissto status -l team=payments
+
+  # Fail a CI job unless the dev environment is healthy
+  lissto status --env dev --summary
+
+  # Watch the dev environment and spot flapping services
+  lissto status --env dev --watch --analyze --interval 10s
+
+  # Let Prometheus scrape the dev environment
+  lissto status --env dev --serve :9090`,
 	RunE:          runStatus,
 	SilenceUsage:  true,
 	SilenceErrors: false,
@@ -57,67 +119,246 @@ Output formats:
 func init() {
 	rootCmd.AddCommand(statusCmd)
 	statusCmd.Flags().StringVar(&statusEnvFilter, "env", "", "Filter by environment name")
+	statusCmd.Flags().BoolVar(&statusRaw, "raw", false, "Print raw Stack resources for -o json/yaml")
+	cmdutil.AddAllContextsFlag(statusCmd)
+	cmdutil.AddCachedFlag(statusCmd)
+	cmdutil.AddSelectorFlag(statusCmd)
+	statusCmd.Flags().StringVar(&statusServe, "serve", "", "Serve status as Prometheus metrics and a /healthz summary on this address (e.g. :9090)")
+	statusCmd.Flags().DurationVar(&statusInterval, "interval", 15*time.Second, "With --serve or --watch, how often to refresh the status")
+	statusCmd.Flags().BoolVar(&statusSummary, "summary", false, "Print one health line per environment; exit non-zero unless all are healthy")
+	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Refresh the status every --interval until Ctrl+C")
+	statusCmd.Flags().BoolVar(&statusAnalyze, "analyze", false, "With --watch, report services that flap between ready and not ready")
+	statusCmd.Flags().DurationVar(&statusWindow, "window", 10*time.Minute, "With --analyze, how much readiness history to consider")
+}
+
+// statusContext is the status data fetched from one Lissto context
+type statusContext struct {
+	name      string
+	apiClient *client.Client
+	k8sClient *k8s.Client // nil when Kubernetes access is unavailable
+	k8sErr    error
+	stacks    []envv1alpha1.Stack
+	envGroups map[string][]envv1alpha1.Stack
+}
+
+// contextReport is the status report of one context, for --all-contexts
+type contextReport struct {
+	Context        string `json:"context" yaml:"context"`
+	*status.Report `yaml:",inline"`
+}
+
+// contextRawStacks are the raw stacks of one context, for --all-contexts --raw
+type contextRawStacks struct {
+	Context string              `json:"context" yaml:"context"`
+	Stacks  []envv1alpha1.Stack `json:"stacks" yaml:"stacks"`
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
-	// Load config
-	cfg, err := config.LoadConfig()
+	ctx := cmd.Context()
+
+	if statusServe != "" && cmdutil.AllContexts(cmd) {
+		return fmt.Errorf("--serve cannot be combined with --all-contexts; run one server per context")
+	}
+	if statusServe != "" && cmdutil.Cached(cmd) {
+		return fmt.Errorf("--serve cannot be combined with --cached")
+	}
+	if statusInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if statusSummary && (statusWatch || statusServe != "" || cmdutil.AllContexts(cmd)) {
+		return fmt.Errorf("--summary cannot be combined with --watch, --serve or --all-contexts")
+	}
+	if statusAnalyze && !statusWatch {
+		return fmt.Errorf("--analyze requires --watch")
+	}
+	if statusWatch {
+		switch {
+		case statusServe != "":
+			return fmt.Errorf("--watch cannot be combined with --serve")
+		case cmdutil.AllContexts(cmd):
+			return fmt.Errorf("--watch cannot be combined with --all-contexts")
+		case cmdutil.Cached(cmd):
+			return fmt.Errorf("--watch cannot be combined with --cached")
+		}
+		if format := cmdutil.GetOutputFormat(cmd); format != "" && format != outputFormatTable && format != "pretty" {
+			return fmt.Errorf("--watch only supports the default and table output")
+		}
+	}
+	selector, err := cmdutil.StackSelector(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
+	statusSelector = selector
 
-	// Get current context
-	ctx, err := cfg.GetCurrentContext()
+	// The spinner goes to stderr and only to a terminal, so it never mixes
+	// with -o json/yaml
+	spin := spinner.StartTransient(os.Stderr, "Loading stacks")
+	results, err := loadStatusContexts(cmd)
+	spin.Stop()
 	if err != nil {
-		return fmt.Errorf("no active context. Run 'lissto login' first: %w", err)
+		return err
 	}
 
-	// Create API client with k8s discovery and validation
-	apiClient, err := client.NewClientFromConfig(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to initialize API client: %w", err)
+	if statusServe != "" {
+		return serveStatus(ctx, statusServe, statusInterval, results[0].Value)
+	}
+	if statusSummary {
+		return printStatusSummary(cmd, results[0].Value)
+	}
+	if statusWatch {
+		return watchStatus(ctx, statusInterval, statusAnalyze, results[0].Value, cmdutil.GetOutputFormat(cmd))
 	}
 
-	// List all stacks (pass empty string to get all)
-	stacks, err := apiClient.ListStacks("")
-	if err != nil {
-		return fmt.Errorf("failed to list stacks: %w", err)
+	contexts := make([]*statusContext, 0, len(results))
+	totalStacks, matchedStacks := 0, 0
+	for _, result := range results {
+		contexts = append(contexts, result.Value)
+		totalStacks += len(result.Value.stacks)
+		for _, stacks := range result.Value.envGroups {
+			matchedStacks += len(stacks)
+		}
 	}
 
-	if len(stacks) == 0 {
+	if totalStacks == 0 {
 		fmt.Println("No stacks found.")
 		fmt.Println("Use 'lissto create' to create a new stack.")
 		return nil
 	}
 
-	// Group stacks by environment
-	envGroups := groupStacksByEnv(stacks, statusEnvFilter)
-
-	if len(envGroups) == 0 {
+	if matchedStacks == 0 {
+		if !statusSelector.Empty() {
+			return fmt.Errorf("no stacks match selector '%s'", statusSelector)
+		}
 		if statusEnvFilter != "" {
 			return fmt.Errorf("no stacks found in environment '%s'", statusEnvFilter)
 		}
 		return fmt.Errorf("no stacks found")
 	}
 
+	allContexts := cmdutil.AllContexts(cmd)
+
 	// Get output format
 	format := cmdutil.GetOutputFormat(cmd)
 
 	// Handle different output formats
-	switch format {
-	case outputFormatJSON:
-		return output.PrintJSON(os.Stdout, stacks)
-	case outputFormatYAML:
-		return output.PrintYAML(os.Stdout, stacks)
-	case outputFormatTable:
-		return printTableStatus(envGroups)
+	switch {
+	case output.IsTemplateFormat(format):
+		return output.PrintTemplate(os.Stdout, format, statusData(ctx, contexts, allContexts))
+	case format == outputFormatJSON:
+		return output.PrintJSON(os.Stdout, statusData(ctx, contexts, allContexts))
+	case format == outputFormatYAML:
+		return output.PrintYAML(os.Stdout, statusData(ctx, contexts, allContexts))
+	case format == outputFormatTable:
+		return printTableStatus(ctx, contexts, allContexts)
 	default:
-		return printPrettyStatus(envGroups, apiClient)
+		for i, sc := range contexts {
+			if allContexts {
+				if i > 0 {
+					fmt.Println()
+				}
+				output.NewPrettyPrinter(os.Stdout).PrintHeader(fmt.Sprintf("Context: %s", sc.name))
+			}
+			printPrettyStatus(ctx, sc)
+		}
+
+		// Show helpful hints
+		fmt.Println()
+		_, _ = fmt.Fprintln(os.Stdout, messages.Get(messages.StatusTip, nil))
+		return nil
+	}
+}
+
+// loadStatusContexts lists the stacks of every selected context, or with
+// --cached loads those of the last successful run without pod details
+func loadStatusContexts(cmd *cobra.Command) ([]cmdutil.ContextResult[*statusContext], error) {
+	if cmdutil.Cached(cmd) {
+		cached, err := cmdutil.LoadOffline[[]envv1alpha1.Stack](cmd, "status")
+		if err != nil {
+			return nil, err
+		}
+		results := make([]cmdutil.ContextResult[*statusContext], 0, len(cached))
+		for _, result := range cached {
+			results = append(results, cmdutil.ContextResult[*statusContext]{
+				Context: result.Context,
+				Value: &statusContext{
+					name:      result.Context,
+					k8sErr:    errStatusCached,
+					stacks:    result.Value,
+					envGroups: groupStacksByEnv(result.Value, statusEnvFilter, statusSelector),
+				},
+			})
+		}
+		return results, nil
 	}
+
+	return cmdutil.ForEachContext(cmd, func(ctx context.Context, target cmdutil.ContextTarget) (*statusContext, error) {
+		// List all stacks (pass empty string to get all)
+		stacks, err := target.Client.ListStacks(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stacks: %w", err)
+		}
+		cmdutil.SaveOffline(target.Context.Name, "status", stacks)
+
+		// Pod details are best-effort
+		k8sClient, k8sErr := target.K8sClient()
+		return &statusContext{
+			name:      target.Context.Name,
+			apiClient: target.Client,
+			k8sClient: k8sClient,
+			k8sErr:    k8sErr,
+			stacks:    stacks,
+			envGroups: groupStacksByEnv(stacks, statusEnvFilter, statusSelector),
+		}, nil
+	})
 }
 
-// groupStacksByEnv groups stacks by environment name
-func groupStacksByEnv(stacks []envv1alpha1.Stack, envFilter string) map[string][]envv1alpha1.Stack {
+// statusData returns what -o json/yaml prints: the normalized report or,
+// with --raw, the stack resources; grouped by context with --all-contexts
+func statusData(ctx context.Context, contexts []*statusContext, allContexts bool) interface{} {
+	if !allContexts {
+		if statusRaw {
+			return contexts[0].stacks
+		}
+		return buildStatusReport(ctx, contexts[0])
+	}
+
+	if statusRaw {
+		raw := make([]contextRawStacks, 0, len(contexts))
+		for _, sc := range contexts {
+			raw = append(raw, contextRawStacks{Context: sc.name, Stacks: sc.stacks})
+		}
+		return raw
+	}
+
+	reports := make([]contextReport, 0, len(contexts))
+	for _, sc := range contexts {
+		reports = append(reports, contextReport{Context: sc.name, Report: buildStatusReport(ctx, sc)})
+	}
+	return reports
+}
+
+// buildStatusReport builds the normalized status model for the filtered stacks
+func buildStatusReport(ctx context.Context, sc *statusContext) *status.Report {
+	var stacks []envv1alpha1.Stack
+	for _, envStacks := range sc.envGroups {
+		stacks = append(stacks, envStacks...)
+	}
+
+	return status.BuildReport(ctx, sc.k8sClient, stacks, blueprintInfraLookup(sc.apiClient))
+}
+
+// blueprintInfraLookup returns an infra lookup backed by the blueprint API
+func blueprintInfraLookup(apiClient *client.Client) status.InfraLookup {
+	return func(ctx context.Context, blueprintRef string) []string {
+		if metadata := fetchBlueprintMetadata(ctx, apiClient, blueprintRef); metadata != nil {
+			return metadata.Infra
+		}
+		return nil
+	}
+}
+
+// groupStacksByEnv groups the stacks matching selector by environment name
+func groupStacksByEnv(stacks []envv1alpha1.Stack, envFilter string, selector labels.Selector) map[string][]envv1alpha1.Stack {
 	groups := make(map[string][]envv1alpha1.Stack)
 
 	for _, stack := range stacks {
@@ -130,6 +371,9 @@ func groupStacksByEnv(stacks []envv1alpha1.Stack, envFilter string) map[string][
 		if envFilter != "" && env != envFilter {
 			continue
 		}
+		if !selector.Matches(labels.Set(stack.Labels)) {
+			continue
+		}
 
 		groups[env] = append(groups[env], stack)
 	}
@@ -137,69 +381,79 @@ func groupStacksByEnv(stacks []envv1alpha1.Stack, envFilter string) map[string][
 	return groups
 }
 
-// printTableStatus prints compact table format
-func printTableStatus(envGroups map[string][]envv1alpha1.Stack) error {
+// printTableStatus prints compact table format, with a CONTEXT column
+// for --all-contexts
+func printTableStatus(ctx context.Context, contexts []*statusContext, allContexts bool) error {
 	headers := []string{"ENV", "STACK", "STATUS", "SERVICES", "AGE"}
+	if allContexts {
+		headers = append([]string{"CONTEXT"}, headers...)
+	}
 	var rows [][]string
 
-	// Try to create k8s client for pod status checking
-	k8sClient, _ := k8s.NewClient()
 	hasErrors := false
 	hasUnknown := false
 
-	// Sort environments for consistent output
-	envs := make([]string, 0, len(envGroups))
-	for env := range envGroups {
-		envs = append(envs, env)
-	}
-	sort.Strings(envs)
+	for _, sc := range contexts {
+		envGroups := sc.envGroups
 
-	for _, env := range envs {
-		stacks := envGroups[env]
+		// Sort environments for consistent output
+		envs := make([]string, 0, len(envGroups))
+		for env := range envGroups {
+			envs = append(envs, env)
+		}
+		sort.Strings(envs)
 
-		// Sort stacks by creation time (newest first)
-		sort.Slice(stacks, func(i, j int) bool {
-			return stacks[i].CreationTimestamp.After(stacks[j].CreationTimestamp.Time)
-		})
+		for _, env := range envs {
+			stacks := envGroups[env]
 
-		for _, stack := range stacks {
-			// Parse stack status
-			stackStatus := status.ParseStackStatus(stack.Status.Conditions)
+			// Sort stacks by creation time (newest first)
+			sort.Slice(stacks, func(i, j int) bool {
+				return stacks[i].CreationTimestamp.After(stacks[j].CreationTimestamp.Time)
+			})
 
-			// Check pod status if k8s client is available
-			if k8sClient != nil {
-				podStatus := checkStackPodsStatus(k8sClient, &stack)
-				switch podStatus {
-				case status.StateUnknown:
-					stackStatus.State = status.StateUnknown
-					hasUnknown = true
-				case podStatusError:
-					stackStatus.State = podStatusError
-					hasErrors = true
-				case podStatusPending:
-					stackStatus.State = status.StateDeploying
+			for _, stack := range stacks {
+				// Parse stack status
+				stackStatus := status.ParseStackStatus(stack.Status.Conditions)
+
+				// Check pod status if k8s client is available
+				if sc.k8sClient != nil {
+					podStatus := status.ListStackPods(ctx, sc.k8sClient, &stack).State()
+					switch podStatus {
+					case status.StateUnknown:
+						stackStatus.State = status.StateUnknown
+						hasUnknown = true
+					case podStatusError:
+						stackStatus.State = podStatusError
+						hasErrors = true
+					case podStatusPending:
+						stackStatus.State = status.StateDeploying
+					}
 				}
-			}
 
-			// Get stack display name (blueprint title if available, otherwise stack name)
-			stackDisplay := types.GetStackDisplayName(&stack)
+				// Get stack display name (blueprint title if available, otherwise stack name)
+				stackDisplay := types.GetStackDisplayName(&stack)
 
-			// Parse service statuses
-			services := status.ParseServiceStatuses(&stack)
-			ready, total := status.CountReadyServices(services)
-			servicesStr := fmt.Sprintf("%d/%d", ready, total)
+				// Parse service statuses
+				services := status.ParseServiceStatuses(&stack)
+				ready, total := status.CountReadyServices(services)
+				servicesStr := fmt.Sprintf("%d/%d", ready, total)
 
-			// Calculate age
-			age := time.Since(stack.CreationTimestamp.Time)
-			ageStr := k8s.FormatAge(age)
+				// Calculate age
+				age := time.Since(stack.CreationTimestamp.Time)
+				ageStr := k8s.FormatAge(age)
 
-			rows = append(rows, []string{
-				env,
-				stackDisplay,
-				stackStatus.State,
-				servicesStr,
-				ageStr,
-			})
+				row := []string{
+					env,
+					stackDisplay,
+					stackStatus.State,
+					servicesStr,
+					ageStr,
+				}
+				if allContexts {
+					row = append([]string{sc.name}, row...)
+				}
+				rows = append(rows, row)
+			}
 		}
 	}
 
@@ -219,16 +473,15 @@ func printTableStatus(envGroups map[string][]envv1alpha1.Stack) error {
 }
 
 // printPrettyStatus prints detailed format with emojis and pod status
-func printPrettyStatus(envGroups map[string][]envv1alpha1.Stack, apiClient *client.Client) error {
+func printPrettyStatus(ctx context.Context, sc *statusContext) {
 	printer := output.NewPrettyPrinter(os.Stdout)
-
-	// Try to create k8s client (may fail if no kubeconfig)
-	k8sClient, err := k8s.NewClient()
-	k8sAvailable := err == nil
+	envGroups := sc.envGroups
+	k8sClient := sc.k8sClient
+	k8sAvailable := k8sClient != nil
 
 	if !k8sAvailable {
 		fmt.Fprintf(os.Stderr, "⚠️  Kubernetes access unavailable - pod details not shown\n")
-		fmt.Fprintf(os.Stderr, "   Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "   Error: %v\n\n", sc.k8sErr)
 	}
 
 	// Sort environments for consistent output
@@ -238,6 +491,22 @@ func printPrettyStatus(envGroups map[string][]envv1alpha1.Stack, apiClient *clie
 	}
 	sort.Strings(envs)
 
+	// Sort stacks by creation time (newest first)
+	var ordered []envv1alpha1.Stack
+	for _, env := range envs {
+		stacks := envGroups[env]
+		sort.Slice(stacks, func(i, j int) bool {
+			return stacks[i].CreationTimestamp.After(stacks[j].CreationTimestamp.Time)
+		})
+		ordered = append(ordered, stacks...)
+	}
+
+	// Fetch pods, blueprints and traffic readiness for all stacks up front
+	data := status.FetchStacks(ctx, k8sClient, ordered, blueprintInfraLookup(sc.apiClient), status.DefaultFetchConcurrency)
+
+	printStatusBanner(envs, envGroups, ordered, data)
+
+	idx := 0
 	for envIdx, env := range envs {
 		if envIdx > 0 {
 			printer.PrintNewline()
@@ -245,42 +514,23 @@ func printPrettyStatus(envGroups map[string][]envv1alpha1.Stack, apiClient *clie
 
 		printer.PrintHeader(fmt.Sprintf("Environment: %s", env))
 
-		stacks := envGroups[env]
+		for stackIdx := range envGroups[env] {
+			stack := &ordered[idx]
+			stackData := &data[idx]
+			idx++
 
-		// Sort stacks by creation time (newest first)
-		sort.Slice(stacks, func(i, j int) bool {
-			return stacks[i].CreationTimestamp.After(stacks[j].CreationTimestamp.Time)
-		})
-
-		for stackIdx, stack := range stacks {
 			if stackIdx > 0 {
 				printer.PrintDivider()
 			}
 
 			// Stack header with blueprint title if available
 			printer.PrintNewline()
-			stackDisplay := types.GetStackDisplayName(&stack)
+			stackDisplay := types.GetStackDisplayName(stack)
 			_, _ = fmt.Fprintf(os.Stdout, "Stack: %s\n", stackDisplay)
 
 			// Stack status - check actual pod status if k8s available
 			stackStatus := status.ParseStackStatus(stack.Status.Conditions)
-			if k8sAvailable {
-				podStatus := checkStackPodsStatus(k8sClient, &stack)
-				switch podStatus {
-				case status.StateUnknown:
-					stackStatus.State = status.StateUnknown
-					stackStatus.Symbol = status.SymbolUnknown
-					stackStatus.Reason = "Can't find pods - check cluster context"
-				case podStatusError:
-					stackStatus.State = podStatusError
-					stackStatus.Symbol = status.SymbolFailed
-					stackStatus.Reason = "Pod issues detected"
-				case podStatusPending:
-					stackStatus.State = status.StateDeploying
-					stackStatus.Symbol = status.SymbolDeploying
-					stackStatus.Reason = "Pods starting"
-				}
-			}
+			status.ApplyPodState(&stackStatus, stackData.Pods.State())
 
 			_, _ = fmt.Fprintf(os.Stdout, "Status: %s %s", stackStatus.Symbol, stackStatus.State)
 			if stackStatus.Reason != "" {
@@ -293,43 +543,34 @@ func printPrettyStatus(envGroups map[string][]envv1alpha1.Stack, apiClient *clie
 			_, _ = fmt.Fprintf(os.Stdout, "Created: %s (%s)\n", formatted, timeAgo)
 
 			// Parse services
-			services := status.ParseServiceStatuses(&stack)
+			services := status.ParseServiceStatuses(stack)
 			if len(services) == 0 {
 				printer.PrintNewline()
 				printer.PrintIndentedLine(1, "No services configured")
 				continue
 			}
 
-			// Fetch blueprint for categorization
-			blueprintContent := fetchBlueprintMetadata(apiClient, stack.Spec.BlueprintReference)
-
 			// 1. Display URLs table
-			displayURLsTable(&stack, services, k8sClient, k8sAvailable)
+			displayURLsTable(stack, services, stackData)
 
 			// 2. Categorize services
-			regularServices, jobs, infra := categorizeServices(services, k8sClient, &stack, k8sAvailable, blueprintContent)
+			regularServices, jobs, infra := categorizeServices(services, stackData)
 
 			// 3. Display categorized pods tables with category-specific headers
 			_, _ = fmt.Fprintf(os.Stdout, "\n")
-			displayCategorizedPodsTable(regularServices, jobs, infra, k8sClient, &stack, k8sAvailable)
+			displayCategorizedPodsTable(regularServices, jobs, infra, stackData, k8sAvailable)
 		}
 	}
-
-	// Show helpful hints
-	printer.PrintNewline()
-	_, _ = fmt.Fprintln(os.Stdout, "💡 Tip: Use 'lissto logs' to view logs, 'lissto update' to update images")
-
-	return nil
 }
 
 // fetchBlueprintMetadata fetches blueprint service metadata for categorization
-func fetchBlueprintMetadata(apiClient *client.Client, blueprintRef string) *client.ServiceMetadata {
+func fetchBlueprintMetadata(ctx context.Context, apiClient *client.Client, blueprintRef string) *client.ServiceMetadata {
 	if apiClient == nil || blueprintRef == "" {
 		return nil
 	}
 
 	// API now accepts scoped IDs directly
-	blueprint, err := apiClient.GetBlueprint(blueprintRef)
+	blueprint, err := apiClient.GetBlueprint(ctx, blueprintRef)
 	if err != nil {
 		return nil
 	}
@@ -338,7 +579,7 @@ func fetchBlueprintMetadata(apiClient *client.Client, blueprintRef string) *clie
 }
 
 // displayURLsTable displays services with exposed URLs
-func displayURLsTable(stack *envv1alpha1.Stack, services []status.ServiceStatus, k8sClient *k8s.Client, k8sAvailable bool) {
+func displayURLsTable(stack *envv1alpha1.Stack, services []status.ServiceStatus, data *status.StackData) {
 	// Filter services with URLs
 	type urlRow struct {
 		Service string
@@ -360,19 +601,9 @@ func displayURLsTable(stack *envv1alpha1.Stack, services []status.ServiceStatus,
 		// Default ready status
 		readyStatus := "⚪ (unknown)"
 
-		if k8sAvailable {
-			// Fetch pods for this service
-			pods, err := fetchServicePods(k8sClient, stack, svc.Name)
-			if err == nil {
-				// Check service readiness (Service, Endpoints, Ingress, Pods)
-				ctx := context.Background()
-				readiness := k8sClient.CheckServiceReadiness(ctx, stack.Namespace, svc.Name, pods, serviceAge)
-				readyStatus = k8s.FormatReadinessStatus(readiness, serviceAge)
-			} else if serviceAge < time.Minute {
-				readyStatus = "⚪ (starting up..)"
-			} else {
-				readyStatus = "⚪ (unknown)"
-			}
+		if readiness, ok := data.Traffic[svc.Name]; ok {
+			// Service readiness (Service, Endpoints, Ingress, Pods)
+			readyStatus = k8s.FormatReadinessStatus(readiness, serviceAge)
 		} else if serviceAge < time.Minute {
 			readyStatus = "⚪ (starting up..)"
 		}
@@ -399,11 +630,11 @@ func displayURLsTable(stack *envv1alpha1.Stack, services []status.ServiceStatus,
 	for _, u := range urlServices {
 		rows = append(rows, []string{u.Service, u.URL, u.Ready, u.Age})
 	}
-	output.PrintTable(os.Stdout, headers, rows)
+	output.PrintTableWithPriorities(os.Stdout, headers, rows, output.ColumnPriorities{"URL": 1})
 }
 
 // displayCategorizedPodsTable displays all pods in a single table with category headers
-func displayCategorizedPodsTable(services, jobs, infra []status.ServiceStatus, k8sClient *k8s.Client, stack *envv1alpha1.Stack, k8sAvailable bool) {
+func displayCategorizedPodsTable(services, jobs, infra []status.ServiceStatus, data *status.StackData, k8sAvailable bool) {
 	if !k8sAvailable {
 		return
 	}
@@ -411,7 +642,7 @@ func displayCategorizedPodsTable(services, jobs, infra []status.ServiceStatus, k
 	// Display regular services
 	if len(services) > 0 {
 		headers := []string{"SERVICE", "POD NAME", "STATUS", "RESTARTS", "AGE"}
-		rows := buildPodRows(services, k8sClient, stack, false)
+		rows := buildPodRows(services, data, false)
 		if len(rows) > 0 {
 			output.PrintTable(os.Stdout, headers, rows)
 		}
@@ -423,7 +654,7 @@ func displayCategorizedPodsTable(services, jobs, infra []status.ServiceStatus, k
 			_, _ = fmt.Fprintf(os.Stdout, "\n")
 		}
 		headers := []string{"INFRA", "POD NAME", "STATUS", "RESTARTS", "AGE"}
-		rows := buildPodRows(infra, k8sClient, stack, false)
+		rows := buildPodRows(infra, data, false)
 		if len(rows) > 0 {
 			output.PrintTable(os.Stdout, headers, rows)
 		}
@@ -435,7 +666,7 @@ func displayCategorizedPodsTable(services, jobs, infra []status.ServiceStatus, k
 			_, _ = fmt.Fprintf(os.Stdout, "\n")
 		}
 		headers := []string{"JOBS", "POD NAME", "STATUS", "RESTARTS", "AGE"}
-		rows := buildPodRows(jobs, k8sClient, stack, true)
+		rows := buildPodRows(jobs, data, true)
 		if len(rows) > 0 {
 			output.PrintTable(os.Stdout, headers, rows)
 		}
@@ -443,12 +674,14 @@ func displayCategorizedPodsTable(services, jobs, infra []status.ServiceStatus, k
 }
 
 // buildPodRows builds table rows for a list of services
-func buildPodRows(services []status.ServiceStatus, k8sClient *k8s.Client, stack *envv1alpha1.Stack, isJobGroup bool) [][]string {
+func buildPodRows(services []status.ServiceStatus, data *status.StackData, isJobGroup bool) [][]string {
 	var rows [][]string
+	// completedRows marks the rows of completed jobs, by row index
+	completedRows := make(map[int]bool)
 
 	for _, svc := range services {
-		pods, err := fetchServicePods(k8sClient, stack, svc.Name)
-		if err != nil || len(pods) == 0 {
+		pods := data.Pods.ForService(svc.Name)
+		if len(pods) == 0 {
 			// Show service with no pods
 			rows = append(rows, []string{
 				svc.Name,
@@ -475,6 +708,7 @@ func buildPodRows(services []status.ServiceStatus, k8sClient *k8s.Client, stack
 
 			if isCompleted {
 				// Gray out completed jobs
+				completedRows[len(rows)] = true
 				serviceName = output.Gray(serviceName)
 				podName = output.Gray(podName)
 				phase = output.Gray(phase)
@@ -491,20 +725,18 @@ func buildPodRows(services []status.ServiceStatus, k8sClient *k8s.Client, stack
 		}
 	}
 
-	// For jobs, sort so failed/active jobs appear first
-	if isJobGroup && len(rows) > 0 {
-		sort.SliceStable(rows, func(i, j int) bool {
-			// Check if rows contain gray ANSI codes (completed jobs)
-			iIsCompleted := strings.Contains(rows[i][2], "\033[90m") // Check STATUS column
-			jIsCompleted := strings.Contains(rows[j][2], "\033[90m")
-
-			// Non-completed (active/failed) jobs should come first
-			if iIsCompleted != jIsCompleted {
-				return !iIsCompleted // i comes first if it's NOT completed
+	// For jobs, failed/active jobs appear first; otherwise keep the order
+	if isJobGroup && len(completedRows) > 0 {
+		active := make([][]string, 0, len(rows))
+		var done [][]string
+		for i, row := range rows {
+			if completedRows[i] {
+				done = append(done, row)
+			} else {
+				active = append(active, row)
 			}
-			// Otherwise maintain original order
-			return false
-		})
+		}
+		rows = append(active, done...)
 	}
 
 	return rows
@@ -523,157 +755,22 @@ func formatRestartCountWithHelpers(restarts int32, isCompleted bool) string {
 	return countStr
 }
 
-// checkStackPodsStatus checks the overall pod status for a stack
-// Returns: status.StateReady, podStatusPending, podStatusError, or status.StateUnknown
-func checkStackPodsStatus(k8sClient *k8s.Client, stack *envv1alpha1.Stack) string {
-	ctx := context.Background()
-
-	// Query all pods for this stack
-	labels := map[string]string{
-		"lissto.dev/stack": stack.Name,
-	}
-
-	pods, err := k8sClient.ListPods(ctx, stack.Namespace, labels)
-	if err != nil {
-		// Error accessing pods (e.g., wrong cluster context, no permissions)
-		return status.StateUnknown
-	}
-
-	if len(pods) == 0 {
-		// No pods found - likely wrong cluster or stack failed to deploy
-		return status.StateUnknown
-	}
-
-	hasError := false
-	hasPending := false
-	allRunning := true
-
-	// Check all pods
-	for _, pod := range pods {
-		phase := pod.Status.Phase
-
-		// Check for explicit failure
-		if phase == corev1.PodFailed {
-			hasError = true
-			continue
-		}
-
-		// Check for pending state
-		if phase == corev1.PodPending {
-			hasPending = true
-			allRunning = false
-			continue
-		}
-
-		// Check if running
-		if phase != corev1.PodRunning {
-			allRunning = false
-		}
-
-		// Check container statuses for any issues
-		for _, cs := range pod.Status.ContainerStatuses {
-			if cs.State.Waiting != nil {
-				reason := cs.State.Waiting.Reason
-				// Check for error states
-				if reason == "CrashLoopBackOff" ||
-					reason == "ImagePullBackOff" ||
-					reason == "ErrImagePull" ||
-					reason == "CreateContainerError" ||
-					reason == "InvalidImageName" {
-					hasError = true
-				} else {
-					// Other waiting reasons mean still starting
-					hasPending = true
-					allRunning = false
-				}
-			}
-			// Check if container has terminated
-			if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
-				hasError = true
-			}
-			// Check if container is not ready
-			if !cs.Ready {
-				allRunning = false
-			}
-		}
-	}
-
-	if hasError {
-		return podStatusError
-	}
-
-	if hasPending || !allRunning {
-		return podStatusPending
-	}
-
-	return status.StateReady
-}
-
-// fetchServicePods queries k8s for pods belonging to a service
-func fetchServicePods(k8sClient *k8s.Client, stack *envv1alpha1.Stack, serviceName string) ([]corev1.Pod, error) {
-	ctx := context.Background()
-
-	// Query pods with stack label only
-	labels := map[string]string{
-		"lissto.dev/stack": stack.Name,
-	}
-
-	pods, err := k8sClient.ListPods(ctx, stack.Namespace, labels)
-	if err != nil {
-		return nil, err
-	}
-
-	// Filter pods by service name using multiple matching strategies
-	var servicePods []corev1.Pod
-	for _, pod := range pods {
-		matched := false
-
-		// Strategy 1: Check lissto.dev/service label
-		if pod.Labels != nil && pod.Labels["lissto.dev/service"] == serviceName {
-			matched = true
-		}
-
-		// Strategy 2: Check io.kompose.service label (from kompose conversion)
-		if !matched && pod.Labels != nil && pod.Labels["io.kompose.service"] == serviceName {
-			matched = true
-		}
-
-		// Strategy 3: Pod name prefix matching (e.g., "bo-67db85fc78-lhs9t" matches "bo")
-		if !matched && strings.HasPrefix(pod.Name, serviceName+"-") {
-			matched = true
-		}
-
-		if matched {
-			servicePods = append(servicePods, pod)
-		}
-	}
-
-	return servicePods, nil
-}
-
 // categorizeServices categorizes services into regular services, jobs, and infra
-func categorizeServices(services []status.ServiceStatus, k8sClient *k8s.Client, stack *envv1alpha1.Stack, k8sAvailable bool, blueprintContent *client.ServiceMetadata) (regularServices, jobs, infra []status.ServiceStatus) {
+func categorizeServices(services []status.ServiceStatus, data *status.StackData) (regularServices, jobs, infra []status.ServiceStatus) {
 	// Create lookup map for infrastructure services from blueprint
 	infraMap := make(map[string]bool)
-	if blueprintContent != nil {
-		for _, name := range blueprintContent.Infra {
-			infraMap[name] = true
-		}
+	for _, name := range data.Infra {
+		infraMap[name] = true
 	}
 
 	for _, svc := range services {
 		// Determine service category based on pod characteristics
-		if k8sAvailable {
-			pods, err := fetchServicePods(k8sClient, stack, svc.Name)
-			if err == nil && len(pods) > 0 {
-				pod := pods[0] // Check first pod to determine type
-
-				// Check restart policy to identify jobs
-				if pod.Spec.RestartPolicy == corev1.RestartPolicyNever ||
-					pod.Spec.RestartPolicy == corev1.RestartPolicyOnFailure {
-					jobs = append(jobs, svc)
-					continue
-				}
+		pods := data.Pods.ForService(svc.Name)
+		if len(pods) > 0 {
+			// Check first pod's restart policy to identify jobs
+			if status.IsJobPod(&pods[0]) {
+				jobs = append(jobs, svc)
+				continue
 			}
 		}
 
@@ -698,3 +795,41 @@ func categorizeServices(services []status.ServiceStatus, k8sClient *k8s.Client,
 
 	return regularServices, jobs, infra
 }
+
+// printStatusBanner prints the health summary of every env above the detailed
+// view. stacks and data are ordered like envs and envGroups.
+func printStatusBanner(envs []string, envGroups map[string][]envv1alpha1.Stack, stacks []envv1alpha1.Stack, data []status.StackData) {
+	report := &status.Report{Envs: make([]status.EnvReport, 0, len(envs))}
+	idx := 0
+	for _, env := range envs {
+		envReport := status.EnvReport{Name: env}
+		for range envGroups[env] {
+			envReport.Stacks = append(envReport.Stacks, status.NewStackReport(&stacks[idx], &data[idx]))
+			idx++
+		}
+		report.Envs = append(report.Envs, envReport)
+	}
+
+	for _, env := range status.Summarize(report).Envs {
+		fmt.Printf("%s %s: %s\n", env.Symbol(), env.Env, env)
+	}
+	fmt.Println()
+}
+
+// printStatusSummary prints one health line per env for --summary and fails
+// unless every env is healthy
+func printStatusSummary(cmd *cobra.Command, sc *statusContext) error {
+	summary := status.Summarize(buildStatusReport(cmd.Context(), sc))
+	err := cmdutil.PrintOutput(cmd, summary, func() {
+		for _, env := range summary.Envs {
+			fmt.Printf("%s %s: %s\n", env.Symbol(), env.Env, env)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if !summary.Healthy() {
+		return &exitError{code: exitCodeNotReady, err: fmt.Errorf("not healthy: %s", summary)}
+	}
+	return nil
+}
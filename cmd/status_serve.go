@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+// statusServer serves a periodically refreshed status report as Prometheus
+// metrics (/metrics) and a JSON summary (/healthz)
+type statusServer struct {
+	sc *statusContext
+
+	mu   sync.RWMutex
+	snap status.Snapshot
+}
+
+// serveStatus runs the status server on addr until ctx is cancelled
+func serveStatus(ctx context.Context, addr string, interval time.Duration, sc *statusContext) error {
+	s := &statusServer{sc: sc}
+	s.refresh(ctx)
+	go s.run(ctx, interval)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/healthz", s.handleHealth)
+	mux.HandleFunc("/{$}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, "lissto status: /metrics (Prometheus), /healthz (JSON)")
+	})
+
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	context.AfterFunc(ctx, func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	})
+
+	fmt.Fprintf(os.Stderr, "📈 Serving status of context '%s' on %s (/metrics, /healthz), refreshing every %s\n", sc.name, addr, interval)
+	fmt.Fprintln(os.Stderr, "Press Ctrl+C to stop.")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve status: %w", err)
+	}
+	return nil
+}
+
+// run refreshes the report every interval
+func (s *statusServer) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refresh(ctx)
+		}
+	}
+}
+
+// refresh fetches a new report. On failure the previous report is kept and
+// the error is reported through lissto_up and /healthz.
+func (s *statusServer) refresh(ctx context.Context) {
+	stacks, err := s.sc.apiClient.ListStacks(ctx, "")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.snap.Err = fmt.Errorf("failed to list stacks: %w", err)
+		return
+	}
+
+	sc := *s.sc
+	sc.stacks = stacks
+	sc.envGroups = groupStacksByEnv(stacks, statusEnvFilter, statusSelector)
+	s.snap = status.Snapshot{Report: buildStatusReport(ctx, &sc), Updated: time.Now()}
+}
+
+func (s *statusServer) snapshot() status.Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snap
+}
+
+func (s *statusServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = status.WritePrometheus(w, s.snapshot())
+}
+
+// handleHealth responds 200 when every stack is ready and 503 otherwise
+func (s *statusServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	health := status.NewHealth(s.snapshot())
+
+	w.Header().Set("Content-Type", "application/json")
+	if health.Status != status.HealthOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(health)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/status"
+	"golang.org/x/term"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// watchStatus reprints the status every interval until interrupted. With
+// analyze, the readiness of every service is recorded and flapping services
+// are reported below the status.
+func watchStatus(ctx context.Context, interval time.Duration, analyze bool, sc *statusContext, format string) error {
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	history := status.NewHistory(statusWindow)
+	interactive := term.IsTerminal(int(os.Stdout.Fd()))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		stacks, err := sc.apiClient.ListStacks(ctx, "")
+		if ctx.Err() != nil {
+			return nil
+		}
+		if interactive {
+			fmt.Print(clearScreen)
+		}
+		fmt.Printf("Every %s: lissto status (%s)\n\n", interval, time.Now().Format(time.TimeOnly))
+		if err != nil {
+			// Keep showing the last stacks; the API may be briefly unreachable
+			fmt.Fprintf(os.Stderr, "⚠️  failed to list stacks: %v\n\n", err)
+		} else {
+			sc.stacks = stacks
+			sc.envGroups = groupStacksByEnv(stacks, statusEnvFilter, statusSelector)
+		}
+
+		if format == outputFormatTable {
+			if err := printTableStatus(ctx, []*statusContext{sc}, false); err != nil {
+				return err
+			}
+		} else {
+			printPrettyStatus(ctx, sc)
+		}
+
+		if analyze {
+			history.Record(time.Now(), buildStatusReport(ctx, sc))
+			fmt.Println()
+			printFlakiness(history.Analyze(status.DefaultFlapTransitions, status.DefaultFlapRestarts))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// printFlakiness prints the services that flapped during the --window
+func printFlakiness(results []status.Flakiness) {
+	samples := 0
+	var rows [][]string
+	for _, f := range results {
+		if f.Samples > samples {
+			samples = f.Samples
+		}
+		if !f.Flapping {
+			continue
+		}
+		rows = append(rows, []string{
+			f.Env,
+			f.Stack,
+			f.Service,
+			strconv.Itoa(int(f.ReadyRatio*100)) + "%",
+			strconv.Itoa(f.Transitions),
+			strconv.Itoa(int(f.Restarts)),
+			f.Reason,
+		})
+	}
+
+	if len(rows) == 0 {
+		fmt.Printf("✅ No flapping services (%d samples)\n", samples)
+		return
+	}
+	fmt.Printf("⚠️  Flapping services (%d samples, last %s):\n", samples, statusWindow)
+	output.PrintTable(os.Stdout, []string{"ENV", "STACK", "SERVICE", "READY", "CHANGES", "RESTARTS", "WHY"}, rows)
+}
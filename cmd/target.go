@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/lissto-dev/cli/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// resolveStack finds a stack by name (in the --env environment if set), or
+// prompts for one when name is empty
+func resolveStack(ctx context.Context, apiClient *client.Client, name string) (*types.Stack, error) {
+	stacks, err := apiClient.ListStacks(ctx, envName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", err)
+	}
+	if len(stacks) == 0 {
+		return nil, fmt.Errorf("no stacks found")
+	}
+
+	if name != "" {
+		for i := range stacks {
+			if stacks[i].Name == name {
+				return &stacks[i], nil
+			}
+		}
+		return nil, fmt.Errorf("stack '%s' %w", name, client.ErrNotFound)
+	}
+
+	if len(stacks) == 1 {
+		return &stacks[0], nil
+	}
+
+	sort.Slice(stacks, func(i, j int) bool {
+		if stacks[i].Spec.Env != stacks[j].Spec.Env {
+			return stacks[i].Spec.Env < stacks[j].Spec.Env
+		}
+		return stacks[i].CreationTimestamp.After(stacks[j].CreationTimestamp.Time)
+	})
+
+	titles := make([]string, len(stacks))
+	ages := make([]string, len(stacks))
+	envs := make([]string, len(stacks))
+	for i := range stacks {
+		titles[i] = types.GetStackDisplayName(&stacks[i])
+		ages[i] = k8s.FormatAge(time.Since(stacks[i].CreationTimestamp.Time))
+		envs[i] = "env: " + stacks[i].Spec.Env
+	}
+
+	idx, err := interactive.SelectOption("Choose a stack:", interactive.FormatAlignedColumns(titles, ages, envs))
+	if err != nil {
+		return nil, fmt.Errorf("stack selection cancelled: %w", err)
+	}
+	return &stacks[idx], nil
+}
+
+// resolveService validates a service name against the stack, or prompts for
+// one when name is empty
+func resolveService(stack *types.Stack, name string) (string, error) {
+	services := status.ParseServiceStatuses(stack)
+	names := make([]string, 0, len(services))
+	for _, svc := range services {
+		names = append(names, svc.Name)
+	}
+	sort.Strings(names)
+
+	if name != "" {
+		for _, n := range names {
+			if n == name {
+				return name, nil
+			}
+		}
+		// Services may not be reflected in conditions yet; let pod matching decide
+		return name, nil
+	}
+
+	switch len(names) {
+	case 0:
+		return "", fmt.Errorf("stack '%s' has no services", stack.Name)
+	case 1:
+		return names[0], nil
+	}
+
+	idx, err := interactive.SelectOption("Choose a service:", names)
+	if err != nil {
+		return "", fmt.Errorf("service selection cancelled: %w", err)
+	}
+	return names[idx], nil
+}
+
+// resolveServicePod picks a running pod for a stack service
+func resolveServicePod(ctx context.Context, k8sClient *k8s.Client, stack *types.Stack, service string) (*corev1.Pod, error) {
+	stackPods := status.ListStackPods(ctx, k8sClient, stack)
+	if stackPods.Err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", stackPods.Err)
+	}
+	pods := stackPods.ForService(service)
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no pods found for service '%s' in stack '%s'", service, stack.Name)
+	}
+
+	pod, err := k8s.PickPod(pods)
+	if err != nil {
+		return nil, fmt.Errorf("service '%s': %w", service, err)
+	}
+	return pod, nil
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/status"
+	"github.com/spf13/cobra"
+)
+
+var (
+	topStack  string
+	topPods   bool
+	topSortBy string
+)
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show CPU and memory usage of stacks",
+	Long: `Show current CPU and memory usage per service (or per pod), summed per stack.
+
+Usage is read from the Kubernetes metrics API, so metrics-server must be
+installed in the cluster. Values are a recent sample, not an average.
+
+Examples:
+  # Usage of every stack in the current environment
+  lissto top
+
+  # Usage of one stack, per pod
+  lissto top --stack my-stack --pods
+
+  # Find the service using the most memory in staging
+  lissto top --env staging --sort-by memory
+
+  # Machine-readable output
+  lissto top --stack my-stack -o json`,
+	Args:          cobra.NoArgs,
+	RunE:          runTop,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+	topCmd.Flags().StringVar(&topStack, "stack", "", "Only show this stack")
+	topCmd.Flags().BoolVar(&topPods, "pods", false, "Show usage per pod instead of per service")
+	topCmd.Flags().StringVar(&topSortBy, "sort-by", status.SortByCPU, "Sort by cpu or memory")
+}
+
+func runTop(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if topSortBy != status.SortByCPU && topSortBy != status.SortByMemory {
+		return fmt.Errorf("invalid --sort-by '%s' (use cpu or memory)", topSortBy)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lisstoCtx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return messages.Wrap(messages.NoActiveContext, nil, err)
+	}
+
+	env := envName
+	if env == "" {
+		env = cfg.CurrentEnv
+	}
+
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+
+	stacks, err := apiClient.ListStacks(ctx, env)
+	if err != nil {
+		return fmt.Errorf("failed to list stacks: %w", err)
+	}
+
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	usages := []status.StackUsage{}
+	for i := range stacks {
+		stack := &stacks[i]
+		if topStack != "" && stack.Name != topStack {
+			continue
+		}
+
+		labels := status.StackPodLabels(stack.Name)
+		pods, err := k8sClient.ListPods(ctx, stack.Namespace, labels)
+		if err != nil {
+			return fmt.Errorf("failed to list pods for stack %s: %w", stack.Name, err)
+		}
+		metrics, err := k8sClient.ListPodMetrics(ctx, stack.Namespace, labels)
+		if err != nil {
+			if errors.Is(err, k8s.ErrMetricsUnavailable) {
+				return err
+			}
+			return fmt.Errorf("failed to get usage for stack %s: %w", stack.Name, err)
+		}
+
+		usage := status.BuildStackUsage(stack, pods, metrics)
+		usage.Sort(topSortBy)
+		usages = append(usages, usage)
+	}
+
+	if topStack != "" && len(usages) == 0 {
+		return fmt.Errorf("stack '%s' %w in environment '%s'", topStack, client.ErrNotFound, env)
+	}
+
+	return cmdutil.PrintOutput(cmd, usages, func() {
+		if len(usages) == 0 {
+			fmt.Println("No stacks found.")
+			return
+		}
+		printTopTable(usages)
+	})
+}
+
+// printTopTable prints one row per service (or pod) and a total per stack
+func printTopTable(usages []status.StackUsage) {
+	var headers []string
+	var rows [][]string
+
+	if topPods {
+		headers = []string{"STACK", "POD", "SERVICE", "CPU", "MEMORY"}
+		for _, stack := range usages {
+			for _, pod := range stack.Pods {
+				rows = append(rows, []string{stack.Name, pod.Name, pod.Service, pod.CPU(), pod.Memory()})
+			}
+			rows = append(rows, []string{stack.Name, "(total)", "", stack.CPU(), stack.Memory()})
+		}
+	} else {
+		headers = []string{"STACK", "SERVICE", "PODS", "CPU", "MEMORY"}
+		for _, stack := range usages {
+			for _, svc := range stack.Services {
+				name := svc.Name
+				if name == "" {
+					name = "-"
+				}
+				rows = append(rows, []string{stack.Name, name, strconv.Itoa(svc.Pods), svc.CPU(), svc.Memory()})
+			}
+			rows = append(rows, []string{stack.Name, "(total)", strconv.Itoa(len(stack.Pods)), stack.CPU(), stack.Memory()})
+		}
+	}
+
+	output.PrintTable(os.Stdout, headers, rows)
+}
@@ -1,14 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
 	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/diff"
+	"github.com/lissto-dev/cli/pkg/hooks"
 	"github.com/lissto-dev/cli/pkg/interactive"
 	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/registry"
+	"github.com/lissto-dev/cli/pkg/spinner"
 	"github.com/lissto-dev/cli/pkg/types"
 	"github.com/spf13/cobra"
 )
@@ -19,7 +28,13 @@ var (
 	updateCommit         string
 	updateTag            string
 	updateYes            bool
+	updateDryRun         bool
 	updateNonInteractive bool
+	updateProvenance     bool
+	updateWait           bool
+	updateTimeout        time.Duration
+	updateNotify         string
+	updateSetImages      []string
 )
 
 var updateCmd = &cobra.Command{
@@ -45,7 +60,22 @@ Examples:
   lissto update --stack my-stack --branch develop
 
   # Update with auto-confirmation
-  lissto update --stack my-stack --branch main --yes`,
+  lissto update --stack my-stack --branch main --yes
+
+  # Update from main, but take the api image from a feature branch
+  lissto update --stack my-stack --branch main --set-image api=branch:feature-x
+
+  # Pin one service to a specific image
+  lissto update --stack my-stack --branch main --set-image worker=ghcr.io/org/worker:v2
+
+  # Preview the image and Kubernetes manifest changes without applying them
+  lissto update --stack my-stack --branch main --dry-run
+
+  # Wait for the rollout to finish (exit code 3 on timeout)
+  lissto update --stack my-stack --branch main --yes --wait
+
+  # Post to a Slack channel when the rollout finished or failed
+  lissto update --stack my-stack --branch main --yes --wait --notify https://hooks.slack.com/services/...`,
 	RunE:          runUpdate,
 	SilenceUsage:  true,
 	SilenceErrors: false,
@@ -57,10 +87,30 @@ func init() {
 	updateCmd.Flags().StringVar(&updateCommit, "commit", "", "Git commit for image resolution")
 	updateCmd.Flags().StringVar(&updateTag, "tag", "", "Git tag for image resolution")
 	updateCmd.Flags().BoolVarP(&updateYes, "yes", "y", false, "Skip confirmation prompt")
+	updateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "Show the image and rendered manifest changes without applying them")
 	updateCmd.Flags().BoolVar(&updateNonInteractive, "non-interactive", false, "Disable interactive prompts")
+	updateCmd.Flags().BoolVar(&updateWait, "wait", false, "Wait until all services are ready after the update")
+	updateCmd.Flags().DurationVar(&updateTimeout, "timeout", defaultWaitTimeout, "Maximum time to wait with --wait")
+	addNotifyFlag(updateCmd, &updateNotify)
+	updateCmd.Flags().BoolVar(&updateProvenance, "provenance", false, "Show image build time and git commit from registry labels")
+	updateCmd.Flags().StringArrayVar(&updateSetImages, "set-image", nil, "Override a service's image as service=ref; ref is branch:<name>, tag:<name>, commit:<sha> or an image reference (repeatable)")
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if updateNonInteractive {
+		spinner.SetAnimated(false)
+	}
+
+	imageRefs, err := cmdutil.ParseSetImages(updateSetImages, nil)
+	if err != nil {
+		return err
+	}
+	if err := validateNotifyFlag(updateNotify, updateWait); err != nil {
+		return err
+	}
+
 	// Load config
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -68,9 +118,9 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get current context
-	ctx, err := cfg.GetCurrentContext()
+	lisstoCtx, err := cfg.GetCurrentContext()
 	if err != nil {
-		return fmt.Errorf("no active context. Run 'lissto login' first: %w", err)
+		return messages.Wrap(messages.NoActiveContext, nil, err)
 	}
 
 	// Get environment from flag or config
@@ -80,17 +130,23 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if envToUse == "" {
-		return fmt.Errorf("no environment selected. Use --env flag or 'lissto env use <name>'")
+		return messages.Error(messages.NoEnvSelected, nil)
+	}
+
+	// Load project hooks from .lissto.yaml (if any)
+	projectCfg, err := hooks.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
 	}
 
 	// Create API client
-	apiClient, err := client.NewClientFromConfig(ctx)
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
 	if err != nil {
 		return fmt.Errorf("failed to initialize API client: %w", err)
 	}
 
 	// Step 1: List stacks in current environment
-	stacks, err := apiClient.ListStacks(envToUse)
+	stacks, err := apiClient.ListStacks(ctx, envToUse)
 	if err != nil {
 		return fmt.Errorf("failed to list stacks: %w", err)
 	}
@@ -112,7 +168,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 			}
 		}
 		if !found {
-			return fmt.Errorf("stack '%s' not found in environment '%s'", updateStack, envToUse)
+			return fmt.Errorf("stack '%s' %w in environment '%s'", updateStack, client.ErrNotFound, envToUse)
 		}
 	} else if len(stacks) == 1 {
 		// Only one stack, use it automatically
@@ -176,6 +232,30 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("\n📦 Updating: %s (env: %s)\n", stackDisplay, stackEnv)
 
+	// Run pre-update hooks (e.g. building images) before resolving images
+	hookVars := hooks.Vars{
+		Context:   lisstoCtx.Name,
+		Env:       stackEnv,
+		Stack:     stackName,
+		Blueprint: blueprintRef,
+	}
+	if !updateDryRun {
+		if err := projectCfg.Run(hooks.PhasePre, "update", hookVars, os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	// Resolve per-service image overrides once; they don't depend on the
+	// branch/tag/commit chosen below
+	var imageOverrides map[string]cmdutil.ImageOverride
+	if len(imageRefs) > 0 {
+		fmt.Println("Resolving image overrides...")
+		imageOverrides, err = cmdutil.ResolveImageOverrides(ctx, apiClient, blueprintRef, stackEnv, nil, imageRefs)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Step 3: Branch/Tag/Commit selection loop
 	branch := updateBranch
 	tag := updateTag
@@ -197,8 +277,10 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		}
 
 		// Step 4: Prepare stack to get new images
-		fmt.Println("\nPreparing update...")
+		fmt.Println()
+		spin := spinner.Start(os.Stdout, "Preparing update")
 		prepareResp, err = apiClient.PrepareStack(
+			ctx,
 			blueprintRef,
 			stackEnv,
 			commit,
@@ -207,7 +289,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 			true, // detailed
 		)
 		if err != nil {
-			fmt.Printf("❌ Failed to prepare update: %v\n", err)
+			spin.Fail(fmt.Sprintf("Failed to prepare update: %v", err))
 
 			if updateNonInteractive || updateYes {
 				return fmt.Errorf("failed to prepare update: %w", err)
@@ -231,31 +313,24 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("update cancelled")
 			}
 		}
+		spin.Success("Update prepared")
 
 		// Validate we have images
 		if prepareResp == nil || len(prepareResp.Images) == 0 {
 			return fmt.Errorf("no images returned from prepare")
 		}
 
-		// Check for missing images
-		hasMissing := false
-		for _, img := range prepareResp.Images {
-			if img.Digest == "" || img.Digest == "N/A" {
-				hasMissing = true
-				break
-			}
+		if err := cmdutil.ApplyImageOverrides(prepareResp.Images, imageOverrides); err != nil {
+			return err
 		}
 
-		if hasMissing {
+		// Check for missing images
+		if output.HasMissingImages(prepareResp.Images) {
 			fmt.Println("\n❌ Some services have missing images:")
-			for _, img := range prepareResp.Images {
-				if img.Digest == "" || img.Digest == "N/A" {
-					fmt.Printf("  - %s\n", img.Service)
-				}
-			}
+			output.PrintImageDiagnostics(os.Stdout, prepareResp.Images)
 
 			if updateNonInteractive || updateYes {
-				return fmt.Errorf("cannot update: some services have missing images")
+				return fmt.Errorf("cannot update: some services have %w", client.ErrMissingImages)
 			}
 
 			action, retryErr := interactive.ConfirmRetry()
@@ -307,6 +382,11 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	if !hasChanges {
 		fmt.Println("\nℹ️  No new images found")
 
+		if updateDryRun {
+			// The blueprint may still have changed since the stack was deployed
+			return printUpdateManifestDiff(ctx, apiClient, stackName, stackEnv, prepareResp.Images)
+		}
+
 		if updateYes || updateNonInteractive {
 			// Non-interactive mode with no changes - just exit
 			return nil
@@ -337,6 +417,11 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 			}
 		}
 	} else {
+		var provenance map[string]*registry.Provenance
+		if updateProvenance {
+			provenance = collectProvenance(ctx, prepareResp.Images)
+		}
+
 		// Show git-style diff for changed services only
 		fmt.Println("\n📋 Image Updates:")
 		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -358,14 +443,21 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 			if img.Digest != "" && currentImageInfo != newImage {
 				fmt.Printf("\n%s:\n", img.Service)
 				if currentImageInfo != "" {
-					fmt.Printf("  \033[31m- %s (old)\033[0m\n", currentImageInfo)
+					fmt.Printf("  %s\n", output.Red(fmt.Sprintf("- %s (old)", currentImageInfo)))
+				}
+				fmt.Printf("  %s\n", output.Green(fmt.Sprintf("+ %s (new)", newImage)))
+				if details := formatImageDetails(provenance[img.Service], img.Vulnerabilities); details != "" {
+					fmt.Printf("    %s\n", details)
 				}
-				fmt.Printf("  \033[32m+ %s (new)\033[0m\n", newImage)
 			}
 		}
 		fmt.Println()
 	}
 
+	if updateDryRun {
+		return printUpdateManifestDiff(ctx, apiClient, stackName, stackEnv, prepareResp.Images)
+	}
+
 	// Step 6: Confirm update (only if there are changes)
 	if !updateYes && !updateNonInteractive && hasChanges {
 		for {
@@ -399,15 +491,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 applyUpdate:
 	// Step 7: Build images map and update stack
 	fmt.Println("Applying update...")
-	imagesMap := make(map[string]interface{})
-	for _, img := range prepareResp.Images {
-		imagesMap[img.Service] = map[string]interface{}{
-			"digest": img.Digest,
-			"image":  img.Image,
-		}
-	}
-
-	if err := apiClient.UpdateStack(stackName, imagesMap); err != nil {
+	if err := apiClient.UpdateStack(ctx, stackName, cmdutil.StackImagesMap(prepareResp.Images)); err != nil {
 		return fmt.Errorf("failed to update stack: %w", err)
 	}
 
@@ -421,5 +505,40 @@ applyUpdate:
 		fmt.Printf("Updated %d services\n", len(changedServices))
 	}
 
+	if updateWait {
+		if err := waitForStackReady(ctx, os.Stdout, apiClient, stackName, stackEnv, updateTimeout, updateNotify); err != nil {
+			return err
+		}
+	}
+
+	return projectCfg.Run(hooks.PhasePost, "update", hookVars, os.Stdout)
+}
+
+// printUpdateManifestDiff prints a kubectl diff style diff of the stack's
+// rendered manifests before and after updating it to images
+func printUpdateManifestDiff(ctx context.Context, apiClient *client.Client, stackName, env string, images []client.DetailedImageResolutionInfo) error {
+	spin := spinner.Start(os.Stdout, "Rendering manifests")
+	manifests, err := apiClient.RenderStackUpdate(ctx, stackName, env, cmdutil.StackImagesMap(images))
+	if err != nil {
+		spin.Fail("Failed to render manifests")
+		return err
+	}
+	diffs, err := diff.Manifests(manifests.Current, manifests.Proposed, 3)
+	if err != nil {
+		spin.Fail("Failed to diff manifests")
+		return err
+	}
+	spin.Stop()
+
+	if len(diffs) == 0 {
+		fmt.Println("ℹ️  No manifest changes")
+	} else {
+		fmt.Println("📋 Manifest Changes:")
+		for _, unified := range diffs {
+			fmt.Println()
+			output.PrintUnifiedDiff(os.Stdout, unified)
+		}
+	}
+	fmt.Printf("\n🔍 Dry run: stack '%s' was not updated\n", stackName)
 	return nil
 }
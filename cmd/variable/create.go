@@ -6,6 +6,7 @@ import (
 
 	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/messages"
 	"github.com/spf13/cobra"
 )
 
@@ -16,16 +17,21 @@ var (
 	createScope      string
 	createEnv        string
 	createRepository string
+	createFromFile   string
 )
 
 var createCmd = &cobra.Command{
-	Use:   "create KEY=value [KEY=value...]",
+	Use:   "create [KEY=value...] [--from-file .env]",
 	Short: "Create or update variable config",
 	Long: `Create a new variable config or merge keys into an existing one.
 
 If a config already exists for the same scope/env, new keys are merged in.
 Rejects only if keys conflict (same key with different value).
 
+--from-file reads KEY=value lines in .env format: # comments, optional
+"export" prefixes, and single- or double-quoted values (which may span
+lines). Keys given as arguments override keys from the file.
+
 Examples:
   # Create env-scoped variables (uses current env)
   lissto variable create KEY1=value1 KEY2=value2
@@ -33,6 +39,12 @@ Examples:
   # Add more keys to the same env (merges)
   lissto variable create KEY3=value3
 
+  # Import keys from a .env file
+  lissto variable create --from-file .env
+
+  # Import from a file and override one key
+  lissto variable create --from-file .env KEY1=override
+
   # Create with explicit env
   lissto variable create KEY1=value1 --env production
 
@@ -42,7 +54,7 @@ Examples:
   # Create global variables (admin only)
   lissto variable create KEY=value --scope global
 `,
-	Args: cobra.MinimumNArgs(1),
+	Args: cobra.ArbitraryArgs,
 	RunE: runCreate,
 }
 
@@ -50,9 +62,12 @@ func init() {
 	createCmd.Flags().StringVarP(&createScope, "scope", "s", "", "Scope: env, repo, or global (default: env)")
 	createCmd.Flags().StringVarP(&createEnv, "env", "e", "", "Environment name (default: current env)")
 	createCmd.Flags().StringVarP(&createRepository, "repository", "r", "", "Repository (required for scope=repo)")
+	createCmd.Flags().StringVar(&createFromFile, "from-file", "", "Read KEY=value pairs from a .env file")
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Default scope to "env"
 	scope := createScope
 	if scope == "" {
@@ -64,17 +79,17 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	if scope == scopeEnv && env == "" {
 		env = cmdutil.GetCurrentEnv()
 		if env == "" {
-			return fmt.Errorf("env is required for scope=env. Set with --env or run 'lissto env use <env>'")
+			return messages.Error(messages.NoEnvForScope, nil)
 		}
 	}
 
-	// Parse KEY=value arguments
-	data, err := cmdutil.ParseKeyValueArgs(args)
+	// Collect keys from --from-file and KEY=value arguments
+	data, err := cmdutil.CollectKeyValues(args, createFromFile)
 	if err != nil {
 		return err
 	}
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -91,7 +106,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		Data:       data,
 	}
 
-	variable, err := apiClient.CreateVariable(req)
+	variable, err := apiClient.CreateVariable(ctx, req)
 	if err != nil {
 		// Check if it's a conflict error (409 or "already exists")
 		if strings.Contains(err.Error(), "409") || strings.Contains(strings.ToLower(err.Error()), "already exists") {
@@ -99,7 +114,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 			fmt.Printf("Variable '%s' already exists, merging keys...\n", name)
 
 			// Get existing variable (pass scope for correct namespace resolution)
-			existing, err := apiClient.GetVariable(name, scope, env, createRepository)
+			existing, err := apiClient.GetVariable(ctx, name, scope, env, createRepository)
 			if err != nil {
 				return fmt.Errorf("failed to get existing variable: %w", err)
 			}
@@ -130,7 +145,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 			updateReq := &client.UpdateVariableRequest{
 				Data: mergedData,
 			}
-			variable, err = apiClient.UpdateVariable(name, scope, env, createRepository, updateReq)
+			variable, err = apiClient.UpdateVariable(ctx, name, scope, env, createRepository, updateReq)
 			if err != nil {
 				return fmt.Errorf("failed to merge variable: %w", err)
 			}
@@ -0,0 +1,185 @@
+package variable
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/interactive"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Edit formats
+const (
+	formatDotEnv = "dotenv"
+	formatYAML   = "yaml"
+)
+
+var (
+	editScope      string
+	editEnv        string
+	editRepository string
+	editFormat     string
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit [name]",
+	Short: "Edit a variable config in your editor",
+	Long: `Open a variable config in $VISUAL or $EDITOR (vi by default), then apply
+the edited keys. Before anything is applied the edited file is validated and
+the added, removed and changed keys are shown for confirmation.
+
+Without a name the config of the scope is edited (the current env's by
+default); a config that doesn't exist yet is created.
+
+Examples:
+  # Edit the current env's variables as a .env file
+  lissto variable edit
+
+  # Edit staging's variables as YAML
+  lissto variable edit --env staging --format yaml
+
+  # Edit repo-scoped variables
+  lissto variable edit --scope repo --repository github.com/org/app`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEdit,
+}
+
+func init() {
+	editCmd.Flags().StringVarP(&editScope, "scope", "s", scopeEnv, "Scope: env, repo, or global")
+	editCmd.Flags().StringVarP(&editEnv, "env", "e", "", "Environment name (default: current env)")
+	editCmd.Flags().StringVarP(&editRepository, "repository", "r", "", "Repository (required for scope=repo)")
+	editCmd.Flags().StringVar(&editFormat, "format", formatDotEnv, "Editing format: dotenv or yaml")
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if editFormat != formatDotEnv && editFormat != formatYAML {
+		return fmt.Errorf("invalid --format '%s' (use dotenv or yaml)", editFormat)
+	}
+
+	env := editEnv
+	if editScope == scopeEnv && env == "" {
+		env = cmdutil.GetCurrentEnv()
+		if env == "" {
+			return messages.Error(messages.NoEnvForScope, nil)
+		}
+	}
+
+	name := cmdutil.GenerateResourceName(editScope, env, editRepository)
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := map[string]string{}
+	exists := true
+	variable, err := apiClient.GetVariable(ctx, name, editScope, env, editRepository)
+	switch {
+	case errors.Is(err, client.ErrNotFound):
+		exists = false
+	case err != nil:
+		return fmt.Errorf("failed to get variable: %w", err)
+	default:
+		current = variable.Data
+	}
+
+	content, err := formatVariables(name, current)
+	if err != nil {
+		return err
+	}
+
+	var edited map[string]string
+	for {
+		content, err = interactive.EditText(content, "lissto-variables-*."+editFormat)
+		if err != nil {
+			return err
+		}
+		edited, err = parseVariables(content)
+		if err == nil {
+			break
+		}
+
+		// Keep the user's edits so a typo doesn't cost them their work
+		fmt.Printf("❌ %v\n", err)
+		again, promptErr := interactive.ConfirmAction("Edit again?", true)
+		if promptErr != nil || !again {
+			return fmt.Errorf("edit cancelled: %w", err)
+		}
+	}
+
+	changes := cmdutil.CompareKeys(current, edited)
+	if changes.Empty() {
+		fmt.Println("No changes")
+		return nil
+	}
+
+	fmt.Printf("Changes to '%s':\n", name)
+	for _, key := range changes.Added {
+		fmt.Printf("  %s\n", output.Green("+ "+key))
+	}
+	for _, key := range changes.Removed {
+		fmt.Printf("  %s\n", output.Red("- "+key))
+	}
+	for _, key := range changes.Changed {
+		fmt.Printf("  %s\n", output.Yellow("~ "+key))
+	}
+	fmt.Println()
+
+	confirmed, err := interactive.ConfirmAction("Apply these changes?", true)
+	if err != nil || !confirmed {
+		return fmt.Errorf("edit cancelled")
+	}
+
+	if !exists {
+		_, err = apiClient.CreateVariable(ctx, &client.CreateVariableRequest{
+			Name:       name,
+			Scope:      editScope,
+			Env:        env,
+			Repository: editRepository,
+			Data:       edited,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create variable: %w", err)
+		}
+		fmt.Printf("✅ Variable '%s' created with %d keys\n", name, len(edited))
+		return nil
+	}
+
+	req := &client.UpdateVariableRequest{Data: edited}
+	if _, err := apiClient.UpdateVariable(ctx, name, editScope, env, editRepository, req); err != nil {
+		return fmt.Errorf("failed to update variable: %w", err)
+	}
+	fmt.Printf("✅ Variable '%s' updated (%d added, %d removed, %d changed)\n",
+		name, len(changes.Added), len(changes.Removed), len(changes.Changed))
+	return nil
+}
+
+// formatVariables renders variables for editing in --format
+func formatVariables(name string, values map[string]string) (string, error) {
+	header := fmt.Sprintf("# Variables of '%s'. Save and close the editor to apply;\n# remove a line to delete the key.\n", name)
+	if editFormat == formatYAML {
+		body, err := cmdutil.FormatYAMLValues(values)
+		if err != nil {
+			return "", err
+		}
+		return header + body, nil
+	}
+	return header + cmdutil.FormatDotEnv(values), nil
+}
+
+// parseVariables parses edited variables in --format
+func parseVariables(content string) (map[string]string, error) {
+	if editFormat == formatYAML {
+		return cmdutil.ParseYAMLValues(content)
+	}
+	return cmdutil.ParseDotEnv(content)
+}
@@ -0,0 +1,109 @@
+package variable
+
+import (
+	"fmt"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportScope      string
+	exportEnv        string
+	exportRepository string
+	exportMerge      bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export variables as dotenv, JSON or YAML",
+	Long: `Export a variable config so the stack environment can be reproduced locally,
+e.g. as a docker compose env_file.
+
+By default a single config is exported (the current env's, or the one selected
+with --scope). --merge combines every config that applies to the env and
+repository with the controller's precedence: global < repo < env.
+
+Output defaults to dotenv; use -o json or -o yaml for structured output.
+
+Examples:
+  # Export the current env's variables to a .env file
+  lissto variable export > .env
+
+  # Export staging's variables as JSON
+  lissto variable export --env staging -o json
+
+  # Export repo-scoped variables
+  lissto variable export --scope repo --repository github.com/org/app
+
+  # Export the effective variables of stacks in staging for a repository
+  lissto variable export --merge --env staging --repository github.com/org/app`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportScope, "scope", "s", scopeEnv, "Scope: env, repo, or global")
+	exportCmd.Flags().StringVarP(&exportEnv, "env", "e", "", "Environment name (default: current env)")
+	exportCmd.Flags().StringVarP(&exportRepository, "repository", "r", "", "Repository (required for scope=repo; used by --merge)")
+	exportCmd.Flags().BoolVar(&exportMerge, "merge", false, "Merge global, repo and env scopes like the controller does")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if exportMerge && cmd.Flags().Changed("scope") {
+		return fmt.Errorf("--merge combines all scopes and cannot be used with --scope")
+	}
+
+	env := exportEnv
+	if (exportMerge || exportScope == scopeEnv) && env == "" {
+		env = cmdutil.GetCurrentEnv()
+		if env == "" {
+			return messages.Error(messages.NoEnvForScope, nil)
+		}
+	}
+
+	repository := exportRepository
+	if repository == "" {
+		repository = cmdutil.LoadOverrides().Repository
+	}
+
+	apiClient, err := cmdutil.GetAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !exportMerge {
+		name := cmdutil.GenerateResourceName(exportScope, env, repository)
+		variable, err := apiClient.GetVariable(ctx, name, exportScope, env, repository)
+		if err != nil {
+			return fmt.Errorf("failed to get variable: %w", err)
+		}
+		return cmdutil.PrintExport(cmd, variable.Data, variable.Data)
+	}
+
+	variables, err := apiClient.ListVariables(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list variables: %w", err)
+	}
+
+	configs := make([]cmdutil.ScopedConfig, 0, len(variables))
+	for _, v := range variables {
+		configs = append(configs, cmdutil.ScopedConfig{
+			Name:       v.Name,
+			Scope:      v.Scope,
+			Env:        v.Env,
+			Repository: v.Repository,
+			Data:       v.Data,
+		})
+	}
+
+	merged := cmdutil.MergeScopes(configs, env, repository)
+	values := make(map[string]string, len(merged))
+	for key, v := range merged {
+		values[key] = v.Value
+	}
+	return cmdutil.PrintExport(cmd, merged, values)
+}
@@ -29,6 +29,8 @@ func init() {
 }
 
 func runGet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	name := args[0]
 
 	// Default env for scope=env
@@ -37,12 +39,12 @@ func runGet(cmd *cobra.Command, args []string) error {
 		env = cmdutil.GetCurrentEnv()
 	}
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	variable, err := apiClient.GetVariable(name, getScope, env, getRepository)
+	variable, err := apiClient.GetVariable(ctx, name, getScope, env, getRepository)
 	if err != nil {
 		return fmt.Errorf("failed to get variable: %w", err)
 	}
@@ -29,6 +29,8 @@ func init() {
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	name := args[0]
 
 	// Parse data
@@ -37,7 +39,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	apiClient, err := cmdutil.GetAPIClient()
+	apiClient, err := cmdutil.GetAPIClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -47,7 +49,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Use default scope (env) - TODO: add scope flags
-	variable, err := apiClient.UpdateVariable(name, "", "", "", req)
+	variable, err := apiClient.UpdateVariable(ctx, name, "", "", "", req)
 	if err != nil {
 		return fmt.Errorf("failed to update variable: %w", err)
 	}
@@ -17,4 +17,6 @@ func init() {
 	VariableCmd.AddCommand(createCmd)
 	VariableCmd.AddCommand(updateCmd)
 	VariableCmd.AddCommand(deleteCmd)
+	VariableCmd.AddCommand(exportCmd)
+	VariableCmd.AddCommand(editCmd)
 }
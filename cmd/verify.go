@@ -3,12 +3,17 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	apicompose "github.com/lissto-dev/api/pkg/compose"
+	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/compose"
+	"github.com/lissto-dev/cli/pkg/diff"
+	"github.com/lissto-dev/cli/pkg/interactive"
 	"github.com/lissto-dev/cli/pkg/output"
 )
 
@@ -24,6 +29,39 @@ This command checks:
 - Network and volume configurations
 - Environment variable references
 
+With --remote, the Lissto API also checks the file against the cluster: that
+each service's repository and registry are configured, that exposed ports are
+allowed and that resource requests fit the environment's quotas.
+
+With --check-env, the ${VAR} placeholders of the file are cross-referenced
+with the variable and secret configs that apply to stacks of the environment
+and repository (global < repo < env). Variables no config provides and that
+have no default (${VAR:-default}) are listed as unresolved, and fail
+verification before they fail the deployment.
+
+With --fix, mechanical issues are rewritten in place after showing a diff:
+the obsolete version key, deprecated external.name and version 1 fields
+(net, log_driver, log_opt), yes/no booleans, unquoted port mappings and
+environment values that YAML reads as booleans or numbers.
+
+With --lint, services are also checked against policy rules:
+  no-latest-tag             images are pinned to a tag other than latest (warning)
+  healthcheck-required      services define a healthcheck (warning)
+  resource-limits-required  services set memory or CPU limits (warning)
+  no-privileged             services don't run in privileged mode (error)
+
+Rules are configured in .lissto-lint.yaml next to the compose file or in the
+working directory (or --lint-config):
+
+  rules:
+    healthcheck-required: error   # error, warning, info or off
+    resource-limits-required: off
+  ignore:
+    no-privileged: [docker-in-docker]
+  failOn: warning                 # lowest severity that fails (default error)
+
+Findings at or above failOn exit with code 7, so CI can gate on them.
+
 Environment variables:
   LISSTO_COMPOSE_FILE  Override compose file path (used when no argument provided)
 
@@ -37,6 +75,21 @@ Examples:
   # Verify quietly (only errors)
   lissto verify compose.yaml --quiet
   
+  # Verify only the services enabled by compose profiles
+  lissto verify compose.yaml --profile dev --verbose
+
+  # Also check for deployment blockers in the dev environment
+  lissto verify compose.yaml --remote --env dev
+
+  # List the variables staging doesn't provide
+  lissto verify compose.yaml --check-env --env staging
+
+  # Fix common issues, confirming the diff first
+  lissto verify compose.yaml --fix
+
+  # Also lint against the policy rules, failing CI on findings
+  lissto verify compose.yaml --lint
+
   # Verify with raw parser output (for debugging)
   lissto verify compose.yaml --raw
   
@@ -50,6 +103,14 @@ func init() {
 	verifyCmd.Flags().BoolP("verbose", "v", false, "Show verbose output including warnings")
 	verifyCmd.Flags().BoolP("quiet", "q", false, "Only show errors, suppress warnings")
 	verifyCmd.Flags().Bool("raw", false, "Show raw parser output (for debugging)")
+	verifyCmd.Flags().StringSlice("profile", nil, "Compose profile to include (repeatable)")
+	verifyCmd.Flags().Bool("remote", false, "Also check the file against the cluster's constraints via the API")
+	verifyCmd.Flags().Bool("check-env", false, "Also report ${VAR} placeholders the environment's variables and secrets don't provide")
+	verifyCmd.Flags().String("repository", "", "With --remote or --check-env, repository of the compose file (default: the git remote)")
+	verifyCmd.Flags().Bool("fix", false, "Rewrite the file to fix mechanical issues, after showing a diff")
+	verifyCmd.Flags().BoolP("yes", "y", false, "With --fix, write without confirmation")
+	verifyCmd.Flags().Bool("lint", false, "Also check services against the lint rules")
+	verifyCmd.Flags().String("lint-config", "", "With --lint, rules config file (default: "+compose.LintConfigFile+" next to the compose file or in the working directory)")
 }
 
 func runVerify(cmd *cobra.Command, args []string) error {
@@ -69,16 +130,32 @@ func runVerify(cmd *cobra.Command, args []string) error {
 
 	verbose, _ := cmd.Flags().GetBool("verbose")
 	raw, _ := cmd.Flags().GetBool("raw")
+	profiles, _ := cmd.Flags().GetStringSlice("profile")
+	fix, _ := cmd.Flags().GetBool("fix")
+	if fix && raw {
+		return fmt.Errorf("--fix cannot be combined with --raw")
+	}
+	lint, _ := cmd.Flags().GetBool("lint")
+	lintConfigPath, _ := cmd.Flags().GetString("lint-config")
+	if lintConfigPath != "" && !lint {
+		return fmt.Errorf("--lint-config requires --lint")
+	}
 
 	// Silence all logs by default (we capture warnings internally)
 	logrus.SetLevel(logrus.PanicLevel)
 
 	// Read file
-	data, err := os.ReadFile(composePath)
+	rawData, err := os.ReadFile(composePath)
 	if err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
+	// Validate what a blueprint created with the same profiles would contain
+	data, profileResult, err := compose.ApplyProfiles(string(rawData), profiles)
+	if err != nil {
+		return err
+	}
+
 	var validationResult *apicompose.ValidationResult
 
 	if raw {
@@ -92,7 +169,7 @@ func runVerify(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 
 		// Call validation without capturing warnings (warnings will be printed as they occur)
-		validationResult, err = apicompose.ValidateComposeRaw(string(data))
+		validationResult, err = apicompose.ValidateComposeRaw(data)
 		if err != nil {
 			return err
 		}
@@ -112,7 +189,7 @@ func runVerify(cmd *cobra.Command, args []string) error {
 		}
 	} else {
 		// Normal mode: validate using shared logic (captures warnings internally)
-		validationResult, err = apicompose.ValidateCompose(string(data))
+		validationResult, err = apicompose.ValidateCompose(data)
 		if err != nil {
 			return err
 		}
@@ -126,6 +203,11 @@ func runVerify(cmd *cobra.Command, args []string) error {
 			Warnings:     validationResult.Warnings,
 			WarningCount: len(validationResult.Warnings),
 		}
+		if len(profileResult.Declared) > 0 {
+			templateData.Profiles = profileResult.Declared
+			templateData.SelectedProfiles = profileResult.Selected
+			templateData.ExcludedServices = profileResult.Disabled
+		}
 
 		// Display results using template
 		if err := output.PrintVerificationResultToStdout(templateData); err != nil {
@@ -133,10 +215,296 @@ func runVerify(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if remote, _ := cmd.Flags().GetBool("remote"); remote {
+		ok, err := verifyRemote(cmd, composePath, data)
+		if err != nil {
+			return err
+		}
+		validationResult.Valid = validationResult.Valid && ok
+	}
+
+	if checkEnv, _ := cmd.Flags().GetBool("check-env"); checkEnv {
+		ok, err := verifyEnvVariables(cmd, composePath, data)
+		if err != nil {
+			return err
+		}
+		validationResult.Valid = validationResult.Valid && ok
+	}
+
+	if fix {
+		valid, err := fixComposeFile(cmd, composePath, string(rawData), profiles, validationResult.Warnings)
+		if err != nil {
+			return err
+		}
+		validationResult.Valid = valid
+	}
+
 	// Exit with error code if invalid
 	if !validationResult.Valid {
 		return fmt.Errorf("validation failed")
 	}
 
+	if lint {
+		return lintCompose(cmd, composePath, lintConfigPath, data)
+	}
 	return nil
 }
+
+// lintCompose checks compose content against the lint rules and fails with
+// exitCodeLint when a finding reaches the configured failOn severity
+func lintCompose(cmd *cobra.Command, composePath, configPath, content string) error {
+	if configPath == "" {
+		configPath = compose.FindLintConfig(composePath)
+	}
+	config := &compose.LintConfig{}
+	if configPath != "" {
+		var err error
+		if config, err = compose.LoadLintConfig(configPath); err != nil {
+			return err
+		}
+	}
+
+	findings, err := compose.Lint(content, config)
+	if err != nil {
+		return err
+	}
+	failing := 0
+	for _, f := range findings {
+		if config.Fails(f) {
+			failing++
+		}
+	}
+
+	if err := cmdutil.PrintOutput(cmd, findings, func() {
+		fmt.Println()
+		if len(findings) == 0 {
+			fmt.Println("✅ Lint: no findings")
+			return
+		}
+		fmt.Printf("🧹 Lint: %d finding(s)\n", len(findings))
+		rows := make([][]string, 0, len(findings))
+		for _, f := range findings {
+			severity := f.Severity
+			switch f.Severity {
+			case compose.SeverityError:
+				severity = output.Red(severity)
+			case compose.SeverityWarning:
+				severity = output.Yellow(severity)
+			}
+			line := ""
+			if f.Line > 0 {
+				line = fmt.Sprint(f.Line)
+			}
+			rows = append(rows, []string{severity, f.Service, f.Rule, line, f.Message})
+		}
+		output.PrintTable(os.Stdout, []string{"SEVERITY", "SERVICE", "RULE", "LINE", "MESSAGE"}, rows)
+	}); err != nil {
+		return err
+	}
+
+	if failing > 0 {
+		return &exitError{code: exitCodeLint, err: fmt.Errorf("lint failed: %d finding(s) at or above the failOn severity", failing)}
+	}
+	return nil
+}
+
+// fixComposeFile rewrites the mechanical issues of a compose file after
+// showing the diff and confirming, and reports whether the result is valid
+func fixComposeFile(cmd *cobra.Command, path, content string, profiles, warnings []string) (bool, error) {
+	fixed, fixes, err := compose.FixCompose(content, warnings)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Println()
+	if len(fixes) == 0 {
+		fmt.Println("✨ Nothing to fix")
+		return revalidate(content, profiles)
+	}
+
+	fmt.Printf("🔧 %d fix(es):\n", len(fixes))
+	for _, f := range fixes {
+		fmt.Printf("  - %s: %s\n", f.Path, f.Message)
+		if f.Warning != "" {
+			fmt.Printf("    %s\n", output.Gray("resolves: "+f.Warning))
+		}
+	}
+	fmt.Println()
+	output.PrintUnifiedDiff(os.Stdout, diff.Unified(path, path+" (fixed)", content, fixed, 3))
+	fmt.Println()
+
+	if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+		confirmed, err := interactive.ConfirmAction(fmt.Sprintf("Write %d fix(es) to %s?", len(fixes), path), false)
+		if err != nil || !confirmed {
+			return false, fmt.Errorf("fix cancelled")
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(fixed), info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write file: %w", err)
+	}
+	fmt.Printf("✅ Wrote %d fix(es) to %s\n", len(fixes), path)
+
+	return revalidate(fixed, profiles)
+}
+
+// revalidate validates compose content again after fixing, reporting the
+// remaining warnings and errors
+func revalidate(content string, profiles []string) (bool, error) {
+	data, _, err := compose.ApplyProfiles(content, profiles)
+	if err != nil {
+		return false, err
+	}
+	result, err := apicompose.ValidateCompose(data)
+	if err != nil {
+		return false, err
+	}
+	for _, errMsg := range result.Errors {
+		fmt.Printf("❌ %s\n", errMsg)
+	}
+	if len(result.Warnings) > 0 {
+		fmt.Printf("⚠️  %d warning(s) remain; run 'lissto verify --verbose' to see them\n", len(result.Warnings))
+	}
+	return result.Valid, nil
+}
+
+// verifyRemote asks the API whether the compose content can be deployed to
+// the selected environment, prints the checks and reports whether none of
+// them would block the deployment
+func verifyRemote(cmd *cobra.Command, composePath, content string) (bool, error) {
+	apiClient, env, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := apiClient.ValidateBlueprint(cmd.Context(), client.ValidateBlueprintRequest{
+		Compose:    content,
+		Repository: verifyRepository(cmd, composePath),
+		Env:        env,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("\n🌐 Cluster checks (env: %s):\n", env)
+	if len(result.Checks) == 0 {
+		fmt.Println("  No checks reported")
+		return true, nil
+	}
+	rows := make([][]string, 0, len(result.Checks))
+	for _, c := range result.Checks {
+		var status string
+		switch c.Status {
+		case client.CheckStatusOK:
+			status = output.Green("✅ ok")
+		case client.CheckStatusWarning:
+			status = output.Yellow("⚠️  warning")
+		default:
+			status = output.Red("❌ " + c.Status)
+		}
+		rows = append(rows, []string{c.Service, c.Check, status, c.Message})
+	}
+	output.PrintTable(os.Stdout, []string{"SERVICE", "CHECK", "STATUS", "DETAIL"}, rows)
+
+	if result.HasErrors() {
+		fmt.Println("❌ The stack would be blocked in this environment")
+		return false, nil
+	}
+	return true, nil
+}
+
+// verifyRepository returns the repository of the compose file: --repository,
+// else the override, else the git remote
+func verifyRepository(cmd *cobra.Command, composePath string) string {
+	repository, _ := cmd.Flags().GetString("repository")
+	if repository == "" {
+		if overrides := cmdutil.LoadOverrides(); overrides.HasRepository() {
+			repository = overrides.Repository
+		} else if inferred, err := inferRepositoryFromFile(composePath); err == nil {
+			repository = inferred
+		}
+	}
+	return repository
+}
+
+// verifyEnvVariables checks the ${VAR} placeholders of compose content against
+// the variables and secrets applying to the selected environment, prints the
+// report and reports whether every variable resolves
+func verifyEnvVariables(cmd *cobra.Command, composePath, content string) (bool, error) {
+	interpolations, err := compose.FindInterpolations(content)
+	if err != nil {
+		return false, err
+	}
+
+	apiClient, env, err := cmdutil.GetAPIClientAndEnv(cmd)
+	if err != nil {
+		return false, err
+	}
+	ctx := cmd.Context()
+	repository := verifyRepository(cmd, composePath)
+
+	variables, err := apiClient.ListVariables(ctx)
+	if err != nil {
+		return false, err
+	}
+	secrets, err := apiClient.ListSecrets(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	configs := make([]cmdutil.ScopedConfig, 0, len(variables)+len(secrets))
+	for _, v := range variables {
+		configs = append(configs, cmdutil.ScopedConfig{Name: "variable " + v.Name, Scope: v.Scope, Env: v.Env, Repository: v.Repository, Data: v.Data})
+	}
+	for _, s := range secrets {
+		keys := make(map[string]string, len(s.Keys))
+		for _, key := range s.Keys {
+			keys[key] = ""
+		}
+		configs = append(configs, cmdutil.ScopedConfig{Name: "secret " + s.Name, Scope: s.Scope, Env: s.Env, Repository: s.Repository, Data: keys})
+	}
+	available := make(map[string]string)
+	for key, v := range cmdutil.MergeScopes(configs, env, repository) {
+		available[key] = fmt.Sprintf("%s (%s)", v.Source, v.Scope)
+	}
+
+	checks := compose.CheckVariables(interpolations, available)
+	unresolved := 0
+	for _, c := range checks {
+		if c.Status == compose.VariableUnresolved {
+			unresolved++
+		}
+	}
+
+	if err := cmdutil.PrintOutput(cmd, checks, func() {
+		fmt.Printf("\n🔤 Variables (env: %s):\n", env)
+		if len(checks) == 0 {
+			fmt.Println("  No ${VAR} placeholders")
+			return
+		}
+		rows := make([][]string, 0, len(checks))
+		for _, c := range checks {
+			var status string
+			switch c.Status {
+			case compose.VariableResolved:
+				status = output.Green("✅ resolved")
+			case compose.VariableDefault:
+				status = output.Gray("default")
+			default:
+				status = output.Red("❌ unresolved")
+			}
+			rows = append(rows, []string{c.Name, status, c.Source, strings.Join(c.Paths, ", ")})
+		}
+		output.PrintTable(os.Stdout, []string{"VARIABLE", "STATUS", "SOURCE", "USED IN"}, rows)
+		if unresolved > 0 {
+			fmt.Printf("❌ %d variable(s) unresolved in %s; set them with 'lissto variable update' or 'lissto secret set'\n", unresolved, env)
+		}
+	}); err != nil {
+		return false, err
+	}
+	return unresolved == 0, nil
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/notify"
+	"github.com/lissto-dev/cli/pkg/status"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+)
+
+// defaultWaitTimeout is the default for --timeout with --wait
+const defaultWaitTimeout = 10 * time.Minute
+
+// stackFetcher returns a status.StackFetcher for a stack. stackID may be a
+// stack name or a scoped identifier as returned by the API.
+func stackFetcher(apiClient *client.Client, stackID, env string) status.StackFetcher {
+	return func(ctx context.Context) (*envv1alpha1.Stack, error) {
+		stacks, err := apiClient.ListStacks(ctx, env)
+		if err != nil {
+			return nil, err
+		}
+		for i := range stacks {
+			if stacks[i].Name == stackID || strings.HasSuffix(stackID, "/"+stacks[i].Name) {
+				return &stacks[i], nil
+			}
+		}
+		return nil, fmt.Errorf("stack '%s' %w", stackID, client.ErrNotFound)
+	}
+}
+
+// waitForStackReady blocks until the stack is ready, printing a line to out
+// whenever a service changes state. stackID may be a stack name or a scoped
+// identifier as returned by the API. With a notify target, the outcome is
+// also sent as a notification.
+func waitForStackReady(ctx context.Context, out io.Writer, apiClient *client.Client, stackID, env string, timeout time.Duration, notifyTarget string) error {
+	k8sClient, err := k8s.NewClient()
+	if err != nil {
+		return fmt.Errorf("--wait requires Kubernetes access: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	fetch := stackFetcher(apiClient, stackID, env)
+
+	fmt.Fprintf(out, "\n⏳ Waiting for stack to become ready (timeout %s)...\n", timeout)
+
+	printed := make(map[string]string)
+	report, err := status.WaitForStack(ctx, k8sClient, fetch, status.DefaultWaitInterval, timeout, func(r status.StackReport) {
+		for _, svc := range r.Services {
+			line := formatServiceProgress(svc)
+			if printed[svc.Name] != line {
+				printed[svc.Name] = line
+				fmt.Fprintf(out, "  %s\n", line)
+			}
+		}
+	})
+	if err != nil {
+		var pending []string
+		for _, svc := range report.Services {
+			if !status.ServiceReady(svc) {
+				pending = append(pending, svc.Name)
+			}
+		}
+		if len(pending) > 0 {
+			err = fmt.Errorf("%w (not ready: %s)", err, strings.Join(pending, ", "))
+		}
+		// Interrupted waits aren't worth a notification
+		if ctx.Err() == nil {
+			sendStackNotification(ctx, out, notifyTarget, stackID, env, report, err)
+		}
+		return &exitError{code: exitCodeNotReady, err: err}
+	}
+
+	fmt.Fprintf(out, "✅ Stack is ready (%d services)\n", len(report.Services))
+	sendStackNotification(ctx, out, notifyTarget, stackID, env, report, nil)
+	return nil
+}
+
+// sendStackNotification notifies notifyTarget, if set, that a stack became
+// ready, failed or didn't become ready (waitErr). Delivery failures are
+// reported as warnings.
+func sendStackNotification(ctx context.Context, out io.Writer, notifyTarget, stackID, env string, report status.StackReport, waitErr error) {
+	if notifyTarget == "" {
+		return
+	}
+
+	name := stackID[strings.LastIndex(stackID, "/")+1:]
+	var title, message string
+	switch {
+	case waitErr != nil:
+		title = "Lissto: stack not ready"
+		message = fmt.Sprintf("Stack '%s' (env: %s): %v", name, env, waitErr)
+	case status.StackReady(report):
+		title = "Lissto: stack ready"
+		message = fmt.Sprintf("Stack '%s' (env: %s) is ready", name, env)
+	default:
+		var failed []string
+		for _, svc := range report.Services {
+			if svc.State == status.StateFailed {
+				failed = append(failed, svc.Name)
+			}
+		}
+		title = "Lissto: stack failed"
+		message = fmt.Sprintf("Stack '%s' (env: %s) failed", name, env)
+		if len(failed) > 0 {
+			message += ": " + strings.Join(failed, ", ")
+		}
+	}
+
+	if err := notify.Send(ctx, notifyTarget, title, message); err != nil {
+		fmt.Fprintf(out, "⚠️  %v\n", err)
+	}
+}
+
+// formatServiceProgress renders one service's readiness for --wait output
+func formatServiceProgress(svc status.ServiceReport) string {
+	if status.ServiceReady(svc) {
+		return fmt.Sprintf("%s %s ready", status.SymbolReady, svc.Name)
+	}
+
+	ready := 0
+	for _, pod := range svc.Pods {
+		if pod.Ready {
+			ready++
+		}
+	}
+
+	detail := fmt.Sprintf("%d/%d pods ready", ready, len(svc.Pods))
+	if svc.Traffic != nil && !svc.Traffic.Ready && svc.Traffic.Reason != "" {
+		detail += ", " + svc.Traffic.Reason
+	}
+
+	symbol := status.SymbolDeploying
+	if svc.State == status.StateFailed {
+		symbol = status.SymbolFailed
+	}
+	return fmt.Sprintf("%s %s %s (%s)", symbol, svc.Name, svc.State, detail)
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/lissto-dev/cli/pkg/fileutil"
 	"gopkg.in/yaml.v3"
 )
 
@@ -95,7 +96,13 @@ func (c *Cache) Set(key string, data any, ttl time.Duration) error {
 		return fmt.Errorf("failed to marshal cache entry: %w", err)
 	}
 
-	if err := os.WriteFile(c.path(key), content, 0600); err != nil {
+	// Concurrent writers take turns; readers never see a partial entry
+	unlock, err := fileutil.LockFile(c.path(key), true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := fileutil.WriteFileAtomic(c.path(key), content, 0600); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
 
@@ -161,9 +168,32 @@ func GetWithMeta[T any](c *Cache, key string) (*Entry[T], bool, error) {
 	return &entry, true, nil
 }
 
+// GetOrFetch returns the cached data for key, calling fetch and caching its
+// result for ttl when the entry is missing or expired, or refresh is set.
+// Failing to read or write the cache never fails the fetch.
+func GetOrFetch[T any](c *Cache, key string, ttl time.Duration, refresh bool, fetch func() (T, error)) (T, error) {
+	if !refresh {
+		if entry, found, err := GetWithMeta[T](c, key); err == nil && found {
+			return entry.Data, nil
+		}
+	}
+
+	data, err := fetch()
+	if err != nil {
+		return data, err
+	}
+	_ = c.Set(key, data, ttl)
+	return data, nil
+}
+
 // Delete removes an entry from the cache
 func (c *Cache) Delete(key string) error {
-	err := os.Remove(c.path(key))
+	unlock, err := fileutil.LockFile(c.path(key), true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = os.Remove(c.path(key))
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to delete cache file: %w", err)
 	}
@@ -1,8 +1,12 @@
 package cache_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -62,6 +66,35 @@ var _ = Describe("Cache", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeFalse())
 		})
+		It("should keep entries readable under concurrent writes", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					Expect(c.Set("shared-key", strings.Repeat(strconv.Itoa(i%10), 1000), time.Hour)).To(Succeed())
+					var data string
+					_, err := c.Get("shared-key", &data)
+					Expect(err).NotTo(HaveOccurred())
+				}(i)
+			}
+			wg.Wait()
+
+			var data string
+			found, err := c.Get("shared-key", &data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(data).To(HaveLen(1000))
+		})
+
+		It("should not leave temporary files behind", func() {
+			Expect(c.Set("test-key", "test-data", time.Hour)).To(Succeed())
+
+			matches, err := filepath.Glob(filepath.Join(tmpDir, "lissto", "*.tmp"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
 	})
 
 	Describe("Delete", func() {
@@ -102,6 +135,51 @@ var _ = Describe("Cache", func() {
 		})
 	})
 
+	Describe("GetOrFetch", func() {
+		var calls int
+		fetch := func() ([]string, error) {
+			calls++
+			return []string{"dev", "staging"}, nil
+		}
+
+		BeforeEach(func() {
+			calls = 0
+		})
+
+		It("should fetch once and serve later calls from the cache", func() {
+			for range 2 {
+				data, err := cache.GetOrFetch(c, "envs", time.Hour, false, fetch)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(data).To(Equal([]string{"dev", "staging"}))
+			}
+			Expect(calls).To(Equal(1))
+		})
+
+		It("should fetch again when refreshing or expired", func() {
+			_, err := cache.GetOrFetch(c, "envs", time.Hour, false, fetch)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cache.GetOrFetch(c, "envs", time.Hour, true, fetch)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(calls).To(Equal(2))
+
+			Expect(c.Set("old", []string{"dev"}, -time.Minute)).To(Succeed())
+			_, err = cache.GetOrFetch(c, "old", time.Hour, false, fetch)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(calls).To(Equal(3))
+		})
+
+		It("should not cache failed fetches", func() {
+			_, err := cache.GetOrFetch(c, "envs", time.Hour, false, func() ([]string, error) {
+				return nil, errors.New("unreachable")
+			})
+			Expect(err).To(MatchError("unreachable"))
+
+			var data []string
+			found, _ := c.Get("envs", &data)
+			Expect(found).To(BeFalse())
+		})
+	})
+
 	Describe("Default", func() {
 		var oldCacheHome string
 
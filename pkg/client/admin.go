@@ -1,6 +1,11 @@
 package client
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
 
 // CreateAPIKeyRequest represents the request to create an API key
 type CreateAPIKeyRequest struct {
@@ -15,21 +20,137 @@ type CreateAPIKeyResponse struct {
 	Role   string `json:"role"`
 }
 
-// CreateAPIKey creates a new API key (admin only)
-func (c *Client) CreateAPIKey(req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+// APIKey describes an API key, without its secret
+type APIKey struct {
+	Name       string     `json:"name" yaml:"name"`
+	Role       string     `json:"role" yaml:"role"`
+	CreatedBy  string     `json:"created_by,omitempty" yaml:"createdBy,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty" yaml:"createdAt,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" yaml:"lastUsedAt,omitempty"` // nil if never used
+}
+
+// doInternal calls an internal admin endpoint, which wraps its data in a
+// {success, data, message} envelope
+func (c *Client) doInternal(ctx context.Context, method, path string, body, data interface{}) error {
 	var response struct {
-		Success bool                  `json:"success"`
-		Data    *CreateAPIKeyResponse `json:"data"`
-		Message string                `json:"message"`
+		Success bool        `json:"success"`
+		Data    interface{} `json:"data"`
+		Message string      `json:"message"`
+	}
+	response.Data = data
+
+	if err := c.Do(ctx, method, path, body, &response); err != nil {
+		return err
 	}
+	if !response.Success {
+		return fmt.Errorf("%s", response.Message)
+	}
+	return nil
+}
 
-	if err := c.Do("POST", "/api/v1/_internal/api-keys", req, &response); err != nil {
+// CreateAPIKey creates a new API key (admin only)
+func (c *Client) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	var data *CreateAPIKeyResponse
+	if err := c.doInternal(ctx, "POST", "/api/v1/_internal/api-keys", req, &data); err != nil {
 		return nil, fmt.Errorf("failed to create API key: %w", err)
 	}
+	if data == nil {
+		return nil, fmt.Errorf("failed to create API key: empty response")
+	}
+
+	return data, nil
+}
+
+// ListAPIKeys lists all API keys (admin only)
+func (c *Client) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	var keys []APIKey
+	if err := c.doInternal(ctx, "GET", "/api/v1/_internal/api-keys", nil, &keys); err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key; requests made with it are rejected from
+// then on (admin only)
+func (c *Client) RevokeAPIKey(ctx context.Context, name string) error {
+	path := "/api/v1/_internal/api-keys/" + url.PathEscape(name)
+	if err := c.doInternal(ctx, "DELETE", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return nil
+}
+
+// RotateAPIKey replaces an API key's secret, keeping its name and role. The
+// previous secret stops working (admin only).
+func (c *Client) RotateAPIKey(ctx context.Context, name string) (*CreateAPIKeyResponse, error) {
+	var data *CreateAPIKeyResponse
+	path := "/api/v1/_internal/api-keys/" + url.PathEscape(name) + "/rotate"
+	if err := c.doInternal(ctx, "POST", path, nil, &data); err != nil {
+		return nil, fmt.Errorf("failed to rotate API key: %w", err)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("failed to rotate API key: empty response")
+	}
+
+	return data, nil
+}
+
+// UserDetails describes a user as admins see it
+type UserDetails struct {
+	Name       string     `json:"name" yaml:"name"`
+	Role       string     `json:"role" yaml:"role"`
+	Active     bool       `json:"active" yaml:"active"`
+	APIKeys    []string   `json:"api_keys,omitempty" yaml:"apiKeys,omitempty"` // names of the user's API keys
+	CreatedAt  *time.Time `json:"created_at,omitempty" yaml:"createdAt,omitempty"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty" yaml:"lastSeenAt,omitempty"` // nil if never seen
+}
+
+// ListUsers lists all users (admin only)
+func (c *Client) ListUsers(ctx context.Context) ([]UserDetails, error) {
+	var users []UserDetails
+	if err := c.doInternal(ctx, "GET", "/api/v1/_internal/users", nil, &users); err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetUser gets a user by name (admin only)
+func (c *Client) GetUser(ctx context.Context, name string) (*UserDetails, error) {
+	var user *UserDetails
+	if err := c.doInternal(ctx, "GET", "/api/v1/_internal/users/"+url.PathEscape(name), nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("failed to get user: empty response")
+	}
+
+	return user, nil
+}
+
+// SetUserRole changes a user's role, which applies to all their API keys
+// (admin only)
+func (c *Client) SetUserRole(ctx context.Context, name, role string) (*UserDetails, error) {
+	var user *UserDetails
+	reqBody := map[string]string{"role": role}
+	if err := c.doInternal(ctx, "PUT", "/api/v1/_internal/users/"+url.PathEscape(name)+"/role", reqBody, &user); err != nil {
+		return nil, fmt.Errorf("failed to set user role: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("failed to set user role: empty response")
+	}
+
+	return user, nil
+}
 
-	if !response.Success || response.Data == nil {
-		return nil, fmt.Errorf("failed to create API key: %s", response.Message)
+// DeactivateUser blocks a user: requests with any of their API keys are
+// rejected. Their stacks and blueprints are kept (admin only).
+func (c *Client) DeactivateUser(ctx context.Context, name string) error {
+	if err := c.doInternal(ctx, "POST", "/api/v1/_internal/users/"+url.PathEscape(name)+"/deactivate", nil, nil); err != nil {
+		return fmt.Errorf("failed to deactivate user: %w", err)
 	}
 
-	return response.Data, nil
+	return nil
 }
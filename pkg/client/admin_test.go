@@ -0,0 +1,121 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("API keys", func() {
+	var (
+		server  *httptest.Server
+		request *http.Request
+		reply   string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			_, _ = w.Write([]byte(reply))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should list keys from the response envelope", func() {
+		reply = `{"success":true,"data":[{"name":"alice","role":"user","created_by":"admin","last_used_at":"2026-10-01T12:00:00Z"},{"name":"ci","role":"deploy"}]}`
+
+		keys, err := client.NewClient(server.URL, "key").ListAPIKeys(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(request.Method).To(Equal(http.MethodGet))
+		Expect(request.URL.Path).To(Equal("/api/v1/_internal/api-keys"))
+		Expect(keys).To(HaveLen(2))
+		Expect(keys[0].CreatedBy).To(Equal("admin"))
+		Expect(keys[0].LastUsedAt).NotTo(BeNil())
+		Expect(keys[1].LastUsedAt).To(BeNil())
+	})
+
+	It("should revoke a key", func() {
+		reply = `{"success":true,"message":"revoked"}`
+
+		Expect(client.NewClient(server.URL, "key").RevokeAPIKey(context.Background(), "alice")).To(Succeed())
+		Expect(request.Method).To(Equal(http.MethodDelete))
+		Expect(request.URL.Path).To(Equal("/api/v1/_internal/api-keys/alice"))
+	})
+
+	It("should return the new secret of a rotated key", func() {
+		reply = `{"success":true,"data":{"api_key":"new-secret","name":"alice","role":"user"}}`
+
+		result, err := client.NewClient(server.URL, "key").RotateAPIKey(context.Background(), "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(request.URL.Path).To(Equal("/api/v1/_internal/api-keys/alice/rotate"))
+		Expect(result.APIKey).To(Equal("new-secret"))
+	})
+
+	It("should report unsuccessful responses", func() {
+		reply = `{"success":false,"message":"API key 'bob' not found"}`
+
+		err := client.NewClient(server.URL, "key").RevokeAPIKey(context.Background(), "bob")
+		Expect(err).To(MatchError(ContainSubstring("API key 'bob' not found")))
+	})
+})
+
+var _ = Describe("Users", func() {
+	var (
+		server  *httptest.Server
+		request *http.Request
+		body    map[string]string
+		reply   string
+	)
+
+	BeforeEach(func() {
+		body = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_, _ = w.Write([]byte(reply))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should list users", func() {
+		reply = `{"success":true,"data":[{"name":"alice","role":"admin","active":true,"api_keys":["alice","alice-laptop"]}]}`
+
+		users, err := client.NewClient(server.URL, "key").ListUsers(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(request.URL.Path).To(Equal("/api/v1/_internal/users"))
+		Expect(users).To(HaveLen(1))
+		Expect(users[0].APIKeys).To(ConsistOf("alice", "alice-laptop"))
+		Expect(users[0].Active).To(BeTrue())
+	})
+
+	It("should set a user's role", func() {
+		reply = `{"success":true,"data":{"name":"bob","role":"deploy","active":true}}`
+
+		user, err := client.NewClient(server.URL, "key").SetUserRole(context.Background(), "bob", "deploy")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(request.Method).To(Equal(http.MethodPut))
+		Expect(request.URL.Path).To(Equal("/api/v1/_internal/users/bob/role"))
+		Expect(body).To(Equal(map[string]string{"role": "deploy"}))
+		Expect(user.Role).To(Equal("deploy"))
+	})
+
+	It("should deactivate a user", func() {
+		reply = `{"success":true}`
+
+		Expect(client.NewClient(server.URL, "key").DeactivateUser(context.Background(), "bob")).To(Succeed())
+		Expect(request.Method).To(Equal(http.MethodPost))
+		Expect(request.URL.Path).To(Equal("/api/v1/_internal/users/bob/deactivate"))
+	})
+})
@@ -1,7 +1,15 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/cache"
+	"golang.org/x/sync/errgroup"
 )
 
 // ServiceMetadata represents service metadata from the API
@@ -15,6 +23,8 @@ type BlueprintResponse struct {
 	ID      string          `json:"id"`      // Scoped identifier
 	Title   string          `json:"title"`   // Title from annotations
 	Content ServiceMetadata `json:"content"` // Service metadata
+	// Repository is the normalized repository URL, if the API reports it
+	Repository string `json:"repository,omitempty"`
 }
 
 // DetailedMetadata represents normalized k8s object metadata
@@ -37,7 +47,7 @@ type BlueprintDetailedResponse struct {
 }
 
 // ListBlueprints lists all blueprints (user and optionally global)
-func (c *Client) ListBlueprints(includeGlobal bool) ([]BlueprintResponse, error) {
+func (c *Client) ListBlueprints(ctx context.Context, includeGlobal bool) ([]BlueprintResponse, error) {
 	var blueprints []BlueprintResponse
 
 	path := "/api/v1/blueprints"
@@ -45,7 +55,7 @@ func (c *Client) ListBlueprints(includeGlobal bool) ([]BlueprintResponse, error)
 		path += "?global=true"
 	}
 
-	if err := c.Do("GET", path, nil, &blueprints); err != nil {
+	if err := c.Do(ctx, "GET", path, nil, &blueprints); err != nil {
 		return nil, fmt.Errorf("failed to list blueprints: %w", err)
 	}
 
@@ -53,12 +63,12 @@ func (c *Client) ListBlueprints(includeGlobal bool) ([]BlueprintResponse, error)
 }
 
 // GetBlueprint gets a specific blueprint by name
-func (c *Client) GetBlueprint(name string) (*BlueprintResponse, error) {
+func (c *Client) GetBlueprint(ctx context.Context, name string) (*BlueprintResponse, error) {
 	var blueprint BlueprintResponse
 
 	path := fmt.Sprintf("/api/v1/blueprints/%s", name)
 
-	if err := c.Do("GET", path, nil, &blueprint); err != nil {
+	if err := c.Do(ctx, "GET", path, nil, &blueprint); err != nil {
 		return nil, fmt.Errorf("failed to get blueprint: %w", err)
 	}
 
@@ -66,12 +76,12 @@ func (c *Client) GetBlueprint(name string) (*BlueprintResponse, error) {
 }
 
 // GetBlueprintDetailed gets the complete blueprint object including all annotations
-func (c *Client) GetBlueprintDetailed(name string) (*BlueprintDetailedResponse, error) {
+func (c *Client) GetBlueprintDetailed(ctx context.Context, name string) (*BlueprintDetailedResponse, error) {
 	var blueprint BlueprintDetailedResponse
 
 	path := fmt.Sprintf("/api/v1/blueprints/%s?format=detailed", name)
 
-	if err := c.Do("GET", path, nil, &blueprint); err != nil {
+	if err := c.Do(ctx, "GET", path, nil, &blueprint); err != nil {
 		return nil, fmt.Errorf("failed to get blueprint details: %w", err)
 	}
 
@@ -87,7 +97,7 @@ type CreateBlueprintRequest struct {
 }
 
 // CreateBlueprint creates a new blueprint
-func (c *Client) CreateBlueprint(req CreateBlueprintRequest) (string, error) {
+func (c *Client) CreateBlueprint(ctx context.Context, req CreateBlueprintRequest) (string, error) {
 	reqBody := map[string]interface{}{
 		"compose": req.Compose,
 	}
@@ -104,7 +114,7 @@ func (c *Client) CreateBlueprint(req CreateBlueprintRequest) (string, error) {
 	}
 
 	var identifier string
-	if err := c.Do("POST", "/api/v1/blueprints", reqBody, &identifier); err != nil {
+	if err := c.Do(ctx, "POST", "/api/v1/blueprints", reqBody, &identifier); err != nil {
 		return "", fmt.Errorf("failed to create blueprint: %w", err)
 	}
 
@@ -112,34 +122,42 @@ func (c *Client) CreateBlueprint(req CreateBlueprintRequest) (string, error) {
 }
 
 // DeleteBlueprint deletes a blueprint
-func (c *Client) DeleteBlueprint(name string) error {
+func (c *Client) DeleteBlueprint(ctx context.Context, name string) error {
 	path := fmt.Sprintf("/api/v1/blueprints/%s", name)
 
-	if err := c.Do("DELETE", path, nil, nil); err != nil {
+	if err := c.Do(ctx, "DELETE", path, nil, nil); err != nil {
 		return fmt.Errorf("failed to delete blueprint: %w", err)
 	}
 
 	return nil
 }
 
+// blueprintLookupConcurrency bounds the detailed blueprint lookups in flight
+// in FindBlueprintsByRepository
+const blueprintLookupConcurrency = 8
+
+// blueprintRepositoryTTL is how long a blueprint's repository is cached.
+// Blueprints are immutable, so it only expires to bound the cache size.
+const blueprintRepositoryTTL = 30 * 24 * time.Hour
+
 // FindBlueprintsByRepository finds all blueprints matching a normalized repository URL
 // Returns blueprints sorted by ID descending (newest first)
-func (c *Client) FindBlueprintsByRepository(normalizedRepo string) ([]BlueprintResponse, error) {
-	allBlueprints, err := c.ListBlueprints(true)
-	if err != nil {
-		return nil, err
+//
+// The repository is passed as a filter to APIs that support it. Blueprints
+// listed without their repository are looked up concurrently, and their
+// repository annotation is cached since blueprints don't change.
+func (c *Client) FindBlueprintsByRepository(ctx context.Context, normalizedRepo string) ([]BlueprintResponse, error) {
+	var allBlueprints []BlueprintResponse
+	path := "/api/v1/blueprints?global=true&repository=" + url.QueryEscape(normalizedRepo)
+	if err := c.Do(ctx, "GET", path, nil, &allBlueprints); err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %w", err)
 	}
 
+	c.fillRepositories(ctx, allBlueprints)
+
 	var matching []BlueprintResponse
 	for _, bp := range allBlueprints {
-		// Get detailed info to access repository annotation
-		detailed, err := c.GetBlueprintDetailed(bp.ID)
-		if err != nil {
-			continue // Skip if can't get details
-		}
-
-		// Check repository annotation
-		if repo, ok := detailed.Metadata.Annotations["lissto.dev/repository"]; ok && repo == normalizedRepo {
+		if bp.Repository == normalizedRepo {
 			matching = append(matching, bp)
 		}
 	}
@@ -147,13 +165,45 @@ func (c *Client) FindBlueprintsByRepository(normalizedRepo string) ([]BlueprintR
 	// Sort by ID descending (newest first)
 	// Blueprint IDs have format: scope/YYYYMMDD-HHMMSS-hash
 	// Lexicographic sort works due to timestamp format
-	for i := 0; i < len(matching)-1; i++ {
-		for j := i + 1; j < len(matching); j++ {
-			if matching[i].ID < matching[j].ID {
-				matching[i], matching[j] = matching[j], matching[i]
-			}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].ID > matching[j].ID
+	})
+
+	return matching, nil
+}
+
+// fillRepositories sets the repository of blueprints listed without it,
+// looking them up concurrently. Blueprints whose details can't be fetched
+// are left without one.
+func (c *Client) fillRepositories(ctx context.Context, blueprints []BlueprintResponse) {
+	repoCache, _ := cache.Default()
+	g := new(errgroup.Group)
+	g.SetLimit(blueprintLookupConcurrency)
+	for i := range blueprints {
+		if blueprints[i].Repository != "" {
+			continue
 		}
+		g.Go(func() error {
+			blueprints[i].Repository, _ = c.blueprintRepository(ctx, repoCache, blueprints[i].ID)
+			return nil
+		})
 	}
+	_ = g.Wait()
+}
 
-	return matching, nil
+// blueprintRepository returns the repository annotation of a blueprint,
+// from repoCache when it was looked up before. repoCache may be nil.
+func (c *Client) blueprintRepository(ctx context.Context, repoCache *cache.Cache, id string) (string, error) {
+	fetch := func() (string, error) {
+		detailed, err := c.GetBlueprintDetailed(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return detailed.Metadata.Annotations["lissto.dev/repository"], nil
+	}
+	if repoCache == nil {
+		return fetch()
+	}
+	key := "blueprint-repo-" + strings.ReplaceAll(id, "/", "_")
+	return cache.GetOrFetch(repoCache, key, blueprintRepositoryTTL, false, fetch)
 }
@@ -0,0 +1,77 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("FindBlueprintsByRepository", func() {
+	var (
+		server        *httptest.Server
+		detailedCalls atomic.Int32
+	)
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CACHE_HOME", GinkgoT().TempDir())
+		detailedCalls.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			if r.URL.Path == "/api/v1/blueprints" {
+				Expect(r.URL.Query().Get("repository")).To(Equal("github.com/org/app"))
+				_, _ = w.Write([]byte(`[
+					{"id":"dev/20260101-000000-aaa"},
+					{"id":"dev/20260301-000000-ccc","repository":"github.com/org/app"},
+					{"id":"dev/20260201-000000-bbb"},
+					{"id":"dev/20260401-000000-ddd"}
+				]`))
+				return
+			}
+			detailedCalls.Add(1)
+			repo := "github.com/org/app"
+			if strings.HasSuffix(r.URL.Path, "bbb") {
+				repo = "github.com/org/other"
+			}
+			if strings.HasSuffix(r.URL.Path, "ddd") {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"metadata":{"annotations":{"lissto.dev/repository":%q}}}`, repo)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should return matching blueprints newest first", func() {
+		blueprints, err := client.NewClient(server.URL, "key").FindBlueprintsByRepository(context.Background(), "github.com/org/app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blueprints).To(HaveLen(2))
+		Expect(blueprints[0].ID).To(Equal("dev/20260301-000000-ccc"))
+		Expect(blueprints[1].ID).To(Equal("dev/20260101-000000-aaa"))
+		// Blueprints listed with their repository aren't looked up
+		Expect(detailedCalls.Load()).To(BeEquivalentTo(3))
+	})
+
+	It("should cache the repositories of looked up blueprints", func() {
+		c := client.NewClient(server.URL, "key")
+		_, err := c.FindBlueprintsByRepository(context.Background(), "github.com/org/app")
+		Expect(err).NotTo(HaveOccurred())
+		detailedCalls.Store(0)
+
+		blueprints, err := c.FindBlueprintsByRepository(context.Background(), "github.com/org/app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blueprints).To(HaveLen(2))
+		// Only the failed lookup is retried
+		Expect(detailedCalls.Load()).To(BeEquivalentTo(1))
+	})
+})
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	"github.com/lissto-dev/cli/pkg/config"
 	"github.com/lissto-dev/cli/pkg/k8s"
@@ -17,18 +16,19 @@ import (
 type Client struct {
 	baseURL       string
 	apiKey        string
+	token         string // Access token used instead of apiKey, see SetToken
 	httpClient    *http.Client
 	expectedAPIID string // Expected API instance ID for verification
+	impersonate   string // User requests are made as, see SetImpersonation
 }
 
 // NewClient creates a new API client
 func NewClient(apiURL, apiKey string) *Client {
 	return &Client{
-		baseURL: apiURL,
-		apiKey:  apiKey,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL:     apiURL,
+		apiKey:      apiKey,
+		httpClient:  newHTTPClient(),
+		impersonate: impersonateUser,
 	}
 }
 
@@ -38,82 +38,163 @@ func NewClientWithAPIID(apiURL, apiKey, apiID string) *Client {
 		baseURL:       apiURL,
 		apiKey:        apiKey,
 		expectedAPIID: apiID,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		httpClient:    newHTTPClient(),
+		impersonate:   impersonateUser,
+	}
+}
+
+// NewClientWithTunnel creates an API client whose requests go through a
+// port-forward tunnel, with API ID verification
+func NewClientWithTunnel(tunnel *Tunnel, apiKey, apiID string) *Client {
+	return &Client{
+		// The host is replaced by the tunnel's current forward
+		baseURL:       "http://lissto-api",
+		apiKey:        apiKey,
+		expectedAPIID: apiID,
+		httpClient: newHTTPClientWith(func(base http.RoundTripper) http.RoundTripper {
+			return &tunnelTransport{tunnel: tunnel, base: base}
+		}),
+		impersonate: impersonateUser,
 	}
 }
 
 // NewClientFromConfig creates an API client from a saved context
-// It validates the k8s context and discovers the API endpoint with caching and retry logic
-func NewClientFromConfig(ctx *config.Context) (*Client, error) {
+// It validates the k8s context and discovers the API endpoint with caching and retry logic.
+// Without a public URL, requests go through a running 'lissto daemon' or a
+// port-forward tunnel shared by all clients of the context in this process,
+// see CloseTunnels.
+func NewClientFromConfig(ctx context.Context, lisstoCtx *config.Context) (*Client, error) {
 	// Validate k8s context (fail if different to prevent accidental operations)
-	if err := config.ValidateAndFail(ctx); err != nil {
+	if err := config.ValidateAndFail(lisstoCtx); err != nil {
+		return nil, err
+	}
+
+	return NewClientForContext(ctx, lisstoCtx)
+}
+
+// NewClientForContext creates an API client for a context selected
+// explicitly (--context, --all-contexts). Discovery uses the context's own
+// kube context, so kubectl's current context doesn't have to match.
+func NewClientForContext(ctx context.Context, lisstoCtx *config.Context) (*Client, error) {
+	client, err := newClientForContext(ctx, lisstoCtx)
+	if err != nil {
 		return nil, err
 	}
+	if lisstoCtx.AccessToken != "" {
+		if err := client.UseContextToken(ctx, lisstoCtx); err != nil {
+			return nil, err
+		}
+	}
+	RecordContextUse(lisstoCtx.Name)
+	return client, nil
+}
 
+// newClientForContext discovers the API of a context and creates a client
+// authenticated with its API key
+func newClientForContext(ctx context.Context, lisstoCtx *config.Context) (*Client, error) {
 	// Check if we have a cached API URL and ID
-	if ctx.APIUrl != "" && ctx.APIID != "" {
+	if lisstoCtx.APIUrl != "" && lisstoCtx.APIID != "" {
 		// Try to use cached URL with ID verification
-		client := NewClientWithAPIID(ctx.APIUrl, ctx.APIKey, ctx.APIID)
+		client := NewClientWithAPIID(lisstoCtx.APIUrl, lisstoCtx.APIKey, lisstoCtx.APIID)
 
 		// Test the connection by calling a simple endpoint
-		if err := client.testConnection(); err == nil {
+		if err := client.testConnection(ctx); err == nil {
 			// Cached URL works and API ID matches
 			return client, nil
 		}
 		// If connection fails or API ID mismatches, we'll re-discover below
 	}
 
+	// In the cluster, reach the API through its service, without port-forward
+	if lisstoCtx.KubeContext == k8s.InClusterContext {
+		return newInClusterClient(ctx, lisstoCtx)
+	}
+
+	// Reuse the tunnel of an earlier client of this context
+	key := tunnelKey(lisstoCtx)
+	if shared, ok := lookupTunnel(key); ok {
+		return NewClientWithTunnel(shared.tunnel, lisstoCtx.APIKey, shared.apiID), nil
+	}
+
+	// Let a running daemon discover the API and hold the port-forward
+	if client, discovery, ok := daemonClient(ctx, lisstoCtx); ok {
+		if discovery.APIID != lisstoCtx.APIID || discovery.PublicURL != lisstoCtx.APIUrl {
+			lisstoCtx.APIID, lisstoCtx.APIUrl = discovery.APIID, discovery.PublicURL
+			_ = config.UpdateConfig(func(cfg *config.Config) error {
+				cfg.AddOrUpdateContext(*lisstoCtx)
+				return nil
+			})
+		}
+		return client, nil
+	}
+
 	// Need to discover the API endpoint (either no cache or cache failed)
-	k8sClient, err := k8s.NewClientWithContext(ctx.KubeContext)
+	k8sClient, err := k8s.NewClientWithContext(lisstoCtx.Kubeconfig, lisstoCtx.KubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create k8s client: %w", err)
 	}
 
 	// Use fast discovery to get public URL and API ID (opens port-forward once)
 	discoveryInfo, err := k8sClient.DiscoverAPIEndpointFast(
-		context.Background(),
-		ctx.ServiceName,
-		ctx.ServiceNamespace,
+		ctx,
+		lisstoCtx.ServiceName,
+		lisstoCtx.ServiceNamespace,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover API endpoint: %w", err)
 	}
 
 	// Update context with discovered information
-	ctx.APIID = discoveryInfo.APIID
-	ctx.APIUrl = discoveryInfo.PublicURL // Cache public URL (empty if not available)
+	lisstoCtx.APIID = discoveryInfo.APIID
+	lisstoCtx.APIUrl = discoveryInfo.PublicURL // Cache public URL (empty if not available)
+
+	// Save the updated context, ignoring save errors
+	_ = config.UpdateConfig(func(cfg *config.Config) error {
+		cfg.AddOrUpdateContext(*lisstoCtx)
+		return nil
+	})
 
-	// Save the updated context
-	cfg, err := config.LoadConfig()
-	if err == nil {
-		cfg.AddOrUpdateContext(*ctx)
-		_ = config.SaveConfig(cfg) // Ignore save errors
+	if discoveryInfo.PublicURL != "" {
+		return NewClientWithAPIID(discoveryInfo.PublicURL, lisstoCtx.APIKey, lisstoCtx.APIID), nil
 	}
 
-	// Use public URL if available, otherwise use the port-forward URL we already established
-	apiURL := discoveryInfo.PublicURL
-	if apiURL == "" {
-		apiURL = discoveryInfo.PortForwardURL
+	// No public URL: keep the discovery port-forward as a shared tunnel
+	tunnel, err := NewTunnel(discoveryInfo.PortForwardURL, discoveryInfo.StopPortForward, func(ctx context.Context) (string, func(), error) {
+		return k8sClient.SetupPortForward(ctx, lisstoCtx.ServiceName, lisstoCtx.ServiceNamespace, tunnelLocalPort)
+	})
+	if err != nil {
+		discoveryInfo.StopPortForward()
+		return nil, err
 	}
+	shared := registerTunnel(key, tunnel, lisstoCtx.APIID)
+	return NewClientWithTunnel(shared.tunnel, lisstoCtx.APIKey, shared.apiID), nil
+}
 
-	// Create client with API ID verification
-	client := NewClientWithAPIID(apiURL, ctx.APIKey, ctx.APIID)
+// newInClusterClient discovers the API of an in-cluster context through the
+// cluster DNS name of its service, which is cached as the context's API URL
+func newInClusterClient(ctx context.Context, lisstoCtx *config.Context) (*Client, error) {
+	k8sClient, err := k8s.NewClientWithContext("", k8s.InClusterContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-cluster k8s client: %w", err)
+	}
+	discoveryInfo, err := k8sClient.DiscoverAPIEndpointInCluster(ctx, lisstoCtx.ServiceName, lisstoCtx.ServiceNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover API endpoint: %w", err)
+	}
 
-	// Wrap the client to add retry logic for API ID mismatches
-	return &Client{
-		baseURL:       client.baseURL,
-		apiKey:        client.apiKey,
-		expectedAPIID: client.expectedAPIID,
-		httpClient:    client.httpClient,
-	}, nil
+	lisstoCtx.APIID = discoveryInfo.APIID
+	lisstoCtx.APIUrl = discoveryInfo.ClusterURL
+	_ = config.UpdateConfig(func(cfg *config.Config) error {
+		cfg.AddOrUpdateContext(*lisstoCtx)
+		return nil
+	})
+	return NewClientWithAPIID(discoveryInfo.ClusterURL, lisstoCtx.APIKey, lisstoCtx.APIID), nil
 }
 
 // testConnection tests if the API is reachable and API ID matches
-func (c *Client) testConnection() error {
-	// Try to call /health endpoint
-	req, err := http.NewRequest("GET", c.baseURL+"/health", nil)
+func (c *Client) testConnection(ctx context.Context) error {
+	// Try to call /health endpoint, once: a stale cached URL falls back to discovery
+	req, err := http.NewRequestWithContext(withoutRetry(ctx), "GET", c.baseURL+"/health", nil)
 	if err != nil {
 		return err
 	}
@@ -140,7 +221,7 @@ func (c *Client) testConnection() error {
 }
 
 // Do performs an HTTP request with authentication
-func (c *Client) Do(method, path string, body, result interface{}) error {
+func (c *Client) Do(ctx context.Context, method, path string, body, result interface{}) error {
 	var reqBody io.Reader
 	if body != nil {
 		jsonData, err := json.Marshal(body)
@@ -151,12 +232,19 @@ func (c *Client) Do(method, path string, body, result interface{}) error {
 	}
 
 	url := c.baseURL + path
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("X-API-Key", c.apiKey)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	if c.impersonate != "" {
+		req.Header.Set(ImpersonateHeader, c.impersonate)
+	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
@@ -184,9 +272,13 @@ func (c *Client) Do(method, path string, body, result interface{}) error {
 		// Try to parse error response
 		var apiErr APIError
 		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.ErrorMessage != "" {
+			apiErr.StatusCode = resp.StatusCode
 			return &apiErr
 		}
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+		return &APIError{
+			ErrorMessage: fmt.Sprintf("API request failed with status %d: %s", resp.StatusCode, string(respBody)),
+			StatusCode:   resp.StatusCode,
+		}
 	}
 
 	if result != nil && len(respBody) > 0 {
@@ -209,6 +301,11 @@ func (c *Client) Do(method, path string, body, result interface{}) error {
 type APIError struct {
 	Success      bool   `json:"success"`
 	ErrorMessage string `json:"error"`
+	// Code identifies the error for programs, e.g. ErrorCodeRequestExpired
+	Code string `json:"code,omitempty"`
+	// Field is the request field the error is about, e.g. "request_id"
+	Field      string `json:"field,omitempty"`
+	StatusCode int    `json:"-"`
 }
 
 func (e *APIError) Error() string {
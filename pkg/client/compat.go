@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Range of Lissto API/controller versions this CLI release understands
+// (minimum inclusive, maximum exclusive)
+const (
+	MinSupportedAPIVersion = "0.1.0-0"
+	MaxSupportedAPIVersion = "0.2.0-0"
+)
+
+// SupportedAPIVersions is the supported range as a semver constraint
+var SupportedAPIVersions = fmt.Sprintf(">= %s, < %s", MinSupportedAPIVersion, MaxSupportedAPIVersion)
+
+// CompatibilityStatus describes how the server version relates to the CLI
+type CompatibilityStatus string
+
+const (
+	CompatibilityOK      CompatibilityStatus = "compatible"
+	CompatibilityUnknown CompatibilityStatus = "unknown"
+	CompatibilityTooOld  CompatibilityStatus = "server-too-old"
+	CompatibilityTooNew  CompatibilityStatus = "server-too-new"
+)
+
+// Compatibility is the result of comparing the server version against the CLI
+type Compatibility struct {
+	Status        CompatibilityStatus
+	ServerVersion string
+	Supported     string
+}
+
+// Compatible returns true unless the server is known to be out of range
+func (c *Compatibility) Compatible() bool {
+	return c.Status == CompatibilityOK || c.Status == CompatibilityUnknown
+}
+
+// Advice returns upgrade instructions for an incompatible server
+func (c *Compatibility) Advice() string {
+	switch c.Status {
+	case CompatibilityTooOld:
+		return fmt.Sprintf("Lissto API %s is older than this CLI supports (%s). Upgrade the Lissto controller and API in the cluster, or install an older CLI release.", c.ServerVersion, c.Supported)
+	case CompatibilityTooNew:
+		return fmt.Sprintf("Lissto API %s is newer than this CLI supports (%s). Upgrade the CLI: brew upgrade lissto-dev/tap/lissto (or download from https://github.com/lissto-dev/cli/releases).", c.ServerVersion, c.Supported)
+	}
+	return ""
+}
+
+// CheckCompatibility compares a reported server version against SupportedAPIVersions.
+// Servers that don't report a version (or report a non-semver one like "dev") are Unknown.
+func CheckCompatibility(serverVersion string) *Compatibility {
+	result := &Compatibility{
+		Status:        CompatibilityUnknown,
+		ServerVersion: serverVersion,
+		Supported:     SupportedAPIVersions,
+	}
+	if serverVersion == "" {
+		return result
+	}
+
+	v, err := semver.NewVersion(serverVersion)
+	if err != nil {
+		return result
+	}
+
+	switch {
+	case v.LessThan(semver.MustParse(MinSupportedAPIVersion)):
+		result.Status = CompatibilityTooOld
+	case !v.LessThan(semver.MustParse(MaxSupportedAPIVersion)):
+		result.Status = CompatibilityTooNew
+	default:
+		result.Status = CompatibilityOK
+	}
+	return result
+}
+
+// CheckServerCompatibility fetches the server version and checks it
+func (c *Client) CheckServerCompatibility(ctx context.Context) (*Compatibility, error) {
+	info, err := c.GetAPIInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return CheckCompatibility(info.Version), nil
+}
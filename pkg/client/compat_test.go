@@ -0,0 +1,34 @@
+package client_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("Compatibility", func() {
+	DescribeTable("CheckCompatibility",
+		func(version string, status client.CompatibilityStatus) {
+			compat := client.CheckCompatibility(version)
+			Expect(compat.Status).To(Equal(status))
+			Expect(compat.ServerVersion).To(Equal(version))
+			Expect(compat.Compatible()).To(Equal(status == client.CompatibilityOK || status == client.CompatibilityUnknown))
+		},
+		Entry("no version", "", client.CompatibilityUnknown),
+		Entry("development build", "dev", client.CompatibilityUnknown),
+		Entry("minimum version", "0.1.0", client.CompatibilityOK),
+		Entry("pre-release of the minimum version", "0.1.0-rc.1", client.CompatibilityOK),
+		Entry("v prefix", "v0.1.7", client.CompatibilityOK),
+		Entry("below the minimum", "0.0.9", client.CompatibilityTooOld),
+		Entry("exactly the maximum version", "0.2.0", client.CompatibilityTooNew),
+		Entry("pre-release of the maximum version", "0.2.0-rc.1", client.CompatibilityTooNew),
+		Entry("above the maximum", "1.0.0", client.CompatibilityTooNew),
+	)
+
+	It("should only advise on incompatible versions", func() {
+		Expect(client.CheckCompatibility("0.1.0").Advice()).To(BeEmpty())
+		Expect(client.CheckCompatibility("0.0.9").Advice()).To(ContainSubstring("older than this CLI supports"))
+		Expect(client.CheckCompatibility("0.2.0").Advice()).To(ContainSubstring("Upgrade the CLI"))
+	})
+})
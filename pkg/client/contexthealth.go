@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/cache"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+)
+
+// Connection modes of a context
+const (
+	ModePublic      = "public"
+	ModePortForward = "port-forward"
+	ModeInCluster   = "in-cluster"
+)
+
+// contextUseKey is the cache entry holding when each context was last used
+const contextUseKey = "context-use"
+
+// contextUseTTL keeps last-used times around long after they are written
+const contextUseTTL = 10 * 365 * 24 * time.Hour
+
+// contextUseInterval throttles how often a context's use is recorded
+const contextUseInterval = time.Minute
+
+// ContextHealth is the result of probing the API of a context
+type ContextHealth struct {
+	Context   string `json:"context" yaml:"context"`
+	Reachable bool   `json:"reachable" yaml:"reachable"`
+	// Mode is how the API is reached: public, port-forward or in-cluster
+	Mode    string `json:"mode,omitempty" yaml:"mode,omitempty"`
+	URL     string `json:"url,omitempty" yaml:"url,omitempty"`
+	APIID   string `json:"apiId,omitempty" yaml:"apiId,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	// LatencyMS is how long the probe took, in milliseconds
+	LatencyMS int64  `json:"latencyMs,omitempty" yaml:"latencyMs,omitempty"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// portForwardProbes serializes probes that port-forward, which share the
+// discovery port
+var portForwardProbes sync.Mutex
+
+// ProbeContext checks within timeout whether the API of a context answers.
+// Contexts with a cached URL are probed directly, others through a
+// port-forward set up with the context's kube context, or the service DNS
+// name for in-cluster contexts.
+func ProbeContext(ctx context.Context, lisstoCtx *config.Context, timeout time.Duration) ContextHealth {
+	health := ContextHealth{Context: lisstoCtx.Name, Mode: ModePublic, URL: lisstoCtx.APIUrl}
+	switch {
+	case lisstoCtx.KubeContext == k8s.InClusterContext:
+		health.Mode = ModeInCluster
+	case lisstoCtx.APIUrl == "":
+		health.Mode = ModePortForward
+		// The timeout starts once the probe holds the port
+		portForwardProbes.Lock()
+		defer portForwardProbes.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	if health.URL == "" {
+		k8sClient, err := k8s.NewClientWithContext(lisstoCtx.Kubeconfig, lisstoCtx.KubeContext)
+		if err != nil {
+			health.Error = fmt.Sprintf("kube context %s: %v", lisstoCtx.KubeContext, err)
+			return health
+		}
+		discover := k8sClient.DiscoverAPIEndpointFast
+		if health.Mode == ModeInCluster {
+			discover = k8sClient.DiscoverAPIEndpointInCluster
+		}
+		discovery, err := discover(ctx, lisstoCtx.ServiceName, lisstoCtx.ServiceNamespace)
+		if err != nil {
+			health.Error = err.Error()
+			return health
+		}
+		if discovery.StopPortForward != nil {
+			defer discovery.StopPortForward()
+		}
+		health.URL = discovery.URL()
+		if health.Mode == ModePortForward && discovery.PublicURL != "" {
+			health.Mode = ModePublic
+		}
+	}
+
+	info, err := ProbeAPI(ctx, health.URL, lisstoCtx.APIID)
+	health.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.Reachable, health.APIID, health.Version = true, info.APIID, info.Version
+	return health
+}
+
+// ProbeContexts probes every context, concurrently except for the
+// port-forwarded ones. Results are in the order of contexts.
+func ProbeContexts(ctx context.Context, contexts []config.Context, timeout time.Duration) []ContextHealth {
+	results := make([]ContextHealth, len(contexts))
+	var wg sync.WaitGroup
+	for i := range contexts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = ProbeContext(ctx, &contexts[i], timeout)
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// ProbeAPI fetches the info of the API at url, once, and checks it is the
+// instance expectedAPIID identifies (when set)
+func ProbeAPI(ctx context.Context, url, expectedAPIID string) (*APIInfo, error) {
+	info, err := NewClient(url, "").GetAPIInfo(withoutRetry(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if expectedAPIID != "" && info.APIID != "" && info.APIID != expectedAPIID {
+		return nil, fmt.Errorf("API instance ID mismatch: expected %s, got %s", expectedAPIID, info.APIID)
+	}
+	return info, nil
+}
+
+// RecordContextUse remembers that a context was used now. Uses within a
+// minute of the recorded one are not written again.
+func RecordContextUse(name string) {
+	c, err := cache.Default()
+	if err != nil {
+		return
+	}
+	used := ContextLastUsed()
+	now := time.Now()
+	if last, ok := used[name]; ok && now.Sub(last) < contextUseInterval {
+		return
+	}
+	used[name] = now
+	// Last-used times are informational; failing to save them is ignored
+	_ = c.Set(contextUseKey, used, contextUseTTL)
+}
+
+// ContextLastUsed returns when each context was last used, by name
+func ContextLastUsed() map[string]time.Time {
+	used := make(map[string]time.Time)
+	if c, err := cache.Default(); err == nil {
+		_, _ = c.Get(contextUseKey, &used)
+	}
+	return used
+}
@@ -0,0 +1,84 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+)
+
+var _ = Describe("Context health", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/health":
+				_, _ = w.Write([]byte(`{"public_url":"https://lissto.example","api_id":"api-1","version":"v1.4.0"}`))
+			case "/slow/health":
+				time.Sleep(500 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	It("should report a reachable public API with its version", func() {
+		health := client.ProbeContext(context.Background(), &config.Context{Name: "prod", APIUrl: server.URL, APIID: "api-1"}, time.Second)
+		Expect(health.Reachable).To(BeTrue())
+		Expect(health.Mode).To(Equal(client.ModePublic))
+		Expect(health.Version).To(Equal("v1.4.0"))
+		Expect(health.APIID).To(Equal("api-1"))
+		Expect(health.Error).To(BeEmpty())
+	})
+
+	It("should report another API instance as unreachable", func() {
+		health := client.ProbeContext(context.Background(), &config.Context{Name: "prod", APIUrl: server.URL, APIID: "api-2"}, time.Second)
+		Expect(health.Reachable).To(BeFalse())
+		Expect(health.Error).To(ContainSubstring("API instance ID mismatch"))
+	})
+
+	It("should give up after the timeout", func() {
+		start := time.Now()
+		health := client.ProbeContext(context.Background(), &config.Context{Name: "slow", APIUrl: server.URL + "/slow"}, 50*time.Millisecond)
+		Expect(health.Reachable).To(BeFalse())
+		Expect(health.Error).NotTo(BeEmpty())
+		Expect(time.Since(start)).To(BeNumerically("<", 400*time.Millisecond))
+	})
+
+	It("should probe every context in order", func() {
+		contexts := []config.Context{
+			{Name: "up", APIUrl: server.URL},
+			{Name: "down", APIUrl: server.URL + "/missing"},
+		}
+		results := client.ProbeContexts(context.Background(), contexts, time.Second)
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Context).To(Equal("up"))
+		Expect(results[0].Reachable).To(BeTrue())
+		Expect(results[1].Context).To(Equal("down"))
+		Expect(results[1].Reachable).To(BeFalse())
+	})
+})
+
+var _ = Describe("Context use", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CACHE_HOME", GinkgoT().TempDir())
+	})
+
+	It("should remember when a context was last used", func() {
+		Expect(client.ContextLastUsed()).To(BeEmpty())
+		client.RecordContextUse("prod")
+		client.RecordContextUse("dev")
+		used := client.ContextLastUsed()
+		Expect(used).To(HaveLen(2))
+		Expect(used["prod"]).To(BeTemporally("~", time.Now(), time.Second))
+	})
+})
@@ -0,0 +1,543 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+)
+
+// DaemonEnv disables the daemon for an invocation when set to a false value
+const DaemonEnv = "LISSTO_DAEMON"
+
+// daemonSocketName is the daemon's unix socket in the cache directory
+const daemonSocketName = "daemon.sock"
+
+// daemonDialTimeout bounds detecting a running daemon, so commands without
+// one don't notice the check
+const daemonDialTimeout = 200 * time.Millisecond
+
+// daemonDiscoverTimeout bounds discovery through the daemon, which may open
+// a port-forward
+const daemonDiscoverTimeout = time.Minute
+
+// Headers naming the API a request to the daemon is for
+const (
+	daemonKubeconfigHeader  = "X-Lissto-Kubeconfig"
+	daemonKubeContextHeader = "X-Lissto-Kube-Context"
+	daemonNamespaceHeader   = "X-Lissto-Service-Namespace"
+	daemonServiceHeader     = "X-Lissto-Service-Name"
+)
+
+// Daemon endpoints; every other path is proxied to the API
+const (
+	daemonStatusPath   = "/_daemon/status"
+	daemonDiscoverPath = "/_daemon/discover"
+	daemonStopPath     = "/_daemon/stop"
+)
+
+// ErrDaemonRunning is returned when starting a daemon while another one
+// serves the socket
+var ErrDaemonRunning = errors.New("daemon is already running")
+
+// ErrDaemonNotRunning is returned when no daemon answers on the socket
+var ErrDaemonNotRunning = errors.New("daemon is not running")
+
+// DaemonSocketPath returns the path of the daemon's unix socket
+func DaemonSocketPath() (string, error) {
+	cacheDir, err := config.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, daemonSocketName), nil
+}
+
+// DaemonTarget identifies the API service a daemon connects to
+type DaemonTarget struct {
+	// Kubeconfig is the kubeconfig file of KubeContext, "" for the default
+	Kubeconfig  string `json:"kubeconfig,omitempty"`
+	KubeContext string `json:"kube_context"`
+	Namespace   string `json:"namespace"`
+	Service     string `json:"service"`
+}
+
+// daemonTargetOf returns the API service of a context
+func daemonTargetOf(lisstoCtx *config.Context) DaemonTarget {
+	return DaemonTarget{
+		Kubeconfig:  lisstoCtx.Kubeconfig,
+		KubeContext: lisstoCtx.KubeContext,
+		Namespace:   lisstoCtx.ServiceNamespace,
+		Service:     lisstoCtx.ServiceName,
+	}
+}
+
+func (t DaemonTarget) key() string {
+	return t.Kubeconfig + ":" + t.KubeContext + "/" + t.Namespace + "/" + t.Service
+}
+
+// setHeaders names t in the headers of a request to the daemon
+func (t DaemonTarget) setHeaders(h http.Header) {
+	if t.Kubeconfig != "" {
+		h.Set(daemonKubeconfigHeader, t.Kubeconfig)
+	}
+	h.Set(daemonKubeContextHeader, t.KubeContext)
+	h.Set(daemonNamespaceHeader, t.Namespace)
+	h.Set(daemonServiceHeader, t.Service)
+}
+
+// daemonTargetFrom reads the target named in a request's headers
+func daemonTargetFrom(h http.Header) (DaemonTarget, error) {
+	t := DaemonTarget{
+		Kubeconfig:  h.Get(daemonKubeconfigHeader),
+		KubeContext: h.Get(daemonKubeContextHeader),
+		Namespace:   h.Get(daemonNamespaceHeader),
+		Service:     h.Get(daemonServiceHeader),
+	}
+	if t.Namespace == "" || t.Service == "" {
+		return t, fmt.Errorf("missing %s or %s header", daemonNamespaceHeader, daemonServiceHeader)
+	}
+	return t, nil
+}
+
+// DaemonDiscovery is what the daemon found out about an API
+type DaemonDiscovery struct {
+	APIID     string `json:"api_id"`
+	PublicURL string `json:"public_url,omitempty"`
+}
+
+// DaemonAPIStatus describes an API the daemon is connected to
+type DaemonAPIStatus struct {
+	DaemonTarget
+	DaemonDiscovery
+	Tunnel bool `json:"tunnel"`
+}
+
+// DaemonStatus describes a running daemon
+type DaemonStatus struct {
+	PID     int               `json:"pid"`
+	Started time.Time         `json:"started"`
+	APIs    []DaemonAPIStatus `json:"apis"`
+}
+
+// DaemonDiscoverer finds the API of target: its public URL, or an open
+// port-forward to it and how to open another one
+type DaemonDiscoverer func(ctx context.Context, target DaemonTarget) (*k8s.APIDiscoveryInfo, TunnelOpener, error)
+
+// discoverWithK8s discovers an API through its kube context
+func discoverWithK8s(ctx context.Context, target DaemonTarget) (*k8s.APIDiscoveryInfo, TunnelOpener, error) {
+	k8sClient, err := k8s.NewClientWithContext(target.Kubeconfig, target.KubeContext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create k8s client: %w", err)
+	}
+	info, err := k8sClient.DiscoverAPIEndpointFast(ctx, target.Service, target.Namespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover API endpoint: %w", err)
+	}
+	open := func(ctx context.Context) (string, func(), error) {
+		return k8sClient.SetupPortForward(ctx, target.Service, target.Namespace, tunnelLocalPort)
+	}
+	return info, open, nil
+}
+
+// Daemon keeps API discovery results and port-forwards warm for the CLI
+// invocations on this machine. It serves a unix socket; requests naming an
+// API in their headers are proxied to it, through a Tunnel when the API has
+// no public URL.
+type Daemon struct {
+	discover DaemonDiscoverer
+	started  time.Time
+
+	mu   sync.Mutex
+	apis map[string]*daemonAPI
+
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+// daemonAPI is an API known to the daemon. ready is closed once discovery
+// finished, with err set if it failed.
+type daemonAPI struct {
+	target    DaemonTarget
+	discovery DaemonDiscovery
+	tunnel    *Tunnel
+	proxy     *httputil.ReverseProxy
+
+	ready chan struct{}
+	err   error
+}
+
+// NewDaemon creates a daemon discovering APIs with discover, or through
+// Kubernetes when it is nil
+func NewDaemon(discover DaemonDiscoverer) *Daemon {
+	if discover == nil {
+		discover = discoverWithK8s
+	}
+	return &Daemon{
+		discover: discover,
+		started:  time.Now(),
+		apis:     map[string]*daemonAPI{},
+		stopped:  make(chan struct{}),
+	}
+}
+
+// Serve serves the daemon on socket until ctx is cancelled or a stop request
+// arrives. A socket left behind by a daemon that died is replaced.
+func (d *Daemon) Serve(ctx context.Context, socket string) error {
+	if _, err := FetchDaemonStatus(ctx, socket); err == nil {
+		return ErrDaemonRunning
+	}
+	if err := os.MkdirAll(filepath.Dir(socket), 0755); err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	_ = os.Remove(socket)
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", socket, err)
+	}
+	// Only the user's own CLI may use the connections
+	if err := os.Chmod(socket, 0600); err != nil {
+		_ = listener.Close()
+		return fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return d.ServeListener(ctx, listener)
+}
+
+// ServeListener serves the daemon on listener until ctx is cancelled or a
+// stop request arrives, then closes the daemon's port-forwards
+func (d *Daemon) ServeListener(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{Handler: d.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-d.stopped:
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	err := server.Serve(listener)
+	d.close()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("daemon failed: %w", err)
+	}
+	return nil
+}
+
+// Handler returns the daemon's HTTP handler
+func (d *Daemon) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+daemonStatusPath, d.handleStatus)
+	mux.HandleFunc("POST "+daemonDiscoverPath, d.handleDiscover)
+	mux.HandleFunc("POST "+daemonStopPath, d.handleStop)
+	mux.HandleFunc("/", d.handleProxy)
+	return mux
+}
+
+// Stop makes Serve return
+func (d *Daemon) Stop() {
+	d.stopOnce.Do(func() { close(d.stopped) })
+}
+
+// close stops the daemon's port-forwards
+func (d *Daemon) close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key, api := range d.apis {
+		if api.tunnel != nil {
+			api.tunnel.Close()
+		}
+		delete(d.apis, key)
+	}
+}
+
+// api returns the API of target, discovering it on first use. Concurrent
+// requests for the same API share one discovery; failed ones are retried by
+// the next request.
+func (d *Daemon) api(ctx context.Context, target DaemonTarget) (*daemonAPI, error) {
+	key := target.key()
+	d.mu.Lock()
+	api, ok := d.apis[key]
+	if !ok {
+		api = &daemonAPI{target: target, ready: make(chan struct{})}
+		d.apis[key] = api
+	}
+	d.mu.Unlock()
+
+	if !ok {
+		// Finish discovery even if this request goes away, others may wait
+		api.err = d.connect(context.WithoutCancel(ctx), api)
+		if api.err != nil {
+			d.mu.Lock()
+			delete(d.apis, key)
+			d.mu.Unlock()
+		}
+		close(api.ready)
+	}
+
+	select {
+	case <-api.ready:
+		return api, api.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// connect discovers api and sets up its proxy
+func (d *Daemon) connect(ctx context.Context, api *daemonAPI) error {
+	ctx, cancel := context.WithTimeout(ctx, daemonDiscoverTimeout)
+	defer cancel()
+
+	info, open, err := d.discover(ctx, api.target)
+	if err != nil {
+		return err
+	}
+	api.discovery = DaemonDiscovery{APIID: info.APIID, PublicURL: info.PublicURL}
+
+	var target *url.URL
+	transport := http.DefaultTransport
+	if info.PublicURL != "" {
+		if target, err = url.Parse(info.PublicURL); err != nil {
+			return fmt.Errorf("invalid public URL: %w", err)
+		}
+	} else {
+		if api.tunnel, err = NewTunnel(info.PortForwardURL, info.StopPortForward, open); err != nil {
+			if info.StopPortForward != nil {
+				info.StopPortForward()
+			}
+			return err
+		}
+		// The host is replaced by the tunnel's current forward
+		target = &url.URL{Scheme: "http", Host: "lissto-api"}
+		transport = &tunnelTransport{tunnel: api.tunnel, base: http.DefaultTransport}
+	}
+
+	api.proxy = &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(target)
+			r.Out.Host = ""
+			r.Out.Header.Del(daemonKubeconfigHeader)
+			r.Out.Header.Del(daemonKubeContextHeader)
+			r.Out.Header.Del(daemonNamespaceHeader)
+			r.Out.Header.Del(daemonServiceHeader)
+		},
+		Transport: transport,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			writeDaemonError(w, http.StatusBadGateway, fmt.Errorf("daemon could not reach the API: %w", err))
+		},
+	}
+	return nil
+}
+
+func (d *Daemon) handleStatus(w http.ResponseWriter, r *http.Request) {
+	status := DaemonStatus{PID: os.Getpid(), Started: d.started, APIs: []DaemonAPIStatus{}}
+
+	d.mu.Lock()
+	for _, api := range d.apis {
+		select {
+		case <-api.ready:
+		default:
+			continue
+		}
+		if api.err != nil {
+			continue
+		}
+		status.APIs = append(status.APIs, DaemonAPIStatus{
+			DaemonTarget:    api.target,
+			DaemonDiscovery: api.discovery,
+			Tunnel:          api.tunnel != nil,
+		})
+	}
+	d.mu.Unlock()
+	sort.Slice(status.APIs, func(i, j int) bool { return status.APIs[i].key() < status.APIs[j].key() })
+
+	writeDaemonJSON(w, status)
+}
+
+func (d *Daemon) handleDiscover(w http.ResponseWriter, r *http.Request) {
+	target, err := daemonTargetFrom(r.Header)
+	if err != nil {
+		writeDaemonError(w, http.StatusBadRequest, err)
+		return
+	}
+	api, err := d.api(r.Context(), target)
+	if err != nil {
+		writeDaemonError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeDaemonJSON(w, api.discovery)
+}
+
+func (d *Daemon) handleStop(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+	d.Stop()
+}
+
+func (d *Daemon) handleProxy(w http.ResponseWriter, r *http.Request) {
+	target, err := daemonTargetFrom(r.Header)
+	if err != nil {
+		writeDaemonError(w, http.StatusBadRequest, err)
+		return
+	}
+	api, err := d.api(r.Context(), target)
+	if err != nil {
+		writeDaemonError(w, http.StatusBadGateway, err)
+		return
+	}
+	api.proxy.ServeHTTP(w, r)
+}
+
+func writeDaemonJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeDaemonError reports err in the API's error format
+func writeDaemonError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(APIError{ErrorMessage: err.Error()})
+}
+
+// daemonHTTPTransport connects to the daemon's socket, whatever the URL
+func daemonHTTPTransport(socket string) *http.Transport {
+	return &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			dialer := net.Dialer{Timeout: daemonDialTimeout}
+			return dialer.DialContext(ctx, "unix", socket)
+		},
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+// daemonCall makes a request to one of the daemon's own endpoints
+func daemonCall(ctx context.Context, socket, method, path string, target *DaemonTarget, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, "http://lissto-daemon"+path, nil)
+	if err != nil {
+		return err
+	}
+	if target != nil {
+		target.setHeaders(req.Header)
+	}
+	resp, err := (&http.Client{Transport: daemonHTTPTransport(socket)}).Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDaemonNotRunning, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		var apiErr APIError
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.ErrorMessage != "" {
+			return errors.New(apiErr.ErrorMessage)
+		}
+		return fmt.Errorf("daemon returned status %d", resp.StatusCode)
+	}
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return fmt.Errorf("failed to parse daemon response: %w", err)
+		}
+	}
+	return nil
+}
+
+// FetchDaemonStatus asks the daemon on socket about itself
+func FetchDaemonStatus(ctx context.Context, socket string) (*DaemonStatus, error) {
+	var status DaemonStatus
+	if err := daemonCall(ctx, socket, http.MethodGet, daemonStatusPath, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// StopDaemon asks the daemon on socket to exit
+func StopDaemon(ctx context.Context, socket string) error {
+	return daemonCall(ctx, socket, http.MethodPost, daemonStopPath, nil, nil)
+}
+
+// DiscoverThroughDaemon asks the daemon on socket for the API of target,
+// connecting to it unless it already is
+func DiscoverThroughDaemon(ctx context.Context, socket string, target DaemonTarget) (*DaemonDiscovery, error) {
+	ctx, cancel := context.WithTimeout(ctx, daemonDiscoverTimeout)
+	defer cancel()
+
+	var discovery DaemonDiscovery
+	if err := daemonCall(ctx, socket, http.MethodPost, daemonDiscoverPath, &target, &discovery); err != nil {
+		return nil, err
+	}
+	return &discovery, nil
+}
+
+// daemonTransport names the target API in requests to the daemon
+type daemonTransport struct {
+	target DaemonTarget
+	base   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *daemonTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	t.target.setHeaders(out.Header)
+	return t.base.RoundTrip(out)
+}
+
+// NewClientWithDaemon creates an API client whose requests are proxied by
+// the daemon on socket to the API of target, with API ID verification
+func NewClientWithDaemon(socket string, target DaemonTarget, apiKey, apiID string) *Client {
+	return &Client{
+		// The daemon is reached through its socket whatever the host
+		baseURL:       "http://lissto-daemon",
+		apiKey:        apiKey,
+		expectedAPIID: apiID,
+		httpClient: newHTTPClientOn(daemonHTTPTransport(socket), func(base http.RoundTripper) http.RoundTripper {
+			return &daemonTransport{target: target, base: base}
+		}),
+		impersonate: impersonateUser,
+	}
+}
+
+// daemonEnabled reports whether commands may use a running daemon
+func daemonEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(DaemonEnv))
+	return err != nil || enabled
+}
+
+// daemonClient returns a client for lisstoCtx using a running daemon, or
+// false when there is none or it can't reach the API
+func daemonClient(ctx context.Context, lisstoCtx *config.Context) (*Client, *DaemonDiscovery, bool) {
+	if !daemonEnabled() {
+		return nil, nil, false
+	}
+	socket, err := DaemonSocketPath()
+	if err != nil {
+		return nil, nil, false
+	}
+	if _, err := os.Stat(socket); err != nil {
+		return nil, nil, false
+	}
+	target := daemonTargetOf(lisstoCtx)
+	discovery, err := DiscoverThroughDaemon(ctx, socket, target)
+	if err != nil {
+		return nil, nil, false
+	}
+	if discovery.PublicURL != "" {
+		return NewClientWithAPIID(discovery.PublicURL, lisstoCtx.APIKey, discovery.APIID), discovery, true
+	}
+	return NewClientWithDaemon(socket, target, lisstoCtx.APIKey, discovery.APIID), discovery, true
+}
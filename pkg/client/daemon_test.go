@@ -0,0 +1,133 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/k8s"
+)
+
+var _ = Describe("Daemon", func() {
+	var (
+		api        *httptest.Server
+		discovered atomic.Int32
+		socket     string
+		cancel     context.CancelFunc
+		done       chan error
+		target     = client.DaemonTarget{KubeContext: "kind-dev", Namespace: "lissto-system", Service: "lissto-api"}
+	)
+
+	start := func(discover client.DaemonDiscoverer) {
+		dir, err := os.MkdirTemp("", "lissto")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() { _ = os.RemoveAll(dir) })
+		socket = filepath.Join(dir, "daemon.sock")
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		done = make(chan error, 1)
+		daemon := client.NewDaemon(discover)
+		go func() { done <- daemon.Serve(ctx, socket) }()
+		Eventually(func() error {
+			_, err := client.FetchDaemonStatus(context.Background(), socket)
+			return err
+		}).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		discovered.Store(0)
+		api = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Lissto-API-ID", "api-1")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `","key":"` + r.Header.Get("X-API-Key") + `","ctx":"` + r.Header.Get("X-Lissto-Kube-Context") + `"}`))
+		}))
+		start(func(ctx context.Context, t client.DaemonTarget) (*k8s.APIDiscoveryInfo, client.TunnelOpener, error) {
+			discovered.Add(1)
+			info := &k8s.APIDiscoveryInfo{PortForwardURL: api.URL, APIID: "api-1", StopPortForward: func() {}}
+			return info, func(ctx context.Context) (string, func(), error) { return api.URL, func() {}, nil }, nil
+		})
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+		api.Close()
+	})
+
+	It("should proxy requests to the API once discovered", func() {
+		discovery, err := client.DiscoverThroughDaemon(context.Background(), socket, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(discovery.APIID).To(Equal("api-1"))
+		Expect(discovery.PublicURL).To(BeEmpty())
+
+		var result struct {
+			Path string `json:"path"`
+			Key  string `json:"key"`
+			Ctx  string `json:"ctx"`
+		}
+		c := client.NewClientWithDaemon(socket, target, "secret", discovery.APIID)
+		Expect(c.Do(context.Background(), http.MethodGet, "/api/v1/stacks", nil, &result)).To(Succeed())
+		Expect(result.Path).To(Equal("/api/v1/stacks"))
+		Expect(result.Key).To(Equal("secret"))
+		Expect(result.Ctx).To(BeEmpty(), "daemon headers must not reach the API")
+	})
+
+	It("should discover each API once", func() {
+		for range 3 {
+			_, err := client.DiscoverThroughDaemon(context.Background(), socket, target)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(client.NewClientWithDaemon(socket, target, "key", "").Do(context.Background(), http.MethodGet, "/user", nil, nil)).To(Succeed())
+		Expect(discovered.Load()).To(BeEquivalentTo(1))
+
+		status, err := client.FetchDaemonStatus(context.Background(), socket)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.PID).To(Equal(os.Getpid()))
+		Expect(status.APIs).To(HaveLen(1))
+		Expect(status.APIs[0].DaemonTarget).To(Equal(target))
+		Expect(status.APIs[0].Tunnel).To(BeTrue())
+	})
+
+	It("should refuse to start twice on the same socket", func() {
+		err := client.NewDaemon(nil).Serve(context.Background(), socket)
+		Expect(err).To(MatchError(client.ErrDaemonRunning))
+	})
+
+	It("should stop on request", func() {
+		Expect(client.StopDaemon(context.Background(), socket)).To(Succeed())
+		Eventually(done).Should(Receive(BeNil()))
+		done <- nil // for AfterEach
+
+		_, err := client.FetchDaemonStatus(context.Background(), socket)
+		Expect(err).To(MatchError(client.ErrDaemonNotRunning))
+	})
+
+	Context("when discovery fails", func() {
+		BeforeEach(func() {
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+			start(func(ctx context.Context, t client.DaemonTarget) (*k8s.APIDiscoveryInfo, client.TunnelOpener, error) {
+				discovered.Add(1)
+				return nil, nil, errors.New("no running pods")
+			})
+		})
+
+		It("should report the error and retry on the next request", func() {
+			_, err := client.DiscoverThroughDaemon(context.Background(), socket, target)
+			Expect(err).To(MatchError(ContainSubstring("no running pods")))
+
+			err = client.NewClientWithDaemon(socket, target, "key", "").Do(context.Background(), http.MethodGet, "/user", nil, nil)
+			Expect(err).To(MatchError(ContainSubstring("no running pods")))
+			Expect(discovered.Load()).To(BeNumerically(">=", 2))
+		})
+	})
+})
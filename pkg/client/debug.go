@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxDebugBody is the number of body bytes printed per request or response
+const maxDebugBody = 4096
+
+// redacted replaces sensitive values in traced bodies
+const redacted = "[REDACTED]"
+
+// sensitiveField matches JSON field names whose values are never printed
+var sensitiveField = regexp.MustCompile(`(?i)(api_?key|token|password|credential)s?$`)
+
+// secretDataFields hold secret values in requests and responses of the
+// /secrets endpoints; "secrets" holds them anywhere
+var secretDataFields = map[string]bool{"data": true, "values": true, "secrets": true}
+
+// requestIDHeaders are the headers that carry a request ID
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id"}
+
+// debugOutput receives HTTP traces of clients created after it is set
+var debugOutput io.Writer
+
+// SetDebugOutput traces every API request of clients created afterwards to
+// w: method, path, status, duration, request ID and redacted bodies. A nil
+// writer disables tracing.
+func SetDebugOutput(w io.Writer) {
+	debugOutput = w
+}
+
+// debugTransport traces requests to an output
+type debugTransport struct {
+	base http.RoundTripper
+	out  io.Writer
+	mu   sync.Mutex
+}
+
+// debugRoundTripper wraps base with tracing if it is enabled
+func debugRoundTripper(base http.RoundTripper) http.RoundTripper {
+	if debugOutput == nil {
+		return base
+	}
+	return &debugTransport{base: base, out: debugOutput}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Tag the request so it can be found in the API logs
+	if req.Header.Get("X-Request-Id") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("X-Request-Id", newRequestID())
+	}
+	requestID := req.Header.Get("X-Request-Id")
+
+	var reqBody []byte
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+			_ = body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start).Round(time.Millisecond)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[http] %s %s", req.Method, req.URL.Path)
+	if req.URL.RawQuery != "" {
+		fmt.Fprintf(&b, "?%s", req.URL.RawQuery)
+	}
+	if err != nil {
+		fmt.Fprintf(&b, " error=%q duration=%s request-id=%s\n", err.Error(), duration, requestID)
+		writeDebugBody(&b, "request", req.URL.Path, reqBody)
+		t.write(b.String())
+		return resp, err
+	}
+
+	if id := responseRequestID(resp); id != "" {
+		requestID = id
+	}
+	fmt.Fprintf(&b, " status=%d duration=%s request-id=%s\n", resp.StatusCode, duration, requestID)
+	writeDebugBody(&b, "request", req.URL.Path, reqBody)
+
+	// API responses are small JSON documents, read in full by the client
+	respBody, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if readErr != nil {
+		fmt.Fprintf(&b, "  response body: read error: %v\n", readErr)
+	} else {
+		writeDebugBody(&b, "response", req.URL.Path, respBody)
+	}
+
+	t.write(b.String())
+	return resp, nil
+}
+
+// write prints a trace without interleaving concurrent requests
+func (t *debugTransport) write(trace string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = io.WriteString(t.out, trace)
+}
+
+// responseRequestID returns the request ID reported by the API, if any
+func responseRequestID(resp *http.Response) string {
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// writeDebugBody prints a redacted, truncated body
+func writeDebugBody(b *strings.Builder, kind, path string, body []byte) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return
+	}
+	text := RedactBody(path, body)
+	if len(text) > maxDebugBody {
+		text = fmt.Sprintf("%s... (%d bytes)", text[:maxDebugBody], len(text))
+	}
+	fmt.Fprintf(b, "  %s body: %s\n", kind, text)
+}
+
+// RedactBody returns a JSON body with sensitive values replaced: fields
+// named like API keys, tokens or passwords, and secret values. Bodies that aren't JSON are returned unchanged.
+func RedactBody(path string, body []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return strings.TrimSpace(string(body))
+	}
+
+	redactValue(value, strings.Contains(path, "/secrets"))
+	redactedBody, err := json.Marshal(value)
+	if err != nil {
+		return strings.TrimSpace(string(body))
+	}
+	return string(redactedBody)
+}
+
+// redactValue redacts sensitive fields of decoded JSON in place
+func redactValue(value interface{}, secretPath bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for field, fieldValue := range v {
+			switch {
+			case sensitiveField.MatchString(field):
+				v[field] = redacted
+			case secretDataFields[field] && (secretPath || field == "secrets"):
+				// Keep the key names, which are useful and not secret
+				if data, ok := fieldValue.(map[string]interface{}); ok {
+					for k := range data {
+						data[k] = redacted
+					}
+				} else {
+					v[field] = redacted
+				}
+			default:
+				redactValue(fieldValue, secretPath)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			redactValue(item, secretPath)
+		}
+	}
+}
+
+// newRequestID returns a random request ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,71 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("Debug HTTP tracing", func() {
+	var (
+		trace     *bytes.Buffer
+		server    *httptest.Server
+		requestID string
+	)
+
+	BeforeEach(func() {
+		trace = &bytes.Buffer{}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID = r.Header.Get("X-Request-Id")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"name":"db","keys":["PASSWORD"],"api_key":"lsk_123"}`))
+		}))
+		client.SetDebugOutput(trace)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		client.SetDebugOutput(nil)
+	})
+
+	It("should trace method, path, status and request ID", func() {
+		var result map[string]interface{}
+		err := client.NewClient(server.URL, "key").Do(context.Background(), "POST", "/api/v1/secrets", map[string]interface{}{
+			"name":    "db",
+			"secrets": map[string]string{"PASSWORD": "hunter2"},
+		}, &result)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(HaveKeyWithValue("api_key", "lsk_123"), "the caller still gets the real body")
+
+		Expect(requestID).NotTo(BeEmpty())
+		Expect(trace.String()).To(ContainSubstring("[http] POST /api/v1/secrets status=201"))
+		Expect(trace.String()).To(ContainSubstring("request-id=" + requestID))
+		Expect(trace.String()).To(ContainSubstring(`"PASSWORD":"[REDACTED]"`))
+		Expect(trace.String()).To(ContainSubstring(`"api_key":"[REDACTED]"`))
+		Expect(trace.String()).NotTo(ContainSubstring("hunter2"))
+		Expect(trace.String()).NotTo(ContainSubstring("lsk_123"))
+	})
+
+	It("should not trace clients created while disabled", func() {
+		client.SetDebugOutput(nil)
+		Expect(client.NewClient(server.URL, "key").Do(context.Background(), "GET", "/", nil, nil)).To(Succeed())
+		Expect(trace.Len()).To(BeZero())
+	})
+})
+
+var _ = DescribeTable("RedactBody",
+	func(path, body, expected string) {
+		Expect(client.RedactBody(path, []byte(body))).To(Equal(expected))
+	},
+	Entry("keeps ordinary fields", "/api/v1/stacks", `{"name":"web","env":"dev"}`, `{"env":"dev","name":"web"}`),
+	Entry("redacts tokens in nested objects", "/api/v1/x", `{"data":{"token":"t"}}`, `{"data":{"token":"[REDACTED]"}}`),
+	Entry("redacts secret values but keeps their keys", "/api/v1/secrets/db/reveal", `{"values":{"A":"1"}}`, `{"values":{"A":"[REDACTED]"}}`),
+	Entry("keeps variable data", "/api/v1/variables", `{"data":{"A":"1"}}`, `{"data":{"A":"1"}}`),
+	Entry("passes non-JSON bodies through", "/", "internal error\n", "internal error"),
+)
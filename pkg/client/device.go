@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/config"
+)
+
+// Device login errors reported by the token endpoint (RFC 8628)
+const (
+	deviceAuthorizationPending = "authorization_pending"
+	deviceSlowDown             = "slow_down"
+	deviceExpiredToken         = "expired_token"
+	deviceAccessDenied         = "access_denied"
+)
+
+// defaultDeviceInterval is the polling interval when the API doesn't set one
+const defaultDeviceInterval = 5 * time.Second
+
+// ErrLoginDenied means the login was rejected in the web console
+var ErrLoginDenied = errors.New("login was denied in the web console")
+
+// ErrLoginExpired means the login wasn't approved in time
+var ErrLoginExpired = errors.New("login code expired before it was approved")
+
+// DeviceAuthorization is a pending browser login: the user approves UserCode
+// at VerificationURI while the CLI polls with DeviceCode
+type DeviceAuthorization struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	// VerificationURIComplete embeds the user code, if the API provides it
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// URL returns the page to open, with the user code when possible
+func (d *DeviceAuthorization) URL() string {
+	if d.VerificationURIComplete != "" {
+		return d.VerificationURIComplete
+	}
+	return d.VerificationURI
+}
+
+// Token is a short-lived access token and the refresh token renewing it
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// ExpiresIn is the access token lifetime in seconds
+	ExpiresIn int `json:"expires_in"`
+}
+
+// Expiry returns when a token issued at now expires
+func (t *Token) Expiry(now time.Time) time.Time {
+	return now.Add(time.Duration(t.ExpiresIn) * time.Second)
+}
+
+// StartDeviceLogin starts a browser login
+func (c *Client) StartDeviceLogin(ctx context.Context) (*DeviceAuthorization, error) {
+	var auth DeviceAuthorization
+	if err := c.Do(withoutRetry(ctx), "POST", "/api/v1/auth/device", map[string]string{"client_id": "lissto-cli"}, &auth); err != nil {
+		return nil, fmt.Errorf("failed to start browser login: %w", err)
+	}
+	if auth.DeviceCode == "" || auth.VerificationURI == "" {
+		return nil, fmt.Errorf("failed to start browser login: the API doesn't support it, use an API key")
+	}
+	return &auth, nil
+}
+
+// PollDeviceToken waits until a browser login is approved and returns the
+// issued token. It fails with ErrLoginDenied or ErrLoginExpired.
+func (c *Client) PollDeviceToken(ctx context.Context, auth *DeviceAuthorization) (*Token, error) {
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDeviceInterval
+	}
+	if auth.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(auth.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrLoginExpired
+			}
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var token Token
+		err := c.Do(withoutRetry(ctx), "POST", "/api/v1/auth/token", map[string]string{
+			"grant_type":  "urn:ietf:params:oauth:grant-type:device_code",
+			"device_code": auth.DeviceCode,
+			"client_id":   "lissto-cli",
+		}, &token)
+		var apiErr *APIError
+		switch {
+		case err == nil:
+			return &token, nil
+		case errors.As(err, &apiErr) && apiErr.ErrorMessage == deviceAuthorizationPending:
+		case errors.As(err, &apiErr) && apiErr.ErrorMessage == deviceSlowDown:
+			interval += defaultDeviceInterval
+		case errors.As(err, &apiErr) && apiErr.ErrorMessage == deviceAccessDenied:
+			return nil, ErrLoginDenied
+		case errors.As(err, &apiErr) && apiErr.ErrorMessage == deviceExpiredToken:
+			return nil, ErrLoginExpired
+		case ctx.Err() != nil:
+			// Reported by the select above
+		default:
+			return nil, fmt.Errorf("failed to complete browser login: %w", err)
+		}
+	}
+}
+
+// RefreshToken exchanges a refresh token for a new token
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*Token, error) {
+	var token Token
+	if err := c.Do(ctx, "POST", "/api/v1/auth/token", map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+		"client_id":     "lissto-cli",
+	}, &token); err != nil {
+		return nil, fmt.Errorf("failed to refresh login: %w", err)
+	}
+	if token.RefreshToken == "" {
+		// The API may keep the refresh token
+		token.RefreshToken = refreshToken
+	}
+	return &token, nil
+}
+
+// SetToken makes requests authenticate with an access token instead of the
+// API key
+func (c *Client) SetToken(accessToken string) {
+	c.token = accessToken
+}
+
+// tokenRefreshMargin renews access tokens this long before they expire
+const tokenRefreshMargin = time.Minute
+
+// UseContextToken authenticates c with the access token of a context from
+// 'lissto login --web', refreshing and saving it when it's about to expire
+func (c *Client) UseContextToken(ctx context.Context, lisstoCtx *config.Context) error {
+	now := time.Now()
+	if lisstoCtx.TokenExpiry.IsZero() || now.Add(tokenRefreshMargin).Before(lisstoCtx.TokenExpiry) {
+		c.SetToken(lisstoCtx.AccessToken)
+		return nil
+	}
+	if lisstoCtx.RefreshToken == "" {
+		return fmt.Errorf("%w: login of context '%s' expired; run 'lissto login --web' again", ErrAuth, lisstoCtx.Name)
+	}
+
+	token, err := c.RefreshToken(ctx, lisstoCtx.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("%w: login of context '%s' expired and couldn't be refreshed (%v); run 'lissto login --web' again", ErrAuth, lisstoCtx.Name, err)
+	}
+	lisstoCtx.AccessToken, lisstoCtx.RefreshToken = token.AccessToken, token.RefreshToken
+	lisstoCtx.TokenExpiry = token.Expiry(now)
+	c.SetToken(token.AccessToken)
+
+	if err := config.UpdateConfig(func(cfg *config.Config) error {
+		saved, err := cfg.GetContext(lisstoCtx.Name)
+		if err != nil {
+			return err
+		}
+		saved.AccessToken, saved.RefreshToken, saved.TokenExpiry = lisstoCtx.AccessToken, lisstoCtx.RefreshToken, lisstoCtx.TokenExpiry
+		cfg.AddOrUpdateContext(*saved)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to save refreshed login: %w", err)
+	}
+	return nil
+}
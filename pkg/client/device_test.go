@@ -0,0 +1,191 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+)
+
+var _ = Describe("Device login", func() {
+	var (
+		server     *httptest.Server
+		tokenCalls atomic.Int32
+		// tokenErrors are returned by the token endpoint before it succeeds
+		tokenErrors []string
+	)
+
+	BeforeEach(func() {
+		tokenCalls.Store(0)
+		tokenErrors = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			w.Header().Set("X-Lissto-API-ID", "api-1")
+			var body map[string]string
+			if r.Body != nil {
+				_ = json.NewDecoder(r.Body).Decode(&body)
+			}
+			switch r.URL.Path {
+			case "/health":
+				w.WriteHeader(http.StatusOK)
+			case "/api/v1/auth/device":
+				_, _ = w.Write([]byte(`{"device_code":"dev-1","user_code":"ABCD-EFGH","verification_uri":"https://console.example/device","expires_in":60,"interval":1}`))
+			case "/api/v1/auth/token":
+				call := int(tokenCalls.Add(1))
+				if body["grant_type"] == "refresh_token" {
+					Expect(body["refresh_token"]).To(Equal("refresh-1"))
+					_, _ = w.Write([]byte(`{"access_token":"access-2","expires_in":3600}`))
+					return
+				}
+				Expect(body["device_code"]).To(Equal("dev-1"))
+				if call <= len(tokenErrors) {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"success":false,"error":"` + tokenErrors[call-1] + `"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"access_token":"access-1","refresh_token":"refresh-1","expires_in":900}`))
+			case "/api/v1/user/me":
+				if r.Header.Get("Authorization") != "Bearer access-2" || r.Header.Get("X-API-Key") != "" {
+					w.WriteHeader(http.StatusUnauthorized)
+					_, _ = w.Write([]byte(`{"success":false,"error":"unauthorized"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"name":"alice","role":"user"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	It("should poll until the login is approved", func() {
+		tokenErrors = []string{"authorization_pending"}
+		c := client.NewClient(server.URL, "")
+		auth, err := c.StartDeviceLogin(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.UserCode).To(Equal("ABCD-EFGH"))
+		Expect(auth.URL()).To(Equal("https://console.example/device"))
+
+		token, err := c.PollDeviceToken(context.Background(), auth)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.AccessToken).To(Equal("access-1"))
+		Expect(token.RefreshToken).To(Equal("refresh-1"))
+		Expect(tokenCalls.Load()).To(BeEquivalentTo(2))
+	})
+
+	It("should report a denied login", func() {
+		tokenErrors = []string{"access_denied"}
+		c := client.NewClient(server.URL, "")
+		_, err := c.PollDeviceToken(context.Background(), &client.DeviceAuthorization{DeviceCode: "dev-1", Interval: 1})
+		Expect(err).To(MatchError(client.ErrLoginDenied))
+	})
+
+	It("should report an expired login code", func() {
+		tokenErrors = []string{"expired_token"}
+		c := client.NewClient(server.URL, "")
+		_, err := c.PollDeviceToken(context.Background(), &client.DeviceAuthorization{DeviceCode: "dev-1", Interval: 1})
+		Expect(err).To(MatchError(client.ErrLoginExpired))
+	})
+
+	It("should refresh an expiring token of a context and save it", func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", GinkgoT().TempDir())
+		GinkgoT().Setenv("XDG_CACHE_HOME", GinkgoT().TempDir())
+		lisstoCtx := config.Context{
+			Name:         "web",
+			APIUrl:       server.URL,
+			APIID:        "api-1",
+			AccessToken:  "access-1",
+			RefreshToken: "refresh-1",
+			TokenExpiry:  time.Now().Add(10 * time.Second),
+		}
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.AddOrUpdateContext(lisstoCtx)
+			return nil
+		})).To(Succeed())
+
+		c, err := client.NewClientForContext(context.Background(), &lisstoCtx)
+		Expect(err).NotTo(HaveOccurred())
+		user, err := c.GetCurrentUser(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(user.Name).To(Equal("alice"))
+
+		cfg, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		saved, err := cfg.GetContext("web")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(saved.AccessToken).To(Equal("access-2"))
+		Expect(saved.RefreshToken).To(Equal("refresh-1"))
+		Expect(saved.TokenExpiry).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+	})
+
+	It("should keep a refreshed token in the keychain", func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", GinkgoT().TempDir())
+		GinkgoT().Setenv("XDG_CACHE_HOME", GinkgoT().TempDir())
+		keychain := memoryKeychain{}
+		DeferCleanup(config.SetKeychainStore(keychain))
+		lisstoCtx := config.Context{
+			Name:         "web",
+			APIUrl:       server.URL,
+			APIID:        "api-1",
+			AccessToken:  "access-1",
+			RefreshToken: "refresh-1",
+			TokenExpiry:  time.Now().Add(10 * time.Second),
+		}
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.Settings.CredentialStore = config.CredentialStoreKeychain
+			cfg.AddOrUpdateContext(lisstoCtx)
+			return nil
+		})).To(Succeed())
+
+		_, err := client.NewClientForContext(context.Background(), &lisstoCtx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(keychain).To(Equal(memoryKeychain{"web/access-token": "access-2", "web/refresh-token": "refresh-1"}))
+		path, err := config.GetConfigPath()
+		Expect(err).NotTo(HaveOccurred())
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("access-"))
+		Expect(string(data)).NotTo(ContainSubstring("refresh-1"))
+	})
+
+	It("should fail with an auth error when the token expired without a refresh token", func() {
+		lisstoCtx := config.Context{Name: "web", APIUrl: server.URL, APIID: "api-1", AccessToken: "access-1", TokenExpiry: time.Now().Add(-time.Hour)}
+		_, err := client.NewClientForContext(context.Background(), &lisstoCtx)
+		Expect(err).To(MatchError(client.ErrAuth))
+		Expect(err.Error()).To(ContainSubstring("lissto login --web"))
+	})
+})
+
+// memoryKeychain is an in-memory credential store
+type memoryKeychain map[string]string
+
+func (k memoryKeychain) Get(account string) (string, error) {
+	secret, ok := k[account]
+	if !ok {
+		return "", config.ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (k memoryKeychain) Set(account, secret string) error {
+	k[account] = secret
+	return nil
+}
+
+func (k memoryKeychain) Delete(account string) error {
+	if _, ok := k[account]; !ok {
+		return config.ErrCredentialNotFound
+	}
+	delete(k, account)
+	return nil
+}
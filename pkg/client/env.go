@@ -1,9 +1,18 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 )
 
+// ErrEnvDeleteUnsupported is returned when the API cannot delete environments
+var ErrEnvDeleteUnsupported = errors.New("this Lissto API does not support deleting environments")
+
+// ErrEnvRenameUnsupported is returned when the API cannot rename environments
+var ErrEnvRenameUnsupported = errors.New("this Lissto API does not support renaming environments")
+
 // EnvResponse represents an environment from the API
 type EnvResponse struct {
 	ID   string `json:"id"`   // Scoped identifier: namespace/envname
@@ -11,10 +20,10 @@ type EnvResponse struct {
 }
 
 // ListEnvs lists all environments
-func (c *Client) ListEnvs() ([]EnvResponse, error) {
+func (c *Client) ListEnvs(ctx context.Context) ([]EnvResponse, error) {
 	var envs []EnvResponse
 
-	if err := c.Do("GET", "/api/v1/envs", nil, &envs); err != nil {
+	if err := c.Do(ctx, "GET", "/api/v1/envs", nil, &envs); err != nil {
 		return nil, fmt.Errorf("failed to list environments: %w", err)
 	}
 
@@ -22,11 +31,11 @@ func (c *Client) ListEnvs() ([]EnvResponse, error) {
 }
 
 // GetEnv gets a specific environment
-func (c *Client) GetEnv(name string) (*EnvResponse, error) {
+func (c *Client) GetEnv(ctx context.Context, name string) (*EnvResponse, error) {
 	var env EnvResponse
 
 	path := fmt.Sprintf("/api/v1/envs/%s", name)
-	if err := c.Do("GET", path, nil, &env); err != nil {
+	if err := c.Do(ctx, "GET", path, nil, &env); err != nil {
 		return nil, fmt.Errorf("failed to get environment: %w", err)
 	}
 
@@ -34,15 +43,62 @@ func (c *Client) GetEnv(name string) (*EnvResponse, error) {
 }
 
 // CreateEnv creates a new environment
-func (c *Client) CreateEnv(name string) (string, error) {
+func (c *Client) CreateEnv(ctx context.Context, name string) (string, error) {
 	reqBody := map[string]interface{}{
 		"name": name,
 	}
 
 	var identifier string
-	if err := c.Do("POST", "/api/v1/envs", reqBody, &identifier); err != nil {
+	if err := c.Do(ctx, "POST", "/api/v1/envs", reqBody, &identifier); err != nil {
 		return "", fmt.Errorf("failed to create environment: %w", err)
 	}
 
 	return identifier, nil
 }
+
+// DeleteEnv deletes an environment. Check the environment exists first: a
+// missing endpoint is reported as ErrEnvDeleteUnsupported.
+func (c *Client) DeleteEnv(ctx context.Context, name string) error {
+	path := fmt.Sprintf("/api/v1/envs/%s", name)
+	if err := c.Do(ctx, "DELETE", path, nil, nil); err != nil {
+		if isUnsupported(err) {
+			return ErrEnvDeleteUnsupported
+		}
+		return fmt.Errorf("failed to delete environment: %w", err)
+	}
+
+	return nil
+}
+
+// RenameEnv renames an environment, returning its new identifier. Check the
+// environment exists first: a missing endpoint is reported as
+// ErrEnvRenameUnsupported.
+func (c *Client) RenameEnv(ctx context.Context, name, newName string) (string, error) {
+	reqBody := map[string]interface{}{
+		"name": newName,
+	}
+
+	var identifier string
+	path := fmt.Sprintf("/api/v1/envs/%s", name)
+	if err := c.Do(ctx, "PUT", path, reqBody, &identifier); err != nil {
+		if isUnsupported(err) {
+			return "", ErrEnvRenameUnsupported
+		}
+		return "", fmt.Errorf("failed to rename environment: %w", err)
+	}
+
+	return identifier, nil
+}
+
+// isUnsupported reports whether an API error means the endpoint doesn't exist
+func isUnsupported(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
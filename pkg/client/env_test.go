@@ -0,0 +1,66 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("Env", func() {
+	var (
+		status int
+		body   string
+		method string
+		path   string
+		c      *client.Client
+	)
+
+	BeforeEach(func() {
+		status, body = http.StatusOK, ""
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method, path = r.Method, r.URL.Path
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+		DeferCleanup(server.Close)
+		c = client.NewClient(server.URL, "key")
+	})
+
+	It("should delete an environment", func() {
+		Expect(c.DeleteEnv(context.Background(), "staging")).To(Succeed())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/api/v1/envs/staging"))
+	})
+
+	It("should rename an environment", func() {
+		body = "ns/qa"
+		id, err := c.RenameEnv(context.Background(), "staging", "qa")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("ns/qa"))
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/api/v1/envs/staging"))
+	})
+
+	DescribeTable("should report APIs without the endpoint",
+		func(code int, response string) {
+			status, body = code, response
+			Expect(c.DeleteEnv(context.Background(), "staging")).To(MatchError(client.ErrEnvDeleteUnsupported))
+			_, err := c.RenameEnv(context.Background(), "staging", "qa")
+			Expect(err).To(MatchError(client.ErrEnvRenameUnsupported))
+		},
+		Entry("method not allowed", http.StatusMethodNotAllowed, `{"message":"Method Not Allowed"}`),
+		Entry("not found", http.StatusNotFound, `{"message":"Not Found"}`),
+	)
+
+	It("should keep other errors", func() {
+		status, body = http.StatusForbidden, `{"error":"forbidden"}`
+		err := c.DeleteEnv(context.Background(), "staging")
+		Expect(err).To(MatchError(client.ErrAuth))
+		Expect(err).NotTo(MatchError(client.ErrEnvDeleteUnsupported))
+	})
+})
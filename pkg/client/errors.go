@@ -0,0 +1,39 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error kinds callers can match with errors.Is. API errors match the kind of
+// their HTTP status; commands wrap them for failures detected client-side.
+var (
+	// ErrAuth means the API key is missing, invalid or lacks permission
+	ErrAuth = errors.New("authentication failed")
+	// ErrNotFound means the requested resource does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrConflict means the resource already exists or changed concurrently
+	ErrConflict = errors.New("conflict")
+	// ErrMissingImages means a deployment was blocked by unresolved images
+	ErrMissingImages = errors.New("missing images")
+)
+
+// Error codes of the API, see APIError.Code
+const (
+	// ErrorCodeRequestExpired means a prepare request ID expired or is unknown
+	ErrorCodeRequestExpired = "request_expired"
+)
+
+// statusErrors maps HTTP statuses to error kinds
+var statusErrors = map[int]error{
+	http.StatusUnauthorized: ErrAuth,
+	http.StatusForbidden:    ErrAuth,
+	http.StatusNotFound:     ErrNotFound,
+	http.StatusConflict:     ErrConflict,
+}
+
+// Is reports whether the API error is of the given kind
+func (e *APIError) Is(target error) bool {
+	kind, ok := statusErrors[e.StatusCode]
+	return ok && kind == target
+}
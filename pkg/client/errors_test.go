@@ -0,0 +1,43 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("Errors", func() {
+	DescribeTable("should classify API errors by status",
+		func(status int, body string, kind error) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			err := client.NewClient(server.URL, "key").Do(context.Background(), "GET", "/", nil, nil)
+			Expect(err).To(HaveOccurred())
+			for _, other := range []error{client.ErrAuth, client.ErrNotFound, client.ErrConflict} {
+				Expect(errors.Is(err, other)).To(Equal(other == kind))
+			}
+		},
+		Entry("unauthorized", http.StatusUnauthorized, `{"error":"invalid API key"}`, client.ErrAuth),
+		Entry("forbidden", http.StatusForbidden, `{"error":"admin only"}`, client.ErrAuth),
+		Entry("not found", http.StatusNotFound, `{"error":"stack not found"}`, client.ErrNotFound),
+		Entry("plain text not found", http.StatusNotFound, "404 page not found", client.ErrNotFound),
+		Entry("conflict", http.StatusConflict, `{"error":"already exists"}`, client.ErrConflict),
+		Entry("bad request", http.StatusBadRequest, `{"error":"invalid name"}`, nil),
+	)
+
+	It("should keep the kind through wrapping", func() {
+		err := fmt.Errorf("failed to get stack: %w", &client.APIError{ErrorMessage: "gone", StatusCode: http.StatusNotFound})
+		Expect(errors.Is(err, client.ErrNotFound)).To(BeTrue())
+	})
+})
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// ImpersonateHeader names the user an admin's requests are made as
+const ImpersonateHeader = "X-Lissto-Impersonate-User"
+
+// impersonateUser is the user clients created after it is set act as
+var impersonateUser string
+
+// SetImpersonation makes clients created afterwards send their requests as
+// user, so they see the blueprints, stacks and envs that user sees. The API
+// only honours it for admins. An empty user disables impersonation.
+func SetImpersonation(user string) {
+	impersonateUser = user
+}
+
+// VerifyImpersonation checks that the API acts as the impersonated user.
+// APIs without impersonation ignore the header and would run every request,
+// deletes included, as the API key's user.
+func (c *Client) VerifyImpersonation(ctx context.Context) error {
+	if c.impersonate == "" {
+		return nil
+	}
+	user, err := c.GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to verify --as %s: %w", c.impersonate, err)
+	}
+	if user.Name != c.impersonate {
+		return fmt.Errorf("the API doesn't support --as: requests would run as '%s', not '%s'", user.Name, c.impersonate)
+	}
+	return nil
+}
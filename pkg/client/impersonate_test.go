@@ -0,0 +1,65 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("Impersonation", func() {
+	var (
+		header string
+		// honour makes the server act as the impersonated user, like an API
+		// with impersonation support
+		honour bool
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		header, honour = "", true
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get(client.ImpersonateHeader)
+			if honour && header != "" {
+				_, _ = w.Write([]byte(`{"name":"` + header + `","role":"user"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"name":"admin","role":"admin"}`))
+		}))
+	})
+
+	AfterEach(func() {
+		client.SetImpersonation("")
+		server.Close()
+	})
+
+	It("sends requests as the impersonated user", func() {
+		client.SetImpersonation("alice")
+		c := client.NewClient(server.URL, "admin-key")
+
+		Expect(c.VerifyImpersonation(context.Background())).To(Succeed())
+		Expect(header).To(Equal("alice"))
+	})
+
+	It("fails verification when the API ignores impersonation", func() {
+		honour = false
+		client.SetImpersonation("alice")
+		c := client.NewClient(server.URL, "admin-key")
+
+		err := c.VerifyImpersonation(context.Background())
+		Expect(err).To(MatchError("the API doesn't support --as: requests would run as 'admin', not 'alice'"))
+	})
+
+	It("doesn't impersonate by default", func() {
+		c := client.NewClient(server.URL, "admin-key")
+
+		Expect(c.VerifyImpersonation(context.Background())).To(Succeed())
+		_, err := c.GetCurrentUser(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(header).To(BeEmpty())
+	})
+})
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,14 +12,17 @@ import (
 type APIInfo struct {
 	PublicURL string `json:"public_url"`
 	APIID     string `json:"api_id"`
+	// Version is the API/controller release version. Current APIs don't
+	// report it, so it's usually empty.
+	Version string `json:"version,omitempty"`
 }
 
 // GetAPIInfo fetches API information from the health endpoint
 // This endpoint works without authentication for initial discovery
-func (c *Client) GetAPIInfo() (*APIInfo, error) {
+func (c *Client) GetAPIInfo(ctx context.Context) (*APIInfo, error) {
 	url := c.baseURL + "/health?info=true"
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
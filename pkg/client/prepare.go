@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -14,6 +15,19 @@ type ImageCandidate struct {
 	Digest   string `json:"digest,omitempty"`
 }
 
+// VulnerabilitySummary contains scan result counts when the API exposes them
+type VulnerabilitySummary struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+}
+
+// Total returns the total number of vulnerabilities
+func (v *VulnerabilitySummary) Total() int {
+	return v.Critical + v.High + v.Medium + v.Low
+}
+
 // DetailedImageResolutionInfo contains detailed info about image resolution
 type DetailedImageResolutionInfo struct {
 	Service    string           `json:"service"`
@@ -25,6 +39,8 @@ type DetailedImageResolutionInfo struct {
 	Candidates []ImageCandidate `json:"candidates,omitempty"`
 	Exposed    bool             `json:"exposed,omitempty"`
 	URL        string           `json:"url,omitempty"`
+
+	Vulnerabilities *VulnerabilitySummary `json:"vulnerabilities,omitempty"`
 }
 
 // ExposedServiceInfo contains information about an exposed service
@@ -42,7 +58,13 @@ type PrepareStackResponse struct {
 }
 
 // PrepareStack prepares a stack by resolving images
-func (c *Client) PrepareStack(blueprint, env, commit, branch, tag string, detailed bool) (*PrepareStackResponse, error) {
+func (c *Client) PrepareStack(ctx context.Context, blueprint, env, commit, branch, tag string, detailed bool) (*PrepareStackResponse, error) {
+	return c.PrepareStackWithParams(ctx, blueprint, env, commit, branch, tag, detailed, nil)
+}
+
+// PrepareStackWithParams prepares a stack passing blueprint parameter values
+// declared via x-lissto.params. Current APIs ignore them.
+func (c *Client) PrepareStackWithParams(ctx context.Context, blueprint, env, commit, branch, tag string, detailed bool, params map[string]string) (*PrepareStackResponse, error) {
 	reqBody := map[string]interface{}{
 		"blueprint": blueprint,
 		"env":       env,
@@ -58,9 +80,12 @@ func (c *Client) PrepareStack(blueprint, env, commit, branch, tag string, detail
 	if tag != "" {
 		reqBody["tag"] = tag
 	}
+	if len(params) > 0 {
+		reqBody["params"] = params
+	}
 
 	var response PrepareStackResponse
-	if err := c.Do("POST", "/api/v1/prepare", reqBody, &response); err != nil {
+	if err := c.Do(ctx, "POST", "/api/v1/prepare", reqBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to prepare stack: %w", err)
 	}
 
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed API requests are retried. Only idempotent
+// requests are retried, on connection errors and transient server responses.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles per attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, including delays requested via Retry-After
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy rides out short API restarts and port-forward hiccups
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// NoRetry performs every request exactly once
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// retryPolicy is the policy used by clients created after it is set
+var retryPolicy = DefaultRetryPolicy
+
+// SetRetryPolicy sets the retry policy of clients created afterwards
+func SetRetryPolicy(policy RetryPolicy) {
+	retryPolicy = policy
+}
+
+// retryableStatus lists the responses worth retrying
+var retryableStatus = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// noRetryKey marks requests that must not be retried
+type noRetryKey struct{}
+
+// withoutRetry returns a context whose requests are attempted only once
+func withoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// retryTransport retries idempotent requests according to a policy
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+}
+
+// newHTTPClient creates the HTTP client used for API requests
+func newHTTPClient() *http.Client {
+	return newHTTPClientWith(func(rt http.RoundTripper) http.RoundTripper { return rt })
+}
+
+// newHTTPClientWith creates the HTTP client used for API requests, with wrap
+// applied to the transport below retries
+func newHTTPClientWith(wrap func(http.RoundTripper) http.RoundTripper) *http.Client {
+	return newHTTPClientOn(http.DefaultTransport, wrap)
+}
+
+// newHTTPClientOn is newHTTPClientWith sending requests over transport
+func newHTTPClientOn(transport http.RoundTripper, wrap func(http.RoundTripper) http.RoundTripper) *http.Client {
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &retryTransport{base: wrap(debugRoundTripper(transport)), policy: retryPolicy},
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.retryable(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.policy.MaxAttempts || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		if resp != nil {
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// retryable reports whether a request may be sent more than once
+func (t *retryTransport) retryable(req *http.Request) bool {
+	if t.policy.MaxAttempts <= 1 || req.Context().Value(noRetryKey{}) != nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	// A body can only be resent if it can be recreated
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// shouldRetry reports whether an attempt failed transiently
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// Cancellation and timeouts of the whole request are final
+		return req.Context().Err() == nil && !errors.Is(err, context.Canceled)
+	}
+	return retryableStatus[resp.StatusCode]
+}
+
+// backoff returns the delay before the next attempt: the server's Retry-After
+// if given, otherwise exponential backoff with full jitter
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(delay, t.policy.MaxDelay)
+		}
+	}
+
+	ceiling := min(t.policy.BaseDelay<<min(attempt-1, 20), t.policy.MaxDelay)
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+// retryAfter parses a Retry-After header in seconds or HTTP date form
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
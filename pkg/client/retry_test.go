@@ -0,0 +1,82 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("Retry", func() {
+	var (
+		attempts atomic.Int32
+		failures int32
+		status   int
+		server   *httptest.Server
+	)
+
+	BeforeEach(func() {
+		attempts.Store(0)
+		failures = 2
+		status = http.StatusServiceUnavailable
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) <= failures {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}))
+		client.SetRetryPolicy(client.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond})
+	})
+
+	AfterEach(func() {
+		server.Close()
+		client.SetRetryPolicy(client.DefaultRetryPolicy)
+	})
+
+	It("should retry idempotent requests on transient errors", func() {
+		err := client.NewClient(server.URL, "key").Do(context.Background(), "GET", "/", nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attempts.Load()).To(Equal(int32(3)))
+	})
+
+	It("should resend the body of retried PUT requests", func() {
+		err := client.NewClient(server.URL, "key").Do(context.Background(), "PUT", "/", map[string]string{"name": "x"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attempts.Load()).To(Equal(int32(3)))
+	})
+
+	It("should not retry POST requests", func() {
+		err := client.NewClient(server.URL, "key").Do(context.Background(), "POST", "/", map[string]string{"name": "x"}, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(attempts.Load()).To(Equal(int32(1)))
+	})
+
+	It("should not retry client errors", func() {
+		status = http.StatusNotFound
+		err := client.NewClient(server.URL, "key").Do(context.Background(), "GET", "/", nil, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(attempts.Load()).To(Equal(int32(1)))
+	})
+
+	It("should give up after the maximum attempts", func() {
+		failures = 5
+		err := client.NewClient(server.URL, "key").Do(context.Background(), "GET", "/", nil, nil)
+		Expect(err).To(MatchError(ContainSubstring("503")))
+		Expect(attempts.Load()).To(Equal(int32(3)))
+	})
+
+	It("should attempt once with NoRetry", func() {
+		client.SetRetryPolicy(client.NoRetry)
+		err := client.NewClient(server.URL, "key").Do(context.Background(), "GET", "/", nil, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(attempts.Load()).To(Equal(int32(1)))
+	})
+})
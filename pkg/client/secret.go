@@ -1,7 +1,10 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
 )
 
 // SecretResponse represents a secret config from the API (keys only, no values)
@@ -16,6 +19,15 @@ type SecretResponse struct {
 	KeyUpdatedAt map[string]int64 `json:"key_updated_at,omitempty"` // Unix timestamps per key
 }
 
+// RevealedSecret is a secret config with its decrypted values
+type RevealedSecret struct {
+	SecretResponse
+	Values map[string]string `json:"values"`
+}
+
+// ErrRevealUnsupported is returned when the API cannot reveal secret values
+var ErrRevealUnsupported = errors.New("this Lissto API does not support revealing secret values")
+
 // CreateSecretRequest represents a request to create a secret config
 type CreateSecretRequest struct {
 	Name       string            `json:"name"`
@@ -31,10 +43,10 @@ type SetSecretRequest struct {
 }
 
 // ListSecrets lists all secrets (keys only)
-func (c *Client) ListSecrets() ([]SecretResponse, error) {
+func (c *Client) ListSecrets(ctx context.Context) ([]SecretResponse, error) {
 	var secrets []SecretResponse
 
-	if err := c.Do("GET", "/api/v1/secrets", nil, &secrets); err != nil {
+	if err := c.Do(ctx, "GET", "/api/v1/secrets", nil, &secrets); err != nil {
 		return nil, fmt.Errorf("failed to list secrets: %w", err)
 	}
 
@@ -42,22 +54,47 @@ func (c *Client) ListSecrets() ([]SecretResponse, error) {
 }
 
 // GetSecret gets a specific secret (keys only)
-func (c *Client) GetSecret(id, scope, env, repository string) (*SecretResponse, error) {
+func (c *Client) GetSecret(ctx context.Context, id, scope, env, repository string) (*SecretResponse, error) {
 	var secret SecretResponse
 	path := buildResourcePath("/api/v1/secrets", id, scope, env, repository)
 
-	if err := c.Do("GET", path, nil, &secret); err != nil {
+	if err := c.Do(ctx, "GET", path, nil, &secret); err != nil {
 		return nil, fmt.Errorf("failed to get secret: %w", err)
 	}
 
 	return &secret, nil
 }
 
+// RevealSecret fetches the decrypted values of a secret, or only of key when
+// set. The API records every reveal in its audit log and may refuse it by
+// policy. Check the secret exists first: a missing reveal endpoint is reported
+// as ErrRevealUnsupported.
+func (c *Client) RevealSecret(ctx context.Context, id, scope, env, repository, key string) (*RevealedSecret, error) {
+	var secret RevealedSecret
+	path := buildResourcePath("/api/v1/secrets", id+"/reveal", scope, env, repository)
+	if key != "" {
+		sep := "?"
+		if scope != "" || env != "" || repository != "" {
+			sep = "&"
+		}
+		path += sep + "key=" + url.QueryEscape(key)
+	}
+
+	if err := c.Do(ctx, "GET", path, nil, &secret); err != nil {
+		if isUnsupported(err) {
+			return nil, ErrRevealUnsupported
+		}
+		return nil, fmt.Errorf("failed to reveal secret: %w", err)
+	}
+
+	return &secret, nil
+}
+
 // CreateSecret creates a new secret config
-func (c *Client) CreateSecret(req *CreateSecretRequest) (*SecretResponse, error) {
+func (c *Client) CreateSecret(ctx context.Context, req *CreateSecretRequest) (*SecretResponse, error) {
 	var secret SecretResponse
 
-	if err := c.Do("POST", "/api/v1/secrets", req, &secret); err != nil {
+	if err := c.Do(ctx, "POST", "/api/v1/secrets", req, &secret); err != nil {
 		return nil, fmt.Errorf("failed to create secret: %w", err)
 	}
 
@@ -65,11 +102,11 @@ func (c *Client) CreateSecret(req *CreateSecretRequest) (*SecretResponse, error)
 }
 
 // UpdateSecret updates/sets secret values
-func (c *Client) UpdateSecret(id, scope, env, repository string, req *SetSecretRequest) (*SecretResponse, error) {
+func (c *Client) UpdateSecret(ctx context.Context, id, scope, env, repository string, req *SetSecretRequest) (*SecretResponse, error) {
 	var secret SecretResponse
 	path := buildResourcePath("/api/v1/secrets", id, scope, env, repository)
 
-	if err := c.Do("PUT", path, req, &secret); err != nil {
+	if err := c.Do(ctx, "PUT", path, req, &secret); err != nil {
 		return nil, fmt.Errorf("failed to update secret: %w", err)
 	}
 
@@ -77,10 +114,10 @@ func (c *Client) UpdateSecret(id, scope, env, repository string, req *SetSecretR
 }
 
 // DeleteSecret deletes a secret config
-func (c *Client) DeleteSecret(id, scope, env, repository string) error {
+func (c *Client) DeleteSecret(ctx context.Context, id, scope, env, repository string) error {
 	path := buildResourcePath("/api/v1/secrets", id, scope, env, repository)
 
-	if err := c.Do("DELETE", path, nil, nil); err != nil {
+	if err := c.Do(ctx, "DELETE", path, nil, nil); err != nil {
 		return fmt.Errorf("failed to delete secret: %w", err)
 	}
 
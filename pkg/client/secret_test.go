@@ -0,0 +1,55 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("RevealSecret", func() {
+	var (
+		status int
+		query  string
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		status = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.URL.Path).To(Equal("/api/v1/secrets/db/reveal"))
+			query = r.URL.RawQuery
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"name":"db","keys":["PASSWORD"],"values":{"PASSWORD":"s3cret"}}`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should return decrypted values for a single key", func() {
+		secret, err := client.NewClient(server.URL, "key").RevealSecret(context.Background(), "db", "env", "dev", "", "PASSWORD")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(Equal("scope=env&env=dev&key=PASSWORD"))
+		Expect(secret.Name).To(Equal("db"))
+		Expect(secret.Values).To(Equal(map[string]string{"PASSWORD": "s3cret"}))
+	})
+
+	It("should report servers without the reveal endpoint", func() {
+		status = http.StatusNotFound
+		_, err := client.NewClient(server.URL, "key").RevealSecret(context.Background(), "db", "", "", "", "")
+		Expect(err).To(MatchError(client.ErrRevealUnsupported))
+	})
+
+	It("should surface permission errors", func() {
+		status = http.StatusForbidden
+		_, err := client.NewClient(server.URL, "key").RevealSecret(context.Background(), "db", "", "", "", "")
+		Expect(err).To(MatchError(client.ErrAuth))
+	})
+})
@@ -1,13 +1,17 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/lissto-dev/cli/pkg/types"
 )
 
 // ListStacks lists all stacks
-func (c *Client) ListStacks(env string) ([]types.Stack, error) {
+func (c *Client) ListStacks(ctx context.Context, env string) ([]types.Stack, error) {
 	var stacks []types.Stack
 
 	path := "/api/v1/stacks"
@@ -15,7 +19,7 @@ func (c *Client) ListStacks(env string) ([]types.Stack, error) {
 		path = fmt.Sprintf("%s?env=%s", path, env)
 	}
 
-	if err := c.Do("GET", path, nil, &stacks); err != nil {
+	if err := c.Do(ctx, "GET", path, nil, &stacks); err != nil {
 		return nil, fmt.Errorf("failed to list stacks: %w", err)
 	}
 
@@ -23,7 +27,7 @@ func (c *Client) ListStacks(env string) ([]types.Stack, error) {
 }
 
 // GetStack gets a specific stack (returns identifier)
-func (c *Client) GetStack(name, env string) (string, error) {
+func (c *Client) GetStack(ctx context.Context, name, env string) (string, error) {
 	var identifier string
 
 	path := fmt.Sprintf("/api/v1/stacks/%s", name)
@@ -31,38 +35,85 @@ func (c *Client) GetStack(name, env string) (string, error) {
 		path = fmt.Sprintf("%s?env=%s", path, env)
 	}
 
-	if err := c.Do("GET", path, nil, &identifier); err != nil {
+	if err := c.Do(ctx, "GET", path, nil, &identifier); err != nil {
 		return "", fmt.Errorf("failed to get stack: %w", err)
 	}
 
 	return identifier, nil
 }
 
+// StackName returns the name of a stack from the identifier CreateStack
+// returns, which may be scoped as namespace/name
+func StackName(stackID string) string {
+	return stackID[strings.LastIndex(stackID, "/")+1:]
+}
+
 // CreateStack creates a new stack using a prepared request_id
-func (c *Client) CreateStack(blueprint, env, requestID string) (string, error) {
+func (c *Client) CreateStack(ctx context.Context, blueprint, env, requestID string) (string, error) {
+	return c.CreateStackWithParams(ctx, blueprint, env, requestID, nil)
+}
+
+// CreateStackOptions are the optional settings of a new stack
+type CreateStackOptions struct {
+	// Params are blueprint parameter values. Current APIs ignore them.
+	Params map[string]string
+}
+
+// CreateStackWithParams creates a new stack passing blueprint parameter
+// values, which current APIs ignore
+func (c *Client) CreateStackWithParams(ctx context.Context, blueprint, env, requestID string, params map[string]string) (string, error) {
+	return c.CreateStackWithOptions(ctx, blueprint, env, requestID, CreateStackOptions{Params: params})
+}
+
+// CreateStackWithOptions creates a new stack with parameter values
+func (c *Client) CreateStackWithOptions(ctx context.Context, blueprint, env, requestID string, opts CreateStackOptions) (string, error) {
 	reqBody := map[string]interface{}{
 		"blueprint":  blueprint,
 		"env":        env,
 		"request_id": requestID,
 	}
+	if len(opts.Params) > 0 {
+		reqBody["params"] = opts.Params
+	}
 
 	var identifier string
-	if err := c.Do("POST", "/api/v1/stacks", reqBody, &identifier); err != nil {
+	if err := c.Do(ctx, "POST", "/api/v1/stacks", reqBody, &identifier); err != nil {
 		return "", fmt.Errorf("failed to create stack: %w", err)
 	}
 
 	return identifier, nil
 }
 
+// IsRequestExpired reports whether a CreateStack error was caused by an
+// expired or unknown prepare request ID: the API reports it with the
+// request_expired code, as a client error about the request_id field, or as
+// a plain 400 "Invalid or expired request ID" message
+func IsRequestExpired(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusGone:
+	default:
+		return false
+	}
+	if apiErr.Code == ErrorCodeRequestExpired || apiErr.Field == "request_id" {
+		return true
+	}
+	return apiErr.StatusCode == http.StatusBadRequest &&
+		strings.Contains(strings.ToLower(apiErr.ErrorMessage), "expired request id")
+}
+
 // UpdateStack updates a stack's images
-func (c *Client) UpdateStack(name string, images map[string]interface{}) error {
+func (c *Client) UpdateStack(ctx context.Context, name string, images map[string]interface{}) error {
 	reqBody := map[string]interface{}{
 		"images": images,
 	}
 
 	path := fmt.Sprintf("/api/v1/stacks/%s", name)
 
-	if err := c.Do("PUT", path, reqBody, nil); err != nil {
+	if err := c.Do(ctx, "PUT", path, reqBody, nil); err != nil {
 		return fmt.Errorf("failed to update stack: %w", err)
 	}
 
@@ -70,13 +121,13 @@ func (c *Client) UpdateStack(name string, images map[string]interface{}) error {
 }
 
 // DeleteStack deletes a stack
-func (c *Client) DeleteStack(name, env string) error {
+func (c *Client) DeleteStack(ctx context.Context, name, env string) error {
 	path := fmt.Sprintf("/api/v1/stacks/%s", name)
 	if env != "" {
 		path = fmt.Sprintf("%s?env=%s", path, env)
 	}
 
-	if err := c.Do("DELETE", path, nil, nil); err != nil {
+	if err := c.Do(ctx, "DELETE", path, nil, nil); err != nil {
 		return fmt.Errorf("failed to delete stack: %w", err)
 	}
 
@@ -84,18 +135,53 @@ func (c *Client) DeleteStack(name, env string) error {
 }
 
 // FindStacksByBlueprint finds all stacks using a specific blueprint ID in the given environment
-func (c *Client) FindStacksByBlueprint(blueprintID string, env string) ([]types.Stack, error) {
-	allStacks, err := c.ListStacks(env)
+func (c *Client) FindStacksByBlueprint(ctx context.Context, blueprintID string, env string) ([]types.Stack, error) {
+	allStacks, err := c.ListStacks(ctx, env)
 	if err != nil {
 		return nil, err
 	}
 
+	return StacksUsingBlueprint(allStacks, blueprintID), nil
+}
+
+// StacksUsingBlueprint filters stacks down to those referencing a blueprint
+func StacksUsingBlueprint(stacks []types.Stack, blueprintID string) []types.Stack {
 	var matching []types.Stack
-	for _, stack := range allStacks {
+	for _, stack := range stacks {
 		if stack.Spec.BlueprintReference == blueprintID {
 			matching = append(matching, stack)
 		}
 	}
+	return matching
+}
+
+// StackManifests are a stack's rendered Kubernetes manifests before and
+// after a change, as multi-document YAML
+type StackManifests struct {
+	Current  string `json:"current"`
+	Proposed string `json:"proposed"`
+}
+
+// RenderStackUpdate renders the manifests an UpdateStack with images would
+// apply, without applying them. APIs that predate the endpoint return an
+// error matching ErrNotFound.
+func (c *Client) RenderStackUpdate(ctx context.Context, name, env string, images map[string]interface{}) (*StackManifests, error) {
+	reqBody := map[string]interface{}{
+		"images": images,
+	}
+
+	path := fmt.Sprintf("/api/v1/stacks/%s/render", name)
+	if env != "" {
+		path = fmt.Sprintf("%s?env=%s", path, env)
+	}
+
+	var manifests StackManifests
+	if err := c.Do(ctx, "POST", path, reqBody, &manifests); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("manifest rendering is not supported by this Lissto API, upgrade it: %w", err)
+		}
+		return nil, fmt.Errorf("failed to render stack: %w", err)
+	}
 
-	return matching, nil
+	return &manifests, nil
 }
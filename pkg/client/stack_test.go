@@ -0,0 +1,119 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("CreateStackWithOptions", func() {
+	var (
+		body   map[string]interface{}
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		body = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.URL.Path).To(Equal("/api/v1/stacks"))
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			_, _ = w.Write([]byte("stack-1"))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should send params", func() {
+		id, err := client.NewClient(server.URL, "key").CreateStackWithOptions(context.Background(), "bp", "dev", "req-1", client.CreateStackOptions{
+			Params: map[string]string{"hostname-prefix": "demo"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("stack-1"))
+		Expect(body).To(HaveKeyWithValue("params", HaveKeyWithValue("hostname-prefix", "demo")))
+	})
+
+	It("should omit empty options", func() {
+		_, err := client.NewClient(server.URL, "key").CreateStack(context.Background(), "bp", "dev", "req-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(HaveKeyWithValue("request_id", "req-1"))
+		Expect(body).NotTo(HaveKey("params"))
+	})
+})
+
+var _ = Describe("RenderStackUpdate", func() {
+	It("should send the images and decode both manifests", func() {
+		var body map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.URL.Path).To(Equal("/api/v1/stacks/my-stack/render"))
+			Expect(r.URL.Query().Get("env")).To(Equal("dev"))
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			_, _ = w.Write([]byte(`{"current":"kind: Deployment\n","proposed":"kind: Deployment\nspec: {}\n"}`))
+		}))
+		defer server.Close()
+
+		manifests, err := client.NewClient(server.URL, "key").RenderStackUpdate(context.Background(), "my-stack", "dev",
+			map[string]interface{}{"api": map[string]interface{}{"image": "api:2"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(HaveKeyWithValue("images", HaveKey("api")))
+		Expect(manifests.Current).To(Equal("kind: Deployment\n"))
+		Expect(manifests.Proposed).To(ContainSubstring("spec"))
+	})
+
+	It("should explain when the API predates manifest rendering", func() {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		_, err := client.NewClient(server.URL, "key").RenderStackUpdate(context.Background(), "my-stack", "dev", nil)
+		Expect(err).To(MatchError(client.ErrNotFound))
+		Expect(err).To(MatchError(ContainSubstring("not supported")))
+	})
+})
+
+var _ = Describe("IsRequestExpired", func() {
+	DescribeTable("should detect expired prepare requests from the API error",
+		func(status int, body string, expired bool) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			_, err := client.NewClient(server.URL, "key").CreateStack(context.Background(), "bp", "dev", "req-1")
+			Expect(err).To(HaveOccurred())
+			Expect(client.IsRequestExpired(err)).To(Equal(expired))
+			Expect(client.IsRequestExpired(fmt.Errorf("failed to create stack: %w", err))).To(Equal(expired))
+		},
+		Entry("expired request", http.StatusGone,
+			`{"error":"request req-1 expired","code":"request_expired","field":"request_id"}`, true),
+		Entry("unknown request ID", http.StatusNotFound,
+			`{"error":"request not found","field":"request_id"}`, true),
+		Entry("plain 404", http.StatusNotFound, "404 page not found", false),
+		Entry("404 for an unknown blueprint", http.StatusNotFound,
+			`{"error":"blueprint not found for request req-1","code":"not_found","field":"blueprint"}`, false),
+		Entry("expiry wording without a code", http.StatusBadRequest,
+			"Invalid or expired request ID. Please run /prepare again.", true),
+		Entry("expiry wording in a JSON error", http.StatusBadRequest,
+			`{"error":"Invalid or expired request ID. Please run /prepare again."}`, true),
+		Entry("other expiry wording", http.StatusBadRequest, `{"error":"invalid request: env expired"}`, false),
+		Entry("server error", http.StatusInternalServerError, `{"error":"request expired","code":"request_expired"}`, false),
+	)
+
+	It("should ignore other errors", func() {
+		Expect(client.IsRequestExpired(nil)).To(BeFalse())
+		Expect(client.IsRequestExpired(errors.New("request expired"))).To(BeFalse())
+	})
+})
@@ -0,0 +1,259 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/config"
+)
+
+// TunnelKeepalive is how often a tunnel checks its port-forward between
+// requests
+const TunnelKeepalive = 30 * time.Second
+
+// tunnelOpenTimeout bounds re-establishing a port-forward
+const tunnelOpenTimeout = 30 * time.Second
+
+// tunnelLocalPort is the local port tried first for a forward
+const tunnelLocalPort = 8080
+
+// TunnelOpener opens a port-forward to the API, returning its base URL and a
+// function that stops it
+type TunnelOpener func(ctx context.Context) (string, func(), error)
+
+// Tunnel is a port-forward to the API that heals itself. Requests of clients
+// created with NewClientWithTunnel are sent to the current forward; when it
+// broke, e.g. because the API pod restarted, a new one is opened and the
+// request is resent. A keepalive checks the forward while it is idle.
+type Tunnel struct {
+	open TunnelOpener
+
+	mu         sync.Mutex
+	target     *url.URL // nil until the broken forward is reopened
+	stop       func()
+	generation int
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewTunnel manages a port-forward opened by open. baseURL and stop describe
+// an already open forward, e.g. the one used for discovery; when baseURL is
+// empty the first request opens one. The tunnel runs until Close.
+func NewTunnel(baseURL string, stop func(), open TunnelOpener) (*Tunnel, error) {
+	t := &Tunnel{open: open, done: make(chan struct{})}
+	if baseURL != "" {
+		target, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tunnel URL: %w", err)
+		}
+		t.target, t.stop = target, stop
+	}
+	go t.keepalive()
+	return t, nil
+}
+
+// Close stops the tunnel and its port-forward
+func (t *Tunnel) Close() {
+	t.closeOnce.Do(func() {
+		close(t.done)
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.stop != nil {
+			t.stop()
+		}
+		t.target, t.stop = nil, nil
+	})
+}
+
+// current returns the forward's URL, opening one if there is none, and its
+// generation for reporting it broken
+func (t *Tunnel) current(ctx context.Context) (*url.URL, int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	select {
+	case <-t.done:
+		return nil, 0, fmt.Errorf("tunnel is closed")
+	default:
+	}
+	if t.target != nil {
+		return t.target, t.generation, nil
+	}
+
+	openCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tunnelOpenTimeout)
+	defer cancel()
+	baseURL, stop, err := t.open(openCtx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to re-establish port-forward: %w", err)
+	}
+	target, err := url.Parse(baseURL)
+	if err != nil {
+		stop()
+		return nil, 0, fmt.Errorf("invalid tunnel URL: %w", err)
+	}
+	t.target, t.stop = target, stop
+	t.generation++
+	return t.target, t.generation, nil
+}
+
+// broken drops the forward of generation, so the next request opens a new
+// one. Forwards already replaced by another request are left alone.
+func (t *Tunnel) broken(generation int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if generation != t.generation || t.target == nil {
+		return
+	}
+	if t.stop != nil {
+		t.stop()
+	}
+	t.target, t.stop = nil, nil
+}
+
+// keepalive checks the forward's health until the tunnel is closed. A dead
+// forward is replaced right away, so the next request doesn't wait for it.
+func (t *Tunnel) keepalive() {
+	ticker := time.NewTicker(TunnelKeepalive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+		}
+
+		t.mu.Lock()
+		target, generation := t.target, t.generation
+		t.mu.Unlock()
+		if target == nil {
+			continue
+		}
+		if err := t.ping(target); err != nil {
+			t.broken(generation)
+			_, _, _ = t.current(context.Background())
+		}
+	}
+}
+
+// ping calls the API's health endpoint through the forward at target
+func (t *Tunnel) ping(target *url.URL) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.JoinPath("/health").String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// tunnelTransport sends requests through a tunnel's current forward
+type tunnelTransport struct {
+	tunnel *Tunnel
+	base   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *tunnelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		target, generation, err := t.tunnel.current(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		out := req.Clone(req.Context())
+		out.URL.Scheme, out.URL.Host = target.Scheme, target.Host
+		out.Host = ""
+		if attempt > 1 && req.GetBody != nil {
+			if out.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.base.RoundTrip(out)
+		if err == nil || req.Context().Err() != nil {
+			return resp, err
+		}
+		t.tunnel.broken(generation)
+		if attempt > 1 || !resendable(req, err) {
+			return nil, err
+		}
+	}
+}
+
+// resendable reports whether a request that failed with err can be sent
+// through a new forward. Requests that may have reached the API are only
+// resent when they are idempotent.
+func resendable(req *http.Request, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// tunnels are the shared tunnels of this process by context
+var (
+	tunnelsMu sync.Mutex
+	tunnels   = map[string]*sharedTunnel{}
+)
+
+// sharedTunnel is a tunnel with the API ID discovered through it
+type sharedTunnel struct {
+	tunnel *Tunnel
+	apiID  string
+}
+
+// lookupTunnel returns the shared tunnel for key
+func lookupTunnel(key string) (*sharedTunnel, bool) {
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+	shared, ok := tunnels[key]
+	return shared, ok
+}
+
+// registerTunnel shares a tunnel under key. If another one was registered in
+// the meantime, t is closed and the registered one returned.
+func registerTunnel(key string, t *Tunnel, apiID string) *sharedTunnel {
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+	if shared, ok := tunnels[key]; ok {
+		t.Close()
+		return shared
+	}
+	shared := &sharedTunnel{tunnel: t, apiID: apiID}
+	tunnels[key] = shared
+	return shared
+}
+
+// CloseTunnels stops the port-forwards shared by the clients of this process
+func CloseTunnels() {
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+	for key, shared := range tunnels {
+		shared.tunnel.Close()
+		delete(tunnels, key)
+	}
+}
+
+// tunnelKey identifies the API service of a context
+func tunnelKey(lisstoCtx *config.Context) string {
+	return lisstoCtx.Kubeconfig + ":" + lisstoCtx.KubeContext + "/" + lisstoCtx.ServiceNamespace + "/" + lisstoCtx.ServiceName
+}
@@ -0,0 +1,111 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("Tunnel", func() {
+	var (
+		first, second *httptest.Server
+		opened        atomic.Int32
+		stopped       atomic.Int32
+		tunnel        *client.Tunnel
+	)
+
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"` + name + `"}`))
+		}))
+	}
+
+	BeforeEach(func() {
+		opened.Store(0)
+		stopped.Store(0)
+		first = newServer("first")
+		second = newServer("second")
+
+		var err error
+		tunnel, err = client.NewTunnel(first.URL, func() { stopped.Add(1) }, func(ctx context.Context) (string, func(), error) {
+			opened.Add(1)
+			return second.URL, func() { stopped.Add(1) }, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		tunnel.Close()
+		first.Close()
+		second.Close()
+	})
+
+	get := func(c *client.Client) string {
+		var result struct {
+			Name string `json:"name"`
+		}
+		Expect(c.Do(context.Background(), http.MethodGet, "/user", nil, &result)).To(Succeed())
+		return result.Name
+	}
+
+	It("should reuse the open forward", func() {
+		c := client.NewClientWithTunnel(tunnel, "key", "")
+		Expect(get(c)).To(Equal("first"))
+		Expect(get(c)).To(Equal("first"))
+		Expect(opened.Load()).To(BeZero())
+	})
+
+	It("should re-establish a broken forward and resend the request", func() {
+		c := client.NewClientWithTunnel(tunnel, "key", "")
+		Expect(get(c)).To(Equal("first"))
+
+		first.Close()
+		Expect(get(c)).To(Equal("second"))
+		Expect(opened.Load()).To(BeEquivalentTo(1))
+		Expect(stopped.Load()).To(BeEquivalentTo(1))
+
+		// Other clients of the tunnel use the new forward
+		Expect(get(client.NewClientWithTunnel(tunnel, "key", ""))).To(Equal("second"))
+		Expect(opened.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should open a forward on the first request when none is given", func() {
+		lazy, err := client.NewTunnel("", nil, func(ctx context.Context) (string, func(), error) {
+			opened.Add(1)
+			return second.URL, func() {}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer lazy.Close()
+
+		Expect(get(client.NewClientWithTunnel(lazy, "key", ""))).To(Equal("second"))
+		Expect(opened.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should report failures to re-establish the forward", func() {
+		failing, err := client.NewTunnel("", nil, func(ctx context.Context) (string, func(), error) {
+			return "", nil, errors.New("no running pods")
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer failing.Close()
+
+		c := client.NewClientWithTunnel(failing, "key", "")
+		err = c.Do(context.Background(), http.MethodGet, "/user", nil, nil)
+		Expect(err).To(MatchError(ContainSubstring("no running pods")))
+	})
+
+	It("should stop the forward on close", func() {
+		tunnel.Close()
+		Expect(stopped.Load()).To(BeEquivalentTo(1))
+
+		err := client.NewClientWithTunnel(tunnel, "key", "").Do(context.Background(), http.MethodGet, "/user", nil, nil)
+		Expect(err).To(MatchError(ContainSubstring("tunnel is closed")))
+	})
+})
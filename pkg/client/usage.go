@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+// StackRef names a stack and its env
+type StackRef struct {
+	Name string `json:"name" yaml:"name"`
+	Env  string `json:"env" yaml:"env"`
+}
+
+// BlueprintUsage is a blueprint, the stacks referencing it and the newer
+// blueprints of its repository
+type BlueprintUsage struct {
+	BlueprintResponse `yaml:",inline"`
+	Stacks            []StackRef `json:"stacks" yaml:"stacks"`
+	// Newer lists the IDs of newer blueprints of the same repository, newest
+	// first
+	Newer []string `json:"newer,omitempty" yaml:"newer,omitempty"`
+}
+
+// Unused reports whether no stack references the blueprint
+func (u BlueprintUsage) Unused() bool {
+	return len(u.Stacks) == 0
+}
+
+// Superseded reports whether the repository has a newer blueprint
+func (u BlueprintUsage) Superseded() bool {
+	return len(u.Newer) > 0
+}
+
+// GetBlueprintUsages lists every blueprint with the stacks of all envs
+// referencing it, newest first
+func (c *Client) GetBlueprintUsages(ctx context.Context) ([]BlueprintUsage, error) {
+	blueprints, err := c.ListBlueprints(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %w", err)
+	}
+	c.fillRepositories(ctx, blueprints)
+
+	stacks, err := c.ListStacks(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", err)
+	}
+	return BlueprintUsages(blueprints, stacks), nil
+}
+
+// BlueprintUsages matches blueprints with the stacks referencing them and the
+// newer blueprints of their repository, newest first
+func BlueprintUsages(blueprints []BlueprintResponse, stacks []types.Stack) []BlueprintUsage {
+	sorted := append([]BlueprintResponse(nil), blueprints...)
+	sort.SliceStable(sorted, func(i, j int) bool { return blueprintName(sorted[i].ID) > blueprintName(sorted[j].ID) })
+
+	byRepository := make(map[string][]string)
+	usages := make([]BlueprintUsage, 0, len(sorted))
+	for _, bp := range sorted {
+		usage := BlueprintUsage{BlueprintResponse: bp, Stacks: []StackRef{}}
+		for _, stack := range StacksUsingBlueprint(stacks, bp.ID) {
+			usage.Stacks = append(usage.Stacks, StackRef{Name: stack.Name, Env: stack.Spec.Env})
+		}
+		sort.Slice(usage.Stacks, func(i, j int) bool {
+			if usage.Stacks[i].Env != usage.Stacks[j].Env {
+				return usage.Stacks[i].Env < usage.Stacks[j].Env
+			}
+			return usage.Stacks[i].Name < usage.Stacks[j].Name
+		})
+		if bp.Repository != "" {
+			usage.Newer = append([]string(nil), byRepository[bp.Repository]...)
+			byRepository[bp.Repository] = append(byRepository[bp.Repository], bp.ID)
+		}
+		usages = append(usages, usage)
+	}
+	return usages
+}
+
+// PruneCandidates returns the blueprints that can be deleted: unused ones
+// beyond the keep newest blueprints of their repository. usages must be
+// newest first, as returned by BlueprintUsages. Blueprints without a known
+// repository are never pruned. A non-empty repository (normalized) limits
+// pruning to its blueprints.
+func PruneCandidates(usages []BlueprintUsage, repository string, keep int) []BlueprintUsage {
+	seen := make(map[string]int)
+	var candidates []BlueprintUsage
+	for _, u := range usages {
+		if u.Repository == "" || (repository != "" && u.Repository != repository) {
+			continue
+		}
+		seen[u.Repository]++
+		if seen[u.Repository] > keep && u.Unused() {
+			candidates = append(candidates, u)
+		}
+	}
+	return candidates
+}
+
+// blueprintName strips the scope of a blueprint ID. Names start with the
+// creation time (YYYYMMDD-HHMMSS-hash), so they sort chronologically across
+// scopes.
+func blueprintName(id string) string {
+	return id[strings.LastIndex(id, "/")+1:]
+}
@@ -0,0 +1,89 @@
+package client_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+var _ = Describe("BlueprintUsages", func() {
+	stack := func(name, env, blueprint string) types.Stack {
+		s := types.Stack{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		s.Spec.Env = env
+		s.Spec.BlueprintReference = blueprint
+		return s
+	}
+
+	It("should match stacks and newer blueprints of the same repository", func() {
+		blueprints := []client.BlueprintResponse{
+			{ID: "alice/20250101-100000-aaa", Repository: "github.com/org/app"},
+			{ID: "alice/20250301-100000-ccc", Repository: "github.com/org/app"},
+			{ID: "global/20250201-100000-bbb", Repository: "github.com/org/app"},
+			{ID: "alice/20250401-100000-ddd", Repository: "github.com/org/other"},
+			{ID: "alice/20240101-100000-eee"},
+		}
+		stacks := []types.Stack{
+			stack("web", "staging", "alice/20250101-100000-aaa"),
+			stack("api", "dev", "alice/20250101-100000-aaa"),
+			stack("new", "dev", "alice/20250301-100000-ccc"),
+			stack("gone", "dev", "alice/20230101-100000-zzz"),
+		}
+
+		usages := client.BlueprintUsages(blueprints, stacks)
+		ids := make([]string, 0, len(usages))
+		for _, u := range usages {
+			ids = append(ids, u.ID)
+		}
+		Expect(ids).To(Equal([]string{
+			"alice/20250401-100000-ddd",
+			"alice/20250301-100000-ccc",
+			"global/20250201-100000-bbb",
+			"alice/20250101-100000-aaa",
+			"alice/20240101-100000-eee",
+		}))
+
+		Expect(usages[0].Superseded()).To(BeFalse())
+		Expect(usages[1].Stacks).To(Equal([]client.StackRef{{Name: "new", Env: "dev"}}))
+		Expect(usages[1].Superseded()).To(BeFalse())
+		Expect(usages[2].Unused()).To(BeTrue())
+		Expect(usages[2].Newer).To(Equal([]string{"alice/20250301-100000-ccc"}))
+		Expect(usages[3].Stacks).To(Equal([]client.StackRef{{Name: "api", Env: "dev"}, {Name: "web", Env: "staging"}}))
+		Expect(usages[3].Newer).To(Equal([]string{"alice/20250301-100000-ccc", "global/20250201-100000-bbb"}))
+		Expect(usages[4].Unused()).To(BeTrue())
+		Expect(usages[4].Superseded()).To(BeFalse())
+	})
+
+	Describe("PruneCandidates", func() {
+		usage := func(id, repository string, stacks ...client.StackRef) client.BlueprintUsage {
+			return client.BlueprintUsage{BlueprintResponse: client.BlueprintResponse{ID: id, Repository: repository}, Stacks: stacks}
+		}
+		usages := []client.BlueprintUsage{
+			usage("a/5", "github.com/org/app"),
+			usage("a/4", "github.com/org/other"),
+			usage("a/3", "github.com/org/app"),
+			usage("a/2", "github.com/org/app", client.StackRef{Name: "web", Env: "dev"}),
+			usage("a/1", "github.com/org/app"),
+			usage("a/0", ""),
+		}
+		ids := func(usages []client.BlueprintUsage) []string {
+			var out []string
+			for _, u := range usages {
+				out = append(out, u.ID)
+			}
+			return out
+		}
+
+		It("should keep the newest per repository and used blueprints", func() {
+			Expect(ids(client.PruneCandidates(usages, "", 1))).To(Equal([]string{"a/3", "a/1"}))
+			Expect(ids(client.PruneCandidates(usages, "", 0))).To(Equal([]string{"a/5", "a/4", "a/3", "a/1"}))
+			Expect(ids(client.PruneCandidates(usages, "", 3))).To(Equal([]string{"a/1"}))
+		})
+
+		It("should limit pruning to a repository", func() {
+			Expect(ids(client.PruneCandidates(usages, "github.com/org/other", 0))).To(Equal([]string{"a/4"}))
+		})
+	})
+})
@@ -1,6 +1,9 @@
 package client
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // User represents a user in the system
 type User struct {
@@ -9,10 +12,10 @@ type User struct {
 }
 
 // GetCurrentUser fetches the current user info
-func (c *Client) GetCurrentUser() (*User, error) {
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
 	var user User
 
-	if err := c.Do("GET", "/api/v1/user/me", nil, &user); err != nil {
+	if err := c.Do(ctx, "GET", "/api/v1/user/me", nil, &user); err != nil {
 		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
 
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Remote validation check statuses
+const (
+	CheckStatusOK      = "ok"
+	CheckStatusWarning = "warning"
+	CheckStatusError   = "error"
+)
+
+// ValidateBlueprintRequest asks the API whether a compose file can be
+// deployed to an environment
+type ValidateBlueprintRequest struct {
+	Compose    string `json:"compose"`
+	Repository string `json:"repository,omitempty"`
+	Env        string `json:"env"`
+}
+
+// ValidationCheck is the result of one cluster constraint check for a
+// service: repository/registry configuration, exposed ports or resource
+// quotas
+type ValidationCheck struct {
+	Service string `json:"service"`
+	Check   string `json:"check"`  // repository, registry, ports, resources
+	Status  string `json:"status"` // ok, warning, error
+	Message string `json:"message,omitempty"`
+}
+
+// BlueprintValidation is the API's verdict on a compose file
+type BlueprintValidation struct {
+	Checks []ValidationCheck `json:"checks"`
+}
+
+// HasErrors reports whether any check would block the deployment
+func (v *BlueprintValidation) HasErrors() bool {
+	for _, c := range v.Checks {
+		if c.Status == CheckStatusError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBlueprint checks a compose file against the cluster's constraints
+// without creating a blueprint. APIs that predate the endpoint return an
+// error matching ErrNotFound.
+func (c *Client) ValidateBlueprint(ctx context.Context, req ValidateBlueprintRequest) (*BlueprintValidation, error) {
+	var result BlueprintValidation
+	if err := c.Do(ctx, "POST", "/api/v1/blueprints/validate", req, &result); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("remote validation is not supported by this Lissto API, upgrade it: %w", err)
+		}
+		return nil, fmt.Errorf("failed to validate blueprint: %w", err)
+	}
+	return &result, nil
+}
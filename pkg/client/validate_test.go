@@ -0,0 +1,44 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+var _ = Describe("ValidateBlueprint", func() {
+	It("should send the compose file and decode the checks", func() {
+		var body client.ValidateBlueprintRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.URL.Path).To(Equal("/api/v1/blueprints/validate"))
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			_, _ = w.Write([]byte(`{"checks":[{"service":"web","check":"ports","status":"error","message":"port 22 is not allowed"}]}`))
+		}))
+		defer server.Close()
+
+		result, err := client.NewClient(server.URL, "key").ValidateBlueprint(context.Background(), client.ValidateBlueprintRequest{
+			Compose: "services: {}", Repository: "github.com/org/repo", Env: "dev",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body.Env).To(Equal("dev"))
+		Expect(result.HasErrors()).To(BeTrue())
+		Expect(result.Checks[0].Message).To(Equal("port 22 is not allowed"))
+	})
+
+	It("should explain when the API predates remote validation", func() {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		_, err := client.NewClient(server.URL, "key").ValidateBlueprint(context.Background(), client.ValidateBlueprintRequest{Env: "dev"})
+		Expect(err).To(MatchError(client.ErrNotFound))
+		Expect(err).To(MatchError(ContainSubstring("not supported")))
+	})
+})
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -31,10 +32,10 @@ type UpdateVariableRequest struct {
 }
 
 // ListVariables lists all variables
-func (c *Client) ListVariables() ([]VariableResponse, error) {
+func (c *Client) ListVariables(ctx context.Context) ([]VariableResponse, error) {
 	var variables []VariableResponse
 
-	if err := c.Do("GET", "/api/v1/variables", nil, &variables); err != nil {
+	if err := c.Do(ctx, "GET", "/api/v1/variables", nil, &variables); err != nil {
 		return nil, fmt.Errorf("failed to list variables: %w", err)
 	}
 
@@ -42,11 +43,11 @@ func (c *Client) ListVariables() ([]VariableResponse, error) {
 }
 
 // GetVariable gets a specific variable
-func (c *Client) GetVariable(id, scope, env, repository string) (*VariableResponse, error) {
+func (c *Client) GetVariable(ctx context.Context, id, scope, env, repository string) (*VariableResponse, error) {
 	var variable VariableResponse
 	path := buildResourcePath("/api/v1/variables", id, scope, env, repository)
 
-	if err := c.Do("GET", path, nil, &variable); err != nil {
+	if err := c.Do(ctx, "GET", path, nil, &variable); err != nil {
 		return nil, fmt.Errorf("failed to get variable: %w", err)
 	}
 
@@ -54,10 +55,10 @@ func (c *Client) GetVariable(id, scope, env, repository string) (*VariableRespon
 }
 
 // CreateVariable creates a new variable config
-func (c *Client) CreateVariable(req *CreateVariableRequest) (*VariableResponse, error) {
+func (c *Client) CreateVariable(ctx context.Context, req *CreateVariableRequest) (*VariableResponse, error) {
 	var variable VariableResponse
 
-	if err := c.Do("POST", "/api/v1/variables", req, &variable); err != nil {
+	if err := c.Do(ctx, "POST", "/api/v1/variables", req, &variable); err != nil {
 		return nil, fmt.Errorf("failed to create variable: %w", err)
 	}
 
@@ -65,11 +66,11 @@ func (c *Client) CreateVariable(req *CreateVariableRequest) (*VariableResponse,
 }
 
 // UpdateVariable updates an existing variable config
-func (c *Client) UpdateVariable(id, scope, env, repository string, req *UpdateVariableRequest) (*VariableResponse, error) {
+func (c *Client) UpdateVariable(ctx context.Context, id, scope, env, repository string, req *UpdateVariableRequest) (*VariableResponse, error) {
 	var variable VariableResponse
 	path := buildResourcePath("/api/v1/variables", id, scope, env, repository)
 
-	if err := c.Do("PUT", path, req, &variable); err != nil {
+	if err := c.Do(ctx, "PUT", path, req, &variable); err != nil {
 		return nil, fmt.Errorf("failed to update variable: %w", err)
 	}
 
@@ -77,10 +78,10 @@ func (c *Client) UpdateVariable(id, scope, env, repository string, req *UpdateVa
 }
 
 // DeleteVariable deletes a variable config
-func (c *Client) DeleteVariable(id, scope, env, repository string) error {
+func (c *Client) DeleteVariable(ctx context.Context, id, scope, env, repository string) error {
 	path := buildResourcePath("/api/v1/variables", id, scope, env, repository)
 
-	if err := c.Do("DELETE", path, nil, nil); err != nil {
+	if err := c.Do(ctx, "DELETE", path, nil, nil); err != nil {
 		return fmt.Errorf("failed to delete variable: %w", err)
 	}
 
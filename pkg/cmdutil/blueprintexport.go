@@ -0,0 +1,97 @@
+package cmdutil
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/output"
+)
+
+// BlueprintExportKind identifies blueprint export files
+const BlueprintExportKind = "BlueprintExport"
+
+// RepositoryAnnotation carries a blueprint's source repository
+const RepositoryAnnotation = "lissto.dev/repository"
+
+// BlueprintExport is the on-disk format of an exported blueprint, written by
+// 'lissto blueprint export' and read by 'lissto blueprint import'
+type BlueprintExport struct {
+	Kind        string            `yaml:"kind"`
+	ID          string            `yaml:"id"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	Compose     string            `yaml:"compose"`
+}
+
+// MarshalBlueprintExport renders a blueprint in the export file format
+func MarshalBlueprintExport(id string, blueprint *client.BlueprintDetailedResponse) ([]byte, error) {
+	export := BlueprintExport{
+		Kind:        BlueprintExportKind,
+		ID:          id,
+		Annotations: blueprint.Metadata.Annotations,
+		Compose:     blueprint.Spec.DockerCompose,
+	}
+
+	var buf bytes.Buffer
+	if err := output.PrintYAML(&buf, export); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadBlueprintExport loads and validates a blueprint export file
+func ReadBlueprintExport(path string) (*BlueprintExport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var export BlueprintExport
+	if err := yaml.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+	if export.Kind != BlueprintExportKind {
+		return nil, fmt.Errorf("not a blueprint export (kind %q)", export.Kind)
+	}
+	if strings.TrimSpace(export.Compose) == "" {
+		return nil, fmt.Errorf("export has no compose content")
+	}
+	return &export, nil
+}
+
+// CollectBlueprintExports expands directories into their YAML files, sorted
+// by name; files are kept as given
+func CollectBlueprintExports(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", arg, err)
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", arg, err)
+		}
+		var dirFiles []string
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				dirFiles = append(dirFiles, filepath.Join(arg, entry.Name()))
+			}
+		}
+		sort.Strings(dirFiles)
+		files = append(files, dirFiles...)
+	}
+	return files, nil
+}
@@ -0,0 +1,83 @@
+package cmdutil_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("Blueprint exports", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	It("should read back an exported blueprint", func() {
+		blueprint := &client.BlueprintDetailedResponse{}
+		blueprint.Metadata.Annotations = map[string]string{cmdutil.RepositoryAnnotation: "github.com/org/app"}
+		blueprint.Spec.DockerCompose = "services:\n  web:\n    image: nginx\n"
+
+		data, err := cmdutil.MarshalBlueprintExport("global/bp-1", blueprint)
+		Expect(err).NotTo(HaveOccurred())
+		path := write("bp-1.yaml", string(data))
+
+		export, err := cmdutil.ReadBlueprintExport(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*export).To(Equal(cmdutil.BlueprintExport{
+			Kind:        cmdutil.BlueprintExportKind,
+			ID:          "global/bp-1",
+			Annotations: map[string]string{cmdutil.RepositoryAnnotation: "github.com/org/app"},
+			Compose:     "services:\n  web:\n    image: nginx\n",
+		}))
+	})
+
+	DescribeTable("should reject malformed exports",
+		func(content, message string) {
+			_, err := cmdutil.ReadBlueprintExport(write("bad.yaml", content))
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("invalid YAML", "kind: [", "failed to parse export"),
+		Entry("other kind", "kind: Stack\ncompose: x\n", `not a blueprint export (kind "Stack")`),
+		Entry("stack file", "version: v1\nblueprint: bp-1\n", `not a blueprint export (kind "")`),
+		Entry("no compose content", "kind: BlueprintExport\nid: bp-1\ncompose: \"  \"\n", "export has no compose content"),
+	)
+
+	It("should report missing files", func() {
+		_, err := cmdutil.ReadBlueprintExport(filepath.Join(dir, "missing.yaml"))
+		Expect(err).To(MatchError(ContainSubstring("failed to read file")))
+	})
+
+	It("should collect the YAML files of directories in name order", func() {
+		write("exports/b.yaml", "")
+		write("exports/a.YML", "")
+		write("exports/notes.txt", "")
+		write("exports/nested/c.yaml", "")
+		single := write("single.yaml", "")
+
+		files, err := cmdutil.CollectBlueprintExports([]string{single, filepath.Join(dir, "exports")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(Equal([]string{
+			single,
+			filepath.Join(dir, "exports", "a.YML"),
+			filepath.Join(dir, "exports", "b.yaml"),
+		}))
+	})
+
+	It("should fail for missing paths", func() {
+		_, err := cmdutil.CollectBlueprintExports([]string{filepath.Join(dir, "missing")})
+		Expect(err).To(MatchError(ContainSubstring("failed to read")))
+	})
+})
@@ -0,0 +1,43 @@
+package cmdutil
+
+import (
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+// CloneRef returns the reference a clone of a stack is prepared with, so the
+// new stack records it too: the branch, else the tag, else the commit
+func CloneRef(source *types.Stack) (branch, tag, commit string) {
+	meta := source.Spec.Metadata
+	switch {
+	case meta.Branch != "":
+		return meta.Branch, "", ""
+	case meta.Tag != "":
+		return "", meta.Tag, ""
+	}
+	return "", "", meta.Commit
+}
+
+// PinCloneImages pins the prepared images to the digests the source stack
+// runs. Services the source doesn't run keep their prepared image. It reports
+// whether any image changed, and fails with client.ErrMissingImages when a
+// service still has no image.
+func PinCloneImages(images []client.DetailedImageResolutionInfo, source *types.Stack) (bool, error) {
+	pinned := false
+	for i := range images {
+		img := &images[i]
+		sourceImg, ok := source.Spec.Images[img.Service]
+		if !ok || sourceImg.Digest == "" {
+			continue
+		}
+		if img.Digest != sourceImg.Digest {
+			pinned = true
+		}
+		img.Image, img.Digest = sourceImg.Image, sourceImg.Digest
+	}
+	if output.HasMissingImages(images) {
+		return pinned, client.ErrMissingImages
+	}
+	return pinned, nil
+}
@@ -0,0 +1,127 @@
+package cmdutil_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+var _ = Describe("Stack clone", func() {
+	DescribeTable("CloneRef",
+		func(meta envv1alpha1.StackMetadata, branch, tag, commit string) {
+			b, t, c := cmdutil.CloneRef(&types.Stack{Spec: types.StackSpec{Metadata: meta}})
+			Expect([]string{b, t, c}).To(Equal([]string{branch, tag, commit}))
+		},
+		Entry("branch wins", envv1alpha1.StackMetadata{Branch: "main", Tag: "v1", Commit: "abc123"}, "main", "", ""),
+		Entry("then the tag", envv1alpha1.StackMetadata{Tag: "v1", Commit: "abc123"}, "", "v1", ""),
+		Entry("then the commit", envv1alpha1.StackMetadata{Commit: "abc123"}, "", "", "abc123"),
+		Entry("nothing recorded", envv1alpha1.StackMetadata{}, "", "", ""),
+	)
+
+	Describe("PinCloneImages", func() {
+		var source *types.Stack
+
+		BeforeEach(func() {
+			source = &types.Stack{Spec: types.StackSpec{Images: map[string]envv1alpha1.ImageInfo{
+				"web":    {Image: "ghcr.io/acme/web", Digest: "sha256:web-old"},
+				"api":    {Image: "ghcr.io/acme/api", Digest: "sha256:api"},
+				"worker": {Image: "ghcr.io/acme/worker"},
+			}}}
+		})
+
+		It("should pin services to the source's digests", func() {
+			images := []client.DetailedImageResolutionInfo{
+				{Service: "web", Image: "ghcr.io/acme/web", Digest: "sha256:web-new"},
+				{Service: "api", Image: "ghcr.io/acme/api", Digest: "sha256:api"},
+			}
+			pinned, err := cmdutil.PinCloneImages(images, source)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pinned).To(BeTrue())
+			Expect(images[0].Digest).To(Equal("sha256:web-old"))
+			Expect(images[1].Digest).To(Equal("sha256:api"))
+		})
+
+		It("should report nothing pinned when the digests match", func() {
+			images := []client.DetailedImageResolutionInfo{
+				{Service: "api", Image: "ghcr.io/acme/api", Digest: "sha256:api"},
+			}
+			pinned, err := cmdutil.PinCloneImages(images, source)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pinned).To(BeFalse())
+		})
+
+		It("should keep the prepared image of services the source doesn't run", func() {
+			images := []client.DetailedImageResolutionInfo{
+				{Service: "web", Image: "ghcr.io/acme/web", Digest: "sha256:web-old"},
+				{Service: "cache", Image: "redis", Digest: "sha256:redis"},
+			}
+			pinned, err := cmdutil.PinCloneImages(images, source)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pinned).To(BeFalse())
+			Expect(images[1]).To(Equal(client.DetailedImageResolutionInfo{Service: "cache", Image: "redis", Digest: "sha256:redis"}))
+		})
+
+		It("should fill in images the prepare couldn't resolve", func() {
+			images := []client.DetailedImageResolutionInfo{
+				{Service: "web", Digest: "N/A"},
+			}
+			pinned, err := cmdutil.PinCloneImages(images, source)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pinned).To(BeTrue())
+			Expect(images[0].Image).To(Equal("ghcr.io/acme/web"))
+		})
+
+		It("should fail for services missing from both", func() {
+			images := []client.DetailedImageResolutionInfo{
+				{Service: "worker", Digest: "N/A"},
+				{Service: "cache", Digest: ""},
+			}
+			_, err := cmdutil.PinCloneImages(images, source)
+			Expect(err).To(MatchError(client.ErrMissingImages))
+		})
+
+		It("should send the pinned digests in the stack update", func() {
+			var method, path string
+			var body map[string]map[string]map[string]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				method, path = r.Method, r.URL.Path
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			}))
+			DeferCleanup(server.Close)
+
+			images := []client.DetailedImageResolutionInfo{
+				{Service: "web", Image: "ghcr.io/acme/web", Digest: "sha256:web-new"},
+			}
+			pinned, err := cmdutil.PinCloneImages(images, source)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pinned).To(BeTrue())
+			Expect(client.NewClient(server.URL, "key").UpdateStack(context.Background(), "stack-1", cmdutil.StackImagesMap(images))).To(Succeed())
+
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/api/v1/stacks/stack-1"))
+			Expect(body).To(Equal(map[string]map[string]map[string]string{
+				"images": {"web": {"image": "ghcr.io/acme/web", "digest": "sha256:web-old"}},
+			}))
+		})
+
+		It("should ignore services of the source missing from the prepare", func() {
+			images := []client.DetailedImageResolutionInfo{
+				{Service: "api", Image: "ghcr.io/acme/api", Digest: "sha256:api"},
+			}
+			_, err := cmdutil.PinCloneImages(images, source)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(images).To(HaveLen(1))
+		})
+	})
+})
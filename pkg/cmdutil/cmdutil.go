@@ -1,29 +1,31 @@
 package cmdutil
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/messages"
 	"github.com/lissto-dev/cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 // GetAPIClient returns configured API client from current context
-func GetAPIClient() (*client.Client, error) {
+func GetAPIClient(ctx context.Context) (*client.Client, error) {
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	ctx, err := cfg.GetCurrentContext()
+	lisstoCtx, err := cfg.GetCurrentContext()
 	if err != nil {
-		return nil, fmt.Errorf("no context selected. Run 'lissto login' first")
+		return nil, messages.Error(messages.NoActiveContext, nil)
 	}
 
-	apiClient, err := client.NewClientFromConfig(ctx)
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize API client: %w", err)
 	}
@@ -37,9 +39,9 @@ func GetAPIClientAndEnv(cmd *cobra.Command) (*client.Client, string, error) {
 		return nil, "", fmt.Errorf("failed to load config: %w", err)
 	}
 
-	ctx, err := cfg.GetCurrentContext()
+	lisstoCtx, err := cfg.GetCurrentContext()
 	if err != nil {
-		return nil, "", fmt.Errorf("no context selected. Run 'lissto login' first")
+		return nil, "", messages.Error(messages.NoActiveContext, nil)
 	}
 
 	// Get environment (from flag or config)
@@ -49,11 +51,11 @@ func GetAPIClientAndEnv(cmd *cobra.Command) (*client.Client, string, error) {
 	}
 
 	if envName == "" {
-		return nil, "", fmt.Errorf("no environment selected. Use --env flag or 'lissto env use <name>'")
+		return nil, "", messages.Error(messages.NoEnvSelected, nil)
 	}
 
 	// Create API client with k8s discovery and validation
-	apiClient, err := client.NewClientFromConfig(ctx)
+	apiClient, err := client.NewClientFromConfig(cmd.Context(), lisstoCtx)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to initialize API client: %w", err)
 	}
@@ -76,11 +78,15 @@ func GetOutputFormat(cmd *cobra.Command) string {
 	return format
 }
 
-// PrintOutput handles JSON/YAML/custom output formatting
-// If data is provided and format is json/yaml, it will be serialized
+// PrintOutput handles JSON/YAML/template/custom output formatting
+// If data is provided and format is json/yaml, go-template=... or
+// jsonpath=..., it will be serialized or rendered
 // Otherwise, customFormatter will be called for default formatting
 func PrintOutput(cmd *cobra.Command, data interface{}, customFormatter func()) error {
 	format := GetOutputFormat(cmd)
+	if output.IsTemplateFormat(format) {
+		return output.PrintTemplate(os.Stdout, format, data)
+	}
 
 	switch format {
 	case "json":
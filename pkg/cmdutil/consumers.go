@@ -0,0 +1,44 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/types"
+	controllerconfig "github.com/lissto-dev/controller/pkg/config"
+)
+
+// StacksUsingConfig returns the stacks a variable or secret config applies
+// to: every stack for global configs, the env's stacks for env configs and
+// the stacks deployed from the repository's blueprints for repo configs
+func StacksUsingConfig(ctx context.Context, apiClient *client.Client, config ScopedConfig) ([]types.Stack, error) {
+	env := ""
+	if config.Scope == ScopeEnv {
+		env = config.Env
+	}
+	stacks, err := apiClient.ListStacks(ctx, env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", err)
+	}
+	if config.Scope != ScopeRepo {
+		return stacks, nil
+	}
+
+	blueprints, err := apiClient.FindBlueprintsByRepository(ctx, controllerconfig.NormalizeRepositoryURL(config.Repository))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find blueprints of %s: %w", config.Repository, err)
+	}
+	ids := make(map[string]bool, len(blueprints))
+	for _, bp := range blueprints {
+		ids[bp.ID] = true
+	}
+
+	var matching []types.Stack
+	for _, stack := range stacks {
+		if ids[stack.Spec.BlueprintReference] {
+			matching = append(matching, stack)
+		}
+	}
+	return matching, nil
+}
@@ -0,0 +1,145 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
+	"github.com/spf13/cobra"
+)
+
+// ContextTarget is a Lissto context a read-only command runs against
+type ContextTarget struct {
+	Context *config.Context
+	Client  *client.Client
+
+	// explicit is set for contexts selected with --context or --all-contexts
+	explicit bool
+}
+
+// K8sClient returns a Kubernetes client for the context's cluster. The
+// current context keeps using kubectl's current context (or the in-cluster
+// config); other contexts use their saved kube context.
+func (t ContextTarget) K8sClient() (*k8s.Client, error) {
+	if !t.explicit {
+		return k8s.NewClient()
+	}
+	return k8s.NewClientWithContext(t.Context.Kubeconfig, t.Context.KubeContext)
+}
+
+// ContextResult is the result of a command for one context
+type ContextResult[T any] struct {
+	Context string
+	Value   T
+}
+
+// AddAllContextsFlag adds --all-contexts to a read-only command. Together
+// with the global --context flag it selects the contexts ForEachContext
+// runs against.
+func AddAllContextsFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("all-contexts", false, "Run against every configured context and merge the results")
+}
+
+// AllContexts reports whether --all-contexts was given
+func AllContexts(cmd *cobra.Command) bool {
+	all, _ := cmd.Flags().GetBool("all-contexts")
+	return all
+}
+
+// SelectContexts returns the contexts a command runs against: every
+// configured context with --all-contexts, the one named by --context, or the
+// current one. explicit is false only for the current context.
+func SelectContexts(cmd *cobra.Command) (contexts []config.Context, explicit bool, err error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	flagName, _ := cmd.Flags().GetString("context")
+	name := flagName
+	if name == "" {
+		name = os.Getenv(config.EnvContext)
+	}
+	switch {
+	case AllContexts(cmd):
+		if flagName != "" {
+			return nil, false, fmt.Errorf("--context and --all-contexts cannot be combined")
+		}
+		if len(cfg.Contexts) == 0 {
+			return nil, false, messages.Error(messages.NoActiveContext, nil)
+		}
+		return cfg.Contexts, true, nil
+	case name != "":
+		lisstoCtx, err := cfg.GetContext(name)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w (see 'lissto context list')", err)
+		}
+		return []config.Context{*lisstoCtx}, true, nil
+	default:
+		lisstoCtx, err := cfg.GetCurrentContext()
+		if err != nil {
+			return nil, false, messages.Wrap(messages.NoActiveContext, nil, err)
+		}
+		return []config.Context{*lisstoCtx}, false, nil
+	}
+}
+
+// ForEachContext runs fn concurrently for every selected context, with an API
+// client for it, and returns the results in config order. With several
+// contexts, failing ones are reported on stderr and skipped, so one
+// unreachable cluster doesn't hide the others; an error is returned only if
+// all of them fail.
+func ForEachContext[T any](cmd *cobra.Command, fn func(ctx context.Context, target ContextTarget) (T, error)) ([]ContextResult[T], error) {
+	ctx := cmd.Context()
+
+	contexts, explicit, err := SelectContexts(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ContextResult[T], len(contexts))
+	errs := make([]error, len(contexts))
+	var wg sync.WaitGroup
+	for i := range contexts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lisstoCtx := &contexts[i]
+			results[i].Context = lisstoCtx.Name
+
+			apiClient, err := newContextClient(ctx, lisstoCtx, explicit)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to initialize API client: %w", err)
+				return
+			}
+
+			results[i].Value, errs[i] = fn(ctx, ContextTarget{Context: lisstoCtx, Client: apiClient, explicit: explicit})
+		}()
+	}
+	wg.Wait()
+
+	if len(contexts) == 1 {
+		if errs[0] != nil {
+			return nil, errs[0]
+		}
+		return results, nil
+	}
+
+	succeeded := make([]ContextResult[T], 0, len(results))
+	for i, result := range results {
+		if errs[i] != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Context '%s': %v\n", result.Context, errs[i])
+			continue
+		}
+		succeeded = append(succeeded, result)
+	}
+	if len(succeeded) == 0 {
+		return nil, fmt.Errorf("all %d contexts failed: %w", len(contexts), errs[0])
+	}
+	return succeeded, nil
+}
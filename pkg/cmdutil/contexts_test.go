@@ -0,0 +1,92 @@
+package cmdutil_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("SelectContexts", func() {
+	var cmd *cobra.Command
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", GinkgoT().TempDir())
+		Expect(config.SaveConfig(&config.Config{
+			CurrentContext: "dev",
+			Contexts:       []config.Context{{Name: "dev"}, {Name: "staging"}},
+		})).To(Succeed())
+
+		cmd = &cobra.Command{Use: "test"}
+		cmd.Flags().String("context", "", "")
+		cmdutil.AddAllContextsFlag(cmd)
+	})
+
+	names := func(contexts []config.Context) []string {
+		var result []string
+		for _, ctx := range contexts {
+			result = append(result, ctx.Name)
+		}
+		return result
+	}
+
+	It("should default to the current context", func() {
+		contexts, explicit, err := cmdutil.SelectContexts(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(explicit).To(BeFalse())
+		Expect(names(contexts)).To(Equal([]string{"dev"}))
+	})
+
+	It("should select the context named by --context", func() {
+		Expect(cmd.ParseFlags([]string{"--context", "staging"})).To(Succeed())
+		contexts, explicit, err := cmdutil.SelectContexts(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(explicit).To(BeTrue())
+		Expect(names(contexts)).To(Equal([]string{"staging"}))
+	})
+
+	It("should select the context named by LISSTO_CONTEXT", func() {
+		GinkgoT().Setenv(config.EnvContext, "staging")
+		contexts, explicit, err := cmdutil.SelectContexts(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(explicit).To(BeTrue())
+		Expect(names(contexts)).To(Equal([]string{"staging"}))
+
+		Expect(cmd.ParseFlags([]string{"--all-contexts"})).To(Succeed())
+		contexts, _, err = cmdutil.SelectContexts(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(contexts)).To(Equal([]string{"dev", "staging"}))
+	})
+
+	It("should make an overridden context active without saving it", func() {
+		config.SetContextOverride("staging")
+		DeferCleanup(config.SetContextOverride, "")
+
+		cfg, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		lisstoCtx, err := cfg.GetCurrentContext()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lisstoCtx.Name).To(Equal("staging"))
+		Expect(cfg.ActiveContextName()).To(Equal("staging"))
+		Expect(cfg.CurrentContext).To(Equal("dev"))
+	})
+
+	It("should select every context with --all-contexts", func() {
+		Expect(cmd.ParseFlags([]string{"--all-contexts"})).To(Succeed())
+		contexts, _, err := cmdutil.SelectContexts(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(contexts)).To(Equal([]string{"dev", "staging"}))
+	})
+
+	It("should reject unknown contexts and conflicting flags", func() {
+		Expect(cmd.ParseFlags([]string{"--context", "prod"})).To(Succeed())
+		_, _, err := cmdutil.SelectContexts(cmd)
+		Expect(err).To(MatchError(ContainSubstring("context 'prod' not found")))
+
+		Expect(cmd.ParseFlags([]string{"--all-contexts"})).To(Succeed())
+		_, _, err = cmdutil.SelectContexts(cmd)
+		Expect(err).To(MatchError(ContainSubstring("cannot be combined")))
+	})
+})
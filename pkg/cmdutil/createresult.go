@@ -0,0 +1,70 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/output"
+)
+
+// CreateResult is the machine-readable result of a stack creation. Besides
+// the stack, it keeps the image preview -o json and -o yaml printed before
+// the result existed, under the same keys, so scripts reading it still work.
+type CreateResult struct {
+	ID        string       `json:"id" yaml:"id"`
+	Env       string       `json:"env" yaml:"env"`
+	Blueprint string       `json:"blueprint" yaml:"blueprint"`
+	URLs      []CreatedURL `json:"urls" yaml:"urls"`
+
+	RequestID string                               `json:"request_id" yaml:"requestid"`
+	Images    []client.DetailedImageResolutionInfo `json:"images" yaml:"images"`
+	Exposed   []client.ExposedServiceInfo          `json:"exposed,omitempty" yaml:"exposed"`
+}
+
+// CreatedURL is an exposed service URL of a created stack
+type CreatedURL struct {
+	Service string `json:"service" yaml:"service"`
+	URL     string `json:"url" yaml:"url"`
+}
+
+// NewCreateResult returns the result of creating a stack from a prepared request
+func NewCreateResult(stackID, env, blueprintID string, prepareResp *client.PrepareStackResponse) *CreateResult {
+	result := &CreateResult{
+		ID:        stackID,
+		Env:       env,
+		Blueprint: blueprintID,
+		URLs:      []CreatedURL{},
+		RequestID: prepareResp.RequestID,
+		Images:    prepareResp.Images,
+		Exposed:   prepareResp.Exposed,
+	}
+	for _, exp := range prepareResp.Exposed {
+		result.URLs = append(result.URLs, CreatedURL{Service: exp.Service, URL: "https://" + exp.URL})
+	}
+	return result
+}
+
+// IsMachineCreateOutput reports whether create emits a machine-readable
+// result for an --output format: with --quiet, -o id, -o json or -o yaml
+func IsMachineCreateOutput(format string, quiet bool) bool {
+	switch format {
+	case "id", "json", "yaml":
+		return true
+	}
+	return quiet
+}
+
+// PrintCreateResult writes a create result in a machine-readable format: the
+// stack ID alone unless the format is json or yaml
+func PrintCreateResult(w io.Writer, format string, result *CreateResult) error {
+	switch format {
+	case "json":
+		return output.PrintJSON(w, result)
+	case "yaml":
+		return output.PrintYAML(w, result)
+	default:
+		_, err := fmt.Fprintln(w, result.ID)
+		return err
+	}
+}
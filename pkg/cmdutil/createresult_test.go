@@ -0,0 +1,79 @@
+package cmdutil_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/output"
+)
+
+var _ = Describe("CreateResult", func() {
+	prepareResp := &client.PrepareStackResponse{
+		RequestID: "req-1",
+		Blueprint: "bp-1",
+		Images:    []client.DetailedImageResolutionInfo{{Service: "web", Image: "ghcr.io/acme/web", Digest: "sha256:abc"}},
+		Exposed:   []client.ExposedServiceInfo{{Service: "web", URL: "web.dev.example.com"}},
+	}
+	result := cmdutil.NewCreateResult("dev-ns/stack-1", "dev", "bp-1", prepareResp)
+
+	DescribeTable("IsMachineCreateOutput",
+		func(format string, quiet, machine bool) {
+			Expect(cmdutil.IsMachineCreateOutput(format, quiet)).To(Equal(machine))
+		},
+		Entry("default output", "", false, false),
+		Entry("table", "table", false, false),
+		Entry("quiet", "", true, true),
+		Entry("id", "id", false, true),
+		Entry("json", "json", false, true),
+		Entry("yaml", "yaml", false, true),
+	)
+
+	It("should print only the stack ID by default", func() {
+		var out bytes.Buffer
+		Expect(cmdutil.PrintCreateResult(&out, "", result)).To(Succeed())
+		Expect(out.String()).To(Equal("dev-ns/stack-1\n"))
+
+		out.Reset()
+		Expect(cmdutil.PrintCreateResult(&out, "id", result)).To(Succeed())
+		Expect(out.String()).To(Equal("dev-ns/stack-1\n"))
+	})
+
+	// decode reads printed output back into a generic map
+	decode := func(format string, print func(*bytes.Buffer) error) map[string]interface{} {
+		var out bytes.Buffer
+		Expect(print(&out)).To(Succeed())
+		var decoded map[string]interface{}
+		if format == "json" {
+			Expect(json.Unmarshal(out.Bytes(), &decoded)).To(Succeed())
+		} else {
+			Expect(yaml.Unmarshal(out.Bytes(), &decoded)).To(Succeed())
+		}
+		return decoded
+	}
+
+	DescribeTable("should print the stack and keep the preview keys",
+		func(format string, printPreview func(*bytes.Buffer, *client.PrepareStackResponse) error) {
+			printed := decode(format, func(out *bytes.Buffer) error { return cmdutil.PrintCreateResult(out, format, result) })
+			Expect(printed).To(HaveKeyWithValue("id", "dev-ns/stack-1"))
+			Expect(printed).To(HaveKeyWithValue("env", "dev"))
+			Expect(printed).To(HaveKeyWithValue("urls", ConsistOf(HaveKeyWithValue("url", "https://web.dev.example.com"))))
+
+			preview := decode(format, func(out *bytes.Buffer) error { return printPreview(out, prepareResp) })
+			for key, value := range preview {
+				Expect(printed).To(HaveKeyWithValue(key, value))
+			}
+		},
+		Entry("json", "json", func(out *bytes.Buffer, resp *client.PrepareStackResponse) error {
+			return output.PrintImagePreviewJSON(out, resp)
+		}),
+		Entry("yaml", "yaml", func(out *bytes.Buffer, resp *client.PrepareStackResponse) error {
+			return output.PrintImagePreviewYAML(out, resp)
+		}),
+	)
+})
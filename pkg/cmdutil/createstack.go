@@ -0,0 +1,24 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+// CreateStack creates a stack from a prepare request and, when images is
+// set, replaces the prepared images with them. The create request only
+// deploys the prepared images, so images are applied with an update.
+func CreateStack(ctx context.Context, apiClient *client.Client, blueprint, env, requestID string, opts client.CreateStackOptions, images map[string]interface{}) (string, error) {
+	stackID, err := apiClient.CreateStackWithOptions(ctx, blueprint, env, requestID, opts)
+	if err != nil {
+		return "", err
+	}
+	if len(images) > 0 {
+		if err := apiClient.UpdateStack(ctx, client.StackName(stackID), images); err != nil {
+			return stackID, fmt.Errorf("stack %s created but failed to apply images: %w", stackID, err)
+		}
+	}
+	return stackID, nil
+}
@@ -0,0 +1,71 @@
+package cmdutil_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("CreateStack", func() {
+	// request is a request received by the fake API
+	type request struct {
+		Method string
+		Path   string
+		Body   map[string]interface{}
+	}
+
+	var (
+		server   *httptest.Server
+		requests []request
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			req := request{Method: r.Method, Path: r.URL.Path}
+			Expect(json.NewDecoder(r.Body).Decode(&req.Body)).To(Succeed())
+			requests = append(requests, req)
+			if r.Method == http.MethodPost {
+				_, _ = w.Write([]byte("dev-ns/stack-1"))
+			}
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	create := func(images map[string]interface{}) (string, error) {
+		return cmdutil.CreateStack(context.Background(), client.NewClient(server.URL, "key"), "bp", "dev", "req-1", client.CreateStackOptions{}, images)
+	}
+
+	It("should apply image overrides with an update after the create", func() {
+		id, err := create(cmdutil.StackImagesMap([]client.DetailedImageResolutionInfo{
+			{Service: "web", Image: "ghcr.io/acme/web", Digest: "sha256:web-feature"},
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("dev-ns/stack-1"))
+
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[0].Method).To(Equal(http.MethodPost))
+		Expect(requests[0].Body).NotTo(HaveKey("images"))
+		Expect(requests[1].Method).To(Equal(http.MethodPut))
+		Expect(requests[1].Path).To(Equal("/api/v1/stacks/stack-1"))
+		Expect(requests[1].Body).To(HaveKeyWithValue("images", HaveKeyWithValue("web", Equal(map[string]interface{}{
+			"image":  "ghcr.io/acme/web",
+			"digest": "sha256:web-feature",
+		}))))
+	})
+
+	It("should only create the stack without overrides", func() {
+		_, err := create(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Method).To(Equal(http.MethodPost))
+	})
+})
@@ -0,0 +1,45 @@
+package cmdutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// DefaultsCommand returns the name a command's defaults are configured
+// under: its path without the root command, joined with dots ("stack.list")
+func DefaultsCommand(cmd *cobra.Command) string {
+	return strings.Join(strings.Fields(cmd.CommandPath())[1:], ".")
+}
+
+// ApplyDefaults sets flags the user didn't give to their configured default
+// values. Flags given on the command line always win. Defaults for flags the
+// command doesn't have are skipped and reported in the error.
+func ApplyDefaults(cmd *cobra.Command, defaults map[string]string) error {
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []string
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			errs = append(errs, fmt.Sprintf("unknown flag --%s", name))
+			continue
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(name, defaults[name]); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid value for --%s: %v", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid defaults for '%s': %s", DefaultsCommand(cmd), strings.Join(errs, "; "))
+	}
+	return nil
+}
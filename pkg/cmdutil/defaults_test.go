@@ -0,0 +1,58 @@
+package cmdutil_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("ApplyDefaults", func() {
+	var (
+		root, logs *cobra.Command
+		tail       int
+		follow     bool
+	)
+
+	BeforeEach(func() {
+		root = &cobra.Command{Use: "lissto"}
+		root.PersistentFlags().StringP("output", "o", "", "")
+		stack := &cobra.Command{Use: "stack"}
+		logs = &cobra.Command{Use: "logs", Run: func(*cobra.Command, []string) {}}
+		logs.Flags().IntVar(&tail, "tail", -1, "")
+		logs.Flags().BoolVarP(&follow, "follow", "f", false, "")
+		stack.AddCommand(logs)
+		root.AddCommand(stack)
+	})
+
+	parse := func(args ...string) {
+		Expect(logs.ParseFlags(args)).To(Succeed())
+	}
+
+	It("should name commands by their path", func() {
+		Expect(cmdutil.DefaultsCommand(logs)).To(Equal("stack.logs"))
+	})
+
+	It("should set flags that weren't given", func() {
+		parse()
+		Expect(cmdutil.ApplyDefaults(logs, map[string]string{"tail": "100", "follow": "true", "output": "json"})).To(Succeed())
+		Expect(tail).To(Equal(100))
+		Expect(follow).To(BeTrue())
+		Expect(logs.Flags().Lookup("output").Value.String()).To(Equal("json"))
+	})
+
+	It("should keep flags given on the command line", func() {
+		parse("--tail", "5")
+		Expect(cmdutil.ApplyDefaults(logs, map[string]string{"tail": "100"})).To(Succeed())
+		Expect(tail).To(Equal(5))
+	})
+
+	It("should report unknown flags and invalid values but apply the rest", func() {
+		parse()
+		err := cmdutil.ApplyDefaults(logs, map[string]string{"tial": "100", "follow": "maybe", "tail": "20"})
+		Expect(err).To(MatchError(ContainSubstring("unknown flag --tial")))
+		Expect(err).To(MatchError(ContainSubstring("invalid value for --follow")))
+		Expect(tail).To(Equal(20))
+	})
+})
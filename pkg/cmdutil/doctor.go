@@ -0,0 +1,163 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/lissto-dev/cli/pkg/cache"
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+// Check results
+const (
+	CheckOK   = "ok"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+	CheckSkip = "skip"
+)
+
+// Check is the result of one diagnostic check
+type Check struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+	Fix    string `json:"fix,omitempty" yaml:"fix,omitempty"`
+}
+
+// Checklist collects the results of checks that run in a fixed order
+type Checklist struct {
+	names  []string
+	Checks []Check
+}
+
+// NewChecklist returns an empty checklist for the named checks, in run order
+func NewChecklist(names ...string) *Checklist {
+	return &Checklist{names: names}
+}
+
+// Add records the result of the next check
+func (l *Checklist) Add(name, status, detail, fix string) {
+	l.Checks = append(l.Checks, Check{Name: name, Status: status, Detail: detail, Fix: fix})
+}
+
+// SkipRest marks every check that hasn't run as skipped and returns all results
+func (l *Checklist) SkipRest() []Check {
+	if len(l.Checks) < len(l.names) {
+		for _, name := range l.names[len(l.Checks):] {
+			l.Checks = append(l.Checks, Check{Name: name, Status: CheckSkip})
+		}
+	}
+	return l.Checks
+}
+
+// FailedChecks counts the failed checks
+func FailedChecks(checks []Check) int {
+	failed := 0
+	for _, c := range checks {
+		if c.Status == CheckFail {
+			failed++
+		}
+	}
+	return failed
+}
+
+// ChecksError returns an error if any check failed
+func ChecksError(checks []Check) error {
+	if failed := FailedChecks(checks); failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// CheckAPIID compares the API instance ID saved in a context with the one
+// the API reports. It returns false on a mismatch.
+func (l *Checklist) CheckAPIID(saved, actual string) bool {
+	switch {
+	case saved == "":
+		l.Add("API instance ID", CheckWarn, "no API ID cached yet", "It is saved on the next command that connects")
+	case actual != saved:
+		l.Add("API instance ID", CheckFail,
+			fmt.Sprintf("saved context expects '%s' but the cluster runs '%s'", saved, actual),
+			"The API was reinstalled or the context points elsewhere; run 'lissto login' again")
+		return false
+	default:
+		l.Add("API instance ID", CheckOK, actual, "")
+	}
+	return true
+}
+
+// CheckUser records the check named name as passed if the API accepts the
+// client's credentials. It returns false if they are rejected.
+func (l *Checklist) CheckUser(ctx context.Context, apiClient *client.Client, name, fix string) bool {
+	user, err := apiClient.GetCurrentUser(ctx)
+	if err != nil {
+		l.Add(name, CheckFail, err.Error(), fix)
+		return false
+	}
+	l.Add(name, CheckOK, fmt.Sprintf("authenticated as %s (%s)", user.Name, user.Role), "")
+	return true
+}
+
+// CheckEnv verifies the current env exists and refreshes the env cache of
+// the context, adding the "Environment" and "Env cache" checks
+func (l *Checklist) CheckEnv(ctx context.Context, apiClient *client.Client, contextName, currentEnv string) {
+	envs, listErr := ListEnvsCached(ctx, apiClient, contextName, true)
+	switch {
+	case currentEnv == "":
+		l.Add("Environment", CheckWarn, "no current environment selected", "Run 'lissto env use <name>'")
+	case listErr != nil:
+		l.Add("Environment", CheckFail, listErr.Error(), "Check the API logs")
+	case hasEnv(envs, currentEnv):
+		l.Add("Environment", CheckOK, fmt.Sprintf("'%s'", currentEnv), "")
+	default:
+		l.Add("Environment", CheckFail, fmt.Sprintf("current environment '%s' no longer exists", currentEnv), "Run 'lissto env list' and 'lissto env use <name>'")
+	}
+	if listErr != nil {
+		l.Add("Env cache", CheckSkip, "environments could not be listed", "")
+		return
+	}
+
+	c, err := cache.Default()
+	if err != nil {
+		l.Add("Env cache", CheckWarn, err.Error(), "Set $XDG_CACHE_HOME to a writable directory")
+		return
+	}
+	entry, found, err := cache.GetWithMeta[[]client.EnvResponse](c, EnvCacheKey(contextName))
+	switch {
+	case err != nil:
+		l.Add("Env cache", CheckWarn, err.Error(), "Run 'lissto env list --refresh' to rebuild it")
+	case !found:
+		l.Add("Env cache", CheckWarn, "env cache could not be written", "Check that $XDG_CACHE_HOME/lissto is writable")
+	default:
+		l.Add("Env cache", CheckOK, fmt.Sprintf("%d env(s) cached", len(entry.Data)), "")
+	}
+}
+
+// PrintChecks prints one line per check, with the fix of those that didn't pass
+func PrintChecks(w io.Writer, checks []Check) {
+	for _, c := range checks {
+		var symbol string
+		switch c.Status {
+		case CheckOK:
+			symbol = "✅"
+		case CheckWarn:
+			symbol = "⚠️ "
+		case CheckFail:
+			symbol = "❌"
+		default:
+			symbol = "⏭️ "
+		}
+
+		line := fmt.Sprintf("%s %s", symbol, c.Name)
+		if c.Detail != "" {
+			line += ": " + c.Detail
+		} else if c.Status == CheckSkip {
+			line += ": skipped"
+		}
+		fmt.Fprintln(w, line)
+		if c.Fix != "" && c.Status != CheckOK {
+			fmt.Fprintf(w, "   💡 %s\n", c.Fix)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package cmdutil_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("Checklist", func() {
+	var (
+		// userStatus and envsStatus answer /api/v1/user/me and /api/v1/envs
+		userStatus, envsStatus int
+		apiClient              *client.Client
+		checks                 *cmdutil.Checklist
+	)
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CACHE_HOME", GinkgoT().TempDir())
+		userStatus, envsStatus = http.StatusOK, http.StatusOK
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/v1/user/me":
+				w.WriteHeader(userStatus)
+				if userStatus == http.StatusOK {
+					_, _ = w.Write([]byte(`{"name":"alice","role":"admin"}`))
+				}
+			case "/api/v1/envs":
+				w.WriteHeader(envsStatus)
+				if envsStatus == http.StatusOK {
+					_, _ = w.Write([]byte(`[{"id":"ns/dev","name":"dev"}]`))
+				}
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(server.Close)
+		apiClient = client.NewClient(server.URL, "key")
+		checks = cmdutil.NewChecklist("API key", "Environment", "Env cache")
+	})
+
+	// run mirrors doctor: the env checks only run with a valid key
+	run := func(currentEnv string) []cmdutil.Check {
+		ctx := context.Background()
+		if !checks.CheckUser(ctx, apiClient, "API key", "Run 'lissto login'") {
+			return checks.SkipRest()
+		}
+		checks.CheckEnv(ctx, apiClient, "ctx", currentEnv)
+		return checks.Checks
+	}
+
+	It("passes every check against a healthy API", func() {
+		result := run("dev")
+
+		Expect(result).To(Equal([]cmdutil.Check{
+			{Name: "API key", Status: cmdutil.CheckOK, Detail: "authenticated as alice (admin)"},
+			{Name: "Environment", Status: cmdutil.CheckOK, Detail: "'dev'"},
+			{Name: "Env cache", Status: cmdutil.CheckOK, Detail: "1 env(s) cached"},
+		}))
+		Expect(cmdutil.FailedChecks(result)).To(BeZero())
+		Expect(cmdutil.ChecksError(result)).To(Succeed())
+	})
+
+	It("fails a rejected key and skips the checks that depend on it", func() {
+		userStatus = http.StatusUnauthorized
+
+		result := run("dev")
+
+		Expect(result).To(HaveLen(3))
+		Expect(result[0].Status).To(Equal(cmdutil.CheckFail))
+		Expect(result[0].Fix).To(Equal("Run 'lissto login'"))
+		Expect(result[1:]).To(Equal([]cmdutil.Check{
+			{Name: "Environment", Status: cmdutil.CheckSkip},
+			{Name: "Env cache", Status: cmdutil.CheckSkip},
+		}))
+		Expect(cmdutil.FailedChecks(result)).To(Equal(1))
+		Expect(cmdutil.ChecksError(result)).To(MatchError("1 check(s) failed"))
+	})
+
+	It("fails an environment that no longer exists", func() {
+		result := run("gone")
+
+		Expect(result[1].Status).To(Equal(cmdutil.CheckFail))
+		Expect(result[1].Detail).To(Equal("current environment 'gone' no longer exists"))
+		Expect(result[2].Status).To(Equal(cmdutil.CheckOK))
+		Expect(cmdutil.FailedChecks(result)).To(Equal(1))
+	})
+
+	It("warns without a current environment", func() {
+		result := run("")
+
+		Expect(result[1].Status).To(Equal(cmdutil.CheckWarn))
+		Expect(cmdutil.ChecksError(result)).To(Succeed())
+	})
+
+	It("skips the env cache when environments can't be listed", func() {
+		envsStatus = http.StatusInternalServerError
+
+		result := run("dev")
+
+		Expect(result[1].Status).To(Equal(cmdutil.CheckFail))
+		Expect(result[2]).To(Equal(cmdutil.Check{Name: "Env cache", Status: cmdutil.CheckSkip, Detail: "environments could not be listed"}))
+		Expect(cmdutil.FailedChecks(result)).To(Equal(1))
+	})
+
+	It("stops on an API instance ID mismatch", func() {
+		Expect(checks.CheckAPIID("", "abc")).To(BeTrue())
+		Expect(checks.CheckAPIID("abc", "abc")).To(BeTrue())
+		Expect(checks.CheckAPIID("abc", "def")).To(BeFalse())
+		Expect(checks.Checks[2].Status).To(Equal(cmdutil.CheckFail))
+		Expect(cmdutil.FailedChecks(checks.Checks)).To(Equal(1))
+	})
+
+	It("prints the fix of checks that didn't pass", func() {
+		var out bytes.Buffer
+		cmdutil.PrintChecks(&out, []cmdutil.Check{
+			{Name: "API key", Status: cmdutil.CheckOK, Detail: "authenticated as alice (admin)", Fix: "unused"},
+			{Name: "Environment", Status: cmdutil.CheckFail, Detail: "gone", Fix: "Run 'lissto env use <name>'"},
+			{Name: "Env cache", Status: cmdutil.CheckSkip},
+		})
+
+		Expect(out.String()).To(Equal("✅ API key: authenticated as alice (admin)\n" +
+			"❌ Environment: gone\n" +
+			"   💡 Run 'lissto env use <name>'\n" +
+			"⏭️  Env cache: skipped\n"))
+	})
+})
@@ -0,0 +1,186 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// dotenvKey matches valid .env variable names
+var dotenvKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// dotenvEscapes are the escape sequences expanded in double-quoted values
+var dotenvEscapes = strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`)
+
+// ReadDotEnvFile parses a .env file
+func ReadDotEnvFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	values, err := ParseDotEnv(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+// ParseDotEnv parses .env content: KEY=value lines with optional "export"
+// prefixes, # comments, and single- or double-quoted values that may span
+// lines. Double-quoted values expand \n, \r, \t, \" and \\; single-quoted
+// values are literal. Later keys override earlier ones.
+func ParseDotEnv(content string) (map[string]string, error) {
+	values := make(map[string]string)
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, rest, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !dotenvKey.MatchString(key) {
+			return nil, fmt.Errorf("line %d: expected KEY=value, got %q", lineNo, lines[i])
+		}
+		rest = strings.TrimLeft(rest, " \t")
+
+		if rest == "" || (rest[0] != '"' && rest[0] != '\'') {
+			values[key] = unquotedValue(rest)
+			continue
+		}
+
+		// Quoted value: read until the closing quote, possibly on a later line
+		quote := rest[0]
+		value := rest[1:]
+		for {
+			if end := closingQuote(value, quote); end >= 0 {
+				if trailing := strings.TrimSpace(value[end+1:]); trailing != "" && !strings.HasPrefix(trailing, "#") {
+					return nil, fmt.Errorf("line %d: unexpected text after closing quote: %q", lineNo, trailing)
+				}
+				value = value[:end]
+				break
+			}
+			i++
+			if i >= len(lines) {
+				return nil, fmt.Errorf("line %d: unterminated quoted value for %s", lineNo, key)
+			}
+			value += "\n" + lines[i]
+		}
+
+		if quote == '"' {
+			value = dotenvEscapes.Replace(value)
+		}
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// unquotedValue trims an unquoted value and strips an inline " #" comment
+func unquotedValue(value string) string {
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = value[:idx]
+	}
+	return strings.TrimSpace(value)
+}
+
+// closingQuote returns the index of the unescaped closing quote in s, or -1
+func closingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote == '"':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// CollectKeyValues merges KEY=value pairs from an optional .env file with
+// KEY=value arguments; arguments override file values
+func CollectKeyValues(args []string, fromFile string) (map[string]string, error) {
+	if len(args) == 0 && fromFile == "" {
+		return nil, fmt.Errorf("provide KEY=value pairs or --from-file")
+	}
+
+	data := make(map[string]string)
+	if fromFile != "" {
+		values, err := ReadDotEnvFile(fromFile)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("no variables found in %s", fromFile)
+		}
+		for k, v := range values {
+			data[k] = v
+		}
+	}
+
+	parsed, err := ParseKeyValueArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range parsed {
+		data[k] = v
+	}
+	return data, nil
+}
+
+// dotenvBare matches values that can be written without quotes
+var dotenvBare = regexp.MustCompile(`^[A-Za-z0-9_./:@,+=-]*$`)
+
+// FormatDotEnv renders values as sorted KEY=value lines that ParseDotEnv and
+// docker compose env_file can read back
+func FormatDotEnv(values map[string]string) string {
+	keys := GetKeysFromMap(values)
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(quoteDotEnvValue(values[key]))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// quoteDotEnvValue quotes a value only when needed: single quotes keep it
+// literal, double quotes are used for newlines and single quotes
+func quoteDotEnvValue(value string) string {
+	switch {
+	case dotenvBare.MatchString(value):
+		return value
+	case !strings.ContainsAny(value, "'\n\r"):
+		return "'" + value + "'"
+	default:
+		escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+		return `"` + escaper.Replace(value) + `"`
+	}
+}
+
+// PrintExport prints exported key/values as dotenv (the default), or data as
+// JSON, YAML or a template
+func PrintExport(cmd *cobra.Command, data interface{}, values map[string]string) error {
+	switch format := GetOutputFormat(cmd); {
+	case format == "" || format == "dotenv":
+		fmt.Print(FormatDotEnv(values))
+		return nil
+	case format == "json" || format == "yaml" || output.IsTemplateFormat(format):
+		return PrintOutput(cmd, data, nil)
+	default:
+		return fmt.Errorf("unsupported output format '%s' for export (use dotenv, json, yaml, go-template= or jsonpath=)", format)
+	}
+}
@@ -0,0 +1,116 @@
+package cmdutil_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("ParseDotEnv", func() {
+	It("should parse plain, quoted and exported values", func() {
+		values, err := cmdutil.ParseDotEnv(`# database settings
+DB_HOST=localhost
+export DB_PORT=5432
+DB_USER = admin # inline comment
+DB_PASS='p@ss #not a comment'
+GREETING="hello\tworld\n"
+EMPTY=
+URL=http://example.com/#anchor
+`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(map[string]string{
+			"DB_HOST":  "localhost",
+			"DB_PORT":  "5432",
+			"DB_USER":  "admin",
+			"DB_PASS":  "p@ss #not a comment",
+			"GREETING": "hello\tworld\n",
+			"EMPTY":    "",
+			"URL":      "http://example.com/#anchor",
+		}))
+	})
+
+	It("should parse multiline quoted values", func() {
+		values, err := cmdutil.ParseDotEnv("CERT=\"-----BEGIN-----\nabc\n-----END-----\"\nSQL='a\n\\n b'\nNEXT=1\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveKeyWithValue("CERT", "-----BEGIN-----\nabc\n-----END-----"))
+		Expect(values).To(HaveKeyWithValue("SQL", "a\n\\n b"))
+		Expect(values).To(HaveKeyWithValue("NEXT", "1"))
+	})
+
+	It("should keep escaped quotes inside double quotes", func() {
+		values, err := cmdutil.ParseDotEnv(`MSG="say \"hi\""`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveKeyWithValue("MSG", `say "hi"`))
+	})
+
+	It("should let later keys override earlier ones", func() {
+		values, err := cmdutil.ParseDotEnv("A=1\r\nA=2\r\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(map[string]string{"A": "2"}))
+	})
+
+	DescribeTable("should report the offending line",
+		func(content, message string) {
+			_, err := cmdutil.ParseDotEnv(content)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("missing equals", "A=1\nNOT_AN_ASSIGNMENT\n", "line 2: expected KEY=value"),
+		Entry("invalid key", "1KEY=value", "line 1: expected KEY=value"),
+		Entry("unterminated quote", "A=1\nB=\"open\nstill open\n", "line 2: unterminated quoted value for B"),
+		Entry("text after quote", `A="x" y`, "line 1: unexpected text after closing quote"),
+	)
+})
+
+var _ = Describe("CollectKeyValues", func() {
+	var envFile string
+
+	BeforeEach(func() {
+		envFile = filepath.Join(GinkgoT().TempDir(), ".env")
+		Expect(os.WriteFile(envFile, []byte("A=from-file\nB=from-file\n"), 0o600)).To(Succeed())
+	})
+
+	It("should let arguments override file values", func() {
+		data, err := cmdutil.CollectKeyValues([]string{"B=from-arg", "C=from-arg"}, envFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal(map[string]string{"A": "from-file", "B": "from-arg", "C": "from-arg"}))
+	})
+
+	It("should require at least one source", func() {
+		_, err := cmdutil.CollectKeyValues(nil, "")
+		Expect(err).To(MatchError(ContainSubstring("--from-file")))
+	})
+
+	It("should reject an empty file", func() {
+		Expect(os.WriteFile(envFile, []byte("# nothing here\n"), 0o600)).To(Succeed())
+		_, err := cmdutil.CollectKeyValues(nil, envFile)
+		Expect(err).To(MatchError(ContainSubstring("no variables found")))
+	})
+})
+
+var _ = Describe("FormatDotEnv", func() {
+	It("should write sorted lines and quote only when needed", func() {
+		Expect(cmdutil.FormatDotEnv(map[string]string{
+			"B": "plain-value",
+			"A": "has spaces",
+			"C": "it's\nmultiline",
+			"D": "",
+		})).To(Equal("A='has spaces'\nB=plain-value\nC=\"it's\\nmultiline\"\nD=\n"))
+	})
+
+	It("should round-trip through ParseDotEnv", func() {
+		values := map[string]string{
+			"URL":    "postgres://u:p@db:5432/app?sslmode=disable",
+			"QUOTES": `say "hi" and 'bye'`,
+			"HASH":   "a #b",
+			"TABS":   "a\tb\\c",
+			"LINES":  "one\ntwo\n",
+		}
+		parsed, err := cmdutil.ParseDotEnv(cmdutil.FormatDotEnv(values))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(values))
+	})
+})
@@ -0,0 +1,103 @@
+package cmdutil
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// MaskedValue replaces the values of secret-sourced environment variables
+const MaskedValue = "********"
+
+// Sources of an effective environment variable
+const (
+	EnvSourceVariable  = "variable"
+	EnvSourceSecret    = "secret"
+	EnvSourceCompose   = "compose"
+	EnvSourceReference = "reference"
+)
+
+// EnvEntry is an environment variable a container received and where its
+// value comes from
+type EnvEntry struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+	// Masked is set when Value hides a secret
+	Masked bool `json:"masked,omitempty" yaml:"masked,omitempty"`
+	// Source is variable, secret, compose (set in the blueprint) or reference
+	// (read from another Kubernetes object)
+	Source string `json:"source" yaml:"source"`
+	Scope  string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	// Config names the variable or secret config, or the referenced object
+	Config string `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// EffectiveEnv lists the environment of a container, sorted by name, with
+// each variable attributed to the merged variable or secret config providing
+// it (see MergeScopes). Values from secrets are masked.
+func EffectiveEnv(container *corev1.Container, variables, secrets map[string]ScopedValue) []EnvEntry {
+	byName := make(map[string]EnvEntry)
+	for _, from := range container.EnvFrom {
+		// The keys of referenced objects aren't in the pod spec
+		entry := EnvEntry{Name: from.Prefix + "*", Source: EnvSourceReference}
+		switch {
+		case from.SecretRef != nil:
+			entry.Value, entry.Masked, entry.Config = MaskedValue, true, "secret/"+from.SecretRef.Name
+		case from.ConfigMapRef != nil:
+			entry.Config = "configmap/" + from.ConfigMapRef.Name
+		}
+		byName[entry.Name] = entry
+	}
+	// Later definitions of a name win, as in Kubernetes
+	for _, env := range container.Env {
+		byName[env.Name] = envEntry(env, variables, secrets)
+	}
+
+	entries := make([]EnvEntry, 0, len(byName))
+	for _, entry := range byName {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// envEntry attributes one environment variable
+func envEntry(env corev1.EnvVar, variables, secrets map[string]ScopedValue) EnvEntry {
+	entry := EnvEntry{Name: env.Name, Value: env.Value}
+	if from := env.ValueFrom; from != nil {
+		switch {
+		case from.SecretKeyRef != nil:
+			entry.Value, entry.Masked = MaskedValue, true
+			if s, ok := secrets[from.SecretKeyRef.Key]; ok {
+				entry.Source, entry.Scope, entry.Config = EnvSourceSecret, s.Scope, s.Source
+				return entry
+			}
+			entry.Source, entry.Config = EnvSourceReference, "secret/"+from.SecretKeyRef.Name+"#"+from.SecretKeyRef.Key
+		case from.ConfigMapKeyRef != nil:
+			if v, ok := variables[from.ConfigMapKeyRef.Key]; ok {
+				entry.Value, entry.Source, entry.Scope, entry.Config = v.Value, EnvSourceVariable, v.Scope, v.Source
+				return entry
+			}
+			entry.Source, entry.Config = EnvSourceReference, "configmap/"+from.ConfigMapKeyRef.Name+"#"+from.ConfigMapKeyRef.Key
+		case from.FieldRef != nil:
+			entry.Source, entry.Config = EnvSourceReference, "field/"+from.FieldRef.FieldPath
+		case from.ResourceFieldRef != nil:
+			entry.Source, entry.Config = EnvSourceReference, "resource/"+from.ResourceFieldRef.Resource
+		default:
+			entry.Source = EnvSourceReference
+		}
+		return entry
+	}
+
+	if s, ok := secrets[env.Name]; ok {
+		entry.Value, entry.Masked = MaskedValue, true
+		entry.Source, entry.Scope, entry.Config = EnvSourceSecret, s.Scope, s.Source
+		return entry
+	}
+	if v, ok := variables[env.Name]; ok && v.Value == env.Value {
+		entry.Source, entry.Scope, entry.Config = EnvSourceVariable, v.Scope, v.Source
+		return entry
+	}
+	entry.Source = EnvSourceCompose
+	return entry
+}
@@ -0,0 +1,52 @@
+package cmdutil_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("EffectiveEnv", func() {
+	variables := map[string]cmdutil.ScopedValue{
+		"LOG_LEVEL": {Value: "debug", Scope: cmdutil.ScopeEnv, Source: "staging"},
+		"REGION":    {Value: "eu", Scope: cmdutil.ScopeGlobal, Source: "global"},
+		"PORT":      {Value: "80", Scope: cmdutil.ScopeGlobal, Source: "global"},
+	}
+	secrets := map[string]cmdutil.ScopedValue{
+		"DB_PASSWORD": {Scope: cmdutil.ScopeRepo, Source: "repo-app"},
+		"API_TOKEN":   {Scope: cmdutil.ScopeEnv, Source: "staging"},
+	}
+
+	It("should attribute every variable and mask secrets", func() {
+		container := &corev1.Container{
+			EnvFrom: []corev1.EnvFromSource{{Prefix: "EXTRA_", SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "extra"}}}},
+			Env: []corev1.EnvVar{
+				{Name: "PORT", Value: "8080"},
+				{Name: "LOG_LEVEL", Value: "debug"},
+				{Name: "DB_PASSWORD", Value: "hunter2"},
+				{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s"}, Key: "API_TOKEN"}}},
+				{Name: "CERT", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}, Key: "crt"}}},
+				{Name: "AREA", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "vars"}, Key: "REGION"}}},
+				{Name: "POD_IP", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}}},
+			},
+		}
+
+		Expect(cmdutil.EffectiveEnv(container, variables, secrets)).To(Equal([]cmdutil.EnvEntry{
+			{Name: "AREA", Value: "eu", Source: cmdutil.EnvSourceVariable, Scope: cmdutil.ScopeGlobal, Config: "global"},
+			{Name: "CERT", Value: cmdutil.MaskedValue, Masked: true, Source: cmdutil.EnvSourceReference, Config: "secret/tls#crt"},
+			{Name: "DB_PASSWORD", Value: cmdutil.MaskedValue, Masked: true, Source: cmdutil.EnvSourceSecret, Scope: cmdutil.ScopeRepo, Config: "repo-app"},
+			{Name: "EXTRA_*", Value: cmdutil.MaskedValue, Masked: true, Source: cmdutil.EnvSourceReference, Config: "secret/extra"},
+			{Name: "LOG_LEVEL", Value: "debug", Source: cmdutil.EnvSourceVariable, Scope: cmdutil.ScopeEnv, Config: "staging"},
+			{Name: "POD_IP", Source: cmdutil.EnvSourceReference, Config: "field/status.podIP"},
+			{Name: "PORT", Value: "8080", Source: cmdutil.EnvSourceCompose},
+			{Name: "TOKEN", Value: cmdutil.MaskedValue, Masked: true, Source: cmdutil.EnvSourceSecret, Scope: cmdutil.ScopeEnv, Config: "staging"},
+		}))
+	})
+
+	It("should keep the last definition of a name", func() {
+		container := &corev1.Container{Env: []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "A", Value: "2"}}}
+		Expect(cmdutil.EffectiveEnv(container, nil, nil)).To(Equal([]cmdutil.EnvEntry{{Name: "A", Value: "2", Source: cmdutil.EnvSourceCompose}}))
+	})
+})
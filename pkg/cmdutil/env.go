@@ -1,7 +1,11 @@
 package cmdutil
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/interactive"
@@ -12,14 +16,14 @@ import (
 // 1. Use provided envFlag if not empty
 // 2. Use first existing environment
 // 3. Create default environment with user's name
-func GetOrCreateDefaultEnv(apiClient *client.Client, envFlag string, nonInteractive bool) (string, error) {
+func GetOrCreateDefaultEnv(ctx context.Context, apiClient *client.Client, envFlag string, nonInteractive bool) (string, error) {
 	// Check flags
 	if envFlag != "" {
 		return envFlag, nil
 	}
 
 	// List existing envs
-	envs, err := apiClient.ListEnvs()
+	envs, err := apiClient.ListEnvs(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to list environments: %w", err)
 	}
@@ -39,16 +43,129 @@ func GetOrCreateDefaultEnv(apiClient *client.Client, envFlag string, nonInteract
 	}
 
 	// No envs exist, create default
-	user, err := apiClient.GetCurrentUser()
+	user, err := apiClient.GetCurrentUser(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get current user: %w", err)
 	}
 
 	fmt.Printf("Creating default environment: %s\n", user.Name)
-	_, err = apiClient.CreateEnv(user.Name)
+	_, err = apiClient.CreateEnv(ctx, user.Name)
 	if err != nil {
 		return "", fmt.Errorf("failed to create environment: %w", err)
 	}
 
 	return user.Name, nil
 }
+
+// ApplyEnvVariables merges variables into the env-scoped variable config,
+// e.g. from a stack file or a cloned stack's env, overwriting keys with
+// different values
+func ApplyEnvVariables(ctx context.Context, out io.Writer, apiClient *client.Client, env string, vars map[string]string) error {
+	if len(vars) == 0 {
+		return nil
+	}
+
+	name := GenerateResourceName(ScopeEnv, env, "")
+	existing, err := apiClient.GetVariable(ctx, name, ScopeEnv, env, "")
+	if errors.Is(err, client.ErrNotFound) {
+		_, err = apiClient.CreateVariable(ctx, &client.CreateVariableRequest{
+			Name:  name,
+			Scope: ScopeEnv,
+			Env:   env,
+			Data:  vars,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create variables: %w", err)
+		}
+		fmt.Fprintf(out, "✅ Created %d variables in env '%s'\n", len(vars), env)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get variables: %w", err)
+	}
+
+	merged := make(map[string]string, len(existing.Data)+len(vars))
+	for k, v := range existing.Data {
+		merged[k] = v
+	}
+	for k, v := range vars {
+		merged[k] = v
+	}
+	if _, err := apiClient.UpdateVariable(ctx, name, ScopeEnv, env, "", &client.UpdateVariableRequest{Data: merged}); err != nil {
+		return fmt.Errorf("failed to update variables: %w", err)
+	}
+	fmt.Fprintf(out, "✅ Applied %d variables to env '%s'\n", len(vars), env)
+	return nil
+}
+
+// DeleteEnvOptions controls how DeleteEnv treats an environment's stacks
+type DeleteEnvOptions struct {
+	// Cascade deletes the environment's stacks without asking
+	Cascade bool
+	// Yes skips confirmation. Without Cascade, an environment with stacks
+	// is then refused.
+	Yes bool
+	// Confirm asks a yes/no question, e.g. interactive.ConfirmAction
+	Confirm func(message string, defaultValue bool) (bool, error)
+}
+
+// ErrDeletionCancelled means the user declined a deletion
+var ErrDeletionCancelled = errors.New("deletion cancelled")
+
+// DeleteEnv deletes an environment after confirmation. Its stacks are
+// deleted first, which needs --cascade or a second confirmation.
+func DeleteEnv(ctx context.Context, out io.Writer, apiClient *client.Client, env string, opts DeleteEnvOptions) error {
+	if _, err := apiClient.GetEnv(ctx, env); err != nil {
+		return fmt.Errorf("failed to get environment: %w", err)
+	}
+
+	stacks, err := apiClient.ListStacks(ctx, env)
+	if err != nil {
+		return fmt.Errorf("failed to list stacks: %w", err)
+	}
+
+	confirm := func(message string) error {
+		confirmed, err := opts.Confirm(message, false)
+		if err != nil || !confirmed {
+			return ErrDeletionCancelled
+		}
+		return nil
+	}
+	if len(stacks) > 0 && !opts.Cascade {
+		names := make([]string, 0, len(stacks))
+		for _, stack := range stacks {
+			names = append(names, stack.Name)
+		}
+		fmt.Fprintf(out, "⚠️  Environment '%s' has %d active stack(s):\n  - %s\n\n", env, len(stacks), strings.Join(names, "\n  - "))
+
+		if opts.Yes {
+			return fmt.Errorf("environment '%s' has active stacks; use --cascade to delete them", env)
+		}
+		if err := confirm("Delete these stacks as well?"); err != nil {
+			return err
+		}
+	} else if !opts.Yes {
+		message := fmt.Sprintf("Delete environment '%s'?", env)
+		if len(stacks) > 0 {
+			message = fmt.Sprintf("Delete environment '%s' and its %d stack(s)?", env, len(stacks))
+		}
+		if err := confirm(message); err != nil {
+			return err
+		}
+	}
+
+	for _, stack := range stacks {
+		if err := apiClient.DeleteStack(ctx, stack.Name, env); err != nil {
+			return fmt.Errorf("failed to delete stack '%s': %w", stack.Name, err)
+		}
+		fmt.Fprintf(out, "✅ Deleted stack: %s\n", stack.Name)
+	}
+
+	if err := apiClient.DeleteEnv(ctx, env); err != nil {
+		if errors.Is(err, client.ErrEnvDeleteUnsupported) && len(stacks) > 0 {
+			return fmt.Errorf("stacks deleted, but environment '%s' was kept: %w", env, err)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmdutil_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("DeleteEnv", func() {
+	var (
+		stacks string
+		// envStatus answers DELETE /api/v1/envs/staging
+		envStatus int
+		deleted   []string
+		questions []string
+		answer    bool
+		out       bytes.Buffer
+		apiClient *client.Client
+	)
+
+	BeforeEach(func() {
+		stacks, envStatus, deleted, questions, answer = `[]`, http.StatusOK, nil, nil, true
+		out.Reset()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/api/v1/envs/staging":
+				_, _ = w.Write([]byte(`{"id":"ns/staging","name":"staging"}`))
+			case r.Method == http.MethodGet && r.URL.Path == "/api/v1/stacks":
+				_, _ = w.Write([]byte(stacks))
+			case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/envs/staging":
+				w.WriteHeader(envStatus)
+				if envStatus == http.StatusOK {
+					deleted = append(deleted, r.URL.Path)
+				}
+			case r.Method == http.MethodDelete:
+				deleted = append(deleted, r.URL.Path)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(server.Close)
+		apiClient = client.NewClient(server.URL, "key")
+	})
+
+	deleteEnv := func(cascade, yes bool) error {
+		return cmdutil.DeleteEnv(context.Background(), &out, apiClient, "staging", cmdutil.DeleteEnvOptions{
+			Cascade: cascade,
+			Yes:     yes,
+			Confirm: func(message string, _ bool) (bool, error) {
+				questions = append(questions, message)
+				return answer, nil
+			},
+		})
+	}
+
+	It("should delete an empty environment after confirmation", func() {
+		Expect(deleteEnv(false, false)).To(Succeed())
+		Expect(questions).To(Equal([]string{"Delete environment 'staging'?"}))
+		Expect(deleted).To(Equal([]string{"/api/v1/envs/staging"}))
+	})
+
+	It("should keep the environment when the deletion is declined", func() {
+		answer = false
+		Expect(deleteEnv(false, false)).To(MatchError(cmdutil.ErrDeletionCancelled))
+		Expect(deleted).To(BeEmpty())
+	})
+
+	Context("with stacks", func() {
+		BeforeEach(func() {
+			stacks = `[{"metadata":{"name":"web"}},{"metadata":{"name":"api"}}]`
+		})
+
+		It("should ask before deleting them", func() {
+			Expect(deleteEnv(false, false)).To(Succeed())
+			Expect(questions).To(Equal([]string{"Delete these stacks as well?"}))
+			Expect(out.String()).To(ContainSubstring("has 2 active stack(s)"))
+			Expect(deleted).To(Equal([]string{"/api/v1/stacks/web", "/api/v1/stacks/api", "/api/v1/envs/staging"}))
+		})
+
+		It("should refuse them with --yes but without --cascade", func() {
+			Expect(deleteEnv(false, true)).To(MatchError(ContainSubstring("use --cascade")))
+			Expect(questions).To(BeEmpty())
+			Expect(deleted).To(BeEmpty())
+		})
+
+		It("should confirm the cascade once", func() {
+			Expect(deleteEnv(true, false)).To(Succeed())
+			Expect(questions).To(Equal([]string{"Delete environment 'staging' and its 2 stack(s)?"}))
+			Expect(deleted).To(HaveLen(3))
+		})
+
+		It("should delete them without asking with --cascade --yes", func() {
+			Expect(deleteEnv(true, true)).To(Succeed())
+			Expect(questions).To(BeEmpty())
+			Expect(deleted).To(HaveLen(3))
+		})
+
+		It("should say the stacks are gone when the API can't delete the environment", func() {
+			envStatus = http.StatusMethodNotAllowed
+			err := deleteEnv(true, true)
+			Expect(err).To(MatchError(client.ErrEnvDeleteUnsupported))
+			Expect(err).To(MatchError(ContainSubstring("stacks deleted")))
+			Expect(deleted).To(Equal([]string{"/api/v1/stacks/web", "/api/v1/stacks/api"}))
+		})
+	})
+})
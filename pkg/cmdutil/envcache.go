@@ -0,0 +1,157 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/cache"
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// EnvCacheTTL is how long a context's environments are cached before the
+// next lookup refreshes them from the API
+const EnvCacheTTL = 5 * time.Minute
+
+// EnvCacheKey returns the cache key for a context's environments
+func EnvCacheKey(contextName string) string {
+	return "envs-" + contextCacheKey(contextName)
+}
+
+// contextCacheKey makes a context name safe to use in a cache key
+func contextCacheKey(contextName string) string {
+	return strings.ReplaceAll(contextName, string(os.PathSeparator), "_")
+}
+
+// ListEnvsCached returns the environments of a context, served from the
+// cache while it is fresh. refresh always asks the API.
+func ListEnvsCached(ctx context.Context, apiClient *client.Client, contextName string, refresh bool) ([]client.EnvResponse, error) {
+	return listEnvsCached(contextName, refresh, func() ([]client.EnvResponse, error) {
+		return apiClient.ListEnvs(ctx)
+	})
+}
+
+// listEnvsCached is ListEnvsCached with a lazy fetch, so callers only build
+// an API client on a cache miss. Without a cache directory it always fetches.
+func listEnvsCached(contextName string, refresh bool, fetch func() ([]client.EnvResponse, error)) ([]client.EnvResponse, error) {
+	c, err := cache.Default()
+	if err != nil {
+		return fetch()
+	}
+	return cache.GetOrFetch(c, EnvCacheKey(contextName), EnvCacheTTL, refresh, fetch)
+}
+
+// InvalidateEnvCache drops the cached environments of a context after
+// environments were created, renamed or deleted
+func InvalidateEnvCache(contextName string) {
+	c, err := cache.Default()
+	if err != nil {
+		return
+	}
+	if err := c.Delete(EnvCacheKey(contextName)); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Failed to update environment cache: %v\n", err)
+	}
+}
+
+// InvalidateCurrentEnvCache drops the cached environments of the current
+// context
+func InvalidateCurrentEnvCache() {
+	cfg, err := config.LoadConfig()
+	if err != nil || cfg.ActiveContextName() == "" {
+		return
+	}
+	InvalidateEnvCache(cfg.ActiveContextName())
+}
+
+// ValidateEnvFlag checks that the environment given with --env exists in the
+// selected context. A name missing from the cache triggers one refresh, so
+// environments created by teammates are found. When the environments can't
+// be listed, validation is skipped and the command reports the API error.
+func ValidateEnvFlag(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("env")
+	if flag == nil || !flag.Changed || flag.Value.String() == "" {
+		return nil
+	}
+	envName := flag.Value.String()
+	if Cached(cmd) {
+		return nil
+	}
+
+	contexts, explicit, err := SelectContexts(cmd)
+	if err != nil || len(contexts) != 1 {
+		return nil
+	}
+	lisstoCtx := &contexts[0]
+	fetch := func() ([]client.EnvResponse, error) {
+		apiClient, err := newContextClient(cmd.Context(), lisstoCtx, explicit)
+		if err != nil {
+			return nil, err
+		}
+		return apiClient.ListEnvs(cmd.Context())
+	}
+
+	for _, refresh := range []bool{false, true} {
+		envs, err := listEnvsCached(lisstoCtx.Name, refresh, fetch)
+		if err != nil {
+			return nil
+		}
+		if hasEnv(envs, envName) {
+			return nil
+		}
+	}
+	return fmt.Errorf("environment '%s' not found in context '%s' (see 'lissto env list')", envName, lisstoCtx.Name)
+}
+
+// CompleteEnvNames completes --env with the environments of the selected
+// context, from the cache when it is fresh
+func CompleteEnvNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	contexts, explicit, err := SelectContexts(cmd)
+	if err != nil || len(contexts) != 1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	lisstoCtx := &contexts[0]
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	envs, err := listEnvsCached(lisstoCtx.Name, false, func() ([]client.EnvResponse, error) {
+		apiClient, err := newContextClient(ctx, lisstoCtx, explicit)
+		if err != nil {
+			return nil, err
+		}
+		return apiClient.ListEnvs(ctx)
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, env := range envs {
+		if strings.HasPrefix(env.Name, toComplete) {
+			names = append(names, env.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// newContextClient creates an API client the way ForEachContext does
+func newContextClient(ctx context.Context, lisstoCtx *config.Context, explicit bool) (*client.Client, error) {
+	if explicit {
+		return client.NewClientForContext(ctx, lisstoCtx)
+	}
+	return client.NewClientFromConfig(ctx, lisstoCtx)
+}
+
+func hasEnv(envs []client.EnvResponse, name string) bool {
+	for _, env := range envs {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package cmdutil_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/cache"
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("Env cache", func() {
+	var cmd *cobra.Command
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", GinkgoT().TempDir())
+		GinkgoT().Setenv("XDG_CACHE_HOME", GinkgoT().TempDir())
+		Expect(config.SaveConfig(&config.Config{
+			CurrentContext: "dev",
+			Contexts:       []config.Context{{Name: "dev"}},
+		})).To(Succeed())
+
+		c, err := cache.Default()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Set(cmdutil.EnvCacheKey("dev"), []client.EnvResponse{
+			{ID: "ns/alice", Name: "alice"},
+			{ID: "ns/bob", Name: "bob"},
+		}, time.Hour)).To(Succeed())
+
+		cmd = &cobra.Command{Use: "test"}
+		cmd.Flags().String("context", "", "")
+		cmd.Flags().String("env", "", "")
+		cmdutil.AddAllContextsFlag(cmd)
+	})
+
+	It("should complete env names from the cache", func() {
+		names, _ := cmdutil.CompleteEnvNames(cmd, nil, "al")
+		Expect(names).To(Equal([]string{"alice"}))
+	})
+
+	It("should accept cached environments and skip validation when the API is unreachable", func() {
+		Expect(cmdutil.ValidateEnvFlag(cmd)).To(Succeed())
+
+		Expect(cmd.ParseFlags([]string{"--env", "bob"})).To(Succeed())
+		Expect(cmdutil.ValidateEnvFlag(cmd)).To(Succeed())
+
+		// carol isn't cached and the refresh fails without a cluster
+		Expect(cmd.ParseFlags([]string{"--env", "carol"})).To(Succeed())
+		Expect(cmdutil.ValidateEnvFlag(cmd)).To(Succeed())
+	})
+})
@@ -0,0 +1,146 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/registry"
+)
+
+// Prefixes selecting where an image override is resolved from; anything
+// else is an image reference
+const (
+	overrideBranchPrefix = "branch:"
+	overrideTagPrefix    = "tag:"
+	overrideCommitPrefix = "commit:"
+)
+
+// ImageOverride is a service image chosen explicitly instead of resolved
+type ImageOverride struct {
+	Image  string
+	Digest string
+}
+
+// ParseSetImages parses repeated service=ref flags into refs, which may
+// already hold refs from a stack file; flags win
+func ParseSetImages(values []string, refs map[string]string) (map[string]string, error) {
+	if refs == nil {
+		refs = make(map[string]string, len(values))
+	}
+	for _, value := range values {
+		service, ref, ok := strings.Cut(value, "=")
+		if !ok || service == "" || ref == "" {
+			return nil, fmt.Errorf("invalid --set-image %q (expected service=ref)", value)
+		}
+		refs[service] = ref
+	}
+	return refs, nil
+}
+
+// ResolveImageOverrides resolves the override ref of each service. branch:,
+// tag: and commit: refs take the service's image from a separate prepare of
+// the blueprint; other refs are image references resolved in the registry.
+func ResolveImageOverrides(ctx context.Context, apiClient *client.Client, blueprintID, env string, params map[string]string, refs map[string]string) (map[string]ImageOverride, error) {
+	services := make([]string, 0, len(refs))
+	for service := range refs {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	// Services overridden from the same ref share one prepare
+	prepared := make(map[string]*client.PrepareStackResponse)
+	overrides := make(map[string]ImageOverride, len(refs))
+	for _, service := range services {
+		ref := refs[service]
+
+		var branch, tag, commit string
+		switch {
+		case strings.HasPrefix(ref, overrideBranchPrefix):
+			branch = strings.TrimPrefix(ref, overrideBranchPrefix)
+		case strings.HasPrefix(ref, overrideTagPrefix):
+			tag = strings.TrimPrefix(ref, overrideTagPrefix)
+		case strings.HasPrefix(ref, overrideCommitPrefix):
+			commit = strings.TrimPrefix(ref, overrideCommitPrefix)
+		default:
+			digest, err := registry.ResolveDigest(ctx, ref)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve image %s for service %s: %w", ref, service, err)
+			}
+			image, _, _ := strings.Cut(ref, "@")
+			overrides[service] = ImageOverride{Image: image, Digest: digest}
+			continue
+		}
+
+		resp, ok := prepared[ref]
+		if !ok {
+			var err error
+			resp, err = apiClient.PrepareStackWithParams(ctx, blueprintID, env, commit, branch, tag, true, params)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve images for %s: %w", ref, err)
+			}
+			prepared[ref] = resp
+		}
+
+		override, err := serviceImage(resp.Images, service)
+		if err != nil {
+			return nil, fmt.Errorf("%w on %s", err, ref)
+		}
+		overrides[service] = override
+	}
+	return overrides, nil
+}
+
+// serviceImage returns the resolved image of a service
+func serviceImage(images []client.DetailedImageResolutionInfo, service string) (ImageOverride, error) {
+	for _, img := range images {
+		if img.Service != service {
+			continue
+		}
+		if img.Digest == "" || img.Digest == "N/A" {
+			return ImageOverride{}, fmt.Errorf("no image found for service %s", service)
+		}
+		return ImageOverride{Image: img.Image, Digest: img.Digest}, nil
+	}
+	return ImageOverride{}, fmt.Errorf("unknown service %s", service)
+}
+
+// ApplyImageOverrides replaces the resolved images of overridden services
+func ApplyImageOverrides(images []client.DetailedImageResolutionInfo, overrides map[string]ImageOverride) error {
+	found := make(map[string]bool, len(overrides))
+	for i := range images {
+		override, ok := overrides[images[i].Service]
+		if !ok {
+			continue
+		}
+		images[i].Image = override.Image
+		images[i].Digest = override.Digest
+		found[images[i].Service] = true
+	}
+
+	for service := range overrides {
+		if !found[service] {
+			services := make([]string, 0, len(images))
+			for _, img := range images {
+				services = append(services, img.Service)
+			}
+			return fmt.Errorf("image override for unknown service '%s' (services: %s)", service, strings.Join(services, ", "))
+		}
+	}
+	return nil
+}
+
+// StackImagesMap builds the images payload of a CreateStack or UpdateStack
+// request
+func StackImagesMap(images []client.DetailedImageResolutionInfo) map[string]interface{} {
+	imagesMap := make(map[string]interface{}, len(images))
+	for _, img := range images {
+		imagesMap[img.Service] = map[string]interface{}{
+			"digest": img.Digest,
+			"image":  img.Image,
+		}
+	}
+	return imagesMap
+}
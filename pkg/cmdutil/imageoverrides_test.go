@@ -0,0 +1,115 @@
+package cmdutil_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("Image overrides", func() {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	It("should parse --set-image values over refs from a stack file", func() {
+		refs, err := cmdutil.ParseSetImages([]string{"web=branch:feature"}, map[string]string{"web": "tag:v1", "api": "tag:v1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refs).To(Equal(map[string]string{"web": "branch:feature", "api": "tag:v1"}))
+
+		_, err = cmdutil.ParseSetImages([]string{"web"}, nil)
+		Expect(err).To(MatchError(ContainSubstring("expected service=ref")))
+	})
+
+	Describe("ResolveImageOverrides", func() {
+		var (
+			server   *httptest.Server
+			prepares atomic.Int32
+			// images are returned by the prepare endpoint, by branch
+			images map[string][]client.DetailedImageResolutionInfo
+		)
+
+		BeforeEach(func() {
+			prepares.Store(0)
+			images = map[string][]client.DetailedImageResolutionInfo{
+				"feature": {
+					{Service: "web", Image: "ghcr.io/acme/web", Digest: "sha256:web-feature"},
+					{Service: "api", Image: "ghcr.io/acme/api", Digest: "sha256:api-feature"},
+					{Service: "worker", Image: "ghcr.io/acme/worker", Digest: "N/A"},
+				},
+			}
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				prepares.Add(1)
+				var body map[string]interface{}
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				branch, _ := body["branch"].(string)
+				_ = json.NewEncoder(w).Encode(client.PrepareStackResponse{RequestID: "req-" + branch, Images: images[branch]})
+			}))
+			DeferCleanup(server.Close)
+		})
+
+		resolve := func(refs map[string]string) (map[string]cmdutil.ImageOverride, error) {
+			return cmdutil.ResolveImageOverrides(context.Background(), client.NewClient(server.URL, "key"), "bp", "dev", nil, refs)
+		}
+
+		It("should take images from one prepare per ref and keep digest references", func() {
+			overrides, err := resolve(map[string]string{
+				"web":   "branch:feature",
+				"api":   "branch:feature",
+				"cache": "redis@" + digest,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overrides).To(Equal(map[string]cmdutil.ImageOverride{
+				"web":   {Image: "ghcr.io/acme/web", Digest: "sha256:web-feature"},
+				"api":   {Image: "ghcr.io/acme/api", Digest: "sha256:api-feature"},
+				"cache": {Image: "redis", Digest: digest},
+			}))
+			Expect(prepares.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("should fail for a service the ref has no image for", func() {
+			_, err := resolve(map[string]string{"worker": "branch:feature"})
+			Expect(err).To(MatchError("no image found for service worker on branch:feature"))
+		})
+
+		It("should fail for a service missing from the blueprint", func() {
+			_, err := resolve(map[string]string{"db": "branch:feature"})
+			Expect(err).To(MatchError("unknown service db on branch:feature"))
+		})
+	})
+
+	Describe("ApplyImageOverrides", func() {
+		var images []client.DetailedImageResolutionInfo
+
+		BeforeEach(func() {
+			images = []client.DetailedImageResolutionInfo{
+				{Service: "web", Image: "ghcr.io/acme/web", Digest: "sha256:web-main"},
+				{Service: "api", Image: "ghcr.io/acme/api", Digest: "sha256:api-main"},
+			}
+		})
+
+		It("should replace the images of overridden services only", func() {
+			Expect(cmdutil.ApplyImageOverrides(images, map[string]cmdutil.ImageOverride{
+				"web": {Image: "ghcr.io/acme/web", Digest: "sha256:web-feature"},
+			})).To(Succeed())
+			Expect(images[0].Digest).To(Equal("sha256:web-feature"))
+			Expect(images[1].Digest).To(Equal("sha256:api-main"))
+
+			Expect(cmdutil.StackImagesMap(images)).To(Equal(map[string]interface{}{
+				"web": map[string]interface{}{"image": "ghcr.io/acme/web", "digest": "sha256:web-feature"},
+				"api": map[string]interface{}{"image": "ghcr.io/acme/api", "digest": "sha256:api-main"},
+			}))
+		})
+
+		It("should fail for an override of an unknown service", func() {
+			err := cmdutil.ApplyImageOverrides(images, map[string]cmdutil.ImageOverride{"db": {Image: "postgres", Digest: digest}})
+			Expect(err).To(MatchError("image override for unknown service 'db' (services: web, api)"))
+		})
+	})
+})
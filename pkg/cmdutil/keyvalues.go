@@ -0,0 +1,91 @@
+package cmdutil
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeyChanges summarizes how the keys of two key/value configs differ
+type KeyChanges struct {
+	Added   []string `json:"added" yaml:"added"`
+	Removed []string `json:"removed" yaml:"removed"`
+	Changed []string `json:"changed" yaml:"changed"`
+}
+
+// Empty reports whether no keys differ
+func (c *KeyChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// CompareKeys compares two key/value configs key by key
+func CompareKeys(oldValues, newValues map[string]string) *KeyChanges {
+	changes := &KeyChanges{Added: []string{}, Removed: []string{}, Changed: []string{}}
+	for key, value := range newValues {
+		oldValue, ok := oldValues[key]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, key)
+		case oldValue != value:
+			changes.Changed = append(changes.Changed, key)
+		}
+	}
+	for key := range oldValues {
+		if _, ok := newValues[key]; !ok {
+			changes.Removed = append(changes.Removed, key)
+		}
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	sort.Strings(changes.Changed)
+	return changes
+}
+
+// ParseYAMLValues parses a flat YAML mapping of KEY: value pairs. Scalar
+// values are kept exactly as written (1.10 stays "1.10"); null is empty.
+func ParseYAMLValues(content string) (map[string]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	values := make(map[string]string)
+	if len(doc.Content) == 0 {
+		return values, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping of KEY: value pairs", root.Line)
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+		if !dotenvKey.MatchString(keyNode.Value) {
+			return nil, fmt.Errorf("line %d: invalid key %q", keyNode.Line, keyNode.Value)
+		}
+		if valueNode.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("line %d: value of %s must be a string, not a list or mapping", valueNode.Line, keyNode.Value)
+		}
+		if valueNode.Tag == "!!null" {
+			values[keyNode.Value] = ""
+			continue
+		}
+		values[keyNode.Value] = valueNode.Value
+	}
+	return values, nil
+}
+
+// FormatYAMLValues renders values as a sorted YAML mapping that
+// ParseYAMLValues reads back
+func FormatYAMLValues(values map[string]string) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return string(data), nil
+}
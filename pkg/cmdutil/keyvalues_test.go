@@ -0,0 +1,64 @@
+package cmdutil_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("CompareKeys", func() {
+	It("should report added, removed and changed keys", func() {
+		changes := cmdutil.CompareKeys(
+			map[string]string{"A": "1", "B": "2", "C": "3"},
+			map[string]string{"A": "1", "B": "two", "D": "4"},
+		)
+		Expect(changes.Added).To(Equal([]string{"D"}))
+		Expect(changes.Removed).To(Equal([]string{"C"}))
+		Expect(changes.Changed).To(Equal([]string{"B"}))
+		Expect(changes.Empty()).To(BeFalse())
+	})
+
+	It("should be empty for equal configs", func() {
+		Expect(cmdutil.CompareKeys(map[string]string{"A": "1"}, map[string]string{"A": "1"}).Empty()).To(BeTrue())
+	})
+})
+
+var _ = Describe("ParseYAMLValues", func() {
+	It("should keep scalars as written", func() {
+		values, err := cmdutil.ParseYAMLValues("VERSION: 1.10\nDEBUG: true\nEMPTY:\nNAME: \"quoted # text\"\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(map[string]string{
+			"VERSION": "1.10",
+			"DEBUG":   "true",
+			"EMPTY":   "",
+			"NAME":    "quoted # text",
+		}))
+	})
+
+	It("should reject nested values and invalid keys", func() {
+		_, err := cmdutil.ParseYAMLValues("LIST:\n  - a\n")
+		Expect(err).To(MatchError(ContainSubstring("line 2: value of LIST must be a string")))
+
+		_, err = cmdutil.ParseYAMLValues("bad key: 1\n")
+		Expect(err).To(MatchError(ContainSubstring(`invalid key "bad key"`)))
+
+		_, err = cmdutil.ParseYAMLValues("- a\n")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should round-trip formatted values", func() {
+		in := map[string]string{"A": "1.10", "B": "multi\nline", "C": "", "D": "yes"}
+		content, err := cmdutil.FormatYAMLValues(in)
+		Expect(err).NotTo(HaveOccurred())
+		out, err := cmdutil.ParseYAMLValues(content)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal(in))
+	})
+
+	It("should treat an empty document as no values", func() {
+		values, err := cmdutil.ParseYAMLValues("# nothing yet\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(BeEmpty())
+	})
+})
@@ -0,0 +1,59 @@
+package cmdutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/types"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ParseLabels parses --label key=value flags, rejecting keys and values
+// Kubernetes doesn't accept as labels
+func ParseLabels(args []string) (map[string]string, error) {
+	values, err := ParseKeyValueArgs(args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --label: %w", err)
+	}
+	for key, value := range values {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key '%s': %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid value for label '%s': %s", key, strings.Join(errs, "; "))
+		}
+	}
+	return values, nil
+}
+
+// AddSelectorFlag adds -l/--selector to a command that lists stacks
+func AddSelectorFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("selector", "l", "", "Only include stacks matching this label selector, e.g. team=payments or 'team in (payments,billing)'")
+}
+
+// StackSelector returns the selector given with --selector, matching every
+// stack when it is absent
+func StackSelector(cmd *cobra.Command) (labels.Selector, error) {
+	value, _ := cmd.Flags().GetString("selector")
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --selector: %w", err)
+	}
+	return selector, nil
+}
+
+// FilterStacks returns the stacks whose labels match selector
+func FilterStacks(stacks []types.Stack, selector labels.Selector) []types.Stack {
+	if selector.Empty() {
+		return stacks
+	}
+	matching := []types.Stack{}
+	for i := range stacks {
+		if selector.Matches(labels.Set(stacks[i].Labels)) {
+			matching = append(matching, stacks[i])
+		}
+	}
+	return matching
+}
@@ -0,0 +1,51 @@
+package cmdutil_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+var _ = Describe("Labels", func() {
+	It("should parse valid labels", func() {
+		values, err := cmdutil.ParseLabels([]string{"team=payments", "lissto.dev/feature=checkout-v2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(map[string]string{"team": "payments", "lissto.dev/feature": "checkout-v2"}))
+	})
+
+	It("should reject invalid label keys and values", func() {
+		_, err := cmdutil.ParseLabels([]string{"my team=payments"})
+		Expect(err).To(MatchError(ContainSubstring("invalid label key")))
+
+		_, err = cmdutil.ParseLabels([]string{"team=payments and billing"})
+		Expect(err).To(MatchError(ContainSubstring("invalid value for label 'team'")))
+
+		_, err = cmdutil.ParseLabels([]string{"team"})
+		Expect(err).To(MatchError(ContainSubstring("expected KEY=value")))
+	})
+
+	It("should filter stacks by selector", func() {
+		stack := func(name string, stackLabels map[string]string) types.Stack {
+			return types.Stack{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: stackLabels}}
+		}
+		stacks := []types.Stack{
+			stack("checkout", map[string]string{"team": "payments"}),
+			stack("invoices", map[string]string{"team": "billing"}),
+			stack("unlabeled", nil),
+		}
+
+		selector, err := labels.Parse("team=payments")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmdutil.FilterStacks(stacks, selector)).To(ConsistOf(HaveField("Name", "checkout")))
+
+		selector, err = labels.Parse("team in (payments,billing)")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmdutil.FilterStacks(stacks, selector)).To(HaveLen(2))
+
+		Expect(cmdutil.FilterStacks(stacks, labels.Everything())).To(HaveLen(3))
+	})
+})
@@ -0,0 +1,116 @@
+package cmdutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/cache"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/spf13/cobra"
+)
+
+// OfflineTTL is how long the last successful API responses are kept for
+// --cached
+const OfflineTTL = 30 * 24 * time.Hour
+
+// AddCachedFlag adds --cached to a read-only command
+func AddCachedFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("cached", false, "Show the last successful response from the local cache without contacting the cluster")
+}
+
+// Cached reports whether --cached was given
+func Cached(cmd *cobra.Command) bool {
+	cached, _ := cmd.Flags().GetBool("cached")
+	return cached
+}
+
+// offlineKey returns the cache key of a context's last response for key
+func offlineKey(contextName, key string) string {
+	return "offline-" + key + "-" + contextCacheKey(contextName)
+}
+
+// SaveOffline stores a successful API response of a context for --cached.
+// Data is stored as JSON, so API types keep their field names. Failures are
+// ignored, the command itself succeeded.
+func SaveOffline(contextName, key string, data any) {
+	c, err := cache.Default()
+	if err != nil {
+		return
+	}
+	content, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	_ = c.Set(offlineKey(contextName, key), string(content), OfflineTTL)
+}
+
+// LoadOffline returns the responses SaveOffline stored for key in every
+// selected context, after printing an "as of <age>" banner for each on
+// stderr. Contexts without cached data fail like unreachable ones do in
+// ForEachContext.
+func LoadOffline[T any](cmd *cobra.Command, key string) ([]ContextResult[T], error) {
+	contexts, _, err := SelectContexts(cmd)
+	if err != nil {
+		return nil, err
+	}
+	c, err := cache.Default()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ContextResult[T]
+	var firstErr error
+	for _, lisstoCtx := range contexts {
+		value, age, err := loadOffline[T](c, lisstoCtx.Name, key)
+		if err != nil {
+			if len(contexts) == 1 {
+				return nil, err
+			}
+			fmt.Fprintf(os.Stderr, "⚠️  Context '%s': %v\n", lisstoCtx.Name, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "📦 Context '%s': cached data as of %s ago\n", lisstoCtx.Name, k8s.FormatAge(age))
+		results = append(results, ContextResult[T]{Context: lisstoCtx.Name, Value: value})
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("all %d contexts failed: %w", len(contexts), firstErr)
+	}
+	return results, nil
+}
+
+func loadOffline[T any](c *cache.Cache, contextName, key string) (T, time.Duration, error) {
+	var value T
+	entry, found, err := cache.GetWithMeta[string](c, offlineKey(contextName, key))
+	if err != nil {
+		return value, 0, err
+	}
+	if !found {
+		return value, 0, fmt.Errorf("no cached data; run the command once without --cached while the cluster is reachable")
+	}
+	if err := json.Unmarshal([]byte(entry.Data), &value); err != nil {
+		return value, 0, fmt.Errorf("failed to decode cached data: %w", err)
+	}
+	return value, entry.Age(), nil
+}
+
+// ForEachContextCached is ForEachContext for read-only commands with
+// --cached: each context's result is saved under key, and with --cached the
+// saved results are returned without contacting the cluster
+func ForEachContextCached[T any](cmd *cobra.Command, key string, fn func(ctx context.Context, target ContextTarget) (T, error)) ([]ContextResult[T], error) {
+	if Cached(cmd) {
+		return LoadOffline[T](cmd, key)
+	}
+	return ForEachContext(cmd, func(ctx context.Context, target ContextTarget) (T, error) {
+		value, err := fn(ctx, target)
+		if err == nil {
+			SaveOffline(target.Context.Name, key, value)
+		}
+		return value, err
+	})
+}
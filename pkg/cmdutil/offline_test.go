@@ -0,0 +1,57 @@
+package cmdutil_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("Offline cache", func() {
+	var cmd *cobra.Command
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", GinkgoT().TempDir())
+		GinkgoT().Setenv("XDG_CACHE_HOME", GinkgoT().TempDir())
+		Expect(config.SaveConfig(&config.Config{
+			CurrentContext: "dev",
+			Contexts:       []config.Context{{Name: "dev"}, {Name: "staging"}},
+		})).To(Succeed())
+
+		cmd = &cobra.Command{Use: "test"}
+		cmd.Flags().String("context", "", "")
+		cmdutil.AddAllContextsFlag(cmd)
+		cmdutil.AddCachedFlag(cmd)
+		Expect(cmd.ParseFlags([]string{"--cached"})).To(Succeed())
+	})
+
+	It("should serve saved responses without contacting the cluster", func() {
+		cmdutil.SaveOffline("dev", "envs", []client.EnvResponse{{ID: "ns/alice", Name: "alice"}})
+
+		results, err := cmdutil.ForEachContextCached(cmd, "envs", func(ctx context.Context, target cmdutil.ContextTarget) ([]client.EnvResponse, error) {
+			Fail("fetch must not be called with --cached")
+			return nil, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Context).To(Equal("dev"))
+		Expect(results[0].Value).To(Equal([]client.EnvResponse{{ID: "ns/alice", Name: "alice"}}))
+	})
+
+	It("should fail without saved data and skip such contexts with --all-contexts", func() {
+		_, err := cmdutil.LoadOffline[[]client.EnvResponse](cmd, "envs")
+		Expect(err).To(MatchError(ContainSubstring("no cached data")))
+
+		cmdutil.SaveOffline("staging", "envs", []client.EnvResponse{{Name: "bob"}})
+		Expect(cmd.ParseFlags([]string{"--all-contexts"})).To(Succeed())
+		results, err := cmdutil.LoadOffline[[]client.EnvResponse](cmd, "envs")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Context).To(Equal("staging"))
+	})
+})
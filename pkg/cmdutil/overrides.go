@@ -1,24 +1,32 @@
 package cmdutil
 
-import "os"
+import (
+	"os"
+	"strconv"
+)
 
 // Environment variable names for overriding auto-detection
 const (
 	EnvOverrideRepository  = "LISSTO_REPOSITORY"
 	EnvOverrideComposeFile = "LISSTO_COMPOSE_FILE"
+	EnvSkipCompatCheck     = "LISSTO_SKIP_COMPAT_CHECK"
 )
 
 // Overrides holds environment variable overrides for CLI behavior
 type Overrides struct {
-	Repository  string // Overrides git repository auto-detection
-	ComposeFile string // Overrides compose file auto-detection
+	Repository      string // Overrides git repository auto-detection
+	ComposeFile     string // Overrides compose file auto-detection
+	SkipCompatCheck bool   // Skips the API version compatibility warning
 }
 
-// LoadOverrides reads all override environment variables
+// LoadOverrides reads all override environment variables. Boolean variables
+// take strconv.ParseBool values; anything else counts as false.
 func LoadOverrides() Overrides {
+	skipCompatCheck, _ := strconv.ParseBool(os.Getenv(EnvSkipCompatCheck))
 	return Overrides{
-		Repository:  os.Getenv(EnvOverrideRepository),
-		ComposeFile: os.Getenv(EnvOverrideComposeFile),
+		Repository:      os.Getenv(EnvOverrideRepository),
+		ComposeFile:     os.Getenv(EnvOverrideComposeFile),
+		SkipCompatCheck: skipCompatCheck,
 	}
 }
 
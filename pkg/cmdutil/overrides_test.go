@@ -0,0 +1,22 @@
+package cmdutil_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = DescribeTable("LoadOverrides skips the compatibility check",
+	func(value string, skip bool) {
+		GinkgoT().Setenv(cmdutil.EnvSkipCompatCheck, value)
+		Expect(cmdutil.LoadOverrides().SkipCompatCheck).To(Equal(skip))
+	},
+	Entry("unset", "", false),
+	Entry("1", "1", true),
+	Entry("true", "true", true),
+	Entry("TRUE", "TRUE", true),
+	Entry("0", "0", false),
+	Entry("false", "false", false),
+	Entry("not a boolean", "yes", false),
+)
@@ -0,0 +1,50 @@
+package cmdutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// PreviewLabel is the stack label recording the pull request of a preview
+const PreviewLabel = "lissto.dev/pr"
+
+// DefaultPreviewPrefix starts the env names of previews, e.g. pr-123
+const DefaultPreviewPrefix = "pr"
+
+// PreviewURL is an exposed service URL of a preview
+type PreviewURL struct {
+	Service string
+	URL     string
+}
+
+// PreviewEnvName derives the env of a pull request's preview from its number
+func PreviewEnvName(prefix string, pr int) (string, error) {
+	if pr <= 0 {
+		return "", fmt.Errorf("--pr must be a positive pull request number")
+	}
+	name := prefix + "-" + strconv.Itoa(pr)
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid preview env name '%s': %s", name, strings.Join(errs, "; "))
+	}
+	return name, nil
+}
+
+// PreviewComment renders a Markdown summary of a preview, ready to post as a
+// pull request comment
+func PreviewComment(pr int, stack, env, blueprint string, urls []PreviewURL) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### 🚀 Preview for #%d\n\n", pr)
+	fmt.Fprintf(&b, "Stack `%s` is deployed in env `%s` from blueprint `%s`.\n", stack, env, blueprint)
+	if len(urls) == 0 {
+		b.WriteString("\nNo services are exposed.\n")
+		return b.String()
+	}
+	b.WriteString("\n| Service | URL |\n| --- | --- |\n")
+	for _, u := range urls {
+		fmt.Fprintf(&b, "| %s | %s |\n", u.Service, u.URL)
+	}
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package cmdutil_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("Preview", func() {
+	It("should derive the env name from the pull request number", func() {
+		name, err := cmdutil.PreviewEnvName(cmdutil.DefaultPreviewPrefix, 123)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("pr-123"))
+	})
+
+	It("should reject invalid numbers and prefixes", func() {
+		_, err := cmdutil.PreviewEnvName("pr", 0)
+		Expect(err).To(MatchError(ContainSubstring("positive")))
+
+		_, err = cmdutil.PreviewEnvName("Preview_", 1)
+		Expect(err).To(MatchError(ContainSubstring("invalid preview env name 'Preview_-1'")))
+	})
+
+	It("should render the URLs as a Markdown table", func() {
+		comment := cmdutil.PreviewComment(123, "my-stack", "pr-123", "org/app", []cmdutil.PreviewURL{
+			{Service: "web", URL: "https://web-pr-123.example.com"},
+		})
+		Expect(comment).To(HavePrefix("### 🚀 Preview for #123\n"))
+		Expect(comment).To(ContainSubstring("Stack `my-stack` is deployed in env `pr-123` from blueprint `org/app`."))
+		Expect(comment).To(ContainSubstring("| web | https://web-pr-123.example.com |\n"))
+	})
+
+	It("should say when nothing is exposed", func() {
+		Expect(cmdutil.PreviewComment(1, "s", "pr-1", "bp", nil)).To(ContainSubstring("No services are exposed."))
+	})
+})
@@ -0,0 +1,53 @@
+package cmdutil
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Character sets for generated secret values
+var Charsets = map[string]string{
+	"alnum":   "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789",
+	"alpha":   "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
+	"hex":     "0123456789abcdef",
+	"numeric": "0123456789",
+	// symbols avoids quotes, backslashes and spaces, which break shells and .env files
+	"symbols": "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789!#%*+-./:=?@^_~",
+}
+
+// CharsetNames returns the names of the supported character sets
+func CharsetNames() []string {
+	names := make([]string, 0, len(Charsets))
+	for name := range Charsets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GenerateValue returns a cryptographically random value of length
+// characters drawn uniformly from the named character set
+func GenerateValue(length int, charset string) (string, error) {
+	chars, ok := Charsets[charset]
+	if !ok {
+		return "", fmt.Errorf("unknown charset '%s' (use %s)", charset, strings.Join(CharsetNames(), ", "))
+	}
+	if length < 1 {
+		return "", fmt.Errorf("length must be at least 1")
+	}
+
+	max := big.NewInt(int64(len(chars)))
+	var b strings.Builder
+	b.Grow(length)
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random value: %w", err)
+		}
+		b.WriteByte(chars[n.Int64()])
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,39 @@
+package cmdutil_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("GenerateValue", func() {
+	It("should draw the requested length from the charset", func() {
+		for _, charset := range cmdutil.CharsetNames() {
+			value, err := cmdutil.GenerateValue(64, charset)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(HaveLen(64))
+			for _, c := range value {
+				Expect(strings.ContainsRune(cmdutil.Charsets[charset], c)).To(BeTrue(), "%q not in %s", c, charset)
+			}
+		}
+	})
+
+	It("should not repeat values", func() {
+		a, err := cmdutil.GenerateValue(32, "alnum")
+		Expect(err).NotTo(HaveOccurred())
+		b, err := cmdutil.GenerateValue(32, "alnum")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a).NotTo(Equal(b))
+	})
+
+	It("should reject unknown charsets and empty lengths", func() {
+		_, err := cmdutil.GenerateValue(32, "emoji")
+		Expect(err).To(MatchError(ContainSubstring("unknown charset 'emoji'")))
+
+		_, err = cmdutil.GenerateValue(0, "alnum")
+		Expect(err).To(HaveOccurred())
+	})
+})
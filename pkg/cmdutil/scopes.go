@@ -0,0 +1,70 @@
+package cmdutil
+
+import (
+	controllerconfig "github.com/lissto-dev/controller/pkg/config"
+)
+
+// Config scopes
+const (
+	ScopeGlobal = "global"
+	ScopeRepo   = "repo"
+	ScopeEnv    = "env"
+)
+
+// scopeRank orders scopes by precedence: later scopes override earlier ones,
+// the same way the controller resolves stack configuration
+var scopeRank = map[string]int{
+	ScopeGlobal: 0,
+	ScopeRepo:   1,
+	ScopeEnv:    2,
+}
+
+// ScopedConfig is a variable or secret config with its scope
+type ScopedConfig struct {
+	Name       string
+	Scope      string
+	Env        string
+	Repository string
+	Data       map[string]string
+}
+
+// ScopedValue is a merged value and the config it came from
+type ScopedValue struct {
+	Value  string `json:"value,omitempty"`
+	Scope  string `json:"scope"`
+	Source string `json:"source"`
+}
+
+// AppliesTo reports whether a config is used by stacks of the given env and
+// repository. An empty repository matches no repo-scoped config.
+func (c ScopedConfig) AppliesTo(env, repository string) bool {
+	switch c.Scope {
+	case ScopeGlobal:
+		return true
+	case ScopeRepo:
+		return repository != "" &&
+			controllerconfig.NormalizeRepositoryURL(c.Repository) == controllerconfig.NormalizeRepositoryURL(repository)
+	case ScopeEnv:
+		return c.Env == env
+	default:
+		return false
+	}
+}
+
+// MergeScopes merges the configs that apply to env and repository, with
+// global < repo < env precedence
+func MergeScopes(configs []ScopedConfig, env, repository string) map[string]ScopedValue {
+	merged := make(map[string]ScopedValue)
+	for _, c := range configs {
+		if !c.AppliesTo(env, repository) {
+			continue
+		}
+		for key, value := range c.Data {
+			if existing, ok := merged[key]; ok && scopeRank[existing.Scope] > scopeRank[c.Scope] {
+				continue
+			}
+			merged[key] = ScopedValue{Value: value, Scope: c.Scope, Source: c.Name}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,34 @@
+package cmdutil_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+)
+
+var _ = Describe("MergeScopes", func() {
+	configs := []cmdutil.ScopedConfig{
+		{Name: "staging", Scope: cmdutil.ScopeEnv, Env: "staging", Data: map[string]string{"LOG_LEVEL": "debug"}},
+		{Name: "repo-app", Scope: cmdutil.ScopeRepo, Repository: "https://github.com/org/app.git", Data: map[string]string{"LOG_LEVEL": "info", "PORT": "8080"}},
+		{Name: "repo-other", Scope: cmdutil.ScopeRepo, Repository: "github.com/org/other", Data: map[string]string{"OTHER": "1"}},
+		{Name: "global", Scope: cmdutil.ScopeGlobal, Data: map[string]string{"PORT": "80", "REGION": "eu"}},
+		{Name: "prod", Scope: cmdutil.ScopeEnv, Env: "prod", Data: map[string]string{"LOG_LEVEL": "warn"}},
+	}
+
+	It("should apply global < repo < env precedence regardless of order", func() {
+		merged := cmdutil.MergeScopes(configs, "staging", "github.com/org/app")
+		Expect(merged).To(Equal(map[string]cmdutil.ScopedValue{
+			"LOG_LEVEL": {Value: "debug", Scope: cmdutil.ScopeEnv, Source: "staging"},
+			"PORT":      {Value: "8080", Scope: cmdutil.ScopeRepo, Source: "repo-app"},
+			"REGION":    {Value: "eu", Scope: cmdutil.ScopeGlobal, Source: "global"},
+		}))
+	})
+
+	It("should skip repo scope without a repository", func() {
+		merged := cmdutil.MergeScopes(configs, "prod", "")
+		Expect(merged).To(HaveLen(3))
+		Expect(merged["LOG_LEVEL"].Value).To(Equal("warn"))
+		Expect(merged["PORT"].Value).To(Equal("80"))
+	})
+})
@@ -0,0 +1,64 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+// StackAnnotator sets annotations on Stack resources, e.g. a *k8s.Client
+type StackAnnotator interface {
+	AnnotateStack(ctx context.Context, namespace, name string, annotations map[string]*string) error
+}
+
+// AnnotateStack sets annotations on a stack of env. The API doesn't take
+// annotations on create, so they're set on the Stack resource in the cluster.
+func AnnotateStack(ctx context.Context, apiClient *client.Client, annotator StackAnnotator, env, stackID string, annotations map[string]string) error {
+	stack, err := findCreatedStack(ctx, apiClient, env, stackID)
+	if err != nil {
+		return err
+	}
+	return annotator.AnnotateStack(ctx, stack.Namespace, stack.Name, stringPointers(annotations))
+}
+
+// StackLabeler sets labels on Stack resources, e.g. a *k8s.Client
+type StackLabeler interface {
+	LabelStack(ctx context.Context, namespace, name string, labels map[string]*string) error
+}
+
+// LabelStack sets labels on a stack of env. The API doesn't take labels on
+// create, so they're set on the Stack resource in the cluster.
+func LabelStack(ctx context.Context, apiClient *client.Client, labeler StackLabeler, env, stackID string, labels map[string]string) error {
+	stack, err := findCreatedStack(ctx, apiClient, env, stackID)
+	if err != nil {
+		return err
+	}
+	return labeler.LabelStack(ctx, stack.Namespace, stack.Name, stringPointers(labels))
+}
+
+// stringPointers returns the values of m as pointers, for metadata patches
+func stringPointers(m map[string]string) map[string]*string {
+	values := make(map[string]*string, len(m))
+	for key, value := range m {
+		values[key] = &value
+	}
+	return values
+}
+
+// findCreatedStack looks up a stack of env by the identifier CreateStack
+// returned
+func findCreatedStack(ctx context.Context, apiClient *client.Client, env, stackID string) (*types.Stack, error) {
+	stacks, err := apiClient.ListStacks(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	name := client.StackName(stackID)
+	for i := range stacks {
+		if stacks[i].Name == name {
+			return &stacks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("stack '%s' %w in env '%s'", name, client.ErrNotFound, env)
+}
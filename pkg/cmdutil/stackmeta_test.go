@@ -0,0 +1,82 @@
+package cmdutil_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/cmdutil"
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+// fakeStackPatcher records the metadata set on stacks, by namespace/name
+type fakeStackPatcher struct {
+	annotations map[string]map[string]*string
+	labels      map[string]map[string]*string
+}
+
+func (p *fakeStackPatcher) LabelStack(_ context.Context, namespace, name string, labels map[string]*string) error {
+	p.labels[namespace+"/"+name] = labels
+	return nil
+}
+
+func (p *fakeStackPatcher) AnnotateStack(_ context.Context, namespace, name string, annotations map[string]*string) error {
+	p.annotations[namespace+"/"+name] = annotations
+	return nil
+}
+
+var _ = Describe("Stack metadata", func() {
+	var (
+		apiClient *client.Client
+		patcher   *fakeStackPatcher
+	)
+
+	BeforeEach(func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.URL.Path).To(Equal("/api/v1/stacks"))
+			Expect(r.URL.Query().Get("env")).To(Equal("dev"))
+			_ = json.NewEncoder(w).Encode([]types.Stack{
+				{ObjectMeta: metav1.ObjectMeta{Name: "my-stack", Namespace: "dev-ns"}},
+			})
+		}))
+		DeferCleanup(server.Close)
+		apiClient = client.NewClient(server.URL, "key")
+		patcher = &fakeStackPatcher{annotations: map[string]map[string]*string{}, labels: map[string]map[string]*string{}}
+	})
+
+	It("should annotate the Stack resource of a created stack", func() {
+		Expect(cmdutil.AnnotateStack(context.Background(), apiClient, patcher, "dev", "dev-ns/my-stack", map[string]string{
+			types.AnnotationExpiresAt: "2026-01-02T00:00:00Z",
+		})).To(Succeed())
+		Expect(patcher.annotations).To(HaveKeyWithValue("dev-ns/my-stack",
+			HaveKeyWithValue(types.AnnotationExpiresAt, HaveValue(Equal("2026-01-02T00:00:00Z")))))
+	})
+
+	It("should label the Stack resource of a created stack", func() {
+		Expect(cmdutil.LabelStack(context.Background(), apiClient, patcher, "dev", "my-stack", map[string]string{
+			cmdutil.PreviewLabel: "42",
+			"team":               "payments",
+		})).To(Succeed())
+		Expect(patcher.labels).To(HaveKeyWithValue("dev-ns/my-stack", And(
+			HaveKeyWithValue(cmdutil.PreviewLabel, HaveValue(Equal("42"))),
+			HaveKeyWithValue("team", HaveValue(Equal("payments"))),
+		)))
+	})
+
+	It("should fail for a stack the API doesn't list", func() {
+		err := cmdutil.AnnotateStack(context.Background(), apiClient, patcher, "dev", "other", map[string]string{"a": "b"})
+		Expect(err).To(MatchError(client.ErrNotFound))
+		Expect(patcher.annotations).To(BeEmpty())
+
+		err = cmdutil.LabelStack(context.Background(), apiClient, patcher, "dev", "other", map[string]string{"a": "b"})
+		Expect(err).To(MatchError(client.ErrNotFound))
+		Expect(patcher.labels).To(BeEmpty())
+	})
+})
@@ -6,9 +6,11 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	apicompose "github.com/lissto-dev/api/pkg/compose"
+	"github.com/lissto-dev/cli/pkg/interactive"
 	"github.com/lissto-dev/controller/pkg/config"
 	"github.com/sirupsen/logrus"
 )
@@ -85,6 +87,66 @@ func ValidateComposeFile(path string) (bool, error) {
 	return validateComposeContent(data), nil
 }
 
+// FileSummary describes the services defined in a compose file
+type FileSummary struct {
+	Path     string
+	Title    string
+	Services []string // Services built from this repository
+	Infra    []string // Infrastructure services (prebuilt images)
+}
+
+// AllServices returns services and infra combined
+func (f *FileSummary) AllServices() []string {
+	all := make([]string, 0, len(f.Services)+len(f.Infra))
+	all = append(all, f.Services...)
+	return append(all, f.Infra...)
+}
+
+// SummarizeComposeFile parses a compose file and returns its categorized services
+func SummarizeComposeFile(path string) (*FileSummary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return SummarizeComposeContent(path, string(data))
+}
+
+// SummarizeComposeContent parses compose content read from path (used for
+// messages) and returns its categorized services
+func SummarizeComposeContent(path, content string) (*FileSummary, error) {
+	cleanup := silenceLoggers()
+	defer cleanup()
+
+	metadata, err := apicompose.ParseBlueprintMetadata(content, config.RepoConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+
+	return &FileSummary{
+		Path:     path,
+		Title:    metadata.Title,
+		Services: metadata.Services.Services,
+		Infra:    metadata.Services.Infra,
+	}, nil
+}
+
+// MissingServices returns the services in expected that the summary lacks
+func (f *FileSummary) MissingServices(expected []string) []string {
+	present := make(map[string]bool)
+	for _, s := range f.AllServices() {
+		present[s] = true
+	}
+
+	var missing []string
+	for _, s := range expected {
+		if !present[s] {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
 // SelectComposeFile prompts the user to select from multiple compose files
 // If only one file, returns it automatically
 // If no files, returns error
@@ -98,11 +160,21 @@ func SelectComposeFile(files []string) (string, error) {
 		return files[0], nil
 	}
 
-	// Multiple files - prompt user to select
-	options := make([]string, len(files))
+	// Multiple files - show parsed services per file in the prompt
+	names := make([]string, len(files))
+	counts := make([]string, len(files))
+	details := make([]string, len(files))
 	for i, f := range files {
-		options[i] = filepath.Base(f)
+		names[i] = filepath.Base(f)
+		summary, err := SummarizeComposeFile(f)
+		if err != nil {
+			counts[i] = "(unparseable)"
+			continue
+		}
+		counts[i] = fmt.Sprintf("%d services, %d infra", len(summary.Services), len(summary.Infra))
+		details[i] = strings.Join(summary.AllServices(), ", ")
 	}
+	options := interactive.FormatAlignedColumns(names, counts, details)
 
 	var selectedIndex int
 	prompt := &survey.Select{
@@ -0,0 +1,72 @@
+package compose_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/compose"
+)
+
+const appCompose = `x-lissto:
+  title: Shop
+services:
+  web:
+    build: .
+  worker:
+    build: ./worker
+  postgres:
+    image: postgres:16
+  cache:
+    image: redis:7
+    labels:
+      lissto.dev/group: services
+`
+
+var _ = Describe("Detect", func() {
+	DescribeTable("SummarizeComposeContent",
+		func(content, title string, services, infra []string) {
+			summary, err := compose.SummarizeComposeContent("docker-compose.yaml", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summary.Path).To(Equal("docker-compose.yaml"))
+			Expect(summary.Title).To(Equal(title))
+			Expect(summary.Services).To(ConsistOf(services))
+			Expect(summary.Infra).To(ConsistOf(infra))
+			Expect(summary.AllServices()).To(ConsistOf(append(append([]string{}, services...), infra...)))
+		},
+		Entry("built services, infra and a group label", appCompose, "Shop",
+			[]string{"web", "worker", "cache"}, []string{"postgres"}),
+		Entry("infra only", "services:\n  db:\n    image: postgres:16\n", "",
+			[]string{}, []string{"db"}),
+	)
+
+	It("should name the file when the content doesn't parse", func() {
+		_, err := compose.SummarizeComposeContent("/repo/compose.dev.yaml", "services: [")
+		Expect(err).To(MatchError(ContainSubstring("failed to parse compose.dev.yaml")))
+	})
+
+	It("should summarize a compose file on disk", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "compose.yaml")
+		Expect(os.WriteFile(path, []byte(appCompose), 0600)).To(Succeed())
+
+		summary, err := compose.SummarizeComposeFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summary.Path).To(Equal(path))
+		Expect(summary.AllServices()).To(HaveLen(4))
+
+		_, err = compose.SummarizeComposeFile(filepath.Join(GinkgoT().TempDir(), "missing.yaml"))
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	DescribeTable("MissingServices",
+		func(expected, missing []string) {
+			summary := &compose.FileSummary{Services: []string{"web", "worker"}, Infra: []string{"postgres"}}
+			Expect(summary.MissingServices(expected)).To(Equal(missing))
+		},
+		Entry("all present", []string{"web", "postgres"}, nil),
+		Entry("nothing expected", nil, nil),
+		Entry("dropped services in expected order", []string{"web", "redis", "worker", "mailer"}, []string{"redis", "mailer"}),
+	)
+})
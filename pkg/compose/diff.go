@@ -0,0 +1,65 @@
+package compose
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ServiceChanges summarizes how the services of two compose files differ
+type ServiceChanges struct {
+	Added   []string `json:"added" yaml:"added"`
+	Removed []string `json:"removed" yaml:"removed"`
+	Changed []string `json:"changed" yaml:"changed"`
+}
+
+// Empty reports whether no services differ
+func (c *ServiceChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// CompareServices compares the service definitions of two compose documents
+func CompareServices(oldContent, newContent string) (*ServiceChanges, error) {
+	oldServices, err := parseServices(oldContent)
+	if err != nil {
+		return nil, err
+	}
+	newServices, err := parseServices(newContent)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &ServiceChanges{Added: []string{}, Removed: []string{}, Changed: []string{}}
+	for name, def := range newServices {
+		oldDef, ok := oldServices[name]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, name)
+		case !reflect.DeepEqual(oldDef, def):
+			changes.Changed = append(changes.Changed, name)
+		}
+	}
+	for name := range oldServices {
+		if _, ok := newServices[name]; !ok {
+			changes.Removed = append(changes.Removed, name)
+		}
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	sort.Strings(changes.Changed)
+	return changes, nil
+}
+
+// parseServices returns the raw service definitions of a compose document
+func parseServices(content string) (map[string]interface{}, error) {
+	var doc struct {
+		Services map[string]interface{} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse compose content: %w", err)
+	}
+	return doc.Services, nil
+}
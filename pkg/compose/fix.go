@@ -0,0 +1,285 @@
+package compose
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fix is a mechanical change made by FixCompose
+type Fix struct {
+	// Rule names the kind of fix, e.g. "obsolete-version"
+	Rule string `json:"rule" yaml:"rule"`
+	// Path locates the changed node, e.g. "services.web.ports"
+	Path string `json:"path" yaml:"path"`
+	// Message describes the change
+	Message string `json:"message" yaml:"message"`
+	// Warning is the parser warning the fix resolves, if one was reported
+	Warning string `json:"warning,omitempty" yaml:"warning,omitempty"`
+}
+
+// fixRule is a rewrite of one kind of compose issue. warning is a substring
+// of the parser warning the rule resolves, if the parser reports it.
+type fixRule struct {
+	name    string
+	warning string
+	apply   func(root *yaml.Node) []Fix
+}
+
+var fixRules = []fixRule{
+	{name: "obsolete-version", warning: "the attribute `version` is obsolete", apply: fixVersion},
+	{name: "external-name", warning: "external.name is deprecated", apply: fixExternalName},
+	{name: "legacy-fields", apply: fixLegacyFields},
+	{name: "yaml11-boolean", warning: "for boolean is not supported by YAML 1.2", apply: fixBooleans},
+	{name: "port-syntax", apply: fixPorts},
+	{name: "env-quoting", apply: fixEnvironment},
+}
+
+// serviceBoolKeys are the boolean service attributes yes/no/on/off are fixed in
+var serviceBoolKeys = []string{"init", "privileged", "read_only", "stdin_open", "tty"}
+
+// FixCompose rewrites the mechanical issues of a compose document: the
+// obsolete version key, deprecated and legacy fields, YAML 1.1 booleans,
+// unquoted port mappings and non-string environment values. warnings are
+// the parser's warnings for the document; each fix records the one it
+// resolves. Content is returned unchanged when there is nothing to fix.
+func FixCompose(content string, warnings []string) (string, []Fix, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return "", nil, fmt.Errorf("failed to parse compose content: %w", err)
+	}
+	root := documentRoot(&doc)
+	if root.Kind != yaml.MappingNode {
+		return content, nil, nil
+	}
+
+	var fixes []Fix
+	for _, rule := range fixRules {
+		ruleFixes := rule.apply(root)
+		for i := range ruleFixes {
+			ruleFixes[i].Rule = rule.name
+			ruleFixes[i].Warning = matchWarning(warnings, rule.warning)
+		}
+		fixes = append(fixes, ruleFixes...)
+	}
+	if len(fixes) == 0 {
+		return content, nil, nil
+	}
+
+	fixed, err := encodeDocument(&doc)
+	if err != nil {
+		return "", nil, err
+	}
+	return fixed, fixes, nil
+}
+
+// matchWarning returns the first warning containing pattern
+func matchWarning(warnings []string, pattern string) string {
+	if pattern == "" {
+		return ""
+	}
+	for _, w := range warnings {
+		if strings.Contains(w, pattern) {
+			return w
+		}
+	}
+	return ""
+}
+
+// encodeDocument encodes a YAML document with the indentation compose files use
+func encodeDocument(doc *yaml.Node) (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return "", fmt.Errorf("failed to encode compose content: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func fixVersion(root *yaml.Node) []Fix {
+	if mappingValue(root, "version") == nil {
+		return nil
+	}
+	removeMappingKey(root, "version")
+	return []Fix{{Path: "version", Message: "removed the obsolete version attribute"}}
+}
+
+// fixExternalName turns "external: {name: x}" into "name: x" and
+// "external: true"
+func fixExternalName(root *yaml.Node) []Fix {
+	var fixes []Fix
+	for _, section := range []string{"networks", "volumes", "secrets", "configs"} {
+		forEachMapping(mappingValue(root, section), func(name string, def *yaml.Node) {
+			external := mappingValue(def, "external")
+			externalName := mappingValue(external, "name")
+			if externalName == nil {
+				return
+			}
+			if mappingValue(def, "name") == nil {
+				setMappingValue(def, "name", scalarNode("!!str", externalName.Value))
+			}
+			setMappingValue(def, "external", scalarNode("!!bool", "true"))
+			fixes = append(fixes, Fix{
+				Path:    section + "." + name,
+				Message: fmt.Sprintf("replaced external.name with name: %s", externalName.Value),
+			})
+		})
+	}
+	return fixes
+}
+
+// fixLegacyFields moves version 1 service fields to their compose spec
+// equivalents: net to network_mode, log_driver and log_opt under logging
+func fixLegacyFields(root *yaml.Node) []Fix {
+	var fixes []Fix
+	forEachMapping(mappingValue(root, "services"), func(name string, def *yaml.Node) {
+		path := "services." + name
+		if net := mappingValue(def, "net"); net != nil && mappingValue(def, "network_mode") == nil {
+			removeMappingKey(def, "net")
+			setMappingValue(def, "network_mode", net)
+			fixes = append(fixes, Fix{Path: path, Message: "renamed net to network_mode"})
+		}
+
+		driver, options := mappingValue(def, "log_driver"), mappingValue(def, "log_opt")
+		if (driver != nil || options != nil) && mappingValue(def, "logging") == nil {
+			logging := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if driver != nil {
+				setMappingValue(logging, "driver", driver)
+			}
+			if options != nil {
+				setMappingValue(logging, "options", options)
+			}
+			removeMappingKey(def, "log_driver")
+			removeMappingKey(def, "log_opt")
+			setMappingValue(def, "logging", logging)
+			fixes = append(fixes, Fix{Path: path, Message: "moved log_driver/log_opt under logging"})
+		}
+	})
+	return fixes
+}
+
+// fixBooleans replaces YAML 1.1 booleans (yes, no, on, off) in boolean
+// service attributes
+func fixBooleans(root *yaml.Node) []Fix {
+	var fixes []Fix
+	forEachMapping(mappingValue(root, "services"), func(name string, def *yaml.Node) {
+		for _, key := range serviceBoolKeys {
+			value := mappingValue(def, key)
+			if value == nil || value.Kind != yaml.ScalarNode || value.Style != 0 {
+				continue
+			}
+			b, ok := yaml11Bool(value.Value)
+			if !ok {
+				continue
+			}
+			old := value.Value
+			value.Value, value.Tag = b, "!!bool"
+			fixes = append(fixes, Fix{
+				Path:    "services." + name + "." + key,
+				Message: fmt.Sprintf("replaced %s with %s", old, b),
+			})
+		}
+	})
+	return fixes
+}
+
+// yaml11Bool maps a YAML 1.1 only boolean to true or false
+func yaml11Bool(value string) (string, bool) {
+	switch strings.ToLower(value) {
+	case "y", "yes", "on":
+		return "true", true
+	case "n", "no", "off":
+		return "false", true
+	}
+	return "", false
+}
+
+// fixPorts quotes short syntax port mappings, which YAML 1.1 parsers may
+// read as base 60 numbers (e.g. 22:22)
+func fixPorts(root *yaml.Node) []Fix {
+	var fixes []Fix
+	forEachMapping(mappingValue(root, "services"), func(name string, def *yaml.Node) {
+		for _, key := range []string{"ports", "expose"} {
+			ports := mappingValue(def, key)
+			if ports == nil || ports.Kind != yaml.SequenceNode {
+				continue
+			}
+			var quoted []string
+			for _, port := range ports.Content {
+				if port.Kind == yaml.ScalarNode && port.Style == 0 {
+					quoteScalar(port)
+					quoted = append(quoted, port.Value)
+				}
+			}
+			if len(quoted) > 0 {
+				fixes = append(fixes, Fix{
+					Path:    "services." + name + "." + key,
+					Message: "quoted " + strings.Join(quoted, ", "),
+				})
+			}
+		}
+	})
+	return fixes
+}
+
+// fixEnvironment quotes environment values YAML reads as booleans or
+// numbers, which compose requires to be strings. Keys without a value are
+// passed through from the shell and left alone.
+func fixEnvironment(root *yaml.Node) []Fix {
+	var fixes []Fix
+	forEachMapping(mappingValue(root, "services"), func(name string, def *yaml.Node) {
+		forEachMapping(mappingValue(def, "environment"), func(key string, value *yaml.Node) {
+			if value.Kind != yaml.ScalarNode || value.Style != 0 {
+				return
+			}
+			_, yaml11 := yaml11Bool(value.Value)
+			switch value.ShortTag() {
+			case "!!bool", "!!int", "!!float":
+			default:
+				if !yaml11 {
+					return
+				}
+			}
+			quoteScalar(value)
+			fixes = append(fixes, Fix{
+				Path:    "services." + name + ".environment." + key,
+				Message: "quoted " + value.Value,
+			})
+		})
+	})
+	return fixes
+}
+
+// forEachMapping calls fn for every key and value of a mapping node
+func forEachMapping(node *yaml.Node, fn func(key string, value *yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fn(node.Content[i].Value, node.Content[i+1])
+	}
+}
+
+// setMappingValue sets key in a mapping node, appending it when absent
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, scalarNode("!!str", key), value)
+}
+
+func scalarNode(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+// quoteScalar turns a plain scalar into a double-quoted string
+func quoteScalar(node *yaml.Node) {
+	node.Tag = "!!str"
+	node.Style = yaml.DoubleQuotedStyle
+}
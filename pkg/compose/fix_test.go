@@ -0,0 +1,71 @@
+package compose_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/compose"
+)
+
+const legacyCompose = `version: "3.8"
+services:
+  web:
+    image: nginx
+    tty: yes
+    net: host
+    log_driver: json-file
+    log_opt:
+      max-size: 10m
+    ports:
+      - 22:22
+      - "8080:80"
+    environment:
+      DEBUG: true
+      WORKERS: 4
+      NAME: web
+      PASSTHROUGH:
+networks:
+  shared:
+    external:
+      name: shared-net
+`
+
+var _ = Describe("FixCompose", func() {
+	It("should fix mechanical issues and link them to parser warnings", func() {
+		warnings := []string{"docker-compose.yaml: the attribute `version` is obsolete, it will be ignored, please remove it to avoid potential confusion"}
+		fixed, fixes, err := compose.FixCompose(legacyCompose, warnings)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fixed).NotTo(ContainSubstring("version"))
+		Expect(fixed).To(ContainSubstring("tty: true"))
+		Expect(fixed).To(ContainSubstring("network_mode: host"))
+		Expect(fixed).To(ContainSubstring("logging:\n      driver: json-file\n      options:\n        max-size: 10m"))
+		Expect(fixed).To(ContainSubstring(`- "22:22"`))
+		Expect(fixed).To(ContainSubstring(`DEBUG: "true"`))
+		Expect(fixed).To(ContainSubstring(`WORKERS: "4"`))
+		Expect(fixed).To(ContainSubstring("NAME: web"))
+		Expect(fixed).To(ContainSubstring("PASSTHROUGH:\n"))
+		Expect(fixed).To(ContainSubstring("external: true\n    name: shared-net"))
+
+		rules := map[string]compose.Fix{}
+		for _, fix := range fixes {
+			rules[fix.Rule] = fix
+		}
+		Expect(rules).To(HaveKey("obsolete-version"))
+		Expect(rules["obsolete-version"].Warning).To(Equal(warnings[0]))
+		Expect(rules["port-syntax"].Path).To(Equal("services.web.ports"))
+		Expect(rules["port-syntax"].Message).To(Equal("quoted 22:22"))
+		Expect(rules).To(HaveKey("external-name"))
+		Expect(rules).To(HaveKey("legacy-fields"))
+		Expect(rules).To(HaveKey("yaml11-boolean"))
+		Expect(rules).To(HaveKey("env-quoting"))
+	})
+
+	It("should leave clean files unchanged", func() {
+		clean := "services:\n  web:\n    image: nginx\n    ports:\n      - \"80:80\"\n"
+		fixed, fixes, err := compose.FixCompose(clean, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fixes).To(BeEmpty())
+		Expect(fixed).To(Equal(clean))
+	})
+})
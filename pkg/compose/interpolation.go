@@ -0,0 +1,130 @@
+package compose
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// interpolationPattern matches $$ escapes, ${VAR}, ${VAR<op>arg} and $VAR
+var interpolationPattern = regexp.MustCompile(`\$(?:\$|\{([A-Za-z_][A-Za-z0-9_]*)(?:(:?[-?+])([^}]*))?\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// Interpolation is a variable placeholder in a compose document
+type Interpolation struct {
+	Name string `json:"name" yaml:"name"`
+	// Operator is the modifier after the name: -, :-, ?, :?, + or :+
+	Operator string `json:"operator,omitempty" yaml:"operator,omitempty"`
+	// Argument is the default, error message or alternate value
+	Argument string `json:"argument,omitempty" yaml:"argument,omitempty"`
+	// Path locates the value, e.g. "services.web.environment.DATABASE_URL"
+	Path string `json:"path" yaml:"path"`
+	Line int    `json:"line,omitempty" yaml:"line,omitempty"`
+}
+
+// Optional reports whether the placeholder resolves without the variable,
+// through a default or alternate value
+func (i Interpolation) Optional() bool {
+	switch i.Operator {
+	case "-", ":-", "+", ":+":
+		return true
+	}
+	return false
+}
+
+// Variable check statuses
+const (
+	VariableResolved   = "resolved"
+	VariableDefault    = "default"
+	VariableUnresolved = "unresolved"
+)
+
+// VariableCheck is whether a variable used by a compose document is available
+type VariableCheck struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+	// Source names the config providing the variable, when resolved
+	Source string   `json:"source,omitempty" yaml:"source,omitempty"`
+	Paths  []string `json:"paths" yaml:"paths"`
+}
+
+// FindInterpolations lists the variable placeholders in the values of a
+// compose document, in document order. $$ escapes are skipped.
+func FindInterpolations(content string) ([]Interpolation, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse compose content: %w", err)
+	}
+	var found []Interpolation
+	collectInterpolations(documentRoot(&doc), "", &found)
+	return found, nil
+}
+
+// collectInterpolations walks a node and appends the placeholders of its
+// scalars to found
+func collectInterpolations(node *yaml.Node, path string, found *[]Interpolation) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			child := node.Content[i].Value
+			if path != "" {
+				child = path + "." + child
+			}
+			collectInterpolations(node.Content[i+1], child, found)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			collectInterpolations(item, path+"["+strconv.Itoa(i)+"]", found)
+		}
+	case yaml.ScalarNode:
+		for _, m := range interpolationPattern.FindAllStringSubmatch(node.Value, -1) {
+			name := m[1]
+			if name == "" {
+				name = m[4]
+			}
+			if name == "" {
+				continue // $$ escape
+			}
+			*found = append(*found, Interpolation{Name: name, Operator: m[2], Argument: m[3], Path: path, Line: node.Line})
+		}
+	}
+}
+
+// CheckVariables reports, per variable name, whether the placeholders can be
+// resolved. available maps the names of the variables the target provides to
+// the config they come from. A variable is only reported as default when
+// every placeholder using it is optional.
+func CheckVariables(interpolations []Interpolation, available map[string]string) []VariableCheck {
+	byName := make(map[string]*VariableCheck)
+	var names []string
+	for _, in := range interpolations {
+		check, ok := byName[in.Name]
+		if !ok {
+			check = &VariableCheck{Name: in.Name, Status: VariableDefault}
+			byName[in.Name] = check
+			names = append(names, in.Name)
+		}
+		if len(check.Paths) == 0 || check.Paths[len(check.Paths)-1] != in.Path {
+			check.Paths = append(check.Paths, in.Path)
+		}
+		if !in.Optional() {
+			check.Status = VariableUnresolved
+		}
+	}
+	sort.Strings(names)
+
+	checks := make([]VariableCheck, 0, len(names))
+	for _, name := range names {
+		check := byName[name]
+		if source, ok := available[name]; ok {
+			check.Status, check.Source = VariableResolved, source
+		}
+		checks = append(checks, *check)
+	}
+	return checks
+}
@@ -0,0 +1,69 @@
+package compose_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/compose"
+)
+
+const interpolationCompose = `services:
+  web:
+    image: ${REGISTRY:-docker.io}/web:${TAG}
+    command: ["echo", "$$HOME", "$GREETING"]
+    environment:
+      DATABASE_URL: postgres://${DB_USER}@db/${DB_NAME:?set DB_NAME}
+      FEATURE: ${FEATURE+enabled}
+  worker:
+    image: worker:${TAG}
+`
+
+var _ = Describe("Interpolation", func() {
+	Describe("FindInterpolations", func() {
+		It("should list placeholders with their operator and location", func() {
+			found, err := compose.FindInterpolations(interpolationCompose)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(Equal([]compose.Interpolation{
+				{Name: "REGISTRY", Operator: ":-", Argument: "docker.io", Path: "services.web.image", Line: 3},
+				{Name: "TAG", Path: "services.web.image", Line: 3},
+				{Name: "GREETING", Path: "services.web.command[2]", Line: 4},
+				{Name: "DB_USER", Path: "services.web.environment.DATABASE_URL", Line: 6},
+				{Name: "DB_NAME", Operator: ":?", Argument: "set DB_NAME", Path: "services.web.environment.DATABASE_URL", Line: 6},
+				{Name: "FEATURE", Operator: "+", Argument: "enabled", Path: "services.web.environment.FEATURE", Line: 7},
+				{Name: "TAG", Path: "services.worker.image", Line: 9},
+			}))
+		})
+
+		It("should reject invalid YAML", func() {
+			_, err := compose.FindInterpolations("services: [")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("CheckVariables", func() {
+		It("should resolve variables from the available configs", func() {
+			found, err := compose.FindInterpolations(interpolationCompose)
+			Expect(err).NotTo(HaveOccurred())
+
+			checks := compose.CheckVariables(found, map[string]string{"TAG": "env/dev", "DB_USER": "secret global"})
+			Expect(checks).To(Equal([]compose.VariableCheck{
+				{Name: "DB_NAME", Status: compose.VariableUnresolved, Paths: []string{"services.web.environment.DATABASE_URL"}},
+				{Name: "DB_USER", Status: compose.VariableResolved, Source: "secret global", Paths: []string{"services.web.environment.DATABASE_URL"}},
+				{Name: "FEATURE", Status: compose.VariableDefault, Paths: []string{"services.web.environment.FEATURE"}},
+				{Name: "GREETING", Status: compose.VariableUnresolved, Paths: []string{"services.web.command[2]"}},
+				{Name: "REGISTRY", Status: compose.VariableDefault, Paths: []string{"services.web.image"}},
+				{Name: "TAG", Status: compose.VariableResolved, Source: "env/dev", Paths: []string{"services.web.image", "services.worker.image"}},
+			}))
+		})
+
+		It("should require a variable used once without a default", func() {
+			checks := compose.CheckVariables([]compose.Interpolation{
+				{Name: "PORT", Operator: ":-", Argument: "80", Path: "a"},
+				{Name: "PORT", Path: "b"},
+			}, nil)
+			Expect(checks).To(HaveLen(1))
+			Expect(checks[0].Status).To(Equal(compose.VariableUnresolved))
+			Expect(checks[0].Paths).To(Equal([]string{"a", "b"}))
+		})
+	})
+})
@@ -0,0 +1,242 @@
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LintConfigFile configures the lint rules, next to the compose file or in
+// the working directory
+const LintConfigFile = ".lissto-lint.yaml"
+
+// Lint severities, from most to least severe. SeverityOff disables a rule.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+	SeverityOff     = "off"
+)
+
+// severityRank orders severities; higher is more severe
+var severityRank = map[string]int{SeverityOff: 0, SeverityInfo: 1, SeverityWarning: 2, SeverityError: 3}
+
+// Finding is a lint rule violation
+type Finding struct {
+	Rule     string `json:"rule" yaml:"rule"`
+	Severity string `json:"severity" yaml:"severity"`
+	Service  string `json:"service" yaml:"service"`
+	// Path locates the offending node, e.g. "services.web.image"
+	Path    string `json:"path" yaml:"path"`
+	Message string `json:"message" yaml:"message"`
+	Line    int    `json:"line,omitempty" yaml:"line,omitempty"`
+}
+
+// LintConfig sets the severity of rules and which services they skip
+type LintConfig struct {
+	// Rules maps rule names to a severity (error, warning, info or off)
+	Rules map[string]string `yaml:"rules"`
+	// Ignore maps rule names to services the rule doesn't apply to
+	Ignore map[string][]string `yaml:"ignore"`
+	// FailOn is the lowest severity that fails verification (default error)
+	FailOn string `yaml:"failOn"`
+}
+
+// LintRule describes a lint rule and its default severity
+type LintRule struct {
+	Name        string
+	Severity    string
+	Description string
+	check       func(name string, service *yaml.Node) []Finding
+}
+
+// LintRules are the available rules
+var LintRules = []LintRule{
+	{Name: "no-latest-tag", Severity: SeverityWarning, Description: "images are pinned to a tag other than latest, or a digest", check: lintLatestTag},
+	{Name: "healthcheck-required", Severity: SeverityWarning, Description: "services define a healthcheck", check: lintHealthcheck},
+	{Name: "resource-limits-required", Severity: SeverityWarning, Description: "services set memory or CPU limits", check: lintResourceLimits},
+	{Name: "no-privileged", Severity: SeverityError, Description: "services don't run in privileged mode", check: lintPrivileged},
+}
+
+// LoadLintConfig reads a lint config file. A missing file yields the
+// defaults.
+func LoadLintConfig(path string) (*LintConfig, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &LintConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lint config: %w", err)
+	}
+	var config LintConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// FindLintConfig returns the lint config next to the compose file, else the
+// one in the working directory, else "" for the defaults
+func FindLintConfig(composePath string) string {
+	for _, dir := range []string{filepath.Dir(composePath), "."} {
+		path := filepath.Join(dir, LintConfigFile)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// validate rejects unknown rules and severities
+func (c *LintConfig) validate() error {
+	known := make(map[string]bool, len(LintRules))
+	for _, rule := range LintRules {
+		known[rule.Name] = true
+	}
+	for name, severity := range c.Rules {
+		if !known[name] {
+			return fmt.Errorf("unknown rule %q", name)
+		}
+		if _, ok := severityRank[severity]; !ok {
+			return fmt.Errorf("rule %s: unknown severity %q (use error, warning, info or off)", name, severity)
+		}
+	}
+	for name := range c.Ignore {
+		if !known[name] {
+			return fmt.Errorf("unknown rule %q in ignore", name)
+		}
+	}
+	if c.FailOn != "" {
+		if rank, ok := severityRank[c.FailOn]; !ok || rank == 0 {
+			return fmt.Errorf("unknown failOn severity %q (use error, warning or info)", c.FailOn)
+		}
+	}
+	return nil
+}
+
+// severity returns the configured severity of a rule
+func (c *LintConfig) severity(rule LintRule) string {
+	if severity, ok := c.Rules[rule.Name]; ok {
+		return severity
+	}
+	return rule.Severity
+}
+
+// ignored reports whether a rule skips a service
+func (c *LintConfig) ignored(rule, service string) bool {
+	for _, s := range c.Ignore[rule] {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+// Fails reports whether a finding fails verification under the config
+func (c *LintConfig) Fails(f Finding) bool {
+	failOn := c.FailOn
+	if failOn == "" {
+		failOn = SeverityError
+	}
+	return severityRank[f.Severity] >= severityRank[failOn]
+}
+
+// Lint checks the services of a compose document against the enabled rules.
+// Findings are ordered by service, then rule.
+func Lint(content string, config *LintConfig) ([]Finding, error) {
+	if config == nil {
+		config = &LintConfig{}
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse compose content: %w", err)
+	}
+
+	var findings []Finding
+	forEachMapping(mappingValue(documentRoot(&doc), "services"), func(name string, service *yaml.Node) {
+		for _, rule := range LintRules {
+			severity := config.severity(rule)
+			if severity == SeverityOff || config.ignored(rule.Name, name) {
+				continue
+			}
+			for _, f := range rule.check(name, service) {
+				f.Rule, f.Severity, f.Service = rule.Name, severity, name
+				findings = append(findings, f)
+			}
+		}
+	})
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Service < findings[j].Service })
+	return findings, nil
+}
+
+// lintLatestTag flags images without a tag, or tagged latest
+func lintLatestTag(name string, service *yaml.Node) []Finding {
+	image := mappingValue(service, "image")
+	// Digests pin the image; interpolated references can't be judged
+	if image == nil || image.Kind != yaml.ScalarNode || strings.ContainsAny(image.Value, "@$") {
+		return nil
+	}
+	// The tag follows the last colon after the last slash (registry ports
+	// also use colons)
+	ref := image.Value[strings.LastIndex(image.Value, "/")+1:]
+	_, tag, tagged := strings.Cut(ref, ":")
+	if tagged && tag != "latest" {
+		return nil
+	}
+	message := fmt.Sprintf("image %s has no tag and defaults to latest", image.Value)
+	if tagged {
+		message = fmt.Sprintf("image %s uses the latest tag", image.Value)
+	}
+	return []Finding{{Path: "services." + name + ".image", Message: message + "; pin a version or digest", Line: image.Line}}
+}
+
+// lintHealthcheck flags services without an enabled healthcheck
+func lintHealthcheck(name string, service *yaml.Node) []Finding {
+	healthcheck := mappingValue(service, "healthcheck")
+	if healthcheck == nil {
+		return []Finding{{Path: "services." + name, Message: "no healthcheck: readiness can't be told from a running container", Line: service.Line}}
+	}
+	if disable := mappingValue(healthcheck, "disable"); disable != nil && isTrue(disable.Value) {
+		return []Finding{{Path: "services." + name + ".healthcheck", Message: "healthcheck is disabled", Line: healthcheck.Line}}
+	}
+	return nil
+}
+
+// lintResourceLimits flags services without memory or CPU limits
+func lintResourceLimits(name string, service *yaml.Node) []Finding {
+	for _, key := range []string{"mem_limit", "cpus"} {
+		if mappingValue(service, key) != nil {
+			return nil
+		}
+	}
+	limits := mappingValue(mappingValue(mappingValue(service, "deploy"), "resources"), "limits")
+	if mappingValue(limits, "memory") != nil || mappingValue(limits, "cpus") != nil {
+		return nil
+	}
+	return []Finding{{Path: "services." + name, Message: "no resource limits: set deploy.resources.limits.memory or cpus", Line: service.Line}}
+}
+
+// lintPrivileged flags services in privileged mode
+func lintPrivileged(name string, service *yaml.Node) []Finding {
+	privileged := mappingValue(service, "privileged")
+	if privileged == nil || !isTrue(privileged.Value) {
+		return nil
+	}
+	return []Finding{{Path: "services." + name + ".privileged", Message: "runs in privileged mode, with full access to the node", Line: privileged.Line}}
+}
+
+// isTrue reports whether a scalar is true, including YAML 1.1 spellings
+func isTrue(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "yes", "on":
+		return true
+	}
+	return false
+}
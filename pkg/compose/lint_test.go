@@ -0,0 +1,114 @@
+package compose_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/compose"
+)
+
+const lintCompose = `services:
+  web:
+    image: nginx
+    privileged: true
+  api:
+    image: registry.local:5000/api:1.4.2
+    healthcheck:
+      test: ["CMD", "curl", "-f", "http://localhost/healthz"]
+    deploy:
+      resources:
+        limits:
+          memory: 256M
+  worker:
+    image: worker:latest
+    mem_limit: 128m
+    healthcheck:
+      disable: true
+  pinned:
+    image: ${IMAGE}
+    cpus: 0.5
+    healthcheck:
+      test: ["CMD", "true"]
+`
+
+var _ = Describe("Lint", func() {
+	rules := func(findings []compose.Finding) []string {
+		var out []string
+		for _, f := range findings {
+			out = append(out, f.Service+":"+f.Rule+":"+f.Severity)
+		}
+		return out
+	}
+
+	It("should apply every rule with its default severity", func() {
+		findings, err := compose.Lint(lintCompose, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules(findings)).To(Equal([]string{
+			"web:no-latest-tag:warning",
+			"web:healthcheck-required:warning",
+			"web:resource-limits-required:warning",
+			"web:no-privileged:error",
+			"worker:no-latest-tag:warning",
+			"worker:healthcheck-required:warning",
+		}))
+		Expect(findings[0].Path).To(Equal("services.web.image"))
+		Expect(findings[0].Line).To(Equal(3))
+		Expect(findings[4].Message).To(ContainSubstring("uses the latest tag"))
+		Expect(findings[5].Message).To(Equal("healthcheck is disabled"))
+	})
+
+	It("should honor configured severities and ignored services", func() {
+		config := &compose.LintConfig{
+			Rules:  map[string]string{"healthcheck-required": compose.SeverityOff, "no-latest-tag": compose.SeverityError},
+			Ignore: map[string][]string{"no-privileged": {"web"}, "resource-limits-required": {"web"}},
+		}
+		findings, err := compose.Lint(lintCompose, config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules(findings)).To(Equal([]string{"web:no-latest-tag:error", "worker:no-latest-tag:error"}))
+	})
+
+	DescribeTable("failing severities",
+		func(failOn, severity string, fails bool) {
+			config := &compose.LintConfig{FailOn: failOn}
+			Expect(config.Fails(compose.Finding{Severity: severity})).To(Equal(fails))
+		},
+		Entry("errors fail by default", "", compose.SeverityError, true),
+		Entry("warnings pass by default", "", compose.SeverityWarning, false),
+		Entry("warnings fail with failOn warning", compose.SeverityWarning, compose.SeverityWarning, true),
+		Entry("info passes with failOn warning", compose.SeverityWarning, compose.SeverityInfo, false),
+	)
+
+	Describe("LoadLintConfig", func() {
+		write := func(content string) string {
+			path := filepath.Join(GinkgoT().TempDir(), compose.LintConfigFile)
+			Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+			return path
+		}
+
+		It("should read rules, ignores and failOn", func() {
+			config, err := compose.LoadLintConfig(write("rules:\n  no-privileged: warning\nignore:\n  healthcheck-required: [db]\nfailOn: warning\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Rules).To(HaveKeyWithValue("no-privileged", "warning"))
+			Expect(config.Ignore["healthcheck-required"]).To(Equal([]string{"db"}))
+			Expect(config.FailOn).To(Equal("warning"))
+		})
+
+		It("should default when the file is missing", func() {
+			config, err := compose.LoadLintConfig(filepath.Join(GinkgoT().TempDir(), "missing.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Rules).To(BeEmpty())
+		})
+
+		It("should reject unknown rules and severities", func() {
+			_, err := compose.LoadLintConfig(write("rules:\n  no-root: error\n"))
+			Expect(err).To(MatchError(ContainSubstring(`unknown rule "no-root"`)))
+			_, err = compose.LoadLintConfig(write("rules:\n  no-privileged: fatal\n"))
+			Expect(err).To(MatchError(ContainSubstring(`unknown severity "fatal"`)))
+			_, err = compose.LoadLintConfig(write("failOn: off\n"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package compose
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExtensionKey is the top-level compose extension holding Lissto settings
+const ExtensionKey = "x-lissto"
+
+// Param describes a blueprint parameter declared under x-lissto.params
+type Param struct {
+	Name        string `yaml:"-"`
+	Description string `yaml:"description,omitempty"`
+	Default     string `yaml:"default,omitempty"`
+	Required    bool   `yaml:"required,omitempty"`
+}
+
+// lisstoExtension is the subset of x-lissto the CLI understands
+type lisstoExtension struct {
+	Params map[string]Param `yaml:"params,omitempty"`
+}
+
+// ParseParams extracts declared parameters from compose content, sorted by name.
+//
+// Example:
+//
+//	x-lissto:
+//	  params:
+//	    hostname-prefix:
+//	      description: Prefix for exposed hostnames
+//	      default: dev
+func ParseParams(content string) ([]Param, error) {
+	var doc map[string]yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse compose content: %w", err)
+	}
+
+	node, ok := doc[ExtensionKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var ext lisstoExtension
+	if err := node.Decode(&ext); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ExtensionKey, err)
+	}
+
+	params := make([]Param, 0, len(ext.Params))
+	for name, p := range ext.Params {
+		p.Name = name
+		params = append(params, p)
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+
+	return params, nil
+}
+
+// UndeclaredParams returns the provided parameter names the blueprint doesn't
+// declare, sorted, e.g. typos in --param
+func UndeclaredParams(declared []Param, provided map[string]string) []string {
+	known := make(map[string]bool, len(declared))
+	for _, p := range declared {
+		known[p.Name] = true
+	}
+
+	var undeclared []string
+	for name := range provided {
+		if !known[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	return undeclared
+}
+
+// ResolveParams merges provided values with declared defaults and reports
+// required params that are still missing
+func ResolveParams(declared []Param, provided map[string]string) (map[string]string, []string) {
+	resolved := make(map[string]string, len(provided))
+	for k, v := range provided {
+		resolved[k] = v
+	}
+
+	var missing []string
+	for _, p := range declared {
+		if _, ok := resolved[p.Name]; ok {
+			continue
+		}
+		if p.Default != "" {
+			resolved[p.Name] = p.Default
+			continue
+		}
+		if p.Required {
+			missing = append(missing, p.Name)
+		}
+	}
+
+	return resolved, missing
+}
@@ -0,0 +1,68 @@
+package compose_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/compose"
+)
+
+var _ = Describe("Params", func() {
+	DescribeTable("ParseParams",
+		func(content string, expected []compose.Param, fails bool) {
+			params, err := compose.ParseParams(content)
+			if fails {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal(expected))
+		},
+		Entry("no extension", "services:\n  web:\n    image: nginx\n", nil, false),
+		Entry("extension without params", "x-lissto:\n  other: true\n", []compose.Param{}, false),
+		Entry("params sorted by name", `x-lissto:
+  params:
+    hostname-prefix:
+      description: Prefix for exposed hostnames
+      default: dev
+    feature-flag:
+      required: true
+`, []compose.Param{
+			{Name: "feature-flag", Required: true},
+			{Name: "hostname-prefix", Description: "Prefix for exposed hostnames", Default: "dev"},
+		}, false),
+		Entry("invalid YAML", "services: [", nil, true),
+		Entry("malformed params", "x-lissto:\n  params: [a, b]\n", nil, true),
+	)
+
+	declared := []compose.Param{
+		{Name: "feature-flag", Required: true},
+		{Name: "hostname-prefix", Default: "dev"},
+		{Name: "optional"},
+	}
+
+	DescribeTable("ResolveParams",
+		func(provided, expected map[string]string, missing []string) {
+			resolved, missingParams := compose.ResolveParams(declared, provided)
+			Expect(resolved).To(Equal(expected))
+			Expect(missingParams).To(Equal(missing))
+		},
+		Entry("defaults fill in", map[string]string{"feature-flag": "on"},
+			map[string]string{"feature-flag": "on", "hostname-prefix": "dev"}, nil),
+		Entry("provided values win", map[string]string{"feature-flag": "on", "hostname-prefix": "pr-1", "optional": "x"},
+			map[string]string{"feature-flag": "on", "hostname-prefix": "pr-1", "optional": "x"}, nil),
+		Entry("empty values count as provided", map[string]string{"feature-flag": ""},
+			map[string]string{"feature-flag": "", "hostname-prefix": "dev"}, nil),
+		Entry("required params are reported", map[string]string{},
+			map[string]string{"hostname-prefix": "dev"}, []string{"feature-flag"}),
+	)
+
+	DescribeTable("UndeclaredParams",
+		func(provided map[string]string, expected []string) {
+			Expect(compose.UndeclaredParams(declared, provided)).To(Equal(expected))
+		},
+		Entry("declared only", map[string]string{"feature-flag": "on", "optional": "x"}, nil),
+		Entry("typos sorted", map[string]string{"hostname_prefix": "x", "feature-flag": "on", "featureflag": "y"},
+			[]string{"featureflag", "hostname_prefix"}),
+	)
+})
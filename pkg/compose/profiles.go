@@ -0,0 +1,170 @@
+package compose
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// allProfiles enables every profile, as with 'docker compose --profile "*"'
+const allProfiles = "*"
+
+// ProfileResult describes how profiles were applied to a compose file
+type ProfileResult struct {
+	// Declared are all profiles used by the file's services
+	Declared []string
+	// Selected are the requested profiles
+	Selected []string
+	// Enabled are the services kept in the blueprint
+	Enabled []string
+	// Disabled are the services dropped because none of their profiles was selected
+	Disabled []string
+}
+
+// Print reports the selected profiles and the services they leave out of the
+// blueprint. Prints nothing for files without profiles.
+func (r *ProfileResult) Print(w io.Writer) {
+	if len(r.Declared) == 0 {
+		return
+	}
+
+	selected := "none (use --profile to include profiled services)"
+	if len(r.Selected) > 0 {
+		selected = strings.Join(r.Selected, ", ")
+	}
+	_, _ = fmt.Fprintf(w, "🏷️  Profiles: %s (available: %s)\n", selected, strings.Join(r.Declared, ", "))
+	if len(r.Disabled) > 0 {
+		_, _ = fmt.Fprintf(w, "   Excluded services: %s\n", strings.Join(r.Disabled, ", "))
+	}
+}
+
+// ApplyProfiles filters a compose document down to the services enabled by the
+// given profiles, following compose semantics: services without profiles are
+// always enabled, others only when one of their profiles is selected. The
+// profiles keys are removed from the result so the blueprint deploys exactly
+// the enabled services. Without selected profiles, or when no service uses
+// profiles, content is returned unchanged; the result still lists the services
+// compose leaves out by default.
+func ApplyProfiles(content string, profiles []string) (string, *ProfileResult, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return "", nil, fmt.Errorf("failed to parse compose content: %w", err)
+	}
+
+	result := &ProfileResult{Selected: profiles}
+	services := mappingValue(documentRoot(&doc), "services")
+	if services == nil || services.Kind != yaml.MappingNode {
+		return content, result, nil
+	}
+
+	selected := make(map[string]bool, len(profiles))
+	for _, p := range profiles {
+		selected[p] = true
+	}
+
+	declared := make(map[string]bool)
+	kept := make([]*yaml.Node, 0, len(services.Content))
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		name, def := services.Content[i], services.Content[i+1]
+
+		serviceProfiles := stringSeq(mappingValue(def, "profiles"))
+		for _, p := range serviceProfiles {
+			declared[p] = true
+		}
+
+		if !profileEnabled(serviceProfiles, selected) {
+			result.Disabled = append(result.Disabled, name.Value)
+			continue
+		}
+		result.Enabled = append(result.Enabled, name.Value)
+		removeMappingKey(def, "profiles")
+		kept = append(kept, name, def)
+	}
+
+	for p := range declared {
+		result.Declared = append(result.Declared, p)
+	}
+	sort.Strings(result.Declared)
+	sort.Strings(result.Enabled)
+	sort.Strings(result.Disabled)
+
+	for _, p := range profiles {
+		if p != allProfiles && !declared[p] {
+			return "", nil, fmt.Errorf("profile '%s' is not used by any service (available: %v)", p, result.Declared)
+		}
+	}
+
+	if len(profiles) == 0 || len(declared) == 0 {
+		return content, result, nil
+	}
+
+	services.Content = kept
+
+	filtered, err := encodeDocument(&doc)
+	if err != nil {
+		return "", nil, err
+	}
+	return filtered, result, nil
+}
+
+// profileEnabled reports whether a service with the given profiles is enabled
+func profileEnabled(serviceProfiles []string, selected map[string]bool) bool {
+	if len(serviceProfiles) == 0 || selected[allProfiles] {
+		return true
+	}
+	for _, p := range serviceProfiles {
+		if selected[p] {
+			return true
+		}
+	}
+	return false
+}
+
+// documentRoot returns the top-level node of a YAML document
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0]
+	}
+	return doc
+}
+
+// mappingValue returns the value of key in a mapping node (nil if absent)
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// removeMappingKey deletes key from a mapping node
+func removeMappingKey(node *yaml.Node, key string) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// stringSeq returns the scalar values of a sequence node
+func stringSeq(node *yaml.Node) []string {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	values := make([]string, 0, len(node.Content))
+	for _, item := range node.Content {
+		values = append(values, item.Value)
+	}
+	return values
+}
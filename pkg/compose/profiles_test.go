@@ -0,0 +1,50 @@
+package compose_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/compose"
+)
+
+const profiledCompose = `services:
+  api:
+    image: api
+  debug:
+    image: busybox
+    profiles: [debug]
+  admin:
+    image: adminer
+    profiles: [tools, debug]
+`
+
+var _ = Describe("ApplyProfiles", func() {
+	It("should leave content unchanged without selected profiles", func() {
+		content, result, err := compose.ApplyProfiles(profiledCompose, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal(profiledCompose))
+		Expect(result.Declared).To(Equal([]string{"debug", "tools"}))
+		Expect(result.Disabled).To(Equal([]string{"admin", "debug"}))
+	})
+
+	It("should keep services of the selected profiles and strip profiles keys", func() {
+		content, result, err := compose.ApplyProfiles(profiledCompose, []string{"tools"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Enabled).To(Equal([]string{"admin", "api"}))
+		Expect(result.Disabled).To(Equal([]string{"debug"}))
+		Expect(content).To(ContainSubstring("admin:"))
+		Expect(content).NotTo(ContainSubstring("debug"))
+		Expect(content).NotTo(ContainSubstring("profiles"))
+	})
+
+	It("should enable every service with the * profile", func() {
+		_, result, err := compose.ApplyProfiles(profiledCompose, []string{"*"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Disabled).To(BeEmpty())
+	})
+
+	It("should reject unknown profiles", func() {
+		_, _, err := compose.ApplyProfiles(profiledCompose, []string{"nope"})
+		Expect(err).To(MatchError(ContainSubstring("profile 'nope'")))
+	})
+})
@@ -0,0 +1,41 @@
+package compose_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/compose"
+)
+
+var _ = Describe("Watch", func() {
+	It("should report writes to the file once they settle", func() {
+		dir := GinkgoT().TempDir()
+		path := filepath.Join(dir, "compose.yaml")
+		Expect(os.WriteFile(path, []byte("services: {}\n"), 0644)).To(Succeed())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		changes := make(chan struct{}, 10)
+		done := make(chan error, 1)
+		go func() {
+			done <- compose.Watch(ctx, path, 50*time.Millisecond, func() { changes <- struct{}{} })
+		}()
+
+		// Give the watcher time to start, then write twice in quick succession
+		time.Sleep(100 * time.Millisecond)
+		Expect(os.WriteFile(filepath.Join(dir, "other.yaml"), []byte("x"), 0644)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("services:\n  a: {}\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("services:\n  b: {}\n"), 0644)).To(Succeed())
+
+		Eventually(changes).Should(Receive())
+		Consistently(changes, 200*time.Millisecond).ShouldNot(Receive())
+
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	})
+})
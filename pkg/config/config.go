@@ -3,13 +3,28 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/lissto-dev/cli/pkg/fileutil"
 	"gopkg.in/yaml.v3"
 )
 
 // Settings represents CLI behavior settings
 type Settings struct {
 	UpdateCheck bool `yaml:"update-check"`
+	// CredentialStore is where API keys and tokens are kept: "file" (default)
+	// or "keychain"
+	CredentialStore string `yaml:"credential-store,omitempty"`
+	// MCP restricts the tools 'lissto mcp' exposes to AI agents
+	MCP MCPSettings `yaml:"mcp,omitempty"`
+}
+
+// MCPSettings is the default tool policy of the MCP server. Tool names are
+// glob patterns and may leave out the "lissto_" prefix.
+type MCPSettings struct {
+	ReadOnly bool     `yaml:"read-only,omitempty"`
+	Allow    []string `yaml:"allow,omitempty"`
+	Deny     []string `yaml:"deny,omitempty"`
 }
 
 // DefaultSettings returns the default settings
@@ -26,17 +41,33 @@ type Config struct {
 	CurrentEnv     string    `yaml:"current-env,omitempty"`
 	Kubeconfig     string    `yaml:"kubeconfig,omitempty"`
 	Settings       Settings  `yaml:"settings"`
+	// Defaults are flag values used when a flag isn't given, by command
+	// (e.g. "logs", "stack.list") and flag name
+	Defaults map[string]map[string]string `yaml:"defaults,omitempty"`
+
+	// storedKeys are the credentials currently in the keychain, by account
+	storedKeys map[string]string
+	// unreadKeys are the accounts that couldn't be read from the keychain
+	unreadKeys map[string]bool
 }
 
 // Context represents an API connection context
 type Context struct {
-	Name             string `yaml:"name"`
-	KubeContext      string `yaml:"kube-context"`
+	Name        string `yaml:"name"`
+	KubeContext string `yaml:"kube-context"`
+	// Kubeconfig is the kubeconfig file of KubeContext, empty for
+	// $KUBECONFIG or ~/.kube/config
+	Kubeconfig       string `yaml:"kubeconfig,omitempty"`
 	ServiceName      string `yaml:"service-name"`
 	ServiceNamespace string `yaml:"service-namespace"`
-	APIKey           string `yaml:"api-key"`
+	APIKey           string `yaml:"api-key,omitempty"`
 	APIUrl           string `yaml:"api-url,omitempty"`
 	APIID            string `yaml:"api-id,omitempty"`
+	// AccessToken is a short-lived token from 'lissto login --web', used
+	// instead of APIKey and renewed with RefreshToken before TokenExpiry
+	AccessToken  string    `yaml:"access-token,omitempty"`
+	RefreshToken string    `yaml:"refresh-token,omitempty"`
+	TokenExpiry  time.Time `yaml:"token-expiry,omitempty"`
 }
 
 // LoadConfig loads the configuration from disk
@@ -46,6 +77,83 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to get config path: %w", err)
 	}
 
+	unlock, err := fileutil.LockFile(configPath, false)
+	if err != nil {
+		return nil, err
+	}
+	config, migrate, err := readConfig(configPath)
+	unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Move plaintext keys left by older versions into the keychain. The
+	// config is read again under the exclusive lock, so changes saved since
+	// the read above aren't overwritten. On failure the keys stay in the file
+	// and the migration is retried next time.
+	if migrate {
+		if err := UpdateConfig(func(c *Config) error {
+			config = c
+			return nil
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Failed to move API keys to keychain: %v\n", err)
+		}
+	}
+
+	return config, nil
+}
+
+// SaveConfig saves the configuration to disk
+func SaveConfig(config *Config) error {
+	if err := EnsureConfigDir(); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get config path: %w", err)
+	}
+
+	unlock, err := fileutil.LockFile(configPath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return writeConfig(configPath, config)
+}
+
+// UpdateConfig loads the configuration, applies update and saves it, holding
+// the config lock throughout so concurrent invocations don't overwrite each
+// other's changes. Nothing is saved when update fails.
+func UpdateConfig(update func(*Config) error) error {
+	if err := EnsureConfigDir(); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get config path: %w", err)
+	}
+
+	unlock, err := fileutil.LockFile(configPath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	config, _, err := readConfig(configPath)
+	if err != nil {
+		return err
+	}
+	if err := update(config); err != nil {
+		return err
+	}
+	return writeConfig(configPath, config)
+}
+
+// readConfig reads and parses the config file, reporting whether it still
+// holds plaintext API keys to migrate to the keychain
+func readConfig(configPath string) (*Config, bool, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -53,9 +161,9 @@ func LoadConfig() (*Config, error) {
 			return &Config{
 				Contexts: []Context{},
 				Settings: DefaultSettings(),
-			}, nil
+			}, false, nil
 		}
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, false, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	// Start with default settings
@@ -63,37 +171,62 @@ func LoadConfig() (*Config, error) {
 		Settings: DefaultSettings(),
 	}
 	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+		return nil, false, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	return &config, nil
+	migrate := config.loadCredentials()
+	return &config, migrate, nil
 }
 
-// SaveConfig saves the configuration to disk
-func SaveConfig(config *Config) error {
-	if err := EnsureConfigDir(); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
-	}
-
-	configPath, err := GetConfigPath()
+// writeConfig stores credentials in the credential store and atomically
+// replaces the config file with the rest
+func writeConfig(configPath string, config *Config) error {
+	contexts, err := config.saveCredentials()
 	if err != nil {
-		return fmt.Errorf("failed to get config path: %w", err)
+		return err
 	}
+	onDisk := *config
+	onDisk.Contexts = contexts
 
-	data, err := yaml.Marshal(config)
+	data, err := yaml.Marshal(&onDisk)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0600); err != nil {
+	if err := fileutil.WriteFileAtomic(configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	return nil
 }
 
-// GetCurrentContext returns the current active context
+// EnvContext selects the context of a single invocation, like --context
+const EnvContext = "LISSTO_CONTEXT"
+
+// contextOverride is the context used instead of the configured current one
+var contextOverride string
+
+// SetContextOverride makes the named context the active one for the rest of
+// the process, without changing the config file, so concurrent invocations
+// can target different contexts. An empty name restores the current context.
+func SetContextOverride(name string) {
+	contextOverride = name
+}
+
+// ActiveContextName returns the name of the active context: the override if
+// one is set, otherwise the configured current context
+func (c *Config) ActiveContextName() string {
+	if contextOverride != "" {
+		return contextOverride
+	}
+	return c.CurrentContext
+}
+
+// GetCurrentContext returns the active context, see ActiveContextName
 func (c *Config) GetCurrentContext() (*Context, error) {
+	if contextOverride != "" {
+		return c.GetContext(contextOverride)
+	}
 	if c.CurrentContext == "" {
 		return nil, fmt.Errorf("no context selected")
 	}
@@ -0,0 +1,68 @@
+package config_test
+
+import (
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/config"
+)
+
+var _ = Describe("UpdateConfig", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", GinkgoT().TempDir())
+	})
+
+	It("should keep the updates of concurrent calls", func() {
+		const updates = 20
+		var wg sync.WaitGroup
+		errs := make(chan error, 2*updates)
+		for _, prefix := range []string{"a", "b"} {
+			wg.Add(1)
+			go func(prefix string) {
+				defer wg.Done()
+				for i := 0; i < updates; i++ {
+					name := fmt.Sprintf("%s-%d", prefix, i)
+					errs <- config.UpdateConfig(func(cfg *config.Config) error {
+						cfg.AddOrUpdateContext(config.Context{Name: name, KubeContext: prefix})
+						return nil
+					})
+				}
+			}(prefix)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		cfg, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Contexts).To(HaveLen(2 * updates))
+		for _, prefix := range []string{"a", "b"} {
+			for i := 0; i < updates; i++ {
+				_, err := cfg.GetContext(fmt.Sprintf("%s-%d", prefix, i))
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+	})
+
+	It("should save nothing when the update fails", func() {
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.AddOrUpdateContext(config.Context{Name: "dev"})
+			return nil
+		})).To(Succeed())
+
+		err := config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.AddOrUpdateContext(config.Context{Name: "staging"})
+			return fmt.Errorf("boom")
+		})
+		Expect(err).To(MatchError("boom"))
+
+		cfg, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Contexts).To(HaveLen(1))
+	})
+})
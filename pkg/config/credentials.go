@@ -0,0 +1,189 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Credential stores for Settings.CredentialStore
+const (
+	// CredentialStoreFile keeps API keys and tokens in config.yaml (the default)
+	CredentialStoreFile = "file"
+	// CredentialStoreKeychain keeps API keys and tokens in the OS keychain: macOS
+	// Keychain, Windows Credential Manager or Secret Service (libsecret)
+	CredentialStoreKeychain = "keychain"
+)
+
+// credentialService is the service name credentials are stored under
+const credentialService = "lissto"
+
+// ErrCredentialNotFound means the store has no such credential
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialStore stores the credentials of contexts by account name
+type CredentialStore interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// keychainStore creates the OS keychain store, see SetKeychainStore
+var keychainStore = newKeychainStore
+
+// SetKeychainStore replaces the OS keychain with store, e.g. an in-memory
+// store in tests, until the returned func restores it
+func SetKeychainStore(store CredentialStore) (restore func()) {
+	previous := keychainStore
+	keychainStore = func() CredentialStore { return store }
+	return func() { keychainStore = previous }
+}
+
+// ValidateCredentialStore checks a credential-store setting value
+func ValidateCredentialStore(name string) error {
+	switch name {
+	case CredentialStoreFile, CredentialStoreKeychain:
+		return nil
+	default:
+		return fmt.Errorf("unknown credential store '%s' (use '%s' or '%s')", name, CredentialStoreFile, CredentialStoreKeychain)
+	}
+}
+
+// credentialStore returns the keychain store, or nil when credentials are
+// kept in the config file
+func (c *Config) credentialStore() CredentialStore {
+	if c.Settings.CredentialStore != CredentialStoreKeychain {
+		return nil
+	}
+	return keychainStore()
+}
+
+// secret is a credential of a context that the keychain store keeps
+type secret struct {
+	// account is the keychain entry it's stored under
+	account string
+	// kind names it in messages
+	kind  string
+	value *string
+}
+
+// secrets returns the credentials of a context. The API key is stored under
+// the context name, the tokens of 'lissto login --web' under the context name
+// with a suffix.
+func secrets(ctx *Context) []secret {
+	return []secret{
+		{account: ctx.Name, kind: "API key", value: &ctx.APIKey},
+		{account: ctx.Name + "/access-token", kind: "access token", value: &ctx.AccessToken},
+		{account: ctx.Name + "/refresh-token", kind: "refresh token", value: &ctx.RefreshToken},
+	}
+}
+
+// loadCredentials fills in credentials from the keychain. Credentials still
+// found in the config file are moved to the keychain; migrated reports
+// whether that happened so the stripped config can be saved.
+func (c *Config) loadCredentials() (migrated bool) {
+	store := c.credentialStore()
+	if store == nil {
+		return false
+	}
+
+	c.storedKeys = make(map[string]string)
+	c.unreadKeys = make(map[string]bool)
+	for i := range c.Contexts {
+		ctx := &c.Contexts[i]
+		for _, s := range secrets(ctx) {
+			if *s.value != "" {
+				migrated = true
+				continue
+			}
+			if s.account != ctx.Name && ctx.APIKey != "" {
+				// Contexts log in with an API key or with tokens, so
+				// there's no need to look up tokens
+				continue
+			}
+
+			value, err := store.Get(s.account)
+			if errors.Is(err, ErrCredentialNotFound) {
+				continue
+			}
+			if err != nil {
+				// Not fatal: commands that don't call the API still work, and
+				// 'lissto config set credential-store file' must stay reachable
+				fmt.Fprintf(os.Stderr, "⚠️  Failed to read %s for context '%s' from keychain: %v\n", s.kind, ctx.Name, err)
+				c.unreadKeys[s.account] = true
+				continue
+			}
+			*s.value = value
+			c.storedKeys[s.account] = value
+		}
+	}
+	return migrated
+}
+
+// saveCredentials syncs credentials with the keychain and returns the
+// contexts to write to the config file, without their credentials when the
+// keychain is used
+func (c *Config) saveCredentials() ([]Context, error) {
+	store := c.credentialStore()
+	if store == nil {
+		// Switched back to the file store: move credentials out of the
+		// keychain, unless some couldn't be read and would be lost
+		for i := range c.Contexts {
+			for _, s := range secrets(&c.Contexts[i]) {
+				if c.unreadKeys[s.account] && *s.value == "" {
+					return nil, fmt.Errorf("%s for context '%s' couldn't be read from keychain; fix keychain access before switching to the file store", s.kind, c.Contexts[i].Name)
+				}
+			}
+		}
+		if len(c.storedKeys) > 0 {
+			keychain := keychainStore()
+			for account := range c.storedKeys {
+				if err := keychain.Delete(account); err != nil && !errors.Is(err, ErrCredentialNotFound) {
+					return nil, fmt.Errorf("failed to remove credential '%s' from keychain: %w", account, err)
+				}
+			}
+			c.storedKeys = nil
+		}
+		return c.Contexts, nil
+	}
+
+	if c.storedKeys == nil {
+		c.storedKeys = make(map[string]string)
+	}
+
+	contexts := make([]Context, len(c.Contexts))
+	current := make(map[string]bool)
+	for i, ctx := range c.Contexts {
+		for _, s := range secrets(&ctx) {
+			stored, ok := c.storedKeys[s.account]
+			switch {
+			case *s.value == "":
+				// Cleared, e.g. an API key replaced by 'lissto login --web'
+				if ok {
+					continue
+				}
+			case !ok || stored != *s.value:
+				if err := store.Set(s.account, *s.value); err != nil {
+					return nil, fmt.Errorf("failed to save %s for context '%s' to keychain: %w", s.kind, ctx.Name, err)
+				}
+				c.storedKeys[s.account] = *s.value
+			}
+			current[s.account] = true
+			*s.value = ""
+		}
+		contexts[i] = ctx
+	}
+
+	// Remove credentials of deleted contexts and cleared ones
+	for account := range c.storedKeys {
+		if current[account] {
+			continue
+		}
+		if err := store.Delete(account); err != nil && !errors.Is(err, ErrCredentialNotFound) {
+			return nil, fmt.Errorf("failed to remove credential '%s' from keychain: %w", account, err)
+		}
+		delete(c.storedKeys, account)
+	}
+
+	return contexts, nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// securityNotFound is the exit code of 'security' for a missing item
+const securityNotFound = 44
+
+// macKeychain stores API keys in the macOS login keychain using the
+// security tool
+type macKeychain struct{}
+
+func newKeychainStore() CredentialStore {
+	return macKeychain{}
+}
+
+func (macKeychain) Get(context string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", credentialService, "-a", context, "-w").Output()
+	if err != nil {
+		return "", securityError(err)
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set passes the key through stdin ('security -i') so it never shows up in
+// the process list
+func (macKeychain) Set(context, apiKey string) error {
+	command := fmt.Sprintf("add-generic-password -U -s %q -a %q -X %s\n", credentialService, context, hex.EncodeToString([]byte(apiKey)))
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(command)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (macKeychain) Delete(context string) error {
+	return securityError(exec.Command("security", "delete-generic-password", "-s", credentialService, "-a", context).Run())
+}
+
+func securityError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == securityNotFound {
+		return ErrCredentialNotFound
+	}
+	return err
+}
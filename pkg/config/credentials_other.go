@@ -0,0 +1,61 @@
+//go:build !darwin && !windows
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretService stores API keys through the freedesktop Secret Service
+// (GNOME Keyring, KWallet) using libsecret's secret-tool
+type secretService struct{}
+
+func newKeychainStore() CredentialStore {
+	return secretService{}
+}
+
+func (secretService) Get(context string) (string, error) {
+	out, err := secretTool(nil, "lookup", "service", credentialService, "context", context)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && len(out) == 0 {
+			return "", ErrCredentialNotFound
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set passes the key through stdin so it never shows up in the process list
+func (secretService) Set(context, apiKey string) error {
+	label := fmt.Sprintf("Lissto API key (%s)", context)
+	_, err := secretTool(strings.NewReader(apiKey), "store", "--label", label, "service", credentialService, "context", context)
+	return err
+}
+
+func (secretService) Delete(context string) error {
+	_, err := secretTool(nil, "clear", "service", credentialService, "context", context)
+	return err
+}
+
+func secretTool(stdin *strings.Reader, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return nil, fmt.Errorf("secret-tool not found (install libsecret-tools or use 'lissto config set credential-store file')")
+	}
+
+	cmd := exec.Command("secret-tool", args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil && stderr.Len() > 0 {
+		return out, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, err
+}
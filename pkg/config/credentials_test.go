@@ -0,0 +1,244 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/config"
+)
+
+// fakeStore is an in-memory keychain whose calls can be made to fail
+type fakeStore struct {
+	mu   sync.Mutex
+	keys map[string]string
+	err  error
+}
+
+func (s *fakeStore) Get(context string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return "", s.err
+	}
+	key, ok := s.keys[context]
+	if !ok {
+		return "", config.ErrCredentialNotFound
+	}
+	return key, nil
+}
+
+func (s *fakeStore) Set(context, apiKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.keys[context] = apiKey
+	return nil
+}
+
+func (s *fakeStore) Delete(context string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	if _, ok := s.keys[context]; !ok {
+		return config.ErrCredentialNotFound
+	}
+	delete(s.keys, context)
+	return nil
+}
+
+var _ = Describe("Credential store", func() {
+	var store *fakeStore
+
+	// configFile returns the raw config file
+	configFile := func() string {
+		path, err := config.GetConfigPath()
+		Expect(err).NotTo(HaveOccurred())
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+	// writeConfigFile replaces the raw config file
+	writeConfigFile := func(content string) {
+		Expect(config.EnsureConfigDir()).To(Succeed())
+		path, err := config.GetConfigPath()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+	}
+	apiKey := func(name string) string {
+		cfg, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		ctx, err := cfg.GetContext(name)
+		Expect(err).NotTo(HaveOccurred())
+		return ctx.APIKey
+	}
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", GinkgoT().TempDir())
+		store = &fakeStore{keys: map[string]string{}}
+		DeferCleanup(config.SetKeychainStore(store))
+	})
+
+	It("should keep keys in the config file with the file store", func() {
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.AddOrUpdateContext(config.Context{Name: "dev", APIKey: "key-dev"})
+			return nil
+		})).To(Succeed())
+		Expect(configFile()).To(ContainSubstring("key-dev"))
+		Expect(store.keys).To(BeEmpty())
+	})
+
+	It("should save keys to the keychain and load them back", func() {
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.Settings.CredentialStore = config.CredentialStoreKeychain
+			cfg.AddOrUpdateContext(config.Context{Name: "dev", APIKey: "key-dev"})
+			return nil
+		})).To(Succeed())
+
+		Expect(store.keys).To(Equal(map[string]string{"dev": "key-dev"}))
+		Expect(configFile()).NotTo(ContainSubstring("key-dev"))
+		Expect(apiKey("dev")).To(Equal("key-dev"))
+	})
+
+	It("should migrate plaintext keys to the keychain", func() {
+		writeConfigFile(`settings:
+  credential-store: keychain
+contexts:
+- name: dev
+  api-key: key-dev
+`)
+		Expect(apiKey("dev")).To(Equal("key-dev"))
+		Expect(store.keys).To(Equal(map[string]string{"dev": "key-dev"}))
+		Expect(configFile()).NotTo(ContainSubstring("key-dev"))
+	})
+
+	It("should keep plaintext keys in the file when the keychain fails during migration", func() {
+		writeConfigFile(`settings:
+  credential-store: keychain
+contexts:
+- name: dev
+  api-key: key-dev
+`)
+		store.err = errors.New("keychain locked")
+		Expect(apiKey("dev")).To(Equal("key-dev"))
+		Expect(configFile()).To(ContainSubstring("key-dev"))
+
+		store.err = nil
+		Expect(apiKey("dev")).To(Equal("key-dev"))
+		Expect(store.keys).To(Equal(map[string]string{"dev": "key-dev"}))
+		Expect(configFile()).NotTo(ContainSubstring("key-dev"))
+	})
+
+	It("should delete the key of a deleted context", func() {
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.Settings.CredentialStore = config.CredentialStoreKeychain
+			cfg.AddOrUpdateContext(config.Context{Name: "dev", APIKey: "key-dev"})
+			cfg.AddOrUpdateContext(config.Context{Name: "staging", APIKey: "key-staging"})
+			return nil
+		})).To(Succeed())
+
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			return cfg.DeleteContext("dev")
+		})).To(Succeed())
+		Expect(store.keys).To(Equal(map[string]string{"staging": "key-staging"}))
+	})
+
+	It("should move keys back to the file when switching to the file store", func() {
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.Settings.CredentialStore = config.CredentialStoreKeychain
+			cfg.AddOrUpdateContext(config.Context{Name: "dev", APIKey: "key-dev"})
+			return nil
+		})).To(Succeed())
+
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.Settings.CredentialStore = config.CredentialStoreFile
+			return nil
+		})).To(Succeed())
+		Expect(store.keys).To(BeEmpty())
+		Expect(configFile()).To(ContainSubstring("key-dev"))
+	})
+
+	It("should keep login tokens out of the config file with the keychain store", func() {
+		expiry := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.Settings.CredentialStore = config.CredentialStoreKeychain
+			cfg.AddOrUpdateContext(config.Context{Name: "dev", AccessToken: "access-1", RefreshToken: "refresh-1", TokenExpiry: expiry})
+			return nil
+		})).To(Succeed())
+
+		Expect(store.keys).To(Equal(map[string]string{"dev/access-token": "access-1", "dev/refresh-token": "refresh-1"}))
+		Expect(configFile()).NotTo(ContainSubstring("access-1"))
+		Expect(configFile()).NotTo(ContainSubstring("refresh-1"))
+		Expect(configFile()).NotTo(ContainSubstring("access-token"))
+
+		cfg, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		ctx, err := cfg.GetContext("dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ctx.AccessToken).To(Equal("access-1"))
+		Expect(ctx.RefreshToken).To(Equal("refresh-1"))
+		Expect(ctx.TokenExpiry).To(Equal(expiry))
+	})
+
+	It("should migrate plaintext tokens to the keychain", func() {
+		writeConfigFile(`settings:
+  credential-store: keychain
+contexts:
+- name: dev
+  access-token: access-1
+  refresh-token: refresh-1
+`)
+		_, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.keys).To(Equal(map[string]string{"dev/access-token": "access-1", "dev/refresh-token": "refresh-1"}))
+		Expect(configFile()).NotTo(ContainSubstring("access-1"))
+		Expect(configFile()).NotTo(ContainSubstring("refresh-1"))
+	})
+
+	It("should remove an API key replaced by a web login", func() {
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.Settings.CredentialStore = config.CredentialStoreKeychain
+			cfg.AddOrUpdateContext(config.Context{Name: "dev", APIKey: "key-dev"})
+			return nil
+		})).To(Succeed())
+
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.AddOrUpdateContext(config.Context{Name: "dev", AccessToken: "access-1", RefreshToken: "refresh-1"})
+			return nil
+		})).To(Succeed())
+		Expect(store.keys).To(Equal(map[string]string{"dev/access-token": "access-1", "dev/refresh-token": "refresh-1"}))
+		Expect(apiKey("dev")).To(BeEmpty())
+	})
+
+	It("should not drop keys it can't read from the keychain", func() {
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.Settings.CredentialStore = config.CredentialStoreKeychain
+			cfg.AddOrUpdateContext(config.Context{Name: "dev", APIKey: "key-dev"})
+			return nil
+		})).To(Succeed())
+
+		store.err = errors.New("keychain locked")
+		err := config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.Settings.CredentialStore = config.CredentialStoreFile
+			return nil
+		})
+		Expect(err).To(MatchError(ContainSubstring("couldn't be read from keychain")))
+
+		// Other changes keep the key in the keychain
+		Expect(config.UpdateConfig(func(cfg *config.Config) error {
+			cfg.CurrentEnv = "dev"
+			return nil
+		})).To(Succeed())
+		store.err = nil
+		Expect(store.keys).To(Equal(map[string]string{"dev": "key-dev"}))
+		Expect(apiKey("dev")).To(Equal("key-dev"))
+	})
+})
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredRead   = advapi32.NewProc("CredReadW")
+	procCredWrite  = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+// winCredential mirrors the CREDENTIALW struct
+type winCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// winCredentialManager stores API keys as generic credentials in the
+// Windows Credential Manager
+type winCredentialManager struct{}
+
+func newKeychainStore() CredentialStore {
+	return winCredentialManager{}
+}
+
+func credentialTarget(context string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(credentialService + ":" + context)
+}
+
+func (winCredentialManager) Get(context string) (string, error) {
+	target, err := credentialTarget(context)
+	if err != nil {
+		return "", err
+	}
+
+	var cred *winCredential
+	ret, _, callErr := procCredRead.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		return "", credentialError(callErr)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+func (winCredentialManager) Set(context, apiKey string) error {
+	target, err := credentialTarget(context)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(context)
+	if err != nil {
+		return err
+	}
+
+	blob := []byte(apiKey)
+	cred := winCredential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		UserName:           user,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+
+	ret, _, callErr := procCredWrite.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return credentialError(callErr)
+	}
+	return nil
+}
+
+func (winCredentialManager) Delete(context string) error {
+	target, err := credentialTarget(context)
+	if err != nil {
+		return err
+	}
+
+	ret, _, callErr := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if ret == 0 {
+		return credentialError(callErr)
+	}
+	return nil
+}
+
+func credentialError(err error) error {
+	if errors.Is(err, errorNotFound) {
+		return ErrCredentialNotFound
+	}
+	return err
+}
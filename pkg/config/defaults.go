@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultsKeyPrefix is the prefix of default flag keys in 'lissto config',
+// e.g. defaults.logs.tail
+const DefaultsKeyPrefix = "defaults."
+
+// ParseDefaultsKey splits a key like defaults.stack.list.output into the
+// command ("stack.list") and the flag ("output")
+func ParseDefaultsKey(key string) (command, flag string, err error) {
+	rest, ok := strings.CutPrefix(key, DefaultsKeyPrefix)
+	if !ok {
+		return "", "", fmt.Errorf("not a defaults key: %s", key)
+	}
+	i := strings.LastIndex(rest, ".")
+	if i <= 0 || i == len(rest)-1 {
+		return "", "", fmt.Errorf("invalid defaults key: %s (use defaults.<command>.<flag>, e.g. defaults.logs.tail)", key)
+	}
+	return rest[:i], rest[i+1:], nil
+}
+
+// CommandDefaults returns the default flag values of a command, by flag name.
+// The command is its path without "lissto", with dots: "logs", "stack.list".
+func (c *Config) CommandDefaults(command string) map[string]string {
+	return c.Defaults[command]
+}
+
+// SetDefault sets the default value of a command's flag; an empty value
+// removes it
+func (c *Config) SetDefault(command, flag, value string) {
+	if value == "" {
+		delete(c.Defaults[command], flag)
+		if len(c.Defaults[command]) == 0 {
+			delete(c.Defaults, command)
+		}
+		return
+	}
+	if c.Defaults == nil {
+		c.Defaults = make(map[string]map[string]string)
+	}
+	if c.Defaults[command] == nil {
+		c.Defaults[command] = make(map[string]string)
+	}
+	c.Defaults[command][flag] = value
+}
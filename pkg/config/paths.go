@@ -40,15 +40,6 @@ func GetCacheDir() (string, error) {
 	return filepath.Join(cacheHome, "lissto"), nil
 }
 
-// GetEnvCachePath returns the full path to the env cache file
-func GetEnvCachePath() (string, error) {
-	cacheDir, err := GetCacheDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(cacheDir, "envs.yaml"), nil
-}
-
 // EnsureConfigDir creates the config directory if it doesn't exist
 func EnsureConfigDir() error {
 	configDir, err := GetConfigDir()
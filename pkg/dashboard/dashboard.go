@@ -0,0 +1,261 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+// DefaultInterval is the default live refresh interval
+const DefaultInterval = 5 * time.Second
+
+// Target identifies the stack an action applies to
+type Target struct {
+	Env   string
+	Stack status.StackReport
+}
+
+// Actions connects the dashboard to the API and cluster. Load is required;
+// a nil action disables its keybinding.
+type Actions struct {
+	// Load fetches the status of all stacks
+	Load func(ctx context.Context) (*status.Report, error)
+	// Restart restarts the pods of a stack
+	Restart func(ctx context.Context, target Target) error
+	// Delete deletes a stack
+	Delete func(ctx context.Context, target Target) error
+	// Logs returns the command streaming a stack's logs
+	Logs func(target Target) *exec.Cmd
+	// Update returns the command updating a stack
+	Update func(target Target) *exec.Cmd
+}
+
+// Messages driving the model
+type (
+	reportMsg struct {
+		report *status.Report
+		err    error
+	}
+	tickMsg   time.Time
+	actionMsg struct {
+		message string
+		err     error
+	}
+)
+
+// pendingAction is a destructive action waiting for confirmation
+type pendingAction struct {
+	prompt string
+	run    func(ctx context.Context, target Target) error
+	done   string
+	target Target
+}
+
+// Model is the dashboard state
+type Model struct {
+	actions  Actions
+	interval time.Duration
+
+	report  *status.Report
+	targets []Target
+	cursor  int
+	updated time.Time
+	loading bool
+	err     error
+
+	message string
+	confirm *pendingAction
+
+	width, height int
+}
+
+// New creates the dashboard model
+func New(actions Actions, interval time.Duration) *Model {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Model{actions: actions, interval: interval, loading: true}
+}
+
+// Run starts the dashboard in the alternate screen until the user quits or
+// ctx is done. Colors are off with --no-color or NO_COLOR.
+func Run(ctx context.Context, actions Actions, interval time.Duration) error {
+	if !output.ColorEnabled() {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	_, err := tea.NewProgram(New(actions, interval), tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	return err
+}
+
+// Init loads the first report and starts the refresh loop
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(m.load(), m.tick())
+}
+
+// load fetches a fresh report in the background
+func (m *Model) load() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		report, err := m.actions.Load(ctx)
+		return reportMsg{report: report, err: err}
+	}
+}
+
+// tick schedules the next refresh
+func (m *Model) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+// Update handles messages and key presses
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case reportMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.setReport(msg.report)
+		}
+		return m, nil
+
+	case tickMsg:
+		m.loading = true
+		return m, tea.Batch(m.load(), m.tick())
+
+	case actionMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("❌ %v", msg.err)
+		} else {
+			m.message = msg.message
+		}
+		m.loading = true
+		return m, m.load()
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey applies a key press
+func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	if m.confirm != nil {
+		pending := m.confirm
+		m.confirm = nil
+		if key != "y" && key != "Y" {
+			m.message = "Cancelled"
+			return m, nil
+		}
+		m.message = fmt.Sprintf("⏳ %s...", pending.done)
+		return m, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			if err := pending.run(ctx, pending.target); err != nil {
+				return actionMsg{err: err}
+			}
+			return actionMsg{message: fmt.Sprintf("✅ %s: %s", pending.done, pending.target.Stack.Name)}
+		}
+	}
+
+	switch key {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.targets)-1 {
+			m.cursor++
+		}
+	case "ctrl+r":
+		m.loading = true
+		return m, m.load()
+	case "l":
+		return m, m.exec(m.actions.Logs, "logs")
+	case "u":
+		return m, m.exec(m.actions.Update, "update")
+	case "r":
+		m.ask(m.actions.Restart, "Restart all pods of", "Restarted")
+	case "d":
+		m.ask(m.actions.Delete, "Delete stack", "Deleted")
+	}
+	return m, nil
+}
+
+// ask requests confirmation for a destructive action on the selected stack
+func (m *Model) ask(run func(ctx context.Context, target Target) error, prompt, done string) {
+	target, ok := m.Selected()
+	if !ok || run == nil {
+		return
+	}
+	m.confirm = &pendingAction{
+		prompt: fmt.Sprintf("%s '%s'? (y/N)", prompt, target.Stack.Name),
+		run:    run,
+		done:   done,
+		target: target,
+	}
+}
+
+// exec suspends the dashboard to run an interactive command on the selected stack
+func (m *Model) exec(build func(target Target) *exec.Cmd, name string) tea.Cmd {
+	target, ok := m.Selected()
+	if !ok || build == nil {
+		return nil
+	}
+	return tea.ExecProcess(build(target), func(err error) tea.Msg {
+		// Non-zero exits (including Ctrl+C) were already reported by the command
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) {
+			return actionMsg{err: fmt.Errorf("%s: %w", name, err)}
+		}
+		return actionMsg{}
+	})
+}
+
+// setReport replaces the report, keeping the selection on the same stack
+func (m *Model) setReport(report *status.Report) {
+	selected, hadSelection := m.Selected()
+
+	m.report = report
+	m.targets = m.targets[:0]
+	for _, env := range report.Envs {
+		for _, stack := range env.Stacks {
+			m.targets = append(m.targets, Target{Env: env.Name, Stack: stack})
+		}
+	}
+	m.updated = time.Now()
+
+	if hadSelection {
+		for i, t := range m.targets {
+			if t.Env == selected.Env && t.Stack.Name == selected.Stack.Name {
+				m.cursor = i
+				return
+			}
+		}
+	}
+	m.cursor = min(m.cursor, max(len(m.targets)-1, 0))
+}
+
+// Selected returns the stack under the cursor
+func (m *Model) Selected() (Target, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.targets) {
+		return Target{}, false
+	}
+	return m.targets[m.cursor], true
+}
@@ -0,0 +1,87 @@
+package dashboard_test
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/dashboard"
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+func key(k string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+var _ = Describe("Dashboard", func() {
+	var (
+		report  *status.Report
+		deleted []string
+		model   *dashboard.Model
+	)
+
+	// refresh triggers a reload and feeds the loaded report back to the model
+	refresh := func() {
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+		model.Update(cmd())
+	}
+
+	BeforeEach(func() {
+		report = &status.Report{Envs: []status.EnvReport{
+			{Name: "dev", Stacks: []status.StackReport{{Name: "a", State: status.StateReady}, {Name: "b"}}},
+			{Name: "prod", Stacks: []status.StackReport{{Name: "c"}}},
+		}}
+		deleted = nil
+		model = dashboard.New(dashboard.Actions{
+			Load: func(ctx context.Context) (*status.Report, error) { return report, nil },
+			Delete: func(ctx context.Context, target dashboard.Target) error {
+				deleted = append(deleted, target.Env+"/"+target.Stack.Name)
+				return nil
+			},
+		}, 0)
+		refresh()
+	})
+
+	It("should navigate across environments", func() {
+		model.Update(key("j"))
+		model.Update(key("j"))
+		model.Update(key("j"))
+
+		target, ok := model.Selected()
+		Expect(ok).To(BeTrue())
+		Expect(target.Env).To(Equal("prod"))
+		Expect(target.Stack.Name).To(Equal("c"))
+	})
+
+	It("should keep the selection on the same stack after a refresh", func() {
+		model.Update(key("j"))
+		report.Envs[0].Stacks = []status.StackReport{{Name: "new"}, {Name: "a"}, {Name: "b"}}
+		refresh()
+
+		target, _ := model.Selected()
+		Expect(target.Stack.Name).To(Equal("b"))
+	})
+
+	It("should only delete after confirmation", func() {
+		model.Update(key("d"))
+		_, cmd := model.Update(key("n"))
+		Expect(cmd).To(BeNil())
+		Expect(deleted).To(BeEmpty())
+
+		model.Update(key("d"))
+		_, cmd = model.Update(key("y"))
+		Expect(cmd).NotTo(BeNil())
+		cmd()
+		Expect(deleted).To(Equal([]string{"dev/a"}))
+	})
+
+	It("should render stacks and details", func() {
+		model.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+		view := model.View()
+		Expect(view).To(ContainSubstring("dev"))
+		Expect(view).To(ContainSubstring("prod"))
+		Expect(view).To(ContainSubstring("Blueprint:"))
+	})
+})
@@ -0,0 +1,229 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+// listWidth is the width of the stack list pane
+const listWidth = 34
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	envStyle      = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+
+	stateStyles = map[string]lipgloss.Style{
+		status.StateReady:     lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
+		status.StateDeploying: lipgloss.NewStyle().Foreground(lipgloss.Color("3")),
+		status.PodStateError:  lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+		status.StateFailed:    lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+	}
+)
+
+// keyHelp lists the keybindings shown in the footer
+const keyHelp = "↑/↓ select · l logs · u update · r restart · d delete · ctrl+r refresh · q quit"
+
+// View renders the dashboard
+func (m *Model) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	header := m.viewHeader()
+	footer := m.viewFooter()
+
+	// Pane borders take two rows and columns
+	bodyHeight := max(m.height-lipgloss.Height(header)-lipgloss.Height(footer)-2, 3)
+	detailWidth := max(m.width-listWidth-8, 20)
+
+	list := paneStyle.Width(listWidth).Height(bodyHeight).Render(clip(m.viewList(), listWidth, bodyHeight))
+	detail := paneStyle.Width(detailWidth).Height(bodyHeight).Render(clip(m.viewDetail(), detailWidth, bodyHeight))
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, lipgloss.JoinHorizontal(lipgloss.Top, list, detail), footer)
+}
+
+// viewHeader renders the title and refresh state
+func (m *Model) viewHeader() string {
+	line := titleStyle.Render("Lissto Dashboard")
+	if !m.updated.IsZero() {
+		line += dimStyle.Render(fmt.Sprintf("  ·  updated %s", m.updated.Format("15:04:05")))
+	}
+	if m.loading {
+		line += dimStyle.Render("  ·  refreshing…")
+	}
+	if m.err != nil {
+		line += "  " + errorStyle.Render(fmt.Sprintf("⚠ %v", m.err))
+	}
+	return line
+}
+
+// viewFooter renders the confirmation prompt or status message and key help
+func (m *Model) viewFooter() string {
+	switch {
+	case m.confirm != nil:
+		return titleStyle.Render(m.confirm.prompt) + "\n" + dimStyle.Render(keyHelp)
+	case m.message != "":
+		return m.message + "\n" + dimStyle.Render(keyHelp)
+	}
+	return "\n" + dimStyle.Render(keyHelp)
+}
+
+// viewList renders environments and their stacks
+func (m *Model) viewList() string {
+	if m.report == nil {
+		return dimStyle.Render("Loading stacks...")
+	}
+	if len(m.targets) == 0 {
+		return dimStyle.Render("No stacks found.")
+	}
+
+	var b strings.Builder
+	idx := 0
+	for _, env := range m.report.Envs {
+		b.WriteString(envStyle.Render(env.Name))
+		b.WriteString("\n")
+		for _, stack := range env.Stacks {
+			line := fmt.Sprintf(" %s %s", stateSymbol(stack.State), stackLabel(stack))
+			if idx == m.cursor {
+				line = selectedStyle.Render(padRight(line, listWidth))
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+			idx++
+		}
+	}
+	return b.String()
+}
+
+// viewDetail renders the services and pods of the selected stack
+func (m *Model) viewDetail() string {
+	target, ok := m.Selected()
+	if !ok {
+		return ""
+	}
+	stack := target.Stack
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(stackLabel(stack)))
+	if stack.Title != "" {
+		b.WriteString(dimStyle.Render("  " + stack.Name))
+	}
+	b.WriteString("\n")
+
+	state := renderState(stack.State)
+	if stack.Reason != "" {
+		state += dimStyle.Render(" (" + stack.Reason + ")")
+	}
+	fmt.Fprintf(&b, "Env: %s   Status: %s\n", target.Env, state)
+	fmt.Fprintf(&b, "Blueprint: %s\n", stack.Blueprint)
+	fmt.Fprintf(&b, "Age: %s   Services: %d/%d ready\n\n", k8s.FormatAge(time.Since(stack.Created)), stack.ReadyServices, stack.TotalServices)
+
+	if len(stack.Services) == 0 {
+		b.WriteString(dimStyle.Render("No services configured"))
+		return b.String()
+	}
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "SERVICE\tTYPE\tSTATE\tPODS\tRESTARTS\tTRAFFIC")
+	for _, svc := range stack.Services {
+		ready, restarts := 0, int32(0)
+		for _, pod := range svc.Pods {
+			if pod.Ready {
+				ready++
+			}
+			restarts += pod.Restarts
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%d/%d\t%d\t%s\n", svc.Name, svc.Category, svc.State, ready, len(svc.Pods), restarts, trafficLabel(svc.Traffic))
+	}
+	_ = tw.Flush()
+
+	var urls []string
+	for _, svc := range stack.Services {
+		if svc.URL != "" {
+			urls = append(urls, fmt.Sprintf("  %s  %s", svc.Name, svc.URL))
+		}
+	}
+	if len(urls) > 0 {
+		b.WriteString("\nURLs:\n")
+		b.WriteString(strings.Join(urls, "\n"))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\nPods:\n")
+	tw = tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, svc := range stack.Services {
+		for _, pod := range svc.Pods {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%d\t%s\n", pod.Name, pod.Phase, pod.Restarts, k8s.FormatAge(time.Since(pod.Created)))
+		}
+	}
+	_ = tw.Flush()
+
+	return b.String()
+}
+
+// stackLabel returns the display name of a stack
+func stackLabel(stack status.StackReport) string {
+	if stack.Title != "" {
+		return stack.Title
+	}
+	return stack.Name
+}
+
+// stateSymbol returns a colored marker for a stack state
+func stateSymbol(state string) string {
+	return stateStyle(state).Render("●")
+}
+
+// renderState colors a state name
+func renderState(state string) string {
+	return stateStyle(state).Render(state)
+}
+
+// stateStyle returns the style of a state, dim for unknown ones
+func stateStyle(state string) lipgloss.Style {
+	if style, ok := stateStyles[state]; ok {
+		return style
+	}
+	return dimStyle
+}
+
+// trafficLabel summarizes the traffic readiness of an exposed service
+func trafficLabel(traffic *status.Traffic) string {
+	switch {
+	case traffic == nil:
+		return "-"
+	case traffic.Ready:
+		return "ready"
+	case traffic.Reason != "":
+		return traffic.Reason
+	}
+	return "not ready"
+}
+
+// padRight pads s with spaces to width cells
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// clip limits text to the given width and height
+func clip(s string, width, height int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,276 @@
+// Package database detects the database services of a compose file and
+// builds the console command to connect to them.
+package database
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Database kinds
+const (
+	Postgres = "postgres"
+	MySQL    = "mysql"
+	Redis    = "redis"
+)
+
+// kind describes how to recognize and connect to a kind of database
+type kind struct {
+	name string
+	// images are substrings of the image name (without registry and tag)
+	images []string
+	port   int
+	client string
+	// user, password and database list the environment variables the
+	// official and Bitnami images read, in order of precedence
+	user     []string
+	password []string
+	database []string
+	// defaultUser is the user when none is configured
+	defaultUser string
+}
+
+var kinds = []kind{
+	{
+		name:        Postgres,
+		images:      []string{"postgres", "postgis", "timescale"},
+		port:        5432,
+		client:      "psql",
+		user:        []string{"POSTGRES_USER", "POSTGRESQL_USERNAME"},
+		password:    []string{"POSTGRES_PASSWORD", "POSTGRESQL_PASSWORD"},
+		database:    []string{"POSTGRES_DB", "POSTGRESQL_DATABASE"},
+		defaultUser: "postgres",
+	},
+	{
+		name:        MySQL,
+		images:      []string{"mysql", "mariadb", "percona"},
+		port:        3306,
+		client:      "mysql",
+		user:        []string{"MYSQL_USER", "MARIADB_USER"},
+		password:    []string{"MYSQL_PASSWORD", "MARIADB_PASSWORD"},
+		database:    []string{"MYSQL_DATABASE", "MARIADB_DATABASE"},
+		defaultUser: "root",
+	},
+	{
+		name:     Redis,
+		images:   []string{"redis", "valkey", "keydb"},
+		port:     6379,
+		client:   "redis-cli",
+		password: []string{"REDIS_PASSWORD", "VALKEY_PASSWORD"},
+	},
+}
+
+// tools are image name parts of tools for a database rather than the
+// database itself, e.g. redis-commander or postgres-exporter
+var tools = []string{"exporter", "commander", "insight", "admin", "backup"}
+
+// mysqlRootPassword lists the variables holding the MySQL root password,
+// used when no regular user is configured
+var mysqlRootPassword = []string{"MYSQL_ROOT_PASSWORD", "MARIADB_ROOT_PASSWORD"}
+
+// Database is a database service of a compose file. User, Password and Name
+// are as written in the compose file and may reference variables, see
+// Variables and Resolve.
+type Database struct {
+	Service  string `json:"service" yaml:"service"`
+	Kind     string `json:"kind" yaml:"kind"`
+	Image    string `json:"image" yaml:"image"`
+	Port     int    `json:"port" yaml:"port"`
+	User     string `json:"user,omitempty" yaml:"user,omitempty"`
+	Password string `json:"-" yaml:"-"`
+	Name     string `json:"database,omitempty" yaml:"database,omitempty"`
+}
+
+// Command is a console client invocation
+type Command struct {
+	Name string
+	Args []string
+	// Env holds the password, so it doesn't show up in the process list
+	Env []string
+}
+
+// Detect returns the database services of a compose document, by service
+// name. Services are recognized by image, so build-only services are skipped.
+func Detect(content string) ([]Database, error) {
+	var doc struct {
+		Services map[string]struct {
+			Image       string    `yaml:"image"`
+			Environment yaml.Node `yaml:"environment"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse compose content: %w", err)
+	}
+
+	var databases []Database
+	for name, svc := range doc.Services {
+		k, ok := detectKind(svc.Image)
+		if !ok {
+			continue
+		}
+		env := environment(&svc.Environment)
+		db := Database{
+			Service:  name,
+			Kind:     k.name,
+			Image:    svc.Image,
+			Port:     k.port,
+			User:     firstSet(env, k.user),
+			Password: firstSet(env, k.password),
+			Name:     firstSet(env, k.database),
+		}
+		if k.name == MySQL && db.User == "" {
+			db.Password = firstSet(env, mysqlRootPassword)
+		}
+		if db.User == "" {
+			db.User = k.defaultUser
+		}
+		databases = append(databases, db)
+	}
+	sort.Slice(databases, func(i, j int) bool { return databases[i].Service < databases[j].Service })
+	return databases, nil
+}
+
+// detectKind recognizes a database image, ignoring registry, namespace
+// and tag
+func detectKind(image string) (kind, bool) {
+	name := image
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	name = path.Base(name)
+	if i := strings.Index(name, ":"); i >= 0 {
+		name = name[:i]
+	}
+	for _, tool := range tools {
+		if strings.Contains(name, tool) {
+			return kind{}, false
+		}
+	}
+	for _, k := range kinds {
+		for _, match := range k.images {
+			if strings.Contains(name, match) {
+				return k, true
+			}
+		}
+	}
+	return kind{}, false
+}
+
+// environment reads a service environment in map or list syntax
+func environment(node *yaml.Node) map[string]string {
+	env := make(map[string]string)
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			env[node.Content[i].Value] = node.Content[i+1].Value
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			key, value, _ := strings.Cut(item.Value, "=")
+			env[key] = value
+		}
+	}
+	return env
+}
+
+func firstSet(env map[string]string, keys []string) string {
+	for _, key := range keys {
+		if value := env[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// variable matches ${NAME}, ${NAME:-default}, ${NAME-default} and $NAME
+var variable = regexp.MustCompile(`\$(?:\{([A-Za-z_][A-Za-z0-9_]*)(?::?-([^}]*))?\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// Variables returns the variables the credentials reference, which are
+// resolved from the environment's secrets and variables
+func (d *Database) Variables() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, value := range []string{d.User, d.Password, d.Name} {
+		for _, m := range variable.FindAllStringSubmatch(value, -1) {
+			name := m[1] + m[3]
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve returns the database with the variables of its credentials
+// replaced by values, falling back to their defaults. Variables without a
+// value or default are returned as missing.
+func (d Database) Resolve(values map[string]string) (Database, []string) {
+	var missing []string
+	interpolate := func(s string) string {
+		return variable.ReplaceAllStringFunc(s, func(ref string) string {
+			m := variable.FindStringSubmatch(ref)
+			name := m[1] + m[3]
+			if value, ok := values[name]; ok {
+				return value
+			}
+			if strings.Contains(ref, "-") {
+				return m[2]
+			}
+			missing = append(missing, name)
+			return ""
+		})
+	}
+	d.User = interpolate(d.User)
+	d.Password = interpolate(d.Password)
+	d.Name = interpolate(d.Name)
+	return d, missing
+}
+
+// Client returns the name of the database's console client
+func (d *Database) Client() string {
+	for _, k := range kinds {
+		if k.name == d.Kind {
+			return k.client
+		}
+	}
+	return ""
+}
+
+// Command returns the console client invocation connecting to the
+// database through a local port
+func (d *Database) Command(localPort int) Command {
+	port := strconv.Itoa(localPort)
+	cmd := Command{Name: d.Client()}
+	switch d.Kind {
+	case Postgres:
+		cmd.Args = []string{"-h", "127.0.0.1", "-p", port, "-U", d.User}
+		if d.Name != "" {
+			cmd.Args = append(cmd.Args, d.Name)
+		}
+		if d.Password != "" {
+			cmd.Env = []string{"PGPASSWORD=" + d.Password}
+		}
+	case MySQL:
+		cmd.Args = []string{"-h", "127.0.0.1", "-P", port, "-u", d.User}
+		if d.Name != "" {
+			cmd.Args = append(cmd.Args, d.Name)
+		}
+		if d.Password != "" {
+			cmd.Env = []string{"MYSQL_PWD=" + d.Password}
+		}
+	case Redis:
+		cmd.Args = []string{"-h", "127.0.0.1", "-p", port}
+		if d.Password != "" {
+			cmd.Env = []string{"REDISCLI_AUTH=" + d.Password}
+		}
+	}
+	return cmd
+}
@@ -0,0 +1,82 @@
+package database_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/database"
+)
+
+var _ = Describe("Database", func() {
+	const compose = `
+services:
+  web:
+    image: ghcr.io/acme/web:1.2
+  db:
+    image: postgres:16-alpine
+    environment:
+      POSTGRES_USER: app
+      POSTGRES_PASSWORD: ${DB_PASSWORD}
+      POSTGRES_DB: ${DB_NAME:-app}
+  cache:
+    image: docker.io/bitnami/redis:7.2
+    environment:
+      - REDIS_PASSWORD=secret
+  mysql:
+    image: mariadb:11
+    environment:
+      MARIADB_ROOT_PASSWORD: root
+  cache-ui:
+    image: rediscommander/redis-commander
+`
+
+	Describe("Detect", func() {
+		It("should recognize database images and their credentials", func() {
+			dbs, err := database.Detect(compose)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dbs).To(HaveLen(3))
+
+			Expect(dbs[0]).To(Equal(database.Database{
+				Service: "cache", Kind: database.Redis, Image: "docker.io/bitnami/redis:7.2", Port: 6379, Password: "secret",
+			}))
+			Expect(dbs[1].Service).To(Equal("db"))
+			Expect(dbs[1].User).To(Equal("app"))
+			Expect(dbs[1].Variables()).To(Equal([]string{"DB_NAME", "DB_PASSWORD"}))
+			Expect(dbs[2]).To(Equal(database.Database{
+				Service: "mysql", Kind: database.MySQL, Image: "mariadb:11", Port: 3306, User: "root", Password: "root",
+			}))
+		})
+	})
+
+	Describe("Resolve", func() {
+		It("should fill in variables and defaults, reporting missing ones", func() {
+			dbs, err := database.Detect(compose)
+			Expect(err).NotTo(HaveOccurred())
+
+			db, missing := dbs[1].Resolve(map[string]string{"DB_PASSWORD": "s3cret"})
+			Expect(missing).To(BeEmpty())
+			Expect(db.Password).To(Equal("s3cret"))
+			Expect(db.Name).To(Equal("app"))
+
+			_, missing = dbs[1].Resolve(nil)
+			Expect(missing).To(Equal([]string{"DB_PASSWORD"}))
+		})
+	})
+
+	Describe("Command", func() {
+		It("should pass the password through the environment", func() {
+			db := database.Database{Kind: database.Postgres, User: "app", Password: "pw", Name: "shop"}
+			Expect(db.Command(15432)).To(Equal(database.Command{
+				Name: "psql",
+				Args: []string{"-h", "127.0.0.1", "-p", "15432", "-U", "app", "shop"},
+				Env:  []string{"PGPASSWORD=pw"},
+			}))
+
+			db = database.Database{Kind: database.Redis}
+			Expect(db.Command(6379)).To(Equal(database.Command{
+				Name: "redis-cli",
+				Args: []string{"-h", "127.0.0.1", "-p", "6379"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,176 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op is the kind of change of a diff line
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// Line is one line of a diff
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Hunk is a group of changes with surrounding context, in unified diff form
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Lines              []Line
+}
+
+// Header returns the unified diff range header of the hunk
+func (h Hunk) Header() string {
+	return fmt.Sprintf("@@ -%s +%s @@", hunkRange(h.OldStart, h.OldLines), hunkRange(h.NewStart, h.NewLines))
+}
+
+// hunkRange formats a unified diff range ("start,count", "start" for one line)
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	if count == 0 {
+		// An empty range points at the line before it
+		start--
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// SplitLines splits text into lines, ignoring a trailing newline
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Lines computes a line diff of a and b based on their longest common
+// subsequence. Deletions come before insertions within a change.
+func Lines(a, b []string) []Line {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]Line, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Op: Equal, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Op: Delete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Op: Insert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Op: Delete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Op: Insert, Text: b[j]})
+	}
+	return lines
+}
+
+// Hunks groups a line diff into hunks with up to context unchanged lines
+// around each change. Returns no hunks when there are no changes.
+func Hunks(lines []Line, context int) []Hunk {
+	// Line numbers in the old and new text at each diff position
+	oldNo := make([]int, len(lines)+1)
+	newNo := make([]int, len(lines)+1)
+	oldNo[0], newNo[0] = 1, 1
+	for idx, line := range lines {
+		oldNo[idx+1], newNo[idx+1] = oldNo[idx], newNo[idx]
+		if line.Op != Insert {
+			oldNo[idx+1]++
+		}
+		if line.Op != Delete {
+			newNo[idx+1]++
+		}
+	}
+
+	// Context windows around each change, merged when they touch
+	var ranges [][2]int
+	for idx, line := range lines {
+		if line.Op == Equal {
+			continue
+		}
+		start, end := max(idx-context, 0), min(idx+context+1, len(lines))
+		if n := len(ranges); n > 0 && start <= ranges[n-1][1] {
+			ranges[n-1][1] = end
+			continue
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+
+	hunks := make([]Hunk, 0, len(ranges))
+	for _, r := range ranges {
+		h := Hunk{OldStart: oldNo[r[0]], NewStart: newNo[r[0]], Lines: lines[r[0]:r[1]]}
+		for _, line := range h.Lines {
+			if line.Op != Insert {
+				h.OldLines++
+			}
+			if line.Op != Delete {
+				h.NewLines++
+			}
+		}
+		hunks = append(hunks, h)
+	}
+	return hunks
+}
+
+// Unified renders a plain unified diff of two texts. Returns "" when they are
+// equal.
+func Unified(oldName, newName, a, b string, context int) string {
+	hunks := Hunks(Lines(SplitLines(a), SplitLines(b)), context)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks {
+		sb.WriteString(h.Header())
+		sb.WriteString("\n")
+		for _, l := range h.Lines {
+			sb.WriteString(Prefix(l.Op))
+			sb.WriteString(l.Text)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// Prefix returns the unified diff line prefix of an op
+func Prefix(op Op) string {
+	switch op {
+	case Delete:
+		return "-"
+	case Insert:
+		return "+"
+	}
+	return " "
+}
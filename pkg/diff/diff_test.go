@@ -0,0 +1,41 @@
+package diff_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/diff"
+)
+
+var _ = Describe("Diff", func() {
+	It("should render an empty diff for equal texts", func() {
+		Expect(diff.Unified("a", "b", "x\ny\n", "x\ny\n", 3)).To(BeEmpty())
+	})
+
+	It("should render a unified diff with context", func() {
+		old := "services:\n  api:\n    image: api:1\n  db:\n    image: postgres\n"
+		updated := "services:\n  api:\n    image: api:2\n  db:\n    image: postgres\n"
+
+		Expect(diff.Unified("old", "new", old, updated, 1)).To(Equal(
+			"--- old\n+++ new\n" +
+				"@@ -2,3 +2,3 @@\n" +
+				"   api:\n" +
+				"-    image: api:1\n" +
+				"+    image: api:2\n" +
+				"   db:\n"))
+	})
+
+	It("should split distant changes into separate hunks", func() {
+		a := []string{"1", "2", "3", "4", "5", "6", "7", "8"}
+		b := []string{"1", "two", "3", "4", "5", "6", "7", "eight"}
+
+		hunks := diff.Hunks(diff.Lines(a, b), 1)
+		Expect(hunks).To(HaveLen(2))
+		Expect(hunks[0].Header()).To(Equal("@@ -1,3 +1,3 @@"))
+		Expect(hunks[1].Header()).To(Equal("@@ -7,2 +7,2 @@"))
+	})
+
+	It("should handle additions to an empty text", func() {
+		Expect(diff.Unified("old", "new", "", "a\n", 3)).To(Equal("--- old\n+++ new\n@@ -0,0 +1 @@\n+a\n"))
+	})
+})
@@ -0,0 +1,93 @@
+package diff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifests diffs two multi-document Kubernetes manifests object by object,
+// the way kubectl diff does. Objects are matched by kind, namespace and
+// name; added and removed objects are diffed against /dev/null. Returns one
+// unified diff per changed object, ordered by object.
+func Manifests(current, proposed string, context int) ([]string, error) {
+	oldObjects, err := splitManifest(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse current manifests: %w", err)
+	}
+	newObjects, err := splitManifest(proposed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proposed manifests: %w", err)
+	}
+
+	ids := make([]string, 0, len(oldObjects)+len(newObjects))
+	for id := range oldObjects {
+		ids = append(ids, id)
+	}
+	for id := range newObjects {
+		if _, ok := oldObjects[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var diffs []string
+	for _, id := range ids {
+		oldName, newName := "current/"+id, "proposed/"+id
+		oldObj, inOld := oldObjects[id]
+		newObj, inNew := newObjects[id]
+		if !inOld {
+			oldName = "/dev/null"
+		}
+		if !inNew {
+			newName = "/dev/null"
+		}
+		if unified := Unified(oldName, newName, oldObj, newObj, context); unified != "" {
+			diffs = append(diffs, unified)
+		}
+	}
+	return diffs, nil
+}
+
+// splitManifest splits a multi-document manifest into objects keyed by
+// kind/namespace/name, with their YAML as value. Documents are re-encoded
+// with sorted keys, so formatting differences between both sides don't show
+// up as changes.
+func splitManifest(manifest string) (map[string]string, error) {
+	objects := make(map[string]string)
+	decoder := yaml.NewDecoder(strings.NewReader(manifest))
+	for {
+		var doc map[string]interface{}
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if doc == nil {
+			continue
+		}
+
+		kind, _ := doc["kind"].(string)
+		metadata, _ := doc["metadata"].(map[string]interface{})
+		name, _ := metadata["name"].(string)
+		namespace, _ := metadata["namespace"].(string)
+		id := kind + "/" + name
+		if namespace != "" {
+			id = kind + "/" + namespace + "/" + name
+		}
+
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return nil, err
+		}
+		objects[id] = buf.String()
+	}
+}
@@ -0,0 +1,88 @@
+package diff_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/diff"
+)
+
+var _ = Describe("Manifests", func() {
+	const current = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: dev
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+  namespace: dev
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: old, namespace: dev}
+`
+
+	It("should diff changed objects and ignore formatting", func() {
+		proposed := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: dev
+spec:
+  replicas: 2
+---
+apiVersion: v1
+kind: Service
+metadata: {name: api, namespace: dev}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: old, namespace: dev}
+`
+		diffs, err := diff.Manifests(current, proposed, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]string{
+			"--- current/Deployment/dev/api\n+++ proposed/Deployment/dev/api\n" +
+				"@@ -7 +7 @@\n" +
+				"-  replicas: 1\n" +
+				"+  replicas: 2\n",
+		}))
+	})
+
+	It("should diff added and removed objects against /dev/null", func() {
+		proposed := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: dev
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+  namespace: dev
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: new
+`
+		diffs, err := diff.Manifests(current, proposed, 3)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(HaveLen(2))
+		Expect(diffs[0]).To(HavePrefix("--- current/ConfigMap/dev/old\n+++ /dev/null\n"))
+		Expect(diffs[1]).To(HavePrefix("--- /dev/null\n+++ proposed/Secret/new\n"))
+	})
+
+	It("should report invalid manifests", func() {
+		_, err := diff.Manifests("kind: [", "", 3)
+		Expect(err).To(MatchError(ContainSubstring("current manifests")))
+	})
+})
@@ -0,0 +1,110 @@
+// Package dump writes stack bug report bundles: a tar.gz archive of
+// runtime state with secret values redacted.
+package dump
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Redacted replaces secret values
+const Redacted = "[REDACTED]"
+
+// sensitiveName matches environment variable and field names holding secrets
+var sensitiveName = regexp.MustCompile(`(?i)(passw(or)?d|secret|token|api_?key|credential|private_?key|access_?key|auth)`)
+
+// sensitiveAssignment matches "password=value" and "token: value" in log lines
+var sensitiveAssignment = regexp.MustCompile(`(?i)\b([\w.-]*(?:passw(?:or)?d|secret|token|api_?key|credential)[\w.-]*"?\s*[=:]\s*"?)([^\s"',&]+)`)
+
+// droppedAnnotations may embed full objects, including secret values
+var droppedAnnotations = []string{"kubectl.kubernetes.io/last-applied-configuration"}
+
+// Archive writes files into a tar.gz archive below a root directory
+type Archive struct {
+	root string
+	gz   *gzip.Writer
+	tw   *tar.Writer
+	now  time.Time
+}
+
+// NewArchive starts an archive on w whose files are placed below root
+func NewArchive(w io.Writer, root string) *Archive {
+	gz := gzip.NewWriter(w)
+	return &Archive{root: root, gz: gz, tw: tar.NewWriter(gz), now: time.Now()}
+}
+
+// Add writes a file to the archive
+func (a *Archive) Add(name string, data []byte) error {
+	header := &tar.Header{
+		Name:    path.Join(a.root, name),
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: a.now,
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := a.tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// AddYAML writes v as a YAML file to the archive
+func (a *Archive) AddYAML(name string, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return a.Add(name, data)
+}
+
+// Close finishes the archive. It doesn't close the underlying writer.
+func (a *Archive) Close() error {
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.gz.Close()
+}
+
+// RedactPod returns a copy of a pod without secret values: literal values
+// of sensitive environment variables are replaced, and annotations that may
+// embed them and managed fields are dropped. References to Secrets are kept.
+func RedactPod(pod *corev1.Pod) *corev1.Pod {
+	redacted := pod.DeepCopy()
+	redacted.ManagedFields = nil
+	for _, annotation := range droppedAnnotations {
+		delete(redacted.Annotations, annotation)
+	}
+	for _, containers := range [][]corev1.Container{redacted.Spec.InitContainers, redacted.Spec.Containers} {
+		for i := range containers {
+			env := containers[i].Env
+			for j := range env {
+				if env[j].Value != "" && IsSensitiveName(env[j].Name) {
+					env[j].Value = Redacted
+				}
+			}
+		}
+	}
+	return redacted
+}
+
+// IsSensitiveName reports whether a variable or field name suggests its value
+// is a secret
+func IsSensitiveName(name string) bool {
+	return sensitiveName.MatchString(name)
+}
+
+// RedactLine replaces values assigned to sensitive keys in a log line, e.g.
+// "password=hunter2" or "api_key: abc"
+func RedactLine(line string) string {
+	return sensitiveAssignment.ReplaceAllString(line, "${1}"+Redacted)
+}
@@ -0,0 +1,81 @@
+package dump_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lissto-dev/cli/pkg/dump"
+)
+
+var _ = Describe("Dump", func() {
+	Describe("Archive", func() {
+		It("should write files below the root directory", func() {
+			var buf bytes.Buffer
+			archive := dump.NewArchive(&buf, "demo-dump")
+			Expect(archive.Add("logs/api.log", []byte("hello\n"))).To(Succeed())
+			Expect(archive.AddYAML("stack.yaml", map[string]string{"name": "demo"})).To(Succeed())
+			Expect(archive.Close()).To(Succeed())
+
+			gz, err := gzip.NewReader(&buf)
+			Expect(err).NotTo(HaveOccurred())
+			tr := tar.NewReader(gz)
+			files := map[string]string{}
+			for {
+				header, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).NotTo(HaveOccurred())
+				data, err := io.ReadAll(tr)
+				Expect(err).NotTo(HaveOccurred())
+				files[header.Name] = string(data)
+			}
+			Expect(files).To(Equal(map[string]string{
+				"demo-dump/logs/api.log": "hello\n",
+				"demo-dump/stack.yaml":   "name: demo\n",
+			}))
+		})
+	})
+
+	Describe("RedactPod", func() {
+		It("should redact sensitive environment values and keep references", func() {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "api-1",
+					Annotations: map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}", "team": "core"},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name: "api",
+					Env: []corev1.EnvVar{
+						{Name: "LOG_LEVEL", Value: "debug"},
+						{Name: "DB_PASSWORD", Value: "hunter2"},
+						{Name: "STRIPE_API_KEY", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{Key: "key"}}},
+					},
+				}}},
+			}
+
+			redacted := dump.RedactPod(pod)
+			env := redacted.Spec.Containers[0].Env
+			Expect(env[0].Value).To(Equal("debug"))
+			Expect(env[1].Value).To(Equal(dump.Redacted))
+			Expect(env[2].ValueFrom.SecretKeyRef.Key).To(Equal("key"))
+			Expect(redacted.Annotations).To(Equal(map[string]string{"team": "core"}))
+			Expect(pod.Spec.Containers[0].Env[1].Value).To(Equal("hunter2"))
+		})
+	})
+
+	Describe("RedactLine", func() {
+		It("should redact values assigned to sensitive keys", func() {
+			Expect(dump.RedactLine(`connecting with password=hunter2 to db`)).To(Equal(`connecting with password=[REDACTED] to db`))
+			Expect(dump.RedactLine(`{"api_key": "abc123", "user": "bob"}`)).To(Equal(`{"api_key": "[REDACTED]", "user": "bob"}`))
+			Expect(dump.RedactLine(`GET /healthz 200`)).To(Equal(`GET /healthz 200`))
+		})
+	})
+})
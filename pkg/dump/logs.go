@@ -0,0 +1,90 @@
+package dump
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+)
+
+// logTimeFormat is RFC3339 with fixed-width nanoseconds, so lines align
+const logTimeFormat = "2006-01-02T15:04:05.000000000Z07:00"
+
+// LogLine is one line of a container's log in a logs bundle
+type LogLine struct {
+	Time      time.Time
+	Pod       string
+	Container string
+	// Previous is set for lines of the previous, terminated container instance
+	Previous bool
+	Message  string
+}
+
+// LogSource identifies the container a log stream belongs to
+type LogSource struct {
+	Pod       string
+	Container string
+	Previous  bool
+}
+
+// ReadLogLines reads a log stream fetched with LogOptions.Timestamps and
+// keeps the lines logged between since and until (zero for no bound).
+// Continuation lines without a timestamp keep the previous line's time.
+func ReadLogLines(r io.Reader, source LogSource, since, until time.Time) ([]LogLine, error) {
+	var lines []LogLine
+	var last time.Time
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		ts, message, ok := k8s.SplitTimestamp(scanner.Text())
+		if ok {
+			last = ts
+		} else {
+			ts = last
+		}
+		if (!since.IsZero() && ts.Before(since)) || (!until.IsZero() && ts.After(until)) {
+			continue
+		}
+		lines = append(lines, LogLine{
+			Time:      ts,
+			Pod:       source.Pod,
+			Container: source.Container,
+			Previous:  source.Previous,
+			Message:   message,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return lines, fmt.Errorf("failed to read logs of %s/%s: %w", source.Pod, source.Container, err)
+	}
+	return lines, nil
+}
+
+// MergeLogs interleaves the lines of several containers chronologically.
+// Lines logged at the same time keep the order of their stream.
+func MergeLogs(sources ...[]LogLine) []LogLine {
+	var merged []LogLine
+	for _, lines := range sources {
+		merged = append(merged, lines...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Time.Before(merged[j].Time) })
+	return merged
+}
+
+// WriteLogLines writes merged lines as "<time> pod/container | message", with
+// secret values redacted
+func WriteLogLines(w io.Writer, lines []LogLine) error {
+	bw := bufio.NewWriter(w)
+	for _, line := range lines {
+		source := line.Pod + "/" + line.Container
+		if line.Previous {
+			source += " (previous)"
+		}
+		if _, err := fmt.Fprintf(bw, "%s %s | %s\n", line.Time.UTC().Format(logTimeFormat), source, RedactLine(line.Message)); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,61 @@
+package dump_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/dump"
+)
+
+var _ = Describe("Logs bundle", func() {
+	base := time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)
+	at := func(minutes int) string {
+		return base.Add(time.Duration(minutes) * time.Minute).Format(time.RFC3339Nano)
+	}
+	api := dump.LogSource{Pod: "api-1", Container: "api"}
+
+	It("should keep the lines inside the window", func() {
+		stream := strings.NewReader(at(0) + " early\n" + at(10) + " during\n" + at(20) + " late\n")
+		lines, err := dump.ReadLogLines(stream, api, base.Add(5*time.Minute), base.Add(15*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0].Message).To(Equal("during"))
+		Expect(lines[0].Pod).To(Equal("api-1"))
+		Expect(lines[0].Time.Equal(base.Add(10 * time.Minute))).To(BeTrue())
+	})
+
+	It("should give continuation lines the time of the line before", func() {
+		stream := strings.NewReader(at(10) + " panic: boom\n\tgoroutine 1\n")
+		lines, err := dump.ReadLogLines(stream, api, base, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[1].Message).To(Equal("\tgoroutine 1"))
+		Expect(lines[1].Time).To(Equal(lines[0].Time))
+	})
+
+	It("should interleave containers chronologically and write them redacted", func() {
+		web := dump.LogSource{Pod: "web-1", Container: "web", Previous: true}
+		apiLines, _ := dump.ReadLogLines(strings.NewReader(at(1)+" a1\n"+at(3)+" token=abc\n"), api, time.Time{}, time.Time{})
+		webLines, _ := dump.ReadLogLines(strings.NewReader(at(2)+" w2\n"+at(3)+" w3\n"), web, time.Time{}, time.Time{})
+
+		merged := dump.MergeLogs(apiLines, webLines)
+		var messages []string
+		for _, line := range merged {
+			messages = append(messages, line.Message)
+		}
+		Expect(messages).To(Equal([]string{"a1", "w2", "token=abc", "w3"}))
+
+		var buf bytes.Buffer
+		Expect(dump.WriteLogLines(&buf, merged)).To(Succeed())
+		Expect(strings.Split(strings.TrimSpace(buf.String()), "\n")).To(Equal([]string{
+			"2025-01-02T03:01:00.000000000Z api-1/api | a1",
+			"2025-01-02T03:02:00.000000000Z web-1/web (previous) | w2",
+			"2025-01-02T03:03:00.000000000Z api-1/api | token=[REDACTED]",
+			"2025-01-02T03:03:00.000000000Z web-1/web (previous) | w3",
+		}))
+	})
+})
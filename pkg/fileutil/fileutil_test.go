@@ -0,0 +1,63 @@
+package fileutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/fileutil"
+)
+
+var _ = Describe("Files", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "sub", "config.yaml")
+	})
+
+	It("should replace a file atomically without leaving temporary files", func() {
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("old"), 0644)).To(Succeed())
+
+		Expect(fileutil.WriteFileAtomic(path, []byte("new"), 0600)).To(Succeed())
+		Expect(os.ReadFile(path)).To(Equal([]byte("new")))
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("should block an exclusive lock until the holder releases it", func() {
+		unlock, err := fileutil.LockFile(path, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		acquired := make(chan func())
+		go func() {
+			defer GinkgoRecover()
+			unlock, err := fileutil.LockFile(path, true)
+			Expect(err).NotTo(HaveOccurred())
+			acquired <- unlock
+		}()
+		Consistently(acquired, 100*time.Millisecond).ShouldNot(Receive())
+
+		unlock()
+		var unlockSecond func()
+		Eventually(acquired).Should(Receive(&unlockSecond))
+		unlockSecond()
+	})
+
+	It("should let shared locks be held together", func() {
+		first, err := fileutil.LockFile(path, false)
+		Expect(err).NotTo(HaveOccurred())
+		defer first()
+		second, err := fileutil.LockFile(path, false)
+		Expect(err).NotTo(HaveOccurred())
+		second()
+	})
+})
@@ -0,0 +1,31 @@
+// Package fileutil has file helpers shared by the config and the cache:
+// advisory locks and atomic writes.
+package fileutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LockFile takes an advisory lock on path, held in a path+".lock" file next
+// to it, blocking until it is available. Shared locks may be held by several
+// readers at once; an exclusive lock by a single writer. The returned func
+// releases the lock.
+func LockFile(path string, exclusive bool) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, nil
+}
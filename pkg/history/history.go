@@ -0,0 +1,152 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/config"
+)
+
+// FileName is the name of the history file in the config directory
+const FileName = "history.jsonl"
+
+// Results of a recorded operation
+const (
+	ResultOK     = "ok"
+	ResultFailed = "failed"
+)
+
+// redactedValue replaces secret values in recorded arguments
+const redactedValue = "***"
+
+// Entry is one recorded operation
+type Entry struct {
+	Time     time.Time `json:"time" yaml:"time"`
+	Command  string    `json:"command" yaml:"command"`
+	Args     []string  `json:"args,omitempty" yaml:"args,omitempty"`
+	Context  string    `json:"context,omitempty" yaml:"context,omitempty"`
+	Env      string    `json:"env,omitempty" yaml:"env,omitempty"`
+	Result   string    `json:"result" yaml:"result"`
+	Error    string    `json:"error,omitempty" yaml:"error,omitempty"`
+	Duration string    `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+// Failed reports whether the operation failed
+func (e *Entry) Failed() bool {
+	return e.Result == ResultFailed
+}
+
+// DefaultPath returns the path of the history file
+func DefaultPath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Append adds an entry to the history file. The file is only ever appended
+// to, one JSON document per line.
+func Append(path string, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// A single write keeps concurrent CLI invocations from interleaving lines
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	return nil
+}
+
+// Read returns all entries of the history file, oldest first. A missing file
+// is an empty history; lines that can't be decoded are skipped.
+func Read(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return entries, nil
+}
+
+// keyValueArg matches KEY=value arguments
+var keyValueArg = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*=`)
+
+// sensitiveFlag matches flags whose values are never recorded
+var sensitiveFlag = regexp.MustCompile(`^--?(api-key|token|password)(=|$)`)
+
+// RedactArgs hides secret values in command-line arguments. KEY=value
+// arguments and flag values are redacted when secretValues is set (e.g. for
+// secret commands); values of flags like --api-key and --token always are.
+func RedactArgs(args []string, secretValues bool) []string {
+	redacted := make([]string, len(args))
+	hideNext := false
+	for i, arg := range args {
+		switch {
+		case hideNext:
+			arg = redactedValue
+			hideNext = false
+		case sensitiveFlag.MatchString(arg):
+			if name, _, ok := strings.Cut(arg, "="); ok {
+				arg = name + "=" + redactedValue
+			} else {
+				hideNext = true
+			}
+		case secretValues:
+			arg = redactKeyValue(arg)
+		}
+		redacted[i] = arg
+	}
+	return redacted
+}
+
+// redactKeyValue hides the value of a KEY=value argument, also when given
+// inline to a flag as in --secret=KEY=value
+func redactKeyValue(arg string) string {
+	prefix := ""
+	if strings.HasPrefix(arg, "-") {
+		flag, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return arg
+		}
+		prefix, arg = flag+"=", value
+	}
+	if !keyValueArg.MatchString(arg) {
+		return prefix + arg
+	}
+	key, _, _ := strings.Cut(arg, "=")
+	return prefix + key + "=" + redactedValue
+}
@@ -0,0 +1,65 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/history"
+)
+
+var _ = Describe("History", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "lissto", history.FileName)
+	})
+
+	It("reads a missing file as an empty history", func() {
+		entries, err := history.Read(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("appends entries and reads them back in order", func() {
+		first := history.Entry{Time: time.Unix(100, 0).UTC(), Command: "stack delete", Args: []string{"web"}, Result: history.ResultOK}
+		second := history.Entry{Time: time.Unix(200, 0).UTC(), Command: "create", Result: history.ResultFailed, Error: "boom"}
+		Expect(history.Append(path, first)).To(Succeed())
+		Expect(history.Append(path, second)).To(Succeed())
+
+		entries, err := history.Read(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(Equal([]history.Entry{first, second}))
+		Expect(entries[1].Failed()).To(BeTrue())
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("skips corrupt lines", func() {
+		Expect(history.Append(path, history.Entry{Command: "update", Result: history.ResultOK})).To(Succeed())
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+		Expect(err).NotTo(HaveOccurred())
+		_, _ = f.WriteString("{truncated\n")
+		Expect(f.Close()).To(Succeed())
+
+		entries, err := history.Read(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	DescribeTable("RedactArgs",
+		func(args []string, secretValues bool, expected []string) {
+			Expect(history.RedactArgs(args, secretValues)).To(Equal(expected))
+		},
+		Entry("keeps ordinary args", []string{"web", "--env", "dev"}, false, []string{"web", "--env", "dev"}),
+		Entry("keeps variable values", []string{"vars", "A=1"}, false, []string{"vars", "A=1"}),
+		Entry("hides secret values", []string{"db", "--secret", "PASSWORD=hunter2", "-k=TOKEN=x"}, true,
+			[]string{"db", "--secret", "PASSWORD=***", "-k=TOKEN=***"}),
+		Entry("hides sensitive flags", []string{"--api-key", "lsk_1", "--token=abc"}, false, []string{"--api-key", "***", "--token=***"}),
+	)
+})
@@ -0,0 +1,147 @@
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFile is the name of the per-repository configuration file
+const ProjectConfigFile = ".lissto.yaml"
+
+// Phase identifies when a hook runs relative to the command
+type Phase string
+
+const (
+	PhasePre  Phase = "pre"
+	PhasePost Phase = "post"
+)
+
+// Environment variables exposed to hook commands
+const (
+	EnvCommand   = "LISSTO_COMMAND"
+	EnvPhase     = "LISSTO_HOOK_PHASE"
+	EnvEnv       = "LISSTO_ENV"
+	EnvStack     = "LISSTO_STACK"
+	EnvBlueprint = "LISSTO_BLUEPRINT"
+	EnvContext   = "LISSTO_CONTEXT"
+)
+
+// Hooks maps command names (e.g. "create", "update") to shell commands
+type Hooks struct {
+	Pre  map[string][]string `yaml:"pre,omitempty"`
+	Post map[string][]string `yaml:"post,omitempty"`
+}
+
+// ProjectConfig represents the .lissto.yaml file found in a repository
+type ProjectConfig struct {
+	Hooks Hooks `yaml:"hooks,omitempty"`
+
+	// Dir is the directory the config was loaded from; hooks run there
+	Dir string `yaml:"-"`
+}
+
+// Vars holds the resolved values exposed to hooks as environment variables
+type Vars struct {
+	Context   string
+	Env       string
+	Stack     string
+	Blueprint string
+}
+
+// Load searches the current directory and its parents for .lissto.yaml.
+// Returns an empty config if no file is found.
+func Load() (*ProjectConfig, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return LoadFrom(wd)
+}
+
+// LoadFrom searches dir and its parents for .lissto.yaml
+func LoadFrom(dir string) (*ProjectConfig, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	for {
+		path := filepath.Join(dir, ProjectConfigFile)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			cfg := &ProjectConfig{}
+			if err := yaml.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			cfg.Dir = dir
+			return cfg, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return &ProjectConfig{}, nil
+		}
+		dir = parent
+	}
+}
+
+// Commands returns the hook commands configured for a phase and command
+func (c *ProjectConfig) Commands(phase Phase, command string) []string {
+	if c == nil {
+		return nil
+	}
+	switch phase {
+	case PhasePre:
+		return c.Hooks.Pre[command]
+	case PhasePost:
+		return c.Hooks.Post[command]
+	}
+	return nil
+}
+
+// Run executes the hooks for a phase and command in order, stopping at the
+// first failure. Hook output is streamed to out.
+func (c *ProjectConfig) Run(phase Phase, command string, vars Vars, out io.Writer) error {
+	for _, script := range c.Commands(phase, command) {
+		fmt.Fprintf(out, "🪝 Running %s-%s hook: %s\n", phase, command, script)
+
+		hook := shellCommand(script)
+		hook.Dir = c.Dir
+		hook.Stdout = out
+		hook.Stderr = out
+		hook.Stdin = os.Stdin
+		hook.Env = append(os.Environ(), vars.environ(phase, command)...)
+
+		if err := hook.Run(); err != nil {
+			return fmt.Errorf("%s-%s hook %q failed: %w", phase, command, script, err)
+		}
+	}
+	return nil
+}
+
+func (v Vars) environ(phase Phase, command string) []string {
+	return []string{
+		EnvCommand + "=" + command,
+		EnvPhase + "=" + string(phase),
+		EnvContext + "=" + v.Context,
+		EnvEnv + "=" + v.Env,
+		EnvStack + "=" + v.Stack,
+		EnvBlueprint + "=" + v.Blueprint,
+	}
+}
+
+func shellCommand(script string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", script)
+	}
+	return exec.Command("sh", "-c", script)
+}
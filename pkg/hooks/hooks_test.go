@@ -0,0 +1,80 @@
+package hooks_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/hooks"
+)
+
+var _ = Describe("Hooks", func() {
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "lissto-hooks-test-*")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	writeConfig := func(content string) {
+		path := filepath.Join(tmpDir, hooks.ProjectConfigFile)
+		Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+	}
+
+	Describe("LoadFrom", func() {
+		It("should return an empty config when no file exists", func() {
+			cfg, err := hooks.LoadFrom(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Commands(hooks.PhasePre, "update")).To(BeEmpty())
+		})
+
+		It("should find the config in a parent directory", func() {
+			writeConfig("hooks:\n  pre:\n    update:\n      - make build-images\n")
+			nested := filepath.Join(tmpDir, "a", "b")
+			Expect(os.MkdirAll(nested, 0755)).To(Succeed())
+
+			cfg, err := hooks.LoadFrom(nested)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Commands(hooks.PhasePre, "update")).To(Equal([]string{"make build-images"}))
+			Expect(cfg.Dir).To(Equal(tmpDir))
+		})
+
+		It("should fail on invalid YAML", func() {
+			writeConfig("hooks: [")
+			_, err := hooks.LoadFrom(tmpDir)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Run", func() {
+		It("should expose resolved values as environment variables", func() {
+			writeConfig("hooks:\n  post:\n    create:\n      - echo \"$LISSTO_COMMAND $LISSTO_ENV $LISSTO_STACK\"\n")
+			cfg, err := hooks.LoadFrom(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			var out bytes.Buffer
+			err = cfg.Run(hooks.PhasePost, "create", hooks.Vars{Env: "dev", Stack: "my-stack"}, &out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(ContainSubstring("create dev my-stack"))
+		})
+
+		It("should stop at the first failing hook", func() {
+			writeConfig("hooks:\n  pre:\n    update:\n      - exit 3\n      - echo unreachable\n")
+			cfg, err := hooks.LoadFrom(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			var out bytes.Buffer
+			err = cfg.Run(hooks.PhasePre, "update", hooks.Vars{}, &out)
+			Expect(err).To(HaveOccurred())
+			Expect(out.String()).NotTo(ContainSubstring("unreachable"))
+		})
+	})
+})
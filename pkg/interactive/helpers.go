@@ -2,6 +2,7 @@ package interactive
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
@@ -280,6 +281,27 @@ func PromptBranchTag() (branch, tag, commit string, err error) {
 	return branch, "", "", nil
 }
 
+// PromptParam prompts for a blueprint parameter value
+func PromptParam(name, description, defaultValue string, required bool) (string, error) {
+	var value string
+	inputPrompt := &survey.Input{
+		Message: fmt.Sprintf("%s:", name),
+		Help:    description,
+		Default: defaultValue,
+	}
+
+	var opts []survey.AskOpt
+	if required {
+		opts = append(opts, survey.WithValidator(survey.Required))
+	}
+
+	if err := survey.AskOne(inputPrompt, &value, opts...); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
 // ConfirmAction asks for a yes/no confirmation
 func ConfirmAction(message string, defaultValue bool) (bool, error) {
 	var confirmed bool
@@ -296,6 +318,42 @@ func ConfirmAction(message string, defaultValue bool) (bool, error) {
 	return confirmed, nil
 }
 
+// ConfirmOnTerminal asks for a yes/no confirmation on the controlling
+// terminal, for commands whose stdin and stdout carry other data
+func ConfirmOnTerminal(message string) (bool, error) {
+	tty, err := openTerminal()
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tty.Close() }()
+
+	var confirmed bool
+	prompt := &survey.Confirm{Message: message}
+	if err := survey.AskOne(prompt, &confirmed, survey.WithStdio(tty, tty, tty)); err != nil {
+		return false, err
+	}
+	return confirmed, nil
+}
+
+// CheckTerminal returns an error when there is no controlling terminal for
+// ConfirmOnTerminal to ask on
+func CheckTerminal() error {
+	tty, err := openTerminal()
+	if err != nil {
+		return err
+	}
+	return tty.Close()
+}
+
+// openTerminal opens the controlling terminal
+func openTerminal() (*os.File, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("no terminal to confirm on: %w", err)
+	}
+	return tty, nil
+}
+
 // SelectEnv prompts the user to select an environment
 func SelectEnv(envs []client.EnvResponse) (*client.EnvResponse, error) {
 	if len(envs) == 0 {
@@ -543,3 +601,40 @@ func ConfirmNextAction() (string, error) {
 	err := survey.AskOne(prompt, &action)
 	return action, err
 }
+
+// SelectMultiple prompts the user to pick any number of options and returns
+// the selected indices
+func SelectMultiple(message string, options []string) ([]int, error) {
+	if len(options) == 0 {
+		return nil, fmt.Errorf("no options available")
+	}
+
+	prompt := &survey.MultiSelect{
+		Message:  message,
+		Options:  options,
+		PageSize: 15,
+	}
+
+	var selected []int
+	if err := survey.AskOne(prompt, &selected, survey.WithValidator(survey.MinItems(1))); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// SelectOption prompts the user to pick one option and returns its index
+func SelectOption(message string, options []string) (int, error) {
+	if len(options) == 0 {
+		return 0, fmt.Errorf("no options available")
+	}
+
+	prompt := &survey.Select{
+		Message:  message,
+		Options:  options,
+		PageSize: 10,
+	}
+
+	var selectedIndex int
+	err := survey.AskOne(prompt, &selectedIndex)
+	return selectedIndex, err
+}
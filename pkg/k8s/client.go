@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
@@ -13,6 +14,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 // Client wraps the Kubernetes client
@@ -21,31 +23,58 @@ type Client struct {
 	restConfig *rest.Config
 }
 
+// InClusterContext is the kube context name of the cluster the CLI runs in,
+// reached with the pod's service account
+const InClusterContext = "in-cluster"
+
+// InCluster reports whether the CLI runs in a pod with a service account
+func InCluster() bool {
+	_, err := rest.InClusterConfig()
+	return err == nil
+}
+
+// kubeContextOverride is the kubeconfig context used instead of the current
+// one, from the kubeconfig file kubeconfigOverride ("" for the default one)
+var kubeContextOverride, kubeconfigOverride string
+
+// SetKubeContext makes NewClient and GetCurrentKubeContext use a context of
+// a kubeconfig file instead of the current one, for the rest of the process.
+// An empty kubeconfig is the default file.
+func SetKubeContext(kubeconfig, name string) {
+	kubeconfigOverride, kubeContextOverride = kubeconfig, name
+}
+
 // NewClient creates a new Kubernetes client using the current context
 func NewClient() (*Client, error) {
-	config, err := getKubeConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	if kubeContextOverride != "" {
+		return NewClientWithContext(kubeconfigOverride, kubeContextOverride)
 	}
 
-	clientset, err := kubernetes.NewForConfig(config)
+	config, err := getKubeConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
 	}
-
-	return &Client{
-		clientset:  clientset,
-		restConfig: config,
-	}, nil
+	return NewClientForConfig(config)
 }
 
-// NewClientWithContext creates a new Kubernetes client for a specific kubeconfig context
-func NewClientWithContext(kubeContext string) (*Client, error) {
-	config, err := getKubeConfigWithContext(kubeContext)
+// NewClientWithContext creates a new Kubernetes client for a context of a
+// kubeconfig file. An empty kubeconfig is KUBECONFIG, else ~/.kube/config.
+func NewClientWithContext(kubeconfig, kubeContext string) (*Client, error) {
+	var config *rest.Config
+	var err error
+	if kubeContext == InClusterContext {
+		config, err = rest.InClusterConfig()
+	} else {
+		config, err = getKubeConfigWithContext(kubeconfig, kubeContext)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get kubeconfig for context %s: %w", kubeContext, err)
 	}
+	return NewClientForConfig(config)
+}
 
+// NewClientForConfig creates a Kubernetes client for a REST config
+func NewClientForConfig(config *rest.Config) (*Client, error) {
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
@@ -59,23 +88,22 @@ func NewClientWithContext(kubeContext string) (*Client, error) {
 
 // GetCurrentKubeContext returns the current context name from kubeconfig
 func GetCurrentKubeContext() (string, error) {
-	// Try KUBECONFIG env var first
-	var kubeconfigPath string
-	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
-		kubeconfigPath = kubeconfig
-	} else {
-		// Try default location
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return "", fmt.Errorf("failed to get home directory: %w", err)
-		}
-		kubeconfigPath = filepath.Join(home, ".kube", "config")
+	if kubeContextOverride != "" {
+		return kubeContextOverride, nil
 	}
+	return KubeconfigCurrentContext("")
+}
 
-	// Load the kubeconfig
-	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+// KubeconfigCurrentContext returns the current context of a kubeconfig file
+// ("" for the default one). In a pod without a default kubeconfig, it is
+// InClusterContext.
+func KubeconfigCurrentContext(kubeconfig string) (string, error) {
+	config, err := loadKubeconfig(kubeconfig)
 	if err != nil {
-		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
+		if kubeconfig == "" && InCluster() {
+			return InClusterContext, nil
+		}
+		return "", err
 	}
 
 	if config.CurrentContext == "" {
@@ -85,6 +113,42 @@ func GetCurrentKubeContext() (string, error) {
 	return config.CurrentContext, nil
 }
 
+// ListKubeContexts returns the names of the contexts of a kubeconfig file
+// ("" for the default one), sorted
+func ListKubeContexts(kubeconfig string) ([]string, error) {
+	config, err := loadKubeconfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// loadKubeconfig loads a kubeconfig file, by default KUBECONFIG, else
+// ~/.kube/config
+func loadKubeconfig(kubeconfig string) (*clientcmdapi.Config, error) {
+	if kubeconfig == "" {
+		kubeconfig = os.Getenv("KUBECONFIG")
+	}
+	if kubeconfig == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		kubeconfig = filepath.Join(home, ".kube", "config")
+	}
+
+	config, err := clientcmd.LoadFromFile(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return config, nil
+}
+
 // getKubeConfig loads kubeconfig from standard locations
 func getKubeConfig() (*rest.Config, error) {
 	// Try in-cluster config first
@@ -111,24 +175,11 @@ func getKubeConfig() (*rest.Config, error) {
 	return clientcmd.BuildConfigFromFlags("", kubeconfig)
 }
 
-// getKubeConfigWithContext loads kubeconfig for a specific context
-func getKubeConfigWithContext(contextName string) (*rest.Config, error) {
-	// Determine kubeconfig path
-	var kubeconfigPath string
-	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
-		kubeconfigPath = kubeconfig
-	} else {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
-		}
-		kubeconfigPath = filepath.Join(home, ".kube", "config")
-	}
-
-	// Load the kubeconfig
-	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+// getKubeConfigWithContext loads a kubeconfig file for a specific context
+func getKubeConfigWithContext(kubeconfig, contextName string) (*rest.Config, error) {
+	config, err := loadKubeconfig(kubeconfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+		return nil, err
 	}
 
 	// Check if the context exists
@@ -140,6 +191,16 @@ func getKubeConfigWithContext(contextName string) (*rest.Config, error) {
 	return clientcmd.NewNonInteractiveClientConfig(*config, contextName, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
 }
 
+// ServerVersion returns the Kubernetes API server version, verifying the
+// cluster is reachable with the current credentials
+func (c *Client) ServerVersion() (string, error) {
+	info, err := c.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to reach Kubernetes API server: %w", err)
+	}
+	return info.GitVersion, nil
+}
+
 // ListPods queries pods by namespace and label selector
 func (c *Client) ListPods(ctx context.Context, namespace string, labels map[string]string) ([]corev1.Pod, error) {
 	// Build label selector
@@ -173,6 +234,14 @@ func (c *Client) GetPod(ctx context.Context, namespace, name string) (*corev1.Po
 	return pod, nil
 }
 
+// DeletePod deletes a pod; its controller recreates it
+func (c *Client) DeletePod(ctx context.Context, namespace, name string) error {
+	if err := c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete pod %s: %w", name, err)
+	}
+	return nil
+}
+
 // ListEndpointSlices lists endpoint slices for a service
 func (c *Client) ListEndpointSlices(ctx context.Context, namespace, serviceName string) ([]discoveryv1.EndpointSlice, error) {
 	// EndpointSlices are labeled with the service name
@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// ListDeployments queries deployments by namespace and label selector
+func (c *Client) ListDeployments(ctx context.Context, namespace string, labels map[string]string) ([]appsv1.Deployment, error) {
+	opts := metav1.ListOptions{}
+	if len(labels) > 0 {
+		opts.LabelSelector = k8slabels.SelectorFromSet(labels).String()
+	}
+
+	deploymentList, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	return deploymentList.Items, nil
+}
+
+// ScaleDeployment sets the replica count of a deployment through its scale
+// subresource and returns the previous count
+func (c *Client) ScaleDeployment(ctx context.Context, namespace, name string, replicas int32) (int32, error) {
+	deployments := c.clientset.AppsV1().Deployments(namespace)
+
+	scale, err := deployments.GetScale(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get scale of deployment %s: %w", name, err)
+	}
+
+	previous := scale.Spec.Replicas
+	if previous == replicas {
+		return previous, nil
+	}
+
+	scale.Spec.Replicas = replicas
+	if _, err := deployments.UpdateScale(ctx, name, scale, metav1.UpdateOptions{}); err != nil {
+		return previous, fmt.Errorf("failed to scale deployment %s: %w", name, err)
+	}
+	return previous, nil
+}
+
+// AnnotateDeployment sets annotations on a deployment; nil values remove the
+// annotation
+func (c *Client) AnnotateDeployment(ctx context.Context, namespace, name string, annotations map[string]*string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode annotations: %w", err)
+	}
+
+	_, err = c.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to annotate deployment %s: %w", name, err)
+	}
+	return nil
+}
+
+// restartedAtAnnotation is the pod template annotation kubectl sets for a
+// rollout restart
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// RestartDeployment triggers a rolling restart of a deployment, like
+// 'kubectl rollout restart'
+func (c *Client) RestartDeployment(ctx context.Context, namespace, name string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{restartedAtAnnotation: time.Now().Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode restart patch: %w", err)
+	}
+
+	_, err = c.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to restart deployment %s: %w", name, err)
+	}
+	return nil
+}
@@ -19,10 +19,29 @@ import (
 type APIDiscoveryInfo struct {
 	PublicURL       string // Public URL if configured (empty if not available)
 	PortForwardURL  string // Port-forward URL (available if no public URL)
+	ClusterURL      string // Cluster DNS URL, only set by in-cluster discovery
 	APIID           string // API instance ID
 	StopPortForward func() // Function to stop the port-forward (nil if public URL exists)
 }
 
+// URL returns the URL to reach the API at: the cluster URL in the cluster,
+// else the public URL, else the port-forward
+func (i *APIDiscoveryInfo) URL() string {
+	if url := i.SavedURL(); url != "" {
+		return url
+	}
+	return i.PortForwardURL
+}
+
+// SavedURL returns the URL worth caching in a context: the cluster URL in
+// the cluster, else the public URL, else "" as port-forwards don't last
+func (i *APIDiscoveryInfo) SavedURL() string {
+	if i.ClusterURL != "" {
+		return i.ClusterURL
+	}
+	return i.PublicURL
+}
+
 // DiscoverAPIEndpointFast discovers the API endpoint with public URL preference
 // It establishes a port-forward connection ONCE, then queries /health?info=true to get
 // public URL and API ID. If public URL exists, closes the port-forward immediately.
@@ -35,33 +54,11 @@ func (c *Client) DiscoverAPIEndpointFast(ctx context.Context, serviceName, names
 		return nil, fmt.Errorf("failed to setup initial connection: %w", err)
 	}
 
-	// Create HTTP client and call /health?info=true through the port-forward
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Get(portForwardURL + "/health?info=true")
+	// Call /health?info=true through the port-forward
+	apiInfo, err := fetchAPIInfo(ctx, portForwardURL)
 	if err != nil {
 		stopFunc() // Clean up on error
-		return nil, fmt.Errorf("failed to get API info: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		stopFunc() // Clean up on error
-		return nil, fmt.Errorf("API info request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Parse the response
-	var apiInfo struct {
-		PublicURL string `json:"public_url"`
-		APIID     string `json:"api_id"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&apiInfo); err != nil {
-		stopFunc() // Clean up on error
-		return nil, fmt.Errorf("failed to parse API info: %w", err)
+		return nil, err
 	}
 
 	// Decision time: close port-forward if we have a public URL
@@ -85,6 +82,71 @@ func (c *Client) DiscoverAPIEndpointFast(ctx context.Context, serviceName, names
 	}, nil
 }
 
+// DiscoverAPIEndpointInCluster discovers the API through the cluster DNS name
+// of its service, for the CLI running in a pod of the same cluster. No
+// port-forward is opened; the service port is read from the service, or
+// assumed to be 80 when the service account may not read services.
+func (c *Client) DiscoverAPIEndpointInCluster(ctx context.Context, serviceName, namespace string) (*APIDiscoveryInfo, error) {
+	port := int32(80)
+	if service, err := c.GetService(ctx, namespace, serviceName); err == nil && len(service.Spec.Ports) > 0 {
+		port = service.Spec.Ports[0].Port
+	}
+	clusterURL := ServiceURL(serviceName, namespace, port)
+
+	apiInfo, err := fetchAPIInfo(ctx, clusterURL)
+	if err != nil {
+		return nil, err
+	}
+	return &APIDiscoveryInfo{
+		PublicURL:  apiInfo.PublicURL,
+		ClusterURL: clusterURL,
+		APIID:      apiInfo.APIID,
+	}, nil
+}
+
+// ServiceURL returns the cluster DNS URL of a service port
+func ServiceURL(serviceName, namespace string, port int32) string {
+	if port == 80 {
+		return fmt.Sprintf("http://%s.%s.svc", serviceName, namespace)
+	}
+	return fmt.Sprintf("http://%s.%s.svc:%d", serviceName, namespace, port)
+}
+
+// apiInfo is the discovery part of the API's /health?info=true response
+type apiInfo struct {
+	PublicURL string `json:"public_url"`
+	APIID     string `json:"api_id"`
+}
+
+// fetchAPIInfo calls /health?info=true on the API at baseURL
+func fetchAPIInfo(ctx context.Context, baseURL string) (*apiInfo, error) {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/health?info=true", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API info: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API info request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var info apiInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to parse API info: %w", err)
+	}
+	return &info, nil
+}
+
 // DiscoverAPIEndpoint discovers the Lissto API endpoint from the cluster
 // Returns just the port-forward URL (simpler version without API info)
 func (c *Client) DiscoverAPIEndpoint(ctx context.Context, serviceName, namespace string) (string, error) {
@@ -244,6 +306,30 @@ func (c *Client) startPortForward(ctx context.Context, namespace, podName string
 	}
 }
 
+// PortForwardPod forwards a local port to a pod port. If localPort is 0 or
+// already in use, the next free port is chosen. Returns the local port in
+// use and a function that stops the forward.
+func (c *Client) PortForwardPod(ctx context.Context, namespace, podName string, localPort, remotePort int) (int, func(), error) {
+	if localPort == 0 {
+		localPort = remotePort
+	}
+
+	if !isPortAvailable(localPort) {
+		availablePort := findAvailablePort(localPort)
+		if availablePort == 0 {
+			return 0, nil, fmt.Errorf("port %d is already in use and no alternative ports available", localPort)
+		}
+		localPort = availablePort
+	}
+
+	stopFunc, err := c.startPortForward(ctx, namespace, podName, localPort, remotePort)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to start port-forward: %w", err)
+	}
+
+	return localPort, stopFunc, nil
+}
+
 // isPortAvailable checks if a port is available on localhost
 func isPortAvailable(port int) bool {
 	address := fmt.Sprintf("localhost:%d", port)
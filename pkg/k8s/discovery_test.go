@@ -0,0 +1,43 @@
+package k8s_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+)
+
+var _ = Describe("Discovery", func() {
+	It("should build the cluster DNS URL of a service", func() {
+		Expect(k8s.ServiceURL("lissto-api", "lissto-system", 80)).To(Equal("http://lissto-api.lissto-system.svc"))
+		Expect(k8s.ServiceURL("lissto-api", "lissto-system", 8080)).To(Equal("http://lissto-api.lissto-system.svc:8080"))
+	})
+
+	DescribeTable("the URL to reach and the URL to save",
+		func(info k8s.APIDiscoveryInfo, url, saved string) {
+			Expect(info.URL()).To(Equal(url))
+			Expect(info.SavedURL()).To(Equal(saved))
+		},
+		Entry("in the cluster", k8s.APIDiscoveryInfo{ClusterURL: "http://lissto-api.lissto-system.svc", PublicURL: "https://lissto.example"},
+			"http://lissto-api.lissto-system.svc", "http://lissto-api.lissto-system.svc"),
+		Entry("public", k8s.APIDiscoveryInfo{PublicURL: "https://lissto.example"}, "https://lissto.example", "https://lissto.example"),
+		Entry("port-forward", k8s.APIDiscoveryInfo{PortForwardURL: "http://localhost:8080"}, "http://localhost:8080", ""),
+	)
+
+	Context("outside a cluster", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv("KUBERNETES_SERVICE_HOST", "")
+			GinkgoT().Setenv("KUBECONFIG", filepath.Join(GinkgoT().TempDir(), "missing"))
+		})
+
+		It("should not report running in the cluster", func() {
+			Expect(k8s.InCluster()).To(BeFalse())
+			_, err := k8s.KubeconfigCurrentContext("")
+			Expect(err).To(HaveOccurred())
+			_, err = k8s.NewClientWithContext("", k8s.InClusterContext)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
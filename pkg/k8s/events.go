@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ListEvents lists events in a namespace, oldest first
+func (c *Client) ListEvents(ctx context.Context, namespace string) ([]corev1.Event, error) {
+	eventList, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	events := eventList.Items
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventTime(&events[i]).Before(EventTime(&events[j]))
+	})
+	return events, nil
+}
+
+// WatchEvents streams new and updated events in a namespace until ctx is done.
+// The returned channel is closed when the watch ends.
+func (c *Client) WatchEvents(ctx context.Context, namespace string) (<-chan corev1.Event, error) {
+	watcher, err := c.clientset.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch events: %w", err)
+	}
+
+	out := make(chan corev1.Event)
+	go func() {
+		defer close(out)
+		defer watcher.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+				if ev.Type != watch.Added && ev.Type != watch.Modified {
+					continue
+				}
+				if e, ok := ev.Object.(*corev1.Event); ok {
+					select {
+					case out <- *e:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// EventTime returns the most relevant timestamp of an event
+func EventTime(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	}
+	return e.CreationTimestamp.Time
+}
@@ -0,0 +1,121 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ExecOptions holds options for running a command in a container
+type ExecOptions struct {
+	Container string
+	Command   []string
+	Stdin     io.Reader // nil disables stdin
+	Stdout    io.Writer
+	Stderr    io.Writer
+	TTY       bool // Allocate a TTY; puts the local terminal in raw mode when stdin is a terminal
+}
+
+// Exec runs a command in a pod container using the SPDY executor
+func (c *Client) Exec(ctx context.Context, namespace, podName string, opts ExecOptions) error {
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: opts.Container,
+			Command:   opts.Command,
+			Stdin:     opts.Stdin != nil,
+			Stdout:    opts.Stdout != nil,
+			Stderr:    opts.Stderr != nil && !opts.TTY, // stderr is merged into stdout with a TTY
+			TTY:       opts.TTY,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.restConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:  opts.Stdin,
+		Stdout: opts.Stdout,
+		Tty:    opts.TTY,
+	}
+	if !opts.TTY {
+		streamOpts.Stderr = opts.Stderr
+	}
+
+	if opts.TTY {
+		if f, ok := opts.Stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+			state, err := term.MakeRaw(int(f.Fd()))
+			if err != nil {
+				return fmt.Errorf("failed to set terminal raw mode: %w", err)
+			}
+			defer func() { _ = term.Restore(int(f.Fd()), state) }()
+
+			if width, height, err := term.GetSize(int(f.Fd())); err == nil {
+				streamOpts.TerminalSizeQueue = newFixedSizeQueue(ctx, uint16(width), uint16(height))
+			}
+		}
+	}
+
+	return executor.StreamWithContext(ctx, streamOpts)
+}
+
+// fixedSizeQueue reports the initial terminal size once, then blocks until
+// the context is done
+type fixedSizeQueue struct {
+	ctx  context.Context
+	size chan remotecommand.TerminalSize
+}
+
+func newFixedSizeQueue(ctx context.Context, width, height uint16) *fixedSizeQueue {
+	q := &fixedSizeQueue{ctx: ctx, size: make(chan remotecommand.TerminalSize, 1)}
+	q.size <- remotecommand.TerminalSize{Width: width, Height: height}
+	return q
+}
+
+func (q *fixedSizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case s := <-q.size:
+		return &s
+	case <-q.ctx.Done():
+		return nil
+	}
+}
+
+// PickPod returns the best pod to target: a running, ready pod if there is one,
+// otherwise the first running pod
+func PickPod(pods []corev1.Pod) (*corev1.Pod, error) {
+	var running *corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if running == nil {
+			running = pod
+		}
+		ready := true
+		for _, cs := range pod.Status.ContainerStatuses {
+			if !cs.Ready {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			return pod, nil
+		}
+	}
+	if running != nil {
+		return running, nil
+	}
+	return nil, fmt.Errorf("no running pods found")
+}
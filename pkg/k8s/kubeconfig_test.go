@@ -0,0 +1,61 @@
+package k8s_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: staging
+clusters:
+- name: c
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: u
+  user:
+    token: t
+contexts:
+- name: staging
+  context: {cluster: c, user: u}
+- name: dev
+  context: {cluster: c, user: u}
+`
+
+var _ = Describe("Kubeconfig", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "config")
+		Expect(os.WriteFile(path, []byte(testKubeconfig), 0600)).To(Succeed())
+		GinkgoT().Setenv("KUBECONFIG", path)
+	})
+
+	It("should list the contexts sorted by name", func() {
+		Expect(k8s.ListKubeContexts("")).To(Equal([]string{"dev", "staging"}))
+	})
+
+	It("should read an explicit kubeconfig file instead of KUBECONFIG", func() {
+		GinkgoT().Setenv("KUBECONFIG", filepath.Join(GinkgoT().TempDir(), "missing"))
+		Expect(k8s.ListKubeContexts("")).Error().To(HaveOccurred())
+		Expect(k8s.ListKubeContexts(path)).To(Equal([]string{"dev", "staging"}))
+		Expect(k8s.KubeconfigCurrentContext(path)).To(Equal("staging"))
+		_, err := k8s.NewClientWithContext(path, "dev")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should fail for a context missing from the kubeconfig file", func() {
+		_, err := k8s.NewClientWithContext(path, "prod")
+		Expect(err).To(MatchError(ContainSubstring("context prod not found")))
+	})
+
+	It("should return the current context", func() {
+		Expect(k8s.GetCurrentKubeContext()).To(Equal("staging"))
+	})
+})
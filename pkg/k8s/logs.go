@@ -5,9 +5,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // LogOptions contains options for streaming logs
@@ -16,21 +20,38 @@ type LogOptions struct {
 	Timestamps bool
 	TailLines  *int64
 	Since      *time.Duration
-	Container  string
+	SinceTime  *time.Time // Takes precedence over Since
+	// UntilTime cuts logs off after this time. The API has no such option:
+	// StreamLogs then always returns timestamps, and StreamLogsMulti ends
+	// each stream at the first later line.
+	UntilTime *time.Time
+	Container string
+	// Previous streams the logs of the previous, terminated container instance
+	Previous bool
+	// InitContainers includes init containers when Container is empty
+	InitContainers bool
+
+	// RefreshInterval is how often StreamLogsMulti re-lists pods when
+	// following (default DefaultLogRefreshDelay)
+	RefreshInterval time.Duration
 }
 
 // StreamLogs streams logs from a pod/container
 func (c *Client) StreamLogs(ctx context.Context, namespace, podName string, opts LogOptions) (io.ReadCloser, error) {
 	podLogOpts := &corev1.PodLogOptions{
 		Follow:     opts.Follow,
-		Timestamps: opts.Timestamps,
+		Timestamps: opts.Timestamps || opts.UntilTime != nil,
+		Previous:   opts.Previous,
 	}
 
 	if opts.TailLines != nil {
 		podLogOpts.TailLines = opts.TailLines
 	}
 
-	if opts.Since != nil {
+	if opts.SinceTime != nil {
+		sinceTime := metav1.NewTime(*opts.SinceTime)
+		podLogOpts.SinceTime = &sinceTime
+	} else if opts.Since != nil {
 		seconds := int64(opts.Since.Seconds())
 		podLogOpts.SinceSeconds = &seconds
 	}
@@ -49,71 +70,367 @@ type LogLine struct {
 	Container string
 	Message   string
 	Timestamp time.Time
+	// Marker is set for status lines from the streamer (pod restarts, new or
+	// terminated pods) rather than container output
+	Marker bool
+}
+
+// SplitTimestamp splits the RFC3339 timestamp Kubernetes prefixes log lines
+// with when LogOptions.Timestamps is set. ok is false if there is none.
+func SplitTimestamp(line string) (ts time.Time, message string, ok bool) {
+	prefix, rest, found := strings.Cut(line, " ")
+	if !found {
+		prefix, rest = line, ""
+	}
+	ts, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return ts, rest, true
 }
 
-// StreamLogsMulti streams logs from multiple pods and multiplexes them
-func (c *Client) StreamLogsMulti(ctx context.Context, namespace string, pods []corev1.Pod, opts LogOptions, output chan<- LogLine) error {
-	errCh := make(chan error, len(pods))
+// LineBefore checks a log line fetched with timestamps against an until
+// cutoff. ok is false once the line was logged after until; message is the
+// line, without its timestamp unless keepTimestamp is set. Lines without a
+// timestamp are kept.
+func LineBefore(line string, until time.Time, keepTimestamp bool) (message string, ok bool) {
+	ts, rest, found := SplitTimestamp(line)
+	if !found {
+		return line, true
+	}
+	if ts.After(until) {
+		return "", false
+	}
+	if keepTimestamp {
+		return line, true
+	}
+	return rest, true
+}
+
+// ParseLogTime parses a point in time given as a duration before now ("30m")
+// or an RFC3339 timestamp
+func ParseLogTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: use a duration (e.g. 10m) or an RFC3339 timestamp", value)
+	}
+	return t, nil
+}
+
+// Reconnect backoff for followed streams
+const (
+	logReconnectMinDelay   = time.Second
+	logReconnectMaxDelay   = 30 * time.Second
+	DefaultLogRefreshDelay = 5 * time.Second
+)
+
+// PodLister lists the current pods of a log stream; used to pick up new pods
+type PodLister func(ctx context.Context) ([]corev1.Pod, error)
+
+// StreamLogsMulti streams logs from multiple pods and multiplexes them.
+//
+// When following, streams that end are reattached with backoff, container
+// restarts and deleted pods are reported as marker lines, and if refresh is
+// set the pod set is re-listed every RefreshInterval so new pods are attached
+// automatically. It then only returns once ctx is done.
+func (c *Client) StreamLogsMulti(ctx context.Context, namespace string, pods []corev1.Pod, opts LogOptions, refresh PodLister, output chan<- LogLine) error {
+	s := &multiStreamer{
+		client:    c,
+		namespace: namespace,
+		opts:      opts,
+		output:    output,
+		attached:  make(map[string]bool),
+	}
+
+	for i := range pods {
+		s.attach(ctx, &pods[i], false)
+	}
+
+	if opts.Follow && refresh != nil {
+		s.watch(ctx, refresh)
+	}
+
+	s.wg.Wait()
+	return s.lastErr
+}
 
-	for _, pod := range pods {
+// multiStreamer tracks the pods attached by StreamLogsMulti
+type multiStreamer struct {
+	client    *Client
+	namespace string
+	opts      LogOptions
+	output    chan<- LogLine
+
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	attached map[string]bool
+	lastErr  error
+}
+
+// attach starts streaming every selected container of a pod, once
+func (s *multiStreamer) attach(ctx context.Context, pod *corev1.Pod, announce bool) {
+	s.mu.Lock()
+	if s.attached[pod.Name] {
+		s.mu.Unlock()
+		return
+	}
+	s.attached[pod.Name] = true
+	s.mu.Unlock()
+
+	if announce {
+		s.mark(ctx, pod.Name, "", fmt.Sprintf("pod %s started", pod.Name))
+	}
+
+	for _, container := range s.containers(pod) {
+		s.wg.Add(1)
 		go func() {
-			// Determine which containers to stream from
-			containers := []string{}
-			if opts.Container != "" {
-				containers = []string{opts.Container}
-			} else {
-				// Stream from all containers
-				for _, c := range pod.Spec.Containers {
-					containers = append(containers, c.Name)
-				}
+			defer s.wg.Done()
+			s.stream(ctx, pod.Name, container, restartCount(pod, container))
+		}()
+	}
+}
+
+// containers returns the containers of a pod to stream from
+func (s *multiStreamer) containers(pod *corev1.Pod) []string {
+	if s.opts.Container != "" {
+		return []string{s.opts.Container}
+	}
+	containers := make([]string, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	if s.opts.InitContainers {
+		for _, c := range pod.Spec.InitContainers {
+			containers = append(containers, c.Name)
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, c.Name)
+	}
+	return containers
+}
+
+// watch re-lists pods periodically and attaches new ones
+func (s *multiStreamer) watch(ctx context.Context, refresh PodLister) {
+	interval := s.opts.RefreshInterval
+	if interval <= 0 {
+		interval = DefaultLogRefreshDelay
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pods, err := refresh(ctx)
+		if err != nil {
+			continue
+		}
+		for i := range pods {
+			if pods[i].DeletionTimestamp == nil {
+				s.attach(ctx, &pods[i], true)
 			}
+		}
+	}
+}
 
-			for _, container := range containers {
-				containerOpts := opts
-				containerOpts.Container = container
-
-				stream, err := c.StreamLogs(ctx, namespace, pod.Name, containerOpts)
-				if err != nil {
-					errCh <- fmt.Errorf("failed to stream logs from pod %s container %s: %w", pod.Name, container, err)
-					continue
-				}
-
-				// Read and send log lines
-				scanner := bufio.NewScanner(stream)
-				for scanner.Scan() {
-					select {
-					case <-ctx.Done():
-						_ = stream.Close()
-						return
-					case output <- LogLine{
-						PodName:   pod.Name,
-						Container: container,
-						Message:   scanner.Text(),
-						Timestamp: time.Now(),
-					}:
-					}
-				}
-
-				_ = stream.Close()
-
-				if err := scanner.Err(); err != nil && err != io.EOF {
-					errCh <- fmt.Errorf("error reading logs from pod %s: %w", pod.Name, err)
-				}
+// stream copies one container's logs to the output. When following, it
+// reattaches after the stream ends until the pod is gone or ctx is done.
+func (s *multiStreamer) stream(ctx context.Context, podName, container string, restarts int32) {
+	state := &FollowState{Container: container, Restarts: restarts, Opts: s.opts}
+	state.Opts.Container = container
+	delay := logReconnectMinDelay
+	warned := false
+
+	for {
+		last, err := s.copyLines(ctx, podName, state.Opts)
+		if ctx.Err() != nil {
+			return
+		}
+		if !s.opts.Follow {
+			if err != nil {
+				s.fail(err)
 			}
+			return
+		}
+		if !last.IsZero() {
+			state.LastSeen = last
+			delay = logReconnectMinDelay
+			warned = false
+		}
+		if err != nil && !warned {
+			// Usually a container that hasn't started yet; keep retrying
+			s.mark(ctx, podName, container, fmt.Sprintf("waiting for logs: %v", err))
+			warned = true
+		}
 
-			errCh <- nil
-		}()
+		if err := sleepContext(ctx, delay); err != nil {
+			return
+		}
+		delay = min(delay*2, logReconnectMaxDelay)
+
+		pod, err := s.client.clientset.CoreV1().Pods(s.namespace).Get(ctx, podName, metav1.GetOptions{})
+		switch state.Reattach(pod, err) {
+		case ReattachPodGone:
+			s.mark(ctx, podName, container, fmt.Sprintf("pod %s terminated", podName))
+			s.mu.Lock()
+			delete(s.attached, podName)
+			s.mu.Unlock()
+			return
+		case ReattachInitDone:
+			return
+		case ReattachRestarted:
+			s.mark(ctx, podName, container, fmt.Sprintf("pod %s container %s restarted (restarts: %d)", podName, container, state.Restarts))
+		}
+	}
+}
+
+// ReattachAction is what a followed container log does after its stream ended
+type ReattachAction int
+
+const (
+	// ReattachResume reattaches after the last line received
+	ReattachResume ReattachAction = iota
+	// ReattachRestarted reads the fresh log of a restarted container from the start
+	ReattachRestarted
+	// ReattachRetry reattaches with unchanged options; the pod couldn't be read
+	ReattachRetry
+	// ReattachPodGone stops: the pod was deleted
+	ReattachPodGone
+	// ReattachInitDone stops: the init container completed and its log won't grow
+	ReattachInitDone
+)
+
+// FollowState is the position of a followed container log
+type FollowState struct {
+	Container string
+	// Restarts is the container's restart count when it was last attached
+	Restarts int32
+	// LastSeen is when the last line was received; zero if none
+	LastSeen time.Time
+	// Opts are the options of the next attach
+	Opts LogOptions
+}
+
+// Reattach decides how to continue from a fresh read of the pod (err is the
+// error of that read) and updates the state for the next attach
+func (f *FollowState) Reattach(pod *corev1.Pod, err error) ReattachAction {
+	if apierrors.IsNotFound(err) || (err == nil && pod.DeletionTimestamp != nil) {
+		return ReattachPodGone
+	}
+	if err != nil {
+		return ReattachRetry
+	}
+	if initContainerDone(pod, f.Container) {
+		return ReattachInitDone
+	}
+
+	if current := restartCount(pod, f.Container); current != f.Restarts {
+		f.Restarts = current
+		f.Opts.TailLines, f.Opts.Since, f.Opts.SinceTime = nil, nil, nil
+		return ReattachRestarted
+	}
+	if !f.LastSeen.IsZero() {
+		since := f.LastSeen
+		f.Opts.TailLines, f.Opts.Since, f.Opts.SinceTime = nil, nil, &since
 	}
+	return ReattachResume
+}
+
+// copyLines streams logs once and returns when the last line was received
+// (zero if none)
+func (s *multiStreamer) copyLines(ctx context.Context, podName string, opts LogOptions) (time.Time, error) {
+	var last time.Time
+
+	stream, err := s.client.StreamLogs(ctx, s.namespace, podName, opts)
+	if err != nil {
+		return last, fmt.Errorf("failed to stream logs from pod %s container %s: %w", podName, opts.Container, err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		last = time.Now()
+		message := scanner.Text()
+		if opts.UntilTime != nil {
+			var ok bool
+			if message, ok = LineBefore(message, *opts.UntilTime, opts.Timestamps); !ok {
+				// Lines are in order: the rest is later too
+				return last, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return last, nil
+		case s.output <- LogLine{
+			PodName:   podName,
+			Container: opts.Container,
+			Message:   message,
+			Timestamp: last,
+		}:
+		}
+	}
+
+	if err := scanner.Err(); err != nil && err != io.EOF {
+		return last, fmt.Errorf("error reading logs from pod %s: %w", podName, err)
+	}
+	return last, nil
+}
 
-	// Wait for all goroutines
-	var lastErr error
-	for i := 0; i < len(pods); i++ {
-		if err := <-errCh; err != nil {
-			lastErr = err
+// mark emits a marker line
+func (s *multiStreamer) mark(ctx context.Context, podName, container, message string) {
+	select {
+	case <-ctx.Done():
+	case s.output <- LogLine{PodName: podName, Container: container, Message: message, Timestamp: time.Now(), Marker: true}:
+	}
+}
+
+// fail records a stream error
+func (s *multiStreamer) fail(err error) {
+	s.mu.Lock()
+	s.lastErr = err
+	s.mu.Unlock()
+}
+
+// restartCount returns the restart count of a container or init container
+func restartCount(pod *corev1.Pod, container string) int32 {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses} {
+		for _, status := range statuses {
+			if status.Name == container {
+				return status.RestartCount
+			}
 		}
 	}
+	return 0
+}
 
-	return lastErr
+// initContainerDone reports whether container is an init container that
+// completed successfully; its log won't grow anymore
+func initContainerDone(pod *corev1.Pod, container string) bool {
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name == container {
+			return status.State.Terminated != nil && status.State.Terminated.ExitCode == 0
+		}
+	}
+	return false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // GetPodContainers returns the list of containers in a pod
@@ -0,0 +1,132 @@
+package k8s_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+)
+
+var _ = Describe("ParseLogTime", func() {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	It("should treat durations as time before now", func() {
+		t, err := k8s.ParseLogTime("30m", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(now.Add(-30 * time.Minute)))
+	})
+
+	It("should parse RFC3339 timestamps", func() {
+		t, err := k8s.ParseLogTime("2025-01-01T10:00:00Z", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)))
+	})
+
+	It("should reject anything else", func() {
+		_, err := k8s.ParseLogTime("yesterday", now)
+		Expect(err).To(MatchError(ContainSubstring("RFC3339")))
+	})
+})
+
+var _ = Describe("LineBefore", func() {
+	until := time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	It("should keep earlier lines, without their timestamp by default", func() {
+		message, ok := k8s.LineBefore("2025-01-02T02:59:59.5Z started", until, false)
+		Expect(ok).To(BeTrue())
+		Expect(message).To(Equal("started"))
+
+		message, ok = k8s.LineBefore("2025-01-02T03:00:00Z at the cutoff", until, true)
+		Expect(ok).To(BeTrue())
+		Expect(message).To(Equal("2025-01-02T03:00:00Z at the cutoff"))
+	})
+
+	It("should stop at later lines", func() {
+		_, ok := k8s.LineBefore("2025-01-02T03:00:00.000001Z too late", until, false)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should keep lines without a timestamp", func() {
+		message, ok := k8s.LineBefore("continued", until, false)
+		Expect(ok).To(BeTrue())
+		Expect(message).To(Equal("continued"))
+	})
+})
+
+var _ = Describe("FollowState", func() {
+	lastSeen := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tail := int64(100)
+
+	var state *k8s.FollowState
+
+	// pod returns a pod whose "app" container and "migrate" init container
+	// have the given restart counts
+	pod := func(restarts int32) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+			Status: corev1.PodStatus{
+				ContainerStatuses:     []corev1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: "migrate", RestartCount: restarts}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		state = &k8s.FollowState{Container: "app", Restarts: 1, Opts: k8s.LogOptions{Container: "app", TailLines: &tail}}
+	})
+
+	It("should resume after the last line received", func() {
+		state.LastSeen = lastSeen
+		Expect(state.Reattach(pod(1), nil)).To(Equal(k8s.ReattachResume))
+		Expect(state.Opts.TailLines).To(BeNil())
+		Expect(state.Opts.SinceTime).To(HaveValue(Equal(lastSeen)))
+	})
+
+	It("should keep the options when no line was received yet", func() {
+		Expect(state.Reattach(pod(1), nil)).To(Equal(k8s.ReattachResume))
+		Expect(state.Opts.TailLines).To(HaveValue(Equal(tail)))
+		Expect(state.Opts.SinceTime).To(BeNil())
+	})
+
+	It("should read a restarted container from the start", func() {
+		state.LastSeen = lastSeen
+		Expect(state.Reattach(pod(2), nil)).To(Equal(k8s.ReattachRestarted))
+		Expect(state.Restarts).To(BeEquivalentTo(2))
+		Expect(state.Opts.TailLines).To(BeNil())
+		Expect(state.Opts.SinceTime).To(BeNil())
+
+		// The restart is reported once
+		Expect(state.Reattach(pod(2), nil)).To(Equal(k8s.ReattachResume))
+	})
+
+	It("should stop when the pod is deleted or being deleted", func() {
+		Expect(state.Reattach(nil, apierrors.NewNotFound(corev1.Resource("pods"), "web-1"))).To(Equal(k8s.ReattachPodGone))
+
+		deleting := pod(1)
+		deleting.DeletionTimestamp = &metav1.Time{Time: lastSeen}
+		Expect(state.Reattach(deleting, nil)).To(Equal(k8s.ReattachPodGone))
+	})
+
+	It("should retry with unchanged options when the pod can't be read", func() {
+		state.LastSeen = lastSeen
+		Expect(state.Reattach(nil, errors.New("connection refused"))).To(Equal(k8s.ReattachRetry))
+		Expect(state.Opts.TailLines).To(HaveValue(Equal(tail)))
+	})
+
+	It("should stop following an init container that completed", func() {
+		state.Container = "migrate"
+		done := pod(1)
+		done.Status.InitContainerStatuses[0].State.Terminated = &corev1.ContainerStateTerminated{ExitCode: 0}
+		Expect(state.Reattach(done, nil)).To(Equal(k8s.ReattachInitDone))
+
+		// A failed one is restarted by the kubelet: keep following
+		done.Status.InitContainerStatuses[0].State.Terminated.ExitCode = 1
+		Expect(state.Reattach(done, nil)).To(Equal(k8s.ReattachResume))
+	})
+})
@@ -0,0 +1,86 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+)
+
+// metricsAPIPath is the pod metrics endpoint served by metrics-server
+const metricsAPIPath = "/apis/metrics.k8s.io/v1beta1"
+
+// ErrMetricsUnavailable is returned when the cluster has no metrics API
+var ErrMetricsUnavailable = errors.New("metrics API (metrics.k8s.io) is not available; is metrics-server installed?")
+
+// ContainerMetrics is the current resource usage of a container
+type ContainerMetrics struct {
+	Name   string
+	CPU    resource.Quantity
+	Memory resource.Quantity
+}
+
+// PodMetrics is the current resource usage of a pod's containers
+type PodMetrics struct {
+	Name       string
+	Namespace  string
+	Containers []ContainerMetrics
+}
+
+// podMetricsList mirrors metrics.k8s.io/v1beta1 PodMetricsList
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Containers []struct {
+			Name  string `json:"name"`
+			Usage struct {
+				CPU    resource.Quantity `json:"cpu"`
+				Memory resource.Quantity `json:"memory"`
+			} `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// ListPodMetrics returns the current usage of pods matching labels, read
+// from the metrics.k8s.io API
+func (c *Client) ListPodMetrics(ctx context.Context, namespace string, labels map[string]string) ([]PodMetrics, error) {
+	req := c.clientset.CoreV1().RESTClient().Get().
+		AbsPath(metricsAPIPath, "namespaces", namespace, "pods")
+	if len(labels) > 0 {
+		req = req.Param("labelSelector", k8slabels.SelectorFromSet(labels).String())
+	}
+
+	data, err := req.DoRaw(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+			return nil, ErrMetricsUnavailable
+		}
+		return nil, fmt.Errorf("failed to get pod metrics: %w", err)
+	}
+
+	var list podMetricsList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode pod metrics: %w", err)
+	}
+
+	metrics := make([]PodMetrics, 0, len(list.Items))
+	for _, item := range list.Items {
+		pod := PodMetrics{Name: item.Metadata.Name, Namespace: item.Metadata.Namespace}
+		for _, container := range item.Containers {
+			pod.Containers = append(pod.Containers, ContainerMetrics{
+				Name:   container.Name,
+				CPU:    container.Usage.CPU,
+				Memory: container.Usage.Memory,
+			})
+		}
+		metrics = append(metrics, pod)
+	}
+	return metrics, nil
+}
@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// stackResource is the Stack custom resource of the Lissto controller
+var stackResource = envv1alpha1.GroupVersion.WithResource("stacks")
+
+// GetStack reads a Stack resource
+func (c *Client) GetStack(ctx context.Context, namespace, name string) (*envv1alpha1.Stack, error) {
+	client, err := dynamic.NewForConfig(c.restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	obj, err := client.Resource(stackResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stack %s: %w", name, err)
+	}
+
+	var stack envv1alpha1.Stack
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &stack); err != nil {
+		return nil, fmt.Errorf("failed to decode stack %s: %w", name, err)
+	}
+	return &stack, nil
+}
+
+// AnnotateStack sets annotations on a Stack resource; nil values remove the
+// annotation
+func (c *Client) AnnotateStack(ctx context.Context, namespace, name string, annotations map[string]*string) error {
+	return c.patchStackMetadata(ctx, namespace, name, map[string]interface{}{"annotations": annotations})
+}
+
+// LabelStack sets labels on a Stack resource; nil values remove the label
+func (c *Client) LabelStack(ctx context.Context, namespace, name string, labels map[string]*string) error {
+	return c.patchStackMetadata(ctx, namespace, name, map[string]interface{}{"labels": labels})
+}
+
+// patchStackMetadata merges metadata fields into a Stack resource
+func (c *Client) patchStackMetadata(ctx context.Context, namespace, name string, metadata map[string]interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return fmt.Errorf("failed to encode stack metadata: %w", err)
+	}
+
+	client, err := dynamic.NewForConfig(c.restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	_, err = client.Resource(stackResource).Namespace(namespace).Patch(ctx, name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update stack %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package k8s_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/rest"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+)
+
+// mergePatch applies a JSON merge patch (RFC 7386) to doc
+func mergePatch(doc, patch map[string]interface{}) {
+	for key, value := range patch {
+		if value == nil {
+			delete(doc, key)
+			continue
+		}
+		if patchMap, ok := value.(map[string]interface{}); ok {
+			docMap, ok := doc[key].(map[string]interface{})
+			if !ok {
+				docMap = map[string]interface{}{}
+				doc[key] = docMap
+			}
+			mergePatch(docMap, patchMap)
+			continue
+		}
+		doc[key] = value
+	}
+}
+
+var _ = Describe("Stack resources", func() {
+	const path = "/apis/env.lissto.dev/v1alpha1/namespaces/dev-ns/stacks/my-stack"
+
+	var (
+		client *k8s.Client
+		stack  map[string]interface{}
+		// contentType is the content type of the last patch
+		contentType string
+	)
+
+	BeforeEach(func() {
+		stack = map[string]interface{}{
+			"apiVersion": "env.lissto.dev/v1alpha1",
+			"kind":       "Stack",
+			"metadata": map[string]interface{}{
+				"name":        "my-stack",
+				"namespace":   "dev-ns",
+				"annotations": map[string]interface{}{"lissto.dev/blueprint-title": "Shop"},
+			},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			if r.URL.Path != path {
+				http.NotFound(w, r)
+				return
+			}
+			if r.Method == http.MethodPatch {
+				contentType = r.Header.Get("Content-Type")
+				body, err := io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				var patch map[string]interface{}
+				Expect(json.Unmarshal(body, &patch)).To(Succeed())
+				mergePatch(stack, patch)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			Expect(json.NewEncoder(w).Encode(stack)).To(Succeed())
+		}))
+		DeferCleanup(server.Close)
+
+		var err error
+		client, err = k8s.NewClientForConfig(&rest.Config{Host: server.URL})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should set annotations with a merge patch and read them back", func() {
+		expiresAt := "2026-01-02T00:00:00Z"
+		Expect(client.AnnotateStack(context.Background(), "dev-ns", "my-stack", map[string]*string{
+			"lissto.dev/expires-at": &expiresAt,
+		})).To(Succeed())
+		Expect(contentType).To(Equal("application/merge-patch+json"))
+
+		got, err := client.GetStack(context.Background(), "dev-ns", "my-stack")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Annotations).To(Equal(map[string]string{
+			"lissto.dev/blueprint-title": "Shop",
+			"lissto.dev/expires-at":      expiresAt,
+		}))
+
+		Expect(client.AnnotateStack(context.Background(), "dev-ns", "my-stack", map[string]*string{
+			"lissto.dev/expires-at": nil,
+		})).To(Succeed())
+		got, err = client.GetStack(context.Background(), "dev-ns", "my-stack")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Annotations).NotTo(HaveKey("lissto.dev/expires-at"))
+	})
+
+	It("should set labels and read them back", func() {
+		pr := "42"
+		Expect(client.LabelStack(context.Background(), "dev-ns", "my-stack", map[string]*string{"lissto.dev/pr": &pr})).To(Succeed())
+
+		got, err := client.GetStack(context.Background(), "dev-ns", "my-stack")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Labels).To(Equal(map[string]string{"lissto.dev/pr": "42"}))
+	})
+
+	It("should fail for a missing stack", func() {
+		_, err := client.GetStack(context.Background(), "dev-ns", "other")
+		Expect(err).To(MatchError(ContainSubstring("failed to get stack other")))
+	})
+})
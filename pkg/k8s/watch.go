@@ -0,0 +1,221 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Kinds of objects a Watcher reports on
+const (
+	WatchPods        = "Pod"
+	WatchDeployments = "Deployment"
+	WatchIngresses   = "Ingress"
+)
+
+// Types of watch events
+const (
+	WatchAdded   = "Added"
+	WatchUpdated = "Updated"
+	WatchDeleted = "Deleted"
+)
+
+// defaultWatchSyncTimeout bounds the initial listing of watched objects
+const defaultWatchSyncTimeout = 30 * time.Second
+
+// watchBuffer is the number of events buffered before handlers block
+const watchBuffer = 256
+
+// WatchOptions selects the objects a Watcher reports on
+type WatchOptions struct {
+	// Namespaces to watch; at least one is required
+	Namespaces []string
+	// Labels every watched object must have, e.g. lissto.dev/stack=<name>
+	Labels map[string]string
+	// Kinds to watch (WatchPods, WatchDeployments, WatchIngresses); all when empty
+	Kinds []string
+	// SyncTimeout bounds the initial listing (default 30s)
+	SyncTimeout time.Duration
+}
+
+// WatchEvent is a change of a watched object. Exactly one of Pod, Deployment
+// and Ingress is set, matching Kind; for deletions it is the last known state.
+type WatchEvent struct {
+	Kind       string
+	Type       string
+	Namespace  string
+	Name       string
+	Pod        *corev1.Pod
+	Deployment *appsv1.Deployment
+	Ingress    *networkingv1.Ingress
+}
+
+// Watcher reports changes of pods, deployments and ingresses through shared
+// informers, so callers react to changes instead of polling. Objects that
+// already exist are reported as added once the watch starts.
+type Watcher struct {
+	events    chan WatchEvent
+	ctx       context.Context
+	stop      context.CancelFunc
+	kinds     map[string]bool
+	factories map[string]informers.SharedInformerFactory
+}
+
+// Watch starts watching objects of the client's cluster until ctx is done
+func (c *Client) Watch(ctx context.Context, opts WatchOptions) (*Watcher, error) {
+	return NewWatcher(ctx, c.clientset, opts)
+}
+
+// NewWatcher starts watching objects through a clientset until ctx is done or
+// Stop is called.
+// It returns once the existing objects are listed.
+func NewWatcher(ctx context.Context, clientset kubernetes.Interface, opts WatchOptions) (*Watcher, error) {
+	if len(opts.Namespaces) == 0 {
+		return nil, fmt.Errorf("watch requires at least one namespace")
+	}
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = []string{WatchPods, WatchDeployments, WatchIngresses}
+	}
+	syncTimeout := opts.SyncTimeout
+	if syncTimeout == 0 {
+		syncTimeout = defaultWatchSyncTimeout
+	}
+
+	selector := ""
+	if len(opts.Labels) > 0 {
+		selector = k8slabels.SelectorFromSet(opts.Labels).String()
+	}
+
+	ctx, stop := context.WithCancel(ctx)
+	w := &Watcher{
+		events:    make(chan WatchEvent, watchBuffer),
+		ctx:       ctx,
+		stop:      stop,
+		kinds:     make(map[string]bool, len(kinds)),
+		factories: make(map[string]informers.SharedInformerFactory, len(opts.Namespaces)),
+	}
+	for _, namespace := range opts.Namespaces {
+		factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+			informers.WithNamespace(namespace),
+			informers.WithTweakListOptions(func(o *metav1.ListOptions) { o.LabelSelector = selector }),
+		)
+		for _, kind := range kinds {
+			var informer cache.SharedIndexInformer
+			switch kind {
+			case WatchPods:
+				informer = factory.Core().V1().Pods().Informer()
+			case WatchDeployments:
+				informer = factory.Apps().V1().Deployments().Informer()
+			case WatchIngresses:
+				informer = factory.Networking().V1().Ingresses().Informer()
+			default:
+				stop()
+				return nil, fmt.Errorf("cannot watch %q: use %s, %s or %s", kind, WatchPods, WatchDeployments, WatchIngresses)
+			}
+			if _, err := informer.AddEventHandler(w.handler()); err != nil {
+				stop()
+				return nil, fmt.Errorf("failed to watch %ss: %w", kind, err)
+			}
+			w.kinds[kind] = true
+		}
+		w.factories[namespace] = factory
+	}
+
+	go func() {
+		<-ctx.Done()
+		for _, factory := range w.factories {
+			factory.Shutdown()
+		}
+		close(w.events)
+	}()
+
+	syncCtx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+	for namespace, factory := range w.factories {
+		factory.Start(ctx.Done())
+		for informerType, synced := range factory.WaitForCacheSync(syncCtx.Done()) {
+			if !synced {
+				stop()
+				return nil, fmt.Errorf("failed to list %s in namespace %s", informerType, namespace)
+			}
+		}
+	}
+	return w, nil
+}
+
+// Events returns the change events. The channel is closed when the watch ends.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// Stop ends the watch
+func (w *Watcher) Stop() {
+	w.stop()
+}
+
+// Pods returns the watched pods of a namespace as currently known, without
+// querying the cluster
+func (w *Watcher) Pods(namespace string) ([]corev1.Pod, error) {
+	factory, ok := w.factories[namespace]
+	if !ok || !w.kinds[WatchPods] {
+		return nil, fmt.Errorf("pods of namespace %s are not watched", namespace)
+	}
+	cached, err := factory.Core().V1().Pods().Lister().Pods(namespace).List(k8slabels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	pods := make([]corev1.Pod, 0, len(cached))
+	for _, pod := range cached {
+		pods = append(pods, *pod)
+	}
+	return pods, nil
+}
+
+// handler turns informer notifications into events
+func (w *Watcher) handler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.send(WatchAdded, obj) },
+		UpdateFunc: func(_, obj interface{}) { w.send(WatchUpdated, obj) },
+		DeleteFunc: func(obj interface{}) {
+			// The final state of objects deleted while the watch was
+			// disconnected is wrapped
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			w.send(WatchDeleted, obj)
+		},
+	}
+}
+
+// send delivers an event unless the watch has ended
+func (w *Watcher) send(eventType string, obj interface{}) {
+	ev := WatchEvent{Type: eventType}
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		ev.Kind, ev.Namespace, ev.Name, ev.Pod = WatchPods, o.Namespace, o.Name, o
+	case *appsv1.Deployment:
+		ev.Kind, ev.Namespace, ev.Name, ev.Deployment = WatchDeployments, o.Namespace, o.Name, o
+	case *networkingv1.Ingress:
+		ev.Kind, ev.Namespace, ev.Name, ev.Ingress = WatchIngresses, o.Namespace, o.Name, o
+	default:
+		return
+	}
+
+	if w.ctx.Err() != nil {
+		return
+	}
+	select {
+	case w.events <- ev:
+	case <-w.ctx.Done():
+	}
+}
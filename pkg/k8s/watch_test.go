@@ -0,0 +1,108 @@
+package k8s_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+)
+
+var _ = Describe("Watcher", func() {
+	var (
+		ctx       context.Context
+		cancel    context.CancelFunc
+		clientset *fake.Clientset
+	)
+
+	pod := func(name, stack string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "dev",
+			Name:      name,
+			Labels:    map[string]string{"lissto.dev/stack": stack},
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(func() { cancel() })
+		clientset = fake.NewSimpleClientset(pod("api-1", "shop"), pod("other-1", "other"))
+	})
+
+	next := func(w *k8s.Watcher) k8s.WatchEvent {
+		var ev k8s.WatchEvent
+		Eventually(w.Events(), 5*time.Second).Should(Receive(&ev))
+		return ev
+	}
+
+	It("should report existing objects, changes and deletions", func() {
+		w, err := k8s.NewWatcher(ctx, clientset, k8s.WatchOptions{
+			Namespaces: []string{"dev"},
+			Labels:     map[string]string{"lissto.dev/stack": "shop"},
+			Kinds:      []string{k8s.WatchPods},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		ev := next(w)
+		Expect(ev.Type).To(Equal(k8s.WatchAdded))
+		Expect(ev.Kind).To(Equal(k8s.WatchPods))
+		Expect(ev.Name).To(Equal("api-1"))
+		Expect(ev.Pod).NotTo(BeNil())
+
+		pods, err := w.Pods("dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pods).To(HaveLen(1))
+
+		updated := pod("api-1", "shop")
+		updated.Status.Phase = corev1.PodRunning
+		_, err = clientset.CoreV1().Pods("dev").Update(ctx, updated, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		ev = next(w)
+		Expect(ev.Type).To(Equal(k8s.WatchUpdated))
+		Expect(ev.Pod.Status.Phase).To(Equal(corev1.PodRunning))
+
+		Expect(clientset.CoreV1().Pods("dev").Delete(ctx, "api-1", metav1.DeleteOptions{})).To(Succeed())
+		ev = next(w)
+		Expect(ev.Type).To(Equal(k8s.WatchDeleted))
+		Expect(ev.Name).To(Equal("api-1"))
+	})
+
+	It("should close the event channel when stopped", func() {
+		w, err := k8s.NewWatcher(ctx, clientset, k8s.WatchOptions{Namespaces: []string{"dev"}})
+		Expect(err).NotTo(HaveOccurred())
+		w.Stop()
+		Eventually(func() bool {
+			for {
+				select {
+				case _, ok := <-w.Events():
+					if !ok {
+						return true
+					}
+				default:
+					return false
+				}
+			}
+		}, 5*time.Second).Should(BeTrue())
+	})
+
+	It("should refuse pods of namespaces it doesn't watch", func() {
+		w, err := k8s.NewWatcher(ctx, clientset, k8s.WatchOptions{Namespaces: []string{"dev"}, Kinds: []string{k8s.WatchDeployments}})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = w.Pods("dev")
+		Expect(err).To(HaveOccurred())
+		_, err = w.Pods("prod")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject unknown kinds and missing namespaces", func() {
+		_, err := k8s.NewWatcher(ctx, clientset, k8s.WatchOptions{Namespaces: []string{"dev"}, Kinds: []string{"Secret"}})
+		Expect(err).To(MatchError(ContainSubstring("cannot watch")))
+		_, err = k8s.NewWatcher(ctx, clientset, k8s.WatchOptions{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,163 @@
+// Package logfilter selects and highlights log lines on the client side
+package logfilter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Level is a log severity detected from a line
+type Level int
+
+const (
+	LevelUnknown Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// levelNames maps --level values and detected words to levels
+var levelNames = map[string]Level{
+	"trace":    LevelDebug,
+	"debug":    LevelDebug,
+	"info":     LevelInfo,
+	"notice":   LevelInfo,
+	"warn":     LevelWarn,
+	"warning":  LevelWarn,
+	"error":    LevelError,
+	"err":      LevelError,
+	"fatal":    LevelError,
+	"panic":    LevelError,
+	"critical": LevelError,
+	"crit":     LevelError,
+}
+
+// levelPatterns detect the level of common log formats, most specific first
+var levelPatterns = []*regexp.Regexp{
+	// JSON: {"level":"error"} / {"severity":"WARNING"} / {"lvl":"warn"}
+	regexp.MustCompile(`(?i)"(?:level|severity|lvl|loglevel)"\s*:\s*"(\w+)"`),
+	// logfmt: level=error / lvl=warn
+	regexp.MustCompile(`(?i)\b(?:level|severity|lvl)=["']?(\w+)`),
+	// Brackets: [ERROR], [warn]
+	regexp.MustCompile(`(?i)\[(trace|debug|info|notice|warn|warning|error|err|fatal|panic|critical|crit)\]`),
+	// Bare upper-case words: "2024-01-01 ERROR something"
+	regexp.MustCompile(`\b(TRACE|DEBUG|INFO|NOTICE|WARN|WARNING|ERROR|FATAL|PANIC|CRITICAL)\b`),
+}
+
+// klogPattern matches klog/glog headers such as "E0102 15:04:05.000000"
+var klogPattern = regexp.MustCompile(`^([IWEF])\d{4} \d{2}:\d{2}:\d{2}`)
+
+var klogLevels = map[string]Level{"I": LevelInfo, "W": LevelWarn, "E": LevelError, "F": LevelError}
+
+// ParseLevel parses a --level value
+func ParseLevel(name string) (Level, error) {
+	level, ok := levelNames[strings.ToLower(name)]
+	if !ok {
+		return LevelUnknown, fmt.Errorf("unknown log level %q (use debug, info, warn or error)", name)
+	}
+	return level, nil
+}
+
+// DetectLevel guesses the level of a log line from common formats
+func DetectLevel(line string) Level {
+	if m := klogPattern.FindStringSubmatch(line); m != nil {
+		return klogLevels[m[1]]
+	}
+	for _, re := range levelPatterns {
+		if m := re.FindStringSubmatch(line); m != nil {
+			if level, ok := levelNames[strings.ToLower(m[1])]; ok {
+				return level
+			}
+		}
+	}
+	return LevelUnknown
+}
+
+// Filter matches log lines against patterns and a minimum level
+type Filter struct {
+	patterns []*regexp.Regexp
+	invert   bool
+	minLevel Level
+}
+
+// New compiles a filter. A line matches if it matches any pattern (or none
+// matches, with invert) and its detected level is at least minLevel. Lines
+// without a recognizable level are hidden when minLevel is set.
+func New(patterns []string, invert bool, minLevel Level) (*Filter, error) {
+	f := &Filter{invert: invert, minLevel: minLevel}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		f.patterns = append(f.patterns, re)
+	}
+	return f, nil
+}
+
+// Active reports whether the filter hides any lines
+func (f *Filter) Active() bool {
+	return len(f.patterns) > 0 || f.minLevel != LevelUnknown
+}
+
+// Match reports whether a line passes the filter
+func (f *Filter) Match(line string) bool {
+	if f.minLevel != LevelUnknown && DetectLevel(line) < f.minLevel {
+		return false
+	}
+	if len(f.patterns) == 0 {
+		return true
+	}
+
+	matched := false
+	for _, re := range f.patterns {
+		if re.MatchString(line) {
+			matched = true
+			break
+		}
+	}
+	return matched != f.invert
+}
+
+// Highlight wraps every pattern match in start/end (e.g. ANSI color codes).
+// Inverted filters have nothing to highlight.
+func (f *Filter) Highlight(line, start, end string) string {
+	if f.invert || len(f.patterns) == 0 {
+		return line
+	}
+
+	// Collect match ranges from all patterns, then merge overlaps
+	var ranges [][]int
+	for _, re := range f.patterns {
+		for _, loc := range re.FindAllStringIndex(line, -1) {
+			if loc[0] < loc[1] {
+				ranges = append(ranges, loc)
+			}
+		}
+	}
+	if len(ranges) == 0 {
+		return line
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+
+	var b strings.Builder
+	pos := 0
+	for i := 0; i < len(ranges); i++ {
+		from, to := ranges[i][0], ranges[i][1]
+		for i+1 < len(ranges) && ranges[i+1][0] <= to {
+			i++
+			to = max(to, ranges[i][1])
+		}
+		from = max(from, pos)
+		b.WriteString(line[pos:from])
+		b.WriteString(start)
+		b.WriteString(line[from:to])
+		b.WriteString(end)
+		pos = to
+	}
+	b.WriteString(line[pos:])
+	return b.String()
+}
@@ -0,0 +1,13 @@
+package logfilter_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLogfilter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Logfilter Suite")
+}
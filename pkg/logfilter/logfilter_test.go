@@ -0,0 +1,65 @@
+package logfilter_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/logfilter"
+)
+
+var _ = Describe("DetectLevel", func() {
+	DescribeTable("should detect levels of common formats",
+		func(line string, expected logfilter.Level) {
+			Expect(logfilter.DetectLevel(line)).To(Equal(expected))
+		},
+		Entry("JSON level", `{"level":"error","msg":"boom"}`, logfilter.LevelError),
+		Entry("JSON severity", `{"severity": "WARNING", "message": "slow"}`, logfilter.LevelWarn),
+		Entry("logfmt", `ts=2024-01-01 level=info msg="started"`, logfilter.LevelInfo),
+		Entry("brackets", `2024-01-01 12:00:00 [warn] disk almost full`, logfilter.LevelWarn),
+		Entry("bare word", `2024-01-01 12:00:00 ERROR connection refused`, logfilter.LevelError),
+		Entry("klog", `E0102 15:04:05.000000    1 controller.go:42] sync failed`, logfilter.LevelError),
+		Entry("unknown", `GET /healthz 200`, logfilter.LevelUnknown),
+		Entry("lower-case word is not a level", `no error here`, logfilter.LevelUnknown),
+	)
+})
+
+var _ = Describe("Filter", func() {
+	It("should match any pattern", func() {
+		f, err := logfilter.New([]string{"timeout", `5\d\d`}, false, logfilter.LevelUnknown)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Match("request timeout")).To(BeTrue())
+		Expect(f.Match("GET / 503")).To(BeTrue())
+		Expect(f.Match("GET / 200")).To(BeFalse())
+	})
+
+	It("should invert matches", func() {
+		f, err := logfilter.New([]string{"healthz"}, true, logfilter.LevelUnknown)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Match("GET /healthz 200")).To(BeFalse())
+		Expect(f.Match("GET /api 200")).To(BeTrue())
+	})
+
+	It("should filter by minimum level", func() {
+		level, err := logfilter.ParseLevel("warn")
+		Expect(err).NotTo(HaveOccurred())
+		f, err := logfilter.New(nil, false, level)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Match(`level=error msg=x`)).To(BeTrue())
+		Expect(f.Match(`level=warning msg=x`)).To(BeTrue())
+		Expect(f.Match(`level=info msg=x`)).To(BeFalse())
+		Expect(f.Match(`no level`)).To(BeFalse())
+	})
+
+	It("should reject invalid patterns and levels", func() {
+		_, err := logfilter.New([]string{"("}, false, logfilter.LevelUnknown)
+		Expect(err).To(MatchError(ContainSubstring("invalid pattern")))
+		_, err = logfilter.ParseLevel("loud")
+		Expect(err).To(MatchError(ContainSubstring("unknown log level")))
+	})
+
+	It("should highlight merged matches", func() {
+		f, err := logfilter.New([]string{"err", "error"}, false, logfilter.LevelUnknown)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Highlight("an error and err", "<", ">")).To(Equal("an <error> and <err>"))
+	})
+})
@@ -0,0 +1,231 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/dump"
+)
+
+// Transports a caller can use
+const (
+	TransportStdio = "stdio"
+	TransportSSE   = "sse"
+	TransportHTTP  = "http"
+)
+
+// Audit outcomes
+const (
+	AuditSuccess = "success"
+	AuditError   = "error"
+	AuditDenied  = "denied"
+)
+
+// secretArgs are tool arguments whose values are all secrets
+var secretArgs = map[string]bool{"secrets": true}
+
+// Caller describes who sent a request
+type Caller struct {
+	Transport string `json:"transport"`
+	// Address is the remote address of HTTP callers
+	Address string `json:"address,omitempty"`
+	// Session is the SSE session ID
+	Session string `json:"session,omitempty"`
+	// Client is the name and version the client announced in initialize, or
+	// the User-Agent of plain HTTP callers
+	Client string `json:"client,omitempty"`
+}
+
+// callerKey stores the Caller of a request in its context
+type callerKey struct{}
+
+// withCaller returns a context carrying the caller of a request
+func withCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// callerFrom returns the caller of a request
+func callerFrom(ctx context.Context) Caller {
+	caller, _ := ctx.Value(callerKey{}).(Caller)
+	return caller
+}
+
+// AuditEntry records one call of a tool that changes something
+type AuditEntry struct {
+	Time      time.Time              `json:"time"`
+	Context   string                 `json:"context,omitempty"`
+	Tool      string                 `json:"tool"`
+	Args      map[string]interface{} `json:"args,omitempty"`
+	Caller    Caller                 `json:"caller"`
+	RequestID interface{}            `json:"request_id,omitempty"`
+	Outcome   string                 `json:"outcome"`
+	Error     string                 `json:"error,omitempty"`
+	// DurationMS is how long the tool ran, in milliseconds
+	DurationMS int64 `json:"duration_ms"`
+}
+
+// AuditLog writes audit entries as JSON lines
+type AuditLog struct {
+	// Context is the lissto context recorded in every entry
+	Context string
+
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewAuditLog writes audit entries to w
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{w: w}
+}
+
+// OpenAuditLog appends audit entries to the file at path
+func OpenAuditLog(path string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit file: %w", err)
+	}
+	return &AuditLog{w: f, closer: f}, nil
+}
+
+// Close closes the audit file
+func (a *AuditLog) Close() error {
+	if a == nil || a.closer == nil {
+		return nil
+	}
+	return a.closer.Close()
+}
+
+// Record writes an entry. Arguments are redacted before they are written.
+func (a *AuditLog) Record(entry AuditEntry) error {
+	if a == nil {
+		return nil
+	}
+	entry.Context = a.Context
+	entry.Args = RedactArgs(entry.Args)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// RedactArgs returns a copy of tool arguments without secret values: values
+// of secret maps and of arguments with sensitive names are replaced, keys are
+// kept
+func RedactArgs(args map[string]interface{}) map[string]interface{} {
+	if args == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(args))
+	for name, value := range args {
+		switch {
+		case secretArgs[name]:
+			redacted[name] = redactAll(value)
+		case dump.IsSensitiveName(name):
+			redacted[name] = dump.Redacted
+		default:
+			if nested, ok := value.(map[string]interface{}); ok {
+				value = RedactArgs(nested)
+			}
+			redacted[name] = value
+		}
+	}
+	return redacted
+}
+
+// redactAll replaces every value of a map, or the value itself
+func redactAll(value interface{}) interface{} {
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return dump.Redacted
+	}
+	redacted := make(map[string]interface{}, len(values))
+	for key := range values {
+		redacted[key] = dump.Redacted
+	}
+	return redacted
+}
+
+// SetAuditLog records every call of a tool that changes something, including
+// calls blocked by the policy
+func (s *Server) SetAuditLog(audit *AuditLog) {
+	s.audit = audit
+}
+
+// audited records a tool call in the audit log, if it changes something
+func (s *Server) audited(ctx context.Context, req *JSONRPCRequest, tool string, args map[string]interface{}, started time.Time, outcome string, err error) {
+	if s.audit == nil || IsReadOnlyTool(tool) {
+		return
+	}
+	caller := callerFrom(ctx)
+	if caller.Client == "" {
+		caller.Client = s.clientName(caller.Session)
+	}
+	entry := AuditEntry{
+		Time:       started.UTC(),
+		Tool:       tool,
+		Args:       args,
+		Caller:     caller,
+		RequestID:  req.ID,
+		Outcome:    outcome,
+		DurationMS: time.Since(started).Milliseconds(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if err := s.audit.Record(entry); err != nil {
+		s.log("Failed to write audit entry: %v", err)
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	}
+}
+
+// rememberClient records the client a session announced in initialize
+func (s *Server) rememberClient(ctx context.Context, params json.RawMessage) {
+	var init struct {
+		ClientInfo struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"clientInfo"`
+	}
+	if json.Unmarshal(params, &init) != nil || init.ClientInfo.Name == "" {
+		return
+	}
+	caller := callerFrom(ctx)
+	if caller.Transport == TransportHTTP {
+		// Plain HTTP is stateless; its callers are named by User-Agent
+		return
+	}
+	name := init.ClientInfo.Name
+	if init.ClientInfo.Version != "" {
+		name += "/" + init.ClientInfo.Version
+	}
+	s.mu.Lock()
+	s.clients[caller.Session] = name
+	s.mu.Unlock()
+}
+
+// clientName returns the client a session announced
+func (s *Server) clientName(session string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clients[session]
+}
+
+// forgetClient drops the client of a closed session
+func (s *Server) forgetClient(session string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clients, session)
+}
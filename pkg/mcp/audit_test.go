@@ -0,0 +1,65 @@
+package mcp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/mcp"
+)
+
+var _ = Describe("Audit log", func() {
+	It("should redact secret values and sensitive arguments", func() {
+		args := map[string]interface{}{
+			"name":    "staging",
+			"secrets": map[string]interface{}{"DB_PASSWORD": "hunter2", "HOST": "db"},
+			"token":   "abc",
+			"data":    map[string]interface{}{"API_KEY": "xyz", "LOG_LEVEL": "debug"},
+		}
+		Expect(mcp.RedactArgs(args)).To(Equal(map[string]interface{}{
+			"name":    "staging",
+			"secrets": map[string]interface{}{"DB_PASSWORD": "[REDACTED]", "HOST": "[REDACTED]"},
+			"token":   "[REDACTED]",
+			"data":    map[string]interface{}{"API_KEY": "[REDACTED]", "LOG_LEVEL": "debug"},
+		}))
+		Expect(args["token"]).To(Equal("abc"), "the arguments must not be modified")
+	})
+
+	It("should record mutating tool calls with the announced client", func() {
+		stdin := &bytes.Buffer{}
+		stdout := &bytes.Buffer{}
+		server, err := mcp.NewServer(stdin, stdout, "")
+		Expect(err).NotTo(HaveOccurred())
+		server.SetPolicy(&mcp.Policy{ReadOnly: true})
+		var audit bytes.Buffer
+		auditLog := mcp.NewAuditLog(&audit)
+		auditLog.Context = "dev"
+		server.SetAuditLog(auditLog)
+
+		// Requests are handled concurrently, so initialize is answered first
+		stdin.WriteString(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"clientInfo":{"name":"cursor","version":"1.2"}}}` + "\n")
+		Expect(server.Run(context.Background())).To(Succeed())
+		stdin.WriteString(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"lissto_secret_set","arguments":{"name":"staging","secrets":{"DB_PASSWORD":"hunter2"}}}}` + "\n")
+		stdin.WriteString(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"lissto_env_current","arguments":{}}}` + "\n")
+		Expect(server.Run(context.Background())).To(Succeed())
+
+		lines := strings.Split(strings.TrimSpace(audit.String()), "\n")
+		Expect(lines).To(HaveLen(1), "read-only tools are not audited")
+		Expect(audit.String()).NotTo(ContainSubstring("hunter2"))
+
+		var entry mcp.AuditEntry
+		Expect(json.Unmarshal([]byte(lines[0]), &entry)).To(Succeed())
+		Expect(entry.Tool).To(Equal("lissto_secret_set"))
+		Expect(entry.Context).To(Equal("dev"))
+		Expect(entry.Outcome).To(Equal(mcp.AuditDenied))
+		Expect(entry.Error).To(ContainSubstring("read-only"))
+		Expect(entry.RequestID).To(BeEquivalentTo(2))
+		Expect(entry.Caller).To(Equal(mcp.Caller{Transport: mcp.TransportStdio, Client: "cursor/1.2"}))
+		Expect(entry.Args).To(HaveKeyWithValue("secrets", map[string]interface{}{"DB_PASSWORD": "[REDACTED]"}))
+		Expect(entry.Time).NotTo(BeZero())
+	})
+})
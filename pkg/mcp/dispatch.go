@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RequestCancelled is the JSON-RPC error code of a request cancelled with
+// $/cancelRequest
+const RequestCancelled = -32800
+
+// DefaultMaxConcurrency is the default number of requests handled at once
+const DefaultMaxConcurrency = 8
+
+// Cancellation methods. MCP clients send notifications/cancelled and expect
+// no response to the cancelled request; LSP-style clients send
+// $/cancelRequest and expect a RequestCancelled error.
+const (
+	methodCancelled     = "notifications/cancelled"
+	methodCancelRequest = "$/cancelRequest"
+)
+
+// inflightRequest is a request being handled, which can be cancelled
+type inflightRequest struct {
+	cancel context.CancelFunc
+	// reply is set when the cancelled request should still get an error
+	// response; unset cancellations drop the response
+	reply     bool
+	cancelled bool
+}
+
+// SetMaxConcurrency limits the number of requests handled at once. It must be
+// called before the server starts serving.
+func (s *Server) SetMaxConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	s.slots = make(chan struct{}, n)
+}
+
+// acquire waits for a free request slot
+func (s *Server) acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a request slot
+func (s *Server) release() {
+	<-s.slots
+}
+
+// dispatch handles a request in its own goroutine and passes the response,
+// if any, to send. Slow requests such as lissto_logs don't hold up others;
+// responses carry their request's ID, so clients match them up regardless of
+// order. scope separates the request IDs of different clients.
+func (s *Server) dispatch(ctx context.Context, scope string, req *JSONRPCRequest, send func(*JSONRPCResponse)) {
+	run := s.track(ctx, scope, req)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if response := run(); response != nil {
+			send(response)
+		}
+	}()
+}
+
+// track registers a request as in flight, so it can be cancelled, and returns
+// the function that handles it. Cancellations are applied right away. The
+// returned function yields nil when there is nothing to send: for
+// notifications, for requests cancelled with notifications/cancelled and when
+// ctx ends before a request slot is free.
+func (s *Server) track(ctx context.Context, scope string, req *JSONRPCRequest) func() *JSONRPCResponse {
+	if req.Method == methodCancelled || req.Method == methodCancelRequest {
+		s.cancelRequest(scope, req)
+		return func() *JSONRPCResponse { return nil }
+	}
+
+	key := requestKey(scope, req.ID)
+	reqCtx, cancel := context.WithCancel(ctx)
+	inflight := &inflightRequest{cancel: cancel}
+
+	if req.ID != nil {
+		s.mu.Lock()
+		if _, ok := s.inflight[key]; ok {
+			s.mu.Unlock()
+			cancel()
+			response := errorResponse(req.ID, InvalidRequest, fmt.Sprintf("Request ID %v is already in use", req.ID))
+			return func() *JSONRPCResponse { return response }
+		}
+		s.inflight[key] = inflight
+		s.mu.Unlock()
+	}
+
+	return func() *JSONRPCResponse {
+		defer cancel()
+
+		var response *JSONRPCResponse
+		if err := s.acquire(reqCtx); err == nil {
+			response = s.Handle(reqCtx, req)
+			s.release()
+		}
+
+		s.mu.Lock()
+		if req.ID != nil {
+			delete(s.inflight, key)
+		}
+		cancelled, reply := inflight.cancelled, inflight.reply
+		s.mu.Unlock()
+
+		if cancelled {
+			s.log("Request %v was cancelled", req.ID)
+			if !reply {
+				return nil
+			}
+			response = errorResponse(req.ID, RequestCancelled, "Request cancelled")
+		}
+		return response
+	}
+}
+
+// cancelRequest cancels the in-flight request named by a cancellation
+// notification. Unknown or finished requests are ignored.
+func (s *Server) cancelRequest(scope string, req *JSONRPCRequest) {
+	var params struct {
+		// RequestID is used by notifications/cancelled, ID by $/cancelRequest
+		RequestID interface{} `json:"requestId"`
+		ID        interface{} `json:"id"`
+		Reason    string      `json:"reason"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		s.log("Invalid cancel params: %v", err)
+		return
+	}
+	id := params.RequestID
+	if id == nil {
+		id = params.ID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inflight, ok := s.inflight[requestKey(scope, id)]
+	if !ok {
+		s.log("Cancel for unknown request %v ignored", id)
+		return
+	}
+	s.log("Cancelling request %v (%s)", id, params.Reason)
+	inflight.cancelled = true
+	inflight.reply = req.Method == methodCancelRequest
+	inflight.cancel()
+}
+
+// requestKey identifies a request ID within a scope. IDs are compared by
+// value, so 1 and "1" are distinct.
+func requestKey(scope string, id interface{}) string {
+	return fmt.Sprintf("%s/%T/%v", scope, id, id)
+}
@@ -0,0 +1,129 @@
+package mcp_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/mcp"
+)
+
+var _ = Describe("Concurrent requests", func() {
+	var (
+		stdin     *io.PipeWriter
+		responses chan mcp.JSONRPCResponse
+		server    *mcp.Server
+		done      chan error
+		running   chan struct{}
+	)
+
+	send := func(request string) {
+		_, err := stdin.Write([]byte(request + "\n"))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	start := func(maxConcurrency int) {
+		inR, inW := io.Pipe()
+		outR, outW := io.Pipe()
+		stdin = inW
+		responses = make(chan mcp.JSONRPCResponse, 10)
+
+		var err error
+		server, err = mcp.NewServer(inR, outW, "")
+		Expect(err).NotTo(HaveOccurred())
+		// Mutating tool calls wait until they're cancelled
+		running = make(chan struct{}, 10)
+		server.SetPolicy(&mcp.Policy{Confirm: func(ctx context.Context, _ string, _ map[string]interface{}) (bool, error) {
+			running <- struct{}{}
+			<-ctx.Done()
+			return false, ctx.Err()
+		}})
+		server.SetMaxConcurrency(maxConcurrency)
+
+		go func() {
+			scanner := bufio.NewScanner(outR)
+			for scanner.Scan() {
+				var response mcp.JSONRPCResponse
+				if json.Unmarshal(scanner.Bytes(), &response) == nil {
+					responses <- response
+				}
+			}
+		}()
+
+		done = make(chan error, 1)
+		go func() {
+			done <- server.Run(context.Background())
+			_ = outW.Close()
+		}()
+	}
+
+	const slowCall = `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"lissto_stack_delete","arguments":{}}}`
+	const toolsList = `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`
+
+	AfterEach(func() {
+		_ = stdin.Close()
+		Eventually(done).Should(Receive())
+		_ = server.Close()
+	})
+
+	It("answers fast requests while a slow one is running", func() {
+		start(4)
+		send(slowCall)
+		send(toolsList)
+
+		var response mcp.JSONRPCResponse
+		Eventually(responses).Should(Receive(&response))
+		Expect(response.ID).To(BeNumerically("==", 2))
+
+		send(`{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":1}}`)
+		Eventually(responses).Should(Receive(&response))
+		Expect(response.ID).To(BeNumerically("==", 1))
+		Expect(response.Error.Code).To(Equal(mcp.RequestCancelled))
+	})
+
+	It("drops the response of requests cancelled with notifications/cancelled", func() {
+		start(4)
+		send(slowCall)
+		send(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1,"reason":"user"}}`)
+		send(toolsList)
+
+		var response mcp.JSONRPCResponse
+		Eventually(responses).Should(Receive(&response))
+		Expect(response.ID).To(BeNumerically("==", 2))
+		Consistently(responses, "200ms").ShouldNot(Receive())
+	})
+
+	It("limits the number of requests handled at once", func() {
+		start(1)
+		send(slowCall)
+		Eventually(running).Should(Receive())
+		send(toolsList)
+		Consistently(responses, "200ms").ShouldNot(Receive())
+
+		send(`{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":1}}`)
+		var ids []float64
+		for range 2 {
+			var response mcp.JSONRPCResponse
+			Eventually(responses).Should(Receive(&response))
+			ids = append(ids, response.ID.(float64))
+		}
+		Expect(ids).To(ConsistOf(1.0, 2.0))
+	})
+
+	It("rejects a request ID that is already in flight", func() {
+		start(4)
+		send(slowCall)
+		send(slowCall)
+
+		var response mcp.JSONRPCResponse
+		Eventually(responses).Should(Receive(&response))
+		Expect(response.Error.Code).To(Equal(mcp.InvalidRequest))
+
+		send(`{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":1}}`)
+		Eventually(responses).Should(Receive())
+	})
+})
@@ -1,14 +1,16 @@
 package mcp
 
 import (
+	"bufio"
 	"context"
 	"fmt"
-	"io"
 	"strings"
+	"time"
 
 	"github.com/lissto-dev/cli/pkg/client"
 	"github.com/lissto-dev/cli/pkg/config"
 	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/messages"
 	"github.com/lissto-dev/cli/pkg/status"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -25,71 +27,79 @@ type Logger interface {
 }
 
 // ExecuteTool executes a tool with the given arguments
-func ExecuteTool(name string, args map[string]interface{}, logger Logger) (interface{}, error) {
+func ExecuteTool(ctx context.Context, name string, args map[string]interface{}, logger Logger) (interface{}, error) {
 	switch name {
 	// Environment tools
 	case "lissto_env_list":
-		return handleEnvList(args, logger)
+		return handleEnvList(ctx, args, logger)
 	case "lissto_env_get":
-		return handleEnvGet(args, logger)
+		return handleEnvGet(ctx, args, logger)
 	case "lissto_env_create":
-		return handleEnvCreate(args, logger)
+		return handleEnvCreate(ctx, args, logger)
 	case "lissto_env_current":
-		return handleEnvCurrent(args, logger)
+		return handleEnvCurrent(ctx, args, logger)
 
 	// Blueprint tools
 	case "lissto_blueprint_list":
-		return handleBlueprintList(args, logger)
+		return handleBlueprintList(ctx, args, logger)
 	case "lissto_blueprint_get":
-		return handleBlueprintGet(args, logger)
+		return handleBlueprintGet(ctx, args, logger)
 	case "lissto_blueprint_create":
-		return handleBlueprintCreate(args, logger)
+		return handleBlueprintCreate(ctx, args, logger)
 	case "lissto_blueprint_delete":
-		return handleBlueprintDelete(args, logger)
+		return handleBlueprintDelete(ctx, args, logger)
 
 	// Stack tools
 	case "lissto_stack_list":
-		return handleStackList(args, logger)
+		return handleStackList(ctx, args, logger)
 	case "lissto_stack_get":
-		return handleStackGet(args, logger)
+		return handleStackGet(ctx, args, logger)
 	case "lissto_stack_create":
-		return handleStackCreate(args, logger)
+		return handleStackCreate(ctx, args, logger)
 	case "lissto_stack_delete":
-		return handleStackDelete(args, logger)
+		return handleStackDelete(ctx, args, logger)
 
 	// Admin tools
 	case "lissto_admin_apikey_create":
-		return handleAdminAPIKeyCreate(args, logger)
+		return handleAdminAPIKeyCreate(ctx, args, logger)
+	case "lissto_admin_apikey_list":
+		return handleAdminAPIKeyList(ctx, args, logger)
+	case "lissto_admin_apikey_revoke":
+		return handleAdminAPIKeyRevoke(ctx, args, logger)
+	case "lissto_admin_apikey_rotate":
+		return handleAdminAPIKeyRotate(ctx, args, logger)
+	case "lissto_admin_blueprint_delete":
+		return handleAdminBlueprintDelete(ctx, args, logger)
 
 	// Variable tools
 	case "lissto_variable_list":
-		return handleVariableList(args, logger)
+		return handleVariableList(ctx, args, logger)
 	case "lissto_variable_get":
-		return handleVariableGet(args, logger)
+		return handleVariableGet(ctx, args, logger)
 	case "lissto_variable_create":
-		return handleVariableCreate(args, logger)
+		return handleVariableCreate(ctx, args, logger)
 	case "lissto_variable_update":
-		return handleVariableUpdate(args, logger)
+		return handleVariableUpdate(ctx, args, logger)
 	case "lissto_variable_delete":
-		return handleVariableDelete(args, logger)
+		return handleVariableDelete(ctx, args, logger)
 
 	// Secret tools
 	case "lissto_secret_list":
-		return handleSecretList(args, logger)
+		return handleSecretList(ctx, args, logger)
 	case "lissto_secret_get":
-		return handleSecretGet(args, logger)
+		return handleSecretGet(ctx, args, logger)
 	case "lissto_secret_create":
-		return handleSecretCreate(args, logger)
+		return handleSecretCreate(ctx, args, logger)
 	case "lissto_secret_set":
-		return handleSecretSet(args, logger)
+		return handleSecretSet(ctx, args, logger)
 	case "lissto_secret_delete":
-		return handleSecretDelete(args, logger)
+		return handleSecretDelete(ctx, args, logger)
 
 	// Status and logs tools
 	case "lissto_status":
-		return handleStatus(args, logger)
+		return handleStatus(ctx, args, logger)
 	case "lissto_logs":
-		return handleLogs(args, logger)
+		return handleLogs(ctx, args, logger)
 
 	default:
 		return nil, fmt.Errorf("unknown tool: %s", name)
@@ -97,19 +107,19 @@ func ExecuteTool(name string, args map[string]interface{}, logger Logger) (inter
 }
 
 // Helper to get API client from current context
-func getAPIClient() (*client.Client, error) {
+func getAPIClient(ctx context.Context) (*client.Client, error) {
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	ctx, err := cfg.GetCurrentContext()
+	lisstoCtx, err := cfg.GetCurrentContext()
 	if err != nil {
-		return nil, fmt.Errorf("no active context. Run 'lissto login' first: %w", err)
+		return nil, messages.Wrap(messages.NoActiveContext, nil, err)
 	}
 
 	// Create API client with k8s discovery and validation
-	apiClient, err := client.NewClientFromConfig(ctx)
+	apiClient, err := client.NewClientFromConfig(ctx, lisstoCtx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize API client: %w", err)
 	}
@@ -140,17 +150,55 @@ func getInt(args map[string]interface{}, key string, defaultVal int) int {
 	return defaultVal
 }
 
+// Helper to get bool from args
+func getBool(args map[string]interface{}, key string, defaultVal bool) bool {
+	if val, ok := args[key].(bool); ok {
+		return val
+	}
+	return defaultVal
+}
+
+// Helper to get the optional scope, env and repository args that select a
+// variable or secret config outside the default env scope
+func getScopeArgs(args map[string]interface{}) (scope, env, repository string) {
+	return getString(args, "scope", ""), getString(args, "env", ""), getString(args, "repository", "")
+}
+
+// redactSecret returns the fields of a secret config that are safe to hand to
+// an AI client: metadata and key names, never values
+func redactSecret(secret *client.SecretResponse) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":    secret.ID,
+		"name":  secret.Name,
+		"scope": secret.Scope,
+		"keys":  secret.Keys,
+	}
+	if secret.Env != "" {
+		result["env"] = secret.Env
+	}
+	if secret.Repository != "" {
+		result["repository"] = secret.Repository
+	}
+	if secret.CreatedAt != "" {
+		result["created_at"] = secret.CreatedAt
+	}
+	if len(secret.KeyUpdatedAt) > 0 {
+		result["key_updated_at"] = secret.KeyUpdatedAt
+	}
+	return result
+}
+
 // Environment handlers
-func handleEnvList(_ map[string]interface{}, logger Logger) (interface{}, error) {
+func handleEnvList(ctx context.Context, _ map[string]interface{}, logger Logger) (interface{}, error) {
 	logger.log("→ handleEnvList: Getting API client")
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		logger.log("→ handleEnvList: Failed to get API client: %v", err)
 		return nil, err
 	}
 
-	logger.log("→ handleEnvList: Calling apiClient.ListEnvs()")
-	envs, err := apiClient.ListEnvs()
+	logger.log("→ handleEnvList: Calling apiClient.ListEnvs(ctx)")
+	envs, err := apiClient.ListEnvs(ctx)
 	if err != nil {
 		logger.log("→ handleEnvList: API call failed: %v", err)
 		return nil, fmt.Errorf("failed to list environments: %w", err)
@@ -164,19 +212,19 @@ func handleEnvList(_ map[string]interface{}, logger Logger) (interface{}, error)
 	return result, nil
 }
 
-func handleEnvGet(args map[string]interface{}, logger Logger) (interface{}, error) {
+func handleEnvGet(ctx context.Context, args map[string]interface{}, logger Logger) (interface{}, error) {
 	logger.log("→ handleEnvGet: args=%+v", args)
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	env, err := apiClient.GetEnv(name)
+	env, err := apiClient.GetEnv(ctx, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get environment: %w", err)
 	}
@@ -184,19 +232,19 @@ func handleEnvGet(args map[string]interface{}, logger Logger) (interface{}, erro
 	return env, nil
 }
 
-func handleEnvCreate(args map[string]interface{}, logger Logger) (interface{}, error) {
+func handleEnvCreate(ctx context.Context, args map[string]interface{}, logger Logger) (interface{}, error) {
 	logger.log("→ handleEnvCreate: args=%+v", args)
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	identifier, err := apiClient.CreateEnv(name)
+	identifier, err := apiClient.CreateEnv(ctx, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create environment: %w", err)
 	}
@@ -207,7 +255,7 @@ func handleEnvCreate(args map[string]interface{}, logger Logger) (interface{}, e
 	}, nil
 }
 
-func handleEnvCurrent(args map[string]interface{}, logger Logger) (interface{}, error) {
+func handleEnvCurrent(ctx context.Context, args map[string]interface{}, logger Logger) (interface{}, error) {
 	logger.log("→ handleEnvCurrent: args=%+v", args)
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -216,23 +264,23 @@ func handleEnvCurrent(args map[string]interface{}, logger Logger) (interface{},
 
 	return map[string]interface{}{
 		"current_env": cfg.CurrentEnv,
-		"context":     cfg.CurrentContext,
+		"context":     cfg.ActiveContextName(),
 	}, nil
 }
 
 // Blueprint handlers
-func handleBlueprintList(_ map[string]interface{}, logger Logger) (interface{}, error) {
+func handleBlueprintList(ctx context.Context, _ map[string]interface{}, logger Logger) (interface{}, error) {
 	// Always include global blueprints (scope determined by the api, not flag)
 	logger.log("→ handleBlueprintList: Listing all blueprints (user + global)")
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		logger.log("→ handleBlueprintList: Failed to get API client: %v", err)
 		return nil, err
 	}
 
-	logger.log("→ handleBlueprintList: Calling apiClient.ListBlueprints()")
-	blueprints, err := apiClient.ListBlueprints(true)
+	logger.log("→ handleBlueprintList: Calling apiClient.ListBlueprints(ctx)")
+	blueprints, err := apiClient.ListBlueprints(ctx, true)
 	if err != nil {
 		logger.log("→ handleBlueprintList: API call failed: %v", err)
 		return nil, fmt.Errorf("failed to list blueprints: %w", err)
@@ -245,18 +293,18 @@ func handleBlueprintList(_ map[string]interface{}, logger Logger) (interface{},
 	}, nil
 }
 
-func handleBlueprintGet(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleBlueprintGet(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	blueprint, err := apiClient.GetBlueprint(name)
+	blueprint, err := apiClient.GetBlueprint(ctx, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get blueprint: %w", err)
 	}
@@ -264,13 +312,13 @@ func handleBlueprintGet(args map[string]interface{}, _ Logger) (interface{}, err
 	return blueprint, nil
 }
 
-func handleBlueprintCreate(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleBlueprintCreate(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	compose := getString(args, "compose", "")
 	if compose == "" {
 		return nil, fmt.Errorf("compose is required")
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -282,7 +330,7 @@ func handleBlueprintCreate(args map[string]interface{}, _ Logger) (interface{},
 		Repository: getString(args, "repository", ""),
 	}
 
-	identifier, err := apiClient.CreateBlueprint(req)
+	identifier, err := apiClient.CreateBlueprint(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create blueprint: %w", err)
 	}
@@ -293,18 +341,18 @@ func handleBlueprintCreate(args map[string]interface{}, _ Logger) (interface{},
 	}, nil
 }
 
-func handleBlueprintDelete(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleBlueprintDelete(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := apiClient.DeleteBlueprint(name); err != nil {
+	if err := apiClient.DeleteBlueprint(ctx, name); err != nil {
 		return nil, fmt.Errorf("failed to delete blueprint: %w", err)
 	}
 
@@ -314,18 +362,18 @@ func handleBlueprintDelete(args map[string]interface{}, _ Logger) (interface{},
 }
 
 // Stack handlers
-func handleStackList(args map[string]interface{}, logger Logger) (interface{}, error) {
+func handleStackList(ctx context.Context, args map[string]interface{}, logger Logger) (interface{}, error) {
 	env := getString(args, "env", "")
 	logger.log("→ handleStackList: env=%v", env)
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		logger.log("→ handleStackList: Failed to get API client: %v", err)
 		return nil, err
 	}
 
-	logger.log("→ handleStackList: Calling apiClient.ListStacks()")
-	stacks, err := apiClient.ListStacks(env)
+	logger.log("→ handleStackList: Calling apiClient.ListStacks(ctx)")
+	stacks, err := apiClient.ListStacks(ctx, env)
 	if err != nil {
 		logger.log("→ handleStackList: API call failed: %v", err)
 		return nil, fmt.Errorf("failed to list stacks: %w", err)
@@ -338,7 +386,7 @@ func handleStackList(args map[string]interface{}, logger Logger) (interface{}, e
 	}, nil
 }
 
-func handleStackGet(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleStackGet(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
@@ -346,12 +394,12 @@ func handleStackGet(args map[string]interface{}, _ Logger) (interface{}, error)
 
 	env := getString(args, "env", "")
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	identifier, err := apiClient.GetStack(name, env)
+	identifier, err := apiClient.GetStack(ctx, name, env)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stack: %w", err)
 	}
@@ -361,7 +409,7 @@ func handleStackGet(args map[string]interface{}, _ Logger) (interface{}, error)
 	}, nil
 }
 
-func handleStackCreate(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleStackCreate(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	blueprintName := getString(args, "blueprint_name", "")
 	if blueprintName == "" {
 		return nil, fmt.Errorf("blueprint_name is required")
@@ -369,13 +417,13 @@ func handleStackCreate(args map[string]interface{}, _ Logger) (interface{}, erro
 
 	env := getString(args, "env", "")
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// First prepare the stack to get request_id
-	prepareResp, err := apiClient.PrepareStack(blueprintName, env, "", "", "", false)
+	prepareResp, err := apiClient.PrepareStack(ctx, blueprintName, env, "", "", "", false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare stack: %w", err)
 	}
@@ -388,7 +436,7 @@ func handleStackCreate(args map[string]interface{}, _ Logger) (interface{}, erro
 	}
 
 	// Create stack with request_id
-	identifier, err := apiClient.CreateStack(blueprintName, env, prepareResp.RequestID)
+	identifier, err := apiClient.CreateStack(ctx, blueprintName, env, prepareResp.RequestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stack: %w", err)
 	}
@@ -399,7 +447,7 @@ func handleStackCreate(args map[string]interface{}, _ Logger) (interface{}, erro
 	}, nil
 }
 
-func handleStackDelete(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleStackDelete(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
@@ -407,12 +455,12 @@ func handleStackDelete(args map[string]interface{}, _ Logger) (interface{}, erro
 
 	env := getString(args, "env", "")
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := apiClient.DeleteStack(name, env); err != nil {
+	if err := apiClient.DeleteStack(ctx, name, env); err != nil {
 		return nil, fmt.Errorf("failed to delete stack: %w", err)
 	}
 
@@ -422,7 +470,7 @@ func handleStackDelete(args map[string]interface{}, _ Logger) (interface{}, erro
 }
 
 // Admin handlers
-func handleAdminAPIKeyCreate(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleAdminAPIKeyCreate(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
@@ -430,7 +478,7 @@ func handleAdminAPIKeyCreate(args map[string]interface{}, _ Logger) (interface{}
 
 	role := getString(args, "role", "user")
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -440,7 +488,7 @@ func handleAdminAPIKeyCreate(args map[string]interface{}, _ Logger) (interface{}
 		Role: role,
 	}
 
-	result, err := apiClient.CreateAPIKey(req)
+	result, err := apiClient.CreateAPIKey(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API key: %w", err)
 	}
@@ -453,15 +501,96 @@ func handleAdminAPIKeyCreate(args map[string]interface{}, _ Logger) (interface{}
 	}, nil
 }
 
+func handleAdminAPIKeyList(ctx context.Context, _ map[string]interface{}, _ Logger) (interface{}, error) {
+	apiClient, err := getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := apiClient.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"api_keys": keys,
+		"count":    len(keys),
+	}, nil
+}
+
+func handleAdminAPIKeyRevoke(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
+	name := getString(args, "name", "")
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	apiClient, err := getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apiClient.RevokeAPIKey(ctx, name); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"message": fmt.Sprintf("API key '%s' revoked successfully", name),
+	}, nil
+}
+
+func handleAdminAPIKeyRotate(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
+	name := getString(args, "name", "")
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	apiClient, err := getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := apiClient.RotateAPIKey(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"api_key": result.APIKey,
+		"name":    result.Name,
+		"role":    result.Role,
+		"message": "API key rotated successfully. The previous key no longer works. IMPORTANT: Save this key securely, it cannot be retrieved later.",
+	}, nil
+}
+
+func handleAdminBlueprintDelete(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
+	name := getString(args, "name", "")
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	apiClient, err := getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apiClient.DeleteBlueprint(ctx, name); err != nil {
+		return nil, fmt.Errorf("failed to delete blueprint: %w", err)
+	}
+
+	return map[string]interface{}{
+		"message": fmt.Sprintf("Blueprint '%s' deleted successfully", name),
+	}, nil
+}
+
 // Variable handlers
-func handleVariableList(_ map[string]interface{}, logger Logger) (interface{}, error) {
+func handleVariableList(ctx context.Context, _ map[string]interface{}, logger Logger) (interface{}, error) {
 	logger.log("→ handleVariableList: Getting API client")
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	variables, err := apiClient.ListVariables()
+	variables, err := apiClient.ListVariables(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list variables: %w", err)
 	}
@@ -472,19 +601,20 @@ func handleVariableList(_ map[string]interface{}, logger Logger) (interface{}, e
 	}, nil
 }
 
-func handleVariableGet(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleVariableGet(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Default to env scope (API default)
-	variable, err := apiClient.GetVariable(name, "", "", "")
+	scope, env, repository := getScopeArgs(args)
+	variable, err := apiClient.GetVariable(ctx, name, scope, env, repository)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get variable: %w", err)
 	}
@@ -492,7 +622,7 @@ func handleVariableGet(args map[string]interface{}, _ Logger) (interface{}, erro
 	return variable, nil
 }
 
-func handleVariableCreate(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleVariableCreate(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	scope := getString(args, "scope", "env")
 	env := getString(args, "env", "")
 	repository := getString(args, "repository", "")
@@ -504,7 +634,7 @@ func handleVariableCreate(args map[string]interface{}, _ Logger) (interface{}, e
 			env = cfg.CurrentEnv
 		}
 		if env == "" {
-			return nil, fmt.Errorf("env is required for scope=env. Set with --env or run 'lissto env use <env>'")
+			return nil, messages.Error(messages.NoEnvForScope, nil)
 		}
 	}
 
@@ -520,7 +650,7 @@ func handleVariableCreate(args map[string]interface{}, _ Logger) (interface{}, e
 		}
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -537,13 +667,13 @@ func handleVariableCreate(args map[string]interface{}, _ Logger) (interface{}, e
 		Data:       data,
 	}
 
-	variable, err := apiClient.CreateVariable(req)
+	variable, err := apiClient.CreateVariable(ctx, req)
 	if err != nil {
 		// Check if it's a conflict error - try to merge
 		errStr := err.Error()
 		if strings.Contains(errStr, "409") || strings.Contains(strings.ToLower(errStr), "already exists") {
 			// Get existing variable (pass scope for correct namespace resolution)
-			existing, err := apiClient.GetVariable(name, scope, env, repository)
+			existing, err := apiClient.GetVariable(ctx, name, scope, env, repository)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get existing variable: %w", err)
 			}
@@ -573,7 +703,7 @@ func handleVariableCreate(args map[string]interface{}, _ Logger) (interface{}, e
 			updateReq := &client.UpdateVariableRequest{
 				Data: mergedData,
 			}
-			variable, err = apiClient.UpdateVariable(name, scope, env, repository, updateReq)
+			variable, err = apiClient.UpdateVariable(ctx, name, scope, env, repository, updateReq)
 			if err != nil {
 				return nil, fmt.Errorf("failed to merge variable: %w", err)
 			}
@@ -609,7 +739,7 @@ func generateVariableNameMCP(scope, env, repository string) string {
 	}
 }
 
-func handleVariableUpdate(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleVariableUpdate(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
@@ -627,7 +757,7 @@ func handleVariableUpdate(args map[string]interface{}, _ Logger) (interface{}, e
 		}
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -636,8 +766,9 @@ func handleVariableUpdate(args map[string]interface{}, _ Logger) (interface{}, e
 		Data: data,
 	}
 
-	// Use default scope (env)
-	variable, err := apiClient.UpdateVariable(name, "", "", "", req)
+	// Default to env scope (API default)
+	scope, env, repository := getScopeArgs(args)
+	variable, err := apiClient.UpdateVariable(ctx, name, scope, env, repository, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update variable: %w", err)
 	}
@@ -648,19 +779,20 @@ func handleVariableUpdate(args map[string]interface{}, _ Logger) (interface{}, e
 	}, nil
 }
 
-func handleVariableDelete(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleVariableDelete(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Use default scope (env)
-	if err := apiClient.DeleteVariable(name, "", "", ""); err != nil {
+	// Default to env scope (API default)
+	scope, env, repository := getScopeArgs(args)
+	if err := apiClient.DeleteVariable(ctx, name, scope, env, repository); err != nil {
 		return nil, fmt.Errorf("failed to delete variable: %w", err)
 	}
 
@@ -670,45 +802,51 @@ func handleVariableDelete(args map[string]interface{}, _ Logger) (interface{}, e
 }
 
 // Secret handlers
-func handleSecretList(_ map[string]interface{}, logger Logger) (interface{}, error) {
+func handleSecretList(ctx context.Context, _ map[string]interface{}, logger Logger) (interface{}, error) {
 	logger.log("→ handleSecretList: Getting API client")
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	secrets, err := apiClient.ListSecrets()
+	secrets, err := apiClient.ListSecrets(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list secrets: %w", err)
 	}
 
+	redacted := make([]map[string]interface{}, 0, len(secrets))
+	for i := range secrets {
+		redacted = append(redacted, redactSecret(&secrets[i]))
+	}
+
 	return map[string]interface{}{
-		"secrets": secrets,
-		"count":   len(secrets),
+		"secrets": redacted,
+		"count":   len(redacted),
 	}, nil
 }
 
-func handleSecretGet(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleSecretGet(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Default to env scope (API default)
-	secret, err := apiClient.GetSecret(name, "", "", "")
+	scope, env, repository := getScopeArgs(args)
+	secret, err := apiClient.GetSecret(ctx, name, scope, env, repository)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get secret: %w", err)
 	}
 
-	return secret, nil
+	return redactSecret(secret), nil
 }
 
-func handleSecretCreate(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleSecretCreate(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	scope := getString(args, "scope", "env")
 	env := getString(args, "env", "")
 	repository := getString(args, "repository", "")
@@ -720,7 +858,7 @@ func handleSecretCreate(args map[string]interface{}, _ Logger) (interface{}, err
 			env = cfg.CurrentEnv
 		}
 		if env == "" {
-			return nil, fmt.Errorf("env is required for scope=env. Set with --env or run 'lissto env use <env>'")
+			return nil, messages.Error(messages.NoEnvForScope, nil)
 		}
 	}
 
@@ -736,7 +874,7 @@ func handleSecretCreate(args map[string]interface{}, _ Logger) (interface{}, err
 		}
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -753,13 +891,13 @@ func handleSecretCreate(args map[string]interface{}, _ Logger) (interface{}, err
 		Secrets:    secrets,
 	}
 
-	secret, err := apiClient.CreateSecret(req)
+	secret, err := apiClient.CreateSecret(ctx, req)
 	if err != nil {
 		// Check if it's a conflict error - DO NOT auto-update (irreversible)
 		errStr := err.Error()
 		if strings.Contains(errStr, "409") || strings.Contains(strings.ToLower(errStr), "already exists") {
 			// Get existing secret to show what exists (pass scope for correct namespace resolution)
-			existing, getErr := apiClient.GetSecret(name, scope, env, repository)
+			existing, getErr := apiClient.GetSecret(ctx, name, scope, env, repository)
 			if getErr != nil {
 				return nil, fmt.Errorf("secret '%s' already exists but failed to retrieve details: %w", name, getErr)
 			}
@@ -772,7 +910,7 @@ func handleSecretCreate(args map[string]interface{}, _ Logger) (interface{}, err
 	}
 
 	return map[string]interface{}{
-		"secret":  secret,
+		"secret":  redactSecret(secret),
 		"message": fmt.Sprintf("Secret '%s' created successfully", secret.Name),
 	}, nil
 }
@@ -794,7 +932,7 @@ func generateSecretNameMCP(scope, env, repository string) string {
 	}
 }
 
-func handleSecretSet(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleSecretSet(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
@@ -812,7 +950,7 @@ func handleSecretSet(args map[string]interface{}, _ Logger) (interface{}, error)
 		}
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -821,31 +959,33 @@ func handleSecretSet(args map[string]interface{}, _ Logger) (interface{}, error)
 		Secrets: secrets,
 	}
 
-	// Use default scope (env)
-	secret, err := apiClient.UpdateSecret(name, "", "", "", req)
+	// Default to env scope (API default)
+	scope, env, repository := getScopeArgs(args)
+	secret, err := apiClient.UpdateSecret(ctx, name, scope, env, repository, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to set secrets: %w", err)
 	}
 
 	return map[string]interface{}{
-		"secret":  secret,
+		"secret":  redactSecret(secret),
 		"message": fmt.Sprintf("Secret '%s' updated successfully", name),
 	}, nil
 }
 
-func handleSecretDelete(args map[string]interface{}, _ Logger) (interface{}, error) {
+func handleSecretDelete(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	name := getString(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Use default scope (env)
-	if err := apiClient.DeleteSecret(name, "", "", ""); err != nil {
+	// Default to env scope (API default)
+	scope, env, repository := getScopeArgs(args)
+	if err := apiClient.DeleteSecret(ctx, name, scope, env, repository); err != nil {
 		return nil, fmt.Errorf("failed to delete secret: %w", err)
 	}
 
@@ -854,43 +994,42 @@ func handleSecretDelete(args map[string]interface{}, _ Logger) (interface{}, err
 	}, nil
 }
 
-// Status handler
-func handleStatus(args map[string]interface{}, _ Logger) (interface{}, error) {
+// Status handler. Stacks are paged (limit, offset) so large installations
+// answer quickly; summary_only skips the cluster and reports stack state only.
+func handleStatus(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	envFilter := getString(args, "env", "")
+	stackFilter := getString(args, "stack", "")
+	summaryOnly := getBool(args, "summary_only", false)
 
-	apiClient, err := getAPIClient()
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// List all stacks
-	stacks, err := apiClient.ListStacks("")
+	stacks, err := apiClient.ListStacks(ctx, envFilter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list stacks: %w", err)
 	}
 
-	if len(stacks) == 0 {
+	page := PageStacks(stacks, stackFilter, getInt(args, "offset", 0), getInt(args, "limit", defaultStatusLimit))
+	if page.Total == 0 {
 		return map[string]interface{}{
 			"stacks":  []interface{}{},
 			"message": "No stacks found",
 		}, nil
 	}
 
-	// Initialize K8s client
-	k8sClient, err := k8s.NewClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create k8s client: %w", err)
+	var k8sClient *k8s.Client
+	if !summaryOnly {
+		if k8sClient, err = k8s.NewClient(); err != nil {
+			return nil, fmt.Errorf("failed to create k8s client: %w", err)
+		}
 	}
 
-	// Collect status for each stack
-	stackStatuses := make([]map[string]interface{}, 0, len(stacks))
-
-	for _, stack := range stacks {
-		// Filter by environment if specified
-		if envFilter != "" && stack.Namespace != envFilter {
-			continue
-		}
+	// Collect status for each stack of the page
+	stackStatuses := make([]map[string]interface{}, 0, len(page.Stacks))
 
+	for _, stack := range page.Stacks {
 		// Parse stack status from conditions
 		stackStatusParsed := status.ParseStackStatus(stack.Status.Conditions)
 
@@ -902,51 +1041,71 @@ func handleStatus(args map[string]interface{}, _ Logger) (interface{}, error) {
 			"reason":    stackStatusParsed.Reason,
 		}
 
-		// Get pods for this stack using label selector
-		labels := map[string]string{
-			"lissto.dev/stack": stack.Name,
-		}
-		pods, err := k8sClient.ListPods(context.Background(), stack.Namespace, labels)
-		if err == nil {
-			podStatuses := []map[string]interface{}{}
-			for _, pod := range pods {
-				podStatus := map[string]interface{}{
-					"name":   pod.Name,
-					"phase":  string(pod.Status.Phase),
-					"ready":  isPodReady(&pod),
-					"reason": getPodReason(&pod),
+		if k8sClient != nil {
+			// Get pods for this stack using label selector
+			labels := map[string]string{
+				"lissto.dev/stack": stack.Name,
+			}
+			pods, err := k8sClient.ListPods(ctx, stack.Namespace, labels)
+			if err == nil {
+				podStatuses := []map[string]interface{}{}
+				for _, pod := range pods {
+					podStatus := map[string]interface{}{
+						"name":   pod.Name,
+						"phase":  string(pod.Status.Phase),
+						"ready":  isPodReady(&pod),
+						"reason": getPodReason(&pod),
+					}
+					podStatuses = append(podStatuses, podStatus)
 				}
-				podStatuses = append(podStatuses, podStatus)
+				stackStatus["pods"] = podStatuses
+				stackStatus["pod_count"] = len(pods)
 			}
-			stackStatus["pods"] = podStatuses
-			stackStatus["pod_count"] = len(pods)
 		}
 
 		stackStatuses = append(stackStatuses, stackStatus)
 	}
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"stacks": stackStatuses,
 		"count":  len(stackStatuses),
-	}, nil
+		"total":  page.Total,
+	}
+	if page.NextOffset > 0 {
+		result["next_offset"] = page.NextOffset
+	}
+	return result, nil
 }
 
-// Logs handler
-func handleLogs(args map[string]interface{}, _ Logger) (interface{}, error) {
+// Logs handler. Logs are paged by a byte budget (max_bytes); when a page is
+// truncated, passing next_cursor back as cursor continues after the last
+// returned line of each container.
+func handleLogs(ctx context.Context, args map[string]interface{}, _ Logger) (interface{}, error) {
 	stackFilter := getString(args, "stack", "")
 	envFilter := getString(args, "env", "")
 	serviceFilter := getString(args, "service", "")
 	podFilter := getString(args, "pod", "")
 	tail := int64(getInt(args, "tail", 100))
 	maxPods := getInt(args, "max_pods", 5)
+	maxBytes := getInt(args, "max_bytes", defaultLogMaxBytes)
+
+	cursor, err := ParseLogCursor(getString(args, "cursor", ""))
+	if err != nil {
+		return nil, err
+	}
 
-	apiClient, err := getAPIClient()
+	sinceTime, err := parseLogSince(getString(args, "since", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient, err := getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// List stacks to filter
-	stacks, err := apiClient.ListStacks(envFilter)
+	stacks, err := apiClient.ListStacks(ctx, envFilter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list stacks: %w", err)
 	}
@@ -959,7 +1118,14 @@ func handleLogs(args map[string]interface{}, _ Logger) (interface{}, error) {
 
 	var logEntries []map[string]interface{}
 	podsProcessed := 0
+	remaining := maxBytes
+	truncated := false
+	nextCursor := LogCursor{}
+	for key, ts := range cursor {
+		nextCursor[key] = ts
+	}
 
+stackLoop:
 	for _, stack := range stacks {
 		// Filter by stack name if specified
 		if stackFilter != "" && stack.Name != stackFilter {
@@ -970,7 +1136,7 @@ func handleLogs(args map[string]interface{}, _ Logger) (interface{}, error) {
 		labels := map[string]string{
 			"lissto.dev/stack": stack.Name,
 		}
-		pods, err := k8sClient.ListPods(context.Background(), stack.Namespace, labels)
+		pods, err := k8sClient.ListPods(ctx, stack.Namespace, labels)
 		if err != nil {
 			continue
 		}
@@ -994,24 +1160,53 @@ func handleLogs(args map[string]interface{}, _ Logger) (interface{}, error) {
 
 			// Get logs for each container in the pod
 			for _, container := range pod.Spec.Containers {
-				// Stream logs using k8s client
+				if remaining <= 0 {
+					truncated = true
+					break stackLoop
+				}
+
+				key := LogCursorKey(pod.Namespace, pod.Name, container.Name)
+				after := cursor[key]
+
+				// Timestamps are needed to track the cursor position; they are
+				// stripped from the returned lines
 				opts := k8s.LogOptions{
 					Follow:     false,
-					Timestamps: false,
-					TailLines:  &tail,
+					Timestamps: true,
 					Container:  container.Name,
 				}
+				switch {
+				case !after.IsZero():
+					// Continuing a previous page: everything after the cursor
+					opts.SinceTime = &after
+				case sinceTime != nil:
+					opts.SinceTime = sinceTime
+				default:
+					opts.TailLines = &tail
+				}
 
-				stream, err := k8sClient.StreamLogs(context.Background(), pod.Namespace, pod.Name, opts)
+				stream, err := k8sClient.StreamLogs(ctx, pod.Namespace, pod.Name, opts)
 				if err != nil {
 					continue
 				}
 
-				// Read all logs from stream
-				logBytes, err := io.ReadAll(stream)
+				// Read line by line and stop as soon as the budget is used up
+				pager := NewLogPager(after, remaining)
+				scanner := bufio.NewScanner(stream)
+				scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+				for scanner.Scan() {
+					if !pager.Add(scanner.Text()) {
+						break
+					}
+				}
 				_ = stream.Close()
-				if err != nil {
-					continue
+
+				remaining -= pager.Bytes
+				if pager.Truncated {
+					truncated = true
+				}
+				if !pager.Last.IsZero() {
+					nextCursor[key] = pager.Last
 				}
 
 				logEntry := map[string]interface{}{
@@ -1019,7 +1214,9 @@ func handleLogs(args map[string]interface{}, _ Logger) (interface{}, error) {
 					"namespace": pod.Namespace,
 					"pod":       pod.Name,
 					"container": container.Name,
-					"logs":      string(logBytes),
+					"logs":      strings.Join(pager.Lines, "\n"),
+					"lines":     len(pager.Lines),
+					"truncated": pager.Truncated,
 				}
 
 				if serviceName, ok := pod.Labels["app"]; ok {
@@ -1033,11 +1230,30 @@ func handleLogs(args map[string]interface{}, _ Logger) (interface{}, error) {
 		}
 	}
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"log_entries":    logEntries,
 		"count":          len(logEntries),
 		"pods_processed": podsProcessed,
-	}, nil
+		"bytes":          maxBytes - remaining,
+		"truncated":      truncated,
+		"next_cursor":    nextCursor.String(),
+	}
+	if truncated {
+		result["hint"] = "Output was truncated at max_bytes. Call again with cursor set to next_cursor to continue."
+	}
+	return result, nil
+}
+
+// parseLogSince parses the since argument: a duration ("10m") or an RFC3339 timestamp
+func parseLogSince(since string) (*time.Time, error) {
+	if since == "" {
+		return nil, nil
+	}
+	t, err := k8s.ParseLogTime(since, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("invalid since: %w", err)
+	}
+	return &t, nil
 }
 
 // Helper functions for pod status
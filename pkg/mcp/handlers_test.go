@@ -1,7 +1,12 @@
 package mcp_test
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/mcp"
 )
 
 var _ = Describe("MCP Handlers", func() {
@@ -31,6 +36,26 @@ var _ = Describe("MCP Handlers", func() {
 		})
 	})
 
+	Describe("Tool Dispatch", func() {
+		BeforeEach(func() {
+			// Isolate from any real lissto config so no handler reaches an API
+			GinkgoT().Setenv("XDG_CONFIG_HOME", GinkgoT().TempDir())
+			GinkgoT().Setenv("HOME", GinkgoT().TempDir())
+		})
+
+		It("should handle every advertised tool", func() {
+			server, err := mcp.NewServer(nil, nil, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, tool := range mcp.GetAllTools() {
+				_, err := mcp.ExecuteTool(context.Background(), tool.Name, map[string]interface{}{}, server)
+				if err != nil {
+					Expect(err.Error()).NotTo(ContainSubstring("unknown tool"), tool.Name)
+				}
+			}
+		})
+	})
+
 	Describe("Tool Execution", func() {
 		Context("when tool name is unknown", func() {
 			It("should return an error", func() {
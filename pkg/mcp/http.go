@@ -0,0 +1,313 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// maxMessageSize limits the size of a JSON-RPC message posted over HTTP
+const maxMessageSize = 4 << 20
+
+// sessionHeader groups plain HTTP requests of one client, so one of them can
+// cancel another
+const sessionHeader = "Mcp-Session-Id"
+
+// HTTPHandler serves the MCP server over HTTP. It supports two transports
+// that share the Server's dispatch layer:
+//
+//   - HTTP+SSE: GET /sse opens an event stream whose first "endpoint" event
+//     names the URL to POST messages to; responses arrive as "message" events
+//   - Plain HTTP: POST /mcp with a JSON-RPC message returns the response as
+//     the JSON body, which suits scripts and stateless clients
+//
+// When a token is set, every request must carry "Authorization: Bearer <token>".
+// Requests from web pages of other origins are refused, and without a token
+// so are requests for a host name other than loopback, so websites can't
+// reach the server through the browser or DNS rebinding.
+type HTTPHandler struct {
+	server *Server
+	token  string
+	mux    *http.ServeMux
+
+	mu        sync.Mutex
+	sessions  map[string]*sseSession
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// sseSession is an open event stream of an HTTP+SSE client
+type sseSession struct {
+	ctx    context.Context
+	events chan []byte
+}
+
+// NewHTTPHandler creates an HTTP handler for the server. An empty token
+// disables authentication.
+func NewHTTPHandler(server *Server, token string) *HTTPHandler {
+	h := &HTTPHandler{
+		server:   server,
+		token:    token,
+		mux:      http.NewServeMux(),
+		sessions: make(map[string]*sseSession),
+		closed:   make(chan struct{}),
+	}
+	h.mux.HandleFunc("GET /sse", h.handleSSE)
+	h.mux.HandleFunc("POST /message", h.handleMessage)
+	h.mux.HandleFunc("POST /mcp", h.handleMCP)
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if reason := h.forbidden(r); reason != "" {
+		h.server.log("Rejected %s %s from %s: %s", r.Method, r.URL.Path, r.RemoteAddr, reason)
+		http.Error(w, reason, http.StatusForbidden)
+		return
+	}
+	if !h.authorized(r) {
+		h.server.log("Rejected unauthorized %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+		w.Header().Set("WWW-Authenticate", `Bearer realm="lissto-mcp"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method == http.MethodPost && !isJSON(r.Header.Get("Content-Type")) {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// Close ends all open event streams, e.g. before shutting down the HTTP server
+func (h *HTTPHandler) Close() {
+	h.closeOnce.Do(func() { close(h.closed) })
+}
+
+// authorized checks the bearer token in constant time
+func (h *HTTPHandler) authorized(r *http.Request) bool {
+	if h.token == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
+
+// forbidden returns why a request from a web page or for a foreign host name
+// is refused, or "" if it's allowed. With a token the server may be reached
+// under any host name, and the token protects it instead.
+func (h *HTTPHandler) forbidden(r *http.Request) string {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || !isLoopback(u.Hostname()) {
+			return "origin not allowed"
+		}
+	}
+	if h.token == "" && !isLoopback(hostname(r.Host)) {
+		return "host not allowed"
+	}
+	return ""
+}
+
+// isJSON reports whether a Content-Type header is application/json
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+// hostname strips the port from a host, if any
+func hostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return strings.Trim(host, "[]")
+}
+
+// isLoopback reports whether a host name is localhost or a loopback IP
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// handleSSE opens an event stream and keeps it open until the client leaves
+func (h *HTTPHandler) handleSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ctx := withCaller(r.Context(), Caller{Transport: TransportSSE, Address: r.RemoteAddr, Session: id})
+	session := &sseSession{ctx: ctx, events: make(chan []byte, 16)}
+
+	h.mu.Lock()
+	h.sessions[id] = session
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.sessions, id)
+		h.mu.Unlock()
+		h.server.forgetClient(id)
+	}()
+	h.server.log("SSE session %s opened from %s", id, r.RemoteAddr)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "event: endpoint\ndata: /message?sessionId=%s\n\n", id)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			h.server.log("SSE session %s closed", id)
+			return
+		case <-h.closed:
+			h.server.log("SSE session %s closed by server shutdown", id)
+			return
+		case data := <-session.events:
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+// handleMessage accepts a JSON-RPC message for an SSE session. The response
+// is delivered on the session's event stream.
+func (h *HTTPHandler) handleMessage(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("sessionId")
+	h.mu.Lock()
+	session, ok := h.sessions[id]
+	h.mu.Unlock()
+	if !ok {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+
+	req, errResp := h.readRequest(r)
+	w.WriteHeader(http.StatusAccepted)
+
+	// Requests outlive the POST, so they're bound to the event stream instead
+	send := func(response *JSONRPCResponse) {
+		data, err := json.Marshal(response)
+		if err != nil {
+			h.server.log("Failed to marshal response: %v", err)
+			return
+		}
+		h.server.log("Sending response to session %s: %s", id, string(data))
+		select {
+		case session.events <- data:
+		case <-session.ctx.Done():
+		}
+	}
+	if req == nil {
+		go send(errResp)
+		return
+	}
+	h.server.dispatch(session.ctx, id, req, send)
+}
+
+// handleMCP handles a JSON-RPC message and writes the response as the body.
+// Requests that carry an Mcp-Session-Id header can be cancelled by posting a
+// cancellation with the same header; other requests end when the client
+// disconnects.
+func (h *HTTPHandler) handleMCP(w http.ResponseWriter, r *http.Request) {
+	req, response := h.readRequest(r)
+	if req != nil {
+		ctx := withCaller(r.Context(), Caller{Transport: TransportHTTP, Address: r.RemoteAddr, Client: r.UserAgent()})
+		response = h.server.track(ctx, httpScope(r), req)()
+		if response == nil && req.ID != nil {
+			if r.Context().Err() != nil {
+				h.server.log("Client of request %v went away", req.ID)
+				return
+			}
+			// Cancelled with notifications/cancelled, but HTTP needs an answer
+			response = errorResponse(req.ID, RequestCancelled, "Request cancelled")
+		}
+	}
+	if response == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	h.server.log("Sending response: %v", response.ID)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.server.log("Failed to write response: %v", err)
+	}
+}
+
+// httpScope returns the scope of the request IDs of a plain HTTP request.
+// Without a session header every request gets its own scope, so stateless
+// clients reusing IDs don't collide.
+func httpScope(r *http.Request) string {
+	if session := r.Header.Get(sessionHeader); session != "" {
+		return "http/" + session
+	}
+	return fmt.Sprintf("http/%p", r)
+}
+
+// readRequest decodes a posted JSON-RPC request, or returns a parse error
+// response
+func (h *HTTPHandler) readRequest(r *http.Request) (*JSONRPCRequest, *JSONRPCResponse) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		return nil, errorResponse(nil, ParseError, fmt.Sprintf("Parse error: %v", err))
+	}
+	h.server.log("Received request: %s", string(body))
+
+	var req JSONRPCRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		h.server.log("Parse error: %v", err)
+		return nil, errorResponse(nil, ParseError, fmt.Sprintf("Parse error: %v", err))
+	}
+	return &req, nil
+}
+
+// ListenAddress returns the address to serve on. Without a token the server
+// only listens on loopback: a bare ":port" binds to 127.0.0.1 and any other
+// host is refused.
+func ListenAddress(addr, token string) (string, error) {
+	if token != "" {
+		return addr, nil
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid listen address %q: %w", addr, err)
+	}
+	if host == "" {
+		return net.JoinHostPort("127.0.0.1", port), nil
+	}
+	if !isLoopback(host) {
+		return "", fmt.Errorf("refusing to listen on %s without a token: set --token or $LISSTO_MCP_TOKEN, or listen on 127.0.0.1", addr)
+	}
+	return addr, nil
+}
+
+// newSessionID returns a random SSE session ID
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,277 @@
+package mcp_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/mcp"
+)
+
+var _ = Describe("HTTP transport", func() {
+	var (
+		server  *mcp.Server
+		handler *mcp.HTTPHandler
+		ts      *httptest.Server
+	)
+
+	const initialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`
+
+	start := func(token string) {
+		var err error
+		server, err = mcp.NewServer(nil, nil, "")
+		Expect(err).NotTo(HaveOccurred())
+		handler = mcp.NewHTTPHandler(server, token)
+		ts = httptest.NewServer(handler)
+	}
+
+	AfterEach(func() {
+		handler.Close()
+		ts.Close()
+		_ = server.Close()
+	})
+
+	post := func(path, body, token string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, ts.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	It("answers plain HTTP requests with the JSON-RPC response", func() {
+		start("")
+		resp := post("/mcp", initialize, "")
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var response mcp.JSONRPCResponse
+		Expect(json.NewDecoder(resp.Body).Decode(&response)).To(Succeed())
+		Expect(response.Error).To(BeNil())
+		Expect(response.Result).To(HaveKey("serverInfo"))
+	})
+
+	It("accepts notifications without a body", func() {
+		start("")
+		resp := post("/mcp", `{"jsonrpc":"2.0","method":"initialized"}`, "")
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+	})
+
+	It("returns parse errors", func() {
+		start("")
+		resp := post("/mcp", `{not json`, "")
+		defer resp.Body.Close()
+
+		var response mcp.JSONRPCResponse
+		Expect(json.NewDecoder(resp.Body).Decode(&response)).To(Succeed())
+		Expect(response.Error.Code).To(Equal(mcp.ParseError))
+	})
+
+	It("requires the bearer token when one is set", func() {
+		start("s3cret")
+		resp := post("/mcp", initialize, "")
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+		resp = post("/mcp", initialize, "wrong")
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+		resp = post("/mcp", initialize, "s3cret")
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("requires JSON request bodies", func() {
+		start("")
+		for _, path := range []string{"/mcp", "/message?sessionId=nope"} {
+			resp, err := http.Post(ts.URL+path, "text/plain", strings.NewReader(initialize))
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnsupportedMediaType), path)
+		}
+
+		resp, err := http.Post(ts.URL+"/mcp", "application/json; charset=utf-8", strings.NewReader(initialize))
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	DescribeTable("checks the Origin and Host of requests",
+		func(token, origin, host string, status int) {
+			start(token)
+			req, err := http.NewRequest(http.MethodPost, ts.URL+"/mcp", strings.NewReader(initialize))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			if origin != "" {
+				req.Header.Set("Origin", origin)
+			}
+			if host != "" {
+				req.Host = host
+			}
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(status))
+		},
+		Entry("loopback origin", "", "http://localhost:3000", "", http.StatusOK),
+		Entry("foreign origin", "", "https://evil.example", "", http.StatusForbidden),
+		Entry("opaque origin", "", "null", "", http.StatusForbidden),
+		Entry("foreign origin with a token", "s3cret", "https://evil.example", "", http.StatusForbidden),
+		Entry("localhost host", "", "", "localhost:8765", http.StatusOK),
+		Entry("rebound host", "", "", "evil.example:8765", http.StatusForbidden),
+		Entry("any host with a token", "s3cret", "", "mcp.example:8765", http.StatusOK),
+	)
+
+	It("delivers responses over the SSE stream", func() {
+		start("")
+		stream, err := http.Get(ts.URL + "/sse")
+		Expect(err).NotTo(HaveOccurred())
+		defer stream.Body.Close()
+		Expect(stream.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+		events := bufio.NewReader(stream.Body)
+		readEvent := func() (string, string) {
+			var event, data string
+			for {
+				line, err := events.ReadString('\n')
+				Expect(err).NotTo(HaveOccurred())
+				line = strings.TrimRight(line, "\n")
+				switch {
+				case line == "":
+					return event, data
+				case strings.HasPrefix(line, "event: "):
+					event = strings.TrimPrefix(line, "event: ")
+				case strings.HasPrefix(line, "data: "):
+					data = strings.TrimPrefix(line, "data: ")
+				}
+			}
+		}
+
+		event, endpoint := readEvent()
+		Expect(event).To(Equal("endpoint"))
+		Expect(endpoint).To(HavePrefix("/message?sessionId="))
+
+		resp := post(endpoint, `{"jsonrpc":"2.0","id":7,"method":"tools/list"}`, "")
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+
+		event, data := readEvent()
+		Expect(event).To(Equal("message"))
+		var response mcp.JSONRPCResponse
+		Expect(json.Unmarshal([]byte(data), &response)).To(Succeed())
+		Expect(response.ID).To(BeNumerically("==", 7))
+		Expect(response.Result).To(HaveKey("tools"))
+	})
+
+	Context("with a slow request", func() {
+		var running chan struct{}
+
+		const slowCall = `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"lissto_stack_delete","arguments":{}}}`
+
+		BeforeEach(func() {
+			start("")
+			// Mutating tool calls wait until they're cancelled
+			running = make(chan struct{}, 10)
+			server.SetPolicy(&mcp.Policy{Confirm: func(ctx context.Context, _ string, _ map[string]interface{}) (bool, error) {
+				running <- struct{}{}
+				<-ctx.Done()
+				return false, ctx.Err()
+			}})
+		})
+
+		postSession := func(body, session string) *http.Response {
+			req, err := http.NewRequest(http.MethodPost, ts.URL+"/mcp", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Mcp-Session-Id", session)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+
+		It("cancels it from another request of the same session", func() {
+			responses := make(chan *http.Response, 1)
+			go func() {
+				responses <- postSession(slowCall, "s1")
+			}()
+			Eventually(running).Should(Receive())
+
+			// Other sessions can't cancel it
+			resp := postSession(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1}}`, "s2")
+			resp.Body.Close()
+			Consistently(responses, "100ms").ShouldNot(Receive())
+
+			resp = postSession(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1}}`, "s1")
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+
+			var slow *http.Response
+			Eventually(responses).Should(Receive(&slow))
+			defer slow.Body.Close()
+			var response mcp.JSONRPCResponse
+			Expect(json.NewDecoder(slow.Body).Decode(&response)).To(Succeed())
+			Expect(response.Error.Code).To(Equal(mcp.RequestCancelled))
+		})
+
+		It("doesn't mix up request IDs of requests without a session", func() {
+			slowCtx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				req, _ := http.NewRequestWithContext(slowCtx, http.MethodPost, ts.URL+"/mcp", strings.NewReader(slowCall))
+				req.Header.Set("Content-Type", "application/json")
+				if resp, err := http.DefaultClient.Do(req); err == nil {
+					resp.Body.Close()
+				}
+			}()
+			Eventually(running).Should(Receive())
+
+			resp := post("/mcp", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`, "")
+			defer resp.Body.Close()
+			var response mcp.JSONRPCResponse
+			Expect(json.NewDecoder(resp.Body).Decode(&response)).To(Succeed())
+			Expect(response.Error).To(BeNil())
+			Expect(response.Result).To(HaveKey("tools"))
+		})
+	})
+
+	It("rejects messages for unknown sessions", func() {
+		start("")
+		resp := post("/message?sessionId=nope", initialize, "")
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+})
+
+var _ = DescribeTable("ListenAddress",
+	func(addr, token, expected string, fails bool) {
+		listen, err := mcp.ListenAddress(addr, token)
+		if fails {
+			Expect(err).To(MatchError(ContainSubstring("without a token")))
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listen).To(Equal(expected))
+	},
+	Entry("bare port binds to loopback", ":8765", "", "127.0.0.1:8765", false),
+	Entry("loopback IPv4", "127.0.0.1:8765", "", "127.0.0.1:8765", false),
+	Entry("loopback IPv6", "[::1]:8765", "", "[::1]:8765", false),
+	Entry("localhost", "localhost:8765", "", "localhost:8765", false),
+	Entry("all interfaces without a token", "0.0.0.0:8765", "", "", true),
+	Entry("remote host without a token", "mcp.example:8765", "", "", true),
+	Entry("any address with a token", ":8765", "s3cret", ":8765", false),
+)
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+)
+
+// defaultLogMaxBytes bounds the log bytes returned by one lissto_logs call
+const defaultLogMaxBytes = 64 * 1024
+
+// LogCursor records, per pod container, the timestamp of the last log line
+// returned, so the next call can continue where the previous one stopped
+type LogCursor map[string]time.Time
+
+// LogCursorKey identifies a container's log stream in a cursor
+func LogCursorKey(namespace, pod, container string) string {
+	return namespace + "/" + pod + "/" + container
+}
+
+// ParseLogCursor decodes a cursor returned by a previous call ("" is empty)
+func ParseLogCursor(s string) (LogCursor, error) {
+	cursor := LogCursor{}
+	if s == "" {
+		return cursor, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return cursor, nil
+}
+
+// String encodes the cursor as an opaque token
+func (c LogCursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// LogPager collects timestamped log lines of one container up to a byte
+// budget, skipping lines already returned before the cursor position
+type LogPager struct {
+	after     time.Time
+	remaining int
+
+	Lines     []string
+	Bytes     int
+	Last      time.Time // Timestamp of the last collected line
+	Truncated bool      // The budget ran out before the stream ended
+}
+
+// NewLogPager creates a pager for lines after the given time (zero for all)
+func NewLogPager(after time.Time, maxBytes int) *LogPager {
+	return &LogPager{after: after, remaining: maxBytes}
+}
+
+// Add consumes one log line as returned with LogOptions.Timestamps. It returns
+// false once the budget is exhausted and the caller should stop reading.
+func (p *LogPager) Add(line string) bool {
+	ts, message, ok := k8s.SplitTimestamp(line)
+	if ok && !p.after.IsZero() && !ts.After(p.after) {
+		return true
+	}
+
+	size := len(message) + 1 // newline
+	if size > p.remaining {
+		p.Truncated = true
+		// Always make progress: cut an oversized first line instead of
+		// returning an empty page forever
+		if len(p.Lines) == 0 && p.remaining > 0 {
+			p.Lines = append(p.Lines, message[:p.remaining])
+			p.Bytes += p.remaining
+			p.remaining = 0
+			if ok {
+				p.Last = ts
+			}
+		}
+		return false
+	}
+
+	p.Lines = append(p.Lines, message)
+	p.Bytes += size
+	p.remaining -= size
+	if ok {
+		p.Last = ts
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package mcp_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/mcp"
+)
+
+var _ = Describe("Log paging", func() {
+	base := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	line := func(offset int, msg string) string {
+		return base.Add(time.Duration(offset)*time.Second).Format(time.RFC3339Nano) + " " + msg
+	}
+
+	Describe("LogCursor", func() {
+		It("should round-trip through its string form", func() {
+			cursor := mcp.LogCursor{mcp.LogCursorKey("ns", "pod", "main"): base}
+			parsed, err := mcp.ParseLogCursor(cursor.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed[mcp.LogCursorKey("ns", "pod", "main")].Equal(base)).To(BeTrue())
+		})
+
+		It("should treat an empty string as an empty cursor", func() {
+			parsed, err := mcp.ParseLogCursor("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(BeEmpty())
+		})
+
+		It("should reject garbage", func() {
+			_, err := mcp.ParseLogCursor("not a cursor!")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("LogPager", func() {
+		It("should strip timestamps and track the last line", func() {
+			pager := mcp.NewLogPager(time.Time{}, 1024)
+			Expect(pager.Add(line(0, "hello"))).To(BeTrue())
+			Expect(pager.Add(line(1, "world"))).To(BeTrue())
+			Expect(pager.Lines).To(Equal([]string{"hello", "world"}))
+			Expect(pager.Last.Equal(base.Add(time.Second))).To(BeTrue())
+			Expect(pager.Truncated).To(BeFalse())
+		})
+
+		It("should skip lines at or before the cursor", func() {
+			pager := mcp.NewLogPager(base.Add(time.Second), 1024)
+			pager.Add(line(0, "old"))
+			pager.Add(line(1, "seen"))
+			pager.Add(line(2, "new"))
+			Expect(pager.Lines).To(Equal([]string{"new"}))
+		})
+
+		It("should stop at the byte budget", func() {
+			pager := mcp.NewLogPager(time.Time{}, 12)
+			Expect(pager.Add(line(0, "12345"))).To(BeTrue())
+			Expect(pager.Add(line(1, "67890"))).To(BeTrue())
+			Expect(pager.Add(line(2, "more"))).To(BeFalse())
+			Expect(pager.Lines).To(HaveLen(2))
+			Expect(pager.Truncated).To(BeTrue())
+			Expect(pager.Last.Equal(base.Add(time.Second))).To(BeTrue())
+		})
+
+		It("should cut an oversized first line to make progress", func() {
+			pager := mcp.NewLogPager(time.Time{}, 4)
+			Expect(pager.Add(line(0, "abcdefgh"))).To(BeFalse())
+			Expect(pager.Lines).To(Equal([]string{"abcd"}))
+			Expect(pager.Last.Equal(base)).To(BeTrue())
+		})
+	})
+})
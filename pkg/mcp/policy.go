@@ -0,0 +1,155 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// PermissionDenied is the JSON-RPC error code for tool calls blocked by the
+// server's policy
+const PermissionDenied = -32003
+
+// ErrPermissionDenied is matched by errors.Is for calls blocked by a Policy
+var ErrPermissionDenied = errors.New("permission denied")
+
+// readOnlyTools are the tools that never change anything
+var readOnlyTools = map[string]bool{
+	"lissto_env_list":       true,
+	"lissto_env_get":        true,
+	"lissto_env_current":    true,
+	"lissto_blueprint_list": true,
+	"lissto_blueprint_get":  true,
+	"lissto_stack_list":     true,
+	"lissto_stack_get":      true,
+	"lissto_variable_list":  true,
+	"lissto_variable_get":   true,
+	"lissto_secret_list":    true,
+	"lissto_secret_get":     true,
+	"lissto_status":         true,
+	"lissto_logs":           true,
+
+	"lissto_admin_apikey_list": true,
+}
+
+// IsReadOnlyTool reports whether a tool only reads data
+func IsReadOnlyTool(name string) bool {
+	return readOnlyTools[name]
+}
+
+// ConfirmFunc asks whether a tool call that changes something may proceed
+type ConfirmFunc func(ctx context.Context, tool string, args map[string]interface{}) (bool, error)
+
+// Policy decides which tools the MCP server may execute. The zero value
+// allows everything.
+type Policy struct {
+	// ReadOnly blocks every tool that isn't read-only
+	ReadOnly bool
+	// Allow lists the tools that may be called; empty allows all tools
+	Allow []string
+	// Deny lists tools that may never be called; it wins over Allow
+	Deny []string
+	// Confirm, if set, is asked before every tool call that changes something
+	Confirm ConfirmFunc
+}
+
+// PermissionError describes a tool call blocked by a Policy
+type PermissionError struct {
+	Tool   string
+	Reason string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("permission denied: tool '%s' %s", e.Tool, e.Reason)
+}
+
+// Is makes PermissionError match ErrPermissionDenied
+func (e *PermissionError) Is(target error) bool {
+	return target == ErrPermissionDenied
+}
+
+// ParseToolList parses a flag value such as "tools=stack_delete,secret_*"
+// into tool patterns. The "tools=" prefix is optional.
+func ParseToolList(value string) ([]string, error) {
+	if key, list, ok := strings.Cut(value, "="); ok {
+		if key != "tools" {
+			return nil, fmt.Errorf("unknown policy key '%s' (expected tools=<name>,...)", key)
+		}
+		value = list
+	}
+
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid tool pattern '%s': %w", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// Allowed reports whether the policy lets the tool be called at all, without
+// asking for confirmation. It is used to hide blocked tools from tools/list.
+func (p *Policy) Allowed(tool string) bool {
+	return p.blocked(tool) == ""
+}
+
+// Check returns a *PermissionError if the tool call is blocked or declined
+func (p *Policy) Check(ctx context.Context, tool string, args map[string]interface{}) error {
+	if p == nil {
+		return nil
+	}
+	if reason := p.blocked(tool); reason != "" {
+		return &PermissionError{Tool: tool, Reason: reason}
+	}
+	if p.Confirm == nil || IsReadOnlyTool(tool) {
+		return nil
+	}
+
+	confirmed, err := p.Confirm(ctx, tool, args)
+	if err != nil {
+		return &PermissionError{Tool: tool, Reason: fmt.Sprintf("could not be confirmed: %v", err)}
+	}
+	if !confirmed {
+		return &PermissionError{Tool: tool, Reason: "was declined by the user"}
+	}
+	return nil
+}
+
+// blocked returns why the policy blocks a tool, or "" if it doesn't
+func (p *Policy) blocked(tool string) string {
+	if p == nil {
+		return ""
+	}
+	if matchTool(p.Deny, tool) {
+		return "is denied by policy"
+	}
+	if len(p.Allow) > 0 && !matchTool(p.Allow, tool) {
+		return "is not in the allowed tools"
+	}
+	if p.ReadOnly && !IsReadOnlyTool(tool) {
+		return "is not allowed in read-only mode"
+	}
+	return ""
+}
+
+// matchTool matches a tool name against glob patterns, which may leave out
+// the "lissto_" prefix
+func matchTool(patterns []string, tool string) bool {
+	short := strings.TrimPrefix(tool, "lissto_")
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, tool); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, short); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package mcp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/mcp"
+)
+
+var _ = Describe("Policy", func() {
+	ctx := context.Background()
+
+	It("allows everything by default", func() {
+		var policy *mcp.Policy
+		Expect(policy.Check(ctx, "lissto_stack_delete", nil)).To(Succeed())
+		Expect((&mcp.Policy{}).Check(ctx, "lissto_stack_delete", nil)).To(Succeed())
+	})
+
+	It("blocks mutating tools in read-only mode", func() {
+		policy := &mcp.Policy{ReadOnly: true}
+		Expect(policy.Check(ctx, "lissto_stack_list", nil)).To(Succeed())
+
+		err := policy.Check(ctx, "lissto_stack_delete", nil)
+		Expect(errors.Is(err, mcp.ErrPermissionDenied)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("read-only"))
+	})
+
+	It("matches allow and deny globs with or without the lissto_ prefix", func() {
+		policy := &mcp.Policy{
+			Allow: []string{"stack_*", "lissto_env_list"},
+			Deny:  []string{"lissto_stack_delete"},
+		}
+		Expect(policy.Allowed("lissto_stack_get")).To(BeTrue())
+		Expect(policy.Allowed("lissto_env_list")).To(BeTrue())
+		Expect(policy.Allowed("lissto_stack_delete")).To(BeFalse())
+		Expect(policy.Allowed("lissto_secret_set")).To(BeFalse())
+	})
+
+	It("asks for confirmation only for mutating tools", func() {
+		var asked []string
+		policy := &mcp.Policy{Confirm: func(_ context.Context, tool string, _ map[string]interface{}) (bool, error) {
+			asked = append(asked, tool)
+			return false, nil
+		}}
+
+		Expect(policy.Check(ctx, "lissto_logs", nil)).To(Succeed())
+		err := policy.Check(ctx, "lissto_secret_set", nil)
+		Expect(errors.Is(err, mcp.ErrPermissionDenied)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("declined"))
+		Expect(asked).To(Equal([]string{"lissto_secret_set"}))
+	})
+
+	DescribeTable("ParseToolList",
+		func(value string, expected []string) {
+			Expect(mcp.ParseToolList(value)).To(Equal(expected))
+		},
+		Entry("with tools= prefix", "tools=stack_delete, secret_*", []string{"stack_delete", "secret_*"}),
+		Entry("bare list", "lissto_logs", []string{"lissto_logs"}),
+	)
+
+	It("rejects unknown keys and bad patterns", func() {
+		_, err := mcp.ParseToolList("resources=x")
+		Expect(err).To(HaveOccurred())
+		_, err = mcp.ParseToolList("tools=[")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("in the server", func() {
+		var (
+			out    *bytes.Buffer
+			server *mcp.Server
+		)
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+			var err error
+			server, err = mcp.NewServer(&bytes.Buffer{}, out, "")
+			Expect(err).NotTo(HaveOccurred())
+			server.SetPolicy(&mcp.Policy{ReadOnly: true})
+		})
+
+		It("hides blocked tools from tools/list", func() {
+			response := server.Handle(ctx, &mcp.JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "tools/list"})
+			data, err := json.Marshal(response.Result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("lissto_stack_list"))
+			Expect(string(data)).NotTo(ContainSubstring("lissto_stack_delete"))
+		})
+
+		It("returns a structured permission denied error", func() {
+			response := server.Handle(ctx, &mcp.JSONRPCRequest{
+				JSONRPC: "2.0",
+				ID:      2,
+				Method:  "tools/call",
+				Params:  json.RawMessage(`{"name":"lissto_stack_delete","arguments":{"name":"web"}}`),
+			})
+			Expect(response.Error).NotTo(BeNil())
+			Expect(response.Error.Code).To(Equal(mcp.PermissionDenied))
+			Expect(response.Error.Data).To(HaveKeyWithValue("tool", "lissto_stack_delete"))
+		})
+	})
+})
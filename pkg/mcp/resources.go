@@ -0,0 +1,259 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+// ResourceNotFound is the MCP error code for unknown resource URIs
+const ResourceNotFound = -32002
+
+// resourceScheme is the URI scheme of Lissto resources
+const resourceScheme = "lissto://"
+
+// Resource kinds, the first segment of a resource URI
+const (
+	resourceStack     = "stack"
+	resourceLogs      = "logs"
+	resourceBlueprint = "blueprint"
+)
+
+// resourceLogTail is the number of lines per container in a logs resource
+const resourceLogTail = 100
+
+// Resource describes an addressable resource in resources/list
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ResourceTemplate describes a family of resources in resources/templates/list
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ResourceContents is the content of a resource in resources/read
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text"`
+}
+
+// ResourceURI identifies a resource: lissto://stack/<env>/<name>,
+// lissto://logs/<env>/<stack> or lissto://blueprint/<id>
+type ResourceURI struct {
+	Kind string
+	Env  string
+	Name string
+}
+
+// String formats the URI, escaping each path segment
+func (u ResourceURI) String() string {
+	if u.Kind == resourceBlueprint {
+		// Blueprint IDs are scoped (namespace/name); keep the slash readable
+		parts := strings.Split(u.Name, "/")
+		for i := range parts {
+			parts[i] = url.PathEscape(parts[i])
+		}
+		return resourceScheme + u.Kind + "/" + strings.Join(parts, "/")
+	}
+	return resourceScheme + u.Kind + "/" + url.PathEscape(u.Env) + "/" + url.PathEscape(u.Name)
+}
+
+// ParseResourceURI parses a lissto:// resource URI
+func ParseResourceURI(uri string) (ResourceURI, error) {
+	rest, ok := strings.CutPrefix(uri, resourceScheme)
+	if !ok {
+		return ResourceURI{}, fmt.Errorf("unsupported resource URI %q: expected %s...", uri, resourceScheme)
+	}
+
+	kind, path, _ := strings.Cut(rest, "/")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil || unescaped == "" {
+			return ResourceURI{}, fmt.Errorf("invalid resource URI %q", uri)
+		}
+		segments[i] = unescaped
+	}
+
+	switch kind {
+	case resourceStack, resourceLogs:
+		if len(segments) != 2 {
+			return ResourceURI{}, fmt.Errorf("invalid resource URI %q: expected %s%s/<env>/<name>", uri, resourceScheme, kind)
+		}
+		return ResourceURI{Kind: kind, Env: segments[0], Name: segments[1]}, nil
+	case resourceBlueprint:
+		return ResourceURI{Kind: kind, Name: strings.Join(segments, "/")}, nil
+	default:
+		return ResourceURI{}, fmt.Errorf("unknown resource kind %q in %q (expected stack, logs or blueprint)", kind, uri)
+	}
+}
+
+// GetResourceTemplates returns the URI templates of all resource kinds
+func GetResourceTemplates() []ResourceTemplate {
+	return []ResourceTemplate{
+		{
+			URITemplate: resourceScheme + "stack/{env}/{name}",
+			Name:        "Stack status",
+			Description: "Status of a stack: state, services, exposed URLs and pods",
+			MimeType:    "application/json",
+		},
+		{
+			URITemplate: resourceScheme + "logs/{env}/{stack}",
+			Name:        "Stack logs",
+			Description: fmt.Sprintf("Last %d log lines of every container of a stack", resourceLogTail),
+			MimeType:    "text/plain",
+		},
+		{
+			URITemplate: resourceScheme + "blueprint/{id}",
+			Name:        "Blueprint compose",
+			Description: "The docker-compose content of a blueprint",
+			MimeType:    "application/yaml",
+		},
+	}
+}
+
+// ListResources lists a status and a logs resource per stack, and a
+// resource per blueprint
+func ListResources(ctx context.Context) ([]Resource, error) {
+	apiClient, err := getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stacks, err := apiClient.ListStacks(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", err)
+	}
+	blueprints, err := apiClient.ListBlueprints(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %w", err)
+	}
+
+	sort.Slice(stacks, func(i, j int) bool {
+		if stacks[i].Spec.Env != stacks[j].Spec.Env {
+			return stacks[i].Spec.Env < stacks[j].Spec.Env
+		}
+		return stacks[i].Name < stacks[j].Name
+	})
+
+	resources := make([]Resource, 0, 2*len(stacks)+len(blueprints))
+	for _, stack := range stacks {
+		ref := stack.Spec.Env + "/" + stack.Name
+		resources = append(resources,
+			Resource{
+				URI:         ResourceURI{Kind: resourceStack, Env: stack.Spec.Env, Name: stack.Name}.String(),
+				Name:        "Stack " + ref,
+				Description: fmt.Sprintf("Status of stack %s (blueprint %s)", ref, stack.Spec.BlueprintReference),
+				MimeType:    "application/json",
+			},
+			Resource{
+				URI:         ResourceURI{Kind: resourceLogs, Env: stack.Spec.Env, Name: stack.Name}.String(),
+				Name:        "Logs " + ref,
+				Description: fmt.Sprintf("Recent logs of stack %s", ref),
+				MimeType:    "text/plain",
+			},
+		)
+	}
+	for _, bp := range blueprints {
+		name := bp.Title
+		if name == "" {
+			name = bp.ID
+		}
+		resources = append(resources, Resource{
+			URI:         ResourceURI{Kind: resourceBlueprint, Name: bp.ID}.String(),
+			Name:        "Blueprint " + name,
+			Description: fmt.Sprintf("Compose content of blueprint %s", bp.ID),
+			MimeType:    "application/yaml",
+		})
+	}
+	return resources, nil
+}
+
+// ReadResource returns the content of a resource
+func ReadResource(ctx context.Context, uri ResourceURI, logger Logger) (*ResourceContents, error) {
+	apiClient, err := getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch uri.Kind {
+	case resourceStack:
+		return readStackResource(ctx, apiClient, uri)
+	case resourceLogs:
+		return readLogsResource(ctx, uri, logger)
+	default:
+		bp, err := apiClient.GetBlueprintDetailed(ctx, uri.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blueprint: %w", err)
+		}
+		return &ResourceContents{URI: uri.String(), MimeType: "application/yaml", Text: bp.Spec.DockerCompose}, nil
+	}
+}
+
+// readStackResource returns the status report of a stack as JSON
+func readStackResource(ctx context.Context, apiClient *client.Client, uri ResourceURI) (*ResourceContents, error) {
+	stacks, err := apiClient.ListStacks(ctx, uri.Env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", err)
+	}
+
+	for i := range stacks {
+		if stacks[i].Name != uri.Name {
+			continue
+		}
+
+		// Pod details are best-effort
+		k8sClient, _ := k8s.NewClient()
+		report := status.BuildStackReport(ctx, k8sClient, &stacks[i], nil)
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &ResourceContents{URI: uri.String(), MimeType: "application/json", Text: string(data)}, nil
+	}
+	return nil, fmt.Errorf("stack '%s' %w in environment '%s'", uri.Name, client.ErrNotFound, uri.Env)
+}
+
+// readLogsResource returns the recent logs of a stack, one section per
+// container, using the lissto_logs tool
+func readLogsResource(ctx context.Context, uri ResourceURI, logger Logger) (*ResourceContents, error) {
+	result, err := handleLogs(ctx, map[string]interface{}{
+		"env":      uri.Env,
+		"stack":    uri.Name,
+		"tail":     float64(resourceLogTail),
+		"max_pods": float64(20),
+	}, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	entries, _ := result.(map[string]interface{})["log_entries"].([]map[string]interface{})
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "==> %s/%s <==\n", entry["pod"], entry["container"])
+		if logs, _ := entry["logs"].(string); logs != "" {
+			b.WriteString(logs)
+			b.WriteByte('\n')
+		}
+		b.WriteByte('\n')
+	}
+	if len(entries) == 0 {
+		b.WriteString("No logs found.\n")
+	}
+	return &ResourceContents{URI: uri.String(), MimeType: "text/plain", Text: b.String()}, nil
+}
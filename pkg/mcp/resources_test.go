@@ -0,0 +1,92 @@
+package mcp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/mcp"
+)
+
+var _ = Describe("Resources", func() {
+	Describe("ParseResourceURI", func() {
+		DescribeTable("should round-trip URIs",
+			func(uri string, expected mcp.ResourceURI) {
+				parsed, err := mcp.ParseResourceURI(uri)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parsed).To(Equal(expected))
+				Expect(parsed.String()).To(Equal(uri))
+			},
+			Entry("stack", "lissto://stack/dev/my-stack", mcp.ResourceURI{Kind: "stack", Env: "dev", Name: "my-stack"}),
+			Entry("logs", "lissto://logs/dev/my-stack", mcp.ResourceURI{Kind: "logs", Env: "dev", Name: "my-stack"}),
+			Entry("scoped blueprint", "lissto://blueprint/global/web-app", mcp.ResourceURI{Kind: "blueprint", Name: "global/web-app"}),
+			Entry("escaped segment", "lissto://stack/dev/a%20b", mcp.ResourceURI{Kind: "stack", Env: "dev", Name: "a b"}),
+		)
+
+		DescribeTable("should reject invalid URIs",
+			func(uri, message string) {
+				_, err := mcp.ParseResourceURI(uri)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("other scheme", "file:///etc/passwd", "unsupported resource URI"),
+			Entry("unknown kind", "lissto://pod/dev/x", "unknown resource kind"),
+			Entry("missing env", "lissto://stack/my-stack", "expected lissto://stack/<env>/<name>"),
+			Entry("empty segment", "lissto://stack/dev/", "invalid resource URI"),
+		)
+	})
+
+	Describe("Server", func() {
+		var (
+			stdin  *bytes.Buffer
+			stdout *bytes.Buffer
+			server *mcp.Server
+		)
+
+		BeforeEach(func() {
+			stdin = &bytes.Buffer{}
+			stdout = &bytes.Buffer{}
+			var err error
+			server, err = mcp.NewServer(stdin, stdout, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			_ = server.Close()
+		})
+
+		call := func(method string, params map[string]interface{}) map[string]interface{} {
+			request, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+			Expect(err).NotTo(HaveOccurred())
+			stdin.Write(append(request, '\n'))
+
+			Expect(server.Run(context.Background())).To(Succeed())
+
+			var response map[string]interface{}
+			Expect(json.Unmarshal(stdout.Bytes(), &response)).To(Succeed())
+			return response
+		}
+
+		It("should list resource templates", func() {
+			response := call("resources/templates/list", nil)
+			templates := response["result"].(map[string]interface{})["resourceTemplates"].([]interface{})
+			Expect(templates).To(HaveLen(3))
+			Expect(templates[0].(map[string]interface{})["uriTemplate"]).To(Equal("lissto://stack/{env}/{name}"))
+		})
+
+		It("should reject reading an invalid URI", func() {
+			response := call("resources/read", map[string]interface{}{"uri": "https://example.com"})
+			errorObj := response["error"].(map[string]interface{})
+			Expect(errorObj["code"]).To(BeNumerically("==", mcp.InvalidParams))
+			Expect(errorObj["message"]).To(ContainSubstring("unsupported resource URI"))
+		})
+
+		It("should advertise the resources capability", func() {
+			response := call("initialize", nil)
+			capabilities := response["result"].(map[string]interface{})["capabilities"].(map[string]interface{})
+			Expect(capabilities).To(HaveKey("resources"))
+		})
+	})
+})
@@ -0,0 +1,249 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaError is a tool argument that doesn't match the tool's InputSchema
+type SchemaError struct {
+	// Pointer is the JSON pointer (RFC 6901) of the argument, e.g. /data/PORT
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every mismatch between tool arguments and a schema
+type ValidationError struct {
+	Tool   string
+	Errors []SchemaError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Errors))
+	for _, se := range e.Errors {
+		parts = append(parts, se.Pointer+": "+se.Message)
+	}
+	return fmt.Sprintf("invalid arguments for %s: %s", e.Tool, strings.Join(parts, "; "))
+}
+
+// findTool returns the declared tool with a name
+func findTool(name string) (Tool, bool) {
+	for _, tool := range GetAllTools() {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return Tool{}, false
+}
+
+// ValidateArgs checks tool arguments against the subset of JSON Schema the
+// tools declare: type, properties, required, additionalProperties, items,
+// enum, minimum and maximum. It returns a *ValidationError, or nil.
+func ValidateArgs(tool string, schema map[string]interface{}, args map[string]interface{}) error {
+	var errs []SchemaError
+	var value interface{} = args
+	if args == nil {
+		// Tools without arguments may be called without an arguments object
+		value = map[string]interface{}{}
+	}
+	validateValue(schema, value, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Tool: tool, Errors: errs}
+}
+
+// validateValue appends the mismatches of value against schema to errs
+func validateValue(schema map[string]interface{}, value interface{}, pointer string, errs *[]SchemaError) {
+	at := pointer
+	if at == "" {
+		at = "/"
+	}
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, SchemaError{Pointer: at, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if types := schemaStrings(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, t := range types {
+			if hasType(value, t) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("expected %s, got %s", strings.Join(types, " or "), jsonType(value))
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"]; ok {
+		allowed := reflect.ValueOf(enum)
+		if allowed.Kind() == reflect.Slice {
+			found := false
+			options := make([]string, 0, allowed.Len())
+			for i := 0; i < allowed.Len(); i++ {
+				option := allowed.Index(i).Interface()
+				options = append(options, fmt.Sprint(option))
+				if reflect.DeepEqual(normalizeNumber(option), normalizeNumber(value)) {
+					found = true
+				}
+			}
+			if !found {
+				fail("must be one of %s, got %v", strings.Join(options, ", "), value)
+			}
+		}
+	}
+
+	if n, ok := value.(float64); ok {
+		if min, ok := schemaNumber(schema["minimum"]); ok && n < min {
+			fail("must be at least %v, got %v", min, n)
+		}
+		if max, ok := schemaNumber(schema["maximum"]); ok && n > max {
+			fail("must be at most %v, got %v", max, n)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		validateObject(schema, v, pointer, errs)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateValue(items, item, fmt.Sprintf("%s/%d", pointer, i), errs)
+			}
+		}
+	}
+}
+
+// validateObject checks the properties of an object, in name order
+func validateObject(schema map[string]interface{}, obj map[string]interface{}, pointer string, errs *[]SchemaError) {
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	for _, name := range schemaStrings(schema["required"]) {
+		if _, ok := obj[name]; !ok {
+			*errs = append(*errs, SchemaError{Pointer: pointer + "/" + escapePointer(name), Message: "is required"})
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := pointer + "/" + escapePointer(name)
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			validateValue(property, obj[name], child, errs)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				*errs = append(*errs, SchemaError{Pointer: child, Message: "is not a known argument"})
+			}
+		case map[string]interface{}:
+			validateValue(additional, obj[name], child, errs)
+		}
+	}
+}
+
+// hasType reports whether a decoded JSON value has a JSON Schema type
+func hasType(value interface{}, t string) bool {
+	switch t {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := normalizeNumber(value).(float64)
+		return ok
+	case "integer":
+		f, ok := normalizeNumber(value).(float64)
+		return ok && f == math.Trunc(f)
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "null":
+		return value == nil
+	}
+	// Unknown types are not enforced
+	return true
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch v := normalizeNumber(value).(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// normalizeNumber converts Go numbers to the float64 JSON decoding produces
+func normalizeNumber(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+// schemaNumber reads a numeric schema keyword
+func schemaNumber(value interface{}) (float64, bool) {
+	f, ok := normalizeNumber(value).(float64)
+	return f, ok
+}
+
+// schemaStrings reads a schema keyword that is a string or a list of strings,
+// as declared in Go ([]string) or decoded from JSON ([]interface{})
+func schemaStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// escapePointer escapes a property name for a JSON pointer
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
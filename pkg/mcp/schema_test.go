@@ -0,0 +1,121 @@
+package mcp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/mcp"
+)
+
+var _ = Describe("Tool argument validation", func() {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":  map[string]interface{}{"type": "string"},
+			"tail":  map[string]interface{}{"type": "integer", "minimum": 1},
+			"scope": map[string]interface{}{"type": "string", "enum": []string{"env", "repo", "global"}},
+			"wait":  map[string]interface{}{"type": "boolean"},
+			"data": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": map[string]interface{}{"type": "string"},
+			},
+			"services": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+		},
+		"required": []string{"name"},
+	}
+
+	// decode mimics arguments as they arrive over JSON-RPC
+	decode := func(s string) map[string]interface{} {
+		var args map[string]interface{}
+		Expect(json.Unmarshal([]byte(s), &args)).To(Succeed())
+		return args
+	}
+
+	pointers := func(err error) []string {
+		var validationErr *mcp.ValidationError
+		Expect(errors.As(err, &validationErr)).To(BeTrue())
+		var out []string
+		for _, se := range validationErr.Errors {
+			out = append(out, se.Pointer)
+		}
+		return out
+	}
+
+	DescribeTable("valid arguments",
+		func(args string) {
+			Expect(mcp.ValidateArgs("t", schema, decode(args))).To(Succeed())
+		},
+		Entry("required only", `{"name":"web"}`),
+		Entry("every type", `{"name":"web","tail":10,"scope":"repo","wait":true,"data":{"PORT":"8080"},"services":["api"]}`),
+		Entry("integers written as floats", `{"name":"web","tail":10.0}`),
+		Entry("unknown arguments", `{"name":"web","extra":1}`),
+	)
+
+	DescribeTable("invalid arguments",
+		func(args string, expected ...string) {
+			Expect(pointers(mcp.ValidateArgs("t", schema, decode(args)))).To(Equal(expected))
+		},
+		Entry("missing required argument", `{}`, "/name"),
+		Entry("wrong type", `{"name":5}`, "/name"),
+		Entry("fractional integer", `{"name":"web","tail":1.5}`, "/tail"),
+		Entry("integer as string", `{"name":"web","tail":"10"}`, "/tail"),
+		Entry("below minimum", `{"name":"web","tail":0}`, "/tail"),
+		Entry("not in enum", `{"name":"web","scope":"cluster"}`, "/scope"),
+		Entry("nested map value", `{"name":"web","data":{"PORT":8080}}`, "/data/PORT"),
+		Entry("array item", `{"name":"web","services":["api",3]}`, "/services/1"),
+		Entry("escaped pointer", `{"name":"web","data":{"a/b~c":1}}`, "/data/a~1b~0c"),
+		Entry("several problems", `{"tail":"x","wait":"yes"}`, "/name", "/tail", "/wait"),
+	)
+
+	It("should describe the problems in the error", func() {
+		err := mcp.ValidateArgs("lissto_logs", schema, decode(`{"name":"web","tail":"10"}`))
+		Expect(err).To(MatchError("invalid arguments for lissto_logs: /tail: expected integer, got string"))
+	})
+
+	It("should accept missing arguments of tools without required ones", func() {
+		Expect(mcp.ValidateArgs("t", map[string]interface{}{"type": "object"}, nil)).To(Succeed())
+	})
+
+	It("should accept every declared tool's own defaults", func() {
+		for _, tool := range mcp.GetAllTools() {
+			properties, _ := tool.InputSchema["properties"].(map[string]interface{})
+			args := map[string]interface{}{}
+			for name, p := range properties {
+				if def, ok := p.(map[string]interface{})["default"]; ok {
+					args[name] = def
+				}
+			}
+			err := mcp.ValidateArgs(tool.Name, tool.InputSchema, args)
+			var validationErr *mcp.ValidationError
+			if errors.As(err, &validationErr) {
+				for _, se := range validationErr.Errors {
+					Expect(se.Message).To(Equal("is required"), "%s %s", tool.Name, se.Pointer)
+				}
+			}
+		}
+	})
+
+	It("should answer tools/call with invalid params and pointers", func() {
+		server, err := mcp.NewServer(&bytes.Buffer{}, &bytes.Buffer{}, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		response := server.Handle(context.Background(), &mcp.JSONRPCRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  "tools/call",
+			Params:  json.RawMessage(`{"name":"lissto_logs","arguments":{"tail":"all"}}`),
+		})
+		Expect(response.Error).NotTo(BeNil())
+		Expect(response.Error.Code).To(Equal(mcp.InvalidParams))
+		Expect(response.Error.Data).To(HaveKeyWithValue("tool", "lissto_logs"))
+		Expect(response.Error.Data).To(HaveKeyWithValue("errors", []mcp.SchemaError{{Pointer: "/tail", Message: "expected integer, got string"}}))
+	})
+})
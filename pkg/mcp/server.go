@@ -2,12 +2,17 @@ package mcp
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"sync"
 	"time"
+
+	"github.com/lissto-dev/cli/pkg/client"
 )
 
 // JSONRPCRequest represents a JSON-RPC 2.0 request
@@ -48,13 +53,29 @@ type Server struct {
 	stdout  io.Writer
 	logger  *log.Logger
 	logFile *os.File
+	policy  *Policy
+	audit   *AuditLog
+
+	// slots limits the number of requests handled at once
+	slots chan struct{}
+	// writeMu serializes responses written to stdout
+	writeMu sync.Mutex
+
+	mu       sync.Mutex
+	inflight map[string]*inflightRequest
+	// clients are the clients announced in initialize, by session
+	clients map[string]string
+	wg      sync.WaitGroup
 }
 
 // NewServer creates a new MCP server with optional logging
 func NewServer(stdin io.Reader, stdout io.Writer, logFilePath string) (*Server, error) {
 	server := &Server{
-		stdin:  stdin,
-		stdout: stdout,
+		stdin:    stdin,
+		stdout:   stdout,
+		slots:    make(chan struct{}, DefaultMaxConcurrency),
+		inflight: make(map[string]*inflightRequest),
+		clients:  make(map[string]string),
 	}
 
 	// Setup logging if log file path is provided
@@ -72,6 +93,11 @@ func NewServer(stdin io.Reader, stdout io.Writer, logFilePath string) (*Server,
 	return server, nil
 }
 
+// SetPolicy restricts the tools the server may execute
+func (s *Server) SetPolicy(policy *Policy) {
+	s.policy = policy
+}
+
 // Close closes the log file if it was opened
 func (s *Server) Close() error {
 	if s.logFile != nil {
@@ -90,9 +116,11 @@ func (s *Server) log(format string, args ...interface{}) {
 	}
 }
 
-// Run starts the MCP server and processes requests
-func (s *Server) Run() error {
+// Run starts the MCP server and processes requests. Requests are handled
+// concurrently; Run returns once stdin is closed and all of them finished.
+func (s *Server) Run(ctx context.Context) error {
 	s.log("Starting to listen for requests on stdin")
+	ctx = withCaller(ctx, Caller{Transport: TransportStdio})
 	scanner := bufio.NewScanner(s.stdin)
 
 	for scanner.Scan() {
@@ -103,15 +131,16 @@ func (s *Server) Run() error {
 		var req JSONRPCRequest
 		if err := json.Unmarshal(line, &req); err != nil {
 			s.log("Parse error: %v", err)
-			s.sendError(nil, ParseError, fmt.Sprintf("Parse error: %v", err), nil)
+			s.sendResponse(errorResponse(nil, ParseError, fmt.Sprintf("Parse error: %v", err)))
 			continue
 		}
 
 		s.log("Parsed request - Method: %s, ID: %v", req.Method, req.ID)
 
 		// Handle request
-		s.handleRequest(&req)
+		s.dispatch(ctx, "", &req, s.sendResponse)
 	}
+	s.wg.Wait()
 
 	if err := scanner.Err(); err != nil {
 		s.log("Error reading stdin: %v", err)
@@ -122,8 +151,9 @@ func (s *Server) Run() error {
 	return nil
 }
 
-// handleRequest processes a single JSON-RPC request
-func (s *Server) handleRequest(req *JSONRPCRequest) {
+// Handle processes a single JSON-RPC request and returns its response, or nil
+// for notifications. It is the dispatch layer shared by all transports.
+func (s *Server) Handle(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
 	// Check if this is a notification (no ID field)
 	// Notifications must not receive any response per JSON-RPC 2.0 spec
 	isNotification := req.ID == nil
@@ -134,42 +164,54 @@ func (s *Server) handleRequest(req *JSONRPCRequest) {
 		s.log("Invalid JSON-RPC version: %s", req.JSONRPC)
 		// Only send error for requests, not notifications
 		if !isNotification {
-			s.sendError(req.ID, InvalidRequest, "Invalid JSON-RPC version", nil)
+			return errorResponse(req.ID, InvalidRequest, "Invalid JSON-RPC version")
 		}
-		return
+		return nil
 	}
 
 	// Route to appropriate handler
 	switch req.Method {
 	case "initialize":
 		s.log("Routing to initialize handler")
-		s.handleInitialize(req)
+		s.rememberClient(ctx, req.Params)
+		return s.handleInitialize(req)
 	case "initialized":
 		s.log("Received initialized notification")
 		// This is a notification sent by the client after initialization
 		// No response required per JSON-RPC 2.0 spec
-		return
+		return nil
 	case "tools/list":
 		s.log("Routing to tools/list handler")
-		s.handleToolsList(req)
+		return s.handleToolsList(req)
 	case "tools/call":
 		s.log("Routing to tools/call handler")
-		s.handleToolsCall(req)
+		return s.handleToolsCall(ctx, req)
+	case "resources/list":
+		s.log("Routing to resources/list handler")
+		return s.handleResourcesList(ctx, req)
+	case "resources/templates/list":
+		s.log("Routing to resources/templates/list handler")
+		return resultResponse(req.ID, map[string]interface{}{"resourceTemplates": GetResourceTemplates()})
+	case "resources/read":
+		s.log("Routing to resources/read handler")
+		return s.handleResourcesRead(ctx, req)
 	default:
 		s.log("Method not found: %s", req.Method)
 		// Only send error for requests, not notifications
 		if !isNotification {
-			s.sendError(req.ID, MethodNotFound, fmt.Sprintf("Method not found: %s", req.Method), nil)
+			return errorResponse(req.ID, MethodNotFound, fmt.Sprintf("Method not found: %s", req.Method))
 		}
+		return nil
 	}
 }
 
 // handleInitialize handles the initialize request
-func (s *Server) handleInitialize(req *JSONRPCRequest) {
+func (s *Server) handleInitialize(req *JSONRPCRequest) *JSONRPCResponse {
 	result := map[string]interface{}{
 		"protocolVersion": "2024-11-05",
 		"capabilities": map[string]interface{}{
-			"tools": map[string]interface{}{},
+			"tools":     map[string]interface{}{},
+			"resources": map[string]interface{}{},
 		},
 		"serverInfo": map[string]interface{}{
 			"name":    "lissto-mcp",
@@ -177,21 +219,26 @@ func (s *Server) handleInitialize(req *JSONRPCRequest) {
 		},
 	}
 
-	s.sendResult(req.ID, result)
+	return resultResponse(req.ID, result)
 }
 
 // handleToolsList handles the tools/list request
-func (s *Server) handleToolsList(req *JSONRPCRequest) {
-	tools := GetAllTools()
+func (s *Server) handleToolsList(req *JSONRPCRequest) *JSONRPCResponse {
+	tools := []Tool{}
+	for _, tool := range GetAllTools() {
+		if s.policy.Allowed(tool.Name) {
+			tools = append(tools, tool)
+		}
+	}
 	result := map[string]interface{}{
 		"tools": tools,
 	}
 
-	s.sendResult(req.ID, result)
+	return resultResponse(req.ID, result)
 }
 
 // handleToolsCall handles the tools/call request
-func (s *Server) handleToolsCall(req *JSONRPCRequest) {
+func (s *Server) handleToolsCall(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
 	// Parse params
 	var params struct {
 		Name      string                 `json:"name"`
@@ -200,8 +247,7 @@ func (s *Server) handleToolsCall(req *JSONRPCRequest) {
 
 	if err := json.Unmarshal(req.Params, &params); err != nil {
 		s.log("Failed to parse tool call params: %v", err)
-		s.sendError(req.ID, InvalidParams, fmt.Sprintf("Invalid params: %v", err), nil)
-		return
+		return errorResponse(req.ID, InvalidParams, fmt.Sprintf("Invalid params: %v", err))
 	}
 
 	s.log("========================================")
@@ -210,18 +256,36 @@ func (s *Server) handleToolsCall(req *JSONRPCRequest) {
 	s.log("Tool Arguments: %+v", params.Arguments)
 	s.log("========================================")
 
+	started := time.Now()
+	if err := s.policy.Check(ctx, params.Name, params.Arguments); err != nil {
+		s.log("🚫 TOOL CALL BLOCKED: %v", err)
+		s.audited(ctx, req, params.Name, params.Arguments, started, AuditDenied, err)
+		return permissionDeniedResponse(req.ID, err)
+	}
+
+	// Reject malformed arguments before they reach a handler
+	if tool, ok := findTool(params.Name); ok {
+		if err := ValidateArgs(tool.Name, tool.InputSchema, params.Arguments); err != nil {
+			s.log("❌ INVALID TOOL ARGUMENTS: %v", err)
+			s.audited(ctx, req, params.Name, params.Arguments, started, AuditError, err)
+			return invalidArgsResponse(req.ID, err)
+		}
+	}
+
 	// Execute tool with logger
-	result, err := ExecuteTool(params.Name, params.Arguments, s)
+	result, err := ExecuteTool(ctx, params.Name, params.Arguments, s)
 	if err != nil {
+		s.audited(ctx, req, params.Name, params.Arguments, started, AuditError, err)
 		s.log("❌ TOOL EXECUTION FAILED")
 		s.log("Tool: %s", params.Name)
 		s.log("Error: %v", err)
 		s.log("Error Type: %T", err)
 		s.log("========================================")
-		s.sendError(req.ID, InternalError, err.Error(), nil)
-		return
+		return errorResponse(req.ID, InternalError, err.Error())
 	}
 
+	s.audited(ctx, req, params.Name, params.Arguments, started, AuditSuccess, nil)
+
 	// Log the result
 	resultJSON, _ := json.MarshalIndent(result, "", "  ")
 	s.log("✅ TOOL EXECUTION SUCCESSFUL")
@@ -239,33 +303,96 @@ func (s *Server) handleToolsCall(req *JSONRPCRequest) {
 		},
 	}
 
-	s.sendResult(req.ID, mcpResult)
+	return resultResponse(req.ID, mcpResult)
 }
 
-// sendResult sends a successful JSON-RPC response
-func (s *Server) sendResult(id interface{}, result interface{}) {
-	response := JSONRPCResponse{
+// handleResourcesList handles the resources/list request
+func (s *Server) handleResourcesList(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	resources, err := ListResources(ctx)
+	if err != nil {
+		s.log("Failed to list resources: %v", err)
+		return errorResponse(req.ID, InternalError, err.Error())
+	}
+
+	return resultResponse(req.ID, map[string]interface{}{"resources": resources})
+}
+
+// handleResourcesRead handles the resources/read request
+func (s *Server) handleResourcesRead(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	var params struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		s.log("Failed to parse resource read params: %v", err)
+		return errorResponse(req.ID, InvalidParams, fmt.Sprintf("Invalid params: %v", err))
+	}
+
+	uri, err := ParseResourceURI(params.URI)
+	if err != nil {
+		return errorResponse(req.ID, InvalidParams, err.Error())
+	}
+
+	s.log("Reading resource: %s", params.URI)
+	contents, err := ReadResource(ctx, uri, s)
+	if err != nil {
+		s.log("Failed to read resource %s: %v", params.URI, err)
+		code := InternalError
+		if errors.Is(err, client.ErrNotFound) {
+			code = ResourceNotFound
+		}
+		return errorResponse(req.ID, code, err.Error())
+	}
+
+	return resultResponse(req.ID, map[string]interface{}{"contents": []*ResourceContents{contents}})
+}
+
+// resultResponse builds a successful JSON-RPC response
+func resultResponse(id interface{}, result interface{}) *JSONRPCResponse {
+	return &JSONRPCResponse{
 		JSONRPC: "2.0",
 		ID:      id,
 		Result:  result,
 	}
+}
+
+// permissionDeniedResponse builds the error response for a tool call blocked
+// by the policy
+func permissionDeniedResponse(id interface{}, err error) *JSONRPCResponse {
+	response := errorResponse(id, PermissionDenied, err.Error())
+	var permErr *PermissionError
+	if errors.As(err, &permErr) {
+		response.Error.Data = map[string]interface{}{
+			"tool":   permErr.Tool,
+			"reason": permErr.Reason,
+		}
+	}
+	return response
+}
 
-	s.sendResponse(&response)
+// invalidArgsResponse builds the error response for tool arguments that don't
+// match the tool's schema, pointing at every offending argument
+func invalidArgsResponse(id interface{}, err error) *JSONRPCResponse {
+	response := errorResponse(id, InvalidParams, err.Error())
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		response.Error.Data = map[string]interface{}{
+			"tool":   validationErr.Tool,
+			"errors": validationErr.Errors,
+		}
+	}
+	return response
 }
 
-// sendError sends an error JSON-RPC response
-func (s *Server) sendError(id interface{}, code int, message string, _ interface{}) {
-	response := JSONRPCResponse{
+// errorResponse builds an error JSON-RPC response
+func errorResponse(id interface{}, code int, message string) *JSONRPCResponse {
+	return &JSONRPCResponse{
 		JSONRPC: "2.0",
 		ID:      id,
 		Error: &RPCError{
 			Code:    code,
 			Message: message,
-			Data:    nil,
 		},
 	}
-
-	s.sendResponse(&response)
 }
 
 // sendResponse writes a JSON-RPC response to stdout
@@ -280,6 +407,9 @@ func (s *Server) sendResponse(response *JSONRPCResponse) {
 
 	s.log("Sending response: %s", string(data))
 
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	// Write response followed by newline
 	data = append(data, '\n')
 	if _, err := s.stdout.Write(data); err != nil {
@@ -2,6 +2,7 @@ package mcp_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -49,7 +50,7 @@ var _ = Describe("MCP Server", func() {
 			// Run server in goroutine (it will read one line and stop when stdin closes)
 			errChan := make(chan error, 1)
 			go func() {
-				errChan <- server.Run()
+				errChan <- server.Run(context.Background())
 			}()
 
 			// Wait for response
@@ -96,7 +97,7 @@ var _ = Describe("MCP Server", func() {
 			// Run server
 			errChan := make(chan error, 1)
 			go func() {
-				errChan <- server.Run()
+				errChan <- server.Run(context.Background())
 			}()
 
 			// Wait for response
@@ -144,7 +145,7 @@ var _ = Describe("MCP Server", func() {
 				stdin.Write(requestJSON)
 				stdin.Write([]byte("\n"))
 
-				go func() { _ = server.Run() }()
+				go func() { _ = server.Run(context.Background()) }()
 
 				Eventually(stdout.Len).Should(BeNumerically(">", 0))
 
@@ -173,7 +174,7 @@ var _ = Describe("MCP Server", func() {
 				stdin.Write(requestJSON)
 				stdin.Write([]byte("\n"))
 
-				go func() { _ = server.Run() }()
+				go func() { _ = server.Run(context.Background()) }()
 
 				Eventually(stdout.Len).Should(BeNumerically(">", 0))
 
@@ -192,7 +193,7 @@ var _ = Describe("MCP Server", func() {
 			It("should return ParseError", func() {
 				stdin.Write([]byte("not valid json\n"))
 
-				go func() { _ = server.Run() }()
+				go func() { _ = server.Run(context.Background()) }()
 
 				Eventually(stdout.Len).Should(BeNumerically(">", 0))
 
@@ -222,7 +223,7 @@ var _ = Describe("MCP Server", func() {
 				stdin.Write(requestJSON)
 				stdin.Write([]byte("\n"))
 
-				go func() { _ = server.Run() }()
+				go func() { _ = server.Run(context.Background()) }()
 
 				// Give it a moment to process
 				Consistently(stdout.Len, "500ms").Should(Equal(0))
@@ -254,7 +255,7 @@ var _ = Describe("MCP Server", func() {
 			stdin.Write(req2JSON)
 			stdin.Write([]byte("\n"))
 
-			go func() { _ = server.Run() }()
+			go func() { _ = server.Run(context.Background()) }()
 
 			// Should receive two responses
 			Eventually(func() int {
@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"sort"
+
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+// Default and maximum number of stacks returned by one lissto_status call
+const (
+	defaultStatusLimit = 20
+	maxStatusLimit     = 100
+)
+
+// StatusPage is one page of the stacks lissto_status reports on
+type StatusPage struct {
+	Stacks []types.Stack
+	// Total is the number of stacks matching the filters, across all pages
+	Total int
+	// NextOffset is the offset of the next page, or 0 on the last page
+	NextOffset int
+}
+
+// PageStacks filters stacks by name (optional) and returns the page starting
+// at offset. Stacks are ordered by env, then name, so pages are stable between
+// calls. A limit outside 1..100 is replaced by the default (20) or capped.
+func PageStacks(stacks []types.Stack, stackFilter string, offset, limit int) StatusPage {
+	if limit <= 0 {
+		limit = defaultStatusLimit
+	}
+	if limit > maxStatusLimit {
+		limit = maxStatusLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	matching := make([]types.Stack, 0, len(stacks))
+	for _, stack := range stacks {
+		if stackFilter == "" || stack.Name == stackFilter {
+			matching = append(matching, stack)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].Namespace != matching[j].Namespace {
+			return matching[i].Namespace < matching[j].Namespace
+		}
+		return matching[i].Name < matching[j].Name
+	})
+
+	page := StatusPage{Total: len(matching)}
+	if offset >= len(matching) {
+		page.Stacks = []types.Stack{}
+		return page
+	}
+	end := offset + limit
+	if end < len(matching) {
+		page.NextOffset = end
+	} else {
+		end = len(matching)
+	}
+	page.Stacks = matching[offset:end]
+	return page
+}
@@ -0,0 +1,57 @@
+package mcp_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lissto-dev/cli/pkg/mcp"
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+var _ = Describe("Status paging", func() {
+	stack := func(env, name string) types.Stack {
+		return types.Stack{ObjectMeta: metav1.ObjectMeta{Namespace: env, Name: name}}
+	}
+	names := func(stacks []types.Stack) []string {
+		out := make([]string, 0, len(stacks))
+		for _, s := range stacks {
+			out = append(out, s.Namespace+"/"+s.Name)
+		}
+		return out
+	}
+	stacks := []types.Stack{stack("prod", "api"), stack("dev", "web"), stack("dev", "api"), stack("staging", "api")}
+
+	It("should order stacks by env and name and page through them", func() {
+		page := mcp.PageStacks(stacks, "", 0, 3)
+		Expect(names(page.Stacks)).To(Equal([]string{"dev/api", "dev/web", "prod/api"}))
+		Expect(page.Total).To(Equal(4))
+		Expect(page.NextOffset).To(Equal(3))
+
+		page = mcp.PageStacks(stacks, "", page.NextOffset, 3)
+		Expect(names(page.Stacks)).To(Equal([]string{"staging/api"}))
+		Expect(page.NextOffset).To(BeZero())
+	})
+
+	It("should filter by stack name before paging", func() {
+		page := mcp.PageStacks(stacks, "api", 1, 10)
+		Expect(names(page.Stacks)).To(Equal([]string{"prod/api", "staging/api"}))
+		Expect(page.Total).To(Equal(3))
+	})
+
+	It("should return an empty page past the end", func() {
+		page := mcp.PageStacks(stacks, "", 10, 3)
+		Expect(page.Stacks).To(BeEmpty())
+		Expect(page.Total).To(Equal(4))
+		Expect(page.NextOffset).To(BeZero())
+	})
+
+	It("should default and cap the limit", func() {
+		many := make([]types.Stack, 0, 150)
+		for i := 0; i < 150; i++ {
+			many = append(many, stack("dev", string(rune('a'+i%26))+string(rune('a'+i/26))))
+		}
+		Expect(mcp.PageStacks(many, "", 0, 0).Stacks).To(HaveLen(20))
+		Expect(mcp.PageStacks(many, "", 0, 1000).Stacks).To(HaveLen(100))
+	})
+})
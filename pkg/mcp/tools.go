@@ -225,6 +225,42 @@ func GetAllTools() []Tool {
 				"required": []string{"name"},
 			},
 		},
+		{
+			Name:        "lissto_admin_apikey_list",
+			Description: "List API keys with their role, creator and last use, without secrets (admin only)",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+		{
+			Name:        "lissto_admin_apikey_revoke",
+			Description: "Revoke an API key; requests using it are rejected from then on (admin only)",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the API key to revoke",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+		{
+			Name:        "lissto_admin_apikey_rotate",
+			Description: "Replace an API key's secret, keeping its name and role; the previous secret stops working (admin only)",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the API key to rotate",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
 		{
 			Name:        "lissto_admin_blueprint_delete",
 			Description: "Force delete a blueprint including global blueprints (admin only)",
@@ -263,6 +299,19 @@ func GetAllTools() []Tool {
 						"type":        "string",
 						"description": "Variable config name or ID",
 					},
+					"scope": map[string]interface{}{
+						"type":        "string",
+						"description": "Scope of the config: env, repo, or global (default: env)",
+						"enum":        []string{"env", "repo", "global"},
+					},
+					"env": map[string]interface{}{
+						"type":        "string",
+						"description": "Environment of an env-scoped config (optional)",
+					},
+					"repository": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository of a repo-scoped config (optional)",
+					},
 				},
 				"required": []string{"name"},
 			},
@@ -308,6 +357,19 @@ func GetAllTools() []Tool {
 						"type":        "string",
 						"description": "Variable config name or ID",
 					},
+					"scope": map[string]interface{}{
+						"type":        "string",
+						"description": "Scope of the config: env, repo, or global (default: env)",
+						"enum":        []string{"env", "repo", "global"},
+					},
+					"env": map[string]interface{}{
+						"type":        "string",
+						"description": "Environment of an env-scoped config (optional)",
+					},
+					"repository": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository of a repo-scoped config (optional)",
+					},
 					"data": map[string]interface{}{
 						"type":        "object",
 						"description": "New key-value pairs (replaces existing)",
@@ -329,6 +391,19 @@ func GetAllTools() []Tool {
 						"type":        "string",
 						"description": "Variable config name or ID",
 					},
+					"scope": map[string]interface{}{
+						"type":        "string",
+						"description": "Scope of the config: env, repo, or global (default: env)",
+						"enum":        []string{"env", "repo", "global"},
+					},
+					"env": map[string]interface{}{
+						"type":        "string",
+						"description": "Environment of an env-scoped config (optional)",
+					},
+					"repository": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository of a repo-scoped config (optional)",
+					},
 				},
 				"required": []string{"name"},
 			},
@@ -353,6 +428,19 @@ func GetAllTools() []Tool {
 						"type":        "string",
 						"description": "Secret config name or ID",
 					},
+					"scope": map[string]interface{}{
+						"type":        "string",
+						"description": "Scope of the config: env, repo, or global (default: env)",
+						"enum":        []string{"env", "repo", "global"},
+					},
+					"env": map[string]interface{}{
+						"type":        "string",
+						"description": "Environment of an env-scoped config (optional)",
+					},
+					"repository": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository of a repo-scoped config (optional)",
+					},
 				},
 				"required": []string{"name"},
 			},
@@ -398,6 +486,19 @@ func GetAllTools() []Tool {
 						"type":        "string",
 						"description": "Secret config name or ID",
 					},
+					"scope": map[string]interface{}{
+						"type":        "string",
+						"description": "Scope of the config: env, repo, or global (default: env)",
+						"enum":        []string{"env", "repo", "global"},
+					},
+					"env": map[string]interface{}{
+						"type":        "string",
+						"description": "Environment of an env-scoped config (optional)",
+					},
+					"repository": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository of a repo-scoped config (optional)",
+					},
 					"secrets": map[string]interface{}{
 						"type":        "object",
 						"description": "Key-value pairs to set",
@@ -419,6 +520,19 @@ func GetAllTools() []Tool {
 						"type":        "string",
 						"description": "Secret config name or ID",
 					},
+					"scope": map[string]interface{}{
+						"type":        "string",
+						"description": "Scope of the config: env, repo, or global (default: env)",
+						"enum":        []string{"env", "repo", "global"},
+					},
+					"env": map[string]interface{}{
+						"type":        "string",
+						"description": "Environment of an env-scoped config (optional)",
+					},
+					"repository": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository of a repo-scoped config (optional)",
+					},
 				},
 				"required": []string{"name"},
 			},
@@ -427,7 +541,7 @@ func GetAllTools() []Tool {
 		// Status and logs tools
 		{
 			Name:        "lissto_status",
-			Description: "Get detailed status of stacks and their pods",
+			Description: "Get detailed status of stacks and their pods. Results are paged: if next_offset is returned, call again with offset set to it for more stacks.",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
@@ -435,12 +549,31 @@ func GetAllTools() []Tool {
 						"type":        "string",
 						"description": "Filter by environment name (optional)",
 					},
+					"stack": map[string]interface{}{
+						"type":        "string",
+						"description": "Filter by stack name (optional)",
+					},
+					"summary_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only report stack state, without per-pod details (faster)",
+						"default":     false,
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of stacks to return (at most 100)",
+						"default":     20,
+					},
+					"offset": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of stacks to skip, e.g. next_offset from a previous call",
+						"default":     0,
+					},
 				},
 			},
 		},
 		{
 			Name:        "lissto_logs",
-			Description: "Get recent logs from stack pods (not streaming, returns last N lines)",
+			Description: "Get recent logs from stack pods (not streaming, returns last N lines). Output is limited to max_bytes; if truncated, call again with cursor set to next_cursor to page through the rest.",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
@@ -470,6 +603,19 @@ func GetAllTools() []Tool {
 						"description": "Maximum number of pods to get logs from",
 						"default":     5,
 					},
+					"since": map[string]interface{}{
+						"type":        "string",
+						"description": "Only return logs newer than a duration (e.g. 10m) or RFC3339 timestamp; overrides tail (optional)",
+					},
+					"cursor": map[string]interface{}{
+						"type":        "string",
+						"description": "next_cursor from a previous call, to continue after the lines already returned (optional)",
+					},
+					"max_bytes": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum total bytes of log output to return",
+						"default":     65536,
+					},
 				},
 			},
 		},
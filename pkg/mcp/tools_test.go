@@ -134,6 +134,15 @@ var _ = Describe("MCP Tools", func() {
 				Expect(required).To(ContainElement("name"))
 			})
 
+			It("should include API key management tools", func() {
+				Expect(findTool(tools, "lissto_admin_apikey_list")).NotTo(BeNil())
+				for _, name := range []string{"lissto_admin_apikey_revoke", "lissto_admin_apikey_rotate"} {
+					tool := findTool(tools, name)
+					Expect(tool).NotTo(BeNil())
+					Expect(tool.InputSchema["required"]).To(ConsistOf("name"))
+				}
+			})
+
 			It("should include lissto_admin_blueprint_delete", func() {
 				tool := findTool(tools, "lissto_admin_blueprint_delete")
 				Expect(tool).NotTo(BeNil())
@@ -0,0 +1,128 @@
+package messages
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"text/template"
+
+	"github.com/lissto-dev/cli/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvMessagesFile points to an alternative message catalog file
+const EnvMessagesFile = "LISSTO_MESSAGES_FILE"
+
+// messagesFileName is the catalog override file inside the config directory
+const messagesFileName = "messages.yaml"
+
+// Key identifies a user-facing message in the catalog
+type Key string
+
+// Message keys
+const (
+	NoActiveContext      Key = "no-active-context"
+	NoEnvSelected        Key = "no-env-selected"
+	NoEnvForScope        Key = "no-env-for-scope"
+	StatusTip            Key = "status-tip"
+	StackAlreadyExists   Key = "stack-already-exists"
+	UseUpdateForExisting Key = "use-update-for-existing"
+	VerifyWarningsHint   Key = "verify-warnings-hint"
+	RepoNotConfigured    Key = "repo-not-configured"
+)
+
+// defaults is the built-in English catalog. Values are text/template strings.
+var defaults = map[Key]string{
+	NoActiveContext:      "no active context. Run 'lissto login' first",
+	NoEnvSelected:        "no environment selected. Use --env flag or 'lissto env use <name>'",
+	NoEnvForScope:        "env is required for scope=env. Set with --env or run 'lissto env use <env>'",
+	StatusTip:            "💡 Tip: Use 'lissto logs' to view logs, 'lissto update' to update images",
+	StackAlreadyExists:   "💡 Tip: Use 'lissto update' to update the stack with new images",
+	UseUpdateForExisting: "💡 Please run 'lissto update' to update the existing stack",
+	VerifyWarningsHint:   "💡 Run 'lissto verify {{.File}} --verbose' to see details",
+	RepoNotConfigured: `💡 This repository is not in your Lissto configuration.
+   If using SSH host aliases (e.g., github.com-lissto), try:
+   • Set {{.EnvVar}}=git@github.com:org/repo.git
+   • Or use: lissto blueprint create <file> --repository git@github.com:org/repo.git`,
+}
+
+var (
+	mu        sync.Mutex
+	loaded    bool
+	overrides map[Key]string
+)
+
+// Get renders the message for key with the given template data.
+// Organization overrides take precedence over the built-in catalog.
+func Get(key Key, data any) string {
+	mu.Lock()
+	if !loaded {
+		overrides, loaded = loadOverrides(), true
+	}
+	text, ok := overrides[key]
+	mu.Unlock()
+	if !ok {
+		text, ok = defaults[key]
+	}
+	if !ok {
+		return string(key)
+	}
+	return render(key, text, data)
+}
+
+// Error returns an error whose message comes from the catalog
+func Error(key Key, data any) error {
+	return fmt.Errorf("%s", Get(key, data))
+}
+
+// Wrap returns a catalog error wrapping err
+func Wrap(key Key, data any, err error) error {
+	return fmt.Errorf("%s: %w", Get(key, data), err)
+}
+
+// Reload reads the override catalog again, e.g. after LISSTO_MESSAGES_FILE
+// changed. Get loads it on first use.
+func Reload() {
+	m := loadOverrides()
+	mu.Lock()
+	defer mu.Unlock()
+	overrides, loaded = m, true
+}
+
+func render(key Key, text string, data any) string {
+	tmpl, err := template.New(string(key)).Parse(text)
+	if err != nil {
+		return text
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return text
+	}
+	return buf.String()
+}
+
+// loadOverrides reads the override catalog from LISSTO_MESSAGES_FILE or
+// the config directory. Missing or invalid files fall back to defaults.
+func loadOverrides() map[Key]string {
+	path := os.Getenv(EnvMessagesFile)
+	if path == "" {
+		configDir, err := config.GetConfigDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(configDir, messagesFileName)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var m map[Key]string
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	return m
+}
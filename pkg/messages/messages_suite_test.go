@@ -0,0 +1,13 @@
+package messages_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMessages(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Messages Suite")
+}
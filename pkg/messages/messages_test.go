@@ -0,0 +1,73 @@
+package messages_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/messages"
+)
+
+var _ = Describe("Messages", func() {
+	var configHome string
+
+	// load writes an override catalog to path and reloads it
+	load := func(path, content string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		messages.Reload()
+	}
+
+	BeforeEach(func() {
+		configHome = GinkgoT().TempDir()
+		GinkgoT().Setenv("XDG_CONFIG_HOME", configHome)
+		GinkgoT().Setenv(messages.EnvMessagesFile, "")
+		messages.Reload()
+		DeferCleanup(messages.Reload)
+	})
+
+	It("should render the built-in catalog", func() {
+		Expect(messages.Get(messages.VerifyWarningsHint, map[string]string{"File": "compose.yaml"})).
+			To(Equal("💡 Run 'lissto verify compose.yaml --verbose' to see details"))
+		Expect(messages.Error(messages.NoActiveContext, nil)).To(MatchError("no active context. Run 'lissto login' first"))
+
+		err := messages.Wrap(messages.NoEnvSelected, nil, os.ErrNotExist)
+		Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue())
+	})
+
+	It("should return the key of an unknown message", func() {
+		Expect(messages.Get("no-such-message", nil)).To(Equal("no-such-message"))
+	})
+
+	It("should load overrides from messages.yaml in the config directory", func() {
+		load(filepath.Join(configHome, "lissto", "messages.yaml"), `no-active-context: "not logged in, ask #platform"`)
+		Expect(messages.Get(messages.NoActiveContext, nil)).To(Equal("not logged in, ask #platform"))
+		// Keys missing from the overrides fall back to the built-in catalog
+		Expect(messages.Get(messages.StatusTip, nil)).To(HavePrefix("💡 Tip: Use 'lissto logs'"))
+	})
+
+	It("should prefer the file named by LISSTO_MESSAGES_FILE", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "org-messages.yaml")
+		GinkgoT().Setenv(messages.EnvMessagesFile, path)
+		load(filepath.Join(configHome, "lissto", "messages.yaml"), `no-active-context: from config dir`)
+		load(path, `no-active-context: "from {{.Org}}"`)
+		Expect(messages.Get(messages.NoActiveContext, map[string]string{"Org": "acme"})).To(Equal("from acme"))
+	})
+
+	It("should ignore an invalid override file", func() {
+		load(filepath.Join(configHome, "lissto", "messages.yaml"), "no-active-context: [unterminated")
+		Expect(messages.Get(messages.NoActiveContext, nil)).To(Equal("no active context. Run 'lissto login' first"))
+	})
+
+	It("should show the raw text of a template that doesn't render", func() {
+		load(filepath.Join(configHome, "lissto", "messages.yaml"), `
+no-active-context: "broken {{.File"
+status-tip: "tip for {{.File.Name}}"
+`)
+		Expect(messages.Get(messages.NoActiveContext, nil)).To(Equal("broken {{.File"))
+		Expect(messages.Get(messages.StatusTip, map[string]string{"File": "x"})).To(Equal("tip for {{.File.Name}}"))
+	})
+})
@@ -0,0 +1,105 @@
+// Package notify tells the user about stack readiness changes, through a
+// desktop notification or a Slack/Discord webhook
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Desktop is the target for a desktop notification
+const Desktop = "desktop"
+
+// webhookTimeout bounds a webhook post
+const webhookTimeout = 10 * time.Second
+
+// Send delivers a notification to target: Desktop, or a Slack or Discord
+// incoming webhook URL
+func Send(ctx context.Context, target, title, message string) error {
+	if target == "" || target == Desktop {
+		return sendDesktop(title, message)
+	}
+	return sendWebhook(ctx, target, title, message)
+}
+
+// ValidateTarget checks that target is Desktop or an http(s) URL
+func ValidateTarget(target string) error {
+	if target == Desktop {
+		return nil
+	}
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid notification target '%s': use '%s' or a webhook URL", target, Desktop)
+	}
+	return nil
+}
+
+func sendDesktop(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		return fmt.Errorf("desktop notifications are not supported on windows, use a webhook")
+	default:
+		cmd = exec.Command("notify-send", title, message)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to send desktop notification: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// webhookPayload builds the message body of a webhook: Discord expects
+// "content", Slack (and compatible services) "text"
+func webhookPayload(webhookURL, title, message string) map[string]string {
+	text := fmt.Sprintf("*%s*\n%s", title, message)
+	if u, err := url.Parse(webhookURL); err == nil && isDiscord(u.Hostname()) {
+		return map[string]string{"content": fmt.Sprintf("**%s**\n%s", title, message)}
+	}
+	return map[string]string{"text": text}
+}
+
+func isDiscord(host string) bool {
+	return host == "discord.com" || host == "discordapp.com" || strings.HasSuffix(host, ".discord.com")
+}
+
+func sendWebhook(ctx context.Context, webhookURL, title, message string) error {
+	body, err := json.Marshal(webhookPayload(webhookURL, title, message))
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post webhook: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/notify"
+)
+
+var _ = Describe("Notify", func() {
+	It("should post Slack style messages to webhooks", func() {
+		var body map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		}))
+		defer server.Close()
+
+		Expect(notify.Send(context.Background(), server.URL, "Stack ready", "my-stack is ready")).To(Succeed())
+		Expect(body).To(Equal(map[string]string{"text": "*Stack ready*\nmy-stack is ready"}))
+	})
+
+	It("should report webhook failures", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		err := notify.Send(context.Background(), server.URL, "Stack ready", "my-stack is ready")
+		Expect(err).To(MatchError(ContainSubstring("403")))
+	})
+
+	It("should accept desktop and webhook URLs as targets", func() {
+		Expect(notify.ValidateTarget(notify.Desktop)).To(Succeed())
+		Expect(notify.ValidateTarget("https://hooks.slack.com/services/T/B/X")).To(Succeed())
+		Expect(notify.ValidateTarget("slack")).To(MatchError(ContainSubstring("invalid notification target")))
+	})
+})
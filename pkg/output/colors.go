@@ -1,28 +1,71 @@
 package output
 
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
 const (
 	ColorReset  = "\033[0m"
 	ColorRed    = "\033[31m"
 	ColorGreen  = "\033[32m"
 	ColorYellow = "\033[33m"
+	ColorBlue   = "\033[34m"
+	ColorPurple = "\033[35m"
+	ColorCyan   = "\033[36m"
 	ColorGray   = "\033[90m"
 	ColorBold   = "\033[1m"
 )
 
+// colorEnabled decides whether formatters emit ANSI color codes
+var colorEnabled = detectColor()
+
+// detectColor enables color only for terminals, unless NO_COLOR is set
+// (https://no-color.org) or the terminal is dumb
+func detectColor() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// SetColor turns colored output on or off, e.g. for --no-color
+func SetColor(enabled bool) {
+	colorEnabled = enabled
+}
+
+// ColorEnabled reports whether colored output is on
+func ColorEnabled() bool {
+	return colorEnabled
+}
+
+// Colorize wraps s in an ANSI color code when color is enabled
+func Colorize(color, s string) string {
+	if !colorEnabled {
+		return s
+	}
+	return color + s + ColorReset
+}
+
 func Gray(s string) string {
-	return ColorGray + s + ColorReset
+	return Colorize(ColorGray, s)
+}
+
+func Red(s string) string {
+	return Colorize(ColorRed, s)
 }
 
 func Yellow(s string) string {
-	return ColorYellow + s + ColorReset
+	return Colorize(ColorYellow, s)
 }
 
 func Green(s string) string {
-	return ColorGreen + s + ColorReset
+	return Colorize(ColorGreen, s)
 }
 
 func Bold(s string) string {
-	return ColorBold + s + ColorReset
+	return Colorize(ColorBold, s)
 }
 
 func GreenCheck() string {
@@ -0,0 +1,32 @@
+package output_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/output"
+)
+
+var _ = Describe("Colors", func() {
+	var enabled bool
+
+	BeforeEach(func() {
+		enabled = output.ColorEnabled()
+	})
+
+	AfterEach(func() {
+		output.SetColor(enabled)
+	})
+
+	It("should wrap text in color codes when enabled", func() {
+		output.SetColor(true)
+		Expect(output.Red("error")).To(Equal(output.ColorRed + "error" + output.ColorReset))
+		Expect(output.Colorize(output.ColorCyan, "pod")).To(Equal(output.ColorCyan + "pod" + output.ColorReset))
+	})
+
+	It("should return plain text when disabled", func() {
+		output.SetColor(false)
+		Expect(output.Gray("done")).To(Equal("done"))
+		Expect(output.GreenCheck()).To(Equal("✓"))
+	})
+})
@@ -0,0 +1,130 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/client"
+)
+
+// Reasons an image candidate could not be resolved
+const (
+	ReasonTagNotFound   = "tag not found"
+	ReasonRegistryAuth  = "registry auth"
+	ReasonArchMismatch  = "architecture mismatch"
+	ReasonRepoNotFound  = "repository not found"
+	ReasonNetwork       = "registry unreachable"
+	ReasonNoCandidates  = "no candidates"
+	ReasonNotConfigured = "no image configured"
+	ReasonUnknown       = "unknown"
+)
+
+// registryErrorCodes maps OCI distribution error codes, as in
+// "MANIFEST_UNKNOWN: manifest unknown", to a diagnosis
+var registryErrorCodes = map[string]string{
+	"MANIFEST_UNKNOWN": ReasonTagNotFound,
+	"NAME_UNKNOWN":     ReasonRepoNotFound,
+	"UNAUTHORIZED":     ReasonRegistryAuth,
+	"DENIED":           ReasonRegistryAuth,
+}
+
+// httpStatusReasons maps registry HTTP status codes to a diagnosis
+var httpStatusReasons = map[string]string{
+	"401": ReasonRegistryAuth,
+	"403": ReasonRegistryAuth,
+	"404": ReasonTagNotFound,
+}
+
+var (
+	registryCodePattern = regexp.MustCompile(`\b([A-Z]+(?:_[A-Z]+)*): `)
+	// statusPattern matches "status code 401" and "401 Unauthorized"
+	statusPattern = regexp.MustCompile(`(?i)\bstatus(?: code)?:? (\d{3})\b|\b(\d{3}) (?:unauthorized|forbidden|not found)\b`)
+	// urlPattern matches URLs, whose repository names and tags could look
+	// like any of the phrases below
+	urlPattern = regexp.MustCompile(`"?https?://\S+`)
+)
+
+// reasonPhrases maps lowercase phrases of registry and network errors without
+// an error or status code to a diagnosis, checked in order
+var reasonPhrases = []struct {
+	reason  string
+	phrases []string
+}{
+	{ReasonArchMismatch, []string{"no matching manifest for", "no child with platform", "does not match the specified platform"}},
+	{ReasonRegistryAuth, []string{"authentication required", "pull access denied", "no basic auth credentials"}},
+	{ReasonRepoNotFound, []string{"name unknown", "repository name not known", "repository does not exist"}},
+	{ReasonTagNotFound, []string{"manifest unknown"}},
+	{ReasonNetwork, []string{"no such host", "connection refused", "i/o timeout", "tls handshake timeout", "x509:", "context deadline exceeded", "client.timeout exceeded", "network is unreachable"}},
+}
+
+// DiagnoseCandidate classifies why an image candidate was rejected, by the
+// registry error code, then the HTTP status code, then known phrases
+func DiagnoseCandidate(candidate client.ImageCandidate) string {
+	if candidate.Error == "" {
+		return ReasonUnknown
+	}
+	for _, m := range registryCodePattern.FindAllStringSubmatch(candidate.Error, -1) {
+		if reason, ok := registryErrorCodes[m[1]]; ok {
+			return reason
+		}
+	}
+	if m := statusPattern.FindStringSubmatch(candidate.Error); m != nil {
+		if reason, ok := httpStatusReasons[m[1]+m[2]]; ok {
+			return reason
+		}
+	}
+
+	msg := strings.ToLower(urlPattern.ReplaceAllString(candidate.Error, ""))
+	for _, rp := range reasonPhrases {
+		for _, phrase := range rp.phrases {
+			if strings.Contains(msg, phrase) {
+				return rp.reason
+			}
+		}
+	}
+	return ReasonUnknown
+}
+
+// PrintImageDiagnostics prints a per-service table explaining why each
+// candidate image of a missing service was rejected
+func PrintImageDiagnostics(w io.Writer, images []client.DetailedImageResolutionInfo) {
+	headers := []string{"SERVICE", "CANDIDATE", "REASON", "DETAIL"}
+	var rows [][]string
+
+	for _, img := range images {
+		if img.Digest != "" && img.Digest != notAvailable {
+			continue
+		}
+
+		if len(img.Candidates) == 0 {
+			reason := ReasonNoCandidates
+			if img.ImageName == "" && img.Image == "" {
+				reason = ReasonNotConfigured
+			}
+			rows = append(rows, []string{img.Service, "-", reason, img.Method})
+			continue
+		}
+
+		for _, candidate := range img.Candidates {
+			if candidate.Success {
+				continue
+			}
+			image := candidate.ImageURL
+			if candidate.Tag != "" && !strings.HasSuffix(image, ":"+candidate.Tag) {
+				image = fmt.Sprintf("%s:%s", image, candidate.Tag)
+			}
+			rows = append(rows, []string{img.Service, image, DiagnoseCandidate(candidate), candidate.Error})
+		}
+	}
+
+	if len(rows) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, "\n🩺 Image Diagnostics:")
+	_, _ = fmt.Fprintln(w, "")
+	PrintTableWithPriorities(w, headers, rows, ColumnPriorities{"DETAIL": 2, "CANDIDATE": 1})
+	_, _ = fmt.Fprintln(w, "")
+}
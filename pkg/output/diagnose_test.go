@@ -0,0 +1,79 @@
+package output_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/output"
+)
+
+var _ = Describe("Image diagnostics", func() {
+	DescribeTable("DiagnoseCandidate",
+		func(err, reason string) {
+			Expect(output.DiagnoseCandidate(client.ImageCandidate{Error: err})).To(Equal(reason))
+		},
+		Entry("no error", "", output.ReasonUnknown),
+		Entry("missing tag on Docker Hub",
+			"GET https://index.docker.io/v2/library/nginx/manifests/nope: MANIFEST_UNKNOWN: manifest unknown; unknown tag=nope",
+			output.ReasonTagNotFound),
+		Entry("missing tag in a repository named like a phrase",
+			"GET https://ghcr.io/v2/acme/platform-api/manifests/feature-x: MANIFEST_UNKNOWN: manifest unknown",
+			output.ReasonTagNotFound),
+		Entry("missing repository on ECR",
+			"GET https://123456789012.dkr.ecr.us-east-1.amazonaws.com/v2/web/manifests/v1: NAME_UNKNOWN: The repository with name 'web' does not exist in the registry with id '123456789012'",
+			output.ReasonRepoNotFound),
+		Entry("unauthorized on Docker Hub",
+			"GET https://index.docker.io/v2/acme/private/manifests/v1: UNAUTHORIZED: authentication required; [map[Action:pull Class: Name:acme/private Type:repository]]",
+			output.ReasonRegistryAuth),
+		Entry("denied on Artifact Registry",
+			`GET https://europe-docker.pkg.dev/v2/acme/images/web/manifests/v1: DENIED: Permission "artifactregistry.repositories.downloadArtifacts" denied on resource "projects/acme/locations/europe/repositories/images" (or it may not exist)`,
+			output.ReasonRegistryAuth),
+		Entry("token endpoint refusing credentials",
+			"GET https://ghcr.io/token?scope=repository%3Aacme%2Fweb%3Apull&service=ghcr.io: unexpected status code 401 Unauthorized",
+			output.ReasonRegistryAuth),
+		Entry("forbidden without a body",
+			"HEAD https://registry.acme.dev/v2/web/manifests/v1: unexpected status code 403 Forbidden (HEAD responses have no body, use GET for details)",
+			output.ReasonRegistryAuth),
+		Entry("not found without a body",
+			"HEAD https://registry.acme.dev/v2/web/manifests/v1: unexpected status code 404 Not Found (HEAD responses have no body, use GET for details)",
+			output.ReasonTagNotFound),
+		Entry("no image for the platform",
+			"no child with platform linux/arm64 in index ghcr.io/acme/web:v1",
+			output.ReasonArchMismatch),
+		Entry("no image for the platform from the Docker daemon",
+			"no matching manifest for linux/arm64/v8 in the manifest list entries",
+			output.ReasonArchMismatch),
+		Entry("unknown host",
+			`Get "https://registry.acme.dev/v2/": dial tcp: lookup registry.acme.dev on 127.0.0.53:53: no such host`,
+			output.ReasonNetwork),
+		Entry("untrusted certificate",
+			`Get "https://registry.acme.dev/v2/": tls: failed to verify certificate: x509: certificate signed by unknown authority`,
+			output.ReasonNetwork),
+		Entry("timeout",
+			`Get "https://registry.acme.dev/v2/": net/http: request canceled while waiting for connection (Client.Timeout exceeded while awaiting headers)`,
+			output.ReasonNetwork),
+		Entry("digest containing a status code",
+			"GET https://registry.acme.dev/v2/web/manifests/sha256:4041cafe: unexpected status code 500 Internal Server Error",
+			output.ReasonUnknown),
+	)
+
+	It("should list the rejected candidates of missing services", func() {
+		var buf bytes.Buffer
+		output.PrintImageDiagnostics(&buf, []client.DetailedImageResolutionInfo{
+			{Service: "web", Digest: "sha256:web"},
+			{Service: "api", Digest: "N/A", Candidates: []client.ImageCandidate{
+				{ImageURL: "ghcr.io/acme/api", Tag: "feature", Error: "GET https://ghcr.io/v2/acme/api/manifests/feature: MANIFEST_UNKNOWN: manifest unknown"},
+				{ImageURL: "ghcr.io/acme/api", Tag: "main", Success: true},
+			}},
+			{Service: "db"},
+		})
+		Expect(buf.String()).To(ContainSubstring("ghcr.io/acme/api:feature"))
+		Expect(buf.String()).To(ContainSubstring(output.ReasonTagNotFound))
+		Expect(buf.String()).To(ContainSubstring(output.ReasonNotConfigured))
+		Expect(buf.String()).NotTo(ContainSubstring("web"))
+		Expect(buf.String()).NotTo(ContainSubstring("api:main"))
+	})
+})
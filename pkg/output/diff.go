@@ -0,0 +1,29 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/diff"
+)
+
+// PrintUnifiedDiff prints a unified diff with colored file headers, hunk
+// headers, insertions and deletions
+func PrintUnifiedDiff(w io.Writer, unified string) {
+	for i, line := range diff.SplitLines(unified) {
+		switch {
+		case i < 2:
+			// --- / +++ file header
+			_, _ = fmt.Fprintln(w, Bold(line))
+		case strings.HasPrefix(line, "@@"):
+			_, _ = fmt.Fprintln(w, Gray(line))
+		case strings.HasPrefix(line, "+"):
+			_, _ = fmt.Fprintln(w, Green(line))
+		case strings.HasPrefix(line, "-"):
+			_, _ = fmt.Fprintln(w, Red(line))
+		default:
+			_, _ = fmt.Fprintln(w, line)
+		}
+	}
+}
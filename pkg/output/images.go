@@ -0,0 +1,113 @@
+package output
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lissto-dev/cli/pkg/registry"
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+// StackImage describes the image a stack service runs and where it came from
+type StackImage struct {
+	Service  string `json:"service" yaml:"service"`
+	Image    string `json:"image" yaml:"image"`
+	Digest   string `json:"digest" yaml:"digest"`
+	Registry string `json:"registry,omitempty" yaml:"registry,omitempty"`
+	// Branch, Tag and Commit are what the stack's images were resolved from
+	Branch string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Tag    string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	// Provenance is read from the image's OCI labels, when the registry allows
+	Provenance *registry.Provenance `json:"provenance,omitempty" yaml:"provenance,omitempty"`
+}
+
+// StackImages lists the images of a stack by service, with the provenance
+// found for them (keyed by service, may be nil)
+func StackImages(stack *types.Stack, provenance map[string]*registry.Provenance) []StackImage {
+	images := make([]StackImage, 0, len(stack.Spec.Images))
+	for service, img := range stack.Spec.Images {
+		image := StackImage{
+			Service:    service,
+			Image:      img.Image,
+			Digest:     img.Digest,
+			Branch:     stack.Spec.Metadata.Branch,
+			Tag:        stack.Spec.Metadata.Tag,
+			Commit:     stack.Spec.Metadata.Commit,
+			Provenance: provenance[service],
+		}
+		ref := img.Image
+		if ref == "" {
+			ref = img.Digest
+		}
+		if parsed, err := registry.ParseReference(ref); err == nil {
+			image.Registry = parsed.Registry
+		}
+		images = append(images, image)
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].Service < images[j].Service })
+	return images
+}
+
+// PrintStackImages prints stack images as a table
+func PrintStackImages(w io.Writer, images []StackImage) {
+	headers := []string{"SERVICE", "IMAGE", "DIGEST", "RESOLVED FROM", "COMMIT", "BUILT", "REGISTRY"}
+	rows := make([][]string, 0, len(images))
+	for _, img := range images {
+		commit, built := "-", "-"
+		if p := img.Provenance; p != nil {
+			if p.Revision != "" {
+				commit = p.ShortRevision()
+			}
+			if !p.Created.IsZero() {
+				_, built = FormatTimestamp(p.Created)
+			}
+		}
+		rows = append(rows, []string{
+			img.Service,
+			orDash(img.Image),
+			orDash(shortDigest(img.Digest)),
+			orDash(img.resolvedFrom()),
+			commit,
+			built,
+			orDash(img.Registry),
+		})
+	}
+	PrintTableWithPriorities(w, headers, rows, ColumnPriorities{"IMAGE": 1})
+}
+
+// resolvedFrom describes the branch, tag or commit the image was resolved from
+func (img StackImage) resolvedFrom() string {
+	var parts []string
+	if img.Branch != "" {
+		parts = append(parts, "branch "+img.Branch)
+	}
+	if img.Tag != "" {
+		parts = append(parts, "tag "+img.Tag)
+	}
+	if img.Commit != "" {
+		commit := img.Commit
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		parts = append(parts, "commit "+commit)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// shortDigest abbreviates a sha256 digest to the 12 characters docker shows
+func shortDigest(digest string) string {
+	hex := strings.TrimPrefix(digest, "sha256:")
+	if hex == digest || len(hex) <= 12 {
+		return digest
+	}
+	return hex[:12]
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
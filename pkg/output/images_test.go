@@ -0,0 +1,56 @@
+package output_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+
+	"github.com/lissto-dev/cli/pkg/output"
+	"github.com/lissto-dev/cli/pkg/registry"
+	"github.com/lissto-dev/cli/pkg/types"
+)
+
+var _ = Describe("StackImages", func() {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	stack := &types.Stack{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-stack"},
+		Spec: types.StackSpec{
+			Images: map[string]envv1alpha1.ImageInfo{
+				"web": {Image: "nginx:1.27", Digest: digest},
+				"api": {Image: "ghcr.io/org/api:main", Digest: digest},
+			},
+			Metadata: envv1alpha1.StackMetadata{Branch: "main"},
+		},
+	}
+
+	It("should list services in order with their registry and resolved ref", func() {
+		images := output.StackImages(stack, nil)
+		Expect(images).To(HaveLen(2))
+		Expect(images[0].Service).To(Equal("api"))
+		Expect(images[0].Registry).To(Equal("ghcr.io"))
+		Expect(images[0].Branch).To(Equal("main"))
+		Expect(images[1].Service).To(Equal("web"))
+		Expect(images[1].Registry).To(Equal("docker.io"))
+		Expect(images[1].Provenance).To(BeNil())
+	})
+
+	It("should attach provenance by service", func() {
+		p := &registry.Provenance{Revision: "abcdef0123456789", Created: time.Now().Add(-2 * time.Hour)}
+		images := output.StackImages(stack, map[string]*registry.Provenance{"api": p})
+		Expect(images[0].Provenance).To(Equal(p))
+		Expect(images[1].Provenance).To(BeNil())
+
+		var buf bytes.Buffer
+		output.PrintStackImages(&buf, images)
+		Expect(buf.String()).To(ContainSubstring("0123456789ab"))
+		Expect(buf.String()).To(ContainSubstring("branch main"))
+		Expect(buf.String()).To(ContainSubstring("abcdef0"))
+		Expect(buf.String()).To(ContainSubstring("2h ago"))
+	})
+})
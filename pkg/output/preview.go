@@ -6,12 +6,20 @@ import (
 	"os"
 
 	"github.com/lissto-dev/cli/pkg/client"
+	"github.com/lissto-dev/cli/pkg/registry"
 )
 
 const notAvailable = "N/A"
 
 // PrintImagePreview prints a preview of resolved images in table format
 func PrintImagePreview(w io.Writer, images []client.DetailedImageResolutionInfo, exposed []client.ExposedServiceInfo) {
+	PrintImagePreviewWithProvenance(w, images, exposed, nil)
+}
+
+// PrintImagePreviewWithProvenance prints the image preview with optional
+// BUILT/COMMIT columns (when provenance is non-nil, keyed by service) and a
+// VULNS column (when the API returned scan results)
+func PrintImagePreviewWithProvenance(w io.Writer, images []client.DetailedImageResolutionInfo, exposed []client.ExposedServiceInfo, provenance map[string]*registry.Provenance) {
 	// Create URL map for quick lookup
 	urlMap := make(map[string]string)
 	for _, exp := range exposed {
@@ -22,7 +30,16 @@ func PrintImagePreview(w io.Writer, images []client.DetailedImageResolutionInfo,
 	_, _ = fmt.Fprintln(w, "\n🔍 Image Preview:")
 	_, _ = fmt.Fprintln(w, "")
 
-	headers := []string{"SERVICE", "STATUS", "IMAGE", "URL"}
+	showVulns := hasVulnerabilityData(images)
+
+	headers := []string{"SERVICE", "STATUS", "IMAGE"}
+	if provenance != nil {
+		headers = append(headers, "BUILT", "COMMIT")
+	}
+	if showVulns {
+		headers = append(headers, "VULNS")
+	}
+	headers = append(headers, "URL")
 	rows := make([][]string, 0, len(images))
 
 	for _, img := range images {
@@ -60,13 +77,55 @@ func PrintImagePreview(w io.Writer, images []client.DetailedImageResolutionInfo,
 			url = fmt.Sprintf("https://%s", exposedURL)
 		}
 
-		rows = append(rows, []string{img.Service, status, image, url})
+		row := []string{img.Service, status, image}
+		if provenance != nil {
+			built, commit := "-", "-"
+			if p := provenance[img.Service]; p != nil {
+				if !p.Created.IsZero() {
+					_, built = FormatTimestamp(p.Created)
+				}
+				if p.Revision != "" {
+					commit = p.ShortRevision()
+				}
+			}
+			row = append(row, built, commit)
+		}
+		if showVulns {
+			row = append(row, FormatVulnerabilities(img.Vulnerabilities))
+		}
+		row = append(row, url)
+
+		rows = append(rows, row)
 	}
 
-	PrintTable(w, headers, rows)
+	PrintTableWithPriorities(w, headers, rows, ColumnPriorities{"URL": 2, "IMAGE": 1})
 	_, _ = fmt.Fprintln(w, "")
 }
 
+// FormatVulnerabilities renders a vulnerability summary as e.g. "2C 5H 10M 3L"
+func FormatVulnerabilities(v *client.VulnerabilitySummary) string {
+	if v == nil {
+		return "-"
+	}
+	if v.Total() == 0 {
+		return "✅ 0"
+	}
+	text := fmt.Sprintf("%dC %dH %dM %dL", v.Critical, v.High, v.Medium, v.Low)
+	if v.Critical > 0 {
+		return "⚠️  " + text
+	}
+	return text
+}
+
+func hasVulnerabilityData(images []client.DetailedImageResolutionInfo) bool {
+	for _, img := range images {
+		if img.Vulnerabilities != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // PrintImagePreviewJSON prints image preview in JSON format
 func PrintImagePreviewJSON(w io.Writer, response *client.PrepareStackResponse) error {
 	return PrintJSON(w, response)
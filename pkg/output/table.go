@@ -2,13 +2,52 @@ package output
 
 import (
 	"io"
+	"os"
+	"sort"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/pkg/twwidth"
 	"github.com/olekukonko/tablewriter/tw"
+	"golang.org/x/term"
 )
 
+// cellPadding is the space tablewriter puts around each cell
+const cellPadding = 2
+
+// minColumnWidth is the narrowest a column is truncated to, unless its
+// header is narrower
+const minColumnWidth = 8
+
+// ellipsis marks truncated cells
+const ellipsis = "…"
+
+// wideOutput disables truncation of tables to the terminal width
+var wideOutput bool
+
+// SetWideOutput turns off truncating tables to the terminal width, e.g. for
+// --wide or -o wide
+func SetWideOutput(wide bool) {
+	wideOutput = wide
+}
+
+// ColumnPriorities hint which columns give way in a narrow terminal, by
+// header: columns with a higher priority are truncated first. Unlisted
+// columns have priority 0 and are truncated last, widest first.
+type ColumnPriorities map[string]int
+
 // PrintTable prints a table with proper alignment and styling
 func PrintTable(w io.Writer, headers []string, rows [][]string) {
+	PrintTableWithPriorities(w, headers, rows, nil)
+}
+
+// PrintTableWithPriorities prints a table, truncating cells with an ellipsis
+// so rows fit a terminal's width. Output that isn't a terminal is never
+// truncated.
+func PrintTableWithPriorities(w io.Writer, headers []string, rows [][]string, priorities ColumnPriorities) {
+	if width := terminalWidth(w); width > 0 && !wideOutput {
+		rows = FitTable(headers, rows, priorities, width)
+	}
+
 	// Create table with no borders and left-aligned headers
 	table := tablewriter.NewTable(w,
 		tablewriter.WithSymbols(tw.NewSymbols(tw.StyleNone)),
@@ -34,3 +73,82 @@ func PrintTable(w io.Writer, headers []string, rows [][]string) {
 	// Render
 	_ = table.Render()
 }
+
+// FitTable truncates cells so the rendered table is at most width columns
+// wide. Columns are shortened in priority order, but never below their
+// header or minColumnWidth. Color codes don't count towards widths.
+func FitTable(headers []string, rows [][]string, priorities ColumnPriorities, width int) [][]string {
+	widths := make([]int, len(headers))
+	minWidths := make([]int, len(headers))
+	total := 0
+	for i, h := range headers {
+		widths[i] = twwidth.Width(h)
+		for _, row := range rows {
+			if i < len(row) {
+				widths[i] = max(widths[i], twwidth.Width(row[i]))
+			}
+		}
+		minWidths[i] = min(widths[i], max(twwidth.Width(h), minColumnWidth))
+		total += widths[i] + cellPadding
+	}
+	if total <= width {
+		return rows
+	}
+
+	// Columns by priority, most expendable first
+	order := make([]int, len(headers))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return priorities[headers[order[a]]] > priorities[headers[order[b]]]
+	})
+
+	excess := total - width
+	for start := 0; start < len(order) && excess > 0; {
+		// Shrink the widest column of the current priority one step at a time
+		priority := priorities[headers[order[start]]]
+		end := start
+		for end < len(order) && priorities[headers[order[end]]] == priority {
+			end++
+		}
+		widest := -1
+		for _, col := range order[start:end] {
+			if widths[col] > minWidths[col] && (widest < 0 || widths[col] > widths[widest]) {
+				widest = col
+			}
+		}
+		if widest < 0 {
+			start = end
+			continue
+		}
+		widths[widest]--
+		excess--
+	}
+
+	fitted := make([][]string, len(rows))
+	for r, row := range rows {
+		fitted[r] = make([]string, len(row))
+		for i, cell := range row {
+			if i < len(widths) && twwidth.Width(cell) > widths[i] {
+				cell = twwidth.Truncate(cell, widths[i], ellipsis)
+			}
+			fitted[r][i] = cell
+		}
+	}
+	return fitted
+}
+
+// terminalWidth returns the width of the terminal w writes to, or 0 if w
+// isn't a terminal
+func terminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
@@ -0,0 +1,48 @@
+package output_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/output"
+)
+
+var _ = Describe("FitTable", func() {
+	headers := []string{"SERVICE", "IMAGE", "URL"}
+	rows := [][]string{
+		{"api", "ghcr.io/org/api@sha256:0123456789abcdef", "https://api.example.com"},
+		{"web", "nginx", "-"},
+	}
+
+	It("should leave tables that fit unchanged", func() {
+		Expect(output.FitTable(headers, rows, nil, 200)).To(Equal(rows))
+	})
+
+	It("should truncate the widest column first without priorities", func() {
+		// Widths 7 + 39 + 23, plus 2 padding per column = 75
+		fitted := output.FitTable(headers, rows, nil, 64)
+		Expect(fitted[0][0]).To(Equal("api"))
+		Expect(fitted[0][1]).To(Equal("ghcr.io/org/api@sha256:0123…"))
+		Expect(fitted[0][2]).To(Equal("https://api.example.com"))
+		Expect(fitted[1]).To(Equal(rows[1]))
+	})
+
+	It("should truncate higher priority columns first", func() {
+		fitted := output.FitTable(headers, rows, output.ColumnPriorities{"URL": 1}, 64)
+		Expect(fitted[0][1]).To(Equal(rows[0][1]))
+		Expect(fitted[0][2]).To(Equal("https://api…"))
+	})
+
+	It("should not shrink columns below their minimum", func() {
+		fitted := output.FitTable(headers, rows, nil, 10)
+		Expect(fitted[0][0]).To(Equal("api"))
+		Expect(fitted[0][1]).To(Equal("ghcr.io…"))
+		Expect(fitted[0][2]).To(Equal("https:/…"))
+	})
+
+	It("should ignore color codes when measuring", func() {
+		colored := [][]string{{output.ColorGray + "abcdefghijkl" + output.ColorReset}}
+		fitted := output.FitTable([]string{"NAME"}, colored, nil, 10)
+		Expect(fitted[0][0]).To(Equal(output.ColorGray + "abcdefg" + output.ColorReset + "…"))
+	})
+})
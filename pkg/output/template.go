@@ -0,0 +1,106 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Template output formats, given as -o go-template=TEMPLATE or
+// -o jsonpath=EXPRESSION
+const (
+	FormatGoTemplate = "go-template"
+	FormatJSONPath   = "jsonpath"
+)
+
+// IsTemplateFormat reports whether format is a go-template= or jsonpath=
+// output format
+func IsTemplateFormat(format string) bool {
+	kind, _, ok := strings.Cut(format, "=")
+	return ok && (kind == FormatGoTemplate || kind == FormatJSONPath)
+}
+
+// PrintTemplate renders data with a go-template= or jsonpath= format. Like
+// kubectl, templates see the JSON form of data, so fields are addressed by
+// their JSON names: {{.metadata.name}} or {.metadata.name}.
+func PrintTemplate(w io.Writer, format string, data interface{}) error {
+	kind, text, _ := strings.Cut(format, "=")
+	if text == "" {
+		return fmt.Errorf("%s output requires a template, e.g. -o %s='%s'", kind, kind, templateExample(kind))
+	}
+
+	obj, err := toJSONValue(data)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	switch kind {
+	case FormatGoTemplate:
+		tmpl, err := template.New("output").Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid go-template: %w", err)
+		}
+		if err := tmpl.Execute(&buf, obj); err != nil {
+			return fmt.Errorf("failed to execute go-template: %w", err)
+		}
+	case FormatJSONPath:
+		jp := jsonpath.New("output").AllowMissingKeys(true)
+		if err := jp.Parse(relaxedJSONPath(text)); err != nil {
+			return fmt.Errorf("invalid jsonpath: %w", err)
+		}
+		if err := jp.Execute(&buf, obj); err != nil {
+			return fmt.Errorf("failed to execute jsonpath: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output format '%s'", kind)
+	}
+
+	// End with a newline so output doesn't run into the shell prompt
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// toJSONValue converts data to the maps, slices and scalars of its JSON form
+func toJSONValue(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	// Keep numbers as written: float64 would print large ints as 1e+06
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var obj interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	return obj, nil
+}
+
+// relaxedJSONPath accepts expressions without braces, as kubectl does:
+// ".metadata.name" means "{.metadata.name}"
+func relaxedJSONPath(expr string) string {
+	if strings.Contains(expr, "{") {
+		return expr
+	}
+	if !strings.HasPrefix(expr, ".") && !strings.HasPrefix(expr, "[") {
+		expr = "." + expr
+	}
+	return "{" + expr + "}"
+}
+
+func templateExample(kind string) string {
+	if kind == FormatGoTemplate {
+		return "{{.name}}"
+	}
+	return "{.name}"
+}
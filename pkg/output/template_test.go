@@ -0,0 +1,61 @@
+package output_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/output"
+)
+
+var _ = Describe("PrintTemplate", func() {
+	type service struct {
+		Name string `json:"name"`
+		URL  string `json:"url,omitempty"`
+	}
+	data := struct {
+		Name     string    `json:"name"`
+		Replicas int64     `json:"replicas"`
+		Services []service `json:"services"`
+	}{
+		Name:     "demo",
+		Replicas: 2000000,
+		Services: []service{{Name: "api", URL: "https://api.example.com"}, {Name: "worker"}},
+	}
+
+	render := func(format string) (string, error) {
+		var buf bytes.Buffer
+		err := output.PrintTemplate(&buf, format, data)
+		return buf.String(), err
+	}
+
+	DescribeTable("should render fields by their JSON names",
+		func(format, expected string) {
+			out, err := render(format)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal(expected))
+		},
+		Entry("go-template", "go-template={{.name}}", "demo\n"),
+		Entry("go-template range", "go-template={{range .services}}{{.name}} {{end}}", "api worker \n"),
+		Entry("jsonpath", "jsonpath={.services[0].url}", "https://api.example.com\n"),
+		Entry("jsonpath filter", `jsonpath={.services[?(@.name=="worker")].name}`, "worker\n"),
+		Entry("jsonpath without braces", "jsonpath=.name", "demo\n"),
+		Entry("large numbers", "jsonpath={.replicas}", "2000000\n"),
+	)
+
+	It("should recognize template formats", func() {
+		Expect(output.IsTemplateFormat("jsonpath={.name}")).To(BeTrue())
+		Expect(output.IsTemplateFormat("go-template={{.name}}")).To(BeTrue())
+		Expect(output.IsTemplateFormat("json")).To(BeFalse())
+		Expect(output.IsTemplateFormat("wide")).To(BeFalse())
+	})
+
+	It("should report invalid templates", func() {
+		_, err := render("go-template={{.name")
+		Expect(err).To(MatchError(ContainSubstring("invalid go-template")))
+
+		_, err = render("jsonpath=")
+		Expect(err).To(MatchError(ContainSubstring("requires a template")))
+	})
+})
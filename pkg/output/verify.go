@@ -3,6 +3,7 @@ package output
 import (
 	"io"
 	"os"
+	"strings"
 	"text/template"
 
 	apicompose "github.com/lissto-dev/api/pkg/compose"
@@ -21,6 +22,12 @@ Found {{.WarningCount}} warning(s). Run with --verbose for details.
 {{if .Metadata.Title}}
 Title: {{.Metadata.Title}}
 {{end -}}
+{{if .Profiles}}
+🏷️  Profiles: {{join .Profiles ", "}}
+  Selected: {{if .SelectedProfiles}}{{join .SelectedProfiles ", "}}{{else}}none (use --profile to include profiled services){{end}}
+{{if .ExcludedServices}}  Excluded services: {{join .ExcludedServices ", "}}
+{{end -}}
+{{end -}}
 {{if .Metadata.Services.Services}}
 📦 Services:
 {{range .Metadata.Services.Services}}  - {{.}}
@@ -70,12 +77,17 @@ type VerifyTemplateData struct {
 	Errors       []string
 	Warnings     []string
 	WarningCount int
+	// Compose profiles declared by the file, those selected with --profile,
+	// and the services they excluded
+	Profiles         []string
+	SelectedProfiles []string
+	ExcludedServices []string
 }
 
 // PrintVerificationResult renders the verification result using templates
 // and writes it to the provided writer
 func PrintVerificationResult(result *VerifyTemplateData, writer io.Writer) error {
-	tmpl, err := template.New("verify").Parse(verifyTemplate)
+	tmpl, err := template.New("verify").Funcs(template.FuncMap{"join": strings.Join}).Parse(verifyTemplate)
 	if err != nil {
 		return err
 	}
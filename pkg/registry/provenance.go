@@ -0,0 +1,449 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRegistry  = "docker.io"
+	dockerHubHost    = "registry-1.docker.io"
+	defaultTag       = "latest"
+	requestTimeout   = 10 * time.Second
+	maxResponseBytes = 4 << 20
+)
+
+// OCI annotation/label keys used for provenance
+const (
+	LabelCreated  = "org.opencontainers.image.created"
+	LabelRevision = "org.opencontainers.image.revision"
+	LabelSource   = "org.opencontainers.image.source"
+)
+
+// Accept headers for manifest requests (single manifests and indexes)
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// ErrNoProvenance means an image carries neither provenance labels nor a
+// build time
+var ErrNoProvenance = errors.New("image has no provenance")
+
+// transport sends registry requests, see SetTransport
+var transport http.RoundTripper = http.DefaultTransport
+
+// SetTransport replaces the transport of registry requests, e.g. with a test
+// server's, until the returned func restores it
+func SetTransport(rt http.RoundTripper) (restore func()) {
+	previous := transport
+	transport = rt
+	return func() { transport = previous }
+}
+
+// newHTTPClient returns the client registry requests are made with
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: requestTimeout, Transport: transport}
+}
+
+// Reference is a parsed image reference
+type Reference struct {
+	Registry   string // e.g. "ghcr.io" or "docker.io"
+	Repository string // e.g. "org/app" or "library/nginx"
+	Tag        string
+	Digest     string
+}
+
+// Provenance describes where an image came from, based on its OCI labels
+type Provenance struct {
+	Created  time.Time `json:"created,omitempty" yaml:"created,omitempty"`
+	Revision string    `json:"revision,omitempty" yaml:"revision,omitempty"`
+	Source   string    `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+// ShortRevision returns the first 7 characters of the git revision
+func (p *Provenance) ShortRevision() string {
+	if len(p.Revision) > 7 {
+		return p.Revision[:7]
+	}
+	return p.Revision
+}
+
+var digestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// ParseReference parses an image reference such as "nginx", "ghcr.io/org/app:v1"
+// or "registry:5000/app@sha256:..."
+func ParseReference(ref string) (Reference, error) {
+	if ref == "" {
+		return Reference{}, fmt.Errorf("empty image reference")
+	}
+
+	var r Reference
+	name := ref
+	if i := strings.Index(name, "@"); i >= 0 {
+		r.Digest = name[i+1:]
+		name = name[:i]
+		if !digestPattern.MatchString(r.Digest) {
+			return Reference{}, fmt.Errorf("invalid digest in %q", ref)
+		}
+	}
+
+	// A tag is the part after the last ':' if it comes after the last '/'
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		r.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		r.Registry = parts[0]
+		r.Repository = parts[1]
+	} else {
+		r.Registry = defaultRegistry
+		r.Repository = name
+	}
+
+	if r.Registry == defaultRegistry && !strings.Contains(r.Repository, "/") {
+		r.Repository = "library/" + r.Repository
+	}
+	if r.Tag == "" && r.Digest == "" {
+		r.Tag = defaultTag
+	}
+	if r.Repository == "" {
+		return Reference{}, fmt.Errorf("invalid image reference %q", ref)
+	}
+
+	return r, nil
+}
+
+// host returns the registry API host
+func (r Reference) host() string {
+	if r.Registry == defaultRegistry {
+		return dockerHubHost
+	}
+	return r.Registry
+}
+
+// reference returns the digest if known, otherwise the tag
+func (r Reference) reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// FetchProvenance queries the registry anonymously for the image config and
+// extracts provenance from its OCI labels. digest overrides any digest or tag
+// in image when set. Images without labels or build time return
+// ErrNoProvenance.
+func FetchProvenance(ctx context.Context, image, digest string) (*Provenance, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	if digestPattern.MatchString(digest) {
+		ref.Digest = digest
+	}
+
+	c := &registryClient{
+		httpClient: newHTTPClient(),
+		ref:        ref,
+	}
+	return c.provenance(ctx)
+}
+
+// ResolveDigest queries the registry anonymously for the manifest digest of
+// an image reference. Pinned references are returned without a request.
+func ResolveDigest(ctx context.Context, image string) (string, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	if ref.Digest != "" {
+		return ref.Digest, nil
+	}
+
+	c := &registryClient{
+		httpClient: newHTTPClient(),
+		ref:        ref,
+	}
+	return c.digest(ctx)
+}
+
+type registryClient struct {
+	httpClient *http.Client
+	ref        Reference
+	token      string
+}
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Platform  *struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+	} `json:"platform,omitempty"`
+}
+
+type manifest struct {
+	MediaType   string            `json:"mediaType"`
+	Config      *descriptor       `json:"config,omitempty"`
+	Manifests   []descriptor      `json:"manifests,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type imageConfig struct {
+	Created time.Time `json:"created"`
+	Config  struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"config"`
+}
+
+func (c *registryClient) provenance(ctx context.Context) (*Provenance, error) {
+	m, err := c.manifest(ctx, c.ref.reference())
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve an index to the linux/amd64 manifest (or the first entry)
+	if len(m.Manifests) > 0 {
+		chosen := m.Manifests[0]
+		for _, d := range m.Manifests {
+			if d.Platform != nil && d.Platform.OS == "linux" && d.Platform.Architecture == "amd64" {
+				chosen = d
+				break
+			}
+		}
+		m, err = c.manifest(ctx, chosen.Digest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if m.Config == nil {
+		return nil, fmt.Errorf("manifest has no config")
+	}
+
+	var cfg imageConfig
+	if err := c.getJSON(ctx, fmt.Sprintf("/v2/%s/blobs/%s", c.ref.Repository, m.Config.Digest), nil, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to fetch image config: %w", err)
+	}
+
+	labels := cfg.Config.Labels
+	p := &Provenance{
+		Created:  cfg.Created,
+		Revision: labels[LabelRevision],
+		Source:   labels[LabelSource],
+	}
+	if created, ok := labels[LabelCreated]; ok {
+		if t, err := time.Parse(time.RFC3339, created); err == nil {
+			p.Created = t
+		}
+	}
+	// Manifest annotations take precedence for revision when present
+	if rev := m.Annotations[LabelRevision]; rev != "" {
+		p.Revision = rev
+	}
+	if p.Created.IsZero() && p.Revision == "" && p.Source == "" {
+		return nil, ErrNoProvenance
+	}
+
+	return p, nil
+}
+
+func (c *registryClient) manifest(ctx context.Context, reference string) (*manifest, error) {
+	var m manifest
+	headers := map[string]string{"Accept": strings.Join(manifestMediaTypes, ", ")}
+	if err := c.getJSON(ctx, fmt.Sprintf("/v2/%s/manifests/%s", c.ref.Repository, reference), headers, &m); err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// digest returns the digest of the manifest the tag points to
+func (c *registryClient) digest(ctx context.Context) (string, error) {
+	headers := map[string]string{"Accept": strings.Join(manifestMediaTypes, ", ")}
+	resp, err := c.fetch(ctx, fmt.Sprintf("/v2/%s/manifests/%s", c.ref.Repository, c.ref.Tag), headers)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if digest := resp.Header.Get("Docker-Content-Digest"); digestPattern.MatchString(digest) {
+		return digest, nil
+	}
+
+	// Not every registry sends the header; the digest is the manifest's hash
+	h := sha256.New()
+	if _, err := io.Copy(h, io.LimitReader(resp.Body, maxResponseBytes)); err != nil {
+		return "", fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (c *registryClient) getJSON(ctx context.Context, path string, headers map[string]string, result interface{}) error {
+	resp, err := c.fetch(ctx, path, headers)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(result)
+}
+
+// fetch performs a GET and returns the response if it succeeded
+func (c *registryClient) fetch(ctx context.Context, path string, headers map[string]string) (*http.Response, error) {
+	resp, err := c.get(ctx, path, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retry once with an anonymous bearer token if the registry asks for one
+	if resp.StatusCode == http.StatusUnauthorized && c.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		if err := c.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		resp, err = c.get(ctx, path, headers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("registry returned %s", resp.Status)
+	}
+	return resp, nil
+}
+
+func (c *registryClient) get(ctx context.Context, path string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+c.ref.host()+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return c.httpClient.Do(req)
+}
+
+// authenticate performs the anonymous token flow described by a
+// `Bearer realm="...",service="...",scope="..."` challenge
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	params := parseChallenge(challenge)
+	realm := params["realm"]
+	if realm == "" {
+		return fmt.Errorf("registry requires authentication")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm, nil)
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	if service := params["service"]; service != "" {
+		q.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", c.ref.Repository)
+	}
+	q.Set("scope", scope)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get registry token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry token request returned %s", resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode registry token: %w", err)
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	if c.token == "" {
+		return fmt.Errorf("registry returned an empty token")
+	}
+	return nil
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+func parseChallenge(challenge string) map[string]string {
+	params := make(map[string]string)
+	if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(challenge)), "bearer ") {
+		return params
+	}
+	for _, m := range challengeParam.FindAllStringSubmatch(challenge, -1) {
+		params[m[1]] = m[2]
+	}
+	return params
+}
+
+// ProvenanceTimeout bounds the total time commands spend querying registries
+// for provenance
+const ProvenanceTimeout = 15 * time.Second
+
+// ImageDigest is an image reference and the digest it resolved to
+type ImageDigest struct {
+	Image  string
+	Digest string
+}
+
+// FetchProvenances queries the provenance of several images in parallel.
+// The result is keyed like images; images that can't be inspected (private
+// registries, missing labels, no digest) are left out.
+func FetchProvenances(ctx context.Context, images map[string]ImageDigest) map[string]*Provenance {
+	result := make(map[string]*Provenance)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for key, img := range images {
+		if img.Image == "" || !digestPattern.MatchString(img.Digest) {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := FetchProvenance(ctx, img.Image, img.Digest)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			result[key] = p
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return result
+}
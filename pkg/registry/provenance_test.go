@@ -0,0 +1,140 @@
+package registry_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/registry"
+)
+
+var _ = Describe("ParseReference", func() {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	DescribeTable("should parse image references",
+		func(ref string, expected registry.Reference) {
+			parsed, err := registry.ParseReference(ref)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(expected))
+		},
+		Entry("official image", "nginx",
+			registry.Reference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}),
+		Entry("docker hub user image with tag", "org/app:v1",
+			registry.Reference{Registry: "docker.io", Repository: "org/app", Tag: "v1"}),
+		Entry("custom registry", "ghcr.io/org/app:main",
+			registry.Reference{Registry: "ghcr.io", Repository: "org/app", Tag: "main"}),
+		Entry("registry with port and digest", "localhost:5000/app@"+digest,
+			registry.Reference{Registry: "localhost:5000", Repository: "app", Digest: digest}),
+	)
+
+	It("should reject invalid digests", func() {
+		_, err := registry.ParseReference("nginx@sha256:abc")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject empty references", func() {
+		_, err := registry.ParseReference("")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ResolveDigest", func() {
+	It("should return pinned digests without contacting the registry", func() {
+		const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+		resolved, err := registry.ResolveDigest(context.Background(), "registry.invalid/org/app:v1@"+digest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolved).To(Equal(digest))
+	})
+})
+
+var _ = Describe("FetchProvenance", func() {
+	const (
+		indexDigest    = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+		manifestDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+		configDigest   = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+	)
+
+	var (
+		server *httptest.Server
+		host   string
+		// config is the image config blob the registry serves
+		config string
+		// tokenScopes are the scopes of token requests
+		tokenScopes []string
+	)
+
+	BeforeEach(func() {
+		tokenScopes = nil
+		config = `{"created":"2026-01-02T03:04:05Z","config":{"Labels":{
+			"org.opencontainers.image.revision":"0123456789abcdef",
+			"org.opencontainers.image.source":"https://github.com/org/app"}}}`
+
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			if r.URL.Path == "/token" {
+				tokenScopes = append(tokenScopes, r.URL.Query().Get("scope"))
+				Expect(r.URL.Query().Get("service")).To(Equal("fake-registry"))
+				_, _ = w.Write([]byte(`{"token":"anonymous"}`))
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer anonymous" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="fake-registry",scope="repository:org/app:pull"`, host))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/v2/org/app/manifests/" + indexDigest:
+				Expect(r.Header.Get("Accept")).To(ContainSubstring("application/vnd.oci.image.index.v1+json"))
+				_, _ = fmt.Fprintf(w, `{"manifests":[
+					{"digest":"sha256:arm","platform":{"architecture":"arm64","os":"linux"}},
+					{"digest":"%s","platform":{"architecture":"amd64","os":"linux"}}]}`, manifestDigest)
+			case "/v2/org/app/manifests/" + manifestDigest:
+				_, _ = fmt.Fprintf(w, `{"config":{"digest":"%s"}}`, configDigest)
+			case "/v2/org/app/blobs/" + configDigest:
+				_, _ = w.Write([]byte(config))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(server.Close)
+		host = strings.TrimPrefix(server.URL, "https://")
+		DeferCleanup(registry.SetTransport(server.Client().Transport))
+	})
+
+	It("should get an anonymous token when challenged and read the labels", func() {
+		p, err := registry.FetchProvenance(context.Background(), host+"/org/app:v1", indexDigest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.Revision).To(Equal("0123456789abcdef"))
+		Expect(p.ShortRevision()).To(Equal("0123456"))
+		Expect(p.Source).To(Equal("https://github.com/org/app"))
+		Expect(p.Created).To(Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+		// The token is reused for the following requests
+		Expect(tokenScopes).To(Equal([]string{"repository:org/app:pull"}))
+	})
+
+	It("should report images without provenance", func() {
+		config = `{"config":{"Labels":{"maintainer":"ops"}}}`
+		_, err := registry.FetchProvenance(context.Background(), host+"/org/app:v1", indexDigest)
+		Expect(err).To(MatchError(registry.ErrNoProvenance))
+	})
+
+	It("should report a missing manifest", func() {
+		const unknown = "sha256:4444444444444444444444444444444444444444444444444444444444444444"
+		_, err := registry.FetchProvenance(context.Background(), host+"/org/app:v1", unknown)
+		Expect(err).To(MatchError(ContainSubstring("404")))
+	})
+
+	It("should leave images without provenance out of FetchProvenances", func() {
+		config = `{"config":{}}`
+		provenance := registry.FetchProvenances(context.Background(), map[string]registry.ImageDigest{
+			"web": {Image: host + "/org/app:v1", Digest: indexDigest},
+		})
+		Expect(provenance).To(BeEmpty())
+	})
+})
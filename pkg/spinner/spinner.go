@@ -0,0 +1,192 @@
+package spinner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// frames are the spinner animation
+var frames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// frameInterval is how often the spinner redraws
+const frameInterval = 100 * time.Millisecond
+
+// showElapsedAfter is when the elapsed time starts being shown
+const showElapsedAfter = time.Second
+
+// animated allows spinners on terminals; off for non-interactive runs
+var animated = true
+
+// SetAnimated turns spinner animation on or off, e.g. off for
+// --non-interactive. Without animation a spinner prints its message once.
+func SetAnimated(enabled bool) {
+	animated = enabled
+}
+
+// Spinner shows that a long operation is running. On a terminal it animates
+// with the elapsed time; elsewhere it prints its message as a plain line.
+type Spinner struct {
+	out   io.Writer
+	tty   bool
+	quiet bool
+	start time.Time
+
+	mu      sync.Mutex
+	message string
+	stopped bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// Start shows message until the spinner is stopped
+func Start(out io.Writer, message string) *Spinner {
+	s := newSpinner(out, message)
+	if !s.tty {
+		fmt.Fprintf(out, "%s...\n", message)
+	}
+	return s
+}
+
+// StartTransient shows message on a terminal until the spinner is stopped;
+// elsewhere it prints nothing, for operations that don't need a log line
+func StartTransient(out io.Writer, message string) *Spinner {
+	s := newSpinner(out, message)
+	if !s.tty {
+		s.quiet = true
+	}
+	return s
+}
+
+// newSpinner creates a spinner, animating it on terminals
+func newSpinner(out io.Writer, message string) *Spinner {
+	s := &Spinner{
+		out:     out,
+		tty:     animated && isTerminal(out),
+		start:   time.Now(),
+		message: message,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if s.tty {
+		go s.run()
+	} else {
+		close(s.done)
+	}
+	return s
+}
+
+// Update changes the message of a running spinner, e.g. for the next phase
+// of an operation
+func (s *Spinner) Update(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped || message == s.message {
+		return
+	}
+	s.message = message
+	if !s.tty && !s.quiet {
+		fmt.Fprintf(s.out, "%s...\n", message)
+	}
+}
+
+// Success stops the spinner and prints a success line with the elapsed time
+func (s *Spinner) Success(message string) {
+	s.finish("✅", message)
+}
+
+// Fail stops the spinner and prints a failure line
+func (s *Spinner) Fail(message string) {
+	s.finish("❌", message)
+}
+
+// Stop removes the spinner without printing a result, e.g. before a prompt.
+// It is safe to call more than once.
+func (s *Spinner) Stop() {
+	s.finish("", "")
+}
+
+// Elapsed returns how long the operation has been running
+func (s *Spinner) Elapsed() time.Duration {
+	return time.Since(s.start)
+}
+
+// finish stops the animation and prints an optional result line
+func (s *Spinner) finish(icon, message string) {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.stopped = true
+	s.mu.Unlock()
+
+	if s.tty {
+		close(s.stop)
+		<-s.done
+		fmt.Fprint(s.out, "\r\033[K")
+	}
+	if message != "" && !s.quiet {
+		fmt.Fprintf(s.out, "%s %s%s\n", icon, message, formatElapsed(s.Elapsed()))
+	}
+}
+
+// run redraws the spinner until it is stopped
+func (s *Spinner) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(frameInterval)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		s.mu.Lock()
+		message := s.message
+		s.mu.Unlock()
+		fmt.Fprintf(s.out, "\r\033[K%s %s...%s", frames[frame%len(frames)], message, formatElapsed(s.Elapsed()))
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// formatElapsed formats the elapsed time of operations taking more than a
+// second, e.g. " (12s)"
+func formatElapsed(d time.Duration) string {
+	if d < showElapsedAfter {
+		return ""
+	}
+	if d < time.Minute {
+		return fmt.Sprintf(" (%ds)", int(d.Seconds()))
+	}
+	return fmt.Sprintf(" (%dm%02ds)", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// isTerminal reports whether out is a terminal
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Steps numbers the phases of a multi-step operation: [1/3], [2/3], ...
+type Steps struct {
+	out     io.Writer
+	total   int
+	current int
+}
+
+// NewSteps starts a sequence of total steps
+func NewSteps(out io.Writer, total int) *Steps {
+	return &Steps{out: out, total: total}
+}
+
+// Next starts the spinner of the next step
+func (s *Steps) Next(message string) *Spinner {
+	s.current++
+	return Start(s.out, fmt.Sprintf("[%d/%d] %s", s.current, s.total, message))
+}
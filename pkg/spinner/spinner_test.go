@@ -0,0 +1,53 @@
+package spinner_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/spinner"
+)
+
+var _ = Describe("Spinner", func() {
+	It("should print plain lines when not writing to a terminal", func() {
+		var out bytes.Buffer
+		s := spinner.Start(&out, "Preparing stack")
+		s.Update("Resolving images")
+		s.Success("Stack prepared")
+
+		Expect(out.String()).To(Equal("Preparing stack...\nResolving images...\n✅ Stack prepared\n"))
+	})
+
+	It("should print only the first result", func() {
+		var out bytes.Buffer
+		s := spinner.Start(&out, "Creating stack")
+		s.Fail("Failed to create stack")
+		s.Success("Stack created")
+		s.Stop()
+
+		Expect(out.String()).To(Equal("Creating stack...\n❌ Failed to create stack\n"))
+	})
+
+	It("should print nothing when stopped without a result", func() {
+		var out bytes.Buffer
+		spinner.Start(&out, "Discovering").Stop()
+		Expect(out.String()).To(Equal("Discovering...\n"))
+	})
+
+	It("should number steps", func() {
+		var out bytes.Buffer
+		steps := spinner.NewSteps(&out, 2)
+		steps.Next("Connecting").Stop()
+		steps.Next("Authenticating").Stop()
+		Expect(out.String()).To(Equal("[1/2] Connecting...\n[2/2] Authenticating...\n"))
+	})
+
+	It("should print nothing for transient spinners when not writing to a terminal", func() {
+		var out bytes.Buffer
+		s := spinner.StartTransient(&out, "Loading stacks")
+		s.Update("Loading pods")
+		s.Success("Loaded")
+		Expect(out.String()).To(BeEmpty())
+	})
+})
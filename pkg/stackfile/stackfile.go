@@ -0,0 +1,109 @@
+package stackfile
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the stack file format version written by Export
+const Version = "v1"
+
+// Spec is a declarative stack description, usually stored in git as
+// stack.yaml and deployed with 'lissto create -f stack.yaml'
+type Spec struct {
+	Version   string `yaml:"version"`
+	Blueprint string `yaml:"blueprint"`
+	Env       string `yaml:"env,omitempty"`
+
+	// At most one of Branch, Tag and Commit selects the images to resolve
+	Branch string `yaml:"branch,omitempty"`
+	Tag    string `yaml:"tag,omitempty"`
+	Commit string `yaml:"commit,omitempty"`
+
+	// Params are blueprint parameter values (x-lissto.params)
+	Params map[string]string `yaml:"params,omitempty"`
+	// Images overrides the resolved image of a service, as for --set-image:
+	// branch:<name>, tag:<name>, commit:<sha> or an image reference
+	// (optionally pinned with @sha256:...)
+	Images map[string]string `yaml:"images,omitempty"`
+	// Variables are merged into the env-scoped variables before deploying
+	Variables map[string]string `yaml:"variables,omitempty"`
+}
+
+// Load reads and validates a stack file
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stack file: %w", err)
+	}
+
+	spec, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stack file %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Parse decodes and validates stack file content. Unknown fields are
+// rejected so typos don't silently change a deployment.
+func Parse(data []byte) (*Spec, error) {
+	spec := &Spec{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(spec); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Validate checks the spec for missing or contradicting fields
+func (s *Spec) Validate() error {
+	if s.Version != "" && s.Version != Version {
+		return fmt.Errorf("unsupported version %q (expected %s)", s.Version, Version)
+	}
+	if s.Blueprint == "" {
+		return fmt.Errorf("blueprint is required")
+	}
+
+	var refs []string
+	for name, value := range map[string]string{"branch": s.Branch, "tag": s.Tag, "commit": s.Commit} {
+		if value != "" {
+			refs = append(refs, name)
+		}
+	}
+	if len(refs) > 1 {
+		sort.Strings(refs)
+		return fmt.Errorf("only one of branch, tag and commit may be set (got %s)", strings.Join(refs, ", "))
+	}
+
+	for service, ref := range s.Images {
+		if ref == "" {
+			return fmt.Errorf("image for service %s is empty", service)
+		}
+	}
+	return nil
+}
+
+// Write encodes the spec as YAML
+func Write(w io.Writer, spec *Spec) error {
+	if spec.Version == "" {
+		spec.Version = Version
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(spec); err != nil {
+		return fmt.Errorf("failed to encode stack file: %w", err)
+	}
+	return enc.Close()
+}
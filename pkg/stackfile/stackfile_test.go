@@ -0,0 +1,65 @@
+package stackfile_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/stackfile"
+)
+
+var _ = Describe("Stack file", func() {
+	It("should parse a full stack file", func() {
+		spec, err := stackfile.Parse([]byte(`version: v1
+blueprint: my-blueprint
+env: staging
+branch: main
+params:
+  hostname-prefix: demo
+images:
+  api: ghcr.io/org/api:feature-x
+variables:
+  LOG_LEVEL: debug
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(&stackfile.Spec{
+			Version:   "v1",
+			Blueprint: "my-blueprint",
+			Env:       "staging",
+			Branch:    "main",
+			Params:    map[string]string{"hostname-prefix": "demo"},
+			Images:    map[string]string{"api": "ghcr.io/org/api:feature-x"},
+			Variables: map[string]string{"LOG_LEVEL": "debug"},
+		}))
+	})
+
+	DescribeTable("should reject invalid stack files",
+		func(content, message string) {
+			_, err := stackfile.Parse([]byte(content))
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("missing blueprint", "env: staging\n", "blueprint is required"),
+		Entry("unknown field", "blueprint: bp\nbrnach: main\n", "field brnach not found"),
+		Entry("several refs", "blueprint: bp\nbranch: main\ntag: v1\n", "only one of branch, tag and commit"),
+		Entry("unsupported version", "version: v9\nblueprint: bp\n", "unsupported version"),
+		Entry("empty image", "blueprint: bp\nimages:\n  api: \"\"\n", "image for service api is empty"),
+	)
+
+	It("should round-trip through Write", func() {
+		spec := &stackfile.Spec{
+			Blueprint: "my-blueprint",
+			Env:       "prod",
+			Tag:       "v1.2.3",
+			Images:    map[string]string{"web": "nginx:1.27"},
+		}
+
+		var buf bytes.Buffer
+		Expect(stackfile.Write(&buf, spec)).To(Succeed())
+		Expect(buf.String()).To(HavePrefix("version: v1\nblueprint: my-blueprint\n"))
+
+		parsed, err := stackfile.Parse(buf.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(spec))
+	})
+})
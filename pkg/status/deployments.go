@@ -0,0 +1,78 @@
+package status
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// AnnotationPausedReplicas records the replica count of a deployment paused
+// with 'lissto stack pause'
+const AnnotationPausedReplicas = "lissto.dev/paused-replicas"
+
+// DeploymentReplicas returns the desired replica count of a deployment
+func DeploymentReplicas(deployment *appsv1.Deployment) int32 {
+	if deployment.Spec.Replicas == nil {
+		return 1
+	}
+	return *deployment.Spec.Replicas
+}
+
+// PausedReplicas returns the replica count recorded when a deployment was
+// paused; ok is false if it isn't paused
+func PausedReplicas(deployment *appsv1.Deployment) (replicas int32, ok bool) {
+	value, found := deployment.Annotations[AnnotationPausedReplicas]
+	if !found {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return int32(n), true
+}
+
+// DeploymentService returns the stack service a deployment runs: its service
+// label, its kompose label, or else its name
+func DeploymentService(deployment *appsv1.Deployment) string {
+	if service := deployment.Labels[LabelService]; service != "" {
+		return service
+	}
+	if service := deployment.Labels[LabelKomposeService]; service != "" {
+		return service
+	}
+	return deployment.Name
+}
+
+// MatchServiceDeployment returns the deployment of one service, or nil
+func MatchServiceDeployment(deployments []appsv1.Deployment, serviceName string) *appsv1.Deployment {
+	for i := range deployments {
+		if DeploymentService(&deployments[i]) == serviceName {
+			return &deployments[i]
+		}
+	}
+	return nil
+}
+
+// ListStackDeployments lists the deployments of a stack's services. Stacks
+// whose deployments carry no stack label are matched by service name.
+func ListStackDeployments(ctx context.Context, k8sClient *k8s.Client, stack *envv1alpha1.Stack) ([]appsv1.Deployment, error) {
+	deployments, err := k8sClient.ListDeployments(ctx, stack.Namespace, StackPodLabels(stack.Name))
+	if err != nil || len(deployments) > 0 {
+		return deployments, err
+	}
+
+	all, err := k8sClient.ListDeployments(ctx, stack.Namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		if _, ok := stack.Spec.Images[DeploymentService(&all[i])]; ok {
+			deployments = append(deployments, all[i])
+		}
+	}
+	return deployments, nil
+}
@@ -0,0 +1,76 @@
+package status_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+func newDeployment(name string, labels map[string]string) appsv1.Deployment {
+	return appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+var _ = Describe("Deployments", func() {
+	Describe("DeploymentService", func() {
+		It("should prefer the service label, then the kompose label, then the name", func() {
+			d := newDeployment("api-v2", map[string]string{
+				status.LabelService:        "api",
+				status.LabelKomposeService: "other",
+			})
+			Expect(status.DeploymentService(&d)).To(Equal("api"))
+
+			d = newDeployment("api-v2", map[string]string{status.LabelKomposeService: "api"})
+			Expect(status.DeploymentService(&d)).To(Equal("api"))
+
+			d = newDeployment("api", nil)
+			Expect(status.DeploymentService(&d)).To(Equal("api"))
+		})
+	})
+
+	Describe("MatchServiceDeployment", func() {
+		It("should find the deployment of a service", func() {
+			deployments := []appsv1.Deployment{
+				newDeployment("web", nil),
+				newDeployment("api-7f", map[string]string{status.LabelService: "api"}),
+			}
+
+			Expect(status.MatchServiceDeployment(deployments, "api").Name).To(Equal("api-7f"))
+			Expect(status.MatchServiceDeployment(deployments, "web").Name).To(Equal("web"))
+			Expect(status.MatchServiceDeployment(deployments, "worker")).To(BeNil())
+		})
+	})
+
+	Describe("PausedReplicas", func() {
+		It("should read the recorded replica count", func() {
+			d := newDeployment("api", nil)
+			d.Annotations = map[string]string{status.AnnotationPausedReplicas: "3"}
+			replicas, ok := status.PausedReplicas(&d)
+			Expect(ok).To(BeTrue())
+			Expect(replicas).To(Equal(int32(3)))
+		})
+
+		It("should ignore deployments that aren't paused or have a bad count", func() {
+			d := newDeployment("api", nil)
+			_, ok := status.PausedReplicas(&d)
+			Expect(ok).To(BeFalse())
+
+			d.Annotations = map[string]string{status.AnnotationPausedReplicas: "-1"}
+			_, ok = status.PausedReplicas(&d)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("DeploymentReplicas", func() {
+		It("should default to one replica", func() {
+			d := newDeployment("api", nil)
+			Expect(status.DeploymentReplicas(&d)).To(Equal(int32(1)))
+
+			zero := int32(0)
+			d.Spec.Replicas = &zero
+			Expect(status.DeploymentReplicas(&d)).To(Equal(int32(0)))
+		})
+	})
+})
@@ -0,0 +1,172 @@
+package status
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Config scopes, as used by variables and secrets
+const (
+	ScopeGlobal = "global"
+	ScopeRepo   = "repo"
+	ScopeEnv    = "env"
+)
+
+// maxDescribeEvents bounds the events included in a stack description
+const maxDescribeEvents = 20
+
+// StackDescription is the detailed view of a single stack
+type StackDescription struct {
+	StackReport `yaml:",inline"`
+	Env         string            `json:"env" yaml:"env"`
+	Repository  string            `json:"repository,omitempty" yaml:"repository,omitempty"`
+	Source      *SourceReport     `json:"source,omitempty" yaml:"source,omitempty"`
+	Images      []ImageReport     `json:"images" yaml:"images"`
+	Conditions  []ConditionReport `json:"conditions" yaml:"conditions"`
+	Variables   []ConfigRef       `json:"variables" yaml:"variables"`
+	Secrets     []ConfigRef       `json:"secrets" yaml:"secrets"`
+	Events      []EventReport     `json:"events" yaml:"events"`
+}
+
+// SourceReport is the git metadata the stack's images were resolved from
+type SourceReport struct {
+	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Tag    string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Branch string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Author string `json:"author,omitempty" yaml:"author,omitempty"`
+}
+
+// ImageReport is the resolved image of one service
+type ImageReport struct {
+	Service string `json:"service" yaml:"service"`
+	Image   string `json:"image,omitempty" yaml:"image,omitempty"`
+	Digest  string `json:"digest" yaml:"digest"`
+}
+
+// ConditionReport is one stack condition
+type ConditionReport struct {
+	Type    string    `json:"type" yaml:"type"`
+	Status  string    `json:"status" yaml:"status"`
+	Reason  string    `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Message string    `json:"message,omitempty" yaml:"message,omitempty"`
+	Time    time.Time `json:"time" yaml:"time"`
+}
+
+// ConfigRef is a variable or secret config and the keys it provides.
+// Secret values are never included.
+type ConfigRef struct {
+	Name       string   `json:"name" yaml:"name"`
+	Scope      string   `json:"scope" yaml:"scope"`
+	Env        string   `json:"env,omitempty" yaml:"env,omitempty"`
+	Repository string   `json:"repository,omitempty" yaml:"repository,omitempty"`
+	Keys       []string `json:"keys" yaml:"keys"`
+}
+
+// AppliesTo reports whether the config is injected into stacks of the given
+// env and repository, following the controller's scope matching
+func (c ConfigRef) AppliesTo(env, repository string) bool {
+	switch c.Scope {
+	case ScopeGlobal:
+		return true
+	case ScopeRepo:
+		return repository != "" && c.Repository == repository
+	case ScopeEnv:
+		return env != "" && c.Env == env
+	default:
+		return false
+	}
+}
+
+// StackConfigs returns the configs that apply to a stack, ordered by
+// precedence (env, repo, then global) and name
+func StackConfigs(configs []ConfigRef, env, repository string) []ConfigRef {
+	matched := make([]ConfigRef, 0, len(configs))
+	for _, c := range configs {
+		if c.AppliesTo(env, repository) {
+			c.Keys = append([]string(nil), c.Keys...)
+			sort.Strings(c.Keys)
+			matched = append(matched, c)
+		}
+	}
+
+	rank := map[string]int{ScopeEnv: 0, ScopeRepo: 1, ScopeGlobal: 2}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if rank[matched[i].Scope] != rank[matched[j].Scope] {
+			return rank[matched[i].Scope] < rank[matched[j].Scope]
+		}
+		return matched[i].Name < matched[j].Name
+	})
+	return matched
+}
+
+// DescribeStack aggregates the detailed view of a stack. k8sClient may be nil,
+// in which case pod details and events are omitted. repository is the
+// blueprint's repository, used to match repo-scoped configs.
+func DescribeStack(ctx context.Context, k8sClient *k8s.Client, stack *envv1alpha1.Stack, infra InfraLookup, repository string, variables, secrets []ConfigRef) *StackDescription {
+	data := FetchStacks(ctx, k8sClient, []envv1alpha1.Stack{*stack}, infra, DefaultFetchConcurrency)[0]
+
+	desc := &StackDescription{
+		StackReport: NewStackReport(stack, &data),
+		Env:         stack.Spec.Env,
+		Repository:  repository,
+		Images:      make([]ImageReport, 0, len(stack.Spec.Images)),
+		Conditions:  make([]ConditionReport, 0, len(stack.Status.Conditions)),
+		Variables:   StackConfigs(variables, stack.Spec.Env, repository),
+		Secrets:     StackConfigs(secrets, stack.Spec.Env, repository),
+		Events:      []EventReport{},
+	}
+
+	if meta := stack.Spec.Metadata; meta != (envv1alpha1.StackMetadata{}) {
+		desc.Source = &SourceReport{Commit: meta.Commit, Tag: meta.Tag, Branch: meta.Branch, Author: meta.Author}
+	}
+
+	for service, info := range stack.Spec.Images {
+		desc.Images = append(desc.Images, ImageReport{Service: service, Image: info.Image, Digest: info.Digest})
+	}
+	sort.Slice(desc.Images, func(i, j int) bool { return desc.Images[i].Service < desc.Images[j].Service })
+
+	// Conditions timeline, oldest first
+	for _, c := range stack.Status.Conditions {
+		desc.Conditions = append(desc.Conditions, ConditionReport{
+			Type:    c.Type,
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+			Time:    c.LastTransitionTime.Time,
+		})
+	}
+	sort.SliceStable(desc.Conditions, func(i, j int) bool {
+		return desc.Conditions[i].Time.Before(desc.Conditions[j].Time)
+	})
+
+	if data.Pods.Listed() {
+		desc.Events = stackEvents(ctx, k8sClient, stack, data.Pods.Items)
+	}
+
+	return desc
+}
+
+// stackEvents returns the most recent events for a stack (best-effort)
+func stackEvents(ctx context.Context, k8sClient *k8s.Client, stack *envv1alpha1.Stack, pods []corev1.Pod) []EventReport {
+	events, err := k8sClient.ListEvents(ctx, stack.Namespace)
+	if err != nil {
+		return []EventReport{}
+	}
+
+	filter := NewEventFilter(stack, pods)
+	reports := []EventReport{}
+	for i := range events {
+		if filter.Matches(&events[i]) {
+			reports = append(reports, NewEventReport(&events[i]))
+		}
+	}
+	if len(reports) > maxDescribeEvents {
+		reports = reports[len(reports)-maxDescribeEvents:]
+	}
+	return reports
+}
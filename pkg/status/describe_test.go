@@ -0,0 +1,34 @@
+package status_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+var _ = Describe("StackConfigs", func() {
+	configs := []status.ConfigRef{
+		{Name: "shared", Scope: status.ScopeGlobal, Keys: []string{"B", "A"}},
+		{Name: "repo-vars", Scope: status.ScopeRepo, Repository: "github.com/org/app"},
+		{Name: "other-repo", Scope: status.ScopeRepo, Repository: "github.com/org/other"},
+		{Name: "dev-vars", Scope: status.ScopeEnv, Env: "dev"},
+		{Name: "prod-vars", Scope: status.ScopeEnv, Env: "prod"},
+	}
+
+	It("should return configs matching the stack ordered by precedence", func() {
+		matched := status.StackConfigs(configs, "dev", "github.com/org/app")
+		names := make([]string, 0, len(matched))
+		for _, c := range matched {
+			names = append(names, c.Name)
+		}
+		Expect(names).To(Equal([]string{"dev-vars", "repo-vars", "shared"}))
+		Expect(matched[2].Keys).To(Equal([]string{"A", "B"}))
+	})
+
+	It("should not match repo-scoped configs without a repository", func() {
+		matched := status.StackConfigs(configs, "prod", "")
+		Expect(matched).To(HaveLen(2))
+		Expect(matched[0].Name).To(Equal("prod-vars"))
+	})
+})
@@ -0,0 +1,72 @@
+package status
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EventReport is a Kubernetes event related to a stack
+type EventReport struct {
+	Time    time.Time `json:"time" yaml:"time"`
+	Type    string    `json:"type" yaml:"type"`
+	Reason  string    `json:"reason" yaml:"reason"`
+	Object  string    `json:"object" yaml:"object"`
+	Message string    `json:"message" yaml:"message"`
+	Count   int32     `json:"count,omitempty" yaml:"count,omitempty"`
+}
+
+// EventFilter matches events whose involved object belongs to a stack
+type EventFilter struct {
+	names    map[string]bool
+	prefixes []string
+}
+
+// NewEventFilter builds a filter from a stack's pods (selected with the
+// stack label), their owners, and the stack's service names
+func NewEventFilter(stack *envv1alpha1.Stack, pods []corev1.Pod) *EventFilter {
+	f := &EventFilter{names: map[string]bool{stack.Name: true}}
+
+	for _, pod := range pods {
+		f.names[pod.Name] = true
+		for _, owner := range pod.OwnerReferences {
+			f.names[owner.Name] = true
+		}
+	}
+
+	for serviceName := range stack.Spec.Images {
+		f.names[serviceName] = true
+		f.prefixes = append(f.prefixes, serviceName+"-")
+	}
+
+	return f
+}
+
+// Matches reports whether an event concerns the stack
+func (f *EventFilter) Matches(e *corev1.Event) bool {
+	name := e.InvolvedObject.Name
+	if f.names[name] {
+		return true
+	}
+	for _, prefix := range f.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewEventReport converts a Kubernetes event to its report form
+func NewEventReport(e *corev1.Event) EventReport {
+	return EventReport{
+		Time:    k8s.EventTime(e),
+		Type:    e.Type,
+		Reason:  e.Reason,
+		Object:  strings.ToLower(e.InvolvedObject.Kind) + "/" + e.InvolvedObject.Name,
+		Message: strings.TrimSpace(e.Message),
+		Count:   e.Count,
+	}
+}
@@ -0,0 +1,54 @@
+package status_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lissto-dev/cli/pkg/status"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+)
+
+func newEvent(kind, name string) *corev1.Event {
+	return &corev1.Event{
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name},
+		Reason:         "Pulled",
+		Message:        "Successfully pulled image\n",
+	}
+}
+
+var _ = Describe("Events", func() {
+	var filter *status.EventFilter
+
+	BeforeEach(func() {
+		stack := &envv1alpha1.Stack{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-stack"},
+			Spec: envv1alpha1.StackSpec{
+				Images: map[string]envv1alpha1.ImageInfo{"api": {}},
+			},
+		}
+		pod := newPod("other-7f8", map[string]string{status.LabelStack: "my-stack"}, corev1.PodRunning, true)
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "other-rs"}}
+		filter = status.NewEventFilter(stack, []corev1.Pod{pod})
+	})
+
+	It("should match stack pods, their owners and service resources", func() {
+		Expect(filter.Matches(newEvent("Pod", "other-7f8"))).To(BeTrue())
+		Expect(filter.Matches(newEvent("ReplicaSet", "other-rs"))).To(BeTrue())
+		Expect(filter.Matches(newEvent("Deployment", "api"))).To(BeTrue())
+		Expect(filter.Matches(newEvent("ReplicaSet", "api-5d9f"))).To(BeTrue())
+		Expect(filter.Matches(newEvent("Stack", "my-stack"))).To(BeTrue())
+	})
+
+	It("should not match unrelated objects", func() {
+		Expect(filter.Matches(newEvent("Pod", "apiserver-1"))).To(BeFalse())
+		Expect(filter.Matches(newEvent("Pod", "unrelated"))).To(BeFalse())
+	})
+
+	It("should build a report with a kind/name object", func() {
+		r := status.NewEventReport(newEvent("Pod", "api-1"))
+		Expect(r.Object).To(Equal("pod/api-1"))
+		Expect(r.Message).To(Equal("Successfully pulled image"))
+	})
+})
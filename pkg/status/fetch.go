@@ -0,0 +1,134 @@
+package status
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultFetchConcurrency bounds the number of API calls in flight while
+// collecting status
+const DefaultFetchConcurrency = 8
+
+// StackData is the cluster state of one stack collected by FetchStacks
+type StackData struct {
+	// Pods are the stack's pods (nil without a cluster client)
+	Pods *StackPods
+	// Infra are the infra service names of the stack's blueprint (nil if unknown)
+	Infra []string
+	// Traffic is the readiness of each exposed service, keyed by service name
+	Traffic map[string]k8s.TrafficReadiness
+}
+
+// FetchStacks collects pods, blueprint infra and traffic readiness for the
+// given stacks concurrently, with at most limit calls in flight. Pods are
+// listed once per namespace and shared by every stack in it, and each
+// blueprint is looked up once. The result is index-aligned with stacks.
+// k8sClient and infra may be nil, in which case those details are omitted.
+func FetchStacks(ctx context.Context, k8sClient *k8s.Client, stacks []envv1alpha1.Stack, infra InfraLookup, limit int) []StackData {
+	if limit <= 0 {
+		limit = DefaultFetchConcurrency
+	}
+
+	type namespacePods struct {
+		pods []corev1.Pod
+		err  error
+	}
+
+	// Entries are allocated up front so goroutines never touch the maps
+	nsPods := make(map[string]*namespacePods)
+	blueprints := make(map[string]*[]string)
+
+	// Phase 1: one pod list per namespace and one lookup per blueprint
+	g := new(errgroup.Group)
+	g.SetLimit(limit)
+	for i := range stacks {
+		stack := &stacks[i]
+
+		if k8sClient != nil {
+			if _, ok := nsPods[stack.Namespace]; !ok {
+				entry := &namespacePods{}
+				nsPods[stack.Namespace] = entry
+				namespace := stack.Namespace
+				g.Go(func() error {
+					entry.pods, entry.err = k8sClient.ListPods(ctx, namespace, nil)
+					return nil
+				})
+			}
+		}
+
+		ref := stack.Spec.BlueprintReference
+		if infra != nil && ref != "" {
+			if _, ok := blueprints[ref]; !ok {
+				names := new([]string)
+				blueprints[ref] = names
+				g.Go(func() error {
+					*names = infra(ctx, ref)
+					return nil
+				})
+			}
+		}
+	}
+	_ = g.Wait()
+
+	data := make([]StackData, len(stacks))
+	for i := range stacks {
+		stack := &stacks[i]
+		if names, ok := blueprints[stack.Spec.BlueprintReference]; ok {
+			data[i].Infra = *names
+		}
+
+		if entry, ok := nsPods[stack.Namespace]; ok {
+			data[i].Pods = NewStackPods(stackPods(entry.pods, stack.Name), entry.err)
+		}
+	}
+
+	// Phase 2: traffic readiness of every exposed service
+	var mu sync.Mutex
+	g = new(errgroup.Group)
+	g.SetLimit(limit)
+	for i := range stacks {
+		if !data[i].Pods.Listed() {
+			continue
+		}
+
+		stack := &stacks[i]
+		sd := &data[i]
+		serviceAge := time.Since(stack.CreationTimestamp.Time)
+		for _, svc := range ParseServiceStatuses(stack) {
+			if svc.URL == "" {
+				continue
+			}
+			if sd.Traffic == nil {
+				sd.Traffic = make(map[string]k8s.TrafficReadiness)
+			}
+			name := svc.Name
+			g.Go(func() error {
+				readiness := k8sClient.CheckServiceReadiness(ctx, stack.Namespace, name, sd.Pods.ForService(name), serviceAge)
+				mu.Lock()
+				sd.Traffic[name] = readiness
+				mu.Unlock()
+				return nil
+			})
+		}
+	}
+	_ = g.Wait()
+
+	return data
+}
+
+// stackPods filters a namespace's pods down to those of one stack
+func stackPods(pods []corev1.Pod, stackName string) []corev1.Pod {
+	var matched []corev1.Pod
+	for _, pod := range pods {
+		if pod.Labels[LabelStack] == stackName {
+			matched = append(matched, pod)
+		}
+	}
+	return matched
+}
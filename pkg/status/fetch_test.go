@@ -0,0 +1,51 @@
+package status_test
+
+import (
+	"context"
+	"sync"
+
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+var _ = Describe("FetchStacks", func() {
+	newStack := func(name, blueprint string) envv1alpha1.Stack {
+		return envv1alpha1.Stack{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "dev"},
+			Spec:       envv1alpha1.StackSpec{BlueprintReference: blueprint},
+		}
+	}
+
+	It("should look up each blueprint once and align results with stacks", func() {
+		var mu sync.Mutex
+		calls := map[string]int{}
+		lookup := func(_ context.Context, ref string) []string {
+			mu.Lock()
+			defer mu.Unlock()
+			calls[ref]++
+			if ref == "bp-a" {
+				return []string{"postgres"}
+			}
+			return nil
+		}
+
+		stacks := []envv1alpha1.Stack{newStack("one", "bp-a"), newStack("two", "bp-b"), newStack("three", "bp-a")}
+		data := status.FetchStacks(context.Background(), nil, stacks, lookup, 2)
+
+		Expect(data).To(HaveLen(3))
+		Expect(data[0].Infra).To(Equal([]string{"postgres"}))
+		Expect(data[1].Infra).To(BeEmpty())
+		Expect(data[2].Infra).To(Equal([]string{"postgres"}))
+		Expect(calls).To(Equal(map[string]int{"bp-a": 1, "bp-b": 1}))
+	})
+
+	It("should not mark pods as listed without a cluster client", func() {
+		data := status.FetchStacks(context.Background(), nil, []envv1alpha1.Stack{newStack("one", "")}, nil, 0)
+		Expect(data[0].Pods).To(BeNil())
+		Expect(data[0].Pods.Listed()).To(BeFalse())
+	})
+})
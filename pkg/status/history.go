@@ -0,0 +1,148 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Default thresholds above which a service is reported as flapping
+const (
+	DefaultFlapTransitions = 2
+	DefaultFlapRestarts    = 3
+)
+
+// ReadinessSample is one observation of a service's readiness
+type ReadinessSample struct {
+	Time  time.Time
+	Ready bool
+	// Restarts is the restart count of each of the service's pods
+	Restarts map[string]int32
+}
+
+// History records readiness samples of services over a sliding window, to
+// spot services that flap between ready and not ready, e.g. crashlooping pods
+// that look ready between restarts
+type History struct {
+	window  time.Duration
+	samples map[serviceKey][]ReadinessSample
+}
+
+// serviceKey identifies a service across reports
+type serviceKey struct {
+	Env, Stack, Service string
+}
+
+// Flakiness summarizes the readiness history of one service
+type Flakiness struct {
+	Env     string `json:"env" yaml:"env"`
+	Stack   string `json:"stack" yaml:"stack"`
+	Service string `json:"service" yaml:"service"`
+	Samples int    `json:"samples" yaml:"samples"`
+	// Transitions counts changes between ready and not ready
+	Transitions int `json:"transitions" yaml:"transitions"`
+	// Restarts counts container restarts during the window
+	Restarts int32 `json:"restarts" yaml:"restarts"`
+	// ReadyRatio is the share of samples in which the service was ready
+	ReadyRatio float64 `json:"readyRatio" yaml:"readyRatio"`
+	Flapping   bool    `json:"flapping" yaml:"flapping"`
+	Reason     string  `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// NewHistory keeps the samples of the last window (all samples if zero)
+func NewHistory(window time.Duration) *History {
+	return &History{window: window, samples: make(map[serviceKey][]ReadinessSample)}
+}
+
+// Record adds a sample of every service in a report
+func (h *History) Record(at time.Time, report *Report) {
+	for _, env := range report.Envs {
+		for _, stack := range env.Stacks {
+			for _, svc := range stack.Services {
+				restarts := make(map[string]int32, len(svc.Pods))
+				for _, pod := range svc.Pods {
+					restarts[pod.Name] = pod.Restarts
+				}
+				key := serviceKey{Env: env.Name, Stack: stack.Name, Service: svc.Name}
+				h.samples[key] = append(h.samples[key], ReadinessSample{Time: at, Ready: ServiceReady(svc), Restarts: restarts})
+			}
+		}
+	}
+	h.trim(at)
+}
+
+// trim drops samples that fell out of the window
+func (h *History) trim(now time.Time) {
+	if h.window <= 0 {
+		return
+	}
+	cutoff := now.Add(-h.window)
+	for key, samples := range h.samples {
+		i := 0
+		for i < len(samples) && samples[i].Time.Before(cutoff) {
+			i++
+		}
+		if i == len(samples) {
+			delete(h.samples, key)
+			continue
+		}
+		h.samples[key] = samples[i:]
+	}
+}
+
+// Analyze summarizes every service's history, flapping services first. A
+// service flaps when it changed between ready and not ready at least
+// transitions times, or its containers restarted at least restarts times.
+func (h *History) Analyze(transitions int, restarts int32) []Flakiness {
+	results := make([]Flakiness, 0, len(h.samples))
+	for key, samples := range h.samples {
+		f := Flakiness{Env: key.Env, Stack: key.Stack, Service: key.Service, Samples: len(samples)}
+		ready := 0
+		for i, sample := range samples {
+			if sample.Ready {
+				ready++
+			}
+			if i == 0 {
+				continue
+			}
+			if sample.Ready != samples[i-1].Ready {
+				f.Transitions++
+			}
+			// Replaced pods have new names; only restarts of the same pod count
+			for pod, count := range sample.Restarts {
+				if previous, ok := samples[i-1].Restarts[pod]; ok && count > previous {
+					f.Restarts += count - previous
+				}
+			}
+		}
+		f.ReadyRatio = float64(ready) / float64(len(samples))
+
+		switch {
+		case f.Transitions >= transitions && f.Restarts >= restarts:
+			f.Flapping = true
+			f.Reason = fmt.Sprintf("%d readiness changes and %d restarts", f.Transitions, f.Restarts)
+		case f.Transitions >= transitions:
+			f.Flapping = true
+			f.Reason = fmt.Sprintf("%d readiness changes", f.Transitions)
+		case f.Restarts >= restarts:
+			f.Flapping = true
+			f.Reason = fmt.Sprintf("%d restarts", f.Restarts)
+		}
+		results = append(results, f)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Flapping != b.Flapping {
+			return a.Flapping
+		}
+		if a.Env != b.Env {
+			return a.Env < b.Env
+		}
+		if a.Stack != b.Stack {
+			return a.Stack < b.Stack
+		}
+		return a.Service < b.Service
+	})
+	return results
+}
@@ -0,0 +1,82 @@
+package status_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+var _ = Describe("History", func() {
+	start := time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	report := func(apiReady bool, apiPod string, apiRestarts int32) *status.Report {
+		api := status.ServiceReport{Name: "api", State: status.StateReady, Pods: []status.PodReport{{Name: apiPod, Ready: apiReady, Restarts: apiRestarts}}}
+		web := status.ServiceReport{Name: "web", State: status.StateReady, Pods: []status.PodReport{{Name: "web-1", Ready: true}}}
+		return &status.Report{Envs: []status.EnvReport{{
+			Name:   "dev",
+			Stacks: []status.StackReport{{Name: "shop", Services: []status.ServiceReport{web, api}}},
+		}}}
+	}
+
+	byService := func(results []status.Flakiness) map[string]status.Flakiness {
+		m := make(map[string]status.Flakiness)
+		for _, f := range results {
+			m[f.Service] = f
+		}
+		return m
+	}
+
+	It("should report services that flap between ready and not ready", func() {
+		h := status.NewHistory(0)
+		for i, ready := range []bool{true, false, true, false} {
+			h.Record(start.Add(time.Duration(i)*time.Minute), report(ready, "api-1", 0))
+		}
+
+		results := h.Analyze(status.DefaultFlapTransitions, status.DefaultFlapRestarts)
+		Expect(results[0].Service).To(Equal("api"))
+
+		services := byService(results)
+		Expect(services["api"].Flapping).To(BeTrue())
+		Expect(services["api"].Transitions).To(Equal(3))
+		Expect(services["api"].ReadyRatio).To(Equal(0.5))
+		Expect(services["web"].Flapping).To(BeFalse())
+		Expect(services["web"].ReadyRatio).To(Equal(1.0))
+	})
+
+	It("should report restart spikes of services that always look ready", func() {
+		h := status.NewHistory(0)
+		for i, restarts := range []int32{5, 6, 8, 9} {
+			h.Record(start.Add(time.Duration(i)*time.Minute), report(true, "api-1", restarts))
+		}
+
+		api := byService(h.Analyze(status.DefaultFlapTransitions, status.DefaultFlapRestarts))["api"]
+		Expect(api.Transitions).To(BeZero())
+		Expect(api.Restarts).To(Equal(int32(4)))
+		Expect(api.Flapping).To(BeTrue())
+		Expect(api.Reason).To(Equal("4 restarts"))
+	})
+
+	It("should not count restarts of replaced pods", func() {
+		h := status.NewHistory(0)
+		h.Record(start, report(true, "api-1", 7))
+		h.Record(start.Add(time.Minute), report(true, "api-2", 0))
+
+		api := byService(h.Analyze(status.DefaultFlapTransitions, status.DefaultFlapRestarts))["api"]
+		Expect(api.Restarts).To(BeZero())
+		Expect(api.Flapping).To(BeFalse())
+	})
+
+	It("should forget samples outside the window", func() {
+		h := status.NewHistory(5 * time.Minute)
+		h.Record(start, report(false, "api-1", 0))
+		h.Record(start.Add(time.Minute), report(true, "api-1", 0))
+		h.Record(start.Add(10*time.Minute), report(true, "api-1", 0))
+
+		api := byService(h.Analyze(status.DefaultFlapTransitions, status.DefaultFlapRestarts))["api"]
+		Expect(api.Samples).To(Equal(1))
+		Expect(api.Transitions).To(BeZero())
+	})
+})
@@ -0,0 +1,176 @@
+package status
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Aggregate pod states reported by PodState
+const (
+	PodStateError   = "Error"
+	PodStatePending = "Pending"
+)
+
+// Labels used to find a stack's pods
+const (
+	LabelStack          = "lissto.dev/stack"
+	LabelService        = "lissto.dev/service"
+	LabelKomposeService = "io.kompose.service"
+)
+
+// errorWaitingReasons are container waiting reasons treated as failures
+var errorWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":     true,
+	"ImagePullBackOff":     true,
+	"ErrImagePull":         true,
+	"CreateContainerError": true,
+	"InvalidImageName":     true,
+}
+
+// StackPodLabels returns the label selector matching all pods of a stack
+func StackPodLabels(stackName string) map[string]string {
+	return map[string]string{LabelStack: stackName}
+}
+
+// MatchServicePods filters a stack's pods down to those of one service using
+// multiple matching strategies
+func MatchServicePods(pods []corev1.Pod, serviceName string) []corev1.Pod {
+	var servicePods []corev1.Pod
+	for _, pod := range pods {
+		matched := false
+
+		// Strategy 1: Check lissto.dev/service label
+		if pod.Labels != nil && pod.Labels[LabelService] == serviceName {
+			matched = true
+		}
+
+		// Strategy 2: Check io.kompose.service label (from kompose conversion)
+		if !matched && pod.Labels != nil && pod.Labels[LabelKomposeService] == serviceName {
+			matched = true
+		}
+
+		// Strategy 3: Pod name prefix matching (e.g., "bo-67db85fc78-lhs9t" matches "bo")
+		if !matched && strings.HasPrefix(pod.Name, serviceName+"-") {
+			matched = true
+		}
+
+		if matched {
+			servicePods = append(servicePods, pod)
+		}
+	}
+	return servicePods
+}
+
+// PodServiceName returns the service a pod belongs to, using the same
+// strategies as MatchServicePods. services are the stack's service names,
+// used for name prefix matching; returns "" if no service matches.
+func PodServiceName(pod *corev1.Pod, services []string) string {
+	if pod.Labels != nil {
+		if name := pod.Labels[LabelService]; name != "" {
+			return name
+		}
+		if name := pod.Labels[LabelKomposeService]; name != "" {
+			return name
+		}
+	}
+
+	// Prefer the longest prefix so "api-worker-x" matches "api-worker" over "api"
+	match := ""
+	for _, svc := range services {
+		if strings.HasPrefix(pod.Name, svc+"-") && len(svc) > len(match) {
+			match = svc
+		}
+	}
+	return match
+}
+
+// PodState aggregates the state of a stack's pods.
+// Returns StateReady, PodStatePending, PodStateError, or StateUnknown (no pods).
+func PodState(pods []corev1.Pod) string {
+	if len(pods) == 0 {
+		// No pods found - likely wrong cluster or stack failed to deploy
+		return StateUnknown
+	}
+
+	hasError := false
+	hasPending := false
+	allRunning := true
+
+	for _, pod := range pods {
+		phase := pod.Status.Phase
+
+		// Check for explicit failure
+		if phase == corev1.PodFailed {
+			hasError = true
+			continue
+		}
+
+		// Check for pending state
+		if phase == corev1.PodPending {
+			hasPending = true
+			allRunning = false
+			continue
+		}
+
+		// Check if running
+		if phase != corev1.PodRunning {
+			allRunning = false
+		}
+
+		// Check container statuses for any issues
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting != nil {
+				if errorWaitingReasons[cs.State.Waiting.Reason] {
+					hasError = true
+				} else {
+					// Other waiting reasons mean still starting
+					hasPending = true
+					allRunning = false
+				}
+			}
+			// Check if container has terminated
+			if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+				hasError = true
+			}
+			// Check if container is not ready
+			if !cs.Ready {
+				allRunning = false
+			}
+		}
+	}
+
+	if hasError {
+		return PodStateError
+	}
+
+	if hasPending || !allRunning {
+		return PodStatePending
+	}
+
+	return StateReady
+}
+
+// ApplyPodState overrides a stack status with the aggregated pod state
+func ApplyPodState(stackStatus *StackStatus, podState string) {
+	switch podState {
+	case StateUnknown:
+		stackStatus.State = StateUnknown
+		stackStatus.Symbol = SymbolUnknown
+		stackStatus.Reason = "Can't find pods - check cluster context"
+	case PodStateError:
+		stackStatus.State = PodStateError
+		stackStatus.Symbol = SymbolFailed
+		stackStatus.Reason = "Pod issues detected"
+	case PodStatePending:
+		stackStatus.State = StateDeploying
+		stackStatus.Symbol = SymbolDeploying
+		stackStatus.Reason = "Pods starting"
+	}
+}
+
+// IsJobPod reports whether a pod belongs to a run-to-completion workload
+func IsJobPod(pod *corev1.Pod) bool {
+	return pod.Spec.RestartPolicy == corev1.RestartPolicyNever ||
+		pod.Spec.RestartPolicy == corev1.RestartPolicyOnFailure
+}
@@ -0,0 +1,113 @@
+package status_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+func newPod(name string, labels map[string]string, phase corev1.PodPhase, ready bool) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "main", Ready: ready}},
+		},
+	}
+}
+
+var _ = Describe("Pods", func() {
+	Describe("MatchServicePods", func() {
+		It("should match by service label, kompose label and name prefix", func() {
+			pods := []corev1.Pod{
+				newPod("a-1", map[string]string{status.LabelService: "api"}, corev1.PodRunning, true),
+				newPod("b-1", map[string]string{status.LabelKomposeService: "api"}, corev1.PodRunning, true),
+				newPod("api-5d9f-x2", nil, corev1.PodRunning, true),
+				newPod("worker-1", map[string]string{status.LabelService: "worker"}, corev1.PodRunning, true),
+				newPod("apiserver-1", nil, corev1.PodRunning, true),
+			}
+
+			matched := status.MatchServicePods(pods, "api")
+			names := make([]string, 0, len(matched))
+			for _, p := range matched {
+				names = append(names, p.Name)
+			}
+			Expect(names).To(ConsistOf("a-1", "b-1", "api-5d9f-x2"))
+		})
+	})
+
+	Describe("PodServiceName", func() {
+		services := []string{"api", "api-worker"}
+
+		It("should prefer service labels", func() {
+			pod := newPod("x-1", map[string]string{status.LabelService: "web"}, corev1.PodRunning, true)
+			Expect(status.PodServiceName(&pod, services)).To(Equal("web"))
+		})
+
+		It("should match the longest name prefix", func() {
+			pod := newPod("api-worker-5d9f-x2", nil, corev1.PodRunning, true)
+			Expect(status.PodServiceName(&pod, services)).To(Equal("api-worker"))
+		})
+
+		It("should return empty when nothing matches", func() {
+			pod := newPod("db-0", nil, corev1.PodRunning, true)
+			Expect(status.PodServiceName(&pod, services)).To(BeEmpty())
+		})
+	})
+
+	Describe("PodState", func() {
+		It("should be unknown without pods", func() {
+			Expect(status.PodState(nil)).To(Equal(status.StateUnknown))
+		})
+
+		It("should be ready when all pods run and are ready", func() {
+			pods := []corev1.Pod{newPod("a", nil, corev1.PodRunning, true)}
+			Expect(status.PodState(pods)).To(Equal(status.StateReady))
+		})
+
+		It("should be pending when a pod is not ready", func() {
+			pods := []corev1.Pod{
+				newPod("a", nil, corev1.PodRunning, true),
+				newPod("b", nil, corev1.PodRunning, false),
+			}
+			Expect(status.PodState(pods)).To(Equal(status.PodStatePending))
+		})
+
+		It("should be error on CrashLoopBackOff", func() {
+			pod := newPod("a", nil, corev1.PodRunning, false)
+			pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+			Expect(status.PodState([]corev1.Pod{pod})).To(Equal(status.PodStateError))
+		})
+	})
+
+	Describe("StackPods", func() {
+		It("should match service pods and aggregate state", func() {
+			pods := status.NewStackPods([]corev1.Pod{
+				newPod("api-1", map[string]string{status.LabelService: "api"}, corev1.PodRunning, true),
+				newPod("worker-1", map[string]string{status.LabelService: "worker"}, corev1.PodPending, false),
+			}, nil)
+
+			Expect(pods.Listed()).To(BeTrue())
+			Expect(pods.ForService("api")).To(HaveLen(1))
+			Expect(pods.ForService("db")).To(BeEmpty())
+			Expect(pods.State()).To(Equal(status.PodStatePending))
+		})
+
+		It("should report unknown state when listing failed", func() {
+			pods := status.NewStackPods(nil, errors.New("forbidden"))
+			Expect(pods.Listed()).To(BeFalse())
+			Expect(pods.ForService("api")).To(BeNil())
+			Expect(pods.State()).To(Equal(status.StateUnknown))
+		})
+
+		It("should leave the state empty when pods were not fetched", func() {
+			var pods *status.StackPods
+			Expect(pods.State()).To(BeEmpty())
+		})
+	})
+})
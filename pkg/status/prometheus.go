@@ -0,0 +1,199 @@
+package status
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Health statuses reported by /healthz
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+	HealthDown     = "down"
+)
+
+// Snapshot is a status report refreshed periodically, as served by
+// 'lissto status --serve'
+type Snapshot struct {
+	Report  *Report
+	Updated time.Time
+	// Err is the last refresh error; Report is then from an earlier refresh
+	Err error
+}
+
+// Health is the /healthz summary of a snapshot
+type Health struct {
+	Status   string        `json:"status" yaml:"status"`
+	Stacks   int           `json:"stacks" yaml:"stacks"`
+	Ready    int           `json:"ready" yaml:"ready"`
+	NotReady []HealthIssue `json:"notReady,omitempty" yaml:"notReady,omitempty"`
+	Updated  time.Time     `json:"updated" yaml:"updated"`
+	Error    string        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// HealthIssue is a stack that isn't ready
+type HealthIssue struct {
+	Env    string `json:"env" yaml:"env"`
+	Stack  string `json:"stack" yaml:"stack"`
+	State  string `json:"state" yaml:"state"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// NewHealth summarizes a snapshot: ok when every stack is ready, degraded
+// when some aren't, down when the status couldn't be fetched
+func NewHealth(snap Snapshot) Health {
+	health := Health{Status: HealthOK, Updated: snap.Updated}
+	if snap.Err != nil {
+		health.Status = HealthDown
+		health.Error = snap.Err.Error()
+	}
+	if snap.Report == nil {
+		if snap.Err == nil {
+			health.Status = HealthDown
+		}
+		return health
+	}
+
+	for _, env := range snap.Report.Envs {
+		for _, stack := range env.Stacks {
+			health.Stacks++
+			if stack.State == StateReady {
+				health.Ready++
+				continue
+			}
+			health.NotReady = append(health.NotReady, HealthIssue{Env: env.Name, Stack: stack.Name, State: stack.State, Reason: stack.Reason})
+		}
+	}
+	if health.Status == HealthOK && len(health.NotReady) > 0 {
+		health.Status = HealthDegraded
+	}
+	return health
+}
+
+// WritePrometheus writes a snapshot in the Prometheus text exposition format
+func WritePrometheus(w io.Writer, snap Snapshot) error {
+	m := &metricWriter{w: w}
+
+	up := 1.0
+	if snap.Err != nil || snap.Report == nil {
+		up = 0
+	}
+	m.family("lissto_up", "gauge", "Whether the last status refresh succeeded")
+	m.sample("lissto_up", "", up)
+	if !snap.Updated.IsZero() {
+		m.family("lissto_last_refresh_timestamp_seconds", "gauge", "Time of the last successful status refresh")
+		m.sample("lissto_last_refresh_timestamp_seconds", "", float64(snap.Updated.Unix()))
+	}
+	if snap.Report == nil {
+		return m.err
+	}
+
+	type stackRef struct {
+		env   string
+		stack StackReport
+	}
+	var stacks []stackRef
+	for _, env := range snap.Report.Envs {
+		for _, stack := range env.Stacks {
+			stacks = append(stacks, stackRef{env.Name, stack})
+		}
+	}
+
+	m.family("lissto_stack_ready", "gauge", "Whether the stack is ready (1) or not (0)")
+	for _, s := range stacks {
+		m.sample("lissto_stack_ready", labels("env", s.env, "stack", s.stack.Name), boolValue(s.stack.State == StateReady))
+	}
+	m.family("lissto_stack_info", "gauge", "Stack metadata; the state label is Ready, Deploying, Failed, Error, Pending or Unknown")
+	for _, s := range stacks {
+		m.sample("lissto_stack_info", labels("env", s.env, "stack", s.stack.Name, "blueprint", s.stack.Blueprint, "state", s.stack.State), 1)
+	}
+	m.family("lissto_stack_services_ready", "gauge", "Number of ready services in the stack")
+	for _, s := range stacks {
+		m.sample("lissto_stack_services_ready", labels("env", s.env, "stack", s.stack.Name), float64(s.stack.ReadyServices))
+	}
+	m.family("lissto_stack_services", "gauge", "Number of services in the stack")
+	for _, s := range stacks {
+		m.sample("lissto_stack_services", labels("env", s.env, "stack", s.stack.Name), float64(s.stack.TotalServices))
+	}
+
+	m.family("lissto_service_ready", "gauge", "Whether the service is ready (1) or not (0)")
+	for _, s := range stacks {
+		for _, svc := range s.stack.Services {
+			m.sample("lissto_service_ready", labels("env", s.env, "stack", s.stack.Name, "service", svc.Name, "category", svc.Category), boolValue(svc.State == StateReady))
+		}
+	}
+	m.family("lissto_service_traffic_ready", "gauge", "Whether an exposed service can receive traffic (1) or not (0)")
+	for _, s := range stacks {
+		for _, svc := range s.stack.Services {
+			if svc.Traffic != nil {
+				m.sample("lissto_service_traffic_ready", labels("env", s.env, "stack", s.stack.Name, "service", svc.Name), boolValue(svc.Traffic.Ready))
+			}
+		}
+	}
+
+	m.family("lissto_pod_ready", "gauge", "Whether the pod is ready (1) or not (0)")
+	for _, s := range stacks {
+		for _, svc := range s.stack.Services {
+			for _, pod := range svc.Pods {
+				m.sample("lissto_pod_ready", labels("env", s.env, "stack", s.stack.Name, "service", svc.Name, "pod", pod.Name, "phase", pod.Phase), boolValue(pod.Ready))
+			}
+		}
+	}
+	m.family("lissto_pod_restarts_total", "counter", "Container restarts of the pod")
+	for _, s := range stacks {
+		for _, svc := range s.stack.Services {
+			for _, pod := range svc.Pods {
+				m.sample("lissto_pod_restarts_total", labels("env", s.env, "stack", s.stack.Name, "service", svc.Name, "pod", pod.Name), float64(pod.Restarts))
+			}
+		}
+	}
+
+	return m.err
+}
+
+// metricWriter writes metric families, keeping the first write error
+type metricWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (m *metricWriter) family(name, kind, help string) {
+	m.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (m *metricWriter) sample(name, labels string, value float64) {
+	m.printf("%s%s %s\n", name, labels, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func (m *metricWriter) printf(format string, args ...interface{}) {
+	if m.err == nil {
+		_, m.err = fmt.Fprintf(m.w, format, args...)
+	}
+}
+
+// labelEscaper escapes label values as the exposition format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels formats name/value pairs as {name="value",...}
+func labels(pairs ...string) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `%s="%s"`, pairs[i], labelEscaper.Replace(pairs[i+1]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package status_test
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+var _ = Describe("Prometheus", func() {
+	updated := time.Unix(1700000000, 0)
+	report := &status.Report{Envs: []status.EnvReport{{
+		Name: "dev",
+		Stacks: []status.StackReport{
+			{
+				Name: "ready-stack", Blueprint: "bp", State: status.StateReady, ReadyServices: 1, TotalServices: 1,
+				Services: []status.ServiceReport{{
+					Name: "api", Category: status.CategoryService, State: status.StateReady,
+					Traffic: &status.Traffic{Ready: true},
+					Pods:    []status.PodReport{{Name: "api-1", Phase: "Running", Ready: true, Restarts: 3}},
+				}},
+			},
+			{Name: "broken \"stack\"", State: status.StateFailed, Reason: "ImagePullBackOff"},
+		},
+	}}}
+
+	It("should write stack, service and pod metrics", func() {
+		var buf bytes.Buffer
+		Expect(status.WritePrometheus(&buf, status.Snapshot{Report: report, Updated: updated})).To(Succeed())
+
+		out := buf.String()
+		Expect(out).To(ContainSubstring("# TYPE lissto_up gauge\nlissto_up 1\n"))
+		Expect(out).To(ContainSubstring("lissto_last_refresh_timestamp_seconds 1700000000\n"))
+		Expect(out).To(ContainSubstring(`lissto_stack_ready{env="dev",stack="ready-stack"} 1`))
+		Expect(out).To(ContainSubstring(`lissto_stack_ready{env="dev",stack="broken \"stack\""} 0`))
+		Expect(out).To(ContainSubstring(`lissto_stack_info{env="dev",stack="ready-stack",blueprint="bp",state="Ready"} 1`))
+		Expect(out).To(ContainSubstring(`lissto_service_traffic_ready{env="dev",stack="ready-stack",service="api"} 1`))
+		Expect(out).To(ContainSubstring(`lissto_pod_restarts_total{env="dev",stack="ready-stack",service="api",pod="api-1"} 3`))
+	})
+
+	It("should report a failed refresh as down", func() {
+		var buf bytes.Buffer
+		Expect(status.WritePrometheus(&buf, status.Snapshot{Err: errors.New("boom")})).To(Succeed())
+		Expect(buf.String()).To(Equal("# HELP lissto_up Whether the last status refresh succeeded\n# TYPE lissto_up gauge\nlissto_up 0\n"))
+	})
+
+	It("should summarize health", func() {
+		health := status.NewHealth(status.Snapshot{Report: report, Updated: updated})
+		Expect(health.Status).To(Equal(status.HealthDegraded))
+		Expect(health.Stacks).To(Equal(2))
+		Expect(health.Ready).To(Equal(1))
+		Expect(health.NotReady).To(Equal([]status.HealthIssue{
+			{Env: "dev", Stack: "broken \"stack\"", State: status.StateFailed, Reason: "ImagePullBackOff"},
+		}))
+
+		Expect(status.NewHealth(status.Snapshot{Err: errors.New("boom")}).Status).To(Equal(status.HealthDown))
+	})
+})
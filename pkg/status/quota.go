@@ -0,0 +1,196 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// QuotaNearThreshold is the share of a quota from which it is reported as
+// nearly used up
+const QuotaNearThreshold = 0.8
+
+// Quota states
+const (
+	QuotaOK       = "ok"
+	QuotaNear     = "near"
+	QuotaExceeded = "exceeded"
+)
+
+// Resources are the summed resource requests and limits of pods
+type Resources struct {
+	Pods     int   `json:"pods" yaml:"pods"`
+	Requests Usage `json:"requests" yaml:"requests"`
+	Limits   Usage `json:"limits" yaml:"limits"`
+}
+
+// StackResources are the requests and limits of a stack's pods
+type StackResources struct {
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Resources `yaml:",inline"`
+}
+
+// QuotaUsage is the use of one resource of a ResourceQuota
+type QuotaUsage struct {
+	Quota     string `json:"quota" yaml:"quota"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Resource  string `json:"resource" yaml:"resource"`
+	Hard      string `json:"hard" yaml:"hard"`
+	// Used is what the quota counts, including pods outside of stacks
+	Used string `json:"used" yaml:"used"`
+	// Stacks is what the environment's stacks account for, if the resource
+	// is one stacks are summed for
+	Stacks  string  `json:"stacks,omitempty" yaml:"stacks,omitempty"`
+	Percent float64 `json:"percent" yaml:"percent"`
+	State   string  `json:"state" yaml:"state"`
+}
+
+// EnvResources are the requests and limits of an environment's stacks,
+// compared to the quotas of their namespaces
+type EnvResources struct {
+	Env    string           `json:"env" yaml:"env"`
+	Total  Resources        `json:"total" yaml:"total"`
+	Stacks []StackResources `json:"stacks" yaml:"stacks"`
+	Quotas []QuotaUsage     `json:"quotas" yaml:"quotas"`
+}
+
+// BuildStackResources sums the requests and limits of a stack's pods.
+// Finished pods are left out, as quotas no longer count them.
+func BuildStackResources(stack *envv1alpha1.Stack, pods []corev1.Pod) StackResources {
+	res := StackResources{Name: stack.Name, Namespace: stack.Namespace}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		res.Pods++
+		res.Requests.add(podResources(pod, func(c *corev1.Container) corev1.ResourceList { return c.Resources.Requests }))
+		res.Limits.add(podResources(pod, func(c *corev1.Container) corev1.ResourceList { return c.Resources.Limits }))
+	}
+	return res
+}
+
+// podResources returns what a pod reserves the way the scheduler counts it:
+// the sum of its containers, or its largest init container if that is more
+func podResources(pod *corev1.Pod, list func(*corev1.Container) corev1.ResourceList) Usage {
+	var sum, initMax Usage
+	for i := range pod.Spec.Containers {
+		sum.add(resourceUsage(list(&pod.Spec.Containers[i])))
+	}
+	for i := range pod.Spec.InitContainers {
+		u := resourceUsage(list(&pod.Spec.InitContainers[i]))
+		initMax.CPUMillis = max(initMax.CPUMillis, u.CPUMillis)
+		initMax.MemoryBytes = max(initMax.MemoryBytes, u.MemoryBytes)
+	}
+	return Usage{
+		CPUMillis:   max(sum.CPUMillis, initMax.CPUMillis),
+		MemoryBytes: max(sum.MemoryBytes, initMax.MemoryBytes),
+	}
+}
+
+func resourceUsage(list corev1.ResourceList) Usage {
+	return Usage{CPUMillis: list.Cpu().MilliValue(), MemoryBytes: list.Memory().Value()}
+}
+
+// BuildEnvResources totals the stacks of an environment and compares each
+// quota resource to its hard limit. Quotas are ordered by use, highest first.
+func BuildEnvResources(env string, stacks []StackResources, quotas []corev1.ResourceQuota) EnvResources {
+	report := EnvResources{Env: env, Stacks: stacks, Quotas: []QuotaUsage{}}
+	if report.Stacks == nil {
+		report.Stacks = []StackResources{}
+	}
+
+	byNamespace := make(map[string]*Resources)
+	for _, s := range stacks {
+		report.Total.add(s.Resources)
+		ns := byNamespace[s.Namespace]
+		if ns == nil {
+			ns = &Resources{}
+			byNamespace[s.Namespace] = ns
+		}
+		ns.add(s.Resources)
+	}
+
+	for _, quota := range quotas {
+		for name, hard := range quota.Status.Hard {
+			used := quota.Status.Used[name]
+			usage := QuotaUsage{
+				Quota:     quota.Name,
+				Namespace: quota.Namespace,
+				Resource:  string(name),
+				Hard:      hard.String(),
+				Used:      used.String(),
+				State:     QuotaOK,
+			}
+			if ns := byNamespace[quota.Namespace]; ns != nil {
+				usage.Stacks = ns.quantity(name)
+			}
+			if h := hard.AsApproximateFloat64(); h > 0 {
+				usage.Percent = used.AsApproximateFloat64() / h * 100
+			} else if !used.IsZero() {
+				usage.Percent = 100
+			}
+			switch {
+			case usage.Percent >= 100:
+				usage.State = QuotaExceeded
+			case usage.Percent >= QuotaNearThreshold*100:
+				usage.State = QuotaNear
+			}
+			report.Quotas = append(report.Quotas, usage)
+		}
+	}
+
+	sort.Slice(report.Quotas, func(i, j int) bool {
+		a, b := report.Quotas[i], report.Quotas[j]
+		if a.Percent != b.Percent {
+			return a.Percent > b.Percent
+		}
+		if a.Quota != b.Quota {
+			return a.Quota < b.Quota
+		}
+		return a.Resource < b.Resource
+	})
+	return report
+}
+
+// Warnings describes the quota resources that are nearly or fully used up
+func (r *EnvResources) Warnings() []string {
+	var warnings []string
+	for _, q := range r.Quotas {
+		switch q.State {
+		case QuotaExceeded:
+			warnings = append(warnings, fmt.Sprintf("%s of quota %s is used up (%s/%s): new pods needing it will be rejected", q.Resource, q.Quota, q.Used, q.Hard))
+		case QuotaNear:
+			warnings = append(warnings, fmt.Sprintf("%s of quota %s is %.0f%% used (%s/%s)", q.Resource, q.Quota, q.Percent, q.Used, q.Hard))
+		}
+	}
+	return warnings
+}
+
+// quantity formats the stacks' share of a quota resource, "" for resources
+// that aren't summed
+func (r *Resources) quantity(name corev1.ResourceName) string {
+	switch name {
+	case corev1.ResourceRequestsCPU, corev1.ResourceCPU:
+		return r.Requests.CPU()
+	case corev1.ResourceLimitsCPU:
+		return r.Limits.CPU()
+	case corev1.ResourceRequestsMemory, corev1.ResourceMemory:
+		return r.Requests.Memory()
+	case corev1.ResourceLimitsMemory:
+		return r.Limits.Memory()
+	case corev1.ResourcePods:
+		return strconv.Itoa(r.Pods)
+	}
+	return ""
+}
+
+func (r *Resources) add(other Resources) {
+	r.Pods += other.Pods
+	r.Requests.add(other.Requests)
+	r.Limits.add(other.Limits)
+}
@@ -0,0 +1,88 @@
+package status_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/status"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Quota", func() {
+	container := func(cpu, memory string) corev1.Container {
+		return corev1.Container{Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)},
+		}}
+	}
+	pod := func(phase corev1.PodPhase, init []corev1.Container, containers ...corev1.Container) corev1.Pod {
+		return corev1.Pod{
+			Spec:   corev1.PodSpec{InitContainers: init, Containers: containers},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	stack := &envv1alpha1.Stack{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "dev-ns"}}
+
+	Describe("BuildStackResources", func() {
+		It("should sum running pods the way the scheduler counts them", func() {
+			res := status.BuildStackResources(stack, []corev1.Pod{
+				pod(corev1.PodRunning, nil, container("100m", "64Mi"), container("50m", "64Mi")),
+				pod(corev1.PodPending, []corev1.Container{container("500m", "32Mi")}, container("100m", "64Mi")),
+				pod(corev1.PodSucceeded, nil, container("1", "1Gi")),
+			})
+
+			Expect(res.Pods).To(Equal(2))
+			Expect(res.Requests.CPU()).To(Equal("650m"))
+			Expect(res.Requests.Memory()).To(Equal("192Mi"))
+			Expect(res.Limits).To(Equal(res.Requests))
+		})
+	})
+
+	Describe("BuildEnvResources", func() {
+		quota := func(hard, used corev1.ResourceList) corev1.ResourceQuota {
+			return corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "dev-ns"},
+				Status:     corev1.ResourceQuotaStatus{Hard: hard, Used: used},
+			}
+		}
+
+		It("should compare quotas to their limits, most used first", func() {
+			stacks := []status.StackResources{
+				status.BuildStackResources(stack, []corev1.Pod{pod(corev1.PodRunning, nil, container("900m", "1Gi"))}),
+			}
+			report := status.BuildEnvResources("dev", stacks, []corev1.ResourceQuota{quota(
+				corev1.ResourceList{
+					corev1.ResourceRequestsCPU:    resource.MustParse("1"),
+					corev1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+					corev1.ResourcePods:           resource.MustParse("1"),
+				},
+				corev1.ResourceList{
+					corev1.ResourceRequestsCPU:    resource.MustParse("900m"),
+					corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+					corev1.ResourcePods:           resource.MustParse("1"),
+				},
+			)})
+
+			Expect(report.Total.Pods).To(Equal(1))
+			Expect(report.Quotas).To(HaveLen(3))
+			Expect(report.Quotas[0].Resource).To(Equal("pods"))
+			Expect(report.Quotas[0].State).To(Equal(status.QuotaExceeded))
+			Expect(report.Quotas[1].Resource).To(Equal("requests.cpu"))
+			Expect(report.Quotas[1].State).To(Equal(status.QuotaNear))
+			Expect(report.Quotas[1].Stacks).To(Equal("900m"))
+			Expect(report.Quotas[2].State).To(Equal(status.QuotaOK))
+			Expect(report.Quotas[2].Percent).To(BeNumerically("~", 25))
+			Expect(report.Warnings()).To(HaveLen(2))
+		})
+
+		It("should report no quotas for an unrestricted namespace", func() {
+			report := status.BuildEnvResources("dev", nil, nil)
+			Expect(report.Stacks).To(BeEmpty())
+			Expect(report.Quotas).To(BeEmpty())
+			Expect(report.Warnings()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,194 @@
+package status
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/types"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+)
+
+// Service categories in a report
+const (
+	CategoryService = "service"
+	CategoryJob     = "job"
+	CategoryInfra   = "infra"
+)
+
+// Report is the normalized status model shared by the pretty view and
+// machine-readable output: env → stacks → services → pods
+type Report struct {
+	Envs []EnvReport `json:"envs" yaml:"envs"`
+}
+
+// EnvReport groups the stacks of one environment
+type EnvReport struct {
+	Name   string        `json:"name" yaml:"name"`
+	Stacks []StackReport `json:"stacks" yaml:"stacks"`
+}
+
+// StackReport is the status of one stack
+type StackReport struct {
+	Name          string          `json:"name" yaml:"name"`
+	Namespace     string          `json:"namespace" yaml:"namespace"`
+	Title         string          `json:"title,omitempty" yaml:"title,omitempty"`
+	Blueprint     string          `json:"blueprint" yaml:"blueprint"`
+	State         string          `json:"state" yaml:"state"`
+	Reason        string          `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Created       time.Time       `json:"created" yaml:"created"`
+	ReadyServices int             `json:"readyServices" yaml:"readyServices"`
+	TotalServices int             `json:"totalServices" yaml:"totalServices"`
+	Services      []ServiceReport `json:"services" yaml:"services"`
+}
+
+// ServiceReport is the status of one service within a stack
+type ServiceReport struct {
+	Name     string      `json:"name" yaml:"name"`
+	Category string      `json:"category" yaml:"category"`
+	State    string      `json:"state" yaml:"state"`
+	Image    string      `json:"image,omitempty" yaml:"image,omitempty"`
+	URL      string      `json:"url,omitempty" yaml:"url,omitempty"`
+	Traffic  *Traffic    `json:"traffic,omitempty" yaml:"traffic,omitempty"`
+	Pods     []PodReport `json:"pods" yaml:"pods"`
+}
+
+// Traffic is the readiness of an exposed service to receive traffic
+type Traffic struct {
+	Ready  bool   `json:"ready" yaml:"ready"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// PodReport is the status of one pod
+type PodReport struct {
+	Name     string    `json:"name" yaml:"name"`
+	Phase    string    `json:"phase" yaml:"phase"`
+	Ready    bool      `json:"ready" yaml:"ready"`
+	Restarts int32     `json:"restarts" yaml:"restarts"`
+	Created  time.Time `json:"created" yaml:"created"`
+}
+
+// InfraLookup returns the infra service names of a blueprint (nil if unknown)
+type InfraLookup func(ctx context.Context, blueprintRef string) []string
+
+// BuildReport builds the status report for the given stacks. k8sClient may be
+// nil, in which case pod and traffic details are omitted.
+func BuildReport(ctx context.Context, k8sClient *k8s.Client, stacks []envv1alpha1.Stack, infra InfraLookup) *Report {
+	groups := make(map[string][]envv1alpha1.Stack)
+	for _, stack := range stacks {
+		env := stack.Spec.Env
+		if env == "" {
+			env = "unknown"
+		}
+		groups[env] = append(groups[env], stack)
+	}
+
+	envNames := make([]string, 0, len(groups))
+	for env := range groups {
+		envNames = append(envNames, env)
+	}
+	sort.Strings(envNames)
+
+	// Order stacks first so all cluster data can be fetched in one pass
+	ordered := make([]envv1alpha1.Stack, 0, len(stacks))
+	for _, env := range envNames {
+		envStacks := groups[env]
+		sort.Slice(envStacks, func(i, j int) bool {
+			return envStacks[i].CreationTimestamp.After(envStacks[j].CreationTimestamp.Time)
+		})
+		ordered = append(ordered, envStacks...)
+	}
+	data := FetchStacks(ctx, k8sClient, ordered, infra, DefaultFetchConcurrency)
+
+	report := &Report{Envs: make([]EnvReport, 0, len(envNames))}
+	idx := 0
+	for _, env := range envNames {
+		envReport := EnvReport{Name: env, Stacks: make([]StackReport, 0, len(groups[env]))}
+		for range groups[env] {
+			envReport.Stacks = append(envReport.Stacks, NewStackReport(&ordered[idx], &data[idx]))
+			idx++
+		}
+		report.Envs = append(report.Envs, envReport)
+	}
+
+	return report
+}
+
+// BuildStackReport builds the status report for a single stack, listing its
+// pods once and matching them to services
+func BuildStackReport(ctx context.Context, k8sClient *k8s.Client, stack *envv1alpha1.Stack, infra InfraLookup) StackReport {
+	data := FetchStacks(ctx, k8sClient, []envv1alpha1.Stack{*stack}, infra, DefaultFetchConcurrency)
+	return NewStackReport(stack, &data[0])
+}
+
+// NewStackReport builds the status report for a stack from its fetched
+// cluster data
+func NewStackReport(stack *envv1alpha1.Stack, data *StackData) StackReport {
+	stackStatus := ParseStackStatus(stack.Status.Conditions)
+	ApplyPodState(&stackStatus, data.Pods.State())
+
+	infraSet := make(map[string]bool)
+	for _, name := range data.Infra {
+		infraSet[name] = true
+	}
+
+	services := ParseServiceStatuses(stack)
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+	ready, total := CountReadyServices(services)
+
+	sr := StackReport{
+		Name:          stack.Name,
+		Namespace:     stack.Namespace,
+		Title:         types.GetBlueprintTitle(stack),
+		Blueprint:     stack.Spec.BlueprintReference,
+		State:         stackStatus.State,
+		Reason:        stackStatus.Reason,
+		Created:       stack.CreationTimestamp.Time,
+		ReadyServices: ready,
+		TotalServices: total,
+		Services:      make([]ServiceReport, 0, len(services)),
+	}
+
+	for _, svc := range services {
+		svcPods := data.Pods.ForService(svc.Name)
+
+		report := ServiceReport{
+			Name:     svc.Name,
+			Category: CategoryService,
+			State:    svc.State,
+			Image:    svc.Image,
+			Pods:     make([]PodReport, 0, len(svcPods)),
+		}
+
+		if svc.URL != "" {
+			report.URL = "https://" + svc.URL
+		}
+
+		switch {
+		case len(svcPods) > 0 && IsJobPod(&svcPods[0]):
+			report.Category = CategoryJob
+		case infraSet[svc.Name]:
+			report.Category = CategoryInfra
+		}
+
+		for i := range svcPods {
+			ps := k8s.ParsePodStatus(&svcPods[i])
+			report.Pods = append(report.Pods, PodReport{
+				Name:     ps.Name,
+				Phase:    ps.Phase,
+				Ready:    ps.Ready,
+				Restarts: ps.Restarts,
+				Created:  svcPods[i].CreationTimestamp.Time,
+			})
+		}
+
+		if readiness, ok := data.Traffic[svc.Name]; ok {
+			report.Traffic = &Traffic{Ready: readiness.IsReady, Reason: readiness.FailureReason}
+		}
+
+		sr.Services = append(sr.Services, report)
+	}
+
+	return sr
+}
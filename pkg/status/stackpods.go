@@ -0,0 +1,70 @@
+package status
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// StackPods are the pods of one stack, listed once per invocation and shared
+// by every service lookup. A nil *StackPods means pods were not fetched.
+type StackPods struct {
+	// Items are all pods of the stack
+	Items []corev1.Pod
+	// Err is set when the pods could not be listed
+	Err error
+
+	mu        sync.Mutex
+	byService map[string][]corev1.Pod
+}
+
+// NewStackPods wraps the result of listing a stack's pods
+func NewStackPods(pods []corev1.Pod, err error) *StackPods {
+	return &StackPods{Items: pods, Err: err}
+}
+
+// ListStackPods lists the pods of a stack with a single API call
+func ListStackPods(ctx context.Context, k8sClient *k8s.Client, stack *envv1alpha1.Stack) *StackPods {
+	return NewStackPods(k8sClient.ListPods(ctx, stack.Namespace, StackPodLabels(stack.Name)))
+}
+
+// Listed reports whether the pods were listed successfully
+func (p *StackPods) Listed() bool {
+	return p != nil && p.Err == nil
+}
+
+// ForService returns the pods of one service, matching them on first use
+func (p *StackPods) ForService(serviceName string) []corev1.Pod {
+	if !p.Listed() {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if pods, ok := p.byService[serviceName]; ok {
+		return pods
+	}
+	if p.byService == nil {
+		p.byService = make(map[string][]corev1.Pod)
+	}
+	pods := MatchServicePods(p.Items, serviceName)
+	p.byService[serviceName] = pods
+	return pods
+}
+
+// State aggregates the pod state of the stack, StateUnknown if listing failed.
+// Returns "" when pods were not fetched.
+func (p *StackPods) State() string {
+	switch {
+	case p == nil:
+		return ""
+	case p.Err != nil:
+		return StateUnknown
+	default:
+		return PodState(p.Items)
+	}
+}
@@ -0,0 +1,115 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Summary is the health of every env of a report, and of all of them together
+type Summary struct {
+	EnvSummary `yaml:",inline"`
+	Envs       []EnvSummary `json:"envs" yaml:"envs"`
+}
+
+// EnvSummary counts the ready stacks and troubled pods of an env
+type EnvSummary struct {
+	Env           string `json:"env,omitempty" yaml:"env,omitempty"`
+	ReadyStacks   int    `json:"readyStacks" yaml:"readyStacks"`
+	TotalStacks   int    `json:"totalStacks" yaml:"totalStacks"`
+	ReadyServices int    `json:"readyServices" yaml:"readyServices"`
+	TotalServices int    `json:"totalServices" yaml:"totalServices"`
+	ErrorPods     int    `json:"errorPods" yaml:"errorPods"`
+	PendingPods   int    `json:"pendingPods" yaml:"pendingPods"`
+	// Score is the percentage of ready services, 100 when there are none
+	Score int `json:"score" yaml:"score"`
+}
+
+// Summarize computes the health of every env of a report
+func Summarize(report *Report) Summary {
+	var summary Summary
+	for _, env := range report.Envs {
+		es := EnvSummary{Env: env.Name}
+		for _, stack := range env.Stacks {
+			es.TotalStacks++
+			if stack.State == StateReady {
+				es.ReadyStacks++
+			}
+			es.ReadyServices += stack.ReadyServices
+			es.TotalServices += stack.TotalServices
+			for _, svc := range stack.Services {
+				for _, pod := range svc.Pods {
+					switch {
+					case podErroring(pod):
+						es.ErrorPods++
+					case podPending(pod):
+						es.PendingPods++
+					}
+				}
+			}
+		}
+		es.Score = score(es.ReadyServices, es.TotalServices)
+		summary.Envs = append(summary.Envs, es)
+
+		summary.ReadyStacks += es.ReadyStacks
+		summary.TotalStacks += es.TotalStacks
+		summary.ReadyServices += es.ReadyServices
+		summary.TotalServices += es.TotalServices
+		summary.ErrorPods += es.ErrorPods
+		summary.PendingPods += es.PendingPods
+	}
+	summary.Score = score(summary.ReadyServices, summary.TotalServices)
+	return summary
+}
+
+// Healthy reports whether every stack is ready and no pod is erroring
+func (s EnvSummary) Healthy() bool {
+	return s.ReadyStacks == s.TotalStacks && s.ErrorPods == 0
+}
+
+// Symbol returns the symbol matching the summary's health
+func (s EnvSummary) Symbol() string {
+	switch {
+	case s.ErrorPods > 0:
+		return SymbolFailed
+	case !s.Healthy():
+		return SymbolDeploying
+	}
+	return SymbolReady
+}
+
+// String renders the summary on one line, e.g. "3/4 stacks ready, 1 pod
+// erroring, 2 pending (health 85%)"
+func (s EnvSummary) String() string {
+	parts := []string{fmt.Sprintf("%d/%d stacks ready", s.ReadyStacks, s.TotalStacks)}
+	if s.ErrorPods > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s erroring", s.ErrorPods, plural(s.ErrorPods, "pod", "pods")))
+	}
+	if s.PendingPods > 0 {
+		parts = append(parts, fmt.Sprintf("%d pending", s.PendingPods))
+	}
+	return fmt.Sprintf("%s (health %d%%)", strings.Join(parts, ", "), s.Score)
+}
+
+// podErroring reports whether a pod failed or can't start its containers
+func podErroring(pod PodReport) bool {
+	return pod.Phase == "Failed" || errorWaitingReasons[pod.Phase]
+}
+
+// podPending reports whether a pod is still starting
+func podPending(pod PodReport) bool {
+	return pod.Phase == "Pending" || (pod.Phase == "Running" && !pod.Ready)
+}
+
+func score(ready, total int) int {
+	if total == 0 {
+		return 100
+	}
+	return ready * 100 / total
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
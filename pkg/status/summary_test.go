@@ -0,0 +1,66 @@
+package status_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+var _ = Describe("Summarize", func() {
+	stack := func(state string, ready, total int, pods ...status.PodReport) status.StackReport {
+		return status.StackReport{
+			State:         state,
+			ReadyServices: ready,
+			TotalServices: total,
+			Services:      []status.ServiceReport{{Name: "api", Pods: pods}},
+		}
+	}
+	running := status.PodReport{Phase: "Running", Ready: true}
+
+	It("should count ready stacks and troubled pods per env", func() {
+		summary := status.Summarize(&status.Report{Envs: []status.EnvReport{
+			{Name: "dev", Stacks: []status.StackReport{
+				stack(status.StateReady, 2, 2, running, running),
+				stack(status.PodStateError, 1, 2, running, status.PodReport{Phase: "CrashLoopBackOff"}),
+				stack(status.StateDeploying, 0, 1, status.PodReport{Phase: "Pending"}, status.PodReport{Phase: "Running"}),
+			}},
+			{Name: "prod", Stacks: []status.StackReport{stack(status.StateReady, 3, 3, running)}},
+		}})
+
+		dev := summary.Envs[0]
+		Expect(dev.Env).To(Equal("dev"))
+		Expect(dev.ReadyStacks).To(Equal(1))
+		Expect(dev.TotalStacks).To(Equal(3))
+		Expect(dev.ErrorPods).To(Equal(1))
+		Expect(dev.PendingPods).To(Equal(2))
+		Expect(dev.Score).To(Equal(60))
+		Expect(dev.Healthy()).To(BeFalse())
+		Expect(dev.Symbol()).To(Equal(status.SymbolFailed))
+		Expect(dev.String()).To(Equal("1/3 stacks ready, 1 pod erroring, 2 pending (health 60%)"))
+
+		prod := summary.Envs[1]
+		Expect(prod.Healthy()).To(BeTrue())
+		Expect(prod.String()).To(Equal("1/1 stacks ready (health 100%)"))
+
+		Expect(summary.ReadyStacks).To(Equal(2))
+		Expect(summary.TotalStacks).To(Equal(4))
+		Expect(summary.Score).To(Equal(75))
+		Expect(summary.Healthy()).To(BeFalse())
+	})
+
+	It("should count completed job pods as fine", func() {
+		summary := status.Summarize(&status.Report{Envs: []status.EnvReport{
+			{Name: "dev", Stacks: []status.StackReport{stack(status.StateReady, 1, 1, status.PodReport{Phase: "Succeeded"})}},
+		}})
+		Expect(summary.ErrorPods + summary.PendingPods).To(BeZero())
+		Expect(summary.Healthy()).To(BeTrue())
+		Expect(summary.Symbol()).To(Equal(status.SymbolReady))
+	})
+
+	It("should score an empty report as healthy", func() {
+		summary := status.Summarize(&status.Report{})
+		Expect(summary.Score).To(Equal(100))
+		Expect(summary.Healthy()).To(BeTrue())
+	})
+})
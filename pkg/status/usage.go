@@ -0,0 +1,146 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Usage sort keys
+const (
+	SortByCPU    = "cpu"
+	SortByMemory = "memory"
+)
+
+// Usage is a CPU and memory usage sample
+type Usage struct {
+	CPUMillis   int64 `json:"cpuMillis" yaml:"cpuMillis"`
+	MemoryBytes int64 `json:"memoryBytes" yaml:"memoryBytes"`
+}
+
+// StackUsage is the resource usage of a stack, per service and per pod
+type StackUsage struct {
+	Name      string `json:"name" yaml:"name"`
+	Env       string `json:"env" yaml:"env"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Usage     `yaml:",inline"`
+	Services  []ServiceUsage `json:"services" yaml:"services"`
+	Pods      []PodUsage     `json:"pods" yaml:"pods"`
+}
+
+// ServiceUsage is the summed usage of a service's pods
+type ServiceUsage struct {
+	Name  string `json:"name" yaml:"name"`
+	Pods  int    `json:"pods" yaml:"pods"`
+	Usage `yaml:",inline"`
+}
+
+// PodUsage is the usage of one pod
+type PodUsage struct {
+	Name    string `json:"name" yaml:"name"`
+	Service string `json:"service" yaml:"service"`
+	Usage   `yaml:",inline"`
+}
+
+// BuildStackUsage aggregates pod metrics per service and for the whole
+// stack. Pods without metrics (e.g. just started) are left out.
+func BuildStackUsage(stack *envv1alpha1.Stack, pods []corev1.Pod, metrics []k8s.PodMetrics) StackUsage {
+	usage := StackUsage{
+		Name:      stack.Name,
+		Env:       stack.Spec.Env,
+		Namespace: stack.Namespace,
+		Services:  []ServiceUsage{},
+		Pods:      []PodUsage{},
+	}
+
+	services := make([]string, 0, len(stack.Spec.Images))
+	for name := range stack.Spec.Images {
+		services = append(services, name)
+	}
+	podServices := make(map[string]string, len(pods))
+	for i := range pods {
+		podServices[pods[i].Name] = PodServiceName(&pods[i], services)
+	}
+
+	byService := make(map[string]*ServiceUsage)
+	for _, m := range metrics {
+		service, ok := podServices[m.Name]
+		if !ok {
+			continue
+		}
+
+		var pod Usage
+		for _, c := range m.Containers {
+			pod.CPUMillis += c.CPU.MilliValue()
+			pod.MemoryBytes += c.Memory.Value()
+		}
+		usage.Pods = append(usage.Pods, PodUsage{Name: m.Name, Service: service, Usage: pod})
+		usage.add(pod)
+
+		svc := byService[service]
+		if svc == nil {
+			svc = &ServiceUsage{Name: service}
+			byService[service] = svc
+		}
+		svc.Pods++
+		svc.add(pod)
+	}
+
+	for _, svc := range byService {
+		usage.Services = append(usage.Services, *svc)
+	}
+	usage.Sort(SortByCPU)
+	return usage
+}
+
+// Sort orders services and pods by descending CPU or memory usage
+func (s *StackUsage) Sort(by string) {
+	sort.Slice(s.Services, func(i, j int) bool {
+		return s.Services[i].Usage.less(s.Services[j].Usage, by, s.Services[i].Name, s.Services[j].Name)
+	})
+	sort.Slice(s.Pods, func(i, j int) bool {
+		return s.Pods[i].Usage.less(s.Pods[j].Usage, by, s.Pods[i].Name, s.Pods[j].Name)
+	})
+}
+
+// less orders by descending usage, then by name
+func (u Usage) less(other Usage, by, name, otherName string) bool {
+	a, b := u.CPUMillis, other.CPUMillis
+	if by == SortByMemory {
+		a, b = u.MemoryBytes, other.MemoryBytes
+	}
+	if a != b {
+		return a > b
+	}
+	return name < otherName
+}
+
+func (u *Usage) add(other Usage) {
+	u.CPUMillis += other.CPUMillis
+	u.MemoryBytes += other.MemoryBytes
+}
+
+// CPU formats CPU usage in millicores, as kubectl top does
+func (u Usage) CPU() string {
+	return fmt.Sprintf("%dm", u.CPUMillis)
+}
+
+// Memory formats memory usage in binary units
+func (u Usage) Memory() string {
+	const (
+		ki = 1 << 10
+		mi = 1 << 20
+		gi = 1 << 30
+	)
+	switch {
+	case u.MemoryBytes >= 10*gi:
+		return fmt.Sprintf("%dGi", u.MemoryBytes/gi)
+	case u.MemoryBytes >= mi:
+		return fmt.Sprintf("%dMi", u.MemoryBytes/mi)
+	default:
+		return fmt.Sprintf("%dKi", u.MemoryBytes/ki)
+	}
+}
@@ -0,0 +1,72 @@
+package status_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+	"github.com/lissto-dev/cli/pkg/status"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("BuildStackUsage", func() {
+	stack := &envv1alpha1.Stack{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "dev-ns"},
+		Spec: envv1alpha1.StackSpec{
+			Env:    "dev",
+			Images: map[string]envv1alpha1.ImageInfo{"api": {}, "web": {}},
+		},
+	}
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "api-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}},
+	}
+	podMetrics := func(name, cpu, memory string) k8s.PodMetrics {
+		return k8s.PodMetrics{Name: name, Containers: []k8s.ContainerMetrics{
+			{Name: "main", CPU: resource.MustParse(cpu), Memory: resource.MustParse(memory)},
+		}}
+	}
+	metrics := []k8s.PodMetrics{
+		podMetrics("api-1", "100m", "100Mi"),
+		podMetrics("api-2", "50m", "100Mi"),
+		podMetrics("web-1", "200m", "50Mi"),
+		podMetrics("other-stack-pod", "1", "1Gi"),
+	}
+
+	It("should sum usage per service and stack, ignoring other pods", func() {
+		usage := status.BuildStackUsage(stack, pods, metrics)
+
+		Expect(usage.Env).To(Equal("dev"))
+		Expect(usage.CPU()).To(Equal("350m"))
+		Expect(usage.Memory()).To(Equal("250Mi"))
+		Expect(usage.Pods).To(HaveLen(3))
+		Expect(usage.Services).To(Equal([]status.ServiceUsage{
+			{Name: "web", Pods: 1, Usage: status.Usage{CPUMillis: 200, MemoryBytes: 50 << 20}},
+			{Name: "api", Pods: 2, Usage: status.Usage{CPUMillis: 150, MemoryBytes: 200 << 20}},
+		}))
+	})
+
+	It("should sort by memory", func() {
+		usage := status.BuildStackUsage(stack, pods, metrics)
+		usage.Sort(status.SortByMemory)
+
+		Expect(usage.Services[0].Name).To(Equal("api"))
+		Expect(usage.Pods[0].Name).To(Equal("api-1"))
+		Expect(usage.Pods[2].Name).To(Equal("web-1"))
+	})
+})
+
+var _ = Describe("Usage", func() {
+	DescribeTable("Memory",
+		func(bytes int64, expected string) {
+			Expect(status.Usage{MemoryBytes: bytes}.Memory()).To(Equal(expected))
+		},
+		Entry("kibibytes", int64(512<<10), "512Ki"),
+		Entry("mebibytes", int64(1536<<20), "1536Mi"),
+		Entry("gibibytes", int64(12<<30), "12Gi"),
+	)
+})
@@ -0,0 +1,156 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lissto-dev/cli/pkg/k8s"
+	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
+)
+
+// ErrWaitTimeout is returned when a stack doesn't become ready in time
+var ErrWaitTimeout = errors.New("timed out waiting for stack to become ready")
+
+// DefaultWaitInterval is the delay between readiness polls
+const DefaultWaitInterval = 5 * time.Second
+
+// StackFetcher returns the current state of the stack being waited on
+type StackFetcher func(ctx context.Context) (*envv1alpha1.Stack, error)
+
+// ServiceReady reports whether a service is fully up: deployed, all pods
+// ready (completed job pods count as ready) and, if exposed, routable
+func ServiceReady(svc ServiceReport) bool {
+	if svc.State != StateReady || len(svc.Pods) == 0 {
+		return false
+	}
+	for _, pod := range svc.Pods {
+		if pod.Ready {
+			continue
+		}
+		if svc.Category == CategoryJob && pod.Phase == "Succeeded" {
+			continue
+		}
+		return false
+	}
+	if svc.Traffic != nil && !svc.Traffic.Ready {
+		return false
+	}
+	return true
+}
+
+// StackReady reports whether a stack and all its services are ready
+func StackReady(report StackReport) bool {
+	if report.State != StateReady {
+		return false
+	}
+	for _, svc := range report.Services {
+		if !ServiceReady(svc) {
+			return false
+		}
+	}
+	return true
+}
+
+// StackFailed reports whether a stack or one of its services failed
+func StackFailed(report StackReport) bool {
+	if report.State == StateFailed {
+		return true
+	}
+	for _, svc := range report.Services {
+		if svc.State == StateFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitForStack polls a stack with the same readiness logic as the status view
+// until it is ready, the timeout expires or ctx is cancelled. Besides polling
+// every interval, it checks again as soon as one of the stack's pods,
+// deployments or ingresses changes. onProgress, if set, is called with the
+// report of every check.
+func WaitForStack(ctx context.Context, k8sClient *k8s.Client, fetch StackFetcher, interval, timeout time.Duration, onProgress func(StackReport)) (StackReport, error) {
+	return WaitForStackUntil(ctx, k8sClient, fetch, interval, timeout, onProgress, StackReady)
+}
+
+// WaitForStackUntil is WaitForStack with a custom condition, e.g. to also
+// stop when the stack fails
+func WaitForStackUntil(ctx context.Context, k8sClient *k8s.Client, fetch StackFetcher, interval, timeout time.Duration, onProgress func(StackReport), until func(StackReport) bool) (StackReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Changes of the stack's objects wake the loop before the next tick
+	var changes <-chan k8s.WatchEvent
+	watched := k8sClient == nil
+	var last StackReport
+	for {
+		stack, err := fetch(ctx)
+		if err == nil && !watched {
+			watched = true
+			changes = watchStack(ctx, k8sClient, stack, interval)
+		}
+		if err == nil {
+			last = BuildStackReport(ctx, k8sClient, stack, nil)
+			// A spec change the controller hasn't reconciled yet would
+			// otherwise report the previous rollout as ready
+			if stack.Status.ObservedGeneration < stack.Generation {
+				last.State = StateDeploying
+				last.Reason = "Waiting for controller to reconcile"
+			}
+			if onProgress != nil {
+				onProgress(last)
+			}
+			if until(last) {
+				return last, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return last, fmt.Errorf("%w after %s", ErrWaitTimeout, timeout)
+			}
+			return last, ctx.Err()
+		case <-ticker.C:
+		case _, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			drain(changes)
+		}
+	}
+}
+
+// watchStack returns the changes of a stack's objects, or nil if they can't
+// be watched within syncTimeout and waiting has to rely on polling alone
+func watchStack(ctx context.Context, k8sClient *k8s.Client, stack *envv1alpha1.Stack, syncTimeout time.Duration) <-chan k8s.WatchEvent {
+	watcher, err := k8sClient.Watch(ctx, k8s.WatchOptions{
+		Namespaces:  []string{stack.Namespace},
+		Labels:      map[string]string{"lissto.dev/stack": stack.Name},
+		SyncTimeout: syncTimeout,
+	})
+	if err != nil {
+		return nil
+	}
+	return watcher.Events()
+}
+
+// drain discards pending events, so a burst of changes is checked once
+func drain(events <-chan k8s.WatchEvent) {
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
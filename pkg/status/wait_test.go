@@ -0,0 +1,54 @@
+package status_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/lissto-dev/cli/pkg/status"
+)
+
+var _ = Describe("Readiness", func() {
+	readyPod := status.PodReport{Name: "api-1", Phase: "Running", Ready: true}
+
+	It("should require deployed services with ready pods", func() {
+		Expect(status.ServiceReady(status.ServiceReport{State: status.StateReady, Pods: []status.PodReport{readyPod}})).To(BeTrue())
+		Expect(status.ServiceReady(status.ServiceReport{State: status.StateDeploying, Pods: []status.PodReport{readyPod}})).To(BeFalse())
+		Expect(status.ServiceReady(status.ServiceReport{State: status.StateReady})).To(BeFalse())
+	})
+
+	It("should count completed job pods as ready", func() {
+		svc := status.ServiceReport{
+			State:    status.StateReady,
+			Category: status.CategoryJob,
+			Pods:     []status.PodReport{{Name: "migrate-1", Phase: "Succeeded"}},
+		}
+		Expect(status.ServiceReady(svc)).To(BeTrue())
+	})
+
+	It("should require exposed services to accept traffic", func() {
+		svc := status.ServiceReport{
+			State:   status.StateReady,
+			Pods:    []status.PodReport{readyPod},
+			Traffic: &status.Traffic{Ready: false, Reason: "no endpoints"},
+		}
+		Expect(status.ServiceReady(svc)).To(BeFalse())
+	})
+
+	It("should require the stack and all services to be ready", func() {
+		ready := status.ServiceReport{State: status.StateReady, Pods: []status.PodReport{readyPod}}
+		pending := status.ServiceReport{State: status.StateDeploying}
+
+		Expect(status.StackReady(status.StackReport{State: status.StateReady, Services: []status.ServiceReport{ready}})).To(BeTrue())
+		Expect(status.StackReady(status.StackReport{State: status.StateReady, Services: []status.ServiceReport{ready, pending}})).To(BeFalse())
+		Expect(status.StackReady(status.StackReport{State: status.StateDeploying, Services: []status.ServiceReport{ready}})).To(BeFalse())
+	})
+
+	It("should detect failed stacks and services", func() {
+		failed := status.ServiceReport{State: status.StateFailed}
+		ready := status.ServiceReport{State: status.StateReady, Pods: []status.PodReport{readyPod}}
+
+		Expect(status.StackFailed(status.StackReport{State: status.StateFailed})).To(BeTrue())
+		Expect(status.StackFailed(status.StackReport{State: status.StateDeploying, Services: []status.ServiceReport{ready, failed}})).To(BeTrue())
+		Expect(status.StackFailed(status.StackReport{State: status.StateReady, Services: []status.ServiceReport{ready}})).To(BeFalse())
+	})
+})
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	envv1alpha1 "github.com/lissto-dev/controller/api/v1alpha1"
 )
@@ -21,6 +22,10 @@ type (
 	EnvSpec = envv1alpha1.EnvSpec
 )
 
+// AnnotationExpiresAt holds the RFC 3339 time after which a stack may be
+// garbage collected
+const AnnotationExpiresAt = "lissto.dev/expires-at"
+
 // GetBlueprintTitle extracts the blueprint title from stack annotations
 func GetBlueprintTitle(stack *Stack) string {
 	if stack.Annotations != nil {
@@ -40,3 +45,23 @@ func GetStackDisplayName(stack *Stack) string {
 	}
 	return stack.Name
 }
+
+// GetStackExpiry returns when a stack expires; ok is false for stacks without
+// a (valid) TTL
+func GetStackExpiry(stack *Stack) (expiresAt time.Time, ok bool) {
+	value := stack.Annotations[AnnotationExpiresAt]
+	if value == "" {
+		return time.Time{}, false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiresAt, true
+}
+
+// IsStackExpired reports whether a stack's TTL has passed at now
+func IsStackExpired(stack *Stack, now time.Time) bool {
+	expiresAt, ok := GetStackExpiry(stack)
+	return ok && !now.Before(expiresAt)
+}